@@ -0,0 +1,164 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package bbs implements the hash_to_scalar and create_generators procedures of the BBS signature scheme
+// (https://datatracker.ietf.org/doc/draft-irtf-cfrg-bbs-signatures) for the BLS12-381 ciphersuites, built on the
+// expand_message functions of this module.
+package bbs
+
+import (
+	"crypto"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+
+	"github.com/bytemare/hash"
+
+	"github.com/bytemare/hash2curve"
+)
+
+// Ciphersuite identifies a BBS ciphersuite over BLS12-381.
+type Ciphersuite byte
+
+const (
+	// BLS12381SHAKE256 identifies the BBS_BLS12381G1_XOF:SHAKE-256_SSWU_RO_ ciphersuite.
+	BLS12381SHAKE256 Ciphersuite = 1 + iota
+
+	// BLS12381SHA256 identifies the BBS_BLS12381G1_XMD:SHA-256_SSWU_RO_ ciphersuite.
+	BLS12381SHA256
+)
+
+const (
+	// IDSHAKE256 is the ciphersuite identifier of BLS12381SHAKE256.
+	IDSHAKE256 = "BBS_BLS12381G1_XOF:SHAKE-256_SSWU_RO_"
+
+	// IDSHA256 is the ciphersuite identifier of BLS12381SHA256.
+	IDSHA256 = "BBS_BLS12381G1_XMD:SHA-256_SSWU_RO_"
+
+	// ExpandLength is the expand_len parameter, ceil((ceil(log2(r)) + k) / 8) for BLS12-381 with k = 128.
+	ExpandLength = 48
+
+	apiIDSuffix         = "H2G_HM2S_"
+	hashToScalarSuffix  = "H2S_"
+	generatorSeedSuffix = "MESSAGE_GENERATOR_SEED"
+	seedDSTSuffix       = "SIG_GENERATOR_SEED_"
+	generatorDSTSuffix  = "SIG_GENERATOR_DST_"
+)
+
+var (
+	errInvalidCiphersuite = fmt.Errorf("%w: unknown BBS ciphersuite", hash2curve.ErrInvalidSuite)
+
+	// order is the order r of the BLS12-381 prime-order subgroups.
+	// = 0x73eda753299d7d483339d80809a1d80553bda402fffe5bfeffffffff00000001.
+	order = new(big.Int).SetBytes([]byte{
+		115, 237, 167, 83, 41, 157, 125, 72, 51, 57, 216, 8, 9, 161, 216, 5,
+		83, 189, 164, 2, 255, 254, 91, 254, 255, 255, 255, 255, 0, 0, 0, 1,
+	})
+)
+
+// Available returns whether the ciphersuite is supported.
+func (c Ciphersuite) Available() bool {
+	return c == BLS12381SHAKE256 || c == BLS12381SHA256
+}
+
+// String returns the ciphersuite identifier.
+func (c Ciphersuite) String() string {
+	switch c {
+	case BLS12381SHAKE256:
+		return IDSHAKE256
+	case BLS12381SHA256:
+		return IDSHA256
+	default:
+		panic(errInvalidCiphersuite)
+	}
+}
+
+// APIID returns the api_id of the ciphersuite for the hash-to-generators message mapping, i.e.
+// ciphersuite_id || "H2G_HM2S_".
+func (c Ciphersuite) APIID() []byte {
+	return []byte(c.String() + apiIDSuffix)
+}
+
+// ExpandMessage applies the expand_message function of the ciphersuite.
+func (c Ciphersuite) ExpandMessage(input, dst []byte, length uint) []byte {
+	switch c {
+	case BLS12381SHAKE256:
+		return hash2curve.ExpandXOF(hash2curve.ExtendableXOF(hash.SHAKE256.GetXOF()), input, dst, length)
+	case BLS12381SHA256:
+		return hash2curve.ExpandXMD(crypto.SHA256, input, dst, length)
+	default:
+		panic(errInvalidCiphersuite)
+	}
+}
+
+// HashToScalarDST returns the default dst for HashToScalar for the given api_id, i.e. api_id || "H2S_".
+func HashToScalarDST(apiID []byte) []byte {
+	return concat(apiID, []byte(hashToScalarSuffix))
+}
+
+// HashToScalar implements the BBS hash_to_scalar procedure, returning OS2IP(expand_message(input, dst, 48)) mod r.
+// The DST must not be empty or nil, and HashToScalarDST gives the one to use by default.
+func (c Ciphersuite) HashToScalar(input, dst []byte) *big.Int {
+	uniform := c.ExpandMessage(input, dst, ExpandLength)
+	s := new(big.Int).SetBytes(uniform)
+
+	return s.Mod(s, order)
+}
+
+// GeneratorDST returns the dst to use with hash_to_curve_g1 when creating generators for the given api_id.
+func GeneratorDST(apiID []byte) []byte {
+	return concat(apiID, []byte(generatorDSTSuffix))
+}
+
+// GeneratorSeeds returns the count successive values v of the create_generators procedure, i.e. the inputs to
+// hash_to_curve_g1. If generatorSeed is nil, the default api_id || "MESSAGE_GENERATOR_SEED" is used.
+func (c Ciphersuite) GeneratorSeeds(count uint, generatorSeed, apiID []byte) [][]byte {
+	if generatorSeed == nil {
+		generatorSeed = concat(apiID, []byte(generatorSeedSuffix))
+	}
+
+	seedDST := concat(apiID, []byte(seedDSTSuffix))
+	seeds := make([][]byte, count)
+	v := c.ExpandMessage(generatorSeed, seedDST, ExpandLength)
+
+	var counter [8]byte
+
+	for i := range count {
+		binary.BigEndian.PutUint64(counter[:], uint64(i+1))
+		v = c.ExpandMessage(concat(v, counter[:]), seedDST, ExpandLength)
+		seeds[i] = v
+	}
+
+	return seeds
+}
+
+// CreateGenerators implements the BBS create_generators procedure, using hashToCurveG1 as hash_to_curve_g1 for the
+// ciphersuite. If generatorSeed is nil, the default api_id || "MESSAGE_GENERATOR_SEED" is used.
+func CreateGenerators[Point any](
+	c Ciphersuite,
+	count uint,
+	generatorSeed, apiID []byte,
+	hashToCurveG1 func(input, dst []byte) Point,
+) []Point {
+	dst := GeneratorDST(apiID)
+	seeds := c.GeneratorSeeds(count, generatorSeed, apiID)
+	generators := make([]Point, count)
+
+	for i, v := range seeds {
+		generators[i] = hashToCurveG1(v, dst)
+	}
+
+	return generators
+}
+
+func concat(a, b []byte) []byte {
+	out := make([]byte, 0, len(a)+len(b))
+	out = append(out, a...)
+
+	return append(out, b...)
+}
@@ -0,0 +1,122 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+// Package benchmarks lists the expanders, for each hash function, and the suites of this module as cases measured with
+// the same inputs, to compare them when choosing a suite and to track performance regressions. Run them with
+//
+//	go test -run '^$' -bench . ./benchmarks
+//
+// which reports ns/op and allocs/op for each case, e.g. to compare with benchstat before and after a change.
+package benchmarks
+
+import (
+	"crypto"
+	"crypto/elliptic"
+
+	"github.com/bytemare/hash"
+
+	"github.com/bytemare/hash2curve"
+	"github.com/bytemare/hash2curve/suite"
+)
+
+var (
+	// Input is the message of all cases, of 64 bytes, e.g. a transcript hash or a public key.
+	Input = []byte("benchmark input of 64 bytes, e.g. a transcript hash or a key....")
+
+	// DST is the domain separation tag of all cases.
+	DST = []byte("QUUX-V01-CS02-with-benchmarks")
+
+	// Lengths are the output lengths of the expander cases: a scalar or a field element, two P-384 elements, and the
+	// two G2 elements of BLS24-315.
+	Lengths = []uint{32, 144, 448}
+)
+
+// Case is an operation to measure.
+type Case struct {
+	// Run performs the operation on input with dst.
+	Run func(input, dst []byte)
+
+	// Name identifies the case, e.g. "XMD:SHA-256" or "P256_XMD:SHA-256_SSWU_RO_".
+	Name string
+}
+
+// expanderHashes are the hash functions of the expander cases, expand_message_xmd for the fixed-length ones and
+// expand_message_xof for the others.
+var expanderHashes = []hash.Hash{
+	hash.SHA256, hash.SHA384, hash.SHA512, hash.SHA3_256, hash.SHA3_512,
+	hash.SHAKE128, hash.SHAKE256,
+}
+
+// Expanders returns the cases expanding to length bytes, with expand_message for each hash function, as well as the
+// Keccak-256 and HKDF expanders.
+func Expanders(length uint) []Case {
+	cases := make([]Case, 0, len(expanderHashes)+2)
+
+	for _, h := range expanderHashes {
+		name := "XMD:" + h.String()
+		if h.Type() == hash.ExtendableOutputFunction {
+			name = "XOF:" + h.String()
+		}
+
+		cases = append(cases, Case{
+			Name: name,
+			Run:  func(input, dst []byte) { _ = hash2curve.ExpandMessage(h, input, dst, length) },
+		})
+	}
+
+	return append(cases,
+		Case{
+			Name: "XMD:KECCAK-256",
+			Run:  func(input, dst []byte) { _ = hash2curve.ExpandXMDKeccak256(input, dst, length) },
+		},
+		Case{
+			Name: "HKDF:SHA-256",
+			Run:  func(input, dst []byte) { _ = hash2curve.ExpandHKDF(crypto.SHA256, input, dst, nil, length) },
+		},
+	)
+}
+
+// HashToField returns the cases hashing to the two elements of the P-256 field of hash_to_curve, with HashToFieldXMD
+// and with a FieldHasher, which reuses its storage across calls and doesn't allocate.
+func HashToField() []Case {
+	p256 := elliptic.P256().Params().P
+	f := hash2curve.NewFieldHasher(crypto.SHA256, 2, 1, 48, p256)
+
+	return []Case{
+		{
+			Name: "HashToFieldXMD",
+			Run: func(input, dst []byte) {
+				_ = hash2curve.HashToFieldXMD(crypto.SHA256, input, dst, 2, 1, 48, p256)
+			},
+		},
+		{
+			Name: "FieldHasher",
+			Run:  func(input, dst []byte) { _ = f.HashToField(input, dst) },
+		},
+	}
+}
+
+// Suites returns the cases hashing to the curves end-to-end, i.e. from the input to the encoded point, for each suite
+// of the registry with the suite package.
+func Suites() []Case {
+	descriptors := hash2curve.Suites()
+	cases := make([]Case, 0, len(descriptors))
+
+	for _, d := range descriptors {
+		s, err := suite.New(d.ID)
+		if err != nil {
+			panic(err)
+		}
+
+		cases = append(cases, Case{Name: d.ID, Run: func(input, dst []byte) { _ = s.Hash(input, dst) }})
+	}
+
+	return cases
+}
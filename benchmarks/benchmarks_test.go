@@ -0,0 +1,46 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+package benchmarks_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/bytemare/hash2curve/benchmarks"
+)
+
+func run(b *testing.B, cases []benchmarks.Case) {
+	for _, c := range cases {
+		b.Run(c.Name, func(b *testing.B) {
+			b.ReportAllocs()
+
+			for range b.N {
+				c.Run(benchmarks.Input, benchmarks.DST)
+			}
+		})
+	}
+}
+
+func BenchmarkExpand(b *testing.B) {
+	for _, length := range benchmarks.Lengths {
+		b.Run(fmt.Sprintf("%d", length), func(b *testing.B) {
+			run(b, benchmarks.Expanders(length))
+		})
+	}
+}
+
+func BenchmarkHashToField(b *testing.B) {
+	run(b, benchmarks.HashToField())
+}
+
+func BenchmarkSuite(b *testing.B) {
+	run(b, benchmarks.Suites())
+}
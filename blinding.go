@@ -0,0 +1,30 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package hash2curve
+
+import "github.com/bytemare/hash2curve/internal"
+
+// SetBlinding enables or disables the module-wide randomized blinding of the mappings to the curve, which is disabled
+// by default. When enabled, the field inversions and square roots of map_to_curve operate on values multiplied by a
+// fresh random non-zero mask, and the projective coordinates of the mapped points are randomized, so that their
+// intermediate values are decorrelated from the input. This hardens the hashing of secret inputs, e.g. passwords in
+// PAKEs and OPRFs, against side-channel analysis, at the cost of a few field multiplications and a draw from
+// crypto/rand per mapping. The outputs are unchanged.
+//
+// It applies to the NIST curves, secp256k1, edwards25519, and curve25519, but not to ristretto255, and not to the
+// other curves. The mappings panic with an error wrapping ErrRandomness if crypto/rand fails. It is safe for
+// concurrent use, but is meant to be set once at program initialization.
+func SetBlinding(enabled bool) {
+	internal.SetBlinding(enabled)
+}
+
+// Blinding reports whether the module-wide randomized blinding of the mappings is enabled.
+func Blinding() bool {
+	return internal.Blinding()
+}
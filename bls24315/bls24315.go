@@ -0,0 +1,305 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+// Package bls24315 implements hashing to the groups G1 and G2 of BLS24-315, a pairing-friendly curve of embedding
+// degree 24 used with recursive SNARKs, with the Shallue-van de Woestijne map and expand_message_xmd with SHA-256, for
+// a security level of 128 bits. G1 is the subgroup of order r of the curve y^2 = x^3 + 1 over GF(p), and G2 that of its
+// sextic twist y^2 = x^3 + 1 / v over GF(p^4) = GF(p)[v] / (v^4 - 13), i.e. the tower GF(p^2) = GF(p)[u] / (u^2 - 13)
+// and GF(p^4) = GF(p^2)[v] / (v^2 - u), with u = v^2.
+//
+// As A = 0, the curves need an isogeny for the simplified SWU map, which RFC 9380 does not specify for BLS24-315: the
+// suites use the Shallue-van de Woestijne map instead, which applies to any curve, and the outputs differ from those
+// of implementations hashing through an isogeny. They follow the construction of the RFC 9380 BLS12-381 suites, but
+// are not specified by RFC 9380.
+package bls24315
+
+import (
+	"crypto"
+	"math/big"
+
+	"github.com/bytemare/hash2curve"
+	"github.com/bytemare/hash2curve/internal/extension"
+	"github.com/bytemare/hash2curve/internal/weierstrass"
+)
+
+const (
+	// G1H2C represents the hash-to-curve string identifier for G1.
+	G1H2C = "BLS24315G1_XMD:SHA-256_SVDW_RO_"
+
+	// G1E2C represents the encode-to-curve string identifier for G1.
+	G1E2C = "BLS24315G1_XMD:SHA-256_SVDW_NU_"
+
+	// G2H2C represents the hash-to-curve string identifier for G2.
+	G2H2C = "BLS24315G2_XMD:SHA-256_SVDW_RO_"
+
+	// G2E2C represents the encode-to-curve string identifier for G2.
+	G2E2C = "BLS24315G2_XMD:SHA-256_SVDW_NU_"
+
+	// securityLevel is the security level k, in bits.
+	securityLevel = 128
+
+	// seed is the BLS parameter x, with p = (x - 1)^2 * (x^8 - x^4 + 1) / 3 + x and r = x^8 - x^4 + 1.
+	seed = -3218079743
+
+	// g2Cofactor is the cofactor of G2, by which the mapped points are multiplied.
+	g2Cofactor = "216079035500590602943546242140422432107555648541092228905249925297233022840522997069049628086159" +
+		"486821981928133195442045258836056038368698198752015929588430502672406127261882483243231901352617" +
+		"383373863699144968206692699635819037532045432968648848220192219321417343498967027189130043882684" +
+		"380082463571969"
+
+	// nonResidue is β in GF(p^4) = GF(p)[v] / (v^4 - β).
+	nonResidue = 13
+)
+
+var g1, g2 = newSuites()
+
+func newSuites() (g1, g2 *weierstrass.Suite) {
+	x := big.NewInt(seed)
+	one := big.NewInt(1)
+
+	// r = x^8 - x^4 + 1
+	x4 := new(big.Int).Exp(x, big.NewInt(4), nil)
+	r := new(big.Int).Mul(x4, x4)
+	r.Sub(r, x4).Add(r, one)
+
+	// h1 = (x - 1)^2 / 3, and p = h1 * r + x
+	h1 := new(big.Int).Sub(x, one)
+	h1.Mul(h1, h1).Quo(h1, big.NewInt(3))
+	p := new(big.Int).Mul(h1, r)
+	p.Add(p, x)
+
+	h2, _ := new(big.Int).SetString(g2Cofactor, 10)
+
+	// The Z are those of RFC 9380's find_z_svdw, which tries 1, -1, 2, -2, ...: 1 for G1, and 5 for G2.
+	fp := extension.NewField(p, 1, big.NewInt(nonResidue))
+	c1 := weierstrass.NewCurveSVDW(fp, fp.New(), fp.Element(one), fp.Element(one), h1)
+
+	fp4 := extension.NewField(p, 4, big.NewInt(nonResidue))
+	b2 := fp4.Inv(fp4.New(), fp4.Element(big.NewInt(0), one))
+	c2 := weierstrass.NewCurveSVDW(fp4, fp4.New(), b2, fp4.Element(big.NewInt(5)), h2)
+
+	return weierstrass.NewSuite(c1, r, crypto.SHA256, securityLevel),
+		weierstrass.NewSuite(c2, r, crypto.SHA256, securityLevel)
+}
+
+type disallowEqual [0]func()
+
+// G1 is a point of G1, in affine coordinates, or the point at infinity.
+type G1 struct {
+	_        disallowEqual
+	X, Y     big.Int
+	infinity bool
+}
+
+func newG1(p *weierstrass.Point) *G1 {
+	q := &G1{infinity: p.Infinity}
+	q.X.Set(p.X[0])
+	q.Y.Set(p.Y[0])
+
+	return q
+}
+
+func (p *G1) point() *weierstrass.Point {
+	return &weierstrass.Point{
+		X:        extension.Element{new(big.Int).Set(&p.X)},
+		Y:        extension.Element{new(big.Int).Set(&p.Y)},
+		Infinity: p.infinity,
+	}
+}
+
+// IsInfinity returns whether p is the point at infinity, i.e. the identity.
+func (p *G1) IsInfinity() bool {
+	return p.infinity
+}
+
+// IsInSubgroup returns whether p is on the curve and in the subgroup of order r.
+func (p *G1) IsInSubgroup() bool {
+	return g1.IsInSubgroup(p.point())
+}
+
+// Add sets p to p1 + p2, and returns p.
+func (p *G1) Add(p1, p2 *G1) *G1 {
+	*p = *newG1(g1.Add(p1.point(), p2.point()))
+	return p
+}
+
+// Equal returns whether p and q are the same point.
+func (p *G1) Equal(q *G1) bool {
+	if p.infinity || q.infinity {
+		return p.infinity == q.infinity
+	}
+
+	return p.X.Cmp(&q.X) == 0 && p.Y.Cmp(&q.Y) == 0
+}
+
+// Bytes returns the SEC 1 compressed encoding of the point, or the single byte 0x00 for the point at infinity.
+func (p *G1) Bytes() []byte {
+	return g1.EncodeSEC1(p.point(), true)
+}
+
+// BytesUncompressed returns the SEC 1 uncompressed encoding 0x04 || x || y of the point, or the single byte 0x00 for
+// the point at infinity.
+func (p *G1) BytesUncompressed() []byte {
+	return g1.EncodeSEC1(p.point(), false)
+}
+
+// ClearCofactor sets p to [h_eff]q, e.g. to move the outputs of MapToG1 into G1, and returns p.
+func (p *G1) ClearCofactor(q *G1) *G1 {
+	*p = *newG1(g1.ClearCofactor(q.point()))
+	return p
+}
+
+// G2 is a point of G2, in affine coordinates, or the point at infinity. The coordinates are elements
+// c0 + c1 * v + c2 * v^2 + c3 * v^3 of GF(p^4), represented by [c0, c1, c2, c3], i.e. the element
+// (c0 + c2 * u) + (c1 + c3 * u) * v of the tower.
+type G2 struct {
+	_        disallowEqual
+	X, Y     [4]big.Int
+	infinity bool
+}
+
+func newG2(p *weierstrass.Point) *G2 {
+	q := &G2{infinity: p.Infinity}
+	for i := range q.X {
+		q.X[i].Set(p.X[i])
+		q.Y[i].Set(p.Y[i])
+	}
+
+	return q
+}
+
+func (p *G2) point() *weierstrass.Point {
+	return &weierstrass.Point{
+		X:        g2.Field.Element(&p.X[0], &p.X[1], &p.X[2], &p.X[3]),
+		Y:        g2.Field.Element(&p.Y[0], &p.Y[1], &p.Y[2], &p.Y[3]),
+		Infinity: p.infinity,
+	}
+}
+
+// IsInfinity returns whether p is the point at infinity, i.e. the identity.
+func (p *G2) IsInfinity() bool {
+	return p.infinity
+}
+
+// IsInSubgroup returns whether p is on the twist and in the subgroup of order r.
+func (p *G2) IsInSubgroup() bool {
+	for i := range p.X {
+		if !g2.Field.Base.IsCanonical(&p.X[i]) || !g2.Field.Base.IsCanonical(&p.Y[i]) {
+			return false
+		}
+	}
+
+	return g2.IsInSubgroup(p.point())
+}
+
+// Add sets p to p1 + p2, and returns p.
+func (p *G2) Add(p1, p2 *G2) *G2 {
+	*p = *newG2(g2.Add(p1.point(), p2.point()))
+	return p
+}
+
+// Equal returns whether p and q are the same point.
+func (p *G2) Equal(q *G2) bool {
+	if p.infinity || q.infinity {
+		return p.infinity == q.infinity
+	}
+
+	for i := range p.X {
+		if p.X[i].Cmp(&q.X[i]) != 0 || p.Y[i].Cmp(&q.Y[i]) != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Bytes returns the encoding x || y of the point, with the coefficients of each coordinate in GF(p^4) in the order of
+// X and Y as fixed-length big-endian integers, or all zeros for the point at infinity.
+func (p *G2) Bytes() []byte {
+	return g2.EncodeXY(p.point())
+}
+
+// ClearCofactor sets p to [h_eff]q, e.g. to move the outputs of MapToG2 into G2, and returns p.
+func (p *G2) ClearCofactor(q *G2) *G2 {
+	*p = *newG2(g2.ClearCofactor(q.point()))
+	return p
+}
+
+// HashToG1 implements hash-to-curve mapping to G1 of input with dst.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func HashToG1(input, dst []byte) *G1 {
+	return newG1(g1.HashToCurve(input, dst))
+}
+
+// EncodeToG1 implements encode-to-curve mapping to G1 of input with dst.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func EncodeToG1(input, dst []byte) *G1 {
+	return newG1(g1.EncodeToCurve(input, dst))
+}
+
+// HashToG2 implements hash-to-curve mapping to G2 of input with dst, hashing to GF(p^4).
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func HashToG2(input, dst []byte) *G2 {
+	return newG2(g2.HashToCurve(input, dst))
+}
+
+// EncodeToG2 implements encode-to-curve mapping to G2 of input with dst, hashing to GF(p^4).
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func EncodeToG2(input, dst []byte) *G2 {
+	return newG2(g2.EncodeToCurve(input, dst))
+}
+
+// HashToScalar returns a safe mapping of the arbitrary input to a scalar modulo r.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func HashToScalar(input, dst []byte) *big.Int {
+	return g1.HashToScalar(input, dst)
+}
+
+// MapToG1 implements the Shallue-van de Woestijne map to the curve of G1, without clearing the cofactor. It panics with
+// hash2curve.ErrNonCanonical if fe is not a canonical element of GF(p).
+func MapToG1(fe *big.Int) *G1 {
+	if !g1.Field.Base.IsCanonical(fe) {
+		panic(hash2curve.ErrNonCanonical)
+	}
+
+	return newG1(g1.MapToCurve(extension.Element{fe}))
+}
+
+// MapToG2 implements the Shallue-van de Woestijne map to the twist, without clearing the cofactor, of
+// fe[0] + fe[1] * v + fe[2] * v^2 + fe[3] * v^3. It panics with hash2curve.ErrNonCanonical if fe is not a canonical
+// element of GF(p^4).
+func MapToG2(fe [4]*big.Int) *G2 {
+	u := extension.Element(fe[:])
+	if !g2.Field.IsCanonical(u) {
+		panic(hash2curve.ErrNonCanonical)
+	}
+
+	return newG2(g2.MapToCurve(u))
+}
+
+// Order returns a copy of the order r of G1 and G2, i.e. the modulus of HashToScalar.
+func Order() *big.Int {
+	return g1.Order()
+}
+
+// FieldPrime returns a copy of the prime p of the base field of BLS24-315, over which G2 is defined by an extension.
+func FieldPrime() *big.Int {
+	return g1.FieldPrime()
+}
+
+// CofactorG1 returns a copy of the cofactor of G1, which clears the outputs of MapToG1 into G1.
+func CofactorG1() *big.Int {
+	return g1.Cofactor()
+}
+
+// CofactorG2 returns a copy of the cofactor of G2, which clears the outputs of MapToG2 into G2.
+func CofactorG2() *big.Int {
+	return g2.Cofactor()
+}
@@ -0,0 +1,159 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+// Package bn254 implements hashing to the G1 group of BN254, the pairing-friendly curve y^2 = x^3 + 3 of Ethereum's
+// EIP-196 and EIP-197 precompiles (also known as alt_bn128), with the Shallue-van de Woestijne map and
+// expand_message_xmd with the legacy Keccak-256 of the EVM, so that contracts can verify the points at an affordable
+// cost. Since A = 0, the simplified SWU map would need an isogenous curve, which is not defined here. The curve offers
+// about 100 bits of security, but the hash_to_field lengths are those of the 128 bits minimum of this module. The
+// suites follow the construction of RFC 9380, but are not specified by it.
+package bn254
+
+import (
+	"math/big"
+
+	"github.com/bytemare/hash2curve"
+	"github.com/bytemare/hash2curve/internal/extension"
+	"github.com/bytemare/hash2curve/internal/weierstrass"
+)
+
+const (
+	// H2C represents the hash-to-curve string identifier for G1.
+	H2C = "BN254G1_XMD:KECCAK-256_SVDW_RO_"
+
+	// E2C represents the encode-to-curve string identifier for G1.
+	E2C = "BN254G1_XMD:KECCAK-256_SVDW_NU_"
+
+	// securityLevel is the security level k, in bits, used for the hash_to_field lengths.
+	securityLevel = 128
+
+	fieldPrime = "30644e72e131a029b85045b68181585d97816a916871ca8d3c208c16d87cfd47"
+	groupOrder = "30644e72e131a029b85045b68181585d2833e84879b9709143e1f593f0000001"
+)
+
+var suite = newSuite()
+
+func newSuite() *weierstrass.Suite {
+	p, _ := new(big.Int).SetString(fieldPrime, 16)
+	r, _ := new(big.Int).SetString(groupOrder, 16)
+
+	// Z = 1 is the first value accepted by RFC 9380's find_z_svdw. G1 is the whole curve, with cofactor 1.
+	fp := extension.NewField(p, 1, big.NewInt(1))
+	c := weierstrass.NewCurveSVDW(fp, fp.New(), fp.Element(big.NewInt(3)), fp.Element(big.NewInt(1)), big.NewInt(1))
+
+	return weierstrass.NewSuiteKeccak256(c, r, securityLevel)
+}
+
+type disallowEqual [0]func()
+
+// Point is a point of G1, in affine coordinates, or the point at infinity.
+type Point struct {
+	_        disallowEqual
+	X, Y     big.Int
+	infinity bool
+}
+
+func newPoint(p *weierstrass.Point) *Point {
+	q := &Point{infinity: p.Infinity}
+	q.X.Set(p.X[0])
+	q.Y.Set(p.Y[0])
+
+	return q
+}
+
+func (p *Point) point() *weierstrass.Point {
+	return &weierstrass.Point{
+		X:        extension.Element{new(big.Int).Set(&p.X)},
+		Y:        extension.Element{new(big.Int).Set(&p.Y)},
+		Infinity: p.infinity,
+	}
+}
+
+// IsInfinity returns whether p is the point at infinity, i.e. the identity.
+func (p *Point) IsInfinity() bool {
+	return p.infinity
+}
+
+// Bytes returns the 64-byte encoding x || y of the point of the EIP-196 precompiles, with big-endian coordinates, or
+// 64 zero bytes for the point at infinity.
+func (p *Point) Bytes() []byte {
+	return suite.EncodeXY(p.point())
+}
+
+// SetBytes sets p to the point with the encoding of Bytes, and returns p. It returns an error wrapping
+// hash2curve.ErrInvalidPoint if the encoding is invalid or not that of a point on the curve.
+func (p *Point) SetBytes(b []byte) (*Point, error) {
+	q, err := suite.DecodeXY(b)
+	if err != nil {
+		return nil, err
+	}
+
+	*p = *newPoint(q)
+
+	return p, nil
+}
+
+// Add sets p to p1 + p2, and returns p.
+func (p *Point) Add(p1, p2 *Point) *Point {
+	*p = *newPoint(suite.Add(p1.point(), p2.point()))
+	return p
+}
+
+// Equal returns whether p and q are the same point.
+func (p *Point) Equal(q *Point) bool {
+	if p.infinity || q.infinity {
+		return p.infinity == q.infinity
+	}
+
+	return p.X.Cmp(&q.X) == 0 && p.Y.Cmp(&q.Y) == 0
+}
+
+// HashToCurve implements hash-to-curve mapping to G1 of input with dst.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func HashToCurve(input, dst []byte) *Point {
+	return newPoint(suite.HashToCurve(input, dst))
+}
+
+// EncodeToCurve implements encode-to-curve mapping to G1 of input with dst.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func EncodeToCurve(input, dst []byte) *Point {
+	return newPoint(suite.EncodeToCurve(input, dst))
+}
+
+// HashToScalar returns a safe mapping of the arbitrary input to a scalar for the prime-order group of BN254.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func HashToScalar(input, dst []byte) *big.Int {
+	return suite.HashToScalar(input, dst)
+}
+
+// MapToCurve implements the Shallue-van de Woestijne map to G1. It panics with hash2curve.ErrNonCanonical if fe is not
+// a canonical field element.
+func MapToCurve(fe *big.Int) *Point {
+	if !suite.Field.Base.IsCanonical(fe) {
+		panic(hash2curve.ErrNonCanonical)
+	}
+
+	return newPoint(suite.MapToCurve(extension.Element{fe}))
+}
+
+// Order returns a copy of the order of the prime-order group of BN254, i.e. the modulus of HashToScalar.
+func Order() *big.Int {
+	return suite.Order()
+}
+
+// FieldPrime returns a copy of the prime of the field of BN254.
+func FieldPrime() *big.Int {
+	return suite.FieldPrime()
+}
+
+// Cofactor returns a copy of the cofactor of BN254, which clears the outputs of MapToCurve into the prime-order group.
+func Cofactor() *big.Int {
+	return suite.Cofactor()
+}
@@ -0,0 +1,426 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Command corpusgen writes structured seed corpora for the fuzz targets of the tests package, in the corpus format of
+// go test, so that fuzzing starts from the inputs random mutations take long to reach: DSTs of edge lengths, expansion
+// lengths at the boundaries of the expanders, messages around the block sizes of the hash functions, hash_to_field
+// lengths around their minimum, and the inputs of the RFC 9380 and edge-case vector files.
+//
+// Usage:
+//
+//	corpusgen [-out <dir>] [-vectors <dir>] [-target <name>] [-list]
+//
+// The seeds of each target are written to <out>/<target>/, by default in tests/testdata/fuzz, where go test runs them
+// as seed inputs and go test -fuzz starts from them. Run it from the root of the module. Use -list to print the
+// targets.
+package main
+
+import (
+	"crypto"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"math/bits"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/bytemare/hash"
+
+	"github.com/bytemare/hash2curve/edgecases"
+)
+
+const (
+	corpusHeader = "go test fuzz v1\n"
+
+	// minExpandLengthXOF is the smallest expansion length the XOF targets accept.
+	minExpandLengthXOF = 32
+
+	// minSecurityBits is the minimum security level of hash_to_field, from which the minimum length L is computed.
+	minSecurityBits = 128
+)
+
+var (
+	errUnknownTarget = errors.New("unknown fuzz target")
+	errNoVectors     = errors.New("no vector files")
+)
+
+// expander is a hash function of an expander, with its identifier as taken by the fuzz targets.
+type expander struct {
+	name      string
+	id        uint
+	size      int // the output size of XMD hash functions, and 0 for XOFs
+	blockSize int
+}
+
+var (
+	xmdHashes = []expander{
+		{name: "SHA256", id: uint(crypto.SHA256), size: crypto.SHA256.Size(), blockSize: sha256.BlockSize},
+		{name: "SHA384", id: uint(crypto.SHA384), size: crypto.SHA384.Size(), blockSize: sha512.BlockSize},
+		{name: "SHA512", id: uint(crypto.SHA512), size: crypto.SHA512.Size(), blockSize: sha512.BlockSize},
+	}
+
+	xofHashes = []expander{
+		{name: "SHAKE128", id: uint(hash.SHAKE128), blockSize: hash.SHAKE128.BlockSize()},
+		{name: "SHAKE256", id: uint(hash.SHAKE256), blockSize: hash.SHAKE256.BlockSize()},
+		// github.com/bytemare/hash doesn't give the block sizes of BLAKE2X, which are those of BLAKE2b and BLAKE2s.
+		{name: "BLAKE2XB", id: uint(hash.BLAKE2XB), blockSize: 128},
+		{name: "BLAKE2XS", id: uint(hash.BLAKE2XS), blockSize: 64},
+	}
+
+	// moduli are the hash_to_field moduli of the int64 fuzz arguments, from the smallest to the largest.
+	moduli = []int64{2, 3, 251, 65537, math.MaxInt32, 1<<61 - 1, math.MaxInt64}
+)
+
+// target is a fuzz target of the tests package, with the seeds matching its arguments.
+type target struct {
+	seeds func(c *inputs) [][]any
+	name  string
+}
+
+var targets = []target{
+	{name: "FuzzExpandXMD", seeds: expandSeeds(xmdHashes, edgecases.ExpandMessageXMD)},
+	{name: "FuzzHashToFieldXMD", seeds: hashToFieldSeeds(xmdHashes)},
+	{name: "FuzzExpandXOF", seeds: expandSeeds(xofHashes, edgecases.ExpandMessageXOF)},
+	{name: "FuzzHashToFieldXOF", seeds: hashToFieldSeeds(xofHashes)},
+	{name: "FuzzP256", seeds: mappingSeeds},
+	{name: "FuzzP384", seeds: mappingSeeds},
+	{name: "FuzzP521", seeds: mappingSeeds},
+	{name: "FuzzSecp256k1", seeds: mappingSeeds},
+	{name: "FuzzEdwards25519", seeds: mappingSeeds},
+	{name: "FuzzRistretto255", seeds: mappingSeeds},
+}
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "corpusgen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("corpusgen", flag.ContinueOnError)
+	out := flags.String("out", filepath.Join("tests", "testdata", "fuzz"), "output directory of the corpora")
+	vectors := flags.String("vectors", filepath.Join("tests", "vectors"), "directory of the RFC 9380 vector files")
+	only := flags.String("target", "", "write the corpus of this target only (default is all targets)")
+	list := flags.Bool("list", false, "list the fuzz targets")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *list {
+		for _, t := range targets {
+			if _, err := fmt.Fprintln(stdout, t.name); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	selected := targets
+	if *only != "" {
+		i := slices.IndexFunc(targets, func(t target) bool { return t.name == *only })
+		if i < 0 {
+			return fmt.Errorf("%w: %q", errUnknownTarget, *only)
+		}
+
+		selected = targets[i : i+1]
+	}
+
+	c, err := loadInputs(*vectors)
+	if err != nil {
+		return err
+	}
+
+	for _, t := range selected {
+		n, err := writeCorpus(filepath.Join(*out, t.name), t.seeds(c))
+		if err != nil {
+			return err
+		}
+
+		if _, err = fmt.Fprintf(stdout, "%s: %d seeds\n", t.name, n); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// inputs holds the messages and DSTs taken from the vector files, and the expander test cases of the edge-case corpus.
+type inputs struct {
+	messages  [][]byte
+	dsts      [][]byte
+	edgeCases []edgecases.Test
+}
+
+// vectorFile holds the fields of the RFC 9380 hash-to-curve and expand_message vector files used as inputs.
+type vectorFile struct {
+	DST     string `json:"dst"`
+	Vectors []struct {
+		Msg string `json:"msg"`
+	} `json:"vectors"`
+	Tests []struct {
+		Msg string `json:"msg"`
+	} `json:"tests"`
+}
+
+func loadInputs(dir string) (*inputs, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*", "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(files) == 0 {
+		return nil, fmt.Errorf("%w in %s", errNoVectors, dir)
+	}
+
+	c := new(inputs)
+
+	for _, name := range files {
+		b, err := os.ReadFile(name)
+		if err != nil {
+			return nil, err
+		}
+
+		// The field names are matched case-insensitively, so DST matches both "dst" and "DST".
+		var v vectorFile
+		if err = json.Unmarshal(b, &v); err != nil {
+			return nil, fmt.Errorf("decoding %s: %w", name, err)
+		}
+
+		if v.DST != "" {
+			c.dsts = appendUnique(c.dsts, []byte(v.DST))
+		}
+
+		for _, t := range v.Vectors {
+			c.messages = appendUnique(c.messages, []byte(t.Msg))
+		}
+
+		for _, t := range v.Tests {
+			c.messages = appendUnique(c.messages, []byte(t.Msg))
+		}
+	}
+
+	groups, err := edgecases.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, g := range groups {
+		c.edgeCases = append(c.edgeCases, g.Tests...)
+	}
+
+	return c, nil
+}
+
+func appendUnique(list [][]byte, b []byte) [][]byte {
+	if slices.ContainsFunc(list, func(e []byte) bool { return string(e) == string(b) }) {
+		return list
+	}
+
+	return append(list, b)
+}
+
+// pattern returns n bytes of a repeating pattern, for the messages and DSTs of a given length.
+func pattern(n int, seed byte) []byte {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = seed + byte(i)
+	}
+
+	return b
+}
+
+// dstLengths are the DST lengths around the 16-byte recommendation and the 255-byte limit of expand_message.
+var dstLengths = []int{1, 15, 16, 17, 254, 255, 256}
+
+// expandLengths returns the expansion lengths at the boundaries of the expander.
+func expandLengths(h expander) []uint {
+	if h.size == 0 {
+		return []uint{minExpandLengthXOF, minExpandLengthXOF + 1, uint(h.blockSize) - 1, uint(h.blockSize),
+			uint(h.blockSize) + 1, math.MaxUint16}
+	}
+
+	return []uint{1, uint(h.size) - 1, uint(h.size), uint(h.size) + 1, 2 * uint(h.size),
+		255*uint(h.size) - 1, 255 * uint(h.size)}
+}
+
+// messageLengths returns the message lengths around the block size of the hash function.
+func messageLengths(h expander) []int {
+	return []int{0, h.blockSize - 1, h.blockSize, h.blockSize + 1, 2 * h.blockSize}
+}
+
+// expandSeeds returns the seeds of the expand_message targets, i.e. (hash, input, dst, length), varying one argument
+// at a time from a base input, followed by the vector and edge-case inputs.
+func expandSeeds(hashes []expander, operation edgecases.Operation) func(c *inputs) [][]any {
+	return func(c *inputs) [][]any {
+		var seeds [][]any
+
+		for _, h := range hashes {
+			dst := []byte("QUUX-V01-CS02-with-expander-" + h.name)
+			length := expandLengths(h)[0]
+
+			for _, n := range dstLengths {
+				seeds = append(seeds, []any{h.id, []byte("abc"), pattern(n, 'D'), length})
+			}
+
+			for _, l := range expandLengths(h) {
+				seeds = append(seeds, []any{h.id, []byte("abc"), dst, l})
+			}
+
+			for _, n := range messageLengths(h) {
+				seeds = append(seeds, []any{h.id, pattern(n, 'M'), dst, length})
+			}
+
+			for _, msg := range c.messages {
+				seeds = append(seeds, []any{h.id, msg, dst, length})
+			}
+
+			for _, d := range c.dsts {
+				seeds = append(seeds, []any{h.id, []byte("abc"), d, length})
+			}
+
+			for _, test := range c.edgeCases {
+				if test.Operation != operation || test.Hash != h.name {
+					continue
+				}
+
+				msg, err := test.MsgBytes()
+				if err != nil {
+					continue
+				}
+
+				d, err := test.DSTBytes()
+				if err != nil {
+					continue
+				}
+
+				seeds = append(seeds, []any{h.id, msg, d, test.Length})
+			}
+		}
+
+		return seeds
+	}
+}
+
+// hashToFieldSeeds returns the seeds of the hash_to_field targets, i.e. (hash, input, dst, count, ext, L, modulo),
+// with L around its minimum for each modulus, and the counts and extension degrees of the suites.
+func hashToFieldSeeds(hashes []expander) func(c *inputs) [][]any {
+	return func(c *inputs) [][]any {
+		var seeds [][]any
+
+		for _, h := range hashes {
+			dst := []byte("QUUX-V01-CS02-with-hash-to-field-" + h.name)
+
+			for _, modulo := range moduli {
+				minLength := uint(bits.Len64(uint64(modulo))+minSecurityBits+7) / 8
+
+				for _, l := range []uint{minLength - 1, minLength, minLength + 1} {
+					seeds = append(seeds, []any{h.id, []byte("abc"), dst, uint(1), uint(1), l, modulo})
+				}
+
+				for _, count := range []uint{1, 2} {
+					for _, ext := range []uint{1, 2} {
+						seeds = append(seeds, []any{h.id, []byte("abc"), dst, count, ext, minLength, modulo})
+					}
+				}
+			}
+
+			largest := moduli[len(moduli)-1]
+			for _, n := range messageLengths(h) {
+				seeds = append(seeds, []any{h.id, pattern(n, 'M'), dst, uint(2), uint(1), uint(48), largest})
+			}
+		}
+
+		return seeds
+	}
+}
+
+// mappingSeeds returns the seeds of the mapping targets, i.e. (input, dst1, dst2), with the vector messages and
+// DSTs, equal DSTs, DSTs differing in their last byte, and DSTs of edge lengths.
+func mappingSeeds(c *inputs) [][]any {
+	var seeds [][]any
+
+	for _, msg := range c.messages {
+		for _, dst := range c.dsts {
+			seeds = append(seeds, []any{msg, dst, dst})
+		}
+	}
+
+	for _, dst := range c.dsts {
+		other := slices.Clone(dst)
+		other[len(other)-1] ^= 1
+		seeds = append(seeds, []any{[]byte("abc"), dst, other})
+	}
+
+	for _, n := range dstLengths {
+		seeds = append(seeds, []any{[]byte("abc"), pattern(n, 'D'), pattern(n, 'd')})
+	}
+
+	return seeds
+}
+
+// writeCorpus writes the seeds to dir, in files named after their content as go test does, and returns the number of
+// distinct seeds.
+func writeCorpus(dir string, seeds [][]any) (int, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return 0, err
+	}
+
+	written := make(map[string]bool, len(seeds))
+
+	for _, seed := range seeds {
+		b, err := marshal(seed)
+		if err != nil {
+			return 0, err
+		}
+
+		sum := sha256.Sum256(b)
+		name := hex.EncodeToString(sum[:])[:16]
+
+		if written[name] {
+			continue
+		}
+
+		if err = os.WriteFile(filepath.Join(dir, name), b, 0o600); err != nil {
+			return 0, err
+		}
+
+		written[name] = true
+	}
+
+	return len(written), nil
+}
+
+// marshal returns the go test corpus file encoding of the values.
+func marshal(values []any) ([]byte, error) {
+	b := []byte(corpusHeader)
+
+	for _, v := range values {
+		switch v := v.(type) {
+		case []byte:
+			b = fmt.Appendf(b, "[]byte(%q)\n", v)
+		case uint:
+			b = fmt.Appendf(b, "uint(%d)\n", v)
+		case int64:
+			b = fmt.Appendf(b, "int64(%d)\n", v)
+		default:
+			return nil, fmt.Errorf("unsupported corpus value type %T", v)
+		}
+	}
+
+	return b, nil
+}
@@ -0,0 +1,142 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var testVectors = filepath.Join("..", "..", "tests", "vectors")
+
+// readCorpora returns the content of the corpus files under dir, by their path relative to dir.
+func readCorpora(t *testing.T, dir string) map[string][]byte {
+	t.Helper()
+
+	files := make(map[string][]byte)
+
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(dir, path)
+		files[rel] = b
+
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return files
+}
+
+// TestRun_Deterministic checks that two runs write the same files, and that a run over an existing corpus doesn't
+// change it, so that regenerating the committed corpora only shows the changes of the generator.
+func TestRun_Deterministic(t *testing.T) {
+	first, second := t.TempDir(), t.TempDir()
+
+	var report1, report2 bytes.Buffer
+	if err := run([]string{"-out", first, "-vectors", testVectors}, &report1); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := run([]string{"-out", second, "-vectors", testVectors}, &report2); err != nil {
+		t.Fatal(err)
+	}
+
+	if report1.String() != report2.String() {
+		t.Fatalf("the reports differ:\n%s\n%s", report1.String(), report2.String())
+	}
+
+	corpus1, corpus2 := readCorpora(t, first), readCorpora(t, second)
+	if len(corpus1) == 0 || len(corpus1) != len(corpus2) {
+		t.Fatalf("want the same number of files, got %d and %d", len(corpus1), len(corpus2))
+	}
+
+	for name, b := range corpus1 {
+		if !bytes.Equal(b, corpus2[name]) {
+			t.Fatalf("%s differs between the runs", name)
+		}
+
+		if !bytes.HasPrefix(b, []byte(corpusHeader)) {
+			t.Fatalf("%s is not a corpus file", name)
+		}
+	}
+
+	for _, target := range targets {
+		if !strings.Contains(report1.String(), target.name+": ") {
+			t.Fatalf("no seeds reported for %s", target.name)
+		}
+	}
+
+	if err := run([]string{"-out", first, "-vectors", testVectors}, io.Discard); err != nil {
+		t.Fatal(err)
+	}
+
+	if again := readCorpora(t, first); len(again) != len(corpus1) {
+		t.Fatalf("a second run over the corpus changed it from %d to %d files", len(corpus1), len(again))
+	}
+}
+
+func TestRun_Target(t *testing.T) {
+	out := t.TempDir()
+
+	if err := run([]string{"-out", out, "-vectors", testVectors, "-target", "FuzzP256"}, io.Discard); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := os.ReadDir(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entries) != 1 || entries[0].Name() != "FuzzP256" {
+		t.Fatalf("unexpected corpora %v", entries)
+	}
+
+	err = run([]string{"-out", out, "-vectors", testVectors, "-target", "FuzzUnknown"}, io.Discard)
+	if !errors.Is(err, errUnknownTarget) {
+		t.Fatalf("want %v, got %v", errUnknownTarget, err)
+	}
+
+	if err = run([]string{"-out", out, "-vectors", t.TempDir()}, io.Discard); !errors.Is(err, errNoVectors) {
+		t.Fatalf("want %v, got %v", errNoVectors, err)
+	}
+}
+
+func TestRun_List(t *testing.T) {
+	var list bytes.Buffer
+	if err := run([]string{"-list"}, &list); err != nil {
+		t.Fatal(err)
+	}
+
+	names := strings.Fields(list.String())
+	if len(names) != len(targets) {
+		t.Fatalf("want %d targets, got %d", len(targets), len(names))
+	}
+
+	for i, name := range names {
+		if name != targets[i].name {
+			t.Fatalf("want %s, got %s", targets[i].name, name)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Command genfield writes a constant-time Go implementation of a prime field, to be used with go:generate.
+//
+// Usage:
+//
+//	genfield -prime <prime> -package <name> [-out <file>] [-big]
+//
+// The prime is given in decimal, or in hexadecimal with the 0x prefix. With -out, the conversions from and to big.Int
+// are written next to the file, with the _big suffix. Without it, the field is written to stdout, or only the
+// conversions with -big.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/bytemare/hash2curve/genfield"
+)
+
+var errPrimeFormat = errors.New("the prime must be a decimal or 0x-prefixed hexadecimal integer")
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "genfield: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("genfield", flag.ContinueOnError)
+	prime := flags.String("prime", "", "field order, in decimal or 0x-prefixed hexadecimal")
+	pkg := flags.String("package", "", "name of the generated package")
+	out := flags.String("out", "", "output file (default is stdout)")
+	onlyBig := flags.Bool("big", false, "write the big.Int conversions to stdout, when -out is not set")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	p, ok := new(big.Int).SetString(*prime, 0)
+	if !ok {
+		return errPrimeFormat
+	}
+
+	config := genfield.Config{
+		Prime:   p,
+		Package: *pkg,
+		Command: "genfield " + strings.Join(args, " "),
+	}
+
+	src, err := genfield.Generate(config)
+	if err != nil {
+		return err
+	}
+
+	bigSrc, err := genfield.GenerateBig(config)
+	if err != nil {
+		return err
+	}
+
+	if *out == "" {
+		if *onlyBig {
+			src = bigSrc
+		}
+
+		_, err = stdout.Write(src)
+
+		return err
+	}
+
+	if err = os.WriteFile(*out, src, 0o600); err != nil {
+		return err
+	}
+
+	return os.WriteFile(strings.TrimSuffix(*out, ".go")+"_big.go", bigSrc, 0o600)
+}
@@ -0,0 +1,208 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+// Command h2c hashes or encodes a message to a curve or to a scalar, and prints the result.
+//
+// Usage:
+//
+//	h2c -suite <suite> -dst <dst> [-mode ro|nu|scalar] [-encoding hex|base64] [-file <path>] [message]
+//
+// The message is taken from the argument if given, or else from the file, or else from stdin. The suite is either a
+// curve name (P256, P384, P521, edwards25519, secp256k1, ristretto255) or a full RFC 9380 suite identifier, in which
+// case the mode is derived from the suffix of the identifier unless it's explicitly set to scalar. Setting the mode to
+// the other encoding than that of the identifier is an error.
+package main
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/bytemare/hash2curve/edwards25519"
+	"github.com/bytemare/hash2curve/nist"
+	"github.com/bytemare/hash2curve/ristretto255"
+	"github.com/bytemare/hash2curve/secp256k1"
+)
+
+const (
+	modeHash   = "ro"
+	modeEncode = "nu"
+	modeScalar = "scalar"
+)
+
+var (
+	errUnknownSuite    = errors.New("unknown suite")
+	errUnknownMode     = errors.New("unknown mode")
+	errModeConflict    = errors.New("the mode conflicts with the suite identifier")
+	errUnknownEncoding = errors.New("unknown output encoding")
+	errEmptyDST        = errors.New("a non-empty dst is required")
+	errTooManyArgs     = errors.New("too many arguments")
+)
+
+// suite holds the functions of a curve for each mode, returning the byte encoding of the output.
+type suite struct {
+	hash, encode, scalar func(input, dst []byte) []byte
+}
+
+var suites = map[string]suite{
+	"P256": {
+		hash:   func(input, dst []byte) []byte { return nist.HashToP256(input, dst).BytesCompressed() },
+		encode: func(input, dst []byte) []byte { return nist.EncodeToP256(input, dst).BytesCompressed() },
+		scalar: func(input, dst []byte) []byte { return fixedBytes(nist.HashToScalarP256(input, dst), 32) },
+	},
+	"P384": {
+		hash:   func(input, dst []byte) []byte { return nist.HashToP384(input, dst).BytesCompressed() },
+		encode: func(input, dst []byte) []byte { return nist.EncodeToP384(input, dst).BytesCompressed() },
+		scalar: func(input, dst []byte) []byte { return fixedBytes(nist.HashToScalarP384(input, dst), 48) },
+	},
+	"P521": {
+		hash:   func(input, dst []byte) []byte { return nist.HashToP521(input, dst).BytesCompressed() },
+		encode: func(input, dst []byte) []byte { return nist.EncodeToP521(input, dst).BytesCompressed() },
+		scalar: func(input, dst []byte) []byte { return fixedBytes(nist.HashToScalarP521(input, dst), 66) },
+	},
+	"edwards25519": {
+		hash:   func(input, dst []byte) []byte { return edwards25519.HashToCurve(input, dst).Bytes() },
+		encode: func(input, dst []byte) []byte { return edwards25519.EncodeToCurve(input, dst).Bytes() },
+		scalar: func(input, dst []byte) []byte { return edwards25519.HashToScalar(input, dst).Bytes() },
+	},
+	"secp256k1": {
+		hash:   func(input, dst []byte) []byte { return secp256k1.HashToCurve(input, dst).Bytes() },
+		encode: func(input, dst []byte) []byte { return secp256k1.EncodeToCurve(input, dst).Bytes() },
+		scalar: func(input, dst []byte) []byte { return fixedBytes(secp256k1.HashToScalar(input, dst), 32) },
+	},
+	"ristretto255": {
+		hash:   func(input, dst []byte) []byte { return ristretto255.HashToGroup(input, dst).Encode(nil) },
+		encode: func(input, dst []byte) []byte { return ristretto255.EncodeToGroup(input, dst).Encode(nil) },
+		scalar: func(input, dst []byte) []byte { return ristretto255.HashToScalar(input, dst).Encode(nil) },
+	},
+}
+
+// suiteIDs maps the RFC 9380 suite identifiers to their curve and mode.
+var suiteIDs = map[string][2]string{
+	nist.H2CP256:     {"P256", modeHash},
+	nist.E2CP256:     {"P256", modeEncode},
+	nist.H2CP384:     {"P384", modeHash},
+	nist.E2CP384:     {"P384", modeEncode},
+	nist.H2CP521:     {"P521", modeHash},
+	nist.E2CP521:     {"P521", modeEncode},
+	edwards25519.H2C: {"edwards25519", modeHash},
+	edwards25519.E2C: {"edwards25519", modeEncode},
+	secp256k1.H2C:    {"secp256k1", modeHash},
+	secp256k1.E2C:    {"secp256k1", modeEncode},
+	ristretto255.H2C: {"ristretto255", modeHash},
+	ristretto255.E2C: {"ristretto255", modeEncode},
+}
+
+func fixedBytes(s *big.Int, length int) []byte {
+	return s.FillBytes(make([]byte, length))
+}
+
+func main() {
+	if err := run(os.Args[1:], os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "h2c: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func resolve(name, mode string) (func(input, dst []byte) []byte, error) {
+	if id, ok := suiteIDs[name]; ok {
+		if (mode == modeHash || mode == modeEncode) && mode != id[1] {
+			return nil, fmt.Errorf("%w: %q with %q", errModeConflict, mode, name)
+		}
+
+		if mode == "" {
+			mode = id[1]
+		}
+
+		name = id[0]
+	}
+
+	s, ok := suites[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", errUnknownSuite, name)
+	}
+
+	switch mode {
+	case modeHash, "":
+		return s.hash, nil
+	case modeEncode:
+		return s.encode, nil
+	case modeScalar:
+		return s.scalar, nil
+	default:
+		return nil, fmt.Errorf("%w: %q", errUnknownMode, mode)
+	}
+}
+
+func readMessage(args []string, file string, stdin io.Reader) ([]byte, error) {
+	switch {
+	case len(args) > 1:
+		return nil, errTooManyArgs
+	case len(args) == 1:
+		return []byte(args[0]), nil
+	case file != "" && file != "-":
+		return os.ReadFile(file)
+	default:
+		return io.ReadAll(stdin)
+	}
+}
+
+func encode(output []byte, encoding string) (string, error) {
+	switch strings.ToLower(encoding) {
+	case "hex":
+		return hex.EncodeToString(output), nil
+	case "base64":
+		return base64.StdEncoding.EncodeToString(output), nil
+	default:
+		return "", fmt.Errorf("%w: %q", errUnknownEncoding, encoding)
+	}
+}
+
+func run(args []string, stdin io.Reader, stdout io.Writer) error {
+	flags := flag.NewFlagSet("h2c", flag.ContinueOnError)
+	suiteName := flags.String("suite", "", "curve name or RFC 9380 suite identifier")
+	dst := flags.String("dst", "", "domain separation tag")
+	mode := flags.String("mode", "", "ro (hash-to-curve), nu (encode-to-curve), or scalar (hash-to-scalar)")
+	encoding := flags.String("encoding", "hex", "output encoding: hex or base64")
+	file := flags.String("file", "", "read the message from this file (- for stdin)")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	f, err := resolve(*suiteName, *mode)
+	if err != nil {
+		return err
+	}
+
+	if *dst == "" {
+		return errEmptyDST
+	}
+
+	msg, err := readMessage(flags.Args(), *file, stdin)
+	if err != nil {
+		return err
+	}
+
+	out, err := encode(f(msg, []byte(*dst)), *encoding)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintln(stdout, out)
+
+	return err
+}
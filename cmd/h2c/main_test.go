@@ -0,0 +1,148 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bytemare/hash2curve/edwards25519"
+	"github.com/bytemare/hash2curve/nist"
+	"github.com/bytemare/hash2curve/ristretto255"
+	"github.com/bytemare/hash2curve/secp256k1"
+)
+
+func TestRun(t *testing.T) {
+	msg, dst := []byte("abc"), []byte("QUUX-V01-CS02-with-h2c")
+
+	file := filepath.Join(t.TempDir(), "message")
+	if err := os.WriteFile(file, msg, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	p256RO := hex.EncodeToString(nist.HashToP256(msg, dst).BytesCompressed())
+	p256NU := hex.EncodeToString(nist.EncodeToP256(msg, dst).BytesCompressed())
+	p256Scalar := hex.EncodeToString(nist.HashToScalarP256(msg, dst).FillBytes(make([]byte, 32)))
+
+	for _, test := range []struct {
+		err   error
+		name  string
+		stdin string
+		want  string
+		args  []string
+	}{
+		{name: "curve", args: []string{"-suite", "P256", "-dst", string(dst), "abc"}, want: p256RO},
+		{name: "curve nu", args: []string{"-suite", "P256", "-dst", string(dst), "-mode", "nu", "abc"}, want: p256NU},
+		{
+			name: "curve scalar",
+			args: []string{"-suite", "P256", "-dst", string(dst), "-mode", "scalar", "abc"},
+			want: p256Scalar,
+		},
+		{name: "ro identifier", args: []string{"-suite", nist.H2CP256, "-dst", string(dst), "abc"}, want: p256RO},
+		{name: "nu identifier", args: []string{"-suite", nist.E2CP256, "-dst", string(dst), "abc"}, want: p256NU},
+		{
+			name: "matching mode",
+			args: []string{"-suite", nist.E2CP256, "-dst", string(dst), "-mode", "nu", "abc"},
+			want: p256NU,
+		},
+		{
+			name: "identifier scalar",
+			args: []string{"-suite", nist.E2CP256, "-dst", string(dst), "-mode", "scalar", "abc"},
+			want: p256Scalar,
+		},
+		{
+			name: "base64",
+			args: []string{"-suite", "P256", "-dst", string(dst), "-encoding", "base64", "abc"},
+			want: base64.StdEncoding.EncodeToString(nist.HashToP256(msg, dst).BytesCompressed()),
+		},
+		{
+			name: "upper case encoding",
+			args: []string{"-suite", "P256", "-dst", string(dst), "-encoding", "HEX", "abc"},
+			want: p256RO,
+		},
+		{name: "stdin", args: []string{"-suite", "P256", "-dst", string(dst)}, stdin: "abc", want: p256RO},
+		{name: "file", args: []string{"-suite", "P256", "-dst", string(dst), "-file", file}, want: p256RO},
+		{
+			name:  "file stdin",
+			args:  []string{"-suite", "P256", "-dst", string(dst), "-file", "-"},
+			stdin: "abc",
+			want:  p256RO,
+		},
+		{
+			name: "edwards25519",
+			args: []string{"-suite", "edwards25519", "-dst", string(dst), "abc"},
+			want: hex.EncodeToString(edwards25519.HashToCurve(msg, dst).Bytes()),
+		},
+		{
+			name: "secp256k1",
+			args: []string{"-suite", secp256k1.E2C, "-dst", string(dst), "abc"},
+			want: hex.EncodeToString(secp256k1.EncodeToCurve(msg, dst).Bytes()),
+		},
+		{
+			name: "ristretto255 scalar",
+			args: []string{"-suite", "ristretto255", "-dst", string(dst), "-mode", "scalar", "abc"},
+			want: hex.EncodeToString(ristretto255.HashToScalar(msg, dst).Encode(nil)),
+		},
+		{
+			name: "conflicting mode",
+			args: []string{"-suite", nist.H2CP256, "-dst", string(dst), "-mode", "nu", "abc"},
+			err:  errModeConflict,
+		},
+		{
+			name: "conflicting mode nu",
+			args: []string{"-suite", nist.E2CP256, "-dst", string(dst), "-mode", "ro", "abc"},
+			err:  errModeConflict,
+		},
+		{name: "unknown suite", args: []string{"-suite", "P257", "-dst", string(dst), "abc"}, err: errUnknownSuite},
+		{name: "no suite", args: []string{"-dst", string(dst), "abc"}, err: errUnknownSuite},
+		{
+			name: "unknown mode",
+			args: []string{"-suite", "P256", "-dst", string(dst), "-mode", "xof", "abc"},
+			err:  errUnknownMode,
+		},
+		{
+			name: "unknown encoding",
+			args: []string{"-suite", "P256", "-dst", string(dst), "-encoding", "base32", "abc"},
+			err:  errUnknownEncoding,
+		},
+		{name: "empty dst", args: []string{"-suite", "P256", "abc"}, err: errEmptyDST},
+		{
+			name: "too many arguments",
+			args: []string{"-suite", "P256", "-dst", string(dst), "a", "b"},
+			err:  errTooManyArgs,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			var stdout bytes.Buffer
+
+			err := run(test.args, strings.NewReader(test.stdin), &stdout)
+			if !errors.Is(err, test.err) {
+				t.Fatalf("want error %v, got %v", test.err, err)
+			}
+
+			if got := strings.TrimSuffix(stdout.String(), "\n"); got != test.want {
+				t.Fatalf("want %q, got %q", test.want, got)
+			}
+		})
+	}
+}
+
+func TestRun_InvalidFlag(t *testing.T) {
+	if err := run([]string{"-unknown"}, strings.NewReader(""), &bytes.Buffer{}); err == nil {
+		t.Fatal("expected an error")
+	}
+}
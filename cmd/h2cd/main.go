@@ -0,0 +1,221 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+// Command h2cd is a reference HTTP server exposing hash_to_curve, encode_to_curve, and hash-to-scalar for all the
+// suites of this module, as listed by hash2curve.Suites, for cross-language interoperability testing, or as a sidecar
+// for platforms without a native implementation.
+//
+// Usage:
+//
+//	h2cd [-addr localhost:8080] [-max-body 1048576]
+//
+// The endpoints take and return JSON, with hex-encoded byte strings:
+//
+//	GET  /v1/suites           the suites, as returned by hash2curve.Suites
+//	POST /v1/hash-to-curve    {"suite": "<identifier>", "dst": "<hex>", "msg": "<hex>"} -> {"output": "<hex>"}
+//	POST /v1/encode-to-curve  same as hash-to-curve
+//	POST /v1/hash-to-scalar   same as hash-to-curve
+//
+// Points and scalars are encoded as by the suite package. hash-to-curve and encode-to-curve accept either the RO or
+// the NU identifier of a suite. Errors are returned as {"error": "<message>"} with a 4xx status.
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/bytemare/hash2curve"
+	"github.com/bytemare/hash2curve/suite"
+)
+
+const (
+	defaultAddr    = "localhost:8080"
+	defaultMaxBody = 1 << 20
+
+	shutdownTimeout   = 10 * time.Second
+	readHeaderTimeout = 5 * time.Second
+)
+
+var (
+	errUnknownSuite = errors.New("unknown suite")
+	errInvalidHex   = errors.New("invalid hex encoding")
+)
+
+// request is the body of the hashing endpoints.
+type request struct {
+	Suite string `json:"suite"`
+	DST   string `json:"dst"`
+	Msg   string `json:"msg"`
+}
+
+type response struct {
+	Output string `json:"output,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// operation computes the output of a hashing endpoint for the input with the hasher.
+type operation func(h *suite.Hasher, input []byte) []byte
+
+type server struct {
+	suites  map[string]*suite.Suite
+	maxBody int64
+}
+
+func newServer(maxBody int64) (*server, error) {
+	s := &server{suites: make(map[string]*suite.Suite), maxBody: maxBody}
+
+	for _, d := range hash2curve.Suites() {
+		st, err := suite.New(d.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		s.suites[d.ID] = st
+	}
+
+	return s, nil
+}
+
+func (s *server) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/suites", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, http.StatusOK, hash2curve.Suites())
+	})
+	mux.Handle("POST /v1/hash-to-curve", s.hashing((*suite.Hasher).HashToCurve))
+	mux.Handle("POST /v1/encode-to-curve", s.hashing((*suite.Hasher).EncodeToCurve))
+	mux.Handle("POST /v1/hash-to-scalar", s.hashing((*suite.Hasher).HashToScalar))
+
+	return mux
+}
+
+// hashing returns the handler of a hashing endpoint, which validates the request before computing the operation, so
+// that invalid inputs are reported instead of panicking.
+func (s *server) hashing(op operation) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req request
+
+		decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, s.maxBody))
+		decoder.DisallowUnknownFields()
+
+		if err := decoder.Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		output, err := s.compute(op, &req)
+		if err != nil {
+			writeError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, response{Output: hex.EncodeToString(output)})
+	})
+}
+
+func (s *server) compute(op operation, req *request) ([]byte, error) {
+	st, ok := s.suites[req.Suite]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", errUnknownSuite, req.Suite)
+	}
+
+	dst, err := hex.DecodeString(req.DST)
+	if err != nil {
+		return nil, fmt.Errorf("dst: %w", errInvalidHex)
+	}
+
+	msg, err := hex.DecodeString(req.Msg)
+	if err != nil {
+		return nil, fmt.Errorf("msg: %w", errInvalidHex)
+	}
+
+	if err = st.ValidateInput(msg); err != nil {
+		return nil, err
+	}
+
+	h, err := st.Hasher(dst)
+	if err != nil {
+		return nil, err
+	}
+
+	return op(h, msg), nil
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, response{Error: err.Error()})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("h2cd: %v", err)
+	}
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	err := run(ctx, os.Args[1:])
+
+	stop()
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "h2cd: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("h2cd", flag.ContinueOnError)
+	addr := flags.String("addr", defaultAddr, "address to listen on")
+	maxBody := flags.Int64("max-body", defaultMaxBody, "maximum size of the request bodies, in bytes")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	s, err := newServer(*maxBody)
+	if err != nil {
+		return err
+	}
+
+	srv := &http.Server{
+		Addr:              *addr,
+		Handler:           s.handler(),
+		ReadHeaderTimeout: readHeaderTimeout,
+	}
+
+	errs := make(chan error, 1)
+
+	go func() {
+		log.Printf("h2cd: listening on %s", *addr)
+		errs <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err = <-errs:
+		return err
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+
+		return srv.Shutdown(shutdownCtx)
+	}
+}
@@ -0,0 +1,215 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+package main
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/bytemare/hash2curve"
+	"github.com/bytemare/hash2curve/nist"
+	"github.com/bytemare/hash2curve/suite"
+)
+
+const testMaxBody = 1024
+
+var (
+	testMsg = []byte("abc")
+	testDST = []byte("QUUX-V01-CS02-with-h2cd")
+)
+
+func newTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	s, err := newServer(testMaxBody)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ts := httptest.NewServer(s.handler())
+	t.Cleanup(ts.Close)
+
+	return ts
+}
+
+func decodeResponse(t *testing.T, resp *http.Response, v any) {
+	t.Helper()
+
+	defer resp.Body.Close()
+
+	if ct := resp.Header.Get("Content-Type"); ct != "application/json" {
+		t.Fatalf("unexpected content type %q", ct)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func expected(t *testing.T, id string, f func(s *suite.Suite) []byte) string {
+	t.Helper()
+
+	s, err := suite.New(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return hex.EncodeToString(f(s))
+}
+
+func TestSuites(t *testing.T) {
+	ts := newTestServer(t)
+
+	resp, err := http.Get(ts.URL + "/v1/suites")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("unexpected status %d", resp.StatusCode)
+	}
+
+	var suites []hash2curve.SuiteDescriptor
+	decodeResponse(t, resp, &suites)
+
+	want := hash2curve.Suites()
+	if len(suites) != len(want) {
+		t.Fatalf("want %d suites, got %d", len(want), len(suites))
+	}
+
+	for i := range want {
+		if suites[i] != want[i] {
+			t.Fatalf("want %+v, got %+v", want[i], suites[i])
+		}
+	}
+}
+
+func TestHashing(t *testing.T) {
+	ts := newTestServer(t)
+	body := func(id string) string {
+		return `{"suite": "` + id + `", "dst": "` + hex.EncodeToString(testDST) + `", "msg": "` +
+			hex.EncodeToString(testMsg) + `"}`
+	}
+
+	for _, test := range []struct {
+		name, endpoint, body, want string
+	}{
+		{
+			name:     "hash-to-curve",
+			endpoint: "/v1/hash-to-curve",
+			body:     body(nist.H2CP256),
+			want:     expected(t, nist.H2CP256, func(s *suite.Suite) []byte { return s.Hash(testMsg, testDST) }),
+		},
+		{
+			name:     "hash-to-curve with the NU identifier",
+			endpoint: "/v1/hash-to-curve",
+			body:     body(nist.E2CP256),
+			want:     expected(t, nist.H2CP256, func(s *suite.Suite) []byte { return s.Hash(testMsg, testDST) }),
+		},
+		{
+			name:     "encode-to-curve",
+			endpoint: "/v1/encode-to-curve",
+			body:     body(nist.H2CP256),
+			want:     expected(t, nist.E2CP256, func(s *suite.Suite) []byte { return s.Hash(testMsg, testDST) }),
+		},
+		{
+			name:     "hash-to-scalar",
+			endpoint: "/v1/hash-to-scalar",
+			body:     body(nist.H2CP256),
+			want: expected(t, nist.H2CP256, func(s *suite.Suite) []byte {
+				return s.HashToScalar(testMsg, testDST)
+			}),
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			resp, err := http.Post(ts.URL+test.endpoint, "application/json", strings.NewReader(test.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if resp.StatusCode != http.StatusOK {
+				t.Fatalf("unexpected status %d", resp.StatusCode)
+			}
+
+			var r response
+			if decodeResponse(t, resp, &r); r.Output != test.want || r.Error != "" {
+				t.Fatalf("want %q, got %+v", test.want, r)
+			}
+		})
+	}
+}
+
+func TestHashing_Errors(t *testing.T) {
+	ts := newTestServer(t)
+	dst := hex.EncodeToString(testDST)
+
+	for _, test := range []struct {
+		name, body string
+		status     int
+	}{
+		{name: "malformed json", body: `{"suite": `, status: http.StatusBadRequest},
+		{name: "unknown field", body: `{"suite": "` + nist.H2CP256 + `", "key": ""}`, status: http.StatusBadRequest},
+		{
+			name: "body too large",
+			body: `{"suite": "` + nist.H2CP256 + `", "dst": "` + dst + `", "msg": "` +
+				strings.Repeat("00", testMaxBody) + `"}`,
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "unknown suite",
+			body:   `{"suite": "P257", "dst": "` + dst + `"}`,
+			status: http.StatusUnprocessableEntity,
+		},
+		{
+			name:   "invalid dst",
+			body:   `{"suite": "` + nist.H2CP256 + `", "dst": "zz"}`,
+			status: http.StatusUnprocessableEntity,
+		},
+		{
+			name:   "invalid msg",
+			body:   `{"suite": "` + nist.H2CP256 + `", "dst": "` + dst + `", "msg": "0"}`,
+			status: http.StatusUnprocessableEntity,
+		},
+		{name: "empty dst", body: `{"suite": "` + nist.H2CP256 + `"}`, status: http.StatusUnprocessableEntity},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			resp, err := http.Post(ts.URL+"/v1/hash-to-curve", "application/json", strings.NewReader(test.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if resp.StatusCode != test.status {
+				t.Fatalf("want status %d, got %d", test.status, resp.StatusCode)
+			}
+
+			var r response
+			if decodeResponse(t, resp, &r); r.Error == "" || r.Output != "" {
+				t.Fatalf("expected an error, got %+v", r)
+			}
+		})
+	}
+
+	// The hashing endpoints only accept POST.
+	resp, err := http.Get(ts.URL + "/v1/hash-to-curve")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Fatalf("want status %d, got %d", http.StatusMethodNotAllowed, resp.StatusCode)
+	}
+}
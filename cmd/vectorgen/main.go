@@ -0,0 +1,89 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+// Command vectorgen writes RFC 9380-format hash-to-curve test vectors for a supported suite.
+//
+// Usage:
+//
+//	vectorgen -suite <suite identifier> [-dst <dst>] [-out <file>] [message ...]
+//
+// Without messages, the messages of the RFC 9380 vectors are used. Without dst, the RFC 9380 test DST of the suite is
+// used. Use -list to print the supported suite identifiers.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/bytemare/hash2curve/vectorgen"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "vectorgen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("vectorgen", flag.ContinueOnError)
+	id := flags.String("suite", "", "suite identifier, e.g. P256_XMD:SHA-256_SSWU_RO_")
+	dst := flags.String("dst", "", "domain separation tag (default is the RFC 9380 test DST of the suite)")
+	out := flags.String("out", "", "output file (default is stdout)")
+	list := flags.Bool("list", false, "list the supported suites")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *list {
+		for _, s := range vectorgen.Suites() {
+			if _, err := fmt.Fprintln(stdout, s.ID); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	suite, err := vectorgen.Lookup(*id)
+	if err != nil {
+		return err
+	}
+
+	if *dst == "" {
+		*dst = vectorgen.DefaultDST(suite.ID)
+	}
+
+	messages := flags.Args()
+	if len(messages) == 0 {
+		messages = vectorgen.DefaultMessages
+	}
+
+	vectors := suite.Generate(*dst, messages...)
+
+	if *out == "" {
+		return vectors.Write(stdout)
+	}
+
+	f, err := os.Create(*out)
+	if err != nil {
+		return err
+	}
+
+	if err = vectors.Write(f); err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	return f.Close()
+}
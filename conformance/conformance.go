@@ -0,0 +1,297 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+// Package conformance checks hash-to-curve implementations against test vector files in the JSON format of RFC 9380,
+// for the hash_to_curve and encode_to_curve suites and for expand_message. It runs the same checks as the tests of
+// this module, so that implementations of other curves, described with a vectorgen.Suite, can prove their conformance
+// in the same way.
+package conformance
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/bytemare/hash2curve/vectorgen"
+)
+
+var (
+	// ErrMalformedVectors indicates a vector file that can't be decoded.
+	ErrMalformedVectors = errors.New("malformed test vectors")
+
+	// ErrSuiteMismatch indicates that the parameters of the vector file are not those of the suite under test.
+	ErrSuiteMismatch = errors.New("test vectors are for another suite")
+
+	// ErrNonConformant indicates that the implementation does not produce the expected values.
+	ErrNonConformant = errors.New("implementation does not match the test vectors")
+)
+
+// Failure reports a value of a test vector that the implementation does not reproduce.
+type Failure struct {
+	// Field is the name of the value in the vector file, e.g. "u[1]", "Q0.x", "P.y", or "uniform_bytes".
+	Field string
+
+	// Want is the expected value, as encoded in the vector file.
+	Want string
+
+	// Got is the value produced by the implementation.
+	Got string
+
+	// Vector is the index of the test vector in the file.
+	Vector int
+}
+
+// String returns a description of the failure.
+func (f Failure) String() string {
+	return fmt.Sprintf("vector %d: %s: want %s, got %s", f.Vector, f.Field, f.Want, f.Got)
+}
+
+// Report holds the results of a conformance run.
+type Report struct {
+	// Suite identifies the vector file, i.e. its ciphersuite, or its expander and hash function.
+	Suite string
+
+	// Failures lists the mismatching values, in the order of the vectors.
+	Failures []Failure
+
+	// Vectors is the number of test vectors checked.
+	Vectors int
+}
+
+// Passed returns whether all the vectors were reproduced.
+func (r *Report) Passed() bool {
+	return len(r.Failures) == 0
+}
+
+// Err returns nil if all the vectors were reproduced, and an error wrapping ErrNonConformant listing the failures
+// otherwise.
+func (r *Report) Err() error {
+	if r.Passed() {
+		return nil
+	}
+
+	failures := make([]string, len(r.Failures))
+	for i, f := range r.Failures {
+		failures[i] = f.String()
+	}
+
+	return fmt.Errorf("%w: %s: %d failures:\n\t%s", ErrNonConformant, r.Suite, len(r.Failures),
+		strings.Join(failures, "\n\t"))
+}
+
+func (r *Report) compare(vector int, field string, want string, got *big.Int) {
+	w, ok := new(big.Int).SetString(want, 0)
+	if !ok || got == nil || w.Cmp(got) != 0 {
+		g := "none"
+		if got != nil {
+			g = fmt.Sprintf("0x%x", got)
+		}
+
+		r.Failures = append(r.Failures, Failure{Vector: vector, Field: field, Want: want, Got: g})
+	}
+}
+
+func (r *Report) comparePoint(vector int, name string, want vectorgen.Point, x, y *big.Int) {
+	r.compare(vector, name+".x", want.X, x)
+	r.compare(vector, name+".y", want.Y, y)
+}
+
+// Run decodes the hash-to-curve vector file from r, and checks the suite against it, as Check.
+func Run(s *vectorgen.Suite, r io.Reader) (*Report, error) {
+	var v vectorgen.Vectors
+	if err := json.NewDecoder(r).Decode(&v); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrMalformedVectors, err)
+	}
+
+	return Check(s, &v)
+}
+
+// RunFile checks the suite against the hash-to-curve vector file at path, as Check.
+func RunFile(s *vectorgen.Suite, path string) (*Report, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = f.Close() }()
+
+	return Run(s, f)
+}
+
+// Check checks the suite against the hash-to-curve vectors: for each message, the field elements u of
+// s.HashToField, the points Q0 and Q1 (or Q for NU suites) of s.MapToCurve, and the point P of s.Encode. It returns
+// an error wrapping ErrSuiteMismatch if the parameters of the vectors, i.e. the ciphersuite, the field, the
+// expander, L, k, Z, the mapping, and the encoding variant, are not those of the suite, and an error wrapping
+// ErrMalformedVectors if they can't be decoded. Mismatching values are listed in the report, whose Err method returns
+// an error if there is any.
+func Check(s *vectorgen.Suite, v *vectorgen.Vectors) (*Report, error) {
+	if err := checkParameters(s, v); err != nil {
+		return nil, err
+	}
+
+	report := &Report{Suite: v.Ciphersuite, Vectors: len(v.Vectors)}
+	dst := []byte(v.Dst)
+
+	count := uint(1)
+	if s.RandomOracle {
+		count = 2
+	}
+
+	for i, vector := range v.Vectors {
+		if uint(len(vector.U)) != count {
+			return nil, fmt.Errorf("%w: vector %d has %d field elements, expected %d",
+				ErrMalformedVectors, i, len(vector.U), count)
+		}
+
+		msg := []byte(vector.Msg)
+		u := s.HashToField(msg, dst, count)
+
+		for j, e := range vector.U {
+			var got *big.Int
+			if j < len(u) {
+				got = u[j]
+			}
+
+			report.compare(i, "u["+strconv.Itoa(j)+"]", e, got)
+		}
+
+		q := []*vectorgen.Point{vector.Q0, vector.Q1}
+		names := []string{"Q0", "Q1"}
+
+		if !s.RandomOracle {
+			q, names = []*vectorgen.Point{vector.Q}, []string{"Q"}
+		}
+
+		for j, want := range q {
+			// The points of the maps are optional in vector files.
+			if want == nil || j >= len(u) {
+				continue
+			}
+
+			x, y := s.MapToCurve(u[j])
+			report.comparePoint(i, names[j], *want, x, y)
+		}
+
+		x, y := s.Encode(msg, dst)
+		report.comparePoint(i, "P", vector.P, x, y)
+	}
+
+	return report, nil
+}
+
+func checkParameters(s *vectorgen.Suite, v *vectorgen.Vectors) error {
+	mismatch := func(name string, want, got any) error {
+		return fmt.Errorf("%w: %s is %v, expected %v", ErrSuiteMismatch, name, got, want)
+	}
+
+	switch {
+	case v.Ciphersuite != s.ID:
+		return mismatch("ciphersuite", s.ID, v.Ciphersuite)
+	case v.RandomOracle != s.RandomOracle:
+		return mismatch("randomOracle", s.RandomOracle, v.RandomOracle)
+	case v.Map.Name != s.Map:
+		return mismatch("map", s.Map, v.Map.Name)
+	case v.Expand != s.Expand:
+		return mismatch("expand", s.Expand, v.Expand)
+	}
+
+	for _, p := range []struct {
+		want  *big.Int
+		name  string
+		value string
+	}{
+		{name: "field.m", value: v.Field.M, want: big.NewInt(1)},
+		{name: "field.p", value: v.Field.P, want: s.Field},
+		{name: "L", value: v.L, want: new(big.Int).SetUint64(uint64(s.L))},
+		{name: "k", value: v.K, want: new(big.Int).SetUint64(uint64(s.K))},
+		{name: "Z", value: v.Z, want: new(big.Int).Mod(s.Z, s.Field)},
+	} {
+		got, ok := new(big.Int).SetString(p.value, 0)
+		if !ok {
+			return fmt.Errorf("%w: invalid %s %q", ErrMalformedVectors, p.name, p.value)
+		}
+
+		if got.Cmp(p.want) != 0 {
+			return mismatch(p.name, p.want, got)
+		}
+	}
+
+	return nil
+}
+
+// ExpandFunc implements an expand_message function.
+type ExpandFunc func(input, dst []byte, length uint) []byte
+
+// ExpandVectors is a set of expand_message test vectors, as encoded in the RFC 9380 vector files.
+type ExpandVectors struct {
+	DST   string         `json:"DST"`
+	Hash  string         `json:"hash"`
+	Name  string         `json:"name"`
+	Tests []ExpandVector `json:"tests"`
+	K     int            `json:"k"`
+}
+
+// ExpandVector is a single expand_message test vector, with hex encoded values.
+type ExpandVector struct {
+	DSTPrime     string `json:"DST_prime"`
+	LenInBytes   string `json:"len_in_bytes"`
+	Msg          string `json:"msg"`
+	MsgPrime     string `json:"msg_prime"`
+	UniformBytes string `json:"uniform_bytes"`
+}
+
+// RunExpand decodes the expand_message vector file from r, and checks the expand function against it, as
+// CheckExpand.
+func RunExpand(expand ExpandFunc, r io.Reader) (*Report, error) {
+	var v ExpandVectors
+	if err := json.NewDecoder(r).Decode(&v); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrMalformedVectors, err)
+	}
+
+	return CheckExpand(expand, &v)
+}
+
+// CheckExpand checks that the expand function reproduces the uniform_bytes of each vector from the message and the
+// DST of the set. The function must implement the expander and the hash function named in the set, and is given the
+// original DST, which it must shorten if it is longer than 255 bytes. It returns an error wrapping ErrMalformedVectors
+// if the vectors can't be decoded.
+func CheckExpand(expand ExpandFunc, v *ExpandVectors) (*Report, error) {
+	report := &Report{Suite: v.Name + " " + v.Hash, Vectors: len(v.Tests)}
+	dst := []byte(v.DST)
+
+	for i, test := range v.Tests {
+		length, err := strconv.ParseUint(test.LenInBytes, 0, 16)
+		if err != nil {
+			return nil, fmt.Errorf("%w: vector %d: invalid len_in_bytes %q", ErrMalformedVectors, i, test.LenInBytes)
+		}
+
+		if _, err = hex.DecodeString(test.UniformBytes); err != nil {
+			return nil, fmt.Errorf("%w: vector %d: invalid uniform_bytes", ErrMalformedVectors, i)
+		}
+
+		got := hex.EncodeToString(expand([]byte(test.Msg), dst, uint(length)))
+		if got != strings.ToLower(test.UniformBytes) {
+			report.Failures = append(report.Failures, Failure{
+				Vector: i,
+				Field:  "uniform_bytes",
+				Want:   test.UniformBytes,
+				Got:    got,
+			})
+		}
+	}
+
+	return report, nil
+}
@@ -0,0 +1,212 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+// Package cose exports the points output by the hash-to-curve suites, and the scalars derived with them, as COSE_Key
+// structures (RFC 9052) in deterministically encoded CBOR (RFC 8949 section 4.2), as exchanged by WebAuthn and IoT
+// protocols: EC2 keys with the P-256, P-384, P-521, and secp256k1 (RFC 8812) curves, and OKP keys with the Ed25519
+// and X25519 curves for the edwards25519 and curve25519 suites (RFC 9053).
+//
+// ristretto255 has no registered COSE curve. The Ed448 and X448 curves are not supported, since this module doesn't
+// hash to the 448-bit curves.
+package cose
+
+import (
+	"fmt"
+
+	"github.com/bytemare/hash2curve"
+	"github.com/bytemare/hash2curve/group"
+	"github.com/bytemare/hash2curve/internal/pubkey"
+)
+
+// The COSE key types.
+const (
+	// KeyTypeOKP is the "kty" of the octet key pairs, i.e. the keys on edwards25519 and curve25519.
+	KeyTypeOKP = 1
+
+	// KeyTypeEC2 is the "kty" of the keys on Weierstrass curves.
+	KeyTypeEC2 = 2
+)
+
+// The COSE elliptic curve identifiers of the supported curves.
+const (
+	CurveP256      = 1
+	CurveP384      = 2
+	CurveP521      = 3
+	CurveX25519    = 4
+	CurveEd25519   = 6
+	CurveSecp256k1 = 8
+)
+
+// The COSE_Key labels.
+const (
+	labelKty = 1
+	labelCrv = -1
+	labelX   = -2
+	labelY   = -3
+	labelD   = -4
+)
+
+// ErrUnsupportedCurve indicates a curve or a group that has no COSE_Key representation.
+var ErrUnsupportedCurve = pubkey.ErrUnsupportedCurve
+
+var (
+	// ec2Curves maps the CURVE_IDs of the Weierstrass curves to their COSE curve identifier.
+	ec2Curves = map[string]int{
+		"P256":      CurveP256,
+		"P384":      CurveP384,
+		"P521":      CurveP521,
+		"secp256k1": CurveSecp256k1,
+	}
+
+	// okpCurves maps the CURVE_IDs of edwards25519 and curve25519 to their COSE curve identifier.
+	okpCurves = map[string]int{
+		"edwards25519": CurveEd25519,
+		"curve25519":   CurveX25519,
+	}
+)
+
+// Key is a COSE_Key. Y is nil for OKP keys, and D is nil for public keys.
+type Key struct {
+	X, Y, D []byte
+	Kty     int
+	Crv     int
+}
+
+// FromSuite returns the COSE_Key of the point in the encoding output by the suite, i.e. the compressed or
+// uncompressed SEC 1 encoding for the Weierstrass curves, and the 32-byte encoding for edwards25519 and curve25519. It
+// returns an error wrapping ErrUnsupportedCurve for the ristretto255 suites, hash2curve.ErrInvalidSuite if the suite is
+// invalid, and hash2curve.ErrInvalidPoint if the point is invalid or is the point at infinity.
+func FromSuite(s hash2curve.Suite, point []byte) (*Key, error) {
+	if !s.Available() {
+		return nil, fmt.Errorf("%w: %s", hash2curve.ErrInvalidSuite, s)
+	}
+
+	curve := s.SuiteID().Curve
+
+	x, y, err := pubkey.Coordinates(curve, point)
+	if err != nil {
+		return nil, err
+	}
+
+	if y == nil {
+		return &Key{Kty: KeyTypeOKP, Crv: okpCurves[curve], X: x}, nil
+	}
+
+	return &Key{Kty: KeyTypeEC2, Crv: ec2Curves[curve], X: x, Y: y}, nil
+}
+
+// PrivateKey returns the EC2 COSE_Key of the encoded scalar derived with the suite, e.g. by suite.Suite.HashToScalar,
+// with its public key. Only the Weierstrass curves are supported, since the OKP private keys are seeds rather than
+// scalars, and an error wrapping ErrUnsupportedCurve is returned otherwise. It returns an error wrapping
+// group.ErrInvalidScalar if the scalar is not canonical or is zero.
+func PrivateKey(s hash2curve.Suite, scalar []byte) (*Key, error) {
+	if !s.Available() {
+		return nil, fmt.Errorf("%w: %s", hash2curve.ErrInvalidSuite, s)
+	}
+
+	if _, ok := ec2Curves[s.SuiteID().Curve]; !ok {
+		return nil, fmt.Errorf("%w: %s private keys", ErrUnsupportedCurve, s.SuiteID().Curve)
+	}
+
+	g, err := group.ForSuite(s)
+	if err != nil {
+		return nil, err
+	}
+
+	d := g.NewScalar()
+	if err = d.Decode(scalar); err != nil {
+		return nil, err
+	}
+
+	if d.IsZero() {
+		return nil, fmt.Errorf("%w: zero", group.ErrInvalidScalar)
+	}
+
+	k, err := FromSuite(s, g.Base().Multiply(d).Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	k.D = append([]byte(nil), scalar...)
+
+	return k, nil
+}
+
+// Public returns the public key of k, i.e. k without its private key.
+func (k *Key) Public() *Key {
+	return &Key{Kty: k.Kty, Crv: k.Crv, X: k.X, Y: k.Y}
+}
+
+// Marshal returns the deterministic CBOR encoding of the COSE_Key, i.e. a map with the labels in the order 1 (kty),
+// -1 (crv), -2 (x), -3 (y), and -4 (d), omitting y and d if they are nil.
+func (k *Key) Marshal() []byte {
+	entries := 3
+	if k.Y != nil {
+		entries++
+	}
+
+	if k.D != nil {
+		entries++
+	}
+
+	out := appendHead(nil, majorMap, uint64(entries))
+	out = appendInt(appendInt(out, labelKty), k.Kty)
+	out = appendInt(appendInt(out, labelCrv), k.Crv)
+	out = appendBytes(appendInt(out, labelX), k.X)
+
+	if k.Y != nil {
+		out = appendBytes(appendInt(out, labelY), k.Y)
+	}
+
+	if k.D != nil {
+		out = appendBytes(appendInt(out, labelD), k.D)
+	}
+
+	return out
+}
+
+// The CBOR major types.
+const (
+	majorUnsigned = 0
+	majorNegative = 1
+	majorBytes    = 2
+	majorMap      = 5
+)
+
+// appendHead appends the CBOR head of the major type with the argument n in its shortest form.
+func appendHead(b []byte, major byte, n uint64) []byte {
+	m := major << 5
+
+	switch {
+	case n < 24:
+		return append(b, m|byte(n))
+	case n <= 0xff:
+		return append(b, m|24, byte(n))
+	case n <= 0xffff:
+		return append(b, m|25, byte(n>>8), byte(n))
+	case n <= 0xffffffff:
+		return append(b, m|26, byte(n>>24), byte(n>>16), byte(n>>8), byte(n))
+	default:
+		return append(b, m|27, byte(n>>56), byte(n>>48), byte(n>>40), byte(n>>32),
+			byte(n>>24), byte(n>>16), byte(n>>8), byte(n))
+	}
+}
+
+func appendInt(b []byte, v int) []byte {
+	if v < 0 {
+		return appendHead(b, majorNegative, uint64(-1-v))
+	}
+
+	return appendHead(b, majorUnsigned, uint64(v))
+}
+
+func appendBytes(b, v []byte) []byte {
+	return append(appendHead(b, majorBytes, uint64(len(v))), v...)
+}
@@ -0,0 +1,115 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+// Package dcrec converts the secp256k1 points of the hash-to-curve suites to and from the types of
+// github.com/decred/dcrd/dcrec/secp256k1/v4, which github.com/btcsuite/btcd/btcec/v2 aliases as btcec.PublicKey and
+// btcec.JacobianPoint, so that Bitcoin and Decred projects use them without re-encoding.
+package dcrec
+
+import (
+	"fmt"
+
+	secp "github.com/decred/dcrd/dcrec/secp256k1/v4"
+
+	"github.com/bytemare/hash2curve"
+	"github.com/bytemare/hash2curve/secp256k1"
+)
+
+const coordinateLength = 32
+
+var errIdentity = fmt.Errorf("%w: the point at infinity is not a public key", hash2curve.ErrInvalidPoint)
+
+// HashToPublicKey returns secp256k1.HashToCurve(input, dst) as a public key. It panics in the negligible case where
+// the output is the point at infinity.
+func HashToPublicKey(input, dst []byte) *secp.PublicKey {
+	return mustPublicKey(secp256k1.HashToCurve(input, dst))
+}
+
+// EncodeToPublicKey returns secp256k1.EncodeToCurve(input, dst) as a public key. It panics in the negligible case
+// where the output is the point at infinity.
+func EncodeToPublicKey(input, dst []byte) *secp.PublicKey {
+	return mustPublicKey(secp256k1.EncodeToCurve(input, dst))
+}
+
+func mustPublicKey(p *secp256k1.Point) *secp.PublicKey {
+	pk, err := PublicKey(p)
+	if err != nil {
+		panic(err)
+	}
+
+	return pk
+}
+
+// PublicKey returns p as a public key, or an error wrapping hash2curve.ErrInvalidPoint if p is the point at infinity.
+func PublicKey(p *secp256k1.Point) (*secp.PublicKey, error) {
+	if p.X.Sign() == 0 && p.Y.Sign() == 0 {
+		return nil, errIdentity
+	}
+
+	var x, y secp.FieldVal
+
+	setCoordinates(&x, &y, p)
+
+	return secp.NewPublicKey(&x, &y), nil
+}
+
+// JacobianPoint returns p in Jacobian coordinates, with Z = 1, or Z = 0 for the point at infinity.
+func JacobianPoint(p *secp256k1.Point) *secp.JacobianPoint {
+	var j secp.JacobianPoint
+
+	if p.X.Sign() == 0 && p.Y.Sign() == 0 {
+		return &j
+	}
+
+	setCoordinates(&j.X, &j.Y, p)
+	j.Z.SetInt(1)
+
+	return &j
+}
+
+// FromPublicKey returns the point of the public key.
+func FromPublicKey(pk *secp.PublicKey) *secp256k1.Point {
+	var p secp256k1.Point
+
+	p.X.Set(pk.X())
+	p.Y.Set(pk.Y())
+
+	return &p
+}
+
+// FromJacobianPoint returns the point of j, which is not modified, with the point at infinity as (0, 0).
+func FromJacobianPoint(j *secp.JacobianPoint) *secp256k1.Point {
+	var (
+		p      secp256k1.Point
+		affine secp.JacobianPoint
+	)
+
+	if j.Z.IsZero() {
+		return &p
+	}
+
+	affine.Set(j)
+	affine.ToAffine()
+
+	x, y := affine.X.Bytes(), affine.Y.Bytes()
+	p.X.SetBytes(x[:])
+	p.Y.SetBytes(y[:])
+
+	return &p
+}
+
+// setCoordinates sets x and y to the coordinates of p, which are below the field prime.
+func setCoordinates(x, y *secp.FieldVal, p *secp256k1.Point) {
+	var b [coordinateLength]byte
+
+	x.SetBytes((*[coordinateLength]byte)(p.X.FillBytes(b[:])))
+	y.SetBytes((*[coordinateLength]byte)(p.Y.FillBytes(b[:])))
+	clear(b[:])
+}
@@ -7,4 +7,8 @@
 // https://spdx.org/licenses/MIT.html
 
 // Package hash2curve Hashing to Elliptic Curves as specified in RFC 9380 (https://datatracker.ietf.org/doc/rfc9380).
+//
+// The HashToScalars functions of the subpackages derive count scalars from a single expansion of the input, e.g. to
+// derive several nonces or challenges from one transcript. Since expand_message binds its output to the requested
+// length, the first of them is HashToScalar(input, dst) only if count is 1.
 package hash2curve
@@ -0,0 +1,157 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+// Package e521 implements hashing to the E-521 Edwards curve x^2 + y^2 = 1 - 376014 * x^2 * y^2 over GF(2^521 - 1),
+// with Elligator 2 on its birationally equivalent Montgomery curve, for a security level of 256 bits. The suites follow
+// the construction of the RFC 9380 edwards25519 suites, but are not specified by RFC 9380.
+package e521
+
+import (
+	"crypto"
+	"math/big"
+
+	"github.com/bytemare/hash2curve"
+	"github.com/bytemare/hash2curve/internal/edwards"
+)
+
+const (
+	// H2C represents the hash-to-curve string identifier.
+	H2C = "E521_XMD:SHA-512_ELL2_RO_"
+
+	// E2C represents the encode-to-curve string identifier.
+	E2C = "E521_XMD:SHA-512_ELL2_NU_"
+
+	// EncodingLength is the length of the point encodings.
+	EncodingLength = 66
+
+	// securityLevel is the security level k, in bits.
+	securityLevel = 256
+
+	// log2Cofactor is the base 2 logarithm of the cofactor h_eff = 4.
+	log2Cofactor = 2
+)
+
+var suite = newSuite()
+
+func newSuite() *edwards.Suite {
+	prime := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 521), big.NewInt(1))
+	order, _ := new(big.Int).SetString(
+		"337554763258501705789107630418782636071904961214051226618635150085779108655765", 10)
+	order.Sub(new(big.Int).Lsh(big.NewInt(1), 519), order)
+
+	// The first non-square of RFC 9380's find_z_ell2 is -1, since p = 3 mod 4.
+	c := edwards.NewUntwistedCurve(prime, big.NewInt(-376014), big.NewInt(-1), log2Cofactor)
+
+	return edwards.NewSuite(c, order, crypto.SHA512, securityLevel)
+}
+
+type disallowEqual [0]func()
+
+// Point is a point on E-521, in affine Edwards coordinates.
+type Point struct {
+	_    disallowEqual
+	X, Y big.Int
+}
+
+func newPoint(x, y *big.Int) *Point {
+	p := &Point{}
+	p.X.Set(x)
+	p.Y.Set(y)
+
+	return p
+}
+
+// Bytes returns the EncodingLength bytes little-endian encoding of Y, with the parity of X in the most significant
+// bit, as for edwards25519 in RFC 8032.
+func (p *Point) Bytes() []byte {
+	return suite.EncodeEdwards(&p.X, &p.Y)
+}
+
+// SetBytes sets p to the point encoded as by Bytes, and returns p. It returns an error wrapping
+// hash2curve.ErrInvalidPoint if the encoding is invalid or not that of a point on the curve. The point may not be in
+// the prime-order subgroup.
+func (p *Point) SetBytes(b []byte) (*Point, error) {
+	x, y, err := suite.DecodeEdwards(b)
+	if err != nil {
+		return nil, err
+	}
+
+	p.X.Set(x)
+	p.Y.Set(y)
+
+	return p, nil
+}
+
+// Add sets p to p1 + p2, and returns p.
+func (p *Point) Add(p1, p2 *Point) *Point {
+	x, y := suite.Add(&p1.X, &p1.Y, &p2.X, &p2.Y)
+	p.X.Set(x)
+	p.Y.Set(y)
+
+	return p
+}
+
+// ClearCofactor sets p to [h_eff]q, e.g. to move the outputs of MapToCurve into the prime-order subgroup, and
+// returns p.
+func (p *Point) ClearCofactor(q *Point) *Point {
+	x, y := suite.ClearCofactor(&q.X, &q.Y)
+	p.X.Set(x)
+	p.Y.Set(y)
+
+	return p
+}
+
+// Equal returns whether p and q are the same point.
+func (p *Point) Equal(q *Point) bool {
+	return p.X.Cmp(&q.X) == 0 && p.Y.Cmp(&q.Y) == 0
+}
+
+// HashToCurve implements hash-to-curve mapping to E-521 of input with dst.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func HashToCurve(input, dst []byte) *Point {
+	return newPoint(suite.HashToCurve(input, dst))
+}
+
+// EncodeToCurve implements encode-to-curve mapping to E-521 of input with dst.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func EncodeToCurve(input, dst []byte) *Point {
+	return newPoint(suite.EncodeToCurve(input, dst))
+}
+
+// HashToScalar returns a safe mapping of the arbitrary input to a scalar for the prime-order group of E-521.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func HashToScalar(input, dst []byte) *big.Int {
+	return suite.HashToScalar(input, dst)
+}
+
+// MapToCurve implements Elligator 2 and the rational map to E-521, without clearing the cofactor. It panics with
+// hash2curve.ErrNonCanonical if fe is not a canonical field element.
+func MapToCurve(fe *big.Int) *Point {
+	if !suite.Field.IsCanonical(fe) {
+		panic(hash2curve.ErrNonCanonical)
+	}
+
+	return newPoint(suite.MapToCurve(fe))
+}
+
+// Order returns a copy of the order of the prime-order group of E-521, i.e. the modulus of HashToScalar.
+func Order() *big.Int {
+	return suite.Order()
+}
+
+// FieldPrime returns a copy of the prime of the field of E-521.
+func FieldPrime() *big.Int {
+	return suite.FieldPrime()
+}
+
+// Cofactor returns a copy of the cofactor of E-521, which clears the outputs of MapToCurve into the prime-order group.
+func Cofactor() *big.Int {
+	return suite.Cofactor()
+}
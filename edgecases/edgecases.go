@@ -0,0 +1,187 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package edgecases provides a corpus of adversarial and boundary test vectors for hash-to-curve (oversize DSTs,
+// boundary expansion lengths, non-canonical field elements, low-order and identity outputs), in a Wycheproof-like
+// format, so that downstream wrappers can run the same negative tests against their integration.
+package edgecases
+
+import (
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"path"
+)
+
+//go:embed vectors/*.json
+var files embed.FS
+
+// Result indicates the expected outcome of a test case.
+type Result string
+
+const (
+	// Valid indicates the operation must succeed and produce the expected output.
+	Valid Result = "valid"
+
+	// Invalid indicates the operation must fail, i.e. abort or return an error.
+	Invalid Result = "invalid"
+)
+
+// Operation names the function under test.
+type Operation string
+
+const (
+	// ExpandMessageXMD is expand_message_xmd, taking Hash, Msg, DST and Length, and expecting Output.
+	ExpandMessageXMD Operation = "expand_message_xmd"
+
+	// ExpandMessageXOF is expand_message_xof, taking Hash, Msg, DST and Length, and expecting Output.
+	ExpandMessageXOF Operation = "expand_message_xof"
+
+	// MapToCurve is map_to_curve without cofactor clearing, taking Suite and U, and expecting the compressed encoding
+	// of the point in Output.
+	MapToCurve Operation = "map_to_curve"
+)
+
+// Flags used in the corpus.
+const (
+	// FlagZeroLengthDST marks an empty DST.
+	FlagZeroLengthDST = "ZeroLengthDST"
+
+	// FlagShortDST marks a DST shorter than the recommended 16 bytes.
+	FlagShortDST = "ShortDST"
+
+	// FlagOversizeDST marks a DST longer than 255 bytes, requiring the H2C-OVERSIZE-DST- reduction.
+	FlagOversizeDST = "OversizeDST"
+
+	// FlagBoundaryLength marks an output length at a boundary of the expander.
+	FlagBoundaryLength = "BoundaryLength"
+
+	// FlagLengthTooLarge marks an output length exceeding the expander's limits.
+	FlagLengthTooLarge = "LengthTooLarge"
+
+	// FlagBlockBoundary marks a message length at a hash block boundary.
+	FlagBlockBoundary = "BlockBoundary"
+
+	// FlagExceptionalCase marks an input hitting the exceptional case of the mapping (e.g. u = 0).
+	FlagExceptionalCase = "ExceptionalCase"
+
+	// FlagNonCanonical marks a field element that is not reduced modulo p.
+	FlagNonCanonical = "NonCanonical"
+
+	// FlagIdentityAfterCofactorClearing marks a mapped point of low order, i.e. the identity after cofactor clearing.
+	FlagIdentityAfterCofactorClearing = "IdentityAfterCofactorClearing"
+)
+
+// Test is a single test case. Byte strings are hex encoded, and field elements are 0x-prefixed hex integers. For long
+// outputs, only the SHA-256 digest of the expected output is given in OutputSum.
+type Test struct {
+	Comment   string    `json:"comment"`
+	Operation Operation `json:"operation"`
+	Hash      string    `json:"hash,omitempty"`
+	Suite     string    `json:"suite,omitempty"`
+	Msg       string    `json:"msg,omitempty"`
+	DST       string    `json:"dst,omitempty"`
+	U         string    `json:"u,omitempty"`
+	Output    string    `json:"output,omitempty"`
+	OutputSum string    `json:"outputSha256,omitempty"`
+	Result    Result    `json:"result"`
+	Flags     []string  `json:"flags,omitempty"`
+	Length    uint      `json:"length,omitempty"`
+	ID        int       `json:"tcId"`
+}
+
+// MsgBytes returns the decoded message.
+func (t *Test) MsgBytes() ([]byte, error) {
+	return hex.DecodeString(t.Msg)
+}
+
+// DSTBytes returns the decoded DST.
+func (t *Test) DSTBytes() ([]byte, error) {
+	return hex.DecodeString(t.DST)
+}
+
+// OutputBytes returns the decoded expected output.
+func (t *Test) OutputBytes() ([]byte, error) {
+	return hex.DecodeString(t.Output)
+}
+
+// CheckOutput returns whether output matches the expected output or its digest.
+func (t *Test) CheckOutput(output []byte) bool {
+	if t.OutputSum != "" {
+		sum := sha256.Sum256(output)
+		return hex.EncodeToString(sum[:]) == t.OutputSum
+	}
+
+	return hex.EncodeToString(output) == t.Output
+}
+
+// FieldElement returns the decoded field element U.
+func (t *Test) FieldElement() (*big.Int, error) {
+	u, ok := new(big.Int).SetString(t.U, 0)
+	if !ok {
+		return nil, fmt.Errorf("invalid field element %q in test %d", t.U, t.ID)
+	}
+
+	return u, nil
+}
+
+// HasFlag returns whether the test case carries the flag.
+func (t *Test) HasFlag(flag string) bool {
+	for _, f := range t.Flags {
+		if f == flag {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Group is a set of test cases, as stored in a corpus file.
+type Group struct {
+	Name  string `json:"name"`
+	Tests []Test `json:"tests"`
+}
+
+// Load returns all the test groups of the corpus.
+func Load() ([]Group, error) {
+	entries, err := files.ReadDir("vectors")
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make([]Group, 0, len(entries))
+
+	for _, entry := range entries {
+		g, err := LoadFile(entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		groups = append(groups, *g)
+	}
+
+	return groups, nil
+}
+
+// LoadFile returns the test group stored in the named corpus file.
+func LoadFile(name string) (*Group, error) {
+	val, err := files.ReadFile(path.Join("vectors", name))
+	if err != nil {
+		return nil, err
+	}
+
+	var g Group
+	if err = json.Unmarshal(val, &g); err != nil {
+		return nil, fmt.Errorf("decoding %s: %w", name, err)
+	}
+
+	return &g, nil
+}
@@ -6,18 +6,22 @@
 // LICENSE file in the root directory of this source tree or at
 // https://spdx.org/licenses/MIT.html
 
+//go:build !nomathbig
+
 // Package edwards25519 implements RFC9380 for the edwards25519 group, and returns points and scalars in
 // filippo.io/edwards25519.
 package edwards25519
 
 import (
 	"crypto"
+	"fmt"
 	"math/big"
 
 	"filippo.io/edwards25519"
 	"filippo.io/edwards25519/field"
 
 	"github.com/bytemare/hash2curve"
+	"github.com/bytemare/hash2curve/internal/curve25519"
 )
 
 const (
@@ -28,51 +32,197 @@ const (
 	E2C = "edwards25519_XMD:SHA-512_ELL2_NU_"
 
 	canonicalEncodingLength = 32
+
+	// secLength is the length L of the field elements in hash_to_field.
+	secLength = 48
 )
 
 // HashToCurve implements hash-to-curve mapping to Edwards25519 of input with dst.
 // The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
 func HashToCurve(input, dst []byte) *edwards25519.Point {
-	u := hash2curve.HashToFieldXMD(crypto.SHA512, input, dst, 2, 1, 48, fieldPrime)
-	q0 := element(adjust(u[0].Bytes()))
-	q1 := element(adjust(u[1].Bytes()))
-	p0 := Elligator2Edwards(q0)
-	p1 := Elligator2Edwards(q1)
-	p0.Add(p0, p1)
-	p0.MultByCofactor(p0)
-
-	return p0
+	return HashToCurveInto(new(edwards25519.Point), nil, input, dst)
 }
 
 // EncodeToCurve implements encode-to-curve mapping to Edwards25519 of input with dst.
 // The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
 func EncodeToCurve(input, dst []byte) *edwards25519.Point {
-	q := hash2curve.HashToFieldXMD(crypto.SHA512, input, dst, 1, 1, 48, fieldPrime)
-	b := adjust(q[0].Bytes())
-	p0 := Elligator2Edwards(element(b))
-	p0.MultByCofactor(p0)
+	return EncodeToCurveInto(new(edwards25519.Point), nil, input, dst)
+}
+
+// TryHashToCurve is HashToCurve returning an error instead of panicking, e.g. when serving untrusted input: the errors
+// of hash2curve.ValidateDST and hash2curve.ValidateInput for input and dst.
+func TryHashToCurve(input, dst []byte) (*edwards25519.Point, error) {
+	if err := validateInput(input, dst, 2); err != nil {
+		return nil, err
+	}
+
+	return HashToCurve(input, dst), nil
+}
+
+// TryEncodeToCurve is EncodeToCurve returning an error instead of panicking, as TryHashToCurve does.
+func TryEncodeToCurve(input, dst []byte) (*edwards25519.Point, error) {
+	if err := validateInput(input, dst, 1); err != nil {
+		return nil, err
+	}
+
+	return EncodeToCurve(input, dst), nil
+}
+
+// validateInput returns the error hash_to_field with count elements would panic with for the input and dst.
+func validateInput(input, dst []byte, count uint) error {
+	if err := hash2curve.ValidateDST(dst, false); err != nil {
+		return err
+	}
+
+	return hash2curve.ValidateInput(input, count*secLength)
+}
+
+// HashToCurveInto sets p to HashToCurve(input, dst), and returns p. If encoding is not nil, the canonical encoding of
+// the point is written to it. Unlike HashToCurve, it does not allocate points, for high-throughput callers reusing p.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func HashToCurveInto(p *edwards25519.Point, encoding *[32]byte, input, dst []byte) *edwards25519.Point {
+	var u [2]field.Element
+
+	hashToField(u[:], input, dst)
+
+	return mapToCurveRO(p, encoding, &u)
+}
+
+// HashToCurveScratch is HashToCurveInto using the working memory of s, which must be a scratch for SHA-512, to
+// expand the input. It does not allocate, with the exceptions listed in hash2curve.Scratch.
+func HashToCurveScratch(
+	s *hash2curve.Scratch,
+	p *edwards25519.Point,
+	encoding *[32]byte,
+	input, dst []byte,
+) *edwards25519.Point {
+	var u [2]field.Element
+
+	hashToFieldScratch(s, u[:], input, dst)
+
+	return mapToCurveRO(p, encoding, &u)
+}
+
+// mapToCurveRO sets p to the sum of the mappings of u, cleared, and returns p.
+func mapToCurveRO(p *edwards25519.Point, encoding *[32]byte, u *[2]field.Element) *edwards25519.Point {
+	var q edwards25519.Point
+
+	curve25519.Elligator2Edwards(p, &u[0])
+	curve25519.Elligator2Edwards(&q, &u[1])
+	p.Add(p, &q)
+	p.MultByCofactor(p)
+	clear(u[:])
+
+	return writeEncoding(p, encoding)
+}
+
+// EncodeToCurveInto sets p to EncodeToCurve(input, dst), and returns p. If encoding is not nil, the canonical encoding
+// of the point is written to it. Unlike EncodeToCurve, it does not allocate points, for high-throughput callers
+// reusing p.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func EncodeToCurveInto(p *edwards25519.Point, encoding *[32]byte, input, dst []byte) *edwards25519.Point {
+	var u [1]field.Element
+
+	hashToField(u[:], input, dst)
+
+	return mapToCurveNU(p, encoding, &u[0])
+}
+
+// EncodeToCurveScratch is EncodeToCurveInto using the working memory of s, which must be a scratch for SHA-512, to
+// expand the input. It does not allocate, with the exceptions listed in hash2curve.Scratch.
+func EncodeToCurveScratch(
+	s *hash2curve.Scratch,
+	p *edwards25519.Point,
+	encoding *[32]byte,
+	input, dst []byte,
+) *edwards25519.Point {
+	var u [1]field.Element
+
+	hashToFieldScratch(s, u[:], input, dst)
+
+	return mapToCurveNU(p, encoding, &u[0])
+}
+
+// mapToCurveNU sets p to the mapping of u, cleared, and returns p.
+func mapToCurveNU(p *edwards25519.Point, encoding *[32]byte, u *field.Element) *edwards25519.Point {
+	curve25519.Elligator2Edwards(p, u)
+	p.MultByCofactor(p)
+	u.Zero()
+
+	return writeEncoding(p, encoding)
+}
+
+func writeEncoding(p *edwards25519.Point, encoding *[32]byte) *edwards25519.Point {
+	if encoding != nil {
+		copy(encoding[:], p.Bytes())
+	}
 
-	return p0
+	return p
 }
 
 // HashToScalar returns a safe mapping of the arbitrary input to a scalar for the Edwards25519 group.
 // The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
 func HashToScalar(input, dst []byte) *edwards25519.Scalar {
-	sc := hash2curve.HashToFieldXMD(crypto.SHA512, input, dst, 1, 1, 48, order)
-	b := adjust(sc[0].Bytes())
+	return HashToScalars(input, dst, 1)[0]
+}
 
-	s, err := edwards25519.NewScalar().SetCanonicalBytes(b)
-	if err != nil {
-		panic(err)
+// TryHashToScalar is HashToScalar returning an error instead of panicking, as TryHashToCurve does.
+func TryHashToScalar(input, dst []byte) (*edwards25519.Scalar, error) {
+	if err := validateInput(input, dst, 1); err != nil {
+		return nil, err
+	}
+
+	return HashToScalar(input, dst), nil
+}
+
+// HashToScalars returns count scalars for Edwards25519 from a single expansion of the input, see [hash2curve].
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func HashToScalars(input, dst []byte, count uint) []*edwards25519.Scalar {
+	sc := hash2curve.HashToFieldXMD(crypto.SHA512, input, dst, count, 1, secLength, order)
+	res := make([]*edwards25519.Scalar, count)
+
+	for i, e := range sc {
+		s, err := edwards25519.NewScalar().SetCanonicalBytes(adjust(e.Bytes()))
+		if err != nil {
+			panic(err)
+		}
+
+		res[i] = s
+	}
+
+	return res
+}
+
+// MapToCurve implements the map_to_curve function for Edwards25519, mapping the field element to a point of the curve,
+// without clearing the cofactor.
+func MapToCurve(fe *big.Int) *edwards25519.Point {
+	if fe.Sign() < 0 || fe.Cmp(fieldPrime) >= 0 {
+		panic(hash2curve.ErrNonCanonical)
 	}
 
-	return s
+	return Elligator2Edwards(element(adjust(fe.Bytes())))
+}
+
+// Order returns a copy of the order of the prime-order subgroup of edwards25519, i.e. the modulus of HashToScalar.
+func Order() *big.Int {
+	return new(big.Int).Set(order)
+}
+
+// FieldPrime returns a copy of the prime 2^255 - 19 of the field of edwards25519.
+func FieldPrime() *big.Int {
+	return new(big.Int).Set(fieldPrime)
+}
+
+// Cofactor returns the cofactor 8 of edwards25519, by which HashToCurve and EncodeToCurve multiply the mapped points.
+func Cofactor() *big.Int {
+	return big.NewInt(8)
 }
 
 var (
+	// orderBytes is the big-endian encoding of the prime order of the group.
 	orderBytes = []byte{
-		237, 211, 245, 92, 26, 99, 18, 88, 214, 156, 247, 162, 222, 249, 222, 20,
-		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 16,
+		16, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		20, 222, 249, 222, 162, 247, 156, 214, 88, 18, 99, 26, 92, 245, 211, 237,
 	}
 	order = new(big.Int).SetBytes(orderBytes)
 
@@ -84,27 +234,50 @@ var (
 		255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 237,
 	}
 	fieldPrime = new(big.Int).SetBytes(p25519)
-	a, _       = fe().SetBytes([]byte{
-		6, 109, 7, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-	})
-	invsqrtD, _ = fe().SetBytes([]byte{
-		6, 126, 69, 255, 170, 4, 110, 204, 130, 26, 125, 75, 209, 211, 161, 197,
-		126, 79, 252, 3, 220, 8, 123, 210, 187, 6, 160, 96, 244, 237, 38, 15,
-	})
-	minA   = fe().Negate(a)
-	zero   = fe().Zero()
-	one    = fe().One()
-	minOne = fe().Negate(one)
-	two    = fe().Add(one, one)
+	a          = curve25519.A
+	invsqrtD   = curve25519.InvSqrtD
+	zero       = fe().Zero()
 )
 
+// hashToField implements hash_to_field to the base field for len(u) elements, reducing the uniform bytes with field
+// arithmetic.
+func hashToField(u []field.Element, input, dst []byte) {
+	uniform := hash2curve.ExpandXMD(crypto.SHA512, input, dst, uint(len(u)*secLength))
+	defer hash2curve.Wipe(uniform)
+
+	for i := range u {
+		curve25519.WideElement(&u[i], uniform[i*secLength:(i+1)*secLength])
+	}
+}
+
+// hashToFieldScratch is hashToField, expanding the input with the working memory of s. It panics with
+// hash2curve.ErrUnsupportedHash if s is not a scratch for SHA-512.
+func hashToFieldScratch(s *hash2curve.Scratch, u []field.Element, input, dst []byte) {
+	if s.Hash() != crypto.SHA512 {
+		panic(fmt.Errorf("%w: the scratch must be for SHA-512", hash2curve.ErrUnsupportedHash))
+	}
+
+	var uniform [2 * secLength]byte
+	defer clear(uniform[:])
+
+	b := uniform[:len(u)*secLength]
+	hash2curve.ExpandXMDInto(s, b, input, dst)
+
+	for i := range u {
+		curve25519.WideElement(&u[i], b[i*secLength:(i+1)*secLength])
+	}
+}
+
 func fe() *field.Element {
 	return new(field.Element)
 }
 
 func element(input []byte) *field.Element {
-	e, err := new(field.Element).SetBytes(input)
-	if err != nil {
+	return setElement(new(field.Element), input)
+}
+
+func setElement(e *field.Element, input []byte) *field.Element {
+	if _, err := e.SetBytes(input); err != nil {
 		panic(err)
 	}
 
@@ -134,46 +307,13 @@ func reverse(b []byte) []byte {
 
 // Elligator2Edwards maps the field element to a point on Edwards25519.
 func Elligator2Edwards(e *field.Element) *edwards25519.Point {
-	u, v := Elligator2Montgomery(e)
-	x, y := MontgomeryToEdwards(u, v)
-
-	return AffineToEdwards(x, y)
+	return curve25519.Elligator2Edwards(new(edwards25519.Point), e)
 }
 
 // Elligator2Montgomery implements the Elligator2 mapping to Curve25519.
 func Elligator2Montgomery(e *field.Element) (x, y *field.Element) {
-	t1 := fe().Square(e)   // u^2
-	t1.Multiply(t1, two)   // t1 = 2u^2
-	e1 := t1.Equal(minOne) //
-	t1.Swap(zero, e1)      // if 2u^2 == -1, t1 = 0
-
-	x1 := fe().Add(t1, one) // t1 + 1
-	x1.Invert(x1)           // 1 / (t1 + 1)
-	x1.Multiply(x1, minA)   // x1 = -A / (t1 + 1).
-
-	gx1 := fe().Add(x1, a) // x1 + A
-	gx1.Multiply(gx1, x1)  // x1 * (x1 + A)
-	gx1.Add(gx1, one)      // x1 * (x1 + A) + 1
-	gx1.Multiply(gx1, x1)  // x1 * (x1 * (x1 + A) + 1)
-
-	x2 := fe().Negate(x1) // -x1
-	x2.Subtract(x2, a)    // -x2 - A
-
-	gx2 := fe().Multiply(t1, gx1) // t1 * gx1
-
-	root1, _isSquare := fe().SqrtRatio(gx1, one) // root1 = (+) sqrt(gx1)
-	negRoot1 := fe().Negate(root1)               // negRoot1 = (-) sqrt(gx1)
-	root2, _ := fe().SqrtRatio(gx2, one)         // root2 = (+) sqrt(gx2)
-
-	// if gx1 is square, set the point to (x1, -root1)
-	// if not, set the point to (x2, +root2)
-	if _isSquare == 1 {
-		x = x1
-		y = negRoot1 // set sgn0(y) == 1, i.e. negative
-	} else {
-		x = x2
-		y = root2 // set sgn0(y) == 0, i.e. positive
-	}
+	x, y = fe(), fe()
+	curve25519.Elligator2Montgomery(x, y, e)
 
 	return x, y
 }
@@ -181,31 +321,18 @@ func Elligator2Montgomery(e *field.Element) (x, y *field.Element) {
 // AffineToEdwards takes the affine coordinates of an Edwards25519 and returns a pointer to Point, represented in
 // extended projective coordinates.
 func AffineToEdwards(x, y *field.Element) *edwards25519.Point {
-	t := fe().Multiply(x, y)
-
-	p, err := new(edwards25519.Point).SetExtendedCoordinates(x, y, fe().One(), t)
-	if err != nil {
-		panic(err)
-	}
-
-	return p
+	return curve25519.AffineToEdwards(new(edwards25519.Point), x, y)
 }
 
 // MontgomeryToEdwards lifts a Curve25519 point to its Edwards25519 equivalent.
 func MontgomeryToEdwards(u, v *field.Element) (x, y *field.Element) {
-	x = fe().Invert(v)
-	x.Multiply(x, u)
-	x.Multiply(x, invsqrtD)
-
-	y = MontgomeryUToEdwardsY(u)
+	x, y = fe(), fe()
+	curve25519.MontgomeryToEdwards(x, y, u, v)
 
-	return
+	return x, y
 }
 
 // MontgomeryUToEdwardsY transforms a Curve25519 x (or u) coordinate to an Edwards25519 y coordinate.
 func MontgomeryUToEdwardsY(u *field.Element) *field.Element {
-	u1 := fe().Subtract(u, one)
-	u2 := fe().Add(u, one)
-
-	return u1.Multiply(u1, u2.Invert(u2))
+	return curve25519.MontgomeryUToEdwardsY(fe(), u)
 }
@@ -0,0 +1,87 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+package edwards25519
+
+import (
+	"filippo.io/edwards25519"
+	"filippo.io/edwards25519/field"
+)
+
+// Elligator2MontgomeryInverse returns the non-negative representative r such that Elligator2Montgomery(r) returns the
+// Curve25519 point (u, v), and 1 if it exists, or 0 otherwise. Roughly half of the points are representable.
+//
+// Elligator2Montgomery returns the point (x1, -sqrt(g(x1))) with x1 = -A / (1 + 2r^2) if g(x1) is square, and
+// (-x1 - A, sqrt(g(-x1 - A))) otherwise. Hence, if u = x1, r^2 = -(u + A) / 2u, and r^2 = -u / 2(u + A) if not.
+func Elligator2MontgomeryInverse(u, v *field.Element) (r *field.Element, ok int) {
+	// The points with v = 0 and u != 0 are only reached with gx1 = 0, which is square.
+	isX1 := v.IsNegative() | (v.Equal(zero) & (1 ^ u.Equal(zero)))
+
+	uPlusA := fe().Add(u, a)
+	num := fe().Select(uPlusA, u, isX1) // u + A if u = x1, u otherwise
+	den := fe().Select(u, uPlusA, isX1) // u if u = x1, u + A otherwise
+	den.Add(den, den)                   // 2u or 2(u + A)
+	num.Negate(num)                     //
+
+	return fe().SqrtRatio(num, den) // r = sqrt(-num / den), which is not square if den = 0
+}
+
+// edwardsToMontgomery returns the Curve25519 coordinates of the point, and 0 if p is the identity element, which has
+// no affine Montgomery equivalent, or 1 otherwise.
+func edwardsToMontgomery(p *edwards25519.Point) (u, v *field.Element, ok int) {
+	x, y, z, _ := p.ExtendedCoordinates()
+
+	// u = (1 + y) / (1 - y), and v = sqrt(-486664) * u / x, in projective coordinates.
+	zMinusY := fe().Subtract(z, y)
+	u = fe().Add(z, y)
+	u.Multiply(u, fe().Invert(zMinusY))
+
+	v = fe().Multiply(u, z)
+	v.Multiply(v, invsqrtD)
+	v.Multiply(v, fe().Invert(x))
+
+	return u, v, 1 ^ zMinusY.Equal(zero)
+}
+
+// IsRepresentable returns whether the point is an output of Elligator2Edwards, and thus has a representative.
+func IsRepresentable(p *edwards25519.Point) bool {
+	u, v, ok := edwardsToMontgomery(p)
+	if ok == 0 {
+		return false
+	}
+
+	_, ok = Elligator2MontgomeryInverse(u, v)
+
+	return ok == 1
+}
+
+// Representative returns a uniformly distributed 32-byte string that Elligator2Edwards maps to the point, and true if
+// the point is representable, or nil and false otherwise. This applies to the outputs of MapToCurve, before cofactor
+// clearing, e.g. ephemeral keys derived for censorship-resistant transports. tweak must be a uniformly random byte:
+// its lowest bit chooses between the two representatives r and -r, and its second bit fills the unused top bit of
+// the encoding, so that the output is indistinguishable from random bytes.
+func Representative(p *edwards25519.Point, tweak byte) ([]byte, bool) {
+	u, v, ok := edwardsToMontgomery(p)
+	if ok == 0 {
+		return nil, false
+	}
+
+	r, ok := Elligator2MontgomeryInverse(u, v)
+	if ok == 0 {
+		return nil, false
+	}
+
+	r.Select(fe().Negate(r), r, int(tweak&1))
+
+	out := r.Bytes()
+	out[canonicalEncodingLength-1] |= (tweak & 2) << 6
+
+	return out, true
+}
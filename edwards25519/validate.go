@@ -0,0 +1,65 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+package edwards25519
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"filippo.io/edwards25519"
+
+	"github.com/bytemare/hash2curve"
+)
+
+var (
+	errNonCanonicalPoint = errors.New("non-canonical point encoding")
+	errIdentity          = errors.New("the point is the identity")
+	errNotInSubgroup     = errors.New("the point is not in the prime-order subgroup")
+
+	// orderMinusOne is the canonical encoding of the scalar l - 1, with l the order of the prime-order subgroup.
+	orderMinusOne = []byte{
+		0xec, 0xd3, 0xf5, 0x5c, 0x1a, 0x63, 0x12, 0x58, 0xd6, 0x9c, 0xf7, 0xa2, 0xde, 0xf9, 0xde, 0x14,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x10,
+	}
+)
+
+// Validate returns an error wrapping hash2curve.ErrInvalidPoint if the encoding is not the canonical 32-byte encoding
+// of a point on the curve, if the point is not in the prime-order subgroup, or if it is the identity. Points from
+// HashToCurve and EncodeToCurve always pass, but points received from peers may have a torsion component.
+func Validate(encoded []byte) error {
+	p, err := new(edwards25519.Point).SetBytes(encoded)
+	if err != nil {
+		return fmt.Errorf("%w: %w", hash2curve.ErrInvalidPoint, err)
+	}
+
+	// SetBytes accepts the non-canonical encodings of y and of the sign of x = 0.
+	if !bytes.Equal(p.Bytes(), encoded) {
+		return fmt.Errorf("%w: %w", hash2curve.ErrInvalidPoint, errNonCanonicalPoint)
+	}
+
+	if p.Equal(edwards25519.NewIdentityPoint()) == 1 {
+		return fmt.Errorf("%w: %w", hash2curve.ErrInvalidPoint, errIdentity)
+	}
+
+	// p is in the subgroup of order l if and only if [l - 1]p + p is the identity.
+	s, err := edwards25519.NewScalar().SetCanonicalBytes(orderMinusOne)
+	if err != nil {
+		panic(err)
+	}
+
+	q := new(edwards25519.Point).ScalarMult(s, p)
+	if q.Add(q, p).Equal(edwards25519.NewIdentityPoint()) != 1 {
+		return fmt.Errorf("%w: %w", hash2curve.ErrInvalidPoint, errNotInSubgroup)
+	}
+
+	return nil
+}
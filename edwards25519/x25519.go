@@ -0,0 +1,33 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+package edwards25519
+
+const (
+	// H2C25519 represents the hash-to-curve string identifier for curve25519.
+	H2C25519 = "curve25519_XMD:SHA-512_ELL2_RO_"
+
+	// E2C25519 represents the encode-to-curve string identifier for curve25519.
+	E2C25519 = "curve25519_XMD:SHA-512_ELL2_NU_"
+)
+
+// HashToCurve25519 implements hash-to-curve mapping to Curve25519 of input with dst, and returns the u-coordinate of
+// the point in the 32-byte little-endian encoding used by X25519, e.g. as the base point of a hashed Diffie-Hellman.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func HashToCurve25519(input, dst []byte) []byte {
+	return HashToCurve(input, dst).BytesMontgomery()
+}
+
+// EncodeToCurve25519 implements encode-to-curve mapping to Curve25519 of input with dst, and returns the u-coordinate
+// of the point in the 32-byte little-endian encoding used by X25519.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func EncodeToCurve25519(input, dst []byte) []byte {
+	return EncodeToCurve(input, dst).BytesMontgomery()
+}
@@ -0,0 +1,93 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+// Package edwards448 implements hash-to-scalar for the prime-order group of edwards448, which Ed448 (RFC 8032) and
+// decaf448 (RFC 9496) share, as hash_to_field of RFC 9380 with the parameters of the edwards448_XOF:SHAKE256 suites,
+// i.e. expand_message_xof with SHAKE256 and L = 84 bytes read as big-endian integers. It is not the HashToScalar of
+// OPRF(decaf448, SHAKE-256) in RFC 9497, which reduces 64 bytes of expand_message_xof read as a little-endian integer,
+// and yields other scalars. The curve arithmetic and the mappings to the curve are not implemented.
+package edwards448
+
+import (
+	"math/big"
+
+	"github.com/bytemare/hash"
+
+	"github.com/bytemare/hash2curve"
+	"github.com/bytemare/hash2curve/internal/field"
+)
+
+const (
+	// ScalarLength is the length of the canonical little-endian encoding of the scalars of decaf448. Ed448 encodes them
+	// on 57 bytes, i.e. with a trailing zero byte.
+	ScalarLength = 56
+
+	// secLength is the length L = ceil((446 + 224) / 8) of the scalars in hash_to_field, for the 224-bit security
+	// level of the group.
+	secLength = 84
+)
+
+var (
+	// order is the prime order 2^446 - 13818066809895115352007386748515426880336692474882178609894547503885 of the
+	// group.
+	order, _ = new(big.Int).SetString("3fffffffffffffffffffffffffffffffffffffffffffffffffffffff"+
+		"7cca23e9c44edb49aed63690216cc2728dc58f552378c292ab5844f3", 16)
+
+	// reducer reduces the uniform strings of hash_to_field modulo the order with constant-time corrections.
+	reducer = field.NewReducer(order, secLength)
+)
+
+// HashToScalar returns a safe mapping of the arbitrary input to a scalar for the prime-order group of edwards448, i.e.
+// hash_to_field with expand_message_xof and SHAKE256, and L = 84 bytes, reduced modulo the order with Barrett
+// reduction and constant-time corrections.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func HashToScalar(input, dst []byte) *big.Int {
+	return HashToScalars(input, dst, 1)[0]
+}
+
+// HashToScalars returns count scalars for edwards448 from a single expansion of the input, see [hash2curve].
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func HashToScalars(input, dst []byte, count uint) []*big.Int {
+	if err := hash2curve.ValidateHashToField(count, 1, secLength, order); err != nil {
+		panic(err)
+	}
+
+	uniform := hash2curve.ExpandXOF(hash2curve.ExtendableXOF(hash.SHAKE256.GetXOF()), input, dst, count*secLength)
+	defer hash2curve.Wipe(uniform)
+
+	res := make([]*big.Int, count)
+	for i := range res {
+		res[i] = reducer.Reduce(uniform[i*secLength : (i+1)*secLength])
+	}
+
+	return res
+}
+
+// HashToScalarBytes is HashToScalar returning the canonical little-endian encoding of the scalar, as decaf448
+// serializes scalars.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func HashToScalarBytes(input, dst []byte) [ScalarLength]byte {
+	var out [ScalarLength]byte
+
+	s := HashToScalar(input, dst)
+	s.FillBytes(out[:])
+	hash2curve.WipeInts(s)
+
+	for i := range ScalarLength / 2 {
+		out[i], out[ScalarLength-1-i] = out[ScalarLength-1-i], out[i]
+	}
+
+	return out
+}
+
+// Order returns a copy of the order of the prime-order group of edwards448, i.e. the modulus of HashToScalar.
+func Order() *big.Int {
+	return new(big.Int).Set(order)
+}
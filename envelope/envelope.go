@@ -0,0 +1,175 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+// Package envelope implements a self-describing serialization of hashed points, bundling the point encoding with the
+// identifier of the suite and the SHA-256 hash of the DST that produced it, so that systems storing or transmitting
+// derived points can later verify they were produced under the expected suite and domain. The encoding is
+//
+//	version (1 byte) || len(suite) (1 byte) || suite || SHA-256(DST) (32 bytes) || len(point) (2 bytes) || point
+//
+// with the lengths in big-endian. The envelope does not authenticate the point: it records its context, and a point
+// must still be recomputed from its input to be trusted.
+package envelope
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/bytemare/hash2curve"
+	"github.com/bytemare/hash2curve/suite"
+)
+
+// Version is the version of the encoding.
+const Version = 1
+
+// DSTHashLength is the length of the hash of the DST.
+const DSTHashLength = sha256.Size
+
+var (
+	// ErrInvalidEnvelope indicates a malformed encoding, or an envelope whose fields can't be encoded.
+	ErrInvalidEnvelope = errors.New("invalid envelope")
+
+	// ErrMismatch indicates an envelope produced under another suite or DST than the expected ones.
+	ErrMismatch = errors.New("envelope mismatch")
+)
+
+// Envelope is a point with the context it was hashed in.
+type Envelope struct {
+	// Suite is the identifier of the suite, e.g. "P256_XMD:SHA-256_SSWU_RO_".
+	Suite string
+
+	// Point is the encoding of the point output by the suite.
+	Point []byte
+
+	// DSTHash is the SHA-256 hash of the DST.
+	DSTHash [DSTHashLength]byte
+}
+
+// New returns the envelope of the point produced by the suite identified by suiteID with dst.
+func New(suiteID string, dst, point []byte) *Envelope {
+	return &Envelope{
+		Suite:   suiteID,
+		Point:   append([]byte(nil), point...),
+		DSTHash: sha256.Sum256(dst),
+	}
+}
+
+// Hash returns the envelope of s.Hash(input, dst).
+func Hash(s *suite.Suite, input, dst []byte) *Envelope {
+	return New(s.ID(), dst, s.Hash(input, dst))
+}
+
+// Verify returns nil if the envelope was produced under the suite identified by suiteID with dst, and an error
+// wrapping ErrMismatch otherwise.
+func (e *Envelope) Verify(suiteID string, dst []byte) error {
+	if e.Suite != suiteID {
+		return fmt.Errorf("%w: expected suite %q, got %q", ErrMismatch, suiteID, e.Suite)
+	}
+
+	if h := sha256.Sum256(dst); subtle.ConstantTimeCompare(h[:], e.DSTHash[:]) != 1 {
+		return fmt.Errorf("%w: the DST differs", ErrMismatch)
+	}
+
+	return nil
+}
+
+// Marshal returns the encoding of the envelope, or an error wrapping ErrInvalidEnvelope if the suite identifier is
+// invalid, or if the point is empty or longer than 65535 bytes.
+func Marshal(e *Envelope) ([]byte, error) {
+	return e.MarshalBinary()
+}
+
+// Unmarshal decodes an envelope, and returns an error wrapping ErrInvalidEnvelope if the encoding is malformed.
+func Unmarshal(data []byte) (*Envelope, error) {
+	e := new(Envelope)
+	if err := e.UnmarshalBinary(data); err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler, as Marshal.
+func (e *Envelope) MarshalBinary() ([]byte, error) {
+	if err := validate(e.Suite, e.Point); err != nil {
+		return nil, err
+	}
+
+	b := make([]byte, 0, 2+len(e.Suite)+DSTHashLength+2+len(e.Point))
+	b = append(b, Version, byte(len(e.Suite)))
+	b = append(b, e.Suite...)
+	b = append(b, e.DSTHash[:]...)
+	b = binary.BigEndian.AppendUint16(b, uint16(len(e.Point)))
+
+	return append(b, e.Point...), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler, as Unmarshal.
+func (e *Envelope) UnmarshalBinary(data []byte) error {
+	if len(data) < 2 {
+		return fmt.Errorf("%w: too short", ErrInvalidEnvelope)
+	}
+
+	if data[0] != Version {
+		return fmt.Errorf("%w: unsupported version %d", ErrInvalidEnvelope, data[0])
+	}
+
+	suiteLength := int(data[1])
+	data = data[2:]
+
+	if len(data) < suiteLength+DSTHashLength+2 {
+		return fmt.Errorf("%w: too short", ErrInvalidEnvelope)
+	}
+
+	id := string(data[:suiteLength])
+	data = data[suiteLength:]
+
+	var dstHash [DSTHashLength]byte
+
+	copy(dstHash[:], data)
+	data = data[DSTHashLength:]
+
+	pointLength := int(binary.BigEndian.Uint16(data))
+	data = data[2:]
+
+	if len(data) != pointLength {
+		return fmt.Errorf("%w: the point length is %d, with %d bytes left", ErrInvalidEnvelope, pointLength, len(data))
+	}
+
+	if err := validate(id, data); err != nil {
+		return err
+	}
+
+	e.Suite = id
+	e.DSTHash = dstHash
+	e.Point = append([]byte(nil), data...)
+
+	return nil
+}
+
+func validate(id string, point []byte) error {
+	if _, err := hash2curve.ValidateSuiteID(id); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidEnvelope, err)
+	}
+
+	if len(id) > math.MaxUint8 {
+		return fmt.Errorf("%w: the suite identifier is longer than 255 bytes", ErrInvalidEnvelope)
+	}
+
+	if len(point) == 0 || len(point) > math.MaxUint16 {
+		return fmt.Errorf("%w: the point must be 1 to 65535 bytes long", ErrInvalidEnvelope)
+	}
+
+	return nil
+}
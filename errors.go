@@ -0,0 +1,61 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package hash2curve
+
+import (
+	"errors"
+
+	"github.com/bytemare/hash2curve/internal"
+)
+
+// The errors reported by this module, either returned or as panic values. Subpackages wrap them when adding context,
+// so they can be identified with errors.Is.
+var (
+	// ErrZeroLengthDST indicates an empty or nil domain separation tag.
+	ErrZeroLengthDST = internal.ErrZeroLengthDST
+
+	// ErrShortDST indicates a domain separation tag shorter than the 16 bytes recommended by RFC 9380, when the strict
+	// DST policy is enabled.
+	ErrShortDST = internal.ErrShortDST
+
+	// ErrLengthTooLarge indicates a requested expansion length beyond what the expander supports.
+	ErrLengthTooLarge = internal.ErrLengthTooLarge
+
+	// ErrInputTooLong indicates an input message longer than the configured limit.
+	ErrInputTooLong = internal.ErrInputTooLong
+
+	// ErrDSTHashTooLong indicates that the hash function can't shorten an oversize DST to at most 255 bytes.
+	ErrDSTHashTooLong = internal.ErrDSTHashTooLong
+
+	// ErrUnsupportedHash indicates a hash function that is neither a fixed length hash function nor an extendable
+	// output function, or that is not available.
+	ErrUnsupportedHash = internal.ErrUnsupportedHash
+
+	// ErrInsufficientSecurity indicates a hash function or a hash_to_field length L that does not meet the target
+	// security level of a curve.
+	ErrInsufficientSecurity = internal.ErrInsufficientSecurity
+
+	// ErrInvalidParameters indicates invalid hash_to_field parameters.
+	ErrInvalidParameters = errors.New("invalid hash_to_field parameters")
+
+	// ErrInvalidSuite indicates an unknown, unsupported, or malformed suite.
+	ErrInvalidSuite = internal.ErrInvalidSuite
+
+	// ErrNonCanonical indicates a field element that is not in [0, p-1].
+	ErrNonCanonical = errors.New("field element is not canonical")
+
+	// ErrInvalidPoint indicates a point that could not be built or is not on the curve.
+	ErrInvalidPoint = internal.ErrInvalidPoint
+
+	// ErrRandomness indicates a failure of the random number generator used for blinding.
+	ErrRandomness = internal.ErrRandomness
+
+	// ErrSelfTest indicates that a known-answer test of the power-on self-test failed.
+	ErrSelfTest = errors.New("self-test failed")
+)
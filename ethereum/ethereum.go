@@ -0,0 +1,95 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+// Package ethereum converts the secp256k1 and BN254 points of the hash-to-curve suites to the types and layouts of the
+// crypto packages of go-ethereum, without depending on it:
+//   - secp256k1 points as *ecdsa.PublicKey, accepted by crypto.FromECDSAPub and crypto.PubkeyToAddress,
+//   - the 64-byte public keys x || y of devp2p node IDs, which crypto.UnmarshalPubkey reads once prefixed with 0x04,
+//   - the 20-byte addresses, convertible to common.Address,
+//   - BN254 G1 points in the 64-byte layout of bn256.G1.Unmarshal and of the EIP-196 precompiles.
+package ethereum
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+
+	secp "github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"golang.org/x/crypto/sha3"
+
+	"github.com/bytemare/hash2curve"
+	"github.com/bytemare/hash2curve/bn254"
+	"github.com/bytemare/hash2curve/secp256k1"
+)
+
+const (
+	// PublicKeyLength is the length of the public keys x || y.
+	PublicKeyLength = 64
+
+	// AddressLength is the length of the addresses.
+	AddressLength = 20
+
+	// G1Length is the length of the encoding of a BN254 G1 point.
+	G1Length = 64
+)
+
+var errIdentity = fmt.Errorf("%w: the point at infinity is not a public key", hash2curve.ErrInvalidPoint)
+
+// PublicKey returns p as an ECDSA public key on secp256k1, or an error wrapping hash2curve.ErrInvalidPoint if p is the
+// point at infinity. Its curve is that of github.com/decred/dcrd/dcrec/secp256k1/v4, as with the pure Go build of
+// go-ethereum, whose functions only read the coordinates.
+func PublicKey(p *secp256k1.Point) (*ecdsa.PublicKey, error) {
+	if isIdentity(p) {
+		return nil, errIdentity
+	}
+
+	return &ecdsa.PublicKey{
+		Curve: secp.S256(),
+		X:     new(big.Int).Set(&p.X),
+		Y:     new(big.Int).Set(&p.Y),
+	}, nil
+}
+
+// PublicKeyBytes returns the 64-byte public key x || y of p, i.e. crypto.FromECDSAPub without its 0x04 prefix, or an
+// error wrapping hash2curve.ErrInvalidPoint if p is the point at infinity.
+func PublicKeyBytes(p *secp256k1.Point) ([]byte, error) {
+	if isIdentity(p) {
+		return nil, errIdentity
+	}
+
+	return p.BytesXY(), nil
+}
+
+// Address returns the address of the public key p, i.e. the last 20 bytes of the Keccak-256 of its 64-byte encoding,
+// as crypto.PubkeyToAddress computes it, or an error wrapping hash2curve.ErrInvalidPoint if p is the point at
+// infinity.
+func Address(p *secp256k1.Point) ([AddressLength]byte, error) {
+	var address [AddressLength]byte
+
+	if isIdentity(p) {
+		return address, errIdentity
+	}
+
+	h := sha3.NewLegacyKeccak256()
+	_, _ = h.Write(p.BytesXY())
+	copy(address[:], h.Sum(nil)[32-AddressLength:])
+
+	return address, nil
+}
+
+// G1Bytes returns the 64-byte encoding x || y of the BN254 G1 point p, which bn256.G1.Unmarshal and the EIP-196
+// precompiles read, with 64 zero bytes for the point at infinity.
+func G1Bytes(p *bn254.Point) []byte {
+	return p.Bytes()
+}
+
+func isIdentity(p *secp256k1.Point) bool {
+	return p.X.Sign() == 0 && p.Y.Sign() == 0
+}
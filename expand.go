@@ -9,41 +9,266 @@
 package hash2curve
 
 import (
+	"context"
 	"crypto"
-	"errors"
+	"io"
+	"math"
+	"slices"
+	"time"
 
 	"github.com/bytemare/hash"
+	"golang.org/x/crypto/hkdf"
+	"golang.org/x/crypto/sha3"
 
 	"github.com/bytemare/hash2curve/internal"
 )
 
-const (
-	minLength            = 0
-	recommendedMinLength = 16
-)
+// ValidateDST returns ErrZeroLengthDST if dst is empty, and ErrShortDST if strict is set and dst is shorter than the 16
+// bytes recommended by RFC 9380. suite.WithStrictDST sets the strict DST policy of a suite.
+func ValidateDST(dst []byte, strict bool) error {
+	return internal.ValidateDST(dst, strict)
+}
 
-var errZeroLenDST = errors.New("zero-length DST")
+// SetInputLimits sets module-wide caps on the length of input messages and on the number of bytes expanded per call,
+// i.e. the count * m * L product of hash_to_field, to bound the resources used on untrusted input. A zero value
+// disables a limit, which is the default. It is safe for concurrent use, but is meant to be set once at program
+// initialization. Over the limits, the functions returning no error panic with ErrInputTooLong or ErrLengthTooLarge,
+// while the error-returning entry points return them: TryExpandXMD, TryExpandXOF, the Try functions of the curve
+// packages, the Try methods of suite.Suite, and the nobig package. ValidateInput returns them to check the input
+// beforehand.
+func SetInputLimits(maxInput, maxExpand uint) {
+	internal.SetInputLimits(maxInput, maxExpand)
+}
+
+// ValidateInput returns ErrInputTooLong if the input is longer than the module-wide limit, and an error wrapping
+// ErrLengthTooLarge if expanding length bytes is over the module-wide limit.
+func ValidateInput(input []byte, length uint) error {
+	return internal.ValidateInput(input, length)
+}
 
 func checkDST(dst []byte) {
-	if len(dst) < recommendedMinLength {
-		if len(dst) == minLength {
-			panic(errZeroLenDST)
-		}
+	if err := ValidateDST(dst, false); err != nil {
+		panic(err)
 	}
 }
 
+func checkInput(input, dst []byte, length uint) {
+	if err := validateInput(input, dst, length); err != nil {
+		panic(err)
+	}
+}
+
+// validateInput returns the error of ValidateDST for dst, or of ValidateInput for input and length.
+func validateInput(input, dst []byte, length uint) error {
+	if err := ValidateDST(dst, false); err != nil {
+		return err
+	}
+
+	return ValidateInput(input, length)
+}
+
 // ExpandXMD expands the input and dst using the given fixed length hash function.
-// - dst MUST be non-nil, longer than 0 and lower than 256. It's recommended that DST at least 16 bytes long.
+// - dst MUST be non-nil, longer than 0 and lower than 256. It's recommended that DST at least 16 bytes long,
+// which ValidateDST checks with strict set.
 // - length must be a positive integer lower than 255 * (size of digest).
 func ExpandXMD(id crypto.Hash, input, dst []byte, length uint) []byte {
-	checkDST(dst)
+	checkInput(input, dst, length)
 	return internal.ExpandXMD(id, input, dst, length)
 }
 
-// ExpandXOF expands the input and dst using the given extendable output hash function.
-// - dst MUST be non-nil and its length longer than 0. It's recommended that DST at least 16 bytes long.
-// - length must be a positive integer higher than 32.
-func ExpandXOF(ext *hash.ExtendableHash, input, dst []byte, length uint) []byte {
+// TryExpandXMD is ExpandXMD returning an error instead of panicking on invalid input, e.g. when serving untrusted
+// input: the errors of ValidateDST and ValidateInput, or ErrLengthTooLarge if length is larger than 255 times the
+// digest size or 65535.
+func TryExpandXMD(id crypto.Hash, input, dst []byte, length uint) ([]byte, error) {
+	if err := validateInput(input, dst, length); err != nil {
+		return nil, err
+	}
+
+	if length > 255*uint(id.Size()) || length > math.MaxUint16 {
+		return nil, ErrLengthTooLarge
+	}
+
+	return internal.ExpandXMD(id, input, dst, length), nil
+}
+
+// ExpandXMDKeccak256 is ExpandXMD with the legacy Keccak-256 of Ethereum, which is not a crypto.Hash and differs from
+// the standardized SHA3-256 by its padding. RFC 9380 does not define suites with Keccak-256, but Ethereum contracts use
+// it in expand_message_xmd, as it is the hash function the EVM provides. The requirements of ExpandXMD apply.
+func ExpandXMDKeccak256(input, dst []byte, length uint) []byte {
+	checkInput(input, dst, length)
+	return internal.ExpandXMDHash("KECCAK-256", sha3.NewLegacyKeccak256(), input, dst, length)
+}
+
+// ReduceDSTXMD returns the tag expand_message_xmd with the hash function uses for dst, as RFC 9380 section 5.3.3
+// prescribes: dst itself if it is at most 255 bytes long, and H("H2C-OVERSIZE-DST-" || dst) otherwise. Protocols
+// adopting the DST conventions of RFC 9380 use it to compute the same tags, e.g. when wrapping expand_message_xmd
+// themselves. It panics with ErrDSTHashTooLong if the digest of the hash function is longer than 255 bytes.
+func ReduceDSTXMD(id crypto.Hash, dst []byte) []byte {
+	return internal.VetDSTXMD(id.New(), dst)
+}
+
+// DSTPrime returns DST_prime = DST || I2OSP(len(DST), 1) of RFC 9380 section 5.3, in a new buffer. It panics with
+// ErrLengthTooLarge if dst is longer than 255 bytes, which must first be reduced with ReduceDSTXMD or ReduceDSTXOF.
+func DSTPrime(dst []byte) []byte {
+	if len(dst) > math.MaxUint8 {
+		panic(ErrLengthTooLarge)
+	}
+
+	return internal.DstPrime(dst)
+}
+
+// MsgPrimeXMD returns msg_prime = Z_pad || msg || I2OSP(length, 2) || I2OSP(0, 1) || DST_prime, the input of the first
+// hash of expand_message_xmd with the hash function, as in RFC 9380 section 5.3.1. dst is used as is, and must
+// already be reduced if it is longer than 255 bytes. It panics with ErrLengthTooLarge if length or dst is too long.
+func MsgPrimeXMD(id crypto.Hash, input, dst []byte, length uint) []byte {
+	dstPrime := DSTPrime(dst)
+	lib := i2osp2(length)
+	zPad := make([]byte, id.New().BlockSize())
+
+	return slices.Concat(zPad, input, lib, []byte{0}, dstPrime)
+}
+
+// MsgPrimeXOF returns msg_prime = msg || I2OSP(length, 2) || DST_prime, the input of the extendable output function
+// in expand_message_xof, as in RFC 9380 section 5.3.2. dst is used as is, and must already be reduced if it is longer
+// than 255 bytes. It panics with ErrLengthTooLarge if length or dst is too long.
+func MsgPrimeXOF(input, dst []byte, length uint) []byte {
+	dstPrime := DSTPrime(dst)
+	return slices.Concat(input, i2osp2(length), dstPrime)
+}
+
+// i2osp2 returns I2OSP(length, 2), or panics with ErrLengthTooLarge if length does not fit.
+func i2osp2(length uint) []byte {
+	if length > math.MaxUint16 {
+		panic(ErrLengthTooLarge)
+	}
+
+	return internal.I2OSP(length, 2)
+}
+
+// ExpandXMDBatch returns ExpandXMD(id, input, dst, length) for each of the inputs, sharing the processing of the DST
+// and the hash state across the batch, e.g. for the batch evaluation of many messages with the same DST.
+// The requirements of ExpandXMD apply to dst, length, and each input.
+func ExpandXMDBatch(id crypto.Hash, inputs [][]byte, dst []byte, length uint) [][]byte {
+	checkBatch(inputs, dst, length)
+	return internal.PrepareXMD(id, dst).ExpandBatch(inputs, length)
+}
+
+// ExpandXMDBatchContext is ExpandXMDBatch checking ctx before each input, so that long batches stop once ctx is
+// cancelled or its deadline is exceeded, e.g. when serving an RPC. It then returns ctx.Err() and no outputs. As with
+// ExpandXMDBatch, the inputs are validated beforehand, and it panics if they are invalid.
+func ExpandXMDBatchContext(
+	ctx context.Context,
+	id crypto.Hash,
+	inputs [][]byte,
+	dst []byte,
+	length uint,
+) ([][]byte, error) {
+	checkBatch(inputs, dst, length)
+	return internal.PrepareXMD(id, dst).ExpandBatchContext(ctx, inputs, length)
+}
+
+func checkBatch(inputs [][]byte, dst []byte, length uint) {
 	checkDST(dst)
+
+	for _, input := range inputs {
+		if err := ValidateInput(input, length); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// ExpandMessage expands the input and dst with expand_message_xmd if h is a fixed length hash function, and with
+// expand_message_xof if h is an extendable output function, as RFC 9380 prescribes for each. It panics with
+// ErrUnsupportedHash if h is neither, e.g. if it is not linked into the binary. The requirements of ExpandXMD and
+// ExpandXOF apply.
+func ExpandMessage(h hash.Hash, input, dst []byte, length uint) []byte {
+	switch h.Type() {
+	case hash.FixedOutputLength:
+		return ExpandXMD(crypto.Hash(h), input, dst, length)
+	case hash.ExtendableOutputFunction:
+		return ExpandXOF(ExtendableXOF(h.GetXOF()), input, dst, length)
+	default:
+		panic(ErrUnsupportedHash)
+	}
+}
+
+// XOFState is the state of an extendable output function, as accepted by ExpandXOF, whose output is read as an
+// io.Reader. It is satisfied by sha3.ShakeHash from golang.org/x/crypto/sha3 and *sha3.SHAKE from crypto/sha3, and
+// ExtendableXOF adapts a *hash.ExtendableHash from github.com/bytemare/hash. Fixed-output hash functions, e.g.
+// SHA3-256, don't satisfy it, and are rejected with ErrUnsupportedHash if their state can be read anyway.
+//
+// Shortening a DST longer than 255 bytes requires the security level of the function, which is read from a
+// SecurityLevel() int method if any, and otherwise inferred from the block size of SHAKE128 and SHAKE256.
+type XOFState interface {
+	io.Writer
+	io.Reader
+	Reset()
+}
+
+// ExtendableXOF returns the XOFState of ext, e.g. ExtendableXOF(hash.SHAKE256.GetXOF()).
+func ExtendableXOF(ext *hash.ExtendableHash) XOFState {
+	return internal.ExtendableXOF{ExtendableHash: ext}
+}
+
+// ExpandXOF expands the input and dst using the given extendable output hash function. The state of ext is reset
+// before use. It panics with ErrUnsupportedHash if the output of ext can't be read, or if the DST must be shortened
+// and the security level of ext is unknown.
+// - dst MUST be non-nil and its length longer than 0. It's recommended that DST at least 16 bytes long,
+// which ValidateDST checks with strict set.
+// - length must be a positive integer higher than 32.
+func ExpandXOF(ext XOFState, input, dst []byte, length uint) []byte {
+	checkInput(input, dst, length)
 	return internal.ExpandXOF(ext, input, dst, length)
 }
+
+// TryExpandXOF is ExpandXOF returning an error instead of panicking on invalid input, as TryExpandXMD does, with
+// ErrLengthTooLarge if length is larger than 65535. It still panics with ErrUnsupportedHash, which depends on ext and
+// not on the input.
+func TryExpandXOF(ext XOFState, input, dst []byte, length uint) ([]byte, error) {
+	if err := validateInput(input, dst, length); err != nil {
+		return nil, err
+	}
+
+	if length > math.MaxUint16 {
+		return nil, ErrLengthTooLarge
+	}
+
+	return internal.ExpandXOF(ext, input, dst, length), nil
+}
+
+// ExpandHKDF returns length bytes of HKDF-Expand(HKDF-Extract(salt = dst, IKM = input), info, length) of RFC 5869
+// with the hash function, as some protocols specified before RFC 9380 expanded their inputs.
+//
+// Warning: this is not expand_message. Its outputs differ from those of ExpandXMD and ExpandXOF, it is not
+// domain-separated from other uses of HKDF with the same salt, and RFC 9380 does not analyze it. It is only meant for
+// migrations that must reproduce legacy outputs before switching to ExpandXMD.
+//
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes. It panics with ErrLengthTooLarge if
+// length is larger than 255 times the digest size.
+func ExpandHKDF(id crypto.Hash, input, dst, info []byte, length uint) []byte {
+	checkInput(input, dst, length)
+
+	if length > 255*uint(id.Size()) {
+		panic(ErrLengthTooLarge)
+	}
+
+	if observe := internal.ObserveExpand(); observe != nil {
+		defer observe("HKDF:"+id.String(), 1, length, time.Now())
+	}
+
+	out := make([]byte, length)
+	if _, err := io.ReadFull(hkdf.New(id.New, input, dst, info), out); err != nil {
+		panic(err)
+	}
+
+	return out
+}
+
+// ReduceDSTXOF returns the tag expand_message_xof with ext uses for dst, as RFC 9380 section 5.3.3 prescribes: dst
+// itself if it is at most 255 bytes long, and otherwise the ceil(2 * k / 8) bytes of output of ext for
+// "H2C-OVERSIZE-DST-" || dst, with k the security level of ext. The state of ext is reset before use. It panics with
+// ErrUnsupportedHash if the DST must be shortened and the security level of ext is unknown, as ExpandXOF does, and
+// with ErrInsufficientSecurity if the output size of a *hash.ExtendableHash is shorter than 2 * k bits.
+func ReduceDSTXOF(ext XOFState, dst []byte) []byte {
+	return internal.VetXofDST(ext, dst)
+}
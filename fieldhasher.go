@@ -0,0 +1,74 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+package hash2curve
+
+import (
+	"crypto"
+	"math/big"
+
+	"github.com/bytemare/hash2curve/internal/field"
+)
+
+// FieldHasher is HashToFieldXMD with fixed parameters, holding the storage of the elements, the uniform bytes, and the
+// reduction, which it reuses across calls so that hashing to the field does not allocate once it is built, with the
+// exceptions listed in Scratch. It reduces with a precomputed Barrett reducer instead of a division. A FieldHasher must
+// not be used concurrently, i.e. use one per goroutine.
+type FieldHasher struct {
+	scratch        *Scratch
+	reducer        *field.Reducer
+	reduction      *field.ReduceScratch
+	uniform        []byte
+	elements       []*big.Int
+	securityLength uint
+}
+
+// NewFieldHasher returns a FieldHasher for HashToFieldXMD with id, count, ext, securityLength, and modulo. It panics if
+// the parameters are invalid, as reported by ValidateHashToField, or with ErrUnsupportedHash if id is not available.
+func NewFieldHasher(id crypto.Hash, count, ext, securityLength uint, modulo *big.Int) *FieldHasher {
+	checkHashToField(count, ext, securityLength, modulo)
+
+	f := &FieldHasher{
+		scratch:        NewScratch(id),
+		reducer:        field.NewReducer(modulo, securityLength),
+		uniform:        make([]byte, count*ext*securityLength),
+		elements:       make([]*big.Int, count*ext),
+		securityLength: securityLength,
+	}
+	f.reduction = f.reducer.NewScratch()
+
+	// Reducing the widest input once sizes the words of the integers, which the next reductions then reuse.
+	wide := make([]byte, securityLength)
+	for i := range wide {
+		wide[i] = 0xff
+	}
+
+	for i := range f.elements {
+		f.elements[i] = f.reducer.ReduceInto(new(big.Int).SetBytes(wide), wide, f.reduction)
+		f.elements[i].SetInt64(0)
+	}
+
+	return f
+}
+
+// HashToField returns HashToFieldXMD(id, input, dst, count, ext, securityLength, modulo) with the parameters of f. The
+// elements are owned by f, and are overwritten by the next call: the caller must copy them to keep them, and must not
+// modify them.
+func (f *FieldHasher) HashToField(input, dst []byte) []*big.Int {
+	ExpandXMDInto(f.scratch, f.uniform, input, dst)
+	defer Wipe(f.uniform)
+
+	for i, e := range f.elements {
+		offset := uint(i) * f.securityLength
+		f.reducer.ReduceInto(e, f.uniform[offset:offset+f.securityLength], f.reduction)
+	}
+
+	return f.elements
+}
@@ -0,0 +1,468 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package genfield generates constant-time Go implementations of prime fields, with saturated 64-bit limbs in
+// Montgomery form, so that new curves don't have to rely on math/big for their field arithmetic.
+package genfield
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"go/token"
+	"math/big"
+	"text/template"
+)
+
+// maxLimbs bounds the size of the supported primes to 576 bits.
+const maxLimbs = 9
+
+var (
+	errPrime   = errors.New("the modulus must be an odd prime larger than 3")
+	errSize    = fmt.Errorf("the modulus must be at most %d bits long", 64*maxLimbs)
+	errPackage = errors.New("invalid package name")
+)
+
+// Config holds the parameters of a generated field.
+type Config struct {
+	// Prime is the modulus of the field.
+	Prime *big.Int
+
+	// Package is the name of the generated package.
+	Package string
+
+	// Command is the command line recorded in the generated file, to regenerate it.
+	Command string
+}
+
+// Generate returns the gofmt-ed source code of a package implementing the field. The field element type is Element,
+// with the same API as the internal fixed-limb fields of this module. Sqrt is only generated for p = 3 mod 4. The
+// conversions from and to big.Int are in the file of GenerateBig, so that the package doesn't depend on math/big when
+// built with the nomathbig build tag.
+func Generate(c Config) ([]byte, error) {
+	return generate(fieldTemplate, c)
+}
+
+// GenerateBig returns the gofmt-ed source code of the conversions of the field of Generate from and to big.Int, and of
+// its Modulus, in a separate file excluded by the nomathbig build tag.
+func GenerateBig(c Config) ([]byte, error) {
+	return generate(bigTemplate, c)
+}
+
+func generate(t *template.Template, c Config) ([]byte, error) {
+	if c.Prime == nil || c.Prime.Cmp(big.NewInt(3)) <= 0 || c.Prime.Bit(0) == 0 || !c.Prime.ProbablyPrime(32) {
+		return nil, errPrime
+	}
+
+	if !token.IsIdentifier(c.Package) {
+		return nil, errPackage
+	}
+
+	limbs := (c.Prime.BitLen() + 63) / 64
+	if limbs > maxLimbs {
+		return nil, errSize
+	}
+
+	p := c.Prime
+	r := new(big.Int).Lsh(big.NewInt(1), uint(64*limbs))
+	mod64 := new(big.Int).Lsh(big.NewInt(1), 64)
+
+	// pInv = -p^-1 mod 2^64, for Montgomery reduction.
+	pInv := new(big.Int).ModInverse(new(big.Int).Mod(p, mod64), mod64)
+	pInv.Sub(mod64, pInv)
+
+	data := map[string]any{
+		"Package": c.Package,
+		"Command": c.Command,
+		"Prime":   fmt.Sprintf("%#x", p),
+		"Limbs":   limbs,
+		"ByteLen": (p.BitLen() + 7) / 8,
+		"P":       toLimbs(p, limbs),
+		"PInv":    fmt.Sprintf("0x%016x", pInv),
+		"R2":      toLimbs(new(big.Int).Mod(new(big.Int).Mul(r, r), p), limbs),
+		"One":     toLimbs(new(big.Int).Mod(r, p), limbs),
+		"PMinus2": toLimbs(new(big.Int).Sub(p, big.NewInt(2)), limbs),
+		"HasSqrt": p.Bit(1) == 1,
+		"SqrtExp": toLimbs(new(big.Int).Rsh(new(big.Int).Add(p, big.NewInt(1)), 2), limbs),
+	}
+
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+
+	return format.Source(buf.Bytes())
+}
+
+// toLimbs returns the little-endian 64-bit limbs of x as Go hexadecimal literals.
+func toLimbs(x *big.Int, n int) []string {
+	limbs := make([]string, n)
+	mask := new(big.Int).SetUint64(^uint64(0))
+
+	for i := range n {
+		limbs[i] = fmt.Sprintf("0x%016x", new(big.Int).And(new(big.Int).Rsh(x, uint(64*i)), mask).Uint64())
+	}
+
+	return limbs
+}
+
+var fieldTemplate = template.Must(template.New("field").Parse(`// Code generated by genfield. DO NOT EDIT.
+{{- if .Command}}
+// {{.Command}}
+{{- end}}
+
+// Package {{.Package}} implements constant-time arithmetic with saturated 64-bit limbs in Montgomery form, modulo
+// {{.Prime}}.
+package {{.Package}}
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+const (
+	// ElementLength is the length of the big-endian encoding of a field element.
+	ElementLength = {{.ByteLen}}
+
+	limbs = {{.Limbs}}
+
+	// pInv is -p^-1 mod 2^64.
+	pInv = {{.PInv}}
+)
+
+var (
+	// p is the field order.
+	p = [limbs]uint64{ {{- range .P}}{{.}}, {{end -}} }
+
+	// r2 is R^2 mod p, with R = 2^(64 * limbs), to convert into the Montgomery domain.
+	r2 = Element{l: [limbs]uint64{ {{- range .R2}}{{.}}, {{end -}} }}
+
+	// one is 1 in the Montgomery domain, i.e. R mod p.
+	one = Element{l: [limbs]uint64{ {{- range .One}}{{.}}, {{end -}} }}
+
+	// pMinus2 is the exponent for inversion.
+	pMinus2 = [limbs]uint64{ {{- range .PMinus2}}{{.}}, {{end -}} }
+{{- if .HasSqrt}}
+
+	// sqrtExp is the exponent (p + 1) / 4 for square roots.
+	sqrtExp = [limbs]uint64{ {{- range .SqrtExp}}{{.}}, {{end -}} }
+{{- end}}
+)
+
+// Element is a field element, in the Montgomery domain and always reduced. The zero value is zero.
+type Element struct {
+	l [limbs]uint64
+}
+
+// Zero sets e to 0 and returns e.
+func (e *Element) Zero() *Element {
+	*e = Element{}
+	return e
+}
+
+// One sets e to 1 and returns e.
+func (e *Element) One() *Element {
+	*e = one
+	return e
+}
+
+// Set sets e to x and returns e.
+func (e *Element) Set(x *Element) *Element {
+	*e = *x
+	return e
+}
+
+// SetBytes sets e to the big-endian encoding in b, and returns e and whether the encoding was canonical. If it was
+// not, e is set to the reduced value.
+func (e *Element) SetBytes(b *[ElementLength]byte) (*Element, bool) {
+	var buf [8 * limbs]byte
+
+	copy(buf[8*limbs-ElementLength:], b[:])
+
+	var x Element
+	for i := range limbs {
+		x.l[i] = binary.BigEndian.Uint64(buf[8*(limbs-1-i):])
+	}
+
+	_, borrow := sub(&x.l, &p)
+
+	// x < 2^(64 * limbs), so x * R^2 < p * R and the Montgomery reduction yields x * R mod p.
+	e.Multiply(&x, &r2)
+
+	return e, borrow == 1
+}
+
+// Bytes returns the big-endian canonical encoding of e.
+func (e *Element) Bytes() [ElementLength]byte {
+	var x Element
+
+	x.l[0] = 1
+	x.Multiply(e, &x)
+
+	var buf [8 * limbs]byte
+	for i := range limbs {
+		binary.BigEndian.PutUint64(buf[8*(limbs-1-i):], x.l[i])
+	}
+
+	var out [ElementLength]byte
+
+	copy(out[:], buf[8*limbs-ElementLength:])
+
+	return out
+}
+
+// SetWideBytes sets e to the big-endian integer in b reduced modulo p, e.g. the uniform bytes of hash_to_field, and
+// returns e. b can have any length, which is not hidden, unlike its value.
+func (e *Element) SetWideBytes(b []byte) *Element {
+	var (
+		chunk    [ElementLength]byte
+		x, shift Element
+	)
+
+	defer clear(chunk[:])
+
+	e.Zero()
+
+	// Chunks of ElementLength - 1 bytes are canonical, since p is longer than 8 * (ElementLength - 1) bits.
+	for len(b) > 0 {
+		n := min(len(b), ElementLength-1)
+
+		clear(chunk[:])
+		chunk[ElementLength-1-n] = 1
+		shift.SetBytes(&chunk) // 2^(8 * n)
+
+		chunk[ElementLength-1-n] = 0
+		copy(chunk[ElementLength-n:], b[:n])
+		x.SetBytes(&chunk)
+
+		e.Multiply(e, &shift)
+		e.Add(e, &x)
+		b = b[n:]
+	}
+
+	return e
+}
+
+// IsZero returns 1 if e == 0, and 0 otherwise.
+func (e *Element) IsZero() int {
+	var acc uint64
+	for _, l := range e.l {
+		acc |= l
+	}
+
+	return int(1 ^ (acc|-acc)>>63)
+}
+
+// Equal returns 1 if e == x, and 0 otherwise.
+func (e *Element) Equal(x *Element) int {
+	var acc uint64
+	for i := range limbs {
+		acc |= e.l[i] ^ x.l[i]
+	}
+
+	return int(1 ^ (acc|-acc)>>63)
+}
+
+// Sgn0 returns the parity of the canonical value of e, as sgn0 in RFC 9380.
+func (e *Element) Sgn0() int {
+	b := e.Bytes()
+	return int(b[ElementLength-1] & 1)
+}
+
+// Select sets e to a if cond == 1, and to b if cond == 0, and returns e.
+func (e *Element) Select(a, b *Element, cond int) *Element {
+	mask := -uint64(cond & 1)
+	for i := range limbs {
+		e.l[i] = (a.l[i] & mask) | (b.l[i] &^ mask)
+	}
+
+	return e
+}
+
+func sub(a, b *[limbs]uint64) ([limbs]uint64, uint64) {
+	var (
+		r      [limbs]uint64
+		borrow uint64
+	)
+
+	for i := range limbs {
+		r[i], borrow = bits.Sub64(a[i], b[i], borrow)
+	}
+
+	return r, borrow
+}
+
+// reduce subtracts p from x if x, with the extra carry bit, is not lower than p, and stores the result in e.
+func (e *Element) reduce(x *[limbs]uint64, carry uint64) {
+	r, borrow := sub(x, &p)
+
+	// Keep x if it is lower than p, i.e. if there is a borrow and no carry.
+	mask := -(borrow &^ carry)
+	for i := range limbs {
+		e.l[i] = (x[i] & mask) | (r[i] &^ mask)
+	}
+}
+
+// Add sets e to x + y and returns e.
+func (e *Element) Add(x, y *Element) *Element {
+	var (
+		s     [limbs]uint64
+		carry uint64
+	)
+
+	for i := range limbs {
+		s[i], carry = bits.Add64(x.l[i], y.l[i], carry)
+	}
+
+	e.reduce(&s, carry)
+
+	return e
+}
+
+// Subtract sets e to x - y and returns e.
+func (e *Element) Subtract(x, y *Element) *Element {
+	d, borrow := sub(&x.l, &y.l)
+
+	// Add p back if the subtraction underflowed.
+	mask := -borrow
+
+	var carry uint64
+	for i := range limbs {
+		e.l[i], carry = bits.Add64(d[i], p[i]&mask, carry)
+	}
+
+	return e
+}
+
+// Negate sets e to -x and returns e.
+func (e *Element) Negate(x *Element) *Element {
+	var zero Element
+	return e.Subtract(&zero, x)
+}
+
+// Multiply sets e to x * y and returns e, with the CIOS Montgomery multiplication.
+func (e *Element) Multiply(x, y *Element) *Element {
+	var t [limbs + 2]uint64
+
+	for i := range limbs {
+		var c, hi, lo, carry uint64
+
+		for j := range limbs {
+			hi, lo = bits.Mul64(x.l[j], y.l[i])
+			lo, carry = bits.Add64(lo, t[j], 0)
+			hi += carry
+			lo, carry = bits.Add64(lo, c, 0)
+			hi += carry
+			t[j], c = lo, hi
+		}
+
+		t[limbs], carry = bits.Add64(t[limbs], c, 0)
+		t[limbs+1] = carry
+
+		m := t[0] * pInv
+		hi, lo = bits.Mul64(m, p[0])
+		_, carry = bits.Add64(lo, t[0], 0)
+		c = hi + carry
+
+		for j := 1; j < limbs; j++ {
+			hi, lo = bits.Mul64(m, p[j])
+			lo, carry = bits.Add64(lo, t[j], 0)
+			hi += carry
+			lo, carry = bits.Add64(lo, c, 0)
+			hi += carry
+			t[j-1], c = lo, hi
+		}
+
+		t[limbs-1], carry = bits.Add64(t[limbs], c, 0)
+		t[limbs] = t[limbs+1] + carry
+	}
+
+	var r [limbs]uint64
+
+	copy(r[:], t[:limbs])
+	e.reduce(&r, t[limbs])
+
+	return e
+}
+
+// Square sets e to x^2 and returns e.
+func (e *Element) Square(x *Element) *Element {
+	return e.Multiply(x, x)
+}
+
+// pow sets e to x^k and returns e. The exponent k is public.
+func (e *Element) pow(x *Element, k *[limbs]uint64) *Element {
+	var r, b Element
+
+	r.One()
+	b.Set(x)
+
+	for i := limbs*64 - 1; i >= 0; i-- {
+		r.Square(&r)
+
+		if (k[i/64]>>(i%64))&1 == 1 {
+			r.Multiply(&r, &b)
+		}
+	}
+
+	return e.Set(&r)
+}
+
+// Invert sets e to 1/x and returns e. If x == 0, e is set to 0.
+func (e *Element) Invert(x *Element) *Element {
+	return e.pow(x, &pMinus2)
+}
+{{- if .HasSqrt}}
+
+// Sqrt sets e to a square root of x, and returns 1 if x is a square and 0 otherwise.
+func (e *Element) Sqrt(x *Element) int {
+	var r, check Element
+
+	r.pow(x, &sqrtExp)
+	check.Square(&r)
+	e.Set(&r)
+
+	return check.Equal(x)
+}
+{{- end}}
+`))
+
+var bigTemplate = template.Must(template.New("big").Parse(`// Code generated by genfield. DO NOT EDIT.
+{{- if .Command}}
+// {{.Command}}
+{{- end}}
+
+//go:build !nomathbig
+
+package {{.Package}}
+
+import "math/big"
+
+// SetBig sets e to x mod p and returns e. This is not constant-time.
+func (e *Element) SetBig(x *big.Int) *Element {
+	var b [ElementLength]byte
+
+	new(big.Int).Mod(x, Modulus()).FillBytes(b[:])
+	e.SetBytes(&b)
+
+	return e
+}
+
+// Big returns e as a big.Int.
+func (e *Element) Big() *big.Int {
+	b := e.Bytes()
+	return new(big.Int).SetBytes(b[:])
+}
+
+// Modulus returns the field order.
+func Modulus() *big.Int {
+	m, _ := new(big.Int).SetString("{{.Prime}}", 0)
+	return m
+}
+`))
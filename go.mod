@@ -6,10 +6,9 @@ require (
 	filippo.io/edwards25519 v1.1.0
 	filippo.io/nistec v0.0.3
 	github.com/bytemare/hash v0.4.0
+	github.com/decred/dcrd/dcrec/secp256k1/v4 v4.4.1
 	github.com/gtank/ristretto255 v0.1.2
+	golang.org/x/crypto v0.28.0
 )
 
-require (
-	golang.org/x/crypto v0.28.0 // indirect
-	golang.org/x/sys v0.26.0 // indirect
-)
+require golang.org/x/sys v0.26.0 // indirect
@@ -0,0 +1,195 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+// Package golden replays a frozen set of (suite, msg, dst) → output records of the suites, embedded in this package,
+// so that downstream users can detect output-breaking changes when upgrading the module, e.g. with a test calling
+// Check. The outputs are those of the suite package with its default options.
+//
+// The golden files are never modified once released. New records are added in new files, so that Check keeps
+// replaying the outputs of the earlier versions.
+package golden
+
+import (
+	"bytes"
+	"embed"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+
+	"github.com/bytemare/hash2curve"
+	"github.com/bytemare/hash2curve/suite"
+)
+
+//go:embed records/*.json
+var files embed.FS
+
+// Operation names the function of a record.
+type Operation string
+
+const (
+	// OperationHash is suite.Suite.Hash, i.e. hash_to_curve for RO suites and encode_to_curve for NU suites.
+	OperationHash Operation = "hash"
+
+	// OperationHashToScalar is suite.Suite.HashToScalar.
+	OperationHashToScalar Operation = "hash_to_scalar"
+)
+
+// ErrMismatch indicates a record whose output changed, or that could not be replayed.
+var ErrMismatch = errors.New("golden output mismatch")
+
+// Record is a frozen output of a suite. The byte strings are hex encoded.
+type Record struct {
+	Suite     string    `json:"suite"`
+	Operation Operation `json:"operation"`
+	Msg       string    `json:"msg"`
+	DST       string    `json:"dst"`
+	Output    string    `json:"output"`
+}
+
+// File is the content of a golden file.
+type File struct {
+	Comment string   `json:"comment"`
+	Records []Record `json:"records"`
+}
+
+// Load returns the records of all the golden files.
+func Load() ([]Record, error) {
+	entries, err := files.ReadDir("records")
+	if err != nil {
+		return nil, err
+	}
+
+	var records []Record
+
+	for _, entry := range entries {
+		val, err := files.ReadFile(path.Join("records", entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		var f File
+		if err = json.Unmarshal(val, &f); err != nil {
+			return nil, fmt.Errorf("decoding %s: %w", entry.Name(), err)
+		}
+
+		records = append(records, f.Records...)
+	}
+
+	return records, nil
+}
+
+// Check replays all the golden records, and returns nil if all outputs are unchanged, or the errors of all the
+// records that differ or fail, each wrapping ErrMismatch.
+func Check() error {
+	records, err := Load()
+	if err != nil {
+		return err
+	}
+
+	errs := make([]error, 0)
+
+	for i := range records {
+		if err = records[i].Check(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Check replays the record, and returns an error wrapping ErrMismatch if the output differs or can't be computed.
+func (r *Record) Check() error {
+	output, err := r.Replay()
+	if err != nil {
+		return fmt.Errorf("%w: %s %s %q: %w", ErrMismatch, r.Suite, r.Operation, r.Msg, err)
+	}
+
+	expected, err := hex.DecodeString(r.Output)
+	if err != nil {
+		return fmt.Errorf("%w: %s %s %q: %w", ErrMismatch, r.Suite, r.Operation, r.Msg, err)
+	}
+
+	if !bytes.Equal(output, expected) {
+		return fmt.Errorf("%w: %s %s %q: expected %s, got %x", ErrMismatch, r.Suite, r.Operation, r.Msg, r.Output,
+			output)
+	}
+
+	return nil
+}
+
+// Replay returns the output of the record's operation with the current implementation.
+func (r *Record) Replay() (output []byte, err error) {
+	s, err := suite.New(r.Suite)
+	if err != nil {
+		return nil, err
+	}
+
+	msg, err := hex.DecodeString(r.Msg)
+	if err != nil {
+		return nil, err
+	}
+
+	dst, err := hex.DecodeString(r.DST)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if e := recover(); e != nil {
+			err = fmt.Errorf("panic: %v", e)
+		}
+	}()
+
+	switch r.Operation {
+	case OperationHash:
+		return s.Hash(msg, dst), nil
+	case OperationHashToScalar:
+		return s.HashToScalar(msg, dst), nil
+	default:
+		return nil, fmt.Errorf("unknown operation %q", r.Operation)
+	}
+}
+
+// Generate returns the records of both operations for the suites, for each pair of message and DST, with the outputs
+// of the current implementation, e.g. to freeze a set of outputs specific to an application.
+func Generate(suites []hash2curve.Suite, msgs, dsts [][]byte) ([]Record, error) {
+	records := make([]Record, 0, 2*len(suites)*len(msgs)*len(dsts))
+
+	for _, id := range suites {
+		if !id.Available() {
+			return nil, fmt.Errorf("%w: %s", hash2curve.ErrInvalidSuite, id)
+		}
+
+		for _, dst := range dsts {
+			for _, msg := range msgs {
+				for _, op := range []Operation{OperationHash, OperationHashToScalar} {
+					r := Record{
+						Suite:     id.String(),
+						Operation: op,
+						Msg:       hex.EncodeToString(msg),
+						DST:       hex.EncodeToString(dst),
+					}
+
+					output, err := r.Replay()
+					if err != nil {
+						return nil, err
+					}
+
+					r.Output = hex.EncodeToString(output)
+					records = append(records, r)
+				}
+			}
+		}
+	}
+
+	return records, nil
+}
@@ -0,0 +1,232 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+package group
+
+import (
+	"math/big"
+	"slices"
+	"sync"
+
+	ed "filippo.io/edwards25519"
+	"github.com/gtank/ristretto255"
+
+	"github.com/bytemare/hash2curve/edwards25519"
+	h2cristretto255 "github.com/bytemare/hash2curve/ristretto255"
+)
+
+const (
+	edwards25519ElementLength = 32
+	edwards25519ScalarLength  = 32
+)
+
+var (
+	edwards25519Group = sync.OnceValue(func() *Group {
+		return &Group{
+			newElement: func(g *Group) Element { return &edwardsElement{g: g, p: ed.NewIdentityPoint()} },
+			base:       func(g *Group) Element { return &edwardsElement{g: g, p: ed.NewGeneratorPoint()} },
+			hashToElement: func(g *Group, input, dst []byte) Element {
+				return &edwardsElement{g: g, p: edwards25519.HashToCurve(input, dst)}
+			},
+			encodeToElement: func(g *Group, input, dst []byte) Element {
+				return &edwardsElement{g: g, p: edwards25519.EncodeToCurve(input, dst)}
+			},
+			hashToScalar: func(input, dst []byte) *big.Int {
+				return littleEndianInt(edwards25519.HashToScalar(input, dst).Bytes())
+			},
+			order:         edwards25519.Order(),
+			name:          "edwards25519",
+			elementLength: edwards25519ElementLength,
+			scalarLength:  edwards25519ScalarLength,
+			littleEndian:  true,
+		}
+	})
+
+	ristretto255Group = sync.OnceValue(func() *Group {
+		return &Group{
+			newElement: func(g *Group) Element { return &ristrettoElement{g: g, e: ristretto255.NewElement()} },
+			base: func(g *Group) Element {
+				return &ristrettoElement{g: g, e: ristretto255.NewElement().Base()}
+			},
+			hashToElement: func(g *Group, input, dst []byte) Element {
+				return &ristrettoElement{g: g, e: h2cristretto255.HashToGroup(input, dst)}
+			},
+			encodeToElement: func(g *Group, input, dst []byte) Element {
+				return &ristrettoElement{g: g, e: h2cristretto255.EncodeToGroup(input, dst)}
+			},
+			hashToScalar: func(input, dst []byte) *big.Int {
+				return littleEndianInt(h2cristretto255.HashToScalar(input, dst).Encode(nil))
+			},
+			order:         h2cristretto255.Order(),
+			name:          "ristretto255",
+			elementLength: edwards25519ElementLength,
+			scalarLength:  edwards25519ScalarLength,
+			littleEndian:  true,
+		}
+	})
+)
+
+// Edwards25519 returns the prime-order subgroup of edwards25519, with the edwards25519_XMD:SHA-512_ELL2_ suites.
+// Decode rejects the points outside the subgroup.
+func Edwards25519() *Group {
+	return edwards25519Group()
+}
+
+// Ristretto255 returns the ristretto255 group, with the ristretto255_XMD:SHA-512_R255MAP_ suites.
+func Ristretto255() *Group {
+	return ristretto255Group()
+}
+
+func littleEndianInt(b []byte) *big.Int {
+	b = slices.Clone(b)
+	slices.Reverse(b)
+
+	return new(big.Int).SetBytes(b)
+}
+
+type edwardsElement struct {
+	g *Group
+	p *ed.Point
+}
+
+func (e *edwardsElement) other(q Element) *edwardsElement {
+	o, ok := q.(*edwardsElement)
+	if !ok {
+		panic(ErrGroupMismatch)
+	}
+
+	checkGroup(e.g, o.g)
+
+	return o
+}
+
+func (e *edwardsElement) Add(q Element) Element {
+	e.p.Add(e.p, e.other(q).p)
+	return e
+}
+
+func (e *edwardsElement) Subtract(q Element) Element {
+	e.p.Subtract(e.p, e.other(q).p)
+	return e
+}
+
+func (e *edwardsElement) Negate() Element {
+	e.p.Negate(e.p)
+	return e
+}
+
+func (e *edwardsElement) Multiply(s Scalar) Element {
+	sc, err := ed.NewScalar().SetCanonicalBytes(scalarOf(e.g, s).Encode())
+	if err != nil {
+		panic(err)
+	}
+
+	e.p.ScalarMult(sc, e.p)
+
+	return e
+}
+
+func (e *edwardsElement) Equal(q Element) bool {
+	return e.p.Equal(e.other(q).p) == 1
+}
+
+func (e *edwardsElement) IsIdentity() bool {
+	return e.p.Equal(ed.NewIdentityPoint()) == 1
+}
+
+func (e *edwardsElement) Copy() Element {
+	return &edwardsElement{g: e.g, p: new(ed.Point).Set(e.p)}
+}
+
+func (e *edwardsElement) Encode() []byte {
+	return e.p.Bytes()
+}
+
+func (e *edwardsElement) Decode(b []byte) error {
+	if err := edwards25519.Validate(b); err != nil {
+		return err
+	}
+
+	if _, err := e.p.SetBytes(b); err != nil {
+		panic(err)
+	}
+
+	return nil
+}
+
+type ristrettoElement struct {
+	g *Group
+	e *ristretto255.Element
+}
+
+func (e *ristrettoElement) other(q Element) *ristrettoElement {
+	o, ok := q.(*ristrettoElement)
+	if !ok {
+		panic(ErrGroupMismatch)
+	}
+
+	checkGroup(e.g, o.g)
+
+	return o
+}
+
+func (e *ristrettoElement) Add(q Element) Element {
+	e.e.Add(e.e, e.other(q).e)
+	return e
+}
+
+func (e *ristrettoElement) Subtract(q Element) Element {
+	e.e.Subtract(e.e, e.other(q).e)
+	return e
+}
+
+func (e *ristrettoElement) Negate() Element {
+	e.e.Negate(e.e)
+	return e
+}
+
+func (e *ristrettoElement) Multiply(s Scalar) Element {
+	sc := ristretto255.NewScalar()
+	if err := sc.Decode(scalarOf(e.g, s).Encode()); err != nil {
+		panic(err)
+	}
+
+	e.e.ScalarMult(sc, e.e)
+
+	return e
+}
+
+func (e *ristrettoElement) Equal(q Element) bool {
+	return e.e.Equal(e.other(q).e) == 1
+}
+
+func (e *ristrettoElement) IsIdentity() bool {
+	return e.e.Equal(ristretto255.NewElement()) == 1
+}
+
+func (e *ristrettoElement) Copy() Element {
+	return &ristrettoElement{g: e.g, e: ristretto255.NewElement().Add(ristretto255.NewElement(), e.e)}
+}
+
+func (e *ristrettoElement) Encode() []byte {
+	return e.e.Encode(nil)
+}
+
+func (e *ristrettoElement) Decode(b []byte) error {
+	if err := h2cristretto255.Validate(b); err != nil {
+		return err
+	}
+
+	if err := e.e.Decode(b); err != nil {
+		panic(err)
+	}
+
+	return nil
+}
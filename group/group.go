@@ -0,0 +1,207 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+// Package group exposes the hash-to-curve suites of this module as prime-order groups, with opaque Element and Scalar
+// interfaces, so that protocols and higher-level libraries can consume all the curves uniformly.
+//
+// The element arithmetic is that of the underlying libraries: filippo.io/nistec for the NIST curves,
+// filippo.io/edwards25519 for the prime-order subgroup of edwards25519, github.com/gtank/ristretto255, and this
+// module's secp256k1 package, whose arithmetic is not constant-time. Scalars are reduced modulo the group order with
+// math/big, which is not constant-time either.
+package group
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/bytemare/hash2curve"
+)
+
+var (
+	// ErrGroupMismatch indicates an operation on elements or scalars of different groups, and is the value of the
+	// panic.
+	ErrGroupMismatch = errors.New("the operands belong to different groups")
+
+	// ErrInvalidScalar indicates a scalar encoding that is not of the scalar length, or not canonical.
+	ErrInvalidScalar = errors.New("invalid scalar encoding")
+)
+
+// Element is an element of a prime-order Group. The methods that modify the receiver also return it, and their
+// operands must belong to the same group, or they panic with ErrGroupMismatch.
+type Element interface {
+	// Add sets the receiver to the sum of the receiver and q, and returns it.
+	Add(q Element) Element
+
+	// Subtract sets the receiver to the difference of the receiver and q, and returns it.
+	Subtract(q Element) Element
+
+	// Negate sets the receiver to its opposite, and returns it.
+	Negate() Element
+
+	// Multiply sets the receiver to its product by the scalar, and returns it.
+	Multiply(s Scalar) Element
+
+	// Equal returns whether the receiver and q are the same element.
+	Equal(q Element) bool
+
+	// IsIdentity returns whether the receiver is the identity element.
+	IsIdentity() bool
+
+	// Copy returns a copy of the receiver.
+	Copy() Element
+
+	// Encode returns the canonical encoding of the element, as defined by the underlying library.
+	Encode() []byte
+
+	// Decode sets the receiver to the decoded element, or returns an error wrapping hash2curve.ErrInvalidPoint if the
+	// encoding is invalid or is that of the identity, leaving the receiver unchanged.
+	Decode(b []byte) error
+}
+
+// Scalar is a scalar of a prime-order Group, i.e. an integer modulo its order. The methods that modify the receiver
+// also return it, and their operands must belong to the same group, or they panic with ErrGroupMismatch.
+type Scalar interface {
+	// Add sets the receiver to the sum of the receiver and t, and returns it.
+	Add(t Scalar) Scalar
+
+	// Subtract sets the receiver to the difference of the receiver and t, and returns it.
+	Subtract(t Scalar) Scalar
+
+	// Multiply sets the receiver to the product of the receiver and t, and returns it.
+	Multiply(t Scalar) Scalar
+
+	// Negate sets the receiver to its opposite, and returns it.
+	Negate() Scalar
+
+	// Invert sets the receiver to its multiplicative inverse, or to zero if it is zero, and returns it.
+	Invert() Scalar
+
+	// Equal returns whether the receiver and t are the same scalar.
+	Equal(t Scalar) bool
+
+	// IsZero returns whether the receiver is zero.
+	IsZero() bool
+
+	// Copy returns a copy of the receiver.
+	Copy() Scalar
+
+	// Encode returns the fixed-length encoding of the scalar, with the byte order of the group.
+	Encode() []byte
+
+	// Decode sets the receiver to the decoded scalar, or returns an error wrapping ErrInvalidScalar if the encoding is
+	// not of the scalar length or not canonical, leaving the receiver unchanged.
+	Decode(b []byte) error
+
+	// BigInt returns the integer value of the scalar.
+	BigInt() *big.Int
+}
+
+// Group is a prime-order group with the hash-to-curve suites of a curve.
+type Group struct {
+	newElement      func(g *Group) Element
+	base            func(g *Group) Element
+	hashToElement   func(g *Group, input, dst []byte) Element
+	encodeToElement func(g *Group, input, dst []byte) Element
+	hashToScalar    func(input, dst []byte) *big.Int
+	order           *big.Int
+	name            string
+	elementLength   int
+	scalarLength    int
+	littleEndian    bool
+}
+
+// Name returns the CURVE_ID of the group in the suite identifiers, e.g. "P256" or "ristretto255".
+func (g *Group) Name() string {
+	return g.name
+}
+
+// Order returns a copy of the order of the group.
+func (g *Group) Order() *big.Int {
+	return new(big.Int).Set(g.order)
+}
+
+// ElementLength returns the length of the encodings of the elements other than the identity.
+func (g *Group) ElementLength() int {
+	return g.elementLength
+}
+
+// ScalarLength returns the length of the encodings of the scalars.
+func (g *Group) ScalarLength() int {
+	return g.scalarLength
+}
+
+// NewElement returns the identity element.
+func (g *Group) NewElement() Element {
+	return g.newElement(g)
+}
+
+// Base returns the generator of the group.
+func (g *Group) Base() Element {
+	return g.base(g)
+}
+
+// NewScalar returns the zero scalar.
+func (g *Group) NewScalar() Scalar {
+	return &scalar{g: g}
+}
+
+// HashToElement implements hash-to-curve of the random oracle suite of the group, with input and dst.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func (g *Group) HashToElement(input, dst []byte) Element {
+	return g.hashToElement(g, input, dst)
+}
+
+// EncodeToElement implements encode-to-curve of the non-uniform suite of the group, with input and dst.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func (g *Group) EncodeToElement(input, dst []byte) Element {
+	return g.encodeToElement(g, input, dst)
+}
+
+// HashToScalar returns a safe mapping of the arbitrary input to a scalar, as the hash-to-scalar function of the
+// curve's package does.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func (g *Group) HashToScalar(input, dst []byte) Scalar {
+	s := &scalar{g: g}
+	s.v.Set(g.hashToScalar(input, dst))
+
+	return s
+}
+
+// ForSuite returns the group of the suite, which is the same for its random oracle and non-uniform variants. It returns
+// an error wrapping hash2curve.ErrInvalidSuite if the suite is invalid, or if it has no prime-order group encoding,
+// as the curve25519 suites, which encode to Montgomery u-coordinates.
+func ForSuite(s hash2curve.Suite) (*Group, error) {
+	switch s {
+	case hash2curve.P256SHA256SSWURO, hash2curve.P256SHA256SSWUNU:
+		return P256(), nil
+	case hash2curve.P384SHA384SSWURO, hash2curve.P384SHA384SSWUNU:
+		return P384(), nil
+	case hash2curve.P521SHA512SSWURO, hash2curve.P521SHA512SSWUNU:
+		return P521(), nil
+	case hash2curve.Edwards25519SHA512ELL2RO, hash2curve.Edwards25519SHA512ELL2NU:
+		return Edwards25519(), nil
+	case hash2curve.Secp256k1SHA256SSWURO, hash2curve.Secp256k1SHA256SSWUNU:
+		return Secp256k1(), nil
+	case hash2curve.Ristretto255SHA512R255MAPRO, hash2curve.Ristretto255SHA512R255MAPNU:
+		return Ristretto255(), nil
+	case hash2curve.Curve25519SHA512ELL2RO, hash2curve.Curve25519SHA512ELL2NU:
+		return nil, fmt.Errorf("%w: %s has no prime-order group encoding", hash2curve.ErrInvalidSuite, s)
+	default:
+		return nil, fmt.Errorf("%w: %d", hash2curve.ErrInvalidSuite, uint8(s))
+	}
+}
+
+// checkGroup panics with ErrGroupMismatch if the groups differ.
+func checkGroup(g, h *Group) {
+	if g != h {
+		panic(ErrGroupMismatch)
+	}
+}
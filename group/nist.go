@@ -0,0 +1,192 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+package group
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"math/big"
+	"slices"
+	"sync"
+
+	"filippo.io/nistec"
+
+	"github.com/bytemare/hash2curve"
+	"github.com/bytemare/hash2curve/nist"
+)
+
+// nistPoint is the interface of the filippo.io/nistec points.
+type nistPoint[P any] interface {
+	Add(p1, p2 P) P
+	ScalarMult(q P, scalar []byte) (P, error)
+	Set(q P) P
+	SetGenerator() P
+	SetBytes(b []byte) (P, error)
+	Bytes() []byte
+	BytesCompressed() []byte
+}
+
+// nistCurve holds the functions of a NIST curve of the nist package.
+type nistCurve[P nistPoint[P]] struct {
+	newPoint     func() P
+	hashToCurve  func(input, dst []byte) P
+	encodeTo     func(input, dst []byte) P
+	hashToScalar func(input, dst []byte, opts ...nist.ScalarOption) *big.Int
+	validate     func(encoded []byte) error
+	name         string
+	order        *big.Int
+	byteLen      int
+}
+
+var (
+	p256Group = sync.OnceValue(func() *Group {
+		return newNISTGroup(nistCurve[*nistec.P256Point]{
+			nistec.NewP256Point, nist.HashToP256, nist.EncodeToP256, nist.HashToScalarP256, nist.ValidateP256,
+			"P256", nist.OrderP256(), 32,
+		})
+	})
+	p384Group = sync.OnceValue(func() *Group {
+		return newNISTGroup(nistCurve[*nistec.P384Point]{
+			nistec.NewP384Point, nist.HashToP384, nist.EncodeToP384, nist.HashToScalarP384, nist.ValidateP384,
+			"P384", nist.OrderP384(), 48,
+		})
+	})
+	p521Group = sync.OnceValue(func() *Group {
+		return newNISTGroup(nistCurve[*nistec.P521Point]{
+			nistec.NewP521Point, nist.HashToP521, nist.EncodeToP521, nist.HashToScalarP521, nist.ValidateP521,
+			"P521", nist.OrderP521(), 66,
+		})
+	})
+)
+
+// P256 returns the NIST P-256 group, with the P256_XMD:SHA-256_SSWU_ suites. The elements are encoded in the SEC 1
+// compressed format.
+func P256() *Group {
+	return p256Group()
+}
+
+// P384 returns the NIST P-384 group, with the P384_XMD:SHA-384_SSWU_ suites. The elements are encoded in the SEC 1
+// compressed format.
+func P384() *Group {
+	return p384Group()
+}
+
+// P521 returns the NIST P-521 group, with the P521_XMD:SHA-512_SSWU_ suites. The elements are encoded in the SEC 1
+// compressed format.
+func P521() *Group {
+	return p521Group()
+}
+
+func newNISTGroup[P nistPoint[P]](c nistCurve[P]) *Group {
+	wrap := func(g *Group, p P) Element {
+		return &nistElement[P]{g: g, c: &c, p: p}
+	}
+
+	return &Group{
+		newElement: func(g *Group) Element { return wrap(g, c.newPoint()) },
+		base:       func(g *Group) Element { return wrap(g, c.newPoint().SetGenerator()) },
+		hashToElement: func(g *Group, input, dst []byte) Element {
+			return wrap(g, c.hashToCurve(input, dst))
+		},
+		encodeToElement: func(g *Group, input, dst []byte) Element {
+			return wrap(g, c.encodeTo(input, dst))
+		},
+		hashToScalar: func(input, dst []byte) *big.Int {
+			return c.hashToScalar(input, dst)
+		},
+		order:         c.order,
+		name:          c.name,
+		elementLength: 1 + c.byteLen,
+		scalarLength:  c.byteLen,
+	}
+}
+
+type nistElement[P nistPoint[P]] struct {
+	g *Group
+	c *nistCurve[P]
+	p P
+}
+
+func (e *nistElement[P]) other(q Element) *nistElement[P] {
+	o, ok := q.(*nistElement[P])
+	if !ok {
+		panic(ErrGroupMismatch)
+	}
+
+	checkGroup(e.g, o.g)
+
+	return o
+}
+
+func (e *nistElement[P]) Add(q Element) Element {
+	e.p.Add(e.p, e.other(q).p)
+	return e
+}
+
+func (e *nistElement[P]) Subtract(q Element) Element {
+	return e.Add(e.other(q).Copy().Negate())
+}
+
+// Negate flips the parity of y in the compressed encoding, as nistec does not expose the coordinates.
+func (e *nistElement[P]) Negate() Element {
+	b := e.p.BytesCompressed()
+	if len(b) == 1 {
+		return e
+	}
+
+	b[0] ^= 1
+
+	if _, err := e.p.SetBytes(b); err != nil {
+		panic(err)
+	}
+
+	return e
+}
+
+func (e *nistElement[P]) Multiply(s Scalar) Element {
+	if _, err := e.p.ScalarMult(e.p, scalarOf(e.g, s).bigEndian()); err != nil {
+		panic(err)
+	}
+
+	return e
+}
+
+func (e *nistElement[P]) Equal(q Element) bool {
+	return subtle.ConstantTimeCompare(e.p.Bytes(), e.other(q).p.Bytes()) == 1
+}
+
+func (e *nistElement[P]) IsIdentity() bool {
+	return len(e.p.Bytes()) == 1
+}
+
+func (e *nistElement[P]) Copy() Element {
+	return &nistElement[P]{g: e.g, c: e.c, p: e.c.newPoint().Set(e.p)}
+}
+
+func (e *nistElement[P]) Encode() []byte {
+	return e.p.BytesCompressed()
+}
+
+func (e *nistElement[P]) Decode(b []byte) error {
+	if len(b) != e.g.elementLength {
+		return fmt.Errorf("%w: the encoding must be %d bytes", hash2curve.ErrInvalidPoint, e.g.elementLength)
+	}
+
+	if err := e.c.validate(b); err != nil {
+		return err
+	}
+
+	if _, err := e.p.SetBytes(slices.Clone(b)); err != nil {
+		return fmt.Errorf("%w: %w", hash2curve.ErrInvalidPoint, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,128 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+package group
+
+import (
+	"crypto/subtle"
+	"math/big"
+	"slices"
+)
+
+// scalar implements Scalar for all groups, as an integer reduced modulo the group order.
+type scalar struct {
+	g *Group
+	v big.Int
+}
+
+func (s *scalar) other(t Scalar) *scalar {
+	return scalarOf(s.g, t)
+}
+
+func (s *scalar) Add(t Scalar) Scalar {
+	s.v.Add(&s.v, &s.other(t).v)
+	s.v.Mod(&s.v, s.g.order)
+
+	return s
+}
+
+func (s *scalar) Subtract(t Scalar) Scalar {
+	s.v.Sub(&s.v, &s.other(t).v)
+	s.v.Mod(&s.v, s.g.order)
+
+	return s
+}
+
+func (s *scalar) Multiply(t Scalar) Scalar {
+	s.v.Mul(&s.v, &s.other(t).v)
+	s.v.Mod(&s.v, s.g.order)
+
+	return s
+}
+
+func (s *scalar) Negate() Scalar {
+	s.v.Neg(&s.v)
+	s.v.Mod(&s.v, s.g.order)
+
+	return s
+}
+
+func (s *scalar) Invert() Scalar {
+	if s.v.Sign() != 0 {
+		s.v.ModInverse(&s.v, s.g.order)
+	}
+
+	return s
+}
+
+func (s *scalar) Equal(t Scalar) bool {
+	return subtle.ConstantTimeCompare(s.Encode(), s.other(t).Encode()) == 1
+}
+
+func (s *scalar) IsZero() bool {
+	return s.v.Sign() == 0
+}
+
+func (s *scalar) Copy() Scalar {
+	c := &scalar{g: s.g}
+	c.v.Set(&s.v)
+
+	return c
+}
+
+func (s *scalar) Encode() []byte {
+	b := s.v.FillBytes(make([]byte, s.g.scalarLength))
+	if s.g.littleEndian {
+		slices.Reverse(b)
+	}
+
+	return b
+}
+
+func (s *scalar) Decode(b []byte) error {
+	if len(b) != s.g.scalarLength {
+		return ErrInvalidScalar
+	}
+
+	be := slices.Clone(b)
+	if s.g.littleEndian {
+		slices.Reverse(be)
+	}
+
+	v := new(big.Int).SetBytes(be)
+	if v.Cmp(s.g.order) >= 0 {
+		return ErrInvalidScalar
+	}
+
+	s.v.Set(v)
+
+	return nil
+}
+
+func (s *scalar) BigInt() *big.Int {
+	return new(big.Int).Set(&s.v)
+}
+
+// bigEndian returns the fixed-length big-endian encoding of the scalar.
+func (s *scalar) bigEndian() []byte {
+	return s.v.FillBytes(make([]byte, s.g.scalarLength))
+}
+
+// scalarOf returns the scalar of the group, or panics with ErrGroupMismatch.
+func scalarOf(g *Group, t Scalar) *scalar {
+	u, ok := t.(*scalar)
+	if !ok {
+		panic(ErrGroupMismatch)
+	}
+
+	checkGroup(g, u.g)
+
+	return u
+}
@@ -0,0 +1,123 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+package group
+
+import (
+	"crypto/subtle"
+	"encoding/hex"
+	"sync"
+
+	"github.com/bytemare/hash2curve/secp256k1"
+)
+
+const (
+	secp256k1ElementLength = 33
+	secp256k1ScalarLength  = 32
+
+	// secp256k1Generator is the compressed encoding of the generator of secp256k1.
+	secp256k1Generator = "0279be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798"
+)
+
+var secp256k1Group = sync.OnceValue(func() *Group {
+	return &Group{
+		newElement: func(g *Group) Element { return &secp256k1Element{g: g, p: new(secp256k1.Point)} },
+		base: func(g *Group) Element {
+			b, _ := hex.DecodeString(secp256k1Generator)
+
+			p, err := new(secp256k1.Point).SetBytes(b)
+			if err != nil {
+				panic(err)
+			}
+
+			return &secp256k1Element{g: g, p: p}
+		},
+		hashToElement: func(g *Group, input, dst []byte) Element {
+			return &secp256k1Element{g: g, p: secp256k1.HashToCurve(input, dst)}
+		},
+		encodeToElement: func(g *Group, input, dst []byte) Element {
+			return &secp256k1Element{g: g, p: secp256k1.EncodeToCurve(input, dst)}
+		},
+		hashToScalar:  secp256k1.HashToScalar,
+		order:         secp256k1.Order(),
+		name:          "secp256k1",
+		elementLength: secp256k1ElementLength,
+		scalarLength:  secp256k1ScalarLength,
+	}
+})
+
+// Secp256k1 returns the secp256k1 group, with the secp256k1_XMD:SHA-256_SSWU_ suites. The elements are encoded in the
+// SEC 1 compressed format, and their arithmetic is not constant-time.
+func Secp256k1() *Group {
+	return secp256k1Group()
+}
+
+type secp256k1Element struct {
+	g *Group
+	p *secp256k1.Point
+}
+
+func (e *secp256k1Element) other(q Element) *secp256k1Element {
+	o, ok := q.(*secp256k1Element)
+	if !ok {
+		panic(ErrGroupMismatch)
+	}
+
+	checkGroup(e.g, o.g)
+
+	return o
+}
+
+func (e *secp256k1Element) Add(q Element) Element {
+	e.p.Add(e.p, e.other(q).p)
+	return e
+}
+
+func (e *secp256k1Element) Subtract(q Element) Element {
+	return e.Add(e.other(q).Copy().Negate())
+}
+
+func (e *secp256k1Element) Negate() Element {
+	e.p.Negate(e.p)
+	return e
+}
+
+func (e *secp256k1Element) Multiply(s Scalar) Element {
+	e.p.ScalarMult(&scalarOf(e.g, s).v, e.p)
+	return e
+}
+
+func (e *secp256k1Element) Equal(q Element) bool {
+	return subtle.ConstantTimeCompare(e.p.Bytes(), e.other(q).p.Bytes()) == 1
+}
+
+func (e *secp256k1Element) IsIdentity() bool {
+	return e.p.X.Sign() == 0 && e.p.Y.Sign() == 0
+}
+
+func (e *secp256k1Element) Copy() Element {
+	return &secp256k1Element{g: e.g, p: new(secp256k1.Point).Add(new(secp256k1.Point), e.p)}
+}
+
+func (e *secp256k1Element) Encode() []byte {
+	return e.p.Bytes()
+}
+
+func (e *secp256k1Element) Decode(b []byte) error {
+	if err := secp256k1.Validate(b); err != nil {
+		return err
+	}
+
+	if _, err := e.p.SetBytes(b); err != nil {
+		panic(err)
+	}
+
+	return nil
+}
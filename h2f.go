@@ -6,46 +6,311 @@
 // LICENSE file in the root directory of this source tree or at
 // https://spdx.org/licenses/MIT.html
 
+//go:build !nomathbig
+
 package hash2curve
 
 import (
 	"crypto"
+	"encoding/binary"
+	"fmt"
 	"math/big"
+	"sync/atomic"
 
 	"github.com/bytemare/hash"
 )
 
-// HashToFieldXOF hashes the input with the domain separation tag (dst) to an integer under modulo, using an
-// extensible output function (e.g. SHAKE).
+const (
+	// minSecurityBits is the lowest security level k of the suites in RFC 9380, used to check that the security length
+	// L = ceil((ceil(log2(p)) + k) / 8) gives a negligible bias.
+	minSecurityBits = 128
+
+	// maxExpandLength is the largest output length of expand_message, which is encoded on 2 bytes.
+	maxExpandLength = 1<<16 - 1
+)
+
+var (
+	errHashToFieldCount     = fmt.Errorf("%w: count must be positive", ErrInvalidParameters)
+	errHashToFieldExt       = fmt.Errorf("%w: extension degree must be positive", ErrInvalidParameters)
+	errHashToFieldModulo    = fmt.Errorf("%w: modulo must be an integer larger than 1", ErrInvalidParameters)
+	errHashToFieldSecLength = fmt.Errorf(
+		"%w: security length must be at least ceil((ceil(log2(p)) + 128) / 8)", ErrInvalidParameters,
+	)
+	errHashToFieldLength = fmt.Errorf(
+		"%w: count * ext * securityLength must not exceed 65535", ErrInvalidParameters,
+	)
+)
+
+// ValidateHashToField checks the hash_to_field parameters, and returns a descriptive error wrapping
+// ErrInvalidParameters if they are invalid.
+// count and ext must be positive, modulo must be larger than 1, securityLength must be large enough for a security
+// level of at least 128 bits over modulo, and the total expansion length count * ext * securityLength must fit
+// expand_message.
+func ValidateHashToField(count, ext, securityLength uint, modulo *big.Int) error {
+	switch {
+	case count == 0:
+		return errHashToFieldCount
+	case ext == 0:
+		return errHashToFieldExt
+	case modulo == nil || modulo.Cmp(big.NewInt(1)) <= 0:
+		return errHashToFieldModulo
+	case securityLength < uint((modulo.BitLen()+minSecurityBits+7)/8):
+		return errHashToFieldSecLength
+	case count > maxExpandLength || ext > maxExpandLength || securityLength > maxExpandLength ||
+		count*ext*securityLength > maxExpandLength:
+		return errHashToFieldLength
+	default:
+		return nil
+	}
+}
+
+// SecurityLength returns the length L = ceil((ceil(log2(modulo)) + k) / 8) of each uniform string hash_to_field
+// reduces to an element of the field of the given modulo, for the security level of k bits, as in RFC 9380 section 5.
+// For example, it is 48 for P-256 and 98 for P-521, with k = 128 and k = 256.
+func SecurityLength(modulo *big.Int, k uint) uint {
+	return (uint(modulo.BitLen()) + k + 7) / 8
+}
+
+var strictSecurity atomic.Bool
+
+// SetStrictSecurity enables or disables the module-wide strict security policy, which is disabled by default. When
+// enabled, the packages reject overrides of the hash function or of the length L of hash_to_field that don't meet the
+// target security level of the curve, as reported by ValidateSecurityLevel, instead of only enforcing the 128-bit
+// minimum. It is safe for concurrent use, but is meant to be set once at program initialization.
+func SetStrictSecurity(enabled bool) {
+	strictSecurity.Store(enabled)
+}
+
+// StrictSecurity reports whether the module-wide strict security policy is enabled.
+func StrictSecurity() bool {
+	return strictSecurity.Load()
+}
+
+// ValidateSecurityLevel checks that expand_message_xmd with h, and hash_to_field with a length L of securityLength over
+// modulo, meet the security level of k bits, as required by RFC 9380 sections 5 and 5.3.1: h must output at least
+// 2 * k bits, and L must be at least SecurityLength(modulo, k). It returns an error wrapping ErrInsufficientSecurity
+// otherwise, or ErrUnsupportedHash if h is not available.
+func ValidateSecurityLevel(h crypto.Hash, securityLength uint, modulo *big.Int, k uint) error {
+	switch {
+	case !h.Available():
+		return ErrUnsupportedHash
+	case modulo == nil || modulo.Cmp(big.NewInt(1)) <= 0:
+		return errHashToFieldModulo
+	case uint(8*h.Size()) < 2*k:
+		return fmt.Errorf("%w: %s outputs %d bits, less than 2 * %d", ErrInsufficientSecurity, h, 8*h.Size(), k)
+	case securityLength < SecurityLength(modulo, k):
+		return fmt.Errorf(
+			"%w: L must be at least %d bytes for %d bits of security, got %d",
+			ErrInsufficientSecurity, SecurityLength(modulo, k), k, securityLength,
+		)
+	default:
+		return nil
+	}
+}
+
+// ExpandForCurve expands the input and dst with h, as ExpandMessage, to count * ext uniform strings of
+// SecurityLength(modulo, k) bytes, for callers reducing them with their own field implementation. It computes the
+// lengths, instead of the caller hardcoding them, and panics if the parameters are invalid, as reported by
+// ValidateHashToField, which requires k to be at least 128.
+func ExpandForCurve(h hash.Hash, input, dst []byte, modulo *big.Int, k, count, ext uint) [][]byte {
+	if modulo == nil {
+		panic(errHashToFieldModulo)
+	}
+
+	securityLength := SecurityLength(modulo, k)
+	checkHashToField(count, ext, securityLength, modulo)
+
+	uniform := ExpandMessage(h, input, dst, count*ext*securityLength)
+	res := make([][]byte, count*ext)
+
+	for i := range res {
+		offset := uint(i) * securityLength
+		res[i] = uniform[offset : offset+securityLength : offset+securityLength]
+	}
+
+	return res
+}
+
+func checkHashToField(count, ext, securityLength uint, modulo *big.Int) {
+	if err := ValidateHashToField(count, ext, securityLength, modulo); err != nil {
+		panic(err)
+	}
+}
+
+// HashToFieldXOF hashes the input with the domain separation tag (dst) to count elements of GF(modulo^ext), using an
+// extensible output function (e.g. SHAKE). It returns the count * ext coordinates, those of each element in turn, so
+// that for ext = 1 it returns count integers under modulo.
 // - dst MUST be non-nil and its length longer than 0. It's recommended that DST at least 16 bytes long.
 // - count * ext * securityLength must be positive integers higher than 32.
+// It panics if the parameters are invalid, as reported by ValidateHashToField.
 func HashToFieldXOF(
 	id *hash.ExtendableHash,
 	input, dst []byte,
 	count, ext, securityLength uint,
 	modulo *big.Int,
 ) []*big.Int {
+	return HashToFieldXOFState(ExtendableXOF(id), input, dst, count, ext, securityLength, modulo)
+}
+
+// HashToFieldXOFState is HashToFieldXOF with any XOFState, e.g. a *sha3.SHAKE from crypto/sha3, as accepted by
+// ExpandXOF. Its state is reset before use.
+// It panics if the parameters are invalid, as reported by ValidateHashToField, and as ExpandXOF does.
+func HashToFieldXOFState(
+	xof XOFState,
+	input, dst []byte,
+	count, ext, securityLength uint,
+	modulo *big.Int,
+) []*big.Int {
+	checkHashToField(count, ext, securityLength, modulo)
 	expLength := count * ext * securityLength // elements * ext * security length
-	uniform := ExpandXOF(id, input, dst, expLength)
+	uniform := ExpandXOF(xof, input, dst, expLength)
+	defer Wipe(uniform)
 
-	return reduceUniform(uniform, count, securityLength, modulo)
+	return reduceUniform(uniform, count, ext, securityLength, modulo)
 }
 
-// HashToFieldXMD hashes the input with the domain separation tag (dst) to an integer under modulo, using a
-// merkle-damgard based expander (e.g. SHA256).
+// HashToFieldXMD hashes the input with the domain separation tag (dst) to count elements of GF(modulo^ext), using a
+// merkle-damgard based expander (e.g. SHA256). It returns the count * ext coordinates, those of each element in turn,
+// so that for ext = 1 it returns count integers under modulo.
 // - dst MUST be non-nil, longer than 0 and lower than 256. It's recommended that DST at least 16 bytes long.
 // - count * ext * securityLength must be a positive integer lower than 255 * (size of digest).
+// It panics if the parameters are invalid, as reported by ValidateHashToField.
 func HashToFieldXMD(id crypto.Hash, input, dst []byte, count, ext, securityLength uint, modulo *big.Int) []*big.Int {
+	checkHashToField(count, ext, securityLength, modulo)
 	expLength := count * ext * securityLength // elements * ext * security length
 	uniform := ExpandXMD(id, input, dst, expLength)
+	defer Wipe(uniform)
+
+	return reduceUniform(uniform, count, ext, securityLength, modulo)
+}
+
+// HashToFieldXMDKeccak256 is HashToFieldXMD with ExpandXMDKeccak256, the expander of the Ethereum-compatible suites.
+// It panics if the parameters are invalid, as reported by ValidateHashToField.
+func HashToFieldXMDKeccak256(input, dst []byte, count, ext, securityLength uint, modulo *big.Int) []*big.Int {
+	checkHashToField(count, ext, securityLength, modulo)
+	uniform := ExpandXMDKeccak256(input, dst, count*ext*securityLength)
+	defer Wipe(uniform)
 
-	return reduceUniform(uniform, count, securityLength, modulo)
+	return reduceUniform(uniform, count, ext, securityLength, modulo)
+}
+
+// HashToFieldXOFBytes is HashToFieldXOF returning each element as its canonical big-endian encoding, of the byte
+// length of modulo, for callers using other field implementations.
+// It panics if the parameters are invalid, as reported by ValidateHashToField.
+func HashToFieldXOFBytes(
+	id *hash.ExtendableHash,
+	input, dst []byte,
+	count, ext, securityLength uint,
+	modulo *big.Int,
+) [][]byte {
+	return encodeElements(HashToFieldXOF(id, input, dst, count, ext, securityLength, modulo), modulo)
+}
+
+// HashToFieldXOFStateBytes is HashToFieldXOFState returning each element as its canonical big-endian encoding, as
+// HashToFieldXOFBytes does.
+// It panics if the parameters are invalid, as HashToFieldXOFState.
+func HashToFieldXOFStateBytes(
+	xof XOFState,
+	input, dst []byte,
+	count, ext, securityLength uint,
+	modulo *big.Int,
+) [][]byte {
+	return encodeElements(HashToFieldXOFState(xof, input, dst, count, ext, securityLength, modulo), modulo)
+}
+
+// HashToFieldXMDBytes is HashToFieldXMD returning each element as its canonical big-endian encoding, of the byte
+// length of modulo, for callers using other field implementations.
+// It panics if the parameters are invalid, as reported by ValidateHashToField.
+func HashToFieldXMDBytes(
+	id crypto.Hash,
+	input, dst []byte,
+	count, ext, securityLength uint,
+	modulo *big.Int,
+) [][]byte {
+	return encodeElements(HashToFieldXMD(id, input, dst, count, ext, securityLength, modulo), modulo)
+}
+
+// encodeElements returns the fixed-width big-endian encodings of the elements reduced modulo.
+func encodeElements(elements []*big.Int, modulo *big.Int) [][]byte {
+	byteLen := (modulo.BitLen() + 7) / 8
+	res := make([][]byte, len(elements))
+	buf := make([]byte, len(elements)*byteLen)
+
+	for i, e := range elements {
+		res[i] = e.FillBytes(buf[i*byteLen : (i+1)*byteLen : (i+1)*byteLen])
+	}
+
+	return res
+}
+
+// HashToFieldXOFMontgomery is HashToFieldXOF returning each element in Montgomery form, as by ToMontgomery, for
+// libraries keeping field elements in that representation (e.g. gnark-crypto).
+// It panics if the parameters are invalid, as reported by ValidateHashToField.
+func HashToFieldXOFMontgomery(
+	id *hash.ExtendableHash,
+	input, dst []byte,
+	count, ext, securityLength uint,
+	modulo *big.Int,
+) [][]uint64 {
+	return montgomeryElements(HashToFieldXOF(id, input, dst, count, ext, securityLength, modulo), modulo)
+}
+
+// HashToFieldXOFStateMontgomery is HashToFieldXOFState returning each element in Montgomery form, as
+// HashToFieldXOFMontgomery does.
+// It panics if the parameters are invalid, as HashToFieldXOFState.
+func HashToFieldXOFStateMontgomery(
+	xof XOFState,
+	input, dst []byte,
+	count, ext, securityLength uint,
+	modulo *big.Int,
+) [][]uint64 {
+	return montgomeryElements(HashToFieldXOFState(xof, input, dst, count, ext, securityLength, modulo), modulo)
+}
+
+// HashToFieldXMDMontgomery is HashToFieldXMD returning each element in Montgomery form, as by ToMontgomery, for
+// libraries keeping field elements in that representation (e.g. gnark-crypto).
+// It panics if the parameters are invalid, as reported by ValidateHashToField.
+func HashToFieldXMDMontgomery(
+	id crypto.Hash,
+	input, dst []byte,
+	count, ext, securityLength uint,
+	modulo *big.Int,
+) [][]uint64 {
+	return montgomeryElements(HashToFieldXMD(id, input, dst, count, ext, securityLength, modulo), modulo)
+}
+
+// ToMontgomery returns the Montgomery form e * R mod modulo of e, with R = 2^(64 * n) and n the number of 64-bit words
+// of modulo, as little-endian 64-bit limbs.
+func ToMontgomery(e, modulo *big.Int) []uint64 {
+	n := (modulo.BitLen() + 63) / 64
+	m := new(big.Int).Lsh(e, uint(64*n))
+	m.Mod(m, modulo)
+
+	limbs := make([]uint64, n)
+	buf := m.FillBytes(make([]byte, 8*n))
+
+	for i := range n {
+		limbs[i] = binary.BigEndian.Uint64(buf[8*(n-1-i):])
+	}
+
+	return limbs
+}
+
+func montgomeryElements(elements []*big.Int, modulo *big.Int) [][]uint64 {
+	res := make([][]uint64, len(elements))
+	for i, e := range elements {
+		res[i] = ToMontgomery(e, modulo)
+	}
+
+	return res
 }
 
-func reduceUniform(uniform []byte, count, securityLength uint, modulo *big.Int) []*big.Int {
-	res := make([]*big.Int, count)
+// reduceUniform reduces the count * ext strings of securityLength bytes to integers modulo, in the order of
+// hash_to_field: the ext coordinates of the first element, then those of the second, and so on.
+func reduceUniform(uniform []byte, count, ext, securityLength uint, modulo *big.Int) []*big.Int {
+	res := make([]*big.Int, count*ext)
 
-	for i := range count {
+	for i := range count * ext {
 		offset := i * securityLength
 		res[i] = reduce(uniform[offset:offset+securityLength], modulo)
 	}
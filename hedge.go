@@ -0,0 +1,76 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+package hash2curve
+
+import (
+	"crypto"
+	"fmt"
+
+	"github.com/bytemare/hash2curve/internal"
+)
+
+const (
+	// hedgedDSTPrefix separates the domain of hedged expansions from that of the plain ones with the same DST.
+	hedgedDSTPrefix = "HEDGED-"
+
+	// maxAuxLength is the largest length of the auxiliary randomness, as it's encoded on 2 bytes.
+	maxAuxLength = 1<<16 - 1
+)
+
+var errHedgeAux = fmt.Errorf("%w: the auxiliary randomness must not be longer than 65535 bytes", ErrInvalidParameters)
+
+// HedgedDST returns the DST of hedged expansions with dst, i.e. "HEDGED-" || dst. DSTs longer than 255 bytes are then
+// shortened by the expander as RFC 9380 prescribes.
+func HedgedDST(dst []byte) []byte {
+	return append([]byte(hedgedDSTPrefix), dst...)
+}
+
+// HedgedInput returns the input of hedged expansions, I2OSP(len(aux), 2) || aux || input, in which the length prefix
+// makes the split between the auxiliary randomness and the input unambiguous. It panics with an error wrapping
+// ErrInvalidParameters if aux is longer than 65535 bytes.
+func HedgedInput(input, aux []byte) []byte {
+	if len(aux) > maxAuxLength {
+		panic(errHedgeAux)
+	}
+
+	hedged := make([]byte, 0, 2+len(aux)+len(input))
+	hedged = append(hedged, internal.I2OSP(uint(len(aux)), 2)...)
+	hedged = append(hedged, aux...)
+
+	return append(hedged, input...)
+}
+
+// ExpandXMDHedged is ExpandXMD mixing in the auxiliary randomness aux, i.e. hedged hashing, as
+// ExpandXMD(id, HedgedInput(input, aux), HedgedDST(dst), length). The output stays unpredictable without aux if the
+// input is secret, and without the input if aux is fresh randomness, which protects deterministic protocols, e.g.
+// signatures, against fault attacks, and randomized ones against a bad random number generator. The outputs never
+// collide with those of ExpandXMD with the same dst.
+// aux should be 32 bytes from a cryptographically secure source. The requirements of ExpandXMD apply to dst and
+// length, and it panics if aux is longer than 65535 bytes.
+func ExpandXMDHedged(id crypto.Hash, input, aux, dst []byte, length uint) []byte {
+	checkDST(dst)
+
+	hedged := HedgedInput(input, aux)
+	defer Wipe(hedged)
+
+	return ExpandXMD(id, hedged, HedgedDST(dst), length)
+}
+
+// ExpandXOFHedged is ExpandXMDHedged for ExpandXOF, as ExpandXOF(ext, HedgedInput(input, aux), HedgedDST(dst), length).
+// The requirements of ExpandXOF apply to dst and length, and it panics if aux is longer than 65535 bytes.
+func ExpandXOFHedged(ext XOFState, input, aux, dst []byte, length uint) []byte {
+	checkDST(dst)
+
+	hedged := HedgedInput(input, aux)
+	defer Wipe(hedged)
+
+	return ExpandXOF(ext, hedged, HedgedDST(dst), length)
+}
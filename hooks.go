@@ -0,0 +1,89 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package hash2curve
+
+import (
+	"time"
+
+	"github.com/bytemare/hash2curve/internal"
+)
+
+// ExpandEvent describes an expansion, as reported to Hooks.Expand.
+type ExpandEvent struct {
+	// Expander identifies the expander and its hash function, e.g. "XMD:SHA-256", "XOF:SHAKE128", or "HKDF:SHA-256".
+	Expander string
+
+	// Count is the number of inputs expanded, which is larger than 1 for batches.
+	Count uint
+
+	// Length is the number of bytes expanded for each input.
+	Length uint
+
+	// Duration is the time the expansion took.
+	Duration time.Duration
+}
+
+// HashEvent describes an operation of a suite of the suite package, as reported to Hooks.Hash.
+type HashEvent struct {
+	// Suite is the suite identifier, e.g. "P256_XMD:SHA-256_SSWU_RO_".
+	Suite string
+
+	// Operation is the operation, i.e. OperationHashToCurve, OperationEncodeToCurve, or OperationHashToScalar.
+	Operation string
+
+	// Duration is the time the operation took, including the expansion.
+	Duration time.Duration
+}
+
+// The operations reported in HashEvent.
+const (
+	OperationHashToCurve   = "hash_to_curve"
+	OperationEncodeToCurve = "encode_to_curve"
+	OperationHashToScalar  = "hash_to_scalar"
+)
+
+// Hooks are callbacks the module calls after its operations, e.g. to collect metrics with Prometheus or
+// OpenTelemetry without wrapping every call site. Nil callbacks are not called.
+//
+// The callbacks are called synchronously on the goroutine of the operation, which they delay, and concurrently if
+// the operations are. They must therefore be fast and safe for concurrent use, and must not call the module.
+type Hooks struct {
+	// Expand is called after each expansion of the expanders of this package, including those of hash_to_field and of
+	// the curve and suite packages. The outputs of NewExpandReader and NewFieldStream are not reported.
+	Expand func(ExpandEvent)
+
+	// Hash is called after each operation of the suites of the suite package.
+	Hash func(HashEvent)
+}
+
+// SetHooks sets the hooks module-wide, or removes them if hooks is nil. Without hooks, which is the default, the
+// operations only check that none is set. It is safe for concurrent use, but is meant to be set once at program
+// initialization.
+func SetHooks(hooks *Hooks) {
+	var (
+		expand internal.ExpandHook
+		hash   internal.HashHook
+	)
+
+	if hooks != nil && hooks.Expand != nil {
+		onExpand := hooks.Expand
+		expand = func(expander string, count, length uint, start time.Time) {
+			onExpand(ExpandEvent{Expander: expander, Count: count, Length: length, Duration: time.Since(start)})
+		}
+	}
+
+	if hooks != nil && hooks.Hash != nil {
+		onHash := hooks.Hash
+		hash = func(suite, operation string, start time.Time) {
+			onHash(HashEvent{Suite: suite, Operation: operation, Duration: time.Since(start)})
+		}
+	}
+
+	internal.SetHooks(expand, hash)
+}
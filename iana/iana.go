@@ -0,0 +1,154 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+// Package iana maps the IANA TLS NamedGroup (RFC 8446 section 4.2.7) and COSE elliptic curve (RFC 9053) identifiers
+// to the hash-to-curve suites of this module, and back, so that protocols negotiating groups numerically can resolve
+// the suite of a group. Each identifier maps to both the RO and NU suites of its curve.
+//
+// The edwards25519 suites have no TLS NamedGroup, since Ed25519 is only registered as a signature scheme, and
+// ristretto255 has neither a NamedGroup nor a COSE curve.
+package iana
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/bytemare/hash2curve"
+	"github.com/bytemare/hash2curve/cose"
+	"github.com/bytemare/hash2curve/internal/pubkey"
+)
+
+// NamedGroup is a TLS NamedGroup value, and converts to the tls.CurveID of crypto/tls.
+type NamedGroup uint16
+
+// The TLS NamedGroup values of the supported curves.
+const (
+	// NamedGroupSecp256k1 is secp256k1, deprecated for TLS by RFC 8422, but still registered.
+	NamedGroupSecp256k1 NamedGroup = 22
+
+	// NamedGroupSecp256r1 is P-256.
+	NamedGroupSecp256r1 NamedGroup = 23
+
+	// NamedGroupSecp384r1 is P-384.
+	NamedGroupSecp384r1 NamedGroup = 24
+
+	// NamedGroupSecp521r1 is P-521.
+	NamedGroupSecp521r1 NamedGroup = 25
+
+	// NamedGroupX25519 is X25519, i.e. curve25519.
+	NamedGroupX25519 NamedGroup = 29
+)
+
+// ErrUnsupportedCurve indicates a suite without an identifier in the registry, or an identifier without a suite.
+var ErrUnsupportedCurve = pubkey.ErrUnsupportedCurve
+
+var (
+	// namedGroups maps the CURVE_IDs to their TLS NamedGroup.
+	namedGroups = map[string]NamedGroup{
+		"secp256k1":  NamedGroupSecp256k1,
+		"P256":       NamedGroupSecp256r1,
+		"P384":       NamedGroupSecp384r1,
+		"P521":       NamedGroupSecp521r1,
+		"curve25519": NamedGroupX25519,
+	}
+
+	// coseCurves maps the CURVE_IDs to their COSE elliptic curve identifier.
+	coseCurves = map[string]int{
+		"P256":         cose.CurveP256,
+		"P384":         cose.CurveP384,
+		"P521":         cose.CurveP521,
+		"curve25519":   cose.CurveX25519,
+		"edwards25519": cose.CurveEd25519,
+		"secp256k1":    cose.CurveSecp256k1,
+	}
+)
+
+// String returns the registered name of the group, or "NamedGroup(n)" if it's not supported.
+func (g NamedGroup) String() string {
+	switch g {
+	case NamedGroupSecp256k1:
+		return "secp256k1"
+	case NamedGroupSecp256r1:
+		return "secp256r1"
+	case NamedGroupSecp384r1:
+		return "secp384r1"
+	case NamedGroupSecp521r1:
+		return "secp521r1"
+	case NamedGroupX25519:
+		return "x25519"
+	default:
+		return "NamedGroup(" + strconv.Itoa(int(g)) + ")"
+	}
+}
+
+// NamedGroupOf returns the TLS NamedGroup of the suite's curve. It returns an error wrapping ErrUnsupportedCurve for
+// the edwards25519 and ristretto255 suites, and hash2curve.ErrInvalidSuite if the suite is invalid.
+func NamedGroupOf(s hash2curve.Suite) (NamedGroup, error) {
+	if !s.Available() {
+		return 0, fmt.Errorf("%w: %s", hash2curve.ErrInvalidSuite, s)
+	}
+
+	g, ok := namedGroups[s.SuiteID().Curve]
+	if !ok {
+		return 0, fmt.Errorf("%w: %s has no TLS NamedGroup", ErrUnsupportedCurve, s.SuiteID().Curve)
+	}
+
+	return g, nil
+}
+
+// SuiteForNamedGroup returns the RO suite of the TLS NamedGroup if randomOracle is true, and its NU suite otherwise.
+// It returns an error wrapping ErrUnsupportedCurve if the group has no suite in this module.
+func SuiteForNamedGroup(g NamedGroup, randomOracle bool) (hash2curve.Suite, error) {
+	for curve, group := range namedGroups {
+		if group == g {
+			return suiteFor(curve, randomOracle), nil
+		}
+	}
+
+	return 0, fmt.Errorf("%w: %s", ErrUnsupportedCurve, g)
+}
+
+// COSECurve returns the COSE elliptic curve identifier of the suite's curve. It returns an error wrapping
+// ErrUnsupportedCurve for the ristretto255 suites, and hash2curve.ErrInvalidSuite if the suite is invalid.
+func COSECurve(s hash2curve.Suite) (int, error) {
+	if !s.Available() {
+		return 0, fmt.Errorf("%w: %s", hash2curve.ErrInvalidSuite, s)
+	}
+
+	crv, ok := coseCurves[s.SuiteID().Curve]
+	if !ok {
+		return 0, fmt.Errorf("%w: %s has no COSE curve", ErrUnsupportedCurve, s.SuiteID().Curve)
+	}
+
+	return crv, nil
+}
+
+// SuiteForCOSECurve returns the RO suite of the COSE elliptic curve identifier if randomOracle is true, and its NU
+// suite otherwise. It returns an error wrapping ErrUnsupportedCurve if the curve has no suite in this module.
+func SuiteForCOSECurve(crv int, randomOracle bool) (hash2curve.Suite, error) {
+	for curve, c := range coseCurves {
+		if c == crv {
+			return suiteFor(curve, randomOracle), nil
+		}
+	}
+
+	return 0, fmt.Errorf("%w: COSE curve %d", ErrUnsupportedCurve, crv)
+}
+
+// suiteFor returns the RO or NU suite of the curve, which must be implemented.
+func suiteFor(curve string, randomOracle bool) hash2curve.Suite {
+	for _, s := range hash2curve.AllSuites() {
+		if s.SuiteID().Curve == curve && s.RandomOracle() == randomOracle {
+			return s
+		}
+	}
+
+	panic(fmt.Errorf("%w: no suite for %s", hash2curve.ErrInvalidSuite, curve))
+}
@@ -0,0 +1,23 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package internal
+
+import "sync/atomic"
+
+var blinding atomic.Bool
+
+// SetBlinding enables or disables the randomized blinding of the mappings module-wide.
+func SetBlinding(enabled bool) {
+	blinding.Store(enabled)
+}
+
+// Blinding reports whether the randomized blinding of the mappings is enabled.
+func Blinding() bool {
+	return blinding.Load()
+}
@@ -0,0 +1,37 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+package internal
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+
+	"github.com/bytemare/hash2curve/internal/field"
+)
+
+// RandomBytes fills b from crypto/rand, and panics with an error wrapping ErrRandomness if it fails.
+func RandomBytes(b []byte) {
+	if _, err := rand.Read(b); err != nil {
+		panic(fmt.Errorf("%w: %w", ErrRandomness, err))
+	}
+}
+
+// RandomMask returns a uniformly random non-zero element of the field, for multiplicative masking, and panics with an
+// error wrapping ErrRandomness if crypto/rand fails.
+func RandomMask(fp *field.Field) *big.Int {
+	r, err := rand.Int(rand.Reader, new(big.Int).Sub(fp.Order(), big.NewInt(1)))
+	if err != nil {
+		panic(fmt.Errorf("%w: %w", ErrRandomness, err))
+	}
+
+	return r.Add(r, big.NewInt(1))
+}
@@ -0,0 +1,17 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build nomathbig
+
+package internal
+
+// RandomBytes panics with ErrRandomness, since crypto/rand depends on math/big. It is never called, as blinding can
+// only be enabled with the hash2curve package, which nomathbig builds don't include.
+func RandomBytes(_ []byte) {
+	panic(ErrRandomness)
+}
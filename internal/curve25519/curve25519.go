@@ -0,0 +1,229 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package curve25519 implements the Elligator 2 map of RFC 9380 to edwards25519, and the ristretto255 map of RFC 9496,
+// with the field arithmetic of filippo.io/edwards25519, without math/big.
+package curve25519
+
+import (
+	"fmt"
+
+	"filippo.io/edwards25519"
+	"filippo.io/edwards25519/field"
+
+	"github.com/bytemare/hash2curve/internal"
+)
+
+// canonicalEncodingLength is the length of the little-endian encoding of field elements.
+const canonicalEncodingLength = 32
+
+var (
+	// A is the coefficient 486662 of Curve25519.
+	A = element([]byte{
+		6, 109, 7, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	})
+
+	// InvSqrtD is 1 / sqrt(-486664), mapping the Curve25519 coordinates to those of edwards25519.
+	InvSqrtD = element([]byte{
+		6, 126, 69, 255, 170, 4, 110, 204, 130, 26, 125, 75, 209, 211, 161, 197,
+		126, 79, 252, 3, 220, 8, 123, 210, 187, 6, 160, 96, 244, 237, 38, 15,
+	})
+
+	minA   = fe().Negate(A)
+	zero   = fe().Zero()
+	one    = fe().One()
+	minOne = fe().Negate(one)
+	two    = fe().Add(one, one)
+
+	// nineteen and thirtyEight are 2^255 and 2^256 mod p.
+	nineteen    = element(append([]byte{19}, make([]byte, 31)...))
+	thirtyEight = element(append([]byte{38}, make([]byte, 31)...))
+)
+
+func fe() *field.Element {
+	return new(field.Element)
+}
+
+func element(input []byte) *field.Element {
+	return setElement(fe(), input)
+}
+
+func setElement(e *field.Element, input []byte) *field.Element {
+	if _, err := e.SetBytes(input); err != nil {
+		panic(err)
+	}
+
+	return e
+}
+
+func reverse(b []byte) {
+	l := len(b) - 1
+	for i := range len(b) / 2 {
+		b[i], b[l-i] = b[l-i], b[i]
+	}
+}
+
+// WideElement sets e to the big-endian integer of at most 64 bytes in b reduced modulo p, e.g. the 48 uniform bytes of
+// hash_to_field, and returns e.
+func WideElement(e *field.Element, b []byte) *field.Element {
+	var hi, lo [canonicalEncodingLength]byte
+
+	split := max(len(b)-canonicalEncodingLength, 0)
+	copy(hi[canonicalEncodingLength-split:], b[:split])
+	copy(lo[canonicalEncodingLength-len(b)+split:], b[split:])
+	reverse(hi[:])
+	reverse(lo[:])
+
+	defer clear(hi[:])
+	defer clear(lo[:])
+
+	// b = hi * 2^256 + lo, with 2^256 = 38 mod p. SetBytes ignores the top bit of lo, which is added back as
+	// 2^255 = 19 mod p.
+	top := int(lo[canonicalEncodingLength-1] >> 7)
+
+	var h, t field.Element
+
+	setElement(e, lo[:])
+	setElement(&h, hi[:])
+	e.Add(e, h.Multiply(&h, thirtyEight))
+
+	return e.Add(e, t.Select(nineteen, zero, top))
+}
+
+// Elligator2Edwards sets p to the Elligator 2 mapping of e to edwards25519, without clearing the cofactor, and
+// returns p.
+func Elligator2Edwards(p *edwards25519.Point, e *field.Element) *edwards25519.Point {
+	var u, v, x, y field.Element
+
+	Elligator2Montgomery(&u, &v, e)
+	MontgomeryToEdwards(&x, &y, &u, &v)
+
+	return AffineToEdwards(p, &x, &y)
+}
+
+// Elligator2Montgomery sets (x, y) to the Elligator 2 mapping of e to Curve25519.
+func Elligator2Montgomery(x, y, e *field.Element) {
+	var t1, x1, gx1, x2, gx2, root1, negRoot1, root2 field.Element
+
+	t1.Square(e)             // u^2
+	t1.Multiply(&t1, two)    // t1 = 2u^2
+	e1 := t1.Equal(minOne)   //
+	t1.Select(zero, &t1, e1) // if 2u^2 == -1, t1 = 0
+
+	x1.Add(&t1, one)       // t1 + 1
+	Invert(&x1, &x1)       // 1 / (t1 + 1)
+	x1.Multiply(&x1, minA) // x1 = -A / (t1 + 1).
+
+	gx1.Add(&x1, A)         // x1 + A
+	gx1.Multiply(&gx1, &x1) // x1 * (x1 + A)
+	gx1.Add(&gx1, one)      // x1 * (x1 + A) + 1
+	gx1.Multiply(&gx1, &x1) // x1 * (x1 * (x1 + A) + 1)
+
+	x2.Negate(&x1)      // -x1
+	x2.Subtract(&x2, A) // -x2 - A
+
+	gx2.Multiply(&t1, &gx1) // t1 * gx1
+
+	isSquare := Sqrt(&root1, &gx1) // root1 = (+) sqrt(gx1)
+	negRoot1.Negate(&root1)        // negRoot1 = (-) sqrt(gx1)
+	Sqrt(&root2, &gx2)             // root2 = (+) sqrt(gx2)
+
+	// if gx1 is square, set the point to (x1, -root1), i.e. with sgn0(y) == 1
+	// if not, set the point to (x2, +root2), i.e. with sgn0(y) == 0
+	x.Select(&x1, &x2, isSquare)
+	y.Select(&negRoot1, &root2, isSquare)
+}
+
+// AffineToEdwards sets p to the edwards25519 point of affine coordinates (x, y), and returns p. It panics with
+// internal.ErrInvalidPoint if the point is not on the curve.
+func AffineToEdwards(p *edwards25519.Point, x, y *field.Element) *edwards25519.Point {
+	var bx, by, t, z field.Element
+
+	bx.Set(x)
+	by.Set(y)
+	z.One()
+
+	if internal.Blinding() {
+		// (X : Y : Z : T) and (l * X : l * Y : l * Z : l * T) are the same point for any non-zero l.
+		mask := RandomMask()
+		bx.Multiply(&bx, mask)
+		by.Multiply(&by, mask)
+		z.Set(mask)
+	}
+
+	t.Multiply(&bx, y)
+
+	if _, err := p.SetExtendedCoordinates(&bx, &by, &z, &t); err != nil {
+		panic(fmt.Errorf("%w: %w", internal.ErrInvalidPoint, err))
+	}
+
+	return p
+}
+
+// MontgomeryToEdwards sets (x, y) to the edwards25519 equivalent of the Curve25519 point (u, v).
+func MontgomeryToEdwards(x, y, u, v *field.Element) {
+	Invert(x, v)
+	x.Multiply(x, u)
+	x.Multiply(x, InvSqrtD)
+	MontgomeryUToEdwardsY(y, u)
+}
+
+// MontgomeryUToEdwardsY sets y to the edwards25519 y-coordinate of the Curve25519 u-coordinate, and returns y.
+func MontgomeryUToEdwardsY(y, u *field.Element) *field.Element {
+	var u1, u2 field.Element
+
+	u1.Subtract(u, one)
+	u2.Add(u, one)
+
+	return y.Multiply(&u1, Invert(&u2, &u2))
+}
+
+// Invert sets e to 1 / x, computed as l / (l * x) with a random mask l if blinding is enabled, and returns e.
+func Invert(e, x *field.Element) *field.Element {
+	if !internal.Blinding() {
+		return e.Invert(x)
+	}
+
+	mask := RandomMask()
+	e.Multiply(x, mask)
+	e.Invert(e)
+
+	return e.Multiply(e, mask)
+}
+
+// Sqrt sets r to the non-negative square root of u, computed as the square root of (l * u) / l with a random mask l
+// if blinding is enabled, and returns 1 if u is square, or 0 otherwise.
+func Sqrt(r, u *field.Element) int {
+	v := one
+
+	if internal.Blinding() {
+		v = RandomMask()
+		u = fe().Multiply(u, v)
+	}
+
+	_, isSquare := r.SqrtRatio(u, v)
+
+	return isSquare
+}
+
+// RandomMask returns a random non-zero field element, for multiplicative masking.
+func RandomMask() *field.Element {
+	var b [canonicalEncodingLength]byte
+	defer internal.Wipe(b[:])
+
+	mask := fe()
+
+	for mask.Equal(zero) == 1 {
+		internal.RandomBytes(b[:])
+
+		// SetBytes only fails on the length of b.
+		_, _ = mask.SetBytes(b[:])
+	}
+
+	return mask
+}
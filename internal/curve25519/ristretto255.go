@@ -0,0 +1,113 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package curve25519
+
+import (
+	"fmt"
+
+	"filippo.io/edwards25519"
+	"filippo.io/edwards25519/field"
+
+	"github.com/bytemare/hash2curve/internal"
+)
+
+// Constants of RFC 9496 section 4.1, in little-endian.
+var (
+	d = element([]byte{
+		163, 120, 89, 19, 202, 77, 235, 117, 171, 216, 65, 65, 77, 10, 112, 0,
+		152, 232, 121, 119, 121, 64, 199, 140, 115, 254, 111, 43, 238, 108, 3, 82,
+	})
+	sqrtM1 = element([]byte{
+		176, 160, 14, 74, 39, 27, 238, 196, 120, 228, 47, 173, 6, 24, 67, 47,
+		167, 215, 251, 61, 153, 0, 77, 43, 11, 223, 193, 79, 128, 36, 131, 43,
+	})
+	sqrtADMinusOne = element([]byte{
+		27, 46, 123, 73, 160, 246, 151, 126, 189, 84, 120, 27, 12, 142, 157, 175,
+		253, 209, 245, 49, 201, 252, 60, 15, 172, 72, 131, 43, 191, 49, 105, 55,
+	})
+	invSqrtAMinusD = element([]byte{
+		234, 64, 93, 128, 170, 253, 200, 153, 190, 114, 65, 90, 23, 22, 47, 157,
+		64, 216, 1, 254, 145, 123, 194, 22, 162, 252, 175, 207, 5, 137, 108, 120,
+	})
+	oneMinusDSquared = element([]byte{
+		118, 193, 95, 148, 193, 9, 124, 226, 15, 53, 94, 205, 56, 161, 129, 44,
+		228, 223, 112, 190, 221, 171, 148, 153, 215, 224, 179, 178, 168, 114, 144, 2,
+	})
+	dMinusOneSquared = element([]byte{
+		32, 77, 237, 68, 170, 90, 173, 49, 153, 25, 30, 176, 44, 74, 158, 210,
+		235, 78, 155, 82, 47, 211, 220, 76, 65, 34, 108, 246, 122, 179, 104, 89,
+	})
+)
+
+// MapToPoint implements MAP(t) of RFC 9496 section 4.3.4, returning an Edwards25519 point in the ristretto255 coset.
+func MapToPoint(t *field.Element) *edwards25519.Point {
+	r := fe().Square(t)
+	r.Multiply(sqrtM1, r)                          // r = SQRT_M1 * t^2
+	u := fe().Add(r, one)                          //
+	u.Multiply(u, oneMinusDSquared)                // u = (r + 1) * ONE_MINUS_D_SQ
+	v := fe().Multiply(r, d)                       //
+	v.Subtract(minOne, v)                          //
+	v.Multiply(v, fe().Add(r, d))                  // v = (-1 - r*D) * (r + D)
+	s, wasSquare := fe().SqrtRatio(u, v)           // (was_square, s) = SQRT_RATIO_M1(u, v)
+	sPrime := fe().Multiply(s, t)                  //
+	sPrime.Absolute(sPrime).Negate(sPrime)         // s_prime = -CT_ABS(s*t)
+	s.Select(s, sPrime, wasSquare)                 // s = CT_SELECT(s IF was_square ELSE s_prime)
+	c := fe().Select(minOne, r, wasSquare)         // c = CT_SELECT(-1 IF was_square ELSE r)
+	n := fe().Subtract(r, one)                     //
+	n.Multiply(n, c).Multiply(n, dMinusOneSquared) //
+	n.Subtract(n, v)                               // N = c * (r - 1) * D_MINUS_ONE_SQ - v
+	s2 := fe().Square(s)                           //
+	w0 := fe().Add(s, s)                           //
+	w0.Multiply(w0, v)                             // w0 = 2 * s * v
+	w1 := fe().Multiply(n, sqrtADMinusOne)         // w1 = N * SQRT_AD_MINUS_ONE
+	w2 := fe().Subtract(one, s2)                   // w2 = 1 - s^2
+	w3 := fe().Add(one, s2)                        // w3 = 1 + s^2
+
+	p, err := new(edwards25519.Point).SetExtendedCoordinates(
+		fe().Multiply(w0, w3),
+		fe().Multiply(w2, w1),
+		fe().Multiply(w1, w3),
+		fe().Multiply(w0, w2),
+	)
+	if err != nil {
+		panic(fmt.Errorf("%w: ristretto255 map output: %w", internal.ErrInvalidPoint, err))
+	}
+
+	return p
+}
+
+// Encode implements the ristretto255 ENCODE function of RFC 9496 section 4.3.2.
+func Encode(p *edwards25519.Point) []byte {
+	x0, y0, z0, t0 := p.ExtendedCoordinates()
+
+	u1 := fe().Multiply(fe().Add(z0, y0), fe().Subtract(z0, y0)) // u1 = (z0 + y0) * (z0 - y0)
+	u2 := fe().Multiply(x0, y0)                                  // u2 = x0 * y0
+
+	invSqrt, _ := fe().SqrtRatio(one, fe().Multiply(u1, fe().Square(u2))) // (_, invsqrt) = SQRT_RATIO_M1(1, u1 * u2^2)
+	den1 := fe().Multiply(invSqrt, u1)                                    // den1 = invsqrt * u1
+	den2 := fe().Multiply(invSqrt, u2)                                    // den2 = invsqrt * u2
+	zInv := fe().Multiply(den1, den2)                                     //
+	zInv.Multiply(zInv, t0)                                               // z_inv = den1 * den2 * t0
+
+	ix0 := fe().Multiply(x0, sqrtM1) // ix0 = x0 * SQRT_M1
+	iy0 := fe().Multiply(y0, sqrtM1) // iy0 = y0 * SQRT_M1
+	enchantedDenominator := fe().Multiply(den1, invSqrtAMinusD)
+	rotate := fe().Multiply(t0, zInv).IsNegative() // rotate = IS_NEGATIVE(t0 * z_inv)
+
+	x := fe().Select(iy0, x0, rotate)                         // x = CT_SELECT(iy0 IF rotate ELSE x0)
+	y := fe().Select(ix0, y0, rotate)                         // y = CT_SELECT(ix0 IF rotate ELSE y0)
+	denInv := fe().Select(enchantedDenominator, den2, rotate) // den_inv = CT_SELECT(enchanted IF rotate ELSE den2)
+
+	y.Select(fe().Negate(y), y, fe().Multiply(x, zInv).IsNegative()) // y = CT_NEG(y, IS_NEGATIVE(x * z_inv))
+
+	s := fe().Subtract(z0, y)
+	s.Multiply(s, denInv).Absolute(s) // s = CT_ABS(den_inv * (z0 - y))
+
+	return s.Bytes()
+}
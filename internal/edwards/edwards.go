@@ -0,0 +1,300 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+// Package edwards implements complete twisted Edwards curves over prime fields with big.Int arithmetic, and the
+// Elligator 2 method of RFC 9380 on their birationally equivalent Montgomery curves.
+package edwards
+
+import (
+	"math/big"
+
+	"github.com/bytemare/hash2curve/internal/field"
+)
+
+// Curve is the twisted Edwards curve a * x^2 + y^2 = 1 + d * x^2 * y^2, birationally equivalent to the Montgomery
+// curve K * t^2 = s^3 + J * s^2 + s, with a = (J + 2) / K and d = (J - 2) / K. a must be a square and d a non-square,
+// for the addition law to be complete.
+type Curve struct {
+	Field      field.Field
+	a, d       *big.Int
+	j, k, z    *big.Int
+	jOverK     *big.Int
+	invKSquare *big.Int
+	cofactor   uint // the base 2 logarithm of h_eff
+}
+
+// NewCurve returns the curve over the field of the prime with the Montgomery parameters j and k, the non-square z of
+// Elligator 2, and the cofactor h_eff = 2^log2Cofactor.
+func NewCurve(prime, j, k, z *big.Int, log2Cofactor uint) *Curve {
+	fp := field.NewField(prime)
+	c := &Curve{
+		Field:      fp,
+		a:          new(big.Int),
+		d:          new(big.Int),
+		j:          fp.Mod(new(big.Int).Set(j)),
+		k:          fp.Mod(new(big.Int).Set(k)),
+		z:          fp.Mod(new(big.Int).Set(z)),
+		jOverK:     new(big.Int),
+		invKSquare: new(big.Int),
+		cofactor:   log2Cofactor,
+	}
+
+	invK := new(big.Int)
+	fp.Inv(invK, c.k)
+	fp.Mul(c.jOverK, c.j, invK)
+	fp.Square(c.invKSquare, invK)
+	fp.Mul(c.a, new(big.Int).Add(c.j, big.NewInt(2)), invK)
+	fp.Mul(c.d, fp.Sub(new(big.Int), c.j, big.NewInt(2)), invK)
+
+	return c
+}
+
+// NewUntwistedCurve returns the Edwards curve x^2 + y^2 = 1 + d * x^2 * y^2, i.e. with a = 1, for which the
+// Montgomery parameters are J = 2 * (1 + d) / (1 - d) and K = 4 / (1 - d).
+func NewUntwistedCurve(prime, d, z *big.Int, log2Cofactor uint) *Curve {
+	fp := field.NewField(prime)
+	j, k, oneMinusD := new(big.Int), new(big.Int), new(big.Int)
+
+	fp.Sub(oneMinusD, fp.One(), fp.Mod(new(big.Int).Set(d)))
+	fp.Inv(oneMinusD, oneMinusD)
+	fp.Add(j, fp.One(), d)
+	fp.Mul(j, j, big.NewInt(2))
+	fp.Mul(j, j, oneMinusD)
+	fp.Mul(k, big.NewInt(4), oneMinusD)
+
+	return NewCurve(prime, j, k, z, log2Cofactor)
+}
+
+// Elligator2 implements map_to_curve_elligator2 of RFC 9380 section 6.7.1 for the Montgomery curve, and returns the
+// coordinates (s, t) of the point. fe must be reduced.
+func (c *Curve) Elligator2(fe *big.Int) (s, t *big.Int) {
+	fp := c.Field
+	var x1, gx1, x2, gx2, tv big.Int
+	s, t = new(big.Int), new(big.Int)
+
+	fp.Square(&tv, fe)                                          // u^2
+	fp.Mul(&tv, c.z, &tv)                                       // Z * u^2
+	fp.Add(&tv, &tv, fp.One())                                  // 1 + Z * u^2
+	fp.Inv(&tv, &tv)                                            // inv0(1 + Z * u^2)
+	fp.Mul(&x1, fp.Neg(new(big.Int), c.jOverK), &tv)            // 1. x1 = -(J / K) * inv0(1 + Z * u^2)
+	fp.CondMov(&x1, &x1, fp.Neg(&tv, c.jOverK), fp.IsZero(&x1)) // 2. If x1 == 0, set x1 = -(J / K)
+	c.montgomeryRHS(&gx1, &x1)                                  // 3. gx1 = x1^3 + (J / K) * x1^2 + x1 / K^2
+	fp.Neg(&x2, &x1)
+	fp.Sub(&x2, &x2, c.jOverK) // 4. x2 = -x1 - (J / K)
+	c.montgomeryRHS(&gx2, &x2) // 5. gx2 = x2^3 + (J / K) * x2^2 + x2 / K^2
+
+	// 6. If is_square(gx1), set x = x1, y = sqrt(gx1) with sgn0(y) == 1.
+	// 7. Else set x = x2, y = sqrt(gx2) with sgn0(y) == 0.
+	isSquare := fp.IsSquare(&gx1)
+	fp.CondMov(s, &x2, &x1, isSquare)
+	fp.CondMov(&tv, &gx2, &gx1, isSquare)
+	fp.SquareRoot(t, &tv)
+
+	sign := uint(0)
+	if isSquare {
+		sign = 1
+	}
+
+	fp.CondMov(t, t, fp.Neg(&tv, t), fp.Sgn0(t) != sign)
+
+	fp.Mul(s, s, c.k) // 8. s = x * K
+	fp.Mul(t, t, c.k) // 9. t = y * K
+
+	return s, t
+}
+
+// montgomeryRHS sets res to x^3 + (J / K) * x^2 + x / K^2.
+func (c *Curve) montgomeryRHS(res, x *big.Int) {
+	fp := c.Field
+	var tv big.Int
+
+	fp.Add(&tv, x, c.jOverK)
+	fp.Mul(&tv, &tv, x)
+	fp.Add(&tv, &tv, c.invKSquare)
+	fp.Mul(res, &tv, x)
+}
+
+// MontgomeryToEdwards implements the rational map of RFC 9380 appendix D.1, (x, y) = (s / t, (s - 1) / (s + 1)), which
+// sends the exceptional points to the identity.
+func (c *Curve) MontgomeryToEdwards(s, t *big.Int) (x, y *big.Int) {
+	fp := c.Field
+	var tv1, tv2 big.Int
+	x, y = new(big.Int), new(big.Int)
+
+	fp.Add(&tv1, s, fp.One())                   // 1. tv1 = s + 1
+	fp.Mul(&tv2, &tv1, t)                       // 2. tv2 = tv1 * t
+	fp.Inv(&tv2, &tv2)                          // 3. tv2 = inv0(tv2)
+	fp.Mul(x, &tv2, &tv1)                       // 4. v = tv2 * tv1
+	fp.Mul(x, x, s)                             // 5. v = v * s
+	fp.Mul(y, &tv2, t)                          // 6. w = tv2 * t
+	fp.Sub(&tv1, s, fp.One())                   // 7. tv1 = s - 1
+	fp.Mul(y, y, &tv1)                          // 8. w = w * tv1
+	fp.CondMov(y, y, fp.One(), fp.IsZero(&tv2)) // 9. w = CMOV(w, 1, tv2 == 0)
+
+	return x, y
+}
+
+// EdwardsToMontgomery returns the coordinates (s, t) = ((1 + y) / (1 - y), s / x) of the point on the Montgomery
+// curve, and false for the identity, which maps to the point at infinity. The point (0, -1) maps to (0, 0).
+func (c *Curve) EdwardsToMontgomery(x, y *big.Int) (s, t *big.Int, ok bool) {
+	fp := c.Field
+	var tv big.Int
+	s, t = new(big.Int), new(big.Int)
+
+	if fp.IsZero(x) {
+		return s, t, y.Cmp(fp.One()) != 0
+	}
+
+	fp.Sub(&tv, fp.One(), y)
+	fp.Inv(&tv, &tv)
+	fp.Add(s, fp.One(), y)
+	fp.Mul(s, s, &tv)
+	fp.Inv(&tv, x)
+	fp.Mul(t, s, &tv)
+
+	return s, t, true
+}
+
+// Add returns the sum of the points (x1, y1) and (x2, y2), with the complete addition law.
+func (c *Curve) Add(x1, y1, x2, y2 *big.Int) (x, y *big.Int) {
+	fp := c.Field
+	var t, num, den big.Int
+	x, y = new(big.Int), new(big.Int)
+
+	fp.Mul(&t, x1, x2)
+	fp.Mul(&t, &t, y1)
+	fp.Mul(&t, &t, y2)
+	fp.Mul(&t, &t, c.d) // d * x1 * x2 * y1 * y2
+
+	// x = (x1 * y2 + y1 * x2) / (1 + t)
+	fp.Mul(&num, x1, y2)
+	fp.Mul(&den, y1, x2)
+	fp.Add(&num, &num, &den)
+	fp.Add(&den, fp.One(), &t)
+	fp.Inv(&den, &den)
+	fp.Mul(x, &num, &den)
+
+	// y = (y1 * y2 - a * x1 * x2) / (1 - t)
+	fp.Mul(&num, x1, x2)
+	fp.Mul(&num, &num, c.a)
+	fp.Mul(&den, y1, y2)
+	fp.Sub(&num, &den, &num)
+	fp.Sub(&den, fp.One(), &t)
+	fp.Inv(&den, &den)
+	fp.Mul(y, &num, &den)
+
+	return x, y
+}
+
+// FieldPrime returns a copy of the prime of the field of the curve.
+func (c *Curve) FieldPrime() *big.Int {
+	return new(big.Int).Set(c.Field.Order())
+}
+
+// Cofactor returns the cofactor h_eff of the curve.
+func (c *Curve) Cofactor() *big.Int {
+	return new(big.Int).Lsh(big.NewInt(1), c.cofactor)
+}
+
+// ClearCofactor returns the point multiplied by h_eff.
+func (c *Curve) ClearCofactor(x, y *big.Int) (cx, cy *big.Int) {
+	cx, cy = x, y
+	for range c.cofactor {
+		cx, cy = c.Add(cx, cy, cx, cy)
+	}
+
+	return cx, cy
+}
+
+// MapToCurve maps the field element to a point of the Edwards curve, with Elligator 2 and the rational map, without
+// clearing the cofactor. fe must be reduced.
+func (c *Curve) MapToCurve(fe *big.Int) (x, y *big.Int) {
+	return c.MontgomeryToEdwards(c.Elligator2(fe))
+}
+
+// IsOnCurve returns whether the reduced coordinates are those of a point on the Edwards curve.
+func (c *Curve) IsOnCurve(x, y *big.Int) bool {
+	fp := c.Field
+	var x2, y2, lhs, rhs big.Int
+
+	fp.Square(&x2, x)
+	fp.Square(&y2, y)
+	fp.Mul(&lhs, c.a, &x2)
+	fp.Add(&lhs, &lhs, &y2)
+	fp.Mul(&rhs, &x2, &y2)
+	fp.Mul(&rhs, &rhs, c.d)
+	fp.Add(&rhs, &rhs, fp.One())
+
+	return lhs.Cmp(&rhs) == 0
+}
+
+// RecoverX returns the x coordinate of the Edwards point with the reduced y coordinate and the parity of x, or false if
+// there is none.
+func (c *Curve) RecoverX(y *big.Int, odd bool) (*big.Int, bool) {
+	fp := c.Field
+	var u, v big.Int
+	x := new(big.Int)
+
+	// x^2 = (1 - y^2) / (a - d * y^2)
+	fp.Square(&u, y)
+	fp.Mul(&v, &u, c.d)
+	fp.Sub(&v, c.a, &v)
+	fp.Sub(&u, fp.One(), &u)
+	fp.Inv(&v, &v)
+	fp.Mul(&u, &u, &v)
+	fp.SquareRoot(x, &u)
+	fp.Square(&v, x)
+
+	if v.Cmp(&u) != 0 {
+		return nil, false
+	}
+
+	if fp.IsZero(x) && odd {
+		return nil, false
+	}
+
+	if (x.Bit(0) == 1) != odd {
+		fp.Neg(x, x)
+	}
+
+	return x, true
+}
+
+// RecoverT returns the t coordinate of the point on the Montgomery curve with the reduced s coordinate and the parity
+// of t, or false if there is none.
+func (c *Curve) RecoverT(s *big.Int, odd bool) (*big.Int, bool) {
+	fp := c.Field
+	var rhs, sq big.Int
+	t := new(big.Int)
+
+	// K * t^2 = s^3 + J * s^2 + s
+	fp.Add(&rhs, s, c.j)
+	fp.Mul(&rhs, &rhs, s)
+	fp.Add(&rhs, &rhs, fp.One())
+	fp.Mul(&rhs, &rhs, s)
+	fp.Inv(&sq, c.k)
+	fp.Mul(&rhs, &rhs, &sq)
+	fp.SquareRoot(t, &rhs)
+	fp.Square(&sq, t)
+
+	if sq.Cmp(&rhs) != 0 {
+		return nil, false
+	}
+
+	if fp.IsZero(t) && odd {
+		return nil, false
+	}
+
+	if (t.Bit(0) == 1) != odd {
+		fp.Neg(t, t)
+	}
+
+	return t, true
+}
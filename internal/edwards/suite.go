@@ -0,0 +1,175 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+package edwards
+
+import (
+	"crypto"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/bytemare/hash2curve"
+)
+
+var (
+	errEncodingLength = errors.New("invalid encoding length")
+	errNonCanonical   = errors.New("non-canonical encoding")
+	errNotOnCurve     = errors.New("the coordinate is not that of a point on the curve")
+)
+
+// Suite implements the hash-to-curve suites of a curve with expand_message_xmd and Elligator 2, as RFC 9380 does for
+// edwards25519.
+type Suite struct {
+	*Curve
+	order        *big.Int
+	hash         crypto.Hash
+	secLength    uint
+	scalarLength uint
+	byteLen      int
+}
+
+// NewSuite returns the suite for the curve, with the order of its prime-order subgroup, the hash function of
+// expand_message_xmd, and the security level k in bits.
+func NewSuite(c *Curve, order *big.Int, h crypto.Hash, k uint) *Suite {
+	return &Suite{
+		Curve:        c,
+		order:        order,
+		hash:         h,
+		secLength:    hash2curve.SecurityLength(c.Field.Order(), k),
+		scalarLength: hash2curve.SecurityLength(order, k),
+		byteLen:      c.Field.ByteLen(),
+	}
+}
+
+// HashToCurve implements hash_to_curve, and returns the affine coordinates of the point on the Edwards curve.
+func (s *Suite) HashToCurve(input, dst []byte) (x, y *big.Int) {
+	u := hash2curve.HashToFieldXMD(s.hash, input, dst, 2, 1, s.secLength, s.Field.Order())
+	x0, y0 := s.MapToCurve(u[0])
+	x1, y1 := s.MapToCurve(u[1])
+	hash2curve.WipeInts(u...)
+
+	return s.ClearCofactor(s.Add(x0, y0, x1, y1))
+}
+
+// EncodeToCurve implements encode_to_curve, and returns the affine coordinates of the point on the Edwards curve.
+func (s *Suite) EncodeToCurve(input, dst []byte) (x, y *big.Int) {
+	u := hash2curve.HashToFieldXMD(s.hash, input, dst, 1, 1, s.secLength, s.Field.Order())
+	x, y = s.MapToCurve(u[0])
+	hash2curve.WipeInts(u...)
+
+	return s.ClearCofactor(x, y)
+}
+
+// HashToScalar returns a safe mapping of the input to a scalar modulo the order of the prime-order subgroup.
+func (s *Suite) HashToScalar(input, dst []byte) *big.Int {
+	return hash2curve.HashToFieldXMD(s.hash, input, dst, 1, 1, s.scalarLength, s.order)[0]
+}
+
+// Order returns a copy of the order of the prime-order subgroup.
+func (s *Suite) Order() *big.Int {
+	return new(big.Int).Set(s.order)
+}
+
+// EncodeEdwards returns the little-endian encoding of y, with the parity of x in the most significant bit, as in
+// RFC 8032.
+func (s *Suite) EncodeEdwards(x, y *big.Int) []byte {
+	return s.encode(y, x.Bit(0) == 1)
+}
+
+// DecodeEdwards returns the affine coordinates of the point encoded as by EncodeEdwards, or an error wrapping
+// hash2curve.ErrInvalidPoint.
+func (s *Suite) DecodeEdwards(b []byte) (x, y *big.Int, err error) {
+	y, odd, err := s.decode(b)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	x, ok := s.RecoverX(y, odd)
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: %w", hash2curve.ErrInvalidPoint, errNotOnCurve)
+	}
+
+	return x, y, nil
+}
+
+// EncodeMontgomery returns the little-endian encoding of the s coordinate of the point on the Montgomery curve, with
+// the parity of its t coordinate in the most significant bit. The point at infinity, i.e. the identity, is encoded as
+// s = 0 with the parity bit set, since (0, 0) is the only point with s = 0.
+func (s *Suite) EncodeMontgomery(x, y *big.Int) []byte {
+	ms, mt, ok := s.EdwardsToMontgomery(x, y)
+	if !ok {
+		return s.encode(ms, true)
+	}
+
+	return s.encode(ms, mt.Bit(0) == 1)
+}
+
+// DecodeMontgomery returns the affine coordinates on the Edwards curve of the point encoded as by EncodeMontgomery, or
+// an error wrapping hash2curve.ErrInvalidPoint.
+func (s *Suite) DecodeMontgomery(b []byte) (x, y *big.Int, err error) {
+	ms, odd, err := s.decode(b)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if s.Field.IsZero(ms) && odd {
+		return big.NewInt(0), big.NewInt(1), nil
+	}
+
+	mt, ok := s.RecoverT(ms, odd)
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: %w", hash2curve.ErrInvalidPoint, errNotOnCurve)
+	}
+
+	if s.Field.IsZero(mt) {
+		// The rational map sends (0, 0) to the identity, but its inverse is (0, -1).
+		return big.NewInt(0), new(big.Int).Sub(s.Field.Order(), big.NewInt(1)), nil
+	}
+
+	x, y = s.MontgomeryToEdwards(ms, mt)
+
+	return x, y, nil
+}
+
+func (s *Suite) encode(v *big.Int, odd bool) []byte {
+	b := v.FillBytes(make([]byte, s.byteLen))
+
+	for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+		b[i], b[j] = b[j], b[i]
+	}
+
+	if odd {
+		b[len(b)-1] |= 0x80
+	}
+
+	return b
+}
+
+func (s *Suite) decode(b []byte) (*big.Int, bool, error) {
+	if len(b) != s.byteLen {
+		return nil, false, fmt.Errorf("%w: %w", hash2curve.ErrInvalidPoint, errEncodingLength)
+	}
+
+	be := make([]byte, len(b))
+	for i := range b {
+		be[len(b)-1-i] = b[i]
+	}
+
+	odd := be[0]&0x80 != 0
+	be[0] &= 0x7f
+
+	v := new(big.Int).SetBytes(be)
+	if !s.Field.IsCanonical(v) {
+		return nil, false, fmt.Errorf("%w: %w", hash2curve.ErrInvalidPoint, errNonCanonical)
+	}
+
+	return v, odd, nil
+}
@@ -0,0 +1,46 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package internal
+
+import "errors"
+
+var (
+	// ErrZeroLengthDST indicates an empty or nil domain separation tag.
+	ErrZeroLengthDST = errors.New("zero-length DST")
+
+	// ErrShortDST indicates a domain separation tag shorter than the 16 bytes recommended by RFC 9380, when the strict
+	// DST policy is enabled.
+	ErrShortDST = errors.New("DST is shorter than 16 bytes")
+
+	// ErrInputTooLong indicates an input message longer than the configured limit.
+	ErrInputTooLong = errors.New("input is too long")
+
+	// ErrInvalidSuite indicates an unknown, unsupported, or malformed suite.
+	ErrInvalidSuite = errors.New("invalid suite")
+
+	// ErrLengthTooLarge indicates a requested expansion length beyond what the expander supports.
+	ErrLengthTooLarge = errors.New("requested byte length is too high")
+
+	// ErrDSTHashTooLong indicates that the hash function can't shorten an oversize DST to at most 255 bytes.
+	ErrDSTHashTooLong = errors.New("hash output for the oversize DST is too long")
+
+	// ErrInsufficientSecurity indicates a hash function or a hash_to_field length L that does not meet the target
+	// security level of a curve.
+	ErrInsufficientSecurity = errors.New("insufficient security level")
+
+	// ErrUnsupportedHash indicates a hash function that is neither a fixed length hash function nor an extendable
+	// output function, or that is not available.
+	ErrUnsupportedHash = errors.New("unsupported hash function")
+
+	// ErrInvalidPoint indicates a point that could not be built or is not on the curve.
+	ErrInvalidPoint = errors.New("invalid point")
+
+	// ErrRandomness indicates a failure of the random number generator.
+	ErrRandomness = errors.New("random number generator failure")
+)
@@ -0,0 +1,353 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package extension implements the finite fields GF(p^m) = GF(p)[u] / (u^m - β) with big.Int arithmetic, for the
+// groups of pairing-friendly curves defined over extensions of their base field. With m = 1, it is GF(p).
+package extension
+
+import (
+	"math/big"
+
+	"github.com/bytemare/hash2curve/internal/field"
+)
+
+// Element is the element c[0] + c[1] * u + ... + c[m-1] * u^(m-1) of GF(p^m), with reduced coefficients.
+type Element []*big.Int
+
+// Field is the extension GF(p^m) = GF(p)[u] / (u^m - β) of degree m.
+type Field struct {
+	Base        field.Field
+	nonResidue  *big.Int
+	order       *big.Int // q = p^m
+	qMinus1div2 *big.Int
+	qMinus2     *big.Int
+	c3          *big.Int // (c2 - 1) / 2, with c2 the odd part of q - 1
+	c5          Element  // c4^c2, with c4 a non-square
+	c1          uint     // the 2-adicity of q - 1
+	degree      int
+}
+
+// NewField returns the extension of degree m of GF(prime), with the reduction polynomial u^m - nonResidue, which must
+// be irreducible. The non-residue is ignored if m = 1.
+func NewField(prime *big.Int, m int, nonResidue *big.Int) *Field {
+	f := &Field{
+		Base:       field.NewField(prime),
+		nonResidue: new(big.Int).Mod(nonResidue, prime),
+		order:      new(big.Int).Exp(prime, big.NewInt(int64(m)), nil),
+		degree:     m,
+	}
+
+	qMinus1 := new(big.Int).Sub(f.order, big.NewInt(1))
+	f.qMinus1div2 = new(big.Int).Rsh(qMinus1, 1)
+	f.qMinus2 = new(big.Int).Sub(f.order, big.NewInt(2))
+	f.c1 = qMinus1.TrailingZeroBits()
+	c2 := new(big.Int).Rsh(qMinus1, f.c1)
+	f.c3 = new(big.Int).Rsh(c2, 1)
+
+	// c4 is the first non-square of 2, 3, ... in GF(p), or of u, u + 1, ... in the extensions, as all the elements of
+	// GF(p) are squares in the extensions of even degree.
+	c4 := f.New()
+	if m == 1 {
+		c4[0].SetInt64(2)
+	} else {
+		c4[1].SetInt64(1)
+	}
+
+	for f.IsSquare(c4) {
+		f.Base.Add(c4[0], c4[0], f.Base.One())
+	}
+
+	f.c5 = f.Exp(c4, c4, c2)
+
+	return f
+}
+
+// Degree returns the extension degree m.
+func (f *Field) Degree() int {
+	return f.degree
+}
+
+// Order returns the number of elements q = p^m of the field.
+func (f *Field) Order() *big.Int {
+	return f.order
+}
+
+// New returns a new zero element.
+func (f *Field) New() Element {
+	e := make(Element, f.degree)
+	for i := range e {
+		e[i] = new(big.Int)
+	}
+
+	return e
+}
+
+// Element returns the element with the given coefficients, reduced, and 0 for the missing ones.
+func (f *Field) Element(coefficients ...*big.Int) Element {
+	e := f.New()
+	for i, c := range coefficients {
+		e[i].Mod(c, f.Base.Order())
+	}
+
+	return e
+}
+
+// IsCanonical returns whether e has m canonical coefficients.
+func (f *Field) IsCanonical(e Element) bool {
+	if len(e) != f.degree {
+		return false
+	}
+
+	for _, c := range e {
+		if c == nil || !f.Base.IsCanonical(c) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Set sets res to x, and returns res.
+func (f *Field) Set(res, x Element) Element {
+	for i := range res {
+		res[i].Set(x[i])
+	}
+
+	return res
+}
+
+// IsZero returns whether x is 0.
+func (f *Field) IsZero(x Element) bool {
+	for _, c := range x {
+		if c.Sign() != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Equal returns whether x and y are equal.
+func (f *Field) Equal(x, y Element) bool {
+	for i := range x {
+		if x[i].Cmp(y[i]) != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Add sets res to x + y, and returns res.
+func (f *Field) Add(res, x, y Element) Element {
+	for i := range res {
+		f.Base.Add(res[i], x[i], y[i])
+	}
+
+	return res
+}
+
+// Sub sets res to x - y, and returns res.
+func (f *Field) Sub(res, x, y Element) Element {
+	for i := range res {
+		f.Base.Sub(res[i], x[i], y[i])
+	}
+
+	return res
+}
+
+// Neg sets res to -x, and returns res.
+func (f *Field) Neg(res, x Element) Element {
+	for i := range res {
+		f.Base.Neg(res[i], x[i])
+	}
+
+	return res
+}
+
+// Mul sets res to x * y, and returns res. res may alias x or y.
+func (f *Field) Mul(res, x, y Element) Element {
+	m := f.degree
+	product := make([]big.Int, 2*m-1)
+
+	var tv big.Int
+
+	for i := range m {
+		for j := range m {
+			product[i+j].Add(&product[i+j], tv.Mul(x[i], y[j]))
+		}
+	}
+
+	// u^(m + i) = β * u^i.
+	for i := 2*m - 2; i >= m; i-- {
+		product[i-m].Add(&product[i-m], tv.Mul(&product[i], f.nonResidue))
+	}
+
+	for i := range res {
+		res[i].Mod(&product[i], f.Base.Order())
+	}
+
+	return res
+}
+
+// Square sets res to x^2, and returns res.
+func (f *Field) Square(res, x Element) Element {
+	return f.Mul(res, x, x)
+}
+
+// MulBase sets res to x * c, with c in GF(p), and returns res.
+func (f *Field) MulBase(res, x Element, c *big.Int) Element {
+	for i := range res {
+		f.Base.Mul(res[i], x[i], c)
+	}
+
+	return res
+}
+
+// Exp sets res to x^n, and returns res.
+func (f *Field) Exp(res, x Element, n *big.Int) Element {
+	acc := f.Element(big.NewInt(1))
+	base := f.Set(f.New(), x)
+
+	for i := n.BitLen() - 1; i >= 0; i-- {
+		f.Square(acc, acc)
+
+		if n.Bit(i) == 1 {
+			f.Mul(acc, acc, base)
+		}
+	}
+
+	return f.Set(res, acc)
+}
+
+// Inv sets res to the inverse of x, or 0 if x is 0, and returns res.
+func (f *Field) Inv(res, x Element) Element {
+	base := f.Base
+
+	switch f.degree {
+	case 1:
+		base.Inv(res[0], x[0])
+	case 2:
+		// 1 / (a + b * u) = (a - b * u) / (a^2 - β * b^2).
+		var t, tv big.Int
+
+		base.Square(&t, x[0])
+		base.Square(&tv, x[1])
+		base.Mul(&tv, &tv, f.nonResidue)
+		base.Sub(&t, &t, &tv)
+		base.Inv(&t, &t)
+		base.Mul(res[0], x[0], &t)
+		base.Mul(&tv, x[1], &t)
+		base.Neg(res[1], &tv)
+	case 3:
+		var c0, c1, c2, t, tv big.Int
+
+		// c0 = a0^2 - β * a1 * a2, c1 = β * a2^2 - a0 * a1, c2 = a1^2 - a0 * a2.
+		base.Square(&c0, x[0])
+		base.Mul(&tv, x[1], x[2])
+		base.Mul(&tv, &tv, f.nonResidue)
+		base.Sub(&c0, &c0, &tv)
+		base.Square(&c1, x[2])
+		base.Mul(&c1, &c1, f.nonResidue)
+		base.Mul(&tv, x[0], x[1])
+		base.Sub(&c1, &c1, &tv)
+		base.Square(&c2, x[1])
+		base.Mul(&tv, x[0], x[2])
+		base.Sub(&c2, &c2, &tv)
+
+		// t = a0 * c0 + β * (a2 * c1 + a1 * c2), the norm of x.
+		base.Mul(&t, x[2], &c1)
+		base.Mul(&tv, x[1], &c2)
+		base.Add(&t, &t, &tv)
+		base.Mul(&t, &t, f.nonResidue)
+		base.Mul(&tv, x[0], &c0)
+		base.Add(&t, &t, &tv)
+		base.Inv(&t, &t)
+
+		base.Mul(res[0], &c0, &t)
+		base.Mul(res[1], &c1, &t)
+		base.Mul(res[2], &c2, &t)
+	case 4:
+		// With y = x(-u), x * y = n0 + n2 * u^2 is in GF(p)[u^2], and 1 / x = y / (n0 + n2 * u^2).
+		y, n := f.Neg(f.New(), x), f.New()
+		f.Set(y[:1], x[:1])
+		f.Set(y[2:3], x[2:3])
+		f.Mul(n, x, y)
+
+		var t, tv big.Int
+
+		base.Square(&t, n[0])
+		base.Square(&tv, n[2])
+		base.Mul(&tv, &tv, f.nonResidue)
+		base.Sub(&t, &t, &tv)
+		base.Inv(&t, &t)
+		base.Mul(n[0], n[0], &t)
+		base.Mul(&tv, n[2], &t)
+		base.Neg(n[2], &tv)
+		f.Mul(res, y, n)
+	default:
+		f.Exp(res, x, f.qMinus2)
+	}
+
+	return res
+}
+
+// IsSquare returns whether x is a square.
+func (f *Field) IsSquare(x Element) bool {
+	if f.IsZero(x) {
+		return true
+	}
+
+	l := f.Exp(f.New(), x, f.qMinus1div2)
+
+	return f.Equal(l, f.Element(big.NewInt(1)))
+}
+
+// SquareRoot sets res to a square root of x, if x is a square, with sqrt_ts of RFC 9380 appendix I.4, and returns res.
+func (f *Field) SquareRoot(res, x Element) Element {
+	one := f.Element(big.NewInt(1))
+	z, t, b, c := f.New(), f.New(), f.New(), f.Set(f.New(), f.c5)
+
+	f.Exp(z, x, f.c3) // 1. z = x^c3
+	f.Square(t, z)
+	f.Mul(t, t, x) // 2. t = z * z * x
+	f.Mul(z, z, x) // 3. z = z * x
+	f.Set(b, t)    // 4. b = t
+
+	for i := f.c1; i >= 2; i-- {
+		for range i - 2 {
+			f.Square(b, b)
+		}
+
+		isOne := f.Equal(b, one)
+		if !isOne {
+			f.Mul(z, z, c) // z = CMOV(z * c, z, b == 1)
+		}
+
+		f.Square(c, c)
+
+		if !isOne {
+			f.Mul(t, t, c) // t = CMOV(t * c, t, b == 1)
+		}
+
+		f.Set(b, t)
+	}
+
+	return f.Set(res, z)
+}
+
+// Sgn0 returns the sign of x, as sgn0 of RFC 9380 section 4.1: the parity of its first non-zero coefficient.
+func (f *Field) Sgn0(x Element) uint {
+	for _, c := range x {
+		if c.Sign() != 0 {
+			return c.Bit(0)
+		}
+	}
+
+	return 0
+}
@@ -0,0 +1,11 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:generate go run ../../../cmd/genfield -prime 0xffffffff00000000ffffffffffffffffbce6faada7179e84f3b9cac2fc632551 -package fn256 -out fn256.go
+
+package fn256
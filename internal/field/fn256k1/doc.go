@@ -0,0 +1,11 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:generate go run ../../../cmd/genfield -prime 0xfffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141 -package fn256k1 -out fn256k1.go
+
+package fn256k1
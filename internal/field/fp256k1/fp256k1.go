@@ -0,0 +1,390 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package fp256k1 implements constant-time arithmetic in the base field of secp256k1, with p = 2^256 - 2^32 - 977,
+// over four 64-bit limbs.
+package fp256k1
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+// ElementLength is the length in bytes of the canonical encoding of an element.
+const ElementLength = 32
+
+// c is 2^256 mod p = 2^32 + 977.
+const c = 0x1000003d1
+
+// p is the field order, in little-endian limbs.
+var p = [4]uint64{0xfffffffefffffc2f, 0xffffffffffffffff, 0xffffffffffffffff, 0xffffffffffffffff}
+
+// Element is an element of the field. The zero value is a valid zero element. Elements are always fully reduced.
+type Element struct {
+	l [4]uint64
+}
+
+// NewElement returns the element for the little-endian limbs l, which must encode a value lower than p. It is meant for
+// precomputed constants.
+func NewElement(l [4]uint64) Element {
+	return Element{l: l}
+}
+
+// Zero sets e to 0 and returns e.
+func (e *Element) Zero() *Element {
+	e.l = [4]uint64{}
+	return e
+}
+
+// One sets e to 1 and returns e.
+func (e *Element) One() *Element {
+	e.l = [4]uint64{1}
+	return e
+}
+
+// Set sets e to x and returns e.
+func (e *Element) Set(x *Element) *Element {
+	e.l = x.l
+	return e
+}
+
+// SetBytes sets e to the 32-byte big-endian encoding in b, and returns e and whether the encoding was canonical. If
+// the encoding is not canonical, e is set to the reduced value.
+func (e *Element) SetBytes(b *[ElementLength]byte) (*Element, bool) {
+	e.l[3] = binary.BigEndian.Uint64(b[0:8])
+	e.l[2] = binary.BigEndian.Uint64(b[8:16])
+	e.l[1] = binary.BigEndian.Uint64(b[16:24])
+	e.l[0] = binary.BigEndian.Uint64(b[24:32])
+
+	_, borrow := sub(&e.l, &p)
+	e.reduce()
+
+	return e, borrow == 1
+}
+
+// SetWideBytes sets e to the big-endian integer in b reduced modulo p, and returns e. b must be longer than 32 and at
+// most 64 bytes long, as the uniform strings of hash_to_field.
+func (e *Element) SetWideBytes(b []byte) *Element {
+	if len(b) <= ElementLength || len(b) > 2*ElementLength {
+		panic("fp256k1: invalid wide input length")
+	}
+
+	var hi, lo [ElementLength]byte
+
+	split := len(b) - ElementLength
+	copy(hi[ElementLength-split:], b[:split])
+	copy(lo[:], b[split:])
+
+	// b = hi * 2^256 + lo, and 2^256 = 2^32 + 977 mod p.
+	var h, l Element
+
+	h.SetBytes(&hi)
+	l.SetBytes(&lo)
+	clear(hi[:])
+	clear(lo[:])
+	h.Multiply(&h, &twoTo256)
+	e.Add(&h, &l)
+	h, l = Element{}, Element{}
+
+	return e
+}
+
+// Bytes returns the 32-byte big-endian canonical encoding of e.
+func (e *Element) Bytes() [ElementLength]byte {
+	var out [ElementLength]byte
+
+	binary.BigEndian.PutUint64(out[0:8], e.l[3])
+	binary.BigEndian.PutUint64(out[8:16], e.l[2])
+	binary.BigEndian.PutUint64(out[16:24], e.l[1])
+	binary.BigEndian.PutUint64(out[24:32], e.l[0])
+
+	return out
+}
+
+// Order returns the big-endian encoding of the field order.
+func Order() []byte {
+	return (&Element{l: p}).bigEndian()
+}
+
+func (e *Element) bigEndian() []byte {
+	b := make([]byte, ElementLength)
+	binary.BigEndian.PutUint64(b[0:8], e.l[3])
+	binary.BigEndian.PutUint64(b[8:16], e.l[2])
+	binary.BigEndian.PutUint64(b[16:24], e.l[1])
+	binary.BigEndian.PutUint64(b[24:32], e.l[0])
+
+	return b
+}
+
+// IsZero returns 1 if e == 0, and 0 otherwise.
+func (e *Element) IsZero() int {
+	z := e.l[0] | e.l[1] | e.l[2] | e.l[3]
+	return int(1 ^ ((z | -z) >> 63))
+}
+
+// Equal returns 1 if e == x, and 0 otherwise.
+func (e *Element) Equal(x *Element) int {
+	var d Element
+
+	d.l[0] = e.l[0] ^ x.l[0]
+	d.l[1] = e.l[1] ^ x.l[1]
+	d.l[2] = e.l[2] ^ x.l[2]
+	d.l[3] = e.l[3] ^ x.l[3]
+
+	return d.IsZero()
+}
+
+// Sgn0 returns the sgn0 of e as defined in RFC 9380, i.e. its parity.
+func (e *Element) Sgn0() int {
+	return int(e.l[0] & 1)
+}
+
+// Select sets e to a if cond == 1, and to b if cond == 0, and returns e.
+func (e *Element) Select(a, b *Element, cond int) *Element {
+	mask := -uint64(cond & 1)
+	e.l[0] = (a.l[0] & mask) | (b.l[0] &^ mask)
+	e.l[1] = (a.l[1] & mask) | (b.l[1] &^ mask)
+	e.l[2] = (a.l[2] & mask) | (b.l[2] &^ mask)
+	e.l[3] = (a.l[3] & mask) | (b.l[3] &^ mask)
+
+	return e
+}
+
+// sub sets a to a - b and returns the final borrow.
+func sub(a, b *[4]uint64) ([4]uint64, uint64) {
+	var r [4]uint64
+	var borrow uint64
+
+	r[0], borrow = bits.Sub64(a[0], b[0], 0)
+	r[1], borrow = bits.Sub64(a[1], b[1], borrow)
+	r[2], borrow = bits.Sub64(a[2], b[2], borrow)
+	r[3], borrow = bits.Sub64(a[3], b[3], borrow)
+
+	return r, borrow
+}
+
+// reduce subtracts p from e if e >= p. e must be lower than 2p.
+func (e *Element) reduce() {
+	r, borrow := sub(&e.l, &p)
+	e.Select(&Element{l: e.l}, &Element{l: r}, int(borrow))
+}
+
+// Add sets e to x + y and returns e.
+func (e *Element) Add(x, y *Element) *Element {
+	var s, t [4]uint64
+	var carry, borrow uint64
+
+	s[0], carry = bits.Add64(x.l[0], y.l[0], 0)
+	s[1], carry = bits.Add64(x.l[1], y.l[1], carry)
+	s[2], carry = bits.Add64(x.l[2], y.l[2], carry)
+	s[3], carry = bits.Add64(x.l[3], y.l[3], carry)
+
+	t[0], borrow = bits.Sub64(s[0], p[0], 0)
+	t[1], borrow = bits.Sub64(s[1], p[1], borrow)
+	t[2], borrow = bits.Sub64(s[2], p[2], borrow)
+	t[3], borrow = bits.Sub64(s[3], p[3], borrow)
+
+	// Keep the subtraction if the sum overflowed 2^256 or if it didn't underflow.
+	return e.Select(&Element{l: t}, &Element{l: s}, int(carry|(1^borrow)))
+}
+
+// Subtract sets e to x - y and returns e.
+func (e *Element) Subtract(x, y *Element) *Element {
+	d, borrow := sub(&x.l, &y.l)
+	mask := -borrow
+
+	var carry uint64
+	d[0], carry = bits.Add64(d[0], p[0]&mask, 0)
+	d[1], carry = bits.Add64(d[1], p[1]&mask, carry)
+	d[2], carry = bits.Add64(d[2], p[2]&mask, carry)
+	d[3], _ = bits.Add64(d[3], p[3]&mask, carry)
+
+	e.l = d
+
+	return e
+}
+
+// Negate sets e to -x and returns e.
+func (e *Element) Negate(x *Element) *Element {
+	return e.Subtract(new(Element), x)
+}
+
+// Multiply sets e to x * y and returns e.
+func (e *Element) Multiply(x, y *Element) *Element {
+	var t [8]uint64
+
+	for i := range 4 {
+		var carry uint64
+
+		for j := range 4 {
+			hi, lo := bits.Mul64(x.l[i], y.l[j])
+
+			var c0, c1 uint64
+			lo, c0 = bits.Add64(lo, t[i+j], 0)
+			lo, c1 = bits.Add64(lo, carry, 0)
+			t[i+j] = lo
+			carry = hi + c0 + c1
+		}
+
+		t[i+4] = carry
+	}
+
+	e.reduceWide(&t)
+
+	return e
+}
+
+// Square sets e to x^2 and returns e.
+func (e *Element) Square(x *Element) *Element {
+	return e.Multiply(x, x)
+}
+
+// reduceWide sets e to t mod p, using 2^256 = c mod p.
+func (e *Element) reduceWide(t *[8]uint64) {
+	var r [4]uint64
+	var carry uint64
+
+	// r = t[0:4] + t[4:8] * c, with the overflow in carry.
+	for i := range 4 {
+		hi, lo := bits.Mul64(t[4+i], c)
+
+		var c0, c1 uint64
+		lo, c0 = bits.Add64(lo, t[i], 0)
+		lo, c1 = bits.Add64(lo, carry, 0)
+		r[i] = lo
+		carry = hi + c0 + c1
+	}
+
+	// Fold the overflow: r = r + carry * c.
+	hi, lo := bits.Mul64(carry, c)
+
+	var cc uint64
+	r[0], cc = bits.Add64(r[0], lo, 0)
+	r[1], cc = bits.Add64(r[1], hi, cc)
+	r[2], cc = bits.Add64(r[2], 0, cc)
+	r[3], cc = bits.Add64(r[3], 0, cc)
+
+	// If that overflowed again, r is small and adding c can't overflow.
+	r[0], cc = bits.Add64(r[0], cc*c, 0)
+	r[1], cc = bits.Add64(r[1], 0, cc)
+	r[2], cc = bits.Add64(r[2], 0, cc)
+	r[3], _ = bits.Add64(r[3], 0, cc)
+
+	e.l = r
+	e.reduce()
+}
+
+// pow sets e to x^k for the public exponent k in little-endian limbs, and returns e.
+func (e *Element) pow(x *Element, k *[4]uint64) *Element {
+	var r, b Element
+
+	r.One()
+	b.Set(x)
+
+	for i := 3; i >= 0; i-- {
+		for j := 63; j >= 0; j-- {
+			r.Square(&r)
+
+			var t Element
+			t.Multiply(&r, &b)
+			r.Select(&t, &r, int((k[i]>>uint(j))&1))
+		}
+	}
+
+	return e.Set(&r)
+}
+
+var (
+	// twoTo256 is 2^256 mod p.
+	twoTo256 = Element{l: [4]uint64{0x1000003d1, 0, 0, 0}}
+
+	// pMinus2 is the exponent for inversion, p - 2.
+	pMinus2 = [4]uint64{0xfffffffefffffc2d, 0xffffffffffffffff, 0xffffffffffffffff, 0xffffffffffffffff}
+
+	// pPlus1Div4 is the exponent for square roots, (p + 1) / 4.
+	pPlus1Div4 = [4]uint64{0xffffffffbfffff0c, 0xffffffffffffffff, 0xffffffffffffffff, 0x3fffffffffffffff}
+
+	// pMinus3Div4 is the c1 constant of sqrt_ratio for p = 3 mod 4, (p - 3) / 4.
+	pMinus3Div4 = [4]uint64{0xffffffffbfffff0b, 0xffffffffffffffff, 0xffffffffffffffff, 0x3fffffffffffffff}
+)
+
+// Invert sets e to 1/x and returns e. If x == 0, e is set to 0.
+func (e *Element) Invert(x *Element) *Element {
+	return e.pow(x, &pMinus2)
+}
+
+// BatchInvert sets out[i] to 1/in[i] for all i with a single inversion, using Montgomery's trick. As with Invert, the
+// inverse of 0 is 0. out and in must have the same length, and may be the same slice.
+func BatchInvert(out, in []*Element) {
+	if len(in) == 0 {
+		return
+	}
+
+	var one, zero Element
+
+	one.One()
+
+	// prefix[i] holds the product of the non-zero elements in in[:i+1].
+	prefix := make([]Element, len(in))
+	acc := new(Element).One()
+
+	for i, x := range in {
+		var t Element
+
+		t.Select(&one, x, x.IsZero())
+		prefix[i].Set(acc.Multiply(acc, &t))
+	}
+
+	inv := new(Element).Invert(acc)
+
+	for i := len(in) - 1; i >= 0; i-- {
+		var x, r Element
+
+		isZero := in[i].IsZero()
+		x.Select(&one, in[i], isZero)
+
+		if i > 0 {
+			r.Multiply(inv, &prefix[i-1])
+		} else {
+			r.Set(inv)
+		}
+
+		inv.Multiply(inv, &x)
+		out[i].Select(&zero, &r, isZero)
+	}
+}
+
+// Sqrt sets e to a square root of x, and returns 1 if x is a square and 0 otherwise.
+func (e *Element) Sqrt(x *Element) int {
+	var r, check Element
+
+	r.pow(x, &pPlus1Div4)
+	check.Square(&r)
+	e.Set(&r)
+
+	return check.Equal(x)
+}
+
+// SqrtRatio implements sqrt_ratio for p = 3 mod 4 (RFC 9380 appendix F.2.1.2), with c2 = sqrt(-Z). It sets e to
+// sqrt(u/v) and returns 1 if u/v is a square, and sets e to sqrt(Z * u/v) and returns 0 otherwise.
+func (e *Element) SqrtRatio(u, v, c2 *Element) int {
+	var tv1, tv2, tv3, y1, y2 Element
+
+	tv1.Square(v)            // 1. tv1 = v^2
+	tv2.Multiply(u, v)       // 2. tv2 = u * v
+	tv1.Multiply(&tv1, &tv2) // 3. tv1 = tv1 * tv2
+	y1.pow(&tv1, &pMinus3Div4)
+	y1.Multiply(&y1, &tv2)   // 5. y1 = y1 * tv2
+	y2.Multiply(&y1, c2)     // 6. y2 = y1 * c2
+	tv3.Square(&y1)          // 7. tv3 = y1^2
+	tv3.Multiply(&tv3, v)    // 8. tv3 = tv3 * v
+	isQR := tv3.Equal(u)     // 9. isQR = tv3 == u
+	e.Select(&y1, &y2, isQR) // 10. y = CMOV(y2, y1, isQR)
+
+	return isQR
+}
@@ -0,0 +1,30 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+package fp256k1
+
+import "math/big"
+
+// SetBig sets e to x mod p and returns e. This is not constant-time.
+func (e *Element) SetBig(x *big.Int) *Element {
+	var b [ElementLength]byte
+
+	r := new(big.Int).Mod(x, new(big.Int).SetBytes(Order()))
+	r.FillBytes(b[:])
+	e.SetBytes(&b)
+
+	return e
+}
+
+// Big returns e as a big.Int.
+func (e *Element) Big() *big.Int {
+	b := e.Bytes()
+	return new(big.Int).SetBytes(b[:])
+}
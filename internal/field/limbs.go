@@ -0,0 +1,362 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package field
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/big"
+	"math/bits"
+)
+
+// MaxBits is the maximum bit length of the primes supported by LimbField.
+const MaxBits = 64 * maxLimbs
+
+const maxLimbs = 9
+
+var errUnsupportedModulus = errors.New("the modulus must be an odd prime larger than 2 of at most 576 bits")
+
+// LimbField implements constant-time arithmetic modulo an odd prime of at most MaxBits bits, over saturated 64-bit
+// limbs in the Montgomery domain. Unlike the generated packages for fixed primes, the number of limbs is set at
+// runtime, so the timing of the operations only depends on the size of the prime.
+type LimbField struct {
+	prime   *big.Int
+	pMinus2 *big.Int
+	p       [maxLimbs]uint64
+	r2      Element // R^2 mod p, with R = 2^(64 * n)
+	one     Element // R mod p, i.e. 1 in the Montgomery domain
+	pInv    uint64  // -p^-1 mod 2^64
+	n       int     // the number of limbs
+	byteLen int
+}
+
+// Element is an element of a LimbField, in the Montgomery domain. The zero value is a valid zero element. Elements
+// are always fully reduced.
+type Element struct {
+	l [maxLimbs]uint64
+}
+
+// NewLimbField returns the field of the prime, and panics if it is even, lower than 3, or wider than MaxBits.
+func NewLimbField(prime *big.Int) *LimbField {
+	if !SupportsLimbs(prime) {
+		panic(errUnsupportedModulus)
+	}
+
+	n := (prime.BitLen() + 63) / 64
+	f := &LimbField{
+		prime:   new(big.Int).Set(prime),
+		pMinus2: new(big.Int).Sub(prime, big.NewInt(2)),
+		n:       n,
+		byteLen: (prime.BitLen() + 7) / 8,
+	}
+
+	f.toLimbs(&f.p, prime)
+
+	// Newton's iteration doubles the number of correct low bits of p^-1, starting from 3 since p * p = 1 mod 8.
+	inv := f.p[0]
+	for range 5 {
+		inv *= 2 - f.p[0]*inv
+	}
+
+	f.pInv = -inv
+
+	r := new(big.Int).Lsh(big.NewInt(1), uint(64*n))
+	f.toLimbs(&f.one.l, new(big.Int).Mod(r, prime))
+	f.toLimbs(&f.r2.l, r.Mod(r.Mul(r, r), prime))
+
+	return f
+}
+
+// SupportsLimbs returns whether NewLimbField supports the prime, i.e. whether it is odd, larger than 2, and of at most
+// MaxBits bits. It does not check primality.
+func SupportsLimbs(prime *big.Int) bool {
+	return prime.Bit(0) == 1 && prime.Cmp(big.NewInt(3)) >= 0 && prime.BitLen() <= MaxBits
+}
+
+// ByteLen returns the length of the canonical encodings of the elements.
+func (f *LimbField) ByteLen() int {
+	return f.byteLen
+}
+
+// toLimbs sets l to the little-endian limbs of x, which must be non-negative and fit in n limbs.
+func (f *LimbField) toLimbs(l *[maxLimbs]uint64, x *big.Int) {
+	buf := make([]byte, 8*maxLimbs)
+	x.FillBytes(buf)
+
+	for i := range l {
+		l[i] = binary.BigEndian.Uint64(buf[8*(maxLimbs-1-i):])
+	}
+
+	clear(buf)
+}
+
+// fromLimbs sets res to the integer of the little-endian limbs, and returns res.
+func (f *LimbField) fromLimbs(res *big.Int, l *[maxLimbs]uint64) *big.Int {
+	buf := make([]byte, 8*maxLimbs)
+	for i := range l {
+		binary.BigEndian.PutUint64(buf[8*(maxLimbs-1-i):], l[i])
+	}
+
+	res.SetBytes(buf)
+	clear(buf)
+
+	return res
+}
+
+// SetBig sets e to x mod p and returns e. Reducing non-canonical values is not constant-time.
+func (f *LimbField) SetBig(e *Element, x *big.Int) *Element {
+	f.setPlain(e, x)
+	return f.Mul(e, e, &f.r2)
+}
+
+// setPlain sets e to the limbs of x mod p, outside of the Montgomery domain, and returns e.
+func (f *LimbField) setPlain(e *Element, x *big.Int) *Element {
+	if x.Sign() < 0 || x.Cmp(f.prime) >= 0 {
+		x = new(big.Int).Mod(x, f.prime)
+	}
+
+	f.toLimbs(&e.l, x)
+
+	return e
+}
+
+// Big sets res to the canonical value of e, and returns res.
+func (f *LimbField) Big(res *big.Int, e *Element) *big.Int {
+	var plain Element
+
+	f.Mul(&plain, e, &Element{l: [maxLimbs]uint64{1}})
+	f.fromLimbs(res, &plain.l)
+	plain = Element{}
+
+	return res
+}
+
+// SetBytes sets e to the big-endian encoding of ByteLen bytes, and returns e and whether the encoding is canonical.
+// If it is not, e is set to 0.
+func (f *LimbField) SetBytes(e *Element, b []byte) (*Element, bool) {
+	if len(b) != f.byteLen {
+		return e.Set(&Element{}), false
+	}
+
+	var r Element
+
+	buf := make([]byte, 8*maxLimbs)
+	copy(buf[len(buf)-len(b):], b)
+
+	for i := range r.l {
+		r.l[i] = binary.BigEndian.Uint64(buf[8*(maxLimbs-1-i):])
+	}
+
+	clear(buf)
+
+	_, borrow := f.sub(&r.l, &f.p)
+	f.Mul(e, &r, &f.r2)
+	f.Select(e, e, &Element{}, int(borrow))
+	r = Element{}
+
+	return e, borrow == 1
+}
+
+// Bytes returns the big-endian canonical encoding of e, of ByteLen bytes.
+func (f *LimbField) Bytes(e *Element) []byte {
+	var plain Element
+
+	f.Mul(&plain, e, &Element{l: [maxLimbs]uint64{1}})
+
+	buf := make([]byte, 8*maxLimbs)
+	for i := range plain.l {
+		binary.BigEndian.PutUint64(buf[8*(maxLimbs-1-i):], plain.l[i])
+	}
+
+	out := make([]byte, f.byteLen)
+	copy(out, buf[len(buf)-f.byteLen:])
+	clear(buf)
+
+	plain = Element{}
+
+	return out
+}
+
+// Set sets e to x and returns e.
+func (e *Element) Set(x *Element) *Element {
+	e.l = x.l
+	return e
+}
+
+// One sets e to 1 and returns e.
+func (f *LimbField) One(e *Element) *Element {
+	return e.Set(&f.one)
+}
+
+// IsZero returns 1 if e == 0, and 0 otherwise.
+func (f *LimbField) IsZero(e *Element) int {
+	var z uint64
+	for i := range f.n {
+		z |= e.l[i]
+	}
+
+	return int(1 ^ ((z | -z) >> 63))
+}
+
+// Equal returns 1 if x == y, and 0 otherwise.
+func (f *LimbField) Equal(x, y *Element) int {
+	var z uint64
+	for i := range f.n {
+		z |= x.l[i] ^ y.l[i]
+	}
+
+	return int(1 ^ ((z | -z) >> 63))
+}
+
+// Select sets e to a if cond == 1, and to b if cond == 0, and returns e.
+func (f *LimbField) Select(e, a, b *Element, cond int) *Element {
+	mask := -uint64(cond & 1)
+	for i := range f.n {
+		e.l[i] = (a.l[i] & mask) | (b.l[i] &^ mask)
+	}
+
+	return e
+}
+
+// sub returns a - b over n limbs, and the final borrow.
+func (f *LimbField) sub(a, b *[maxLimbs]uint64) ([maxLimbs]uint64, uint64) {
+	var (
+		r      [maxLimbs]uint64
+		borrow uint64
+	)
+
+	for i := range f.n {
+		r[i], borrow = bits.Sub64(a[i], b[i], borrow)
+	}
+
+	return r, borrow
+}
+
+// Add sets e to x + y and returns e.
+func (f *LimbField) Add(e, x, y *Element) *Element {
+	var (
+		s     [maxLimbs]uint64
+		carry uint64
+	)
+
+	for i := range f.n {
+		s[i], carry = bits.Add64(x.l[i], y.l[i], carry)
+	}
+
+	// Subtract p if the sum overflowed or is not lower than p.
+	d, borrow := f.sub(&s, &f.p)
+	_, borrow = bits.Sub64(carry, 0, borrow)
+
+	return f.Select(e, &Element{l: s}, &Element{l: d}, int(borrow))
+}
+
+// Sub sets e to x - y and returns e.
+func (f *LimbField) Sub(e, x, y *Element) *Element {
+	d, borrow := f.sub(&x.l, &y.l)
+
+	// Add p if the difference is negative.
+	mask := -borrow
+
+	var carry uint64
+	for i := range f.n {
+		e.l[i], carry = bits.Add64(d[i], f.p[i]&mask, carry)
+	}
+
+	return e
+}
+
+// Neg sets e to -x and returns e.
+func (f *LimbField) Neg(e, x *Element) *Element {
+	return f.Sub(e, &Element{}, x)
+}
+
+// Mul sets e to x * y and returns e, with the coarsely integrated operand scanning Montgomery multiplication.
+func (f *LimbField) Mul(e, x, y *Element) *Element {
+	var (
+		t                [maxLimbs + 2]uint64
+		c, hi, lo, carry uint64
+	)
+
+	n := f.n
+
+	for i := range n {
+		// t += x * y[i]
+		c = 0
+
+		for j := range n {
+			hi, lo = bits.Mul64(x.l[j], y.l[i])
+			lo, carry = bits.Add64(lo, t[j], 0)
+			hi += carry
+			t[j], carry = bits.Add64(lo, c, 0)
+			c = hi + carry
+		}
+
+		t[n], carry = bits.Add64(t[n], c, 0)
+		t[n+1] = carry
+
+		// t = (t + m * p) / 2^64, with m such that the lowest limb vanishes.
+		m := t[0] * f.pInv
+		hi, lo = bits.Mul64(m, f.p[0])
+		_, carry = bits.Add64(lo, t[0], 0)
+		c = hi + carry
+
+		for j := 1; j < n; j++ {
+			hi, lo = bits.Mul64(m, f.p[j])
+			lo, carry = bits.Add64(lo, t[j], 0)
+			hi += carry
+			t[j-1], carry = bits.Add64(lo, c, 0)
+			c = hi + carry
+		}
+
+		t[n-1], carry = bits.Add64(t[n], c, 0)
+		t[n] = t[n+1] + carry
+	}
+
+	// t < 2p, so a conditional subtraction yields the canonical value.
+	var r [maxLimbs]uint64
+
+	copy(r[:], t[:n])
+	d, borrow := f.sub(&r, &f.p)
+	_, borrow = bits.Sub64(t[n], 0, borrow)
+	f.Select(e, &Element{l: r}, &Element{l: d}, int(borrow))
+
+	t, r = [maxLimbs + 2]uint64{}, [maxLimbs]uint64{}
+
+	return e
+}
+
+// Square sets e to x^2 and returns e.
+func (f *LimbField) Square(e, x *Element) *Element {
+	return f.Mul(e, x, x)
+}
+
+// Exp sets e to x^n and returns e, for a non-negative n. The operations do not depend on x, and only on the bit length
+// of n.
+func (f *LimbField) Exp(e, x *Element, n *big.Int) *Element {
+	var acc, base, tv Element
+
+	f.One(&acc)
+	base.Set(x)
+
+	for i := n.BitLen() - 1; i >= 0; i-- {
+		f.Square(&acc, &acc)
+		f.Mul(&tv, &acc, &base)
+		f.Select(&acc, &tv, &acc, int(n.Bit(i)))
+	}
+
+	e.Set(&acc)
+	acc, base, tv = Element{}, Element{}, Element{}
+
+	return e
+}
+
+// Inv sets e to the inverse of x, or 0 if x is 0, and returns e.
+func (f *LimbField) Inv(e, x *Element) *Element {
+	return f.Exp(e, x, f.pMinus2)
+}
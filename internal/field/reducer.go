@@ -0,0 +1,119 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package field
+
+import (
+	"crypto/subtle"
+	"math/big"
+)
+
+// Reducer reduces big-endian integers of a fixed length modulo a modulus with Barrett reduction: the quotient is
+// estimated with a precomputed constant instead of a division, and corrected with subtractions selected in constant
+// time.
+type Reducer struct {
+	modulus      *big.Int
+	mu           *big.Int
+	modulusBytes []byte // the fixed-width encoding of modulus, with one leading zero byte
+	shift        uint
+}
+
+// NewReducer returns a Reducer of inputs of inputLength bytes modulo modulus, which must be larger than 1 and not
+// wider than the inputs.
+func NewReducer(modulus *big.Int, inputLength uint) *Reducer {
+	// With w the input bit length and mu = floor(2^w / m), the estimate q = floor(x * mu / 2^w) is at most 2 below
+	// floor(x / m) for any x < 2^w, so x - q * m < 3m.
+	shift := 8 * inputLength
+	mu := new(big.Int).Lsh(big.NewInt(1), shift)
+	mu.Quo(mu, modulus)
+
+	return &Reducer{
+		modulus:      new(big.Int).Set(modulus),
+		mu:           mu,
+		modulusBytes: modulus.FillBytes(make([]byte, (modulus.BitLen()+7)/8+1)),
+		shift:        shift,
+	}
+}
+
+// NewPrecomputedReducer returns the Reducer of NewReducer with its precomputed constant
+// mu = floor(2^(8 * inputLength) / modulus), e.g. generated ahead of time.
+func NewPrecomputedReducer(modulus, mu *big.Int, inputLength uint) *Reducer {
+	return &Reducer{
+		modulus:      modulus,
+		mu:           mu,
+		modulusBytes: modulus.FillBytes(make([]byte, (modulus.BitLen()+7)/8+1)),
+		shift:        8 * inputLength,
+	}
+}
+
+// Reduce interprets the input as a big-endian unsigned integer, and returns it reduced modulo the modulus. The input
+// must be at most the length the Reducer was built for.
+func (r *Reducer) Reduce(input []byte) *big.Int {
+	return r.ReduceInto(new(big.Int), input, r.NewScratch())
+}
+
+// ReduceScratch holds the intermediate values of ReduceInto, whose storage is reused across reductions.
+type ReduceScratch struct {
+	q, t      big.Int
+	res, diff []byte
+}
+
+// NewScratch returns a ReduceScratch for the reductions of r.
+func (r *Reducer) NewScratch() *ReduceScratch {
+	return &ReduceScratch{
+		res:  make([]byte, len(r.modulusBytes)),
+		diff: make([]byte, len(r.modulusBytes)),
+	}
+}
+
+// ReduceInto sets x to Reduce(input), using the intermediate values of s, and returns x. It does not allocate once x
+// and s have held the values of a reduction of an input of the full length, e.g. after a first reduction.
+func (r *Reducer) ReduceInto(x *big.Int, input []byte, s *ReduceScratch) *big.Int {
+	x.SetBytes(input)
+	s.q.Mul(x, r.mu)
+	s.q.Rsh(&s.q, r.shift)
+	x.Sub(x, s.t.Mul(&s.q, r.modulus))
+
+	// x < 3m, so two conditional subtractions yield the canonical residue.
+	res := x.FillBytes(s.res)
+
+	for range 2 {
+		borrow := subBytes(s.diff, res, r.modulusBytes)
+		subtle.ConstantTimeCopy(int(1^borrow), res, s.diff)
+	}
+
+	x.SetBytes(res)
+
+	// Best-effort wiping of the intermediate values, keeping their storage.
+	wipeInt(&s.q)
+	wipeInt(&s.t)
+	clear(s.res)
+	clear(s.diff)
+
+	return x
+}
+
+// wipeInt overwrites the words of x with zeros, and sets x to 0.
+func wipeInt(x *big.Int) {
+	words := x.Bits()
+	clear(words[:cap(words)])
+	x.SetInt64(0)
+}
+
+// subBytes sets out to the fixed-width big-endian difference a - b, and returns the final borrow, which is 1 if b > a.
+func subBytes(out, a, b []byte) uint16 {
+	var borrow uint16
+
+	for i := len(a) - 1; i >= 0; i-- {
+		d := uint16(a[i]) - uint16(b[i]) - borrow
+		out[i] = byte(d)
+		borrow = (d >> 8) & 1
+	}
+
+	return borrow
+}
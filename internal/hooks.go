@@ -0,0 +1,61 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package internal
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// ExpandHook is called after expansions, with the name of the expander (e.g. "XMD:SHA-256"), the number of inputs
+// expanded, the output length of each, and the time the expansion started.
+type ExpandHook func(expander string, count, length uint, start time.Time)
+
+// HashHook is called after the operations of a suite, with the suite identifier, the operation, and the time the
+// operation started.
+type HashHook func(suite, operation string, start time.Time)
+
+var (
+	expandHook atomic.Pointer[ExpandHook]
+	hashHook   atomic.Pointer[HashHook]
+)
+
+// SetHooks sets the hooks module-wide, and removes those that are nil.
+func SetHooks(expand ExpandHook, hash HashHook) {
+	if expand == nil {
+		expandHook.Store(nil)
+	} else {
+		expandHook.Store(&expand)
+	}
+
+	if hash == nil {
+		hashHook.Store(nil)
+	} else {
+		hashHook.Store(&hash)
+	}
+}
+
+// ObserveExpand returns the ExpandHook, or nil if it is not set, so that callers only pay for an atomic load without
+// hook. Callers defer the call, e.g. defer observe(name, 1, length, time.Now()), to have time.Now() evaluated first.
+func ObserveExpand() ExpandHook {
+	if h := expandHook.Load(); h != nil {
+		return *h
+	}
+
+	return nil
+}
+
+// ObserveHash returns the HashHook, or nil if it is not set, as ObserveExpand does.
+func ObserveHash() HashHook {
+	if h := hashHook.Load(); h != nil {
+		return *h
+	}
+
+	return nil
+}
@@ -6,6 +6,8 @@
 // LICENSE file in the root directory of this source tree or at
 // https://spdx.org/licenses/MIT.html
 
+//go:build !nomathbig
+
 package internal
 
 import (
@@ -14,10 +16,146 @@ import (
 	"github.com/bytemare/hash2curve/internal/field"
 )
 
-// MapToCurveSSWU implements the Simplified SWU method for Weierstrass curves for any base field.
+// SSWU holds the parameters of the Simplified SWU map for a Weierstrass curve over any base field: the field, A, B,
+// and Z, reduced, and the constants of sqrt_ratio, which are computed once to map multiple field elements. It is safe
+// for concurrent use.
+type SSWU struct {
+	fp *field.Field
+
+	// c1 = (p - 3) / 4 and c2 = sqrt(-Z) are the constants of sqrt_ratio_3mod4 of RFC 9380 appendix F.2.1.2, or nil
+	// if p != 3 mod 4, for which sqrt_ratio uses the generic method of the field.
+	c1, c2  *big.Int
+	a, b, z big.Int
+}
+
+// NewSSWU returns the parameters of the Simplified SWU map over fp for the curve y^2 = x^3 + a * x + b and the
+// constant z, which must all be non-zero modulo p, with z a non-square.
+func NewSSWU(fp *field.Field, a, b, z *big.Int) *SSWU {
+	s := &SSWU{fp: fp}
+	s.a.Set(fp.Mod(new(big.Int).Set(a)))
+	s.b.Set(fp.Mod(new(big.Int).Set(b)))
+	s.z.Set(fp.Mod(new(big.Int).Set(z)))
+
+	if p := fp.Order(); p.Bit(0) == 1 && p.Bit(1) == 1 {
+		s.c1 = new(big.Int).Rsh(p, 2) // (p - 3) / 4, since p = 3 mod 4
+		s.c2 = fp.SquareRoot(new(big.Int), fp.Neg(new(big.Int), &s.z))
+	}
+
+	return s
+}
+
+// NewPrecomputedSSWU returns the parameters of NewSSWU for p = 3 mod 4, with a, b, and z reduced, and the precomputed
+// constants c1 = (p - 3) / 4 and c2 = sqrt(-z) of sqrt_ratio, e.g. generated ahead of time.
+func NewPrecomputedSSWU(fp *field.Field, a, b, z, c1, c2 *big.Int) *SSWU {
+	s := &SSWU{fp: fp, c1: c1, c2: c2}
+	s.a.Set(a)
+	s.b.Set(b)
+	s.z.Set(z)
+
+	return s
+}
+
+// Map implements the Simplified SWU method, and returns the affine coordinates of the point on the curve. fe must be
+// reduced.
+func (s *SSWU) Map(fe *big.Int) (x, y *big.Int) {
+	x, y, d := s.mapToCurve(fe)
+	invert(s.fp, d, d) //  25.   1 / tv4
+	s.fp.Mul(x, x, d)  //	 26.   x = x / tv4
+
+	return x, y
+}
+
+// MapJacobian is Map returning the Jacobian coordinates (X, Y, Z) of the point, i.e. the affine point
+// (X / Z^2, Y / Z^3), which saves the field inversion. Z is never zero.
+func (s *SSWU) MapJacobian(fe *big.Int) (x, y, zz *big.Int) {
+	var zz3 big.Int
+
+	fp := s.fp
+
+	// With Z = tv4, X = x * Z^2 = xn * tv4 and Y = y * Z^3.
+	x, y, zz = s.mapToCurve(fe)
+	fp.Mul(x, x, zz)
+	fp.Square(&zz3, zz)
+	fp.Mul(&zz3, &zz3, zz)
+	fp.Mul(y, y, &zz3)
+
+	if Blinding() {
+		// (X, Y, Z) and (l^2 * X, l^3 * Y, l * Z) are the same point for any non-zero l.
+		var mask2, mask3 big.Int
+
+		mask := RandomMask(fp)
+		fp.Square(&mask2, mask)
+		fp.Mul(&mask3, &mask2, mask)
+		fp.Mul(x, x, &mask2)
+		fp.Mul(y, y, &mask3)
+		fp.Mul(zz, zz, mask)
+	}
+
+	return x, y, zz
+}
+
+// MapBatch returns Map for each of the field elements, with a single field inversion for the batch using Montgomery's
+// trick. The square root in each mapping remains, so this saves about half the exponentiations.
+func (s *SSWU) MapBatch(fes []*big.Int) (xs, ys []*big.Int) {
+	fp := s.fp
+	xs, ys = make([]*big.Int, len(fes)), make([]*big.Int, len(fes))
+	dens := make([]*big.Int, len(fes))
+
+	for i, fe := range fes {
+		xs[i], ys[i], dens[i] = s.mapToCurve(fe)
+	}
+
+	// The denominators are never zero, since A and Z are not, and -tv2 is selected only if it is not zero.
+	var mask *big.Int
+	if Blinding() {
+		mask = RandomMask(fp)
+		for _, d := range dens {
+			fp.Mul(d, d, mask)
+		}
+	}
+
+	fp.BatchInvert(dens, dens)
+
+	for i, d := range dens {
+		if mask != nil {
+			fp.Mul(d, d, mask)
+		}
+
+		fp.Mul(xs[i], xs[i], d)
+	}
+
+	return xs, ys
+}
+
+// MapToCurveSSWU implements the Simplified SWU method for Weierstrass curves for any base field. z and fe must be
+// reduced.
+//
+// Deprecated: use NewSSWU and SSWU.Map, which compute the parameters once for all mappings.
 func MapToCurveSSWU(fp *field.Field, a, b, z, fe *big.Int) (x, y *big.Int) {
-	var tv1, tv2, tv3, tv4, tv5, tv6, _y1 big.Int
-	x, y = new(big.Int), new(big.Int)
+	return NewSSWU(fp, a, b, z).Map(fe)
+}
+
+// MapToCurveSSWUJacobian is MapToCurveSSWU returning the Jacobian coordinates (X, Y, Z) of the point.
+//
+// Deprecated: use NewSSWU and SSWU.MapJacobian, which compute the parameters once for all mappings.
+func MapToCurveSSWUJacobian(fp *field.Field, a, b, z, fe *big.Int) (x, y, zz *big.Int) {
+	return NewSSWU(fp, a, b, z).MapJacobian(fe)
+}
+
+// MapToCurveSSWUBatch returns MapToCurveSSWU for each of the field elements, with a single field inversion.
+//
+// Deprecated: use NewSSWU and SSWU.MapBatch, which compute the parameters once for all mappings.
+func MapToCurveSSWUBatch(fp *field.Field, a, b, z *big.Int, fes []*big.Int) (xs, ys []*big.Int) {
+	return NewSSWU(fp, a, b, z).MapBatch(fes)
+}
+
+// mapToCurve returns the numerator of x, y, and the denominator of x of the Simplified SWU map, i.e. the steps before
+// the inversion.
+func (s *SSWU) mapToCurve(fe *big.Int) (x, y, tv4 *big.Int) {
+	var tv1, tv2, tv3, tv5, tv6, _y1 big.Int
+
+	fp, a, b, z := s.fp, &s.a, &s.b, &s.z
+	x, y, tv4 = new(big.Int), new(big.Int), new(big.Int)
 
 	fp.Square(&tv1, fe)          //    1.  tv1 = u^2
 	fp.Mul(&tv1, z, &tv1)        //    2.  tv1 = Z * tv1
@@ -25,28 +163,71 @@ func MapToCurveSSWU(fp *field.Field, a, b, z, fe *big.Int) (x, y *big.Int) {
 	fp.Add(&tv2, &tv2, &tv1)     //    4.  tv2 = tv2 + tv1
 	fp.Add(&tv3, &tv2, fp.One()) //    5.  tv3 = tv2 + 1
 	fp.Mul(&tv3, b, &tv3)        //    6.  tv3 = B * tv3
-	fp.CondMov(&tv4, z,
+	fp.CondMov(tv4, z,
 		fp.Neg(&big.Int{}, &tv2),
 		!fp.IsZero(&tv2)) //    7.  tv4 = CMOV(Z, -tv2, tv2 != 0)
-	fp.Mul(&tv4, a, &tv4)                            //    8.  tv4 = A * tv4
-	fp.Square(&tv2, &tv3)                            //    9.  tv2 = tv3^2
-	fp.Square(&tv6, &tv4)                            //    10. tv6 = tv4^2
-	fp.Mul(&tv5, a, &tv6)                            //    11. tv5 = A * tv6
-	fp.Add(&tv2, &tv2, &tv5)                         //    12. tv2 = tv2 + tv5
-	fp.Mul(&tv2, &tv2, &tv3)                         //    13. tv2 = tv2 * tv3
-	fp.Mul(&tv6, &tv6, &tv4)                         //    14. tv6 = tv6 * tv4
-	fp.Mul(&tv5, b, &tv6)                            //    15. tv5 = B * tv6
-	fp.Add(&tv2, &tv2, &tv5)                         //    16. tv2 = tv2 + tv5
-	fp.Mul(x, &tv1, &tv3)                            //    17.   x = tv1 * tv3
-	isGx1Square := fp.SqrtRatio(&_y1, z, &tv2, &tv6) //    18. isGx1Square, y1 = sqrt_ratio(tv2, tv6)
-	fp.Mul(y, &tv1, fe)                              //    19.   y = tv1 * u
-	fp.Mul(y, y, &_y1)                               //    20.   y = y * y1
-	fp.CondMov(x, x, &tv3, isGx1Square)              //    21.   x = CMOV(x, tv3, isGx1Square)
-	fp.CondMov(y, y, &_y1, isGx1Square)              //    22.   y = CMOV(y, y1, isGx1Square)
-	e1 := fp.Sgn0(fe) == fp.Sgn0(y)                  //    23.  e1 = sgn0(u) == sgn0(y)
-	fp.CondMov(y, fp.Neg(&big.Int{}, y), y, e1)      //    24.   y = CMOV(-y, y, e1)
-	fp.Inv(&tv4, &tv4)                               //    25.   1 / tv4
-	fp.Mul(x, x, &tv4)                               //	 26.   x = x / tv4
+	fp.Mul(tv4, a, tv4)                          //    8.  tv4 = A * tv4
+	fp.Square(&tv2, &tv3)                        //    9.  tv2 = tv3^2
+	fp.Square(&tv6, tv4)                         //    10. tv6 = tv4^2
+	fp.Mul(&tv5, a, &tv6)                        //    11. tv5 = A * tv6
+	fp.Add(&tv2, &tv2, &tv5)                     //    12. tv2 = tv2 + tv5
+	fp.Mul(&tv2, &tv2, &tv3)                     //    13. tv2 = tv2 * tv3
+	fp.Mul(&tv6, &tv6, tv4)                      //    14. tv6 = tv6 * tv4
+	fp.Mul(&tv5, b, &tv6)                        //    15. tv5 = B * tv6
+	fp.Add(&tv2, &tv2, &tv5)                     //    16. tv2 = tv2 + tv5
+	fp.Mul(x, &tv1, &tv3)                        //    17.   x = tv1 * tv3
+	isGx1Square := s.sqrtRatio(&_y1, &tv2, &tv6) //    18. isGx1Square, y1 = sqrt_ratio(tv2, tv6)
+	fp.Mul(y, &tv1, fe)                          //    19.   y = tv1 * u
+	fp.Mul(y, y, &_y1)                           //    20.   y = y * y1
+	fp.CondMov(x, x, &tv3, isGx1Square)          //    21.   x = CMOV(x, tv3, isGx1Square)
+	fp.CondMov(y, y, &_y1, isGx1Square)          //    22.   y = CMOV(y, y1, isGx1Square)
+	e1 := fp.Sgn0(fe) == fp.Sgn0(y)              //    23.  e1 = sgn0(u) == sgn0(y)
+	fp.CondMov(y, fp.Neg(&big.Int{}, y), y, e1)  //    24.   y = CMOV(-y, y, e1)
 
-	return x, y
+	return x, y, tv4
+}
+
+// invert sets res to 1 / x, computed as l / (l * x) with a random mask l if blinding is enabled.
+func invert(fp *field.Field, res, x *big.Int) {
+	if !Blinding() {
+		fp.Inv(res, x)
+		return
+	}
+
+	mask := RandomMask(fp)
+	fp.Mul(res, x, mask)
+	fp.Inv(res, res)
+	fp.Mul(res, res, mask)
+}
+
+// sqrtRatio sets res to sqrt(u / v) if u / v is a square and returns true, and sets res to sqrt(Z * u / v) and
+// returns false otherwise. u and v are multiplied by a random mask if blinding is enabled, which doesn't change their
+// ratio, and are overwritten. The sign of the root is that of the method, which the mapping then fixes with sgn0.
+func (s *SSWU) sqrtRatio(res, u, v *big.Int) bool {
+	fp := s.fp
+
+	if Blinding() {
+		mask := RandomMask(fp)
+		fp.Mul(u, u, mask)
+		fp.Mul(v, v, mask)
+	}
+
+	if s.c1 == nil {
+		return fp.SqrtRatio(res, &s.z, u, v)
+	}
+
+	var tv1, tv2, y2 big.Int
+
+	fp.Square(&tv1, v)              // 1. tv1 = v^2
+	fp.Mul(&tv2, u, v)              // 2. tv2 = u * v
+	fp.Mul(&tv1, &tv1, &tv2)        // 3. tv1 = tv1 * tv2
+	fp.Exponent(res, &tv1, s.c1)    // 4. y1 = tv1^c1
+	fp.Mul(res, res, &tv2)          // 5. y1 = y1 * tv2
+	fp.Mul(&y2, res, s.c2)          // 6. y2 = y1 * c2
+	fp.Square(&tv1, res)            // 7. tv3 = y1^2
+	fp.Mul(&tv1, &tv1, v)           // 8. tv3 = tv3 * v
+	isQR := fp.AreEqual(&tv1, u)    // 9. isQR = tv3 == u
+	fp.CondMov(res, &y2, res, isQR) // 10. y = CMOV(y2, y1, isQR)
+
+	return isQR
 }
@@ -0,0 +1,96 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package internal
+
+// FieldElement is the interface of the elements of the fields generated by genfield for the primes p = 3 mod 4, whose
+// pointer type *E implements the arithmetic.
+type FieldElement[E any] interface {
+	*E
+	Set(x *E) *E
+	One() *E
+	Add(x, y *E) *E
+	Subtract(x, y *E) *E
+	Negate(x *E) *E
+	Multiply(x, y *E) *E
+	Square(x *E) *E
+	Invert(x *E) *E
+	Sqrt(x *E) int
+	Select(a, b *E, cond int) *E
+	IsZero() int
+	Sgn0() int
+}
+
+// SSWUElement holds the parameters of the Simplified SWU map over a generated field, whose arithmetic is
+// constant-time. It is safe for concurrent use.
+type SSWUElement[E any, F FieldElement[E]] struct {
+	a, b, z E
+	c1      E // -B / A
+	c2      E // B / (Z * A), the x-coordinate for the exceptional case
+}
+
+// NewSSWUElement returns the parameters of the Simplified SWU map over the generated field for the curve
+// y^2 = x^3 + a * x + b and the constant z, which must all be non-zero, with z a non-square.
+func NewSSWUElement[E any, F FieldElement[E]](a, b, z *E) *SSWUElement[E, F] {
+	s := &SSWUElement[E, F]{}
+	F(&s.a).Set(a)
+	F(&s.b).Set(b)
+	F(&s.z).Set(z)
+
+	var inv E
+
+	F(&inv).Invert(&s.a)
+	F(&s.c1).Multiply(&s.b, &inv)
+	F(&s.c1).Negate(&s.c1)
+	F(&s.c2).Invert(&s.z)
+	F(&s.c2).Multiply(&s.c2, &s.c1)
+	F(&s.c2).Negate(&s.c2)
+
+	return s
+}
+
+// MapElement implements the straight-line Simplified SWU method of RFC 9380 section 6.6.2, and sets x and y to the
+// affine coordinates of the point on the curve for u.
+func (s *SSWUElement[E, F]) MapElement(x, y, u *E) {
+	var zu2, tv1, x2, gx1, gx2, y2 E
+
+	F(&zu2).Square(u)
+	F(&zu2).Multiply(&zu2, &s.z) // Z * u^2
+	F(&tv1).Square(&zu2)
+	F(&tv1).Add(&tv1, &zu2) // Z^2 * u^4 + Z * u^2
+	exceptional := F(&tv1).IsZero()
+	F(&tv1).Invert(&tv1) // inv0, with inv0(0) = 0
+
+	F(x).One()
+	F(x).Add(x, &tv1)
+	F(x).Multiply(x, &s.c1)
+	F(x).Select(&s.c2, x, exceptional) // x1 = (-B / A) * (1 + tv1), or B / (Z * A) if tv1 == 0
+	s.g(&gx1, x)
+	F(&x2).Multiply(&zu2, x) // x2 = Z * u^2 * x1
+	s.g(&gx2, &x2)
+
+	isGx1Square := F(y).Sqrt(&gx1)
+	F(&y2).Sqrt(&gx2)
+	F(x).Select(x, &x2, isGx1Square)
+	F(y).Select(y, &y2, isGx1Square)
+
+	// y = -y if sgn0(u) != sgn0(y).
+	F(&y2).Negate(y)
+	F(y).Select(&y2, y, F(u).Sgn0()^F(y).Sgn0())
+}
+
+// g sets gx to x^3 + A * x + B.
+func (s *SSWUElement[E, F]) g(gx, x *E) {
+	var ax E
+
+	F(gx).Square(x)
+	F(gx).Multiply(gx, x)
+	F(&ax).Multiply(&s.a, x)
+	F(gx).Add(gx, &ax)
+	F(gx).Add(gx, &s.b)
+}
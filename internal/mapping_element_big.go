@@ -0,0 +1,48 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+package internal
+
+import "math/big"
+
+// BigFieldElement is FieldElement with the conversions from and to big.Int.
+type BigFieldElement[E any] interface {
+	FieldElement[E]
+	SetBig(x *big.Int) *E
+	Big() *big.Int
+}
+
+// SSWUBig is the Simplified SWU map of SSWUElement on big.Int field elements, whose conversions from and to the
+// generated field are not constant-time.
+type SSWUBig[E any, F BigFieldElement[E]] struct {
+	*SSWUElement[E, F]
+}
+
+// NewSSWUBig returns the Simplified SWU map over the generated field for the curve y^2 = x^3 + a * x + b and the
+// constant z, as NewSSWUElement does.
+func NewSSWUBig[E any, F BigFieldElement[E]](a, b, z *big.Int) SSWUBig[E, F] {
+	var ae, be, ze E
+
+	F(&ae).SetBig(a)
+	F(&be).SetBig(b)
+	F(&ze).SetBig(z)
+
+	return SSWUBig[E, F]{NewSSWUElement[E, F](&ae, &be, &ze)}
+}
+
+// Map returns the affine coordinates of the point of MapElement for fe, which must be reduced.
+func (s SSWUBig[E, F]) Map(fe *big.Int) (x, y *big.Int) {
+	var u, xe, ye E
+
+	F(&u).SetBig(fe)
+	s.MapElement(&xe, &ye, &u)
+
+	return F(&xe).Big(), F(&ye).Big()
+}
@@ -0,0 +1,113 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+// Package pubkey decodes the points output by the suites to the coordinates of the public key formats.
+package pubkey
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	ed "filippo.io/edwards25519"
+	"filippo.io/nistec"
+
+	"github.com/bytemare/hash2curve"
+	"github.com/bytemare/hash2curve/secp256k1"
+)
+
+const (
+	okpLength              = 32
+	secp256k1ElementLength = 32
+)
+
+// ErrUnsupportedCurve indicates a curve or a group that has no public key representation.
+var ErrUnsupportedCurve = errors.New("no key representation for the curve")
+
+// Coordinates returns the coordinates of the point in the encoding output by the suites on the curve, i.e. the
+// compressed or uncompressed SEC 1 encoding for the Weierstrass curves, and the 32-byte encoding for edwards25519 and
+// curve25519. These are the big-endian affine x and y for the Weierstrass curves, and the 32-byte encoding as x and a
+// nil y otherwise. It returns an error wrapping ErrUnsupportedCurve for ristretto255, and hash2curve.ErrInvalidPoint if
+// the point is invalid or is the point at infinity.
+func Coordinates(curve string, point []byte) (x, y []byte, err error) {
+	switch curve {
+	case "P256":
+		return sec1Coordinates(point, nistec.NewP256Point)
+	case "P384":
+		return sec1Coordinates(point, nistec.NewP384Point)
+	case "P521":
+		return sec1Coordinates(point, nistec.NewP521Point)
+	case "secp256k1":
+		return secp256k1Coordinates(point)
+	case "edwards25519":
+		if _, err = new(ed.Point).SetBytes(point); err != nil {
+			return nil, nil, fmt.Errorf("%w: %w", hash2curve.ErrInvalidPoint, err)
+		}
+
+		return okpCoordinates(point)
+	case "curve25519":
+		return okpCoordinates(point)
+	default:
+		return nil, nil, fmt.Errorf("%w: %s", ErrUnsupportedCurve, curve)
+	}
+}
+
+type sec1Point[P any] interface {
+	SetBytes(b []byte) (P, error)
+	Bytes() []byte
+}
+
+func sec1Coordinates[P sec1Point[P]](point []byte, newPoint func() P) (x, y []byte, err error) {
+	p, err := newPoint().SetBytes(point)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", hash2curve.ErrInvalidPoint, err)
+	}
+
+	return affine(p.Bytes())
+}
+
+// secp256k1Coordinates decodes the uncompressed encoding by decompressing its x-coordinate and comparing the results.
+func secp256k1Coordinates(point []byte) (x, y []byte, err error) {
+	compressed := point
+	if len(point) == 1+2*secp256k1ElementLength && point[0] == 4 {
+		compressed = append([]byte{2 | point[len(point)-1]&1}, point[1:1+secp256k1ElementLength]...)
+	}
+
+	p, err := new(secp256k1.Point).SetBytes(compressed)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	uncompressed := p.BytesUncompressed()
+	if len(compressed) != len(point) && !bytes.Equal(uncompressed, point) {
+		return nil, nil, fmt.Errorf("%w: not on the curve", hash2curve.ErrInvalidPoint)
+	}
+
+	return affine(uncompressed)
+}
+
+// affine splits the uncompressed SEC 1 encoding of a point into its coordinates.
+func affine(encoding []byte) (x, y []byte, err error) {
+	if len(encoding) == 1 {
+		return nil, nil, fmt.Errorf("%w: the point at infinity has no key representation", hash2curve.ErrInvalidPoint)
+	}
+
+	byteLen := (len(encoding) - 1) / 2
+
+	return encoding[1 : 1+byteLen], encoding[1+byteLen:], nil
+}
+
+func okpCoordinates(point []byte) (x, y []byte, err error) {
+	if len(point) != okpLength {
+		return nil, nil, fmt.Errorf("%w: expected %d bytes, got %d", hash2curve.ErrInvalidPoint, okpLength, len(point))
+	}
+
+	return point, nil, nil
+}
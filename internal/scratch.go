@@ -0,0 +1,135 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package internal
+
+import (
+	"crypto"
+	"hash"
+	"math"
+	"time"
+)
+
+// maxDigestSize is the largest output length of a crypto.Hash.
+const maxDigestSize = 64
+
+var (
+	// zeroBlock is a read-only block of zeros, large enough for Z_pad with any crypto.Hash, e.g. the 144 bytes of the
+	// rate of SHA3-224.
+	zeroBlock [256]byte
+
+	// dstLongPrefixBytes is dstLongPrefix, without converting it on each use.
+	dstLongPrefixBytes = []byte(dstLongPrefix)
+)
+
+// zeros returns n bytes of zeros, without allocating if n fits in zeroBlock. They must not be written to.
+func zeros(n int) []byte {
+	if n <= len(zeroBlock) {
+		return zeroBlock[:n]
+	}
+
+	return make([]byte, n)
+}
+
+// XMDScratch holds a hash state and all the working memory of expand_message_xmd, so that expansions into caller
+// provided buffers don't allocate. It must not be used concurrently.
+type XMDScratch struct {
+	h        hash.Hash
+	id       crypto.Hash
+	dstPrime [dstMaxLength + 1]byte
+	b0       [maxDigestSize]byte
+	bi       [maxDigestSize]byte
+	suffix   [3]byte
+}
+
+// NewXMDScratch returns an XMDScratch for id. It panics with ErrUnsupportedHash if id is not available.
+func NewXMDScratch(id crypto.Hash) *XMDScratch {
+	if !id.Available() || id.Size() > maxDigestSize {
+		panic(ErrUnsupportedHash)
+	}
+
+	return &XMDScratch{h: id.New(), id: id}
+}
+
+// Hash returns the hash function of the scratch.
+func (s *XMDScratch) Hash() crypto.Hash {
+	return s.id
+}
+
+// ExpandInto sets out to expand_message_xmd of input and dst, with len(out) as the length. Only the hash function
+// allocates, if its implementation does. The scratch is wiped before returning.
+func (s *XMDScratch) ExpandInto(out, input, dst []byte) {
+	if observe := ObserveExpand(); observe != nil {
+		defer observe(xmdPrefix+s.id.String(), 1, uint(len(out)), time.Now())
+	}
+
+	size := s.h.Size()
+	ell := (len(out) + size - 1) / size
+
+	if ell > math.MaxUint8 || len(out) > math.MaxUint16 {
+		panic(ErrLengthTooLarge)
+	}
+
+	defer s.wipe()
+
+	h := s.h
+	dstPrime := s.vetDST(dst)
+
+	// b_0 = H(Z_pad || msg || l_i_b_str || I2OSP(0, 1) || DST_prime)
+	s.suffix = [3]byte{byte(len(out) >> 8), byte(len(out)), 0}
+
+	absorbZPad(s.id, h)
+	_, _ = h.Write(input)
+	_, _ = h.Write(s.suffix[:])
+	_, _ = h.Write(dstPrime)
+	b0 := h.Sum(s.b0[:0])
+
+	// b_i = H(strxor(b_0, b_(i - 1)) || I2OSP(i, 1) || DST_prime), with b_1 = H(b_0 || I2OSP(1, 1) || DST_prime).
+	bi := s.bi[:size]
+	copy(bi, b0)
+
+	for i := 1; i <= ell; i++ {
+		if i > 1 {
+			xorSlices(bi, b0)
+		}
+
+		s.suffix[0] = byte(i)
+
+		h.Reset()
+		_, _ = h.Write(bi)
+		_, _ = h.Write(s.suffix[:1])
+		_, _ = h.Write(dstPrime)
+		bi = h.Sum(bi[:0])
+
+		copy(out[(i-1)*size:], bi)
+	}
+}
+
+// vetDST writes DST_prime into the scratch, shortening dst as VetDSTXMD does, and returns it.
+func (s *XMDScratch) vetDST(dst []byte) []byte {
+	n := len(dst)
+
+	if n > dstMaxLength {
+		s.h.Reset()
+		_, _ = s.h.Write(dstLongPrefixBytes)
+		_, _ = s.h.Write(dst)
+		n = len(s.h.Sum(s.dstPrime[:0]))
+	} else {
+		copy(s.dstPrime[:], dst)
+	}
+
+	s.dstPrime[n] = byte(n)
+
+	return s.dstPrime[:n+1]
+}
+
+// wipe clears the working memory and resets the hash state.
+func (s *XMDScratch) wipe() {
+	s.h.Reset()
+	Wipe(s.dstPrime[:], s.b0[:], s.bi[:], s.suffix[:])
+}
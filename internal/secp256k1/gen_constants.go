@@ -0,0 +1,136 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build ignore
+
+// This program generates constants.go, holding the precomputed limbs of the secp256k1 mapping and isogeny constants.
+// Run it with go generate.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"log"
+	"math/big"
+	"os"
+)
+
+const header = `// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Code generated by gen_constants.go. DO NOT EDIT.
+
+package secp256k1
+
+import "github.com/bytemare/hash2curve/internal/field/fp256k1"
+
+// The constants of the simplified SWU map on the 3-isogenous curve E', and the coefficients k_(i,j) of the
+// isogeny map of RFC 9380, Appendix E.1, named Kij.
+`
+
+var p, _ = new(big.Int).SetString("fffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f", 16)
+
+type constant struct {
+	name, comment string
+	value         *big.Int
+}
+
+func hexInt(s string) *big.Int {
+	i, ok := new(big.Int).SetString(s, 16)
+	if !ok {
+		log.Fatalf("invalid constant %q", s)
+	}
+
+	return i
+}
+
+func constants() []constant {
+	z := new(big.Int).Mod(big.NewInt(-11), p)
+
+	// sqrt(-Z) = (-Z)^((p+1)/4), since p = 3 mod 4.
+	e := new(big.Int).Add(p, big.NewInt(1))
+	e.Rsh(e, 2)
+	negZ := new(big.Int).Mod(new(big.Int).Neg(z), p)
+	c2 := new(big.Int).Exp(negZ, e, p)
+
+	if new(big.Int).Exp(c2, big.NewInt(2), p).Cmp(negZ) != 0 {
+		log.Fatal("-Z is not a square")
+	}
+
+	return []constant{
+		{"Z", "Z = -11.", z},
+		{"C2", "sqrt(-Z), used in sqrt_ratio.", c2},
+		{
+			"IsoA", "A' of the 3-isogenous curve.",
+			hexInt("3f8731abdd661adca08a5558f0f5d272e953d363cb6f0e5d405447c01a444533"),
+		},
+		{"IsoB", "B' = 1771 of the 3-isogenous curve.", big.NewInt(1771)},
+		{"K10", "", hexInt("8e38e38e38e38e38e38e38e38e38e38e38e38e38e38e38e38e38e38daaaaa8c7")},
+		{"K11", "", hexInt("07d3d4c80bc321d5b9f315cea7fd44c5d595d2fc0bf63b92dfff1044f17c6581")},
+		{"K12", "", hexInt("534c328d23f234e6e2a413deca25caece4506144037c40314ecbd0b53d9dd262")},
+		{"K13", "", hexInt("8e38e38e38e38e38e38e38e38e38e38e38e38e38e38e38e38e38e38daaaaa88c")},
+		{"K20", "", hexInt("d35771193d94918a9ca34ccbb7b640dd86cd409542f8487d9fe6b745781eb49b")},
+		{"K21", "", hexInt("edadc6f64383dc1df7c4b2d51b54225406d36b641f5e41bbc52a56612a8c6d14")},
+		{"K30", "", hexInt("4bda12f684bda12f684bda12f684bda12f684bda12f684bda12f684b8e38e23c")},
+		{"K31", "", hexInt("c75e0c32d5cb7c0fa9d0a54b12a0a6d5647ab046d686da6fdffc90fc201d71a3")},
+		{"K32", "", hexInt("29a6194691f91a73715209ef6512e576722830a201be2018a765e85a9ecee931")},
+		{"K33", "", hexInt("2f684bda12f684bda12f684bda12f684bda12f684bda12f684bda12f38e38d84")},
+		{"K40", "", hexInt("fffffffffffffffffffffffffffffffffffffffffffffffffffffffefffff93b")},
+		{"K41", "", hexInt("7a06534bb8bdb49fd5e9e6632722c2989467c1bfc8e8d978dfb425d2685c2573")},
+		{"K42", "", hexInt("6484aa716545ca2cf3a70c3fa8fe337e0a3d21162f0d6299a7bf8192bfd2a76f")},
+	}
+}
+
+func limbs(v *big.Int) [4]uint64 {
+	var l [4]uint64
+
+	mask := new(big.Int).SetUint64(^uint64(0))
+	for i := range l {
+		l[i] = new(big.Int).And(new(big.Int).Rsh(v, uint(64*i)), mask).Uint64()
+	}
+
+	return l
+}
+
+func main() {
+	var buf bytes.Buffer
+
+	buf.WriteString(header)
+	buf.WriteString("var (\n")
+
+	for _, c := range constants() {
+		if c.value.Cmp(p) >= 0 {
+			log.Fatalf("constant %s is not reduced", c.name)
+		}
+
+		if c.comment != "" {
+			fmt.Fprintf(&buf, "\t// %s\n", c.comment)
+		}
+
+		l := limbs(c.value)
+		fmt.Fprintf(&buf, "\t%s = fp256k1.NewElement([4]uint64{\n\t\t%#016x, %#016x, %#016x, %#016x,\n\t})\n",
+			c.name, l[0], l[1], l[2], l[3])
+	}
+
+	buf.WriteString(")\n")
+
+	out, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err = os.WriteFile("constants.go", out, 0o600); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,188 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package secp256k1 implements the simplified SWU map to the 3-isogenous curve of secp256k1 and the isogeny map of
+// RFC 9380 on the fixed-limb field, without math/big.
+package secp256k1
+
+//go:generate go run gen_constants.go
+
+import (
+	"github.com/bytemare/hash2curve/internal"
+	"github.com/bytemare/hash2curve/internal/field/fp256k1"
+)
+
+// maskLength is the length of the uniform bytes reduced to random masks, i.e. L of the secp256k1 suites.
+const maskLength = 48
+
+// IsoPoint is an affine point on the 3-isogenous curve, with limb-based coordinates.
+type IsoPoint struct {
+	X, Y fp256k1.Element
+}
+
+// MapToIsoCurve implements the straight-line Simplified SWU method on the 3-isogenous curve.
+func MapToIsoCurve(u *fp256k1.Element) *IsoPoint {
+	var tv1, tv2, tv3, tv4, tv5, tv6, y1, t fp256k1.Element
+	var one fp256k1.Element
+
+	q := new(IsoPoint)
+	x, y := &q.X, &q.Y
+	one.One()
+
+	tv1.Square(u)                                //    1.  tv1 = u^2
+	tv1.Multiply(&Z, &tv1)                       //    2.  tv1 = Z * tv1
+	tv2.Square(&tv1)                             //    3.  tv2 = tv1^2
+	tv2.Add(&tv2, &tv1)                          //    4.  tv2 = tv2 + tv1
+	tv3.Add(&tv2, &one)                          //    5.  tv3 = tv2 + 1
+	tv3.Multiply(&IsoB, &tv3)                    //    6.  tv3 = B * tv3
+	tv4.Select(&Z, t.Negate(&tv2), tv2.IsZero()) //    7.  tv4 = CMOV(Z, -tv2, tv2 != 0)
+	tv4.Multiply(&IsoA, &tv4)                    //    8.  tv4 = A * tv4
+	tv2.Square(&tv3)                             //    9.  tv2 = tv3^2
+	tv6.Square(&tv4)                             //    10. tv6 = tv4^2
+	tv5.Multiply(&IsoA, &tv6)                    //    11. tv5 = A * tv6
+	tv2.Add(&tv2, &tv5)                          //    12. tv2 = tv2 + tv5
+	tv2.Multiply(&tv2, &tv3)                     //    13. tv2 = tv2 * tv3
+	tv6.Multiply(&tv6, &tv4)                     //    14. tv6 = tv6 * tv4
+	tv5.Multiply(&IsoB, &tv6)                    //    15. tv5 = B * tv6
+	tv2.Add(&tv2, &tv5)                          //    16. tv2 = tv2 + tv5
+	x.Multiply(&tv1, &tv3)                       //    17.   x = tv1 * tv3
+
+	if internal.Blinding() {
+		// Masking both terms doesn't change their ratio, and thus the square root.
+		mask := RandomMask()
+		tv2.Multiply(&tv2, mask)
+		tv6.Multiply(&tv6, mask)
+	}
+
+	isGx1Square := y1.SqrtRatio(&tv2, &tv6, &C2) //    18. isGx1Square, y1 = sqrt_ratio(tv2, tv6)
+	y.Multiply(&tv1, u)                          //    19.   y = tv1 * u
+	y.Multiply(y, &y1)                           //    20.   y = y * y1
+	x.Select(&tv3, x, isGx1Square)               //    21.   x = CMOV(x, tv3, isGx1Square)
+	y.Select(&y1, y, isGx1Square)                //    22.   y = CMOV(y, y1, isGx1Square)
+	e1 := 1 ^ (u.Sgn0() ^ y.Sgn0())              //    23.  e1 = sgn0(u) == sgn0(y)
+	y.Select(y, t.Negate(y), e1)                 //    24.   y = CMOV(-y, y, e1)
+	Invert(&tv4, &tv4)                           //    25.   1 / tv4
+	x.Multiply(x, &tv4)                          //    26.   x = x / tv4
+
+	return q
+}
+
+// Add sets p to p + q and returns p. It uses an affine add because the others are tailored for a = 0 and b = 7.
+func (p *IsoPoint) Add(q *IsoPoint) *IsoPoint {
+	var t0, t1, ll, x, y fp256k1.Element
+	x1, y1 := &p.X, &p.Y
+	x2, y2 := &q.X, &q.Y
+
+	t0.Subtract(y2, y1)   // (y2-y1)
+	t1.Subtract(x2, x1)   // (x2-x1)
+	Invert(&t1, &t1)      // 1/(x2-x1)
+	ll.Multiply(&t0, &t1) // l = (y2-y1)/(x2-x1).
+
+	t0.Square(&ll)       // l^2
+	t0.Subtract(&t0, x1) // l^2-x1
+	x.Subtract(&t0, x2)  // X' = l^2-x1-x2
+
+	t0.Subtract(x1, &x)   // x1-x3
+	t0.Multiply(&t0, &ll) // l(x1-x3)
+	y.Subtract(&t0, y1)   // y3 = l(x1-x3)-y1.
+
+	p.X.Set(&x)
+	p.Y.Set(&y)
+
+	return p
+}
+
+// Isogeny is the 3-degree isogeny from secp256k1 3-ISO to the secp256k1 elliptic curve. isIdentity is 1 if the image
+// is the point at infinity, and 0 otherwise.
+func Isogeny(x, y *fp256k1.Element) (px, py *fp256k1.Element, isIdentity int) {
+	var x2, x3, k11, k12, k13, k21, k31, k32, k33, k41, k42 fp256k1.Element
+	x2.Square(x)
+	x3.Multiply(&x2, x)
+
+	// x_num, x_den
+	var xNum fp256k1.Element
+	k13.Multiply(&K13, &x3) // _k(1,3) * x'^3
+	k12.Multiply(&K12, &x2) // _k(1,2) * x'^2
+	k11.Multiply(&K11, x)   // _k(1,1) * x'
+	xNum.Add(&k13, &k12)
+	xNum.Add(&xNum, &k11)
+	xNum.Add(&xNum, &K10)
+
+	var xDen fp256k1.Element
+	k21.Multiply(&K21, x) // _k(2,1) * x'
+	xDen.Add(&x2, &k21)
+	xDen.Add(&xDen, &K20)
+
+	// y_num, y_den
+	var yNum fp256k1.Element
+	k33.Multiply(&K33, &x3) // _k(3,3) * x'^3
+	k32.Multiply(&K32, &x2) // _k(3,2) * x'^2
+	k31.Multiply(&K31, x)   // _k(3,1) * x'
+	yNum.Add(&k33, &k32)
+	yNum.Add(&yNum, &k31)
+	yNum.Add(&yNum, &K30)
+
+	var yDen fp256k1.Element
+	k42.Multiply(&K42, &x2) // _k(4,2) * x'^2
+	k41.Multiply(&K41, x)   // _k(4,1) * x'
+	yDen.Add(&x3, &k42)
+	yDen.Add(&yDen, &k41)
+	yDen.Add(&yDen, &K40)
+
+	// final x, y
+	px, py = new(fp256k1.Element), new(fp256k1.Element)
+
+	var mask *fp256k1.Element
+	if internal.Blinding() {
+		mask = RandomMask()
+		xDen.Multiply(&xDen, mask)
+		yDen.Multiply(&yDen, mask)
+	}
+
+	fp256k1.BatchInvert([]*fp256k1.Element{px, py}, []*fp256k1.Element{&xDen, &yDen})
+
+	if mask != nil {
+		px.Multiply(px, mask)
+		py.Multiply(py, mask)
+	}
+
+	isIdentity = px.IsZero() | py.IsZero()
+	px.Multiply(px, &xNum)
+	py.Multiply(py, &yNum)
+	py.Multiply(py, y)
+
+	return px, py, isIdentity
+}
+
+// Invert sets e to 1 / x, computed as l / (l * x) with a random mask l if blinding is enabled, and returns e.
+func Invert(e, x *fp256k1.Element) *fp256k1.Element {
+	if !internal.Blinding() {
+		return e.Invert(x)
+	}
+
+	mask := RandomMask()
+	e.Multiply(x, mask)
+	e.Invert(e)
+
+	return e.Multiply(e, mask)
+}
+
+// RandomMask returns a random non-zero field element, for multiplicative masking.
+func RandomMask() *fp256k1.Element {
+	var b [maskLength]byte
+	defer internal.Wipe(b[:])
+
+	mask := new(fp256k1.Element)
+
+	for mask.IsZero() == 1 {
+		internal.RandomBytes(b[:])
+		mask.SetWideBytes(b[:])
+	}
+
+	return mask
+}
@@ -0,0 +1,258 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !purego
+
+package internal
+
+import (
+	"encoding/binary"
+	"math"
+	"sync"
+	"sync/atomic"
+)
+
+const (
+	sha256BlockSize = 64
+	sha256Size      = 32
+)
+
+// sha256IV is the initial hash value of SHA-256.
+var sha256IV = [8]uint32{
+	0x6a09e667, 0xbb67ae85, 0x3c6ef372, 0xa54ff53a, 0x510e527f, 0x9b05688c, 0x1f83d9ab, 0x5be0cd19,
+}
+
+// block8 applies the SHA-256 compression function to each of the 8 lanes of the transposed state, with the transposed
+// message words in w[0:16]. It overwrites w[16:64] with the message schedule.
+//
+//go:noescape
+func block8(state *[8][8]uint32, w *[64][8]uint32)
+
+func cpuid(eaxArg, ecxArg uint32) (eax, ebx, ecx, edx uint32)
+
+func xgetbv() (eax uint32)
+
+// cpuFeatures reports whether the CPU and the OS support AVX2, and whether the CPU supports the SHA extensions.
+func cpuFeatures() (avx2, sha bool) {
+	const (
+		osxsave = 1 << 27
+		avx     = 1 << 28
+		ymm     = 6 // the XMM and YMM state bits of XCR0
+	)
+
+	if maxID, _, _, _ := cpuid(0, 0); maxID < 7 {
+		return false, false
+	}
+
+	_, ebx, _, _ := cpuid(7, 0)
+	sha = ebx&(1<<29) != 0
+
+	if _, _, ecx, _ := cpuid(1, 0); ecx&osxsave == 0 || ecx&avx == 0 || xgetbv()&ymm != ymm {
+		return false, sha
+	}
+
+	return ebx&(1<<5) != 0, sha
+}
+
+var (
+	hasAVX2, hasSHA = cpuFeatures()
+
+	// useSHA256x8 selects the multi-lane SHA-256 for batch expansions. With the SHA extensions, the standard library
+	// hashes faster on a single lane.
+	useSHA256x8 = func() *atomic.Bool {
+		var b atomic.Bool
+		b.Store(hasAVX2 && !hasSHA)
+
+		return &b
+	}()
+)
+
+// ForceSHA256x8 enables or disables the multi-lane SHA-256 of batch expansions, regardless of the SHA extensions, e.g.
+// for tests and benchmarks, and returns whether it was enabled. It can't be enabled without AVX2.
+func ForceSHA256x8(enabled bool) bool {
+	return useSHA256x8.Swap(enabled && hasAVX2)
+}
+
+// sha256x8 holds the transposed state and message schedule of 8 SHA-256 instances.
+type sha256x8 struct {
+	state [8][8]uint32
+	w     [64][8]uint32
+}
+
+var (
+	zPadMidstateOnce sync.Once
+
+	// zPadMidstate is the SHA-256 state after absorbing Z_pad, i.e. one block of zeros.
+	zPadMidstate [8]uint32
+)
+
+func sha256ZPadMidstate() *[8]uint32 {
+	zPadMidstateOnce.Do(func() {
+		var s sha256x8
+
+		s.reset(&sha256IV)
+		block8(&s.state, &s.w)
+
+		for i := range zPadMidstate {
+			zPadMidstate[i] = s.state[i][0]
+		}
+	})
+
+	return &zPadMidstate
+}
+
+func (s *sha256x8) reset(iv *[8]uint32) {
+	for i, v := range iv {
+		for lane := range sha256Lanes {
+			s.state[i][lane] = v
+		}
+	}
+}
+
+// sum sets digests[i] to the SHA-256 digest of msgs[i], for up to 8 messages, starting from the state iv in which
+// whole blocks were already absorbed. The message of each lane must have been padded with pad. The lanes with shorter
+// messages are compressed with zero blocks, which are then ignored.
+func (s *sha256x8) sum(digests [][]byte, iv *[8]uint32, msgs [][]byte) {
+	s.reset(iv)
+
+	blocks := 0
+	for _, m := range msgs {
+		blocks = max(blocks, len(m)/sha256BlockSize)
+	}
+
+	for b := range blocks {
+		offset := b * sha256BlockSize
+
+		for lane, m := range msgs {
+			if offset >= len(m) {
+				for t := range 16 {
+					s.w[t][lane] = 0
+				}
+
+				continue
+			}
+
+			for t := range 16 {
+				s.w[t][lane] = binary.BigEndian.Uint32(m[offset+4*t:])
+			}
+		}
+
+		block8(&s.state, &s.w)
+
+		for lane, m := range msgs {
+			if len(m) == offset+sha256BlockSize {
+				for i := range 8 {
+					binary.BigEndian.PutUint32(digests[lane][4*i:], s.state[i][lane])
+				}
+			}
+		}
+	}
+}
+
+// wipe clears the state and the message schedule.
+func (s *sha256x8) wipe() {
+	*s = sha256x8{}
+}
+
+// pad appends the SHA-256 padding to the message in buf, which follows prefixLen bytes already absorbed.
+func pad(buf []byte, prefixLen int) []byte {
+	length := prefixLen + len(buf)
+	zeros := sha256BlockSize - 1 - (length+8)%sha256BlockSize
+
+	buf = append(buf, 0x80)
+	for range zeros {
+		buf = append(buf, 0)
+	}
+
+	return binary.BigEndian.AppendUint64(buf, uint64(length)*8)
+}
+
+// paddedLength returns the length of a message of length bytes once padded.
+func paddedLength(length int) int {
+	return (length + 8 + sha256BlockSize) &^ (sha256BlockSize - 1)
+}
+
+// canExpandXMDSHA256x8 reports whether expandXMDSHA256x8 is available, and faster than the SHA-256 implementation of
+// the standard library, i.e. by default if the CPU supports AVX2 but not the SHA extensions.
+func canExpandXMDSHA256x8() bool {
+	return useSHA256x8.Load()
+}
+
+// expandXMDSHA256x8 returns expand_message_xmd with SHA-256 of each of up to 8 inputs with the DST prime, computing the
+// hashes of the inputs together with block8.
+func expandXMDSHA256x8(inputs [][]byte, dstPrime []byte, length uint) [][]byte {
+	ell := (length + sha256Size - 1) / sha256Size
+	if ell > math.MaxUint8 || length > math.MaxUint16 || len(dstPrime) > math.MaxUint8+1 {
+		panic(ErrLengthTooLarge)
+	}
+
+	var s sha256x8
+	defer s.wipe()
+
+	n := len(inputs)
+	msgs := make([][]byte, n)
+	out := make([][]byte, n)
+	b0 := make([][]byte, n)
+	bi := make([][]byte, n)
+
+	// The b_0 digests, and the messages of the b_i, share a buffer.
+	biLength := paddedLength(sha256Size + 1 + len(dstPrime))
+	scratch := make([]byte, n*(sha256Size+biLength))
+
+	defer Wipe(scratch)
+
+	// b_0 = H(Z_pad || msg || l_i_b_str || I2OSP(0, 1) || DST_prime), from the state after absorbing Z_pad.
+	for i, input := range inputs {
+		m := make([]byte, 0, paddedLength(len(input)+3+len(dstPrime)))
+		m = append(m, input...)
+		m = append(m, I2OSP(length, 2)...)
+		m = append(m, 0)
+		m = append(m, dstPrime...)
+		msgs[i] = pad(m, sha256BlockSize)
+		b0[i] = scratch[i*sha256Size : (i+1)*sha256Size]
+		out[i] = make([]byte, ell*sha256Size)
+	}
+
+	s.sum(b0, sha256ZPadMidstate(), msgs)
+	Wipe(msgs...)
+
+	scratch = scratch[n*sha256Size:]
+	for i := range n {
+		msgs[i] = scratch[i*biLength : i*biLength : (i+1)*biLength]
+	}
+
+	// b_i = H(strxor(b_0, b_(i - 1)) || I2OSP(i, 1) || DST_prime), with b_1 = H(b_0 || I2OSP(1, 1) || DST_prime).
+	for j := uint(1); j <= ell; j++ {
+		for i := range n {
+			m := msgs[i][:0]
+
+			if j == 1 {
+				m = append(m, b0[i]...)
+			} else {
+				prev := out[i][(j-2)*sha256Size : (j-1)*sha256Size]
+				for k := range sha256Size {
+					m = append(m, b0[i][k]^prev[k])
+				}
+			}
+
+			m = append(m, byte(j))
+			m = append(m, dstPrime...)
+			msgs[i] = pad(m, 0)
+			bi[i] = out[i][(j-1)*sha256Size : j*sha256Size]
+		}
+
+		s.sum(bi, &sha256IV, msgs)
+	}
+
+	for i := range out {
+		Wipe(out[i][length:])
+		out[i] = out[i][:length]
+	}
+
+	return out
+}
@@ -0,0 +1,269 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !purego
+
+#include "textflag.h"
+
+// The SHA-256 compression function on 8 independent lanes, one per 32-bit element of the AVX2 registers. The state
+// and the message schedule are transposed, i.e. state[i][lane] and w[t][lane], so that each round of each lane is
+// computed by the same vector instruction. Without AVX-512, the rotations are shifts and ORs.
+
+// ROTR_XOR sets dst ^= x >>> n, using tmp.
+#define ROTR_XOR(x, n, dst, tmp) \
+	VPSRLD $n, x, tmp; \
+	VPXOR  tmp, dst, dst; \
+	VPSLLD $(32-n), x, tmp; \
+	VPXOR  tmp, dst, dst
+
+// SIGMA sets dst = (x >>> r1) ^ (x >>> r2) ^ (x >>> r3), using t1, t2, and t3, with independent shifts to shorten the
+// dependency chain.
+#define SIGMA(x, r1, r2, r3, dst, t1, t2, t3) \
+	VPSRLD $r1, x, dst; \
+	VPSLLD $(32-r1), x, t1; \
+	VPSRLD $r2, x, t2; \
+	VPSLLD $(32-r2), x, t3; \
+	VPOR   t1, dst, dst; \
+	VPOR   t3, t2, t2; \
+	VPSRLD $r3, x, t1; \
+	VPSLLD $(32-r3), x, t3; \
+	VPOR   t3, t1, t1; \
+	VPXOR  t2, dst, dst; \
+	VPXOR  t1, dst, dst
+
+// ROUND computes round i, with the message word at (i*32)(DI) and the constant at (i*4)(R8), and leaves the new a in
+// h and the new e in d, so that the next round is called with the registers rotated. The terms of T1 that don't depend
+// on e are summed first in Y12, which then holds T1, and Y8 holds Σ1(e), then Σ0(a) + Maj(a, b, c).
+#define ROUND(a, b, c, d, e, f, g, h, i) \
+	VPADDD       (i*32)(DI), h, Y12; \
+	VPBROADCASTD (i*4)(R8), Y13; \
+	VPADDD       Y13, Y12, Y12; \
+	VPXOR        f, g, Y13; \
+	VPAND        e, Y13, Y13; \
+	VPXOR        g, Y13, Y13; \
+	VPADDD       Y13, Y12, Y12; \
+	SIGMA(e, 6, 11, 25, Y8, Y9, Y10, Y11); \
+	VPADDD       Y8, Y12, Y12; \
+	VPADDD       Y12, d, d; \
+	SIGMA(a, 2, 13, 22, Y8, Y9, Y10, Y11); \
+	VPOR         a, b, Y13; \
+	VPAND        c, Y13, Y13; \
+	VPAND        a, b, Y14; \
+	VPOR         Y14, Y13, Y13; \
+	VPADDD       Y13, Y8, Y8; \
+	VPADDD       Y8, Y12, h
+
+// func block8(state *[8][8]uint32, w *[64][8]uint32)
+TEXT ·block8(SB), NOSPLIT, $0-16
+	MOVQ state+0(FP), SI
+	MOVQ w+8(FP), DI
+
+	// Expand the message schedule w[16:64] from w[0:16].
+	LEAQ 512(DI), DX
+	MOVQ $48, CX
+
+schedule:
+	// Y9 = σ1(w[t-2]) = (x >>> 17) ^ (x >>> 19) ^ (x >> 10)
+	VMOVDQU -64(DX), Y8
+	VPSRLD  $10, Y8, Y9
+	ROTR_XOR(Y8, 17, Y9, Y10)
+	ROTR_XOR(Y8, 19, Y9, Y10)
+
+	// Y11 = σ0(w[t-15]) = (x >>> 7) ^ (x >>> 18) ^ (x >> 3)
+	VMOVDQU -480(DX), Y8
+	VPSRLD  $3, Y8, Y11
+	ROTR_XOR(Y8, 7, Y11, Y10)
+	ROTR_XOR(Y8, 18, Y11, Y10)
+
+	// w[t] = σ1(w[t-2]) + w[t-7] + σ0(w[t-15]) + w[t-16]
+	VPADDD  Y11, Y9, Y9
+	VPADDD  -224(DX), Y9, Y9
+	VPADDD  -512(DX), Y9, Y9
+	VMOVDQU Y9, (DX)
+	ADDQ    $32, DX
+	DECQ    CX
+	JNZ     schedule
+
+	LEAQ ·k256<>(SB), R8
+
+	VMOVDQU 0(SI), Y0
+	VMOVDQU 32(SI), Y1
+	VMOVDQU 64(SI), Y2
+	VMOVDQU 96(SI), Y3
+	VMOVDQU 128(SI), Y4
+	VMOVDQU 160(SI), Y5
+	VMOVDQU 192(SI), Y6
+	VMOVDQU 224(SI), Y7
+	ROUND(Y0, Y1, Y2, Y3, Y4, Y5, Y6, Y7, 0)
+	ROUND(Y7, Y0, Y1, Y2, Y3, Y4, Y5, Y6, 1)
+	ROUND(Y6, Y7, Y0, Y1, Y2, Y3, Y4, Y5, 2)
+	ROUND(Y5, Y6, Y7, Y0, Y1, Y2, Y3, Y4, 3)
+	ROUND(Y4, Y5, Y6, Y7, Y0, Y1, Y2, Y3, 4)
+	ROUND(Y3, Y4, Y5, Y6, Y7, Y0, Y1, Y2, 5)
+	ROUND(Y2, Y3, Y4, Y5, Y6, Y7, Y0, Y1, 6)
+	ROUND(Y1, Y2, Y3, Y4, Y5, Y6, Y7, Y0, 7)
+	ROUND(Y0, Y1, Y2, Y3, Y4, Y5, Y6, Y7, 8)
+	ROUND(Y7, Y0, Y1, Y2, Y3, Y4, Y5, Y6, 9)
+	ROUND(Y6, Y7, Y0, Y1, Y2, Y3, Y4, Y5, 10)
+	ROUND(Y5, Y6, Y7, Y0, Y1, Y2, Y3, Y4, 11)
+	ROUND(Y4, Y5, Y6, Y7, Y0, Y1, Y2, Y3, 12)
+	ROUND(Y3, Y4, Y5, Y6, Y7, Y0, Y1, Y2, 13)
+	ROUND(Y2, Y3, Y4, Y5, Y6, Y7, Y0, Y1, 14)
+	ROUND(Y1, Y2, Y3, Y4, Y5, Y6, Y7, Y0, 15)
+	ROUND(Y0, Y1, Y2, Y3, Y4, Y5, Y6, Y7, 16)
+	ROUND(Y7, Y0, Y1, Y2, Y3, Y4, Y5, Y6, 17)
+	ROUND(Y6, Y7, Y0, Y1, Y2, Y3, Y4, Y5, 18)
+	ROUND(Y5, Y6, Y7, Y0, Y1, Y2, Y3, Y4, 19)
+	ROUND(Y4, Y5, Y6, Y7, Y0, Y1, Y2, Y3, 20)
+	ROUND(Y3, Y4, Y5, Y6, Y7, Y0, Y1, Y2, 21)
+	ROUND(Y2, Y3, Y4, Y5, Y6, Y7, Y0, Y1, 22)
+	ROUND(Y1, Y2, Y3, Y4, Y5, Y6, Y7, Y0, 23)
+	ROUND(Y0, Y1, Y2, Y3, Y4, Y5, Y6, Y7, 24)
+	ROUND(Y7, Y0, Y1, Y2, Y3, Y4, Y5, Y6, 25)
+	ROUND(Y6, Y7, Y0, Y1, Y2, Y3, Y4, Y5, 26)
+	ROUND(Y5, Y6, Y7, Y0, Y1, Y2, Y3, Y4, 27)
+	ROUND(Y4, Y5, Y6, Y7, Y0, Y1, Y2, Y3, 28)
+	ROUND(Y3, Y4, Y5, Y6, Y7, Y0, Y1, Y2, 29)
+	ROUND(Y2, Y3, Y4, Y5, Y6, Y7, Y0, Y1, 30)
+	ROUND(Y1, Y2, Y3, Y4, Y5, Y6, Y7, Y0, 31)
+	ROUND(Y0, Y1, Y2, Y3, Y4, Y5, Y6, Y7, 32)
+	ROUND(Y7, Y0, Y1, Y2, Y3, Y4, Y5, Y6, 33)
+	ROUND(Y6, Y7, Y0, Y1, Y2, Y3, Y4, Y5, 34)
+	ROUND(Y5, Y6, Y7, Y0, Y1, Y2, Y3, Y4, 35)
+	ROUND(Y4, Y5, Y6, Y7, Y0, Y1, Y2, Y3, 36)
+	ROUND(Y3, Y4, Y5, Y6, Y7, Y0, Y1, Y2, 37)
+	ROUND(Y2, Y3, Y4, Y5, Y6, Y7, Y0, Y1, 38)
+	ROUND(Y1, Y2, Y3, Y4, Y5, Y6, Y7, Y0, 39)
+	ROUND(Y0, Y1, Y2, Y3, Y4, Y5, Y6, Y7, 40)
+	ROUND(Y7, Y0, Y1, Y2, Y3, Y4, Y5, Y6, 41)
+	ROUND(Y6, Y7, Y0, Y1, Y2, Y3, Y4, Y5, 42)
+	ROUND(Y5, Y6, Y7, Y0, Y1, Y2, Y3, Y4, 43)
+	ROUND(Y4, Y5, Y6, Y7, Y0, Y1, Y2, Y3, 44)
+	ROUND(Y3, Y4, Y5, Y6, Y7, Y0, Y1, Y2, 45)
+	ROUND(Y2, Y3, Y4, Y5, Y6, Y7, Y0, Y1, 46)
+	ROUND(Y1, Y2, Y3, Y4, Y5, Y6, Y7, Y0, 47)
+	ROUND(Y0, Y1, Y2, Y3, Y4, Y5, Y6, Y7, 48)
+	ROUND(Y7, Y0, Y1, Y2, Y3, Y4, Y5, Y6, 49)
+	ROUND(Y6, Y7, Y0, Y1, Y2, Y3, Y4, Y5, 50)
+	ROUND(Y5, Y6, Y7, Y0, Y1, Y2, Y3, Y4, 51)
+	ROUND(Y4, Y5, Y6, Y7, Y0, Y1, Y2, Y3, 52)
+	ROUND(Y3, Y4, Y5, Y6, Y7, Y0, Y1, Y2, 53)
+	ROUND(Y2, Y3, Y4, Y5, Y6, Y7, Y0, Y1, 54)
+	ROUND(Y1, Y2, Y3, Y4, Y5, Y6, Y7, Y0, 55)
+	ROUND(Y0, Y1, Y2, Y3, Y4, Y5, Y6, Y7, 56)
+	ROUND(Y7, Y0, Y1, Y2, Y3, Y4, Y5, Y6, 57)
+	ROUND(Y6, Y7, Y0, Y1, Y2, Y3, Y4, Y5, 58)
+	ROUND(Y5, Y6, Y7, Y0, Y1, Y2, Y3, Y4, 59)
+	ROUND(Y4, Y5, Y6, Y7, Y0, Y1, Y2, Y3, 60)
+	ROUND(Y3, Y4, Y5, Y6, Y7, Y0, Y1, Y2, 61)
+	ROUND(Y2, Y3, Y4, Y5, Y6, Y7, Y0, Y1, 62)
+	ROUND(Y1, Y2, Y3, Y4, Y5, Y6, Y7, Y0, 63)
+
+	VPADDD  0(SI), Y0, Y0
+	VPADDD  32(SI), Y1, Y1
+	VPADDD  64(SI), Y2, Y2
+	VPADDD  96(SI), Y3, Y3
+	VPADDD  128(SI), Y4, Y4
+	VPADDD  160(SI), Y5, Y5
+	VPADDD  192(SI), Y6, Y6
+	VPADDD  224(SI), Y7, Y7
+	VMOVDQU Y0, 0(SI)
+	VMOVDQU Y1, 32(SI)
+	VMOVDQU Y2, 64(SI)
+	VMOVDQU Y3, 96(SI)
+	VMOVDQU Y4, 128(SI)
+	VMOVDQU Y5, 160(SI)
+	VMOVDQU Y6, 192(SI)
+	VMOVDQU Y7, 224(SI)
+
+	VZEROUPPER
+	RET
+
+DATA ·k256<>+0x00(SB)/4, $0x428a2f98
+DATA ·k256<>+0x04(SB)/4, $0x71374491
+DATA ·k256<>+0x08(SB)/4, $0xb5c0fbcf
+DATA ·k256<>+0x0c(SB)/4, $0xe9b5dba5
+DATA ·k256<>+0x10(SB)/4, $0x3956c25b
+DATA ·k256<>+0x14(SB)/4, $0x59f111f1
+DATA ·k256<>+0x18(SB)/4, $0x923f82a4
+DATA ·k256<>+0x1c(SB)/4, $0xab1c5ed5
+DATA ·k256<>+0x20(SB)/4, $0xd807aa98
+DATA ·k256<>+0x24(SB)/4, $0x12835b01
+DATA ·k256<>+0x28(SB)/4, $0x243185be
+DATA ·k256<>+0x2c(SB)/4, $0x550c7dc3
+DATA ·k256<>+0x30(SB)/4, $0x72be5d74
+DATA ·k256<>+0x34(SB)/4, $0x80deb1fe
+DATA ·k256<>+0x38(SB)/4, $0x9bdc06a7
+DATA ·k256<>+0x3c(SB)/4, $0xc19bf174
+DATA ·k256<>+0x40(SB)/4, $0xe49b69c1
+DATA ·k256<>+0x44(SB)/4, $0xefbe4786
+DATA ·k256<>+0x48(SB)/4, $0x0fc19dc6
+DATA ·k256<>+0x4c(SB)/4, $0x240ca1cc
+DATA ·k256<>+0x50(SB)/4, $0x2de92c6f
+DATA ·k256<>+0x54(SB)/4, $0x4a7484aa
+DATA ·k256<>+0x58(SB)/4, $0x5cb0a9dc
+DATA ·k256<>+0x5c(SB)/4, $0x76f988da
+DATA ·k256<>+0x60(SB)/4, $0x983e5152
+DATA ·k256<>+0x64(SB)/4, $0xa831c66d
+DATA ·k256<>+0x68(SB)/4, $0xb00327c8
+DATA ·k256<>+0x6c(SB)/4, $0xbf597fc7
+DATA ·k256<>+0x70(SB)/4, $0xc6e00bf3
+DATA ·k256<>+0x74(SB)/4, $0xd5a79147
+DATA ·k256<>+0x78(SB)/4, $0x06ca6351
+DATA ·k256<>+0x7c(SB)/4, $0x14292967
+DATA ·k256<>+0x80(SB)/4, $0x27b70a85
+DATA ·k256<>+0x84(SB)/4, $0x2e1b2138
+DATA ·k256<>+0x88(SB)/4, $0x4d2c6dfc
+DATA ·k256<>+0x8c(SB)/4, $0x53380d13
+DATA ·k256<>+0x90(SB)/4, $0x650a7354
+DATA ·k256<>+0x94(SB)/4, $0x766a0abb
+DATA ·k256<>+0x98(SB)/4, $0x81c2c92e
+DATA ·k256<>+0x9c(SB)/4, $0x92722c85
+DATA ·k256<>+0xa0(SB)/4, $0xa2bfe8a1
+DATA ·k256<>+0xa4(SB)/4, $0xa81a664b
+DATA ·k256<>+0xa8(SB)/4, $0xc24b8b70
+DATA ·k256<>+0xac(SB)/4, $0xc76c51a3
+DATA ·k256<>+0xb0(SB)/4, $0xd192e819
+DATA ·k256<>+0xb4(SB)/4, $0xd6990624
+DATA ·k256<>+0xb8(SB)/4, $0xf40e3585
+DATA ·k256<>+0xbc(SB)/4, $0x106aa070
+DATA ·k256<>+0xc0(SB)/4, $0x19a4c116
+DATA ·k256<>+0xc4(SB)/4, $0x1e376c08
+DATA ·k256<>+0xc8(SB)/4, $0x2748774c
+DATA ·k256<>+0xcc(SB)/4, $0x34b0bcb5
+DATA ·k256<>+0xd0(SB)/4, $0x391c0cb3
+DATA ·k256<>+0xd4(SB)/4, $0x4ed8aa4a
+DATA ·k256<>+0xd8(SB)/4, $0x5b9cca4f
+DATA ·k256<>+0xdc(SB)/4, $0x682e6ff3
+DATA ·k256<>+0xe0(SB)/4, $0x748f82ee
+DATA ·k256<>+0xe4(SB)/4, $0x78a5636f
+DATA ·k256<>+0xe8(SB)/4, $0x84c87814
+DATA ·k256<>+0xec(SB)/4, $0x8cc70208
+DATA ·k256<>+0xf0(SB)/4, $0x90befffa
+DATA ·k256<>+0xf4(SB)/4, $0xa4506ceb
+DATA ·k256<>+0xf8(SB)/4, $0xbef9a3f7
+DATA ·k256<>+0xfc(SB)/4, $0xc67178f2
+GLOBL ·k256<>(SB), RODATA|NOPTR, $256
+
+// func cpuid(eaxArg, ecxArg uint32) (eax, ebx, ecx, edx uint32)
+TEXT ·cpuid(SB), NOSPLIT, $0-24
+	MOVL eaxArg+0(FP), AX
+	MOVL ecxArg+4(FP), CX
+	CPUID
+	MOVL AX, eax+8(FP)
+	MOVL BX, ebx+12(FP)
+	MOVL CX, ecx+16(FP)
+	MOVL DX, edx+20(FP)
+	RET
+
+// func xgetbv() (eax uint32)
+TEXT ·xgetbv(SB), NOSPLIT, $0-4
+	MOVL $0, CX
+	XGETBV
+	MOVL AX, eax+0(FP)
+	RET
@@ -0,0 +1,25 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !amd64 || purego
+
+package internal
+
+// ForceSHA256x8 has no effect, since the multi-lane SHA-256 requires AVX2, and returns false.
+func ForceSHA256x8(_ bool) bool {
+	return false
+}
+
+// canExpandXMDSHA256x8 reports whether expandXMDSHA256x8 is available, which requires AVX2.
+func canExpandXMDSHA256x8() bool {
+	return false
+}
+
+func expandXMDSHA256x8(_ [][]byte, _ []byte, _ uint) [][]byte {
+	panic("expandXMDSHA256x8 is not available")
+}
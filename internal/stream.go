@@ -0,0 +1,164 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package internal
+
+import (
+	"crypto"
+	"hash"
+	"io"
+	"math"
+)
+
+// XMDReader streams the output of expand_message_xmd, computing one digest block b_i at a time.
+type XMDReader struct {
+	h         hash.Hash
+	b0        []byte
+	bi        []byte
+	dstPrime  []byte
+	buf       []byte
+	i         uint
+	remaining uint
+}
+
+// MaxLengthXMD returns the maximum output length of expand_message_xmd with the hash function.
+func MaxLengthXMD(id crypto.Hash) uint {
+	return min(255*uint(id.Size()), math.MaxUint16)
+}
+
+// NewXMDReader returns a reader over the length bytes of expand_message_xmd(input, dst, length). Only b_0 and b_1 are
+// computed upfront.
+func NewXMDReader(id crypto.Hash, input, dst []byte, length uint) *XMDReader {
+	return newXMDReader(id, id.New(), input, dst, length)
+}
+
+// NewXMDHashReader is NewXMDReader with the state h of a hash function that is not a crypto.Hash, e.g. Keccak-256.
+func NewXMDHashReader(h hash.Hash, input, dst []byte, length uint) *XMDReader {
+	return newXMDReader(0, h, input, dst, length)
+}
+
+// newXMDReader returns the XMDReader with the state h of id, which is 0 if h is not a crypto.Hash.
+func newXMDReader(id crypto.Hash, h hash.Hash, input, dst []byte, length uint) *XMDReader {
+	if length > min(255*uint(h.Size()), math.MaxUint16) {
+		panic(ErrLengthTooLarge)
+	}
+
+	dstPrime := DstPrime(VetDSTXMD(h, dst))
+
+	absorbZPad(id, h)
+	b0 := _write(h, input, I2OSP(length, 2), []byte{0}, dstPrime)
+	b1 := _hash(h, b0, []byte{1}, dstPrime)
+
+	return &XMDReader{
+		h:         h,
+		b0:        b0,
+		bi:        b1,
+		dstPrime:  dstPrime,
+		buf:       b1,
+		i:         1,
+		remaining: length,
+	}
+}
+
+// Read implements io.Reader, and returns io.EOF once the full length has been read.
+func (r *XMDReader) Read(p []byte) (int, error) {
+	n := 0
+
+	for n < len(p) && r.remaining > 0 {
+		if len(r.buf) == 0 {
+			r.i++
+			r.bi = _hash(r.h, xorSlices(r.bi, r.b0), []byte{byte(r.i)}, r.dstPrime)
+			r.buf = r.bi
+		}
+
+		c := copy(p[n:], r.buf[:min(uint(len(r.buf)), r.remaining)])
+		r.buf = r.buf[c:]
+		r.remaining -= uint(c)
+		n += c
+	}
+
+	if r.remaining == 0 && r.b0 != nil {
+		Wipe(r.b0, r.bi)
+		r.b0, r.bi, r.buf = nil, nil, nil
+		r.h.Reset()
+	}
+
+	if n == 0 && len(p) > 0 {
+		return 0, io.EOF
+	}
+
+	return n, nil
+}
+
+// XOFReader streams the output of expand_message_xof, squeezing the function as the output is read.
+type XOFReader struct {
+	x         XOF
+	buf       []byte
+	remaining uint
+}
+
+// NewXOFReader returns a reader over the length bytes of expand_message_xof(input, dst, length). Only msg_prime is
+// absorbed upfront.
+func NewXOFReader(x XOF, input, dst []byte, length uint) *XOFReader {
+	if length > math.MaxUint16 {
+		panic(ErrLengthTooLarge)
+	}
+
+	dst = VetXofDST(x, dst)
+
+	if ext, ok := x.(ExtendableXOF); ok {
+		ext.SetOutputSize(int(length))
+	} else if isFixedKeccak(x) {
+		panic(ErrUnsupportedHash)
+	}
+
+	x.Reset()
+
+	for _, in := range [][]byte{input, I2OSP(length, 2), dst, I2OSP(uint(len(dst)), 1)} {
+		_, _ = x.Write(in)
+	}
+
+	return &XOFReader{x: x, remaining: length}
+}
+
+// Read implements io.Reader, and returns io.EOF once the full length has been read.
+func (r *XOFReader) Read(p []byte) (int, error) {
+	if r.remaining == 0 {
+		if len(p) > 0 {
+			return 0, io.EOF
+		}
+
+		return 0, nil
+	}
+
+	p = p[:min(uint(len(p)), r.remaining)]
+
+	if ext, ok := r.x.(ExtendableXOF); ok {
+		// A *hash.ExtendableHash can't be read by less than its output size, which is the full length for BLAKE2X,
+		// so its output is squeezed by digests with Sum.
+		for n := 0; n < len(p); {
+			if len(r.buf) == 0 {
+				r.buf = ext.Sum(nil)
+			}
+
+			c := copy(p[n:], r.buf)
+			r.buf = r.buf[c:]
+			n += c
+		}
+	} else if _, err := io.ReadFull(r.x, p); err != nil {
+		return 0, err
+	}
+
+	if r.remaining -= uint(len(p)); r.remaining == 0 {
+		Wipe(r.buf)
+		r.buf = nil
+		r.x.Reset()
+	}
+
+	return len(p), nil
+}
@@ -0,0 +1,55 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package internal
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+const (
+	minLength            = 0
+	recommendedMinLength = 16
+)
+
+var inputLimit, expandLimit atomic.Uint64
+
+// ValidateDST implements hash2curve.ValidateDST.
+func ValidateDST(dst []byte, strict bool) error {
+	if len(dst) < recommendedMinLength {
+		if len(dst) == minLength {
+			return ErrZeroLengthDST
+		}
+
+		if strict {
+			return ErrShortDST
+		}
+	}
+
+	return nil
+}
+
+// SetInputLimits implements hash2curve.SetInputLimits.
+func SetInputLimits(maxInput, maxExpand uint) {
+	inputLimit.Store(uint64(maxInput))
+	expandLimit.Store(uint64(maxExpand))
+}
+
+// ValidateInput implements hash2curve.ValidateInput.
+func ValidateInput(input []byte, length uint) error {
+	if limit := inputLimit.Load(); limit != 0 && uint64(len(input)) > limit {
+		return ErrInputTooLong
+	}
+
+	if limit := expandLimit.Load(); limit != 0 && uint64(length) > limit {
+		return fmt.Errorf("%w: the limit is %d bytes", ErrLengthTooLarge, limit)
+	}
+
+	return nil
+}
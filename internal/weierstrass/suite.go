@@ -0,0 +1,242 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+package weierstrass
+
+import (
+	"crypto"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/bytemare/hash2curve"
+	"github.com/bytemare/hash2curve/internal/extension"
+)
+
+var (
+	errEncodingLength = errors.New("invalid encoding length")
+	errEncodingPrefix = errors.New("invalid encoding prefix")
+	errNonCanonical   = errors.New("non-canonical encoding")
+	errNotOnCurve     = errors.New("the coordinates are not those of a point on the curve")
+)
+
+// hashToField is the signature of hash2curve.HashToFieldXMD with its hash function set.
+type hashToField func(input, dst []byte, count, ext, securityLength uint, modulo *big.Int) []*big.Int
+
+// Suite implements the hash-to-curve suites of a curve with expand_message_xmd and the map of the curve, as RFC 9380
+// does for the groups of BLS12-381, hashing to GF(p^m) for the curves over extension fields.
+type Suite struct {
+	*Curve
+	order        *big.Int
+	hashToField  hashToField
+	secLength    uint
+	scalarLength uint
+}
+
+// NewSuite returns the suite for the curve, with the order of its prime-order subgroup, the hash function of
+// expand_message_xmd, and the security level k in bits.
+func NewSuite(c *Curve, order *big.Int, h crypto.Hash, k uint) *Suite {
+	return newSuite(c, order, func(input, dst []byte, count, ext, securityLength uint, modulo *big.Int) []*big.Int {
+		return hash2curve.HashToFieldXMD(h, input, dst, count, ext, securityLength, modulo)
+	}, k)
+}
+
+// NewSuiteKeccak256 returns the suite for the curve as NewSuite does, with expand_message_xmd over the legacy
+// Keccak-256 of Ethereum.
+func NewSuiteKeccak256(c *Curve, order *big.Int, k uint) *Suite {
+	return newSuite(c, order, hash2curve.HashToFieldXMDKeccak256, k)
+}
+
+func newSuite(c *Curve, order *big.Int, h2f hashToField, k uint) *Suite {
+	return &Suite{
+		Curve:        c,
+		order:        order,
+		hashToField:  h2f,
+		secLength:    hash2curve.SecurityLength(c.Field.Base.Order(), k),
+		scalarLength: hash2curve.SecurityLength(order, k),
+	}
+}
+
+// HashToField implements hash_to_field to count elements of GF(p^m).
+func (s *Suite) HashToField(input, dst []byte, count uint) []extension.Element {
+	m := s.Field.Degree()
+	coordinates := s.hashToField(input, dst, count, uint(m), s.secLength, s.Field.Base.Order())
+	u := make([]extension.Element, count)
+
+	for i := range u {
+		u[i] = coordinates[i*m : (i+1)*m : (i+1)*m]
+	}
+
+	return u
+}
+
+// HashToCurve implements hash_to_curve.
+func (s *Suite) HashToCurve(input, dst []byte) *Point {
+	u := s.HashToField(input, dst, 2)
+	q0 := s.MapToCurve(u[0])
+	q1 := s.MapToCurve(u[1])
+
+	for _, e := range u {
+		hash2curve.WipeInts(e...)
+	}
+
+	return s.ClearCofactor(s.Add(q0, q1))
+}
+
+// EncodeToCurve implements encode_to_curve.
+func (s *Suite) EncodeToCurve(input, dst []byte) *Point {
+	u := s.HashToField(input, dst, 1)
+	q := s.MapToCurve(u[0])
+	hash2curve.WipeInts(u[0]...)
+
+	return s.ClearCofactor(q)
+}
+
+// HashToScalar returns a safe mapping of the input to a scalar modulo the order of the prime-order subgroup.
+func (s *Suite) HashToScalar(input, dst []byte) *big.Int {
+	return s.hashToField(input, dst, 1, 1, s.scalarLength, s.order)[0]
+}
+
+// Order returns a copy of the order of the prime-order subgroup.
+func (s *Suite) Order() *big.Int {
+	return new(big.Int).Set(s.order)
+}
+
+// IsInSubgroup returns whether the point is on the curve and in the prime-order subgroup. The point at infinity is.
+func (s *Suite) IsInSubgroup(p *Point) bool {
+	return s.IsOnCurve(p) && s.ScalarMult(p, s.order).Infinity
+}
+
+// EncodeSEC1 returns the SEC 1 encoding of the point, 0x02 or 0x03 || x if compressed and 0x04 || x || y otherwise, or
+// the single byte 0x00 for the point at infinity. It is only defined for the curves over GF(p).
+func (s *Suite) EncodeSEC1(p *Point, compressed bool) []byte {
+	if p.Infinity {
+		return []byte{0}
+	}
+
+	byteLen := s.Field.Base.ByteLen()
+
+	if compressed {
+		b := make([]byte, 1+byteLen)
+		b[0] = byte(2 | p.Y[0].Bit(0))
+		p.X[0].FillBytes(b[1:])
+
+		return b
+	}
+
+	b := make([]byte, 1+2*byteLen)
+	b[0] = 4
+	p.X[0].FillBytes(b[1 : 1+byteLen])
+	p.Y[0].FillBytes(b[1+byteLen:])
+
+	return b
+}
+
+// DecodeSEC1 returns the point encoded as by EncodeSEC1, compressed or not, or an error wrapping
+// hash2curve.ErrInvalidPoint. The point may not be in the prime-order subgroup.
+func (s *Suite) DecodeSEC1(b []byte) (*Point, error) {
+	f := s.Field
+	byteLen := f.Base.ByteLen()
+
+	switch {
+	case len(b) == 1 && b[0] == 0:
+		return &Point{X: f.New(), Y: f.New(), Infinity: true}, nil
+	case len(b) == 1+byteLen && (b[0] == 2 || b[0] == 3):
+		x := extension.Element{new(big.Int).SetBytes(b[1:])}
+		if !f.IsCanonical(x) {
+			return nil, fmt.Errorf("%w: %w", hash2curve.ErrInvalidPoint, errNonCanonical)
+		}
+
+		y := s.rhs(f.New(), x)
+		if !f.IsSquare(y) {
+			return nil, fmt.Errorf("%w: %w", hash2curve.ErrInvalidPoint, errNotOnCurve)
+		}
+
+		if f.SquareRoot(y, y); y[0].Bit(0) != uint(b[0]&1) {
+			f.Neg(y, y)
+		}
+
+		if y[0].Bit(0) != uint(b[0]&1) {
+			// y = 0 has no odd square root.
+			return nil, fmt.Errorf("%w: %w", hash2curve.ErrInvalidPoint, errNotOnCurve)
+		}
+
+		return &Point{X: x, Y: y}, nil
+	case len(b) == 1+2*byteLen && b[0] == 4:
+		p := &Point{
+			X: extension.Element{new(big.Int).SetBytes(b[1 : 1+byteLen])},
+			Y: extension.Element{new(big.Int).SetBytes(b[1+byteLen:])},
+		}
+
+		if !f.IsCanonical(p.X) || !f.IsCanonical(p.Y) {
+			return nil, fmt.Errorf("%w: %w", hash2curve.ErrInvalidPoint, errNonCanonical)
+		}
+
+		if !s.IsOnCurve(p) {
+			return nil, fmt.Errorf("%w: %w", hash2curve.ErrInvalidPoint, errNotOnCurve)
+		}
+
+		return p, nil
+	case len(b) == 1+byteLen || len(b) == 1+2*byteLen || len(b) == 1:
+		return nil, fmt.Errorf("%w: %w", hash2curve.ErrInvalidPoint, errEncodingPrefix)
+	default:
+		return nil, fmt.Errorf("%w: %w", hash2curve.ErrInvalidPoint, errEncodingLength)
+	}
+}
+
+// EncodeXY returns the encoding x || y of the point, with fixed-length big-endian coordinates, and all zeros for the
+// point at infinity, as Ethereum's EIP-196 precompiles use. Over GF(p^m), each coordinate is encoded as its
+// coefficients c_0, ..., c_(m-1) in that order. It is only defined for the curves for which (0, 0) is not on the
+// curve, i.e. with B != 0.
+func (s *Suite) EncodeXY(p *Point) []byte {
+	byteLen := s.Field.Base.ByteLen()
+	m := s.Field.Degree()
+	b := make([]byte, 2*m*byteLen)
+
+	if !p.Infinity {
+		for i := range m {
+			p.X[i].FillBytes(b[i*byteLen : (i+1)*byteLen])
+			p.Y[i].FillBytes(b[(m+i)*byteLen : (m+i+1)*byteLen])
+		}
+	}
+
+	return b
+}
+
+// DecodeXY returns the point encoded as by EncodeXY over GF(p), or an error wrapping hash2curve.ErrInvalidPoint. The
+// point may not be in the prime-order subgroup.
+func (s *Suite) DecodeXY(b []byte) (*Point, error) {
+	f := s.Field
+	byteLen := f.Base.ByteLen()
+
+	if len(b) != 2*byteLen {
+		return nil, fmt.Errorf("%w: %w", hash2curve.ErrInvalidPoint, errEncodingLength)
+	}
+
+	p := &Point{
+		X: extension.Element{new(big.Int).SetBytes(b[:byteLen])},
+		Y: extension.Element{new(big.Int).SetBytes(b[byteLen:])},
+	}
+
+	if f.IsZero(p.X) && f.IsZero(p.Y) {
+		p.Infinity = true
+		return p, nil
+	}
+
+	if !f.IsCanonical(p.X) || !f.IsCanonical(p.Y) {
+		return nil, fmt.Errorf("%w: %w", hash2curve.ErrInvalidPoint, errNonCanonical)
+	}
+
+	if !s.IsOnCurve(p) {
+		return nil, fmt.Errorf("%w: %w", hash2curve.ErrInvalidPoint, errNotOnCurve)
+	}
+
+	return p, nil
+}
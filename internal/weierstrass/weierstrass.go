@@ -0,0 +1,395 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+// Package weierstrass implements the simplified SWU method of RFC 9380 for short Weierstrass curves
+// y^2 = x^3 + A * x + B with A * B != 0 over GF(p^m), and the Shallue-van de Woestijne method for the others, with
+// big.Int arithmetic to sum the mapped points and clear their cofactor.
+package weierstrass
+
+import (
+	"math/big"
+
+	"github.com/bytemare/hash2curve/internal/extension"
+)
+
+// Point is an affine point of the curve, or the point at infinity.
+type Point struct {
+	X, Y     extension.Element
+	Infinity bool
+}
+
+// jacobian is the point (X / Z^2, Y / Z^3), and the point at infinity if Z = 0.
+type jacobian struct {
+	x, y, z extension.Element
+}
+
+// Curve is the short Weierstrass curve y^2 = x^3 + A * x + B over GF(p^m).
+type Curve struct {
+	Field       *extension.Field
+	a, b, z     extension.Element
+	minusBOverA extension.Element
+	bOverZA     extension.Element
+	svdw        *svdw
+	cofactor    *big.Int
+}
+
+// svdw holds the constants of the Shallue-van de Woestijne method, if the curve uses it.
+type svdw struct {
+	c1, c2, c3, c4 extension.Element
+}
+
+// NewCurve returns the curve with the coefficients a and b, which must both be non-zero, the non-square z of the
+// simplified SWU map, and the cofactor h_eff.
+func NewCurve(f *extension.Field, a, b, z extension.Element, cofactor *big.Int) *Curve {
+	c := &Curve{
+		Field:       f,
+		a:           f.Set(f.New(), a),
+		b:           f.Set(f.New(), b),
+		z:           f.Set(f.New(), z),
+		minusBOverA: f.New(),
+		bOverZA:     f.New(),
+		cofactor:    new(big.Int).Set(cofactor),
+	}
+
+	f.Inv(c.minusBOverA, a)
+	f.Mul(c.minusBOverA, c.minusBOverA, b)
+	f.Neg(c.minusBOverA, c.minusBOverA)
+	f.Mul(c.bOverZA, z, a)
+	f.Inv(c.bOverZA, c.bOverZA)
+	f.Mul(c.bOverZA, c.bOverZA, b)
+
+	return c
+}
+
+// NewCurveSVDW returns the curve with the coefficients a and b, mapping with the Shallue-van de Woestijne method of
+// RFC 9380 section 6.6.1 and its constant z, and the cofactor h_eff. This is the method for the curves with A = 0 or
+// B = 0, for which there is no known isogeny to use the simplified SWU map.
+func NewCurveSVDW(f *extension.Field, a, b, z extension.Element, cofactor *big.Int) *Curve {
+	c := &Curve{
+		Field:    f,
+		a:        f.Set(f.New(), a),
+		b:        f.Set(f.New(), b),
+		z:        f.Set(f.New(), z),
+		svdw:     &svdw{c1: f.New(), c2: f.New(), c3: f.New(), c4: f.New()},
+		cofactor: new(big.Int).Set(cofactor),
+	}
+
+	tv := f.New()
+	c.rhs(c.svdw.c1, z) // c1 = g(Z)
+
+	f.Inv(c.svdw.c2, f.Element(big.NewInt(2)))
+	f.Mul(c.svdw.c2, c.svdw.c2, z)
+	f.Neg(c.svdw.c2, c.svdw.c2) // c2 = -Z / 2
+
+	f.Square(tv, z)
+	f.MulBase(tv, tv, big.NewInt(3))
+	f.Add(tv, tv, f.MulBase(f.New(), a, big.NewInt(4))) // 3 * Z^2 + 4 * A
+
+	// c3 = sqrt(-g(Z) * (3 * Z^2 + 4 * A)), with sgn0(c3) = 0
+	f.Mul(c.svdw.c3, c.svdw.c1, tv)
+	f.Neg(c.svdw.c3, c.svdw.c3)
+	f.SquareRoot(c.svdw.c3, c.svdw.c3)
+
+	if f.Sgn0(c.svdw.c3) == 1 {
+		f.Neg(c.svdw.c3, c.svdw.c3)
+	}
+
+	// c4 = -4 * g(Z) / (3 * Z^2 + 4 * A)
+	f.Inv(tv, tv)
+	f.Mul(c.svdw.c4, c.svdw.c1, tv)
+	f.MulBase(c.svdw.c4, c.svdw.c4, big.NewInt(-4))
+
+	return c
+}
+
+// rhs sets res to x^3 + A * x + B, and returns res.
+func (c *Curve) rhs(res, x extension.Element) extension.Element {
+	f := c.Field
+	tv := f.Square(f.New(), x)
+	f.Add(tv, tv, c.a)
+	f.Mul(tv, tv, x)
+
+	return f.Add(res, tv, c.b)
+}
+
+// MapToCurve implements the simplified SWU map of RFC 9380 section 6.6.2, or the Shallue-van de Woestijne method of
+// section 6.6.1 for the curves created with NewCurveSVDW. u must be canonical.
+func (c *Curve) MapToCurve(u extension.Element) *Point {
+	if c.svdw != nil {
+		return c.mapSVDW(u)
+	}
+
+	f := c.Field
+	tv1, zu2, x, y := f.New(), f.New(), f.New(), f.New()
+
+	f.Square(zu2, u)
+	f.Mul(zu2, zu2, c.z) // Z * u^2
+	f.Square(tv1, zu2)
+	f.Add(tv1, tv1, zu2)
+	f.Inv(tv1, tv1) // 1. tv1 = inv0(Z^2 * u^4 + Z * u^2)
+
+	if f.IsZero(tv1) {
+		f.Set(x, c.bOverZA) // 3. If tv1 == 0, set x1 = B / (Z * A)
+	} else {
+		f.Add(x, tv1, f.Element(big.NewInt(1)))
+		f.Mul(x, x, c.minusBOverA) // 2. x1 = (-B / A) * (1 + tv1)
+	}
+
+	c.rhs(y, x) // 4. gx1 = x1^3 + A * x1 + B
+
+	// 5. x2 = Z * u^2 * x1, 6. gx2 = x2^3 + A * x2 + B
+	// 7. If is_square(gx1), set x = x1 and y = sqrt(gx1)
+	// 8. Else set x = x2 and y = sqrt(gx2)
+	if !f.IsSquare(y) {
+		f.Mul(x, x, zu2)
+		c.rhs(y, x)
+	}
+
+	f.SquareRoot(y, y)
+
+	// 9. If sgn0(u) != sgn0(y), set y = -y
+	if f.Sgn0(u) != f.Sgn0(y) {
+		f.Neg(y, y)
+	}
+
+	return &Point{X: x, Y: y}
+}
+
+// mapSVDW implements the Shallue-van de Woestijne method of RFC 9380 section 6.6.1.
+func (c *Curve) mapSVDW(u extension.Element) *Point {
+	f := c.Field
+	one := f.Element(big.NewInt(1))
+	tv1, tv2, tv3, tv4, x, gx := f.New(), f.New(), f.New(), f.New(), f.New(), f.New()
+
+	f.Square(tv1, u)
+	f.Mul(tv1, tv1, c.svdw.c1) // 1. tv1 = u^2 * c1
+	f.Add(tv2, one, tv1)       // 2. tv2 = 1 + tv1
+	f.Sub(tv1, one, tv1)       // 3. tv1 = 1 - tv1
+	f.Mul(tv3, tv1, tv2)       // 4. tv3 = tv1 * tv2
+	f.Inv(tv3, tv3)            // 5. tv3 = inv0(tv3)
+	f.Mul(tv4, u, tv1)         // 6. tv4 = u * tv1
+	f.Mul(tv4, tv4, tv3)       // 7. tv4 = tv4 * tv3
+	f.Mul(tv4, tv4, c.svdw.c3) // 8. tv4 = tv4 * c3
+
+	f.Sub(x, c.svdw.c2, tv4) // 9. x1 = c2 - tv4
+	c.rhs(gx, x)             // 10-14. gx1 = x1^3 + A * x1 + B
+
+	// 15. e1 = is_square(gx1), 25. x = CMOV(x3, x1, e1)
+	if !f.IsSquare(gx) {
+		f.Add(x, c.svdw.c2, tv4) // 16. x2 = c2 + tv4
+		c.rhs(gx, x)             // 17-21. gx2 = x2^3 + A * x2 + B
+
+		// 22. e2 = is_square(gx2) AND NOT e1, 26. x = CMOV(x, x2, e2)
+		if !f.IsSquare(gx) {
+			f.Square(x, tv2)
+			f.Mul(x, x, tv3)
+			f.Square(x, x)
+			f.Mul(x, x, c.svdw.c4)
+			f.Add(x, x, c.z) // 23-24. x3 = (tv2^2 * tv3)^2 * c4 + Z
+			c.rhs(gx, x)     // 27-31. gx = x^3 + A * x + B
+		}
+	}
+
+	y := f.SquareRoot(f.New(), gx) // 32. y = sqrt(gx)
+
+	// 33-34. If sgn0(u) != sgn0(y), set y = -y
+	if f.Sgn0(u) != f.Sgn0(y) {
+		f.Neg(y, y)
+	}
+
+	return &Point{X: x, Y: y}
+}
+
+// IsOnCurve returns whether the point is on the curve. The point at infinity is.
+func (c *Curve) IsOnCurve(p *Point) bool {
+	if p.Infinity {
+		return true
+	}
+
+	f := c.Field
+	if !f.IsCanonical(p.X) || !f.IsCanonical(p.Y) {
+		return false
+	}
+
+	return f.Equal(f.Square(f.New(), p.Y), c.rhs(f.New(), p.X))
+}
+
+// Add returns p + q.
+func (c *Curve) Add(p, q *Point) *Point {
+	return c.toAffine(c.addMixed(c.toJacobian(p), q))
+}
+
+// ScalarMult returns [n]p, for a non-negative n.
+func (c *Curve) ScalarMult(p *Point, n *big.Int) *Point {
+	f := c.Field
+	r := &jacobian{x: f.New(), y: f.New(), z: f.New()}
+
+	for i := n.BitLen() - 1; i >= 0; i-- {
+		r = c.double(r)
+
+		if n.Bit(i) == 1 {
+			r = c.addMixed(r, p)
+		}
+	}
+
+	return c.toAffine(r)
+}
+
+// FieldPrime returns a copy of the characteristic p of the field of the curve.
+func (c *Curve) FieldPrime() *big.Int {
+	return new(big.Int).Set(c.Field.Base.Order())
+}
+
+// Cofactor returns a copy of the cofactor h_eff of the curve.
+func (c *Curve) Cofactor() *big.Int {
+	return new(big.Int).Set(c.cofactor)
+}
+
+// ClearCofactor returns [h_eff]p.
+func (c *Curve) ClearCofactor(p *Point) *Point {
+	if c.cofactor.Cmp(big.NewInt(1)) == 0 {
+		return p
+	}
+
+	return c.ScalarMult(p, c.cofactor)
+}
+
+func (c *Curve) toJacobian(p *Point) *jacobian {
+	f := c.Field
+	if p.Infinity {
+		return &jacobian{x: f.New(), y: f.New(), z: f.New()}
+	}
+
+	return &jacobian{x: f.Set(f.New(), p.X), y: f.Set(f.New(), p.Y), z: f.Element(big.NewInt(1))}
+}
+
+func (c *Curve) toAffine(p *jacobian) *Point {
+	f := c.Field
+	if f.IsZero(p.z) {
+		return &Point{X: f.New(), Y: f.New(), Infinity: true}
+	}
+
+	zInv, zInv2 := f.New(), f.New()
+	f.Inv(zInv, p.z)
+	f.Square(zInv2, zInv)
+
+	x := f.Mul(f.New(), p.x, zInv2)
+	y := f.Mul(f.New(), p.y, f.Mul(zInv2, zInv2, zInv))
+
+	return &Point{X: x, Y: y}
+}
+
+// double returns 2 * p, with the dbl-2007-bl formulas for any A.
+func (c *Curve) double(p *jacobian) *jacobian {
+	f := c.Field
+	if f.IsZero(p.z) || f.IsZero(p.y) {
+		return &jacobian{x: f.New(), y: f.New(), z: f.New()}
+	}
+
+	xx, yy, yyyy, zz := f.Square(f.New(), p.x), f.Square(f.New(), p.y), f.New(), f.Square(f.New(), p.z)
+	s, m, tv := f.New(), f.New(), f.New()
+	r := &jacobian{x: f.New(), y: f.New(), z: f.New()}
+
+	f.Square(yyyy, yy)
+
+	// S = 2 * ((X + YY)^2 - XX - YYYY)
+	f.Add(s, p.x, yy)
+	f.Square(s, s)
+	f.Sub(s, s, xx)
+	f.Sub(s, s, yyyy)
+	f.Add(s, s, s)
+
+	// M = 3 * XX + A * ZZ^2
+	f.Add(m, xx, xx)
+	f.Add(m, m, xx)
+	f.Square(tv, zz)
+	f.Mul(tv, tv, c.a)
+	f.Add(m, m, tv)
+
+	// X3 = M^2 - 2 * S
+	f.Square(r.x, m)
+	f.Sub(r.x, r.x, s)
+	f.Sub(r.x, r.x, s)
+
+	// Y3 = M * (S - X3) - 8 * YYYY
+	f.Sub(tv, s, r.x)
+	f.Mul(r.y, m, tv)
+	f.Add(yyyy, yyyy, yyyy)
+	f.Add(yyyy, yyyy, yyyy)
+	f.Add(yyyy, yyyy, yyyy)
+	f.Sub(r.y, r.y, yyyy)
+
+	// Z3 = (Y + Z)^2 - YY - ZZ
+	f.Add(r.z, p.y, p.z)
+	f.Square(r.z, r.z)
+	f.Sub(r.z, r.z, yy)
+	f.Sub(r.z, r.z, zz)
+
+	return r
+}
+
+// addMixed returns p + q, with the madd-2007-bl formulas.
+func (c *Curve) addMixed(p *jacobian, q *Point) *jacobian {
+	f := c.Field
+
+	switch {
+	case q.Infinity:
+		return p
+	case f.IsZero(p.z):
+		return c.toJacobian(q)
+	}
+
+	z1z1, u2, s2, h, hh := f.New(), f.New(), f.New(), f.New(), f.New()
+	i, j, rr, v := f.New(), f.New(), f.New(), f.New()
+	r := &jacobian{x: f.New(), y: f.New(), z: f.New()}
+
+	f.Square(z1z1, p.z)  // Z1Z1 = Z1^2
+	f.Mul(u2, q.X, z1z1) // U2 = X2 * Z1Z1
+	f.Mul(s2, q.Y, p.z)
+	f.Mul(s2, s2, z1z1) // S2 = Y2 * Z1 * Z1Z1
+	f.Sub(h, u2, p.x)   // H = U2 - X1
+	f.Sub(rr, s2, p.y)
+	f.Add(rr, rr, rr) // r = 2 * (S2 - Y1)
+
+	if f.IsZero(h) {
+		if f.IsZero(rr) {
+			return c.double(p)
+		}
+
+		return &jacobian{x: f.New(), y: f.New(), z: f.New()}
+	}
+
+	f.Square(hh, h) // HH = H^2
+	f.Add(i, hh, hh)
+	f.Add(i, i, i)   // I = 4 * HH
+	f.Mul(j, h, i)   // J = H * I
+	f.Mul(v, p.x, i) // V = X1 * I
+
+	// X3 = r^2 - J - 2 * V
+	f.Square(r.x, rr)
+	f.Sub(r.x, r.x, j)
+	f.Sub(r.x, r.x, v)
+	f.Sub(r.x, r.x, v)
+
+	// Y3 = r * (V - X3) - 2 * Y1 * J
+	f.Sub(v, v, r.x)
+	f.Mul(r.y, rr, v)
+	f.Mul(j, j, p.y)
+	f.Add(j, j, j)
+	f.Sub(r.y, r.y, j)
+
+	// Z3 = (Z1 + H)^2 - Z1Z1 - HH
+	f.Add(r.z, p.z, h)
+	f.Square(r.z, r.z)
+	f.Sub(r.z, r.z, z1z1)
+	f.Sub(r.z, r.z, hh)
+
+	return r
+}
@@ -0,0 +1,19 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package internal
+
+import "runtime"
+
+// Wipe overwrites the buffers with zeros. This is best-effort, as the runtime may have copied them before.
+func Wipe(buffers ...[]byte) {
+	for _, b := range buffers {
+		clear(b)
+		runtime.KeepAlive(b)
+	}
+}
@@ -0,0 +1,28 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+package internal
+
+import (
+	"math/big"
+	"runtime"
+)
+
+// WipeInt overwrites the words of x with zeros, and sets x to 0.
+func WipeInt(x *big.Int) {
+	if x == nil {
+		return
+	}
+
+	words := x.Bits()
+	clear(words[:cap(words)])
+	runtime.KeepAlive(words)
+	x.SetInt64(0)
+}
@@ -9,50 +9,188 @@
 package internal
 
 import (
+	"context"
 	"crypto"
-	"errors"
-	"fmt"
 	"hash"
 	"math"
+	"sync"
+	"time"
 )
 
-var errLengthTooLarge = errors.New("requested byte length is too high")
+const (
+	// xmdPrefix prefixes the names of the hash functions in the names of the expanders reported to the ExpandHook.
+	xmdPrefix = "XMD:"
+
+	// sha256Lanes is the number of inputs expanded together with multi-lane SHA-256.
+	sha256Lanes = 8
+)
+
+var (
+	// hashPools holds reusable hash states for each crypto.Hash, to avoid reallocating them on each expansion.
+	hashPools [crypto.BLAKE2b_512 + 1]sync.Pool
+)
+
+// getHash returns a hash state for id, either from the pool or newly allocated.
+func getHash(id crypto.Hash) hash.Hash {
+	if int(id) < len(hashPools) {
+		if h, ok := hashPools[id].Get().(hash.Hash); ok {
+			return h
+		}
+	}
+
+	return id.New()
+}
+
+// putHash resets the hash state h and returns it to the pool for id.
+func putHash(id crypto.Hash, h hash.Hash) {
+	h.Reset()
+
+	if int(id) < len(hashPools) {
+		hashPools[id].Put(h)
+	}
+}
 
 // ExpandXMD implements expand_message_xmd as specified in RFC 9380 section 5.3.1.
 func ExpandXMD(id crypto.Hash, input, dst []byte, length uint) []byte {
-	h := id.New()
-	dst = VetDSTXMD(h, dst)
-	b := id.Size()
-	blockSize := h.BlockSize()
+	if observe := ObserveExpand(); observe != nil {
+		defer observe(xmdPrefix+id.String(), 1, length, time.Now())
+	}
+
+	h := getHash(id)
+	defer putHash(id, h)
+
+	return expandXMD(id, h, input, DstPrime(VetDSTXMD(h, dst)), length)
+}
+
+// PreparedXMD holds the DST prime of expand_message_xmd for a hash function and a DST, which are resolved once to
+// expand multiple inputs. It holds its own copy of the DST, which the caller may modify afterwards.
+type PreparedXMD struct {
+	dstPrime []byte
+	id       crypto.Hash
+}
+
+// PrepareXMD returns the PreparedXMD for the hash function and dst.
+func PrepareXMD(id crypto.Hash, dst []byte) *PreparedXMD {
+	h := getHash(id)
+	defer putHash(id, h)
+
+	return &PreparedXMD{
+		dstPrime: DstPrime(VetDSTXMD(h, dst)),
+		id:       id,
+	}
+}
+
+// Expand returns expand_message_xmd of input with the prepared DST.
+func (p *PreparedXMD) Expand(input []byte, length uint) []byte {
+	if observe := ObserveExpand(); observe != nil {
+		defer observe(xmdPrefix+p.id.String(), 1, length, time.Now())
+	}
+
+	h := getHash(p.id)
+	defer putHash(p.id, h)
+
+	return expandXMD(p.id, h, input, p.dstPrime, length)
+}
+
+// ExpandBatch returns expand_message_xmd of each input with the prepared DST, reusing the same hash state across the
+// batch. With SHA-256 on CPUs with AVX2 but without the SHA extensions, up to 8 inputs are expanded together with
+// multi-lane SHA-256.
+func (p *PreparedXMD) ExpandBatch(inputs [][]byte, length uint) [][]byte {
+	out, _ := p.ExpandBatchContext(context.Background(), inputs, length)
+	return out
+}
+
+// ExpandBatchContext is ExpandBatch checking ctx before each input, or each group of inputs expanded together. Once ctx
+// is done, it wipes the outputs computed so far, and returns ctx.Err().
+func (p *PreparedXMD) ExpandBatchContext(ctx context.Context, inputs [][]byte, length uint) ([][]byte, error) {
+	if observe := ObserveExpand(); observe != nil {
+		defer observe(xmdPrefix+p.id.String(), uint(len(inputs)), length, time.Now())
+	}
+
+	if p.id == crypto.SHA256 && len(inputs) > 1 && canExpandXMDSHA256x8() {
+		return p.expandBatchSHA256x8(ctx, inputs, length)
+	}
 
-	ell := math.Ceil(float64(length) / float64(b))
-	if ell > 255 || length > math.MaxUint16 || len(dst) > math.MaxUint8 {
-		panic(errLengthTooLarge)
+	h := getHash(p.id)
+	defer putHash(p.id, h)
+
+	out := make([][]byte, len(inputs))
+	for i, input := range inputs {
+		if err := ctx.Err(); err != nil {
+			Wipe(out[:i]...)
+			return nil, err
+		}
+
+		out[i] = expandXMD(p.id, h, input, p.dstPrime, length)
+	}
+
+	return out, nil
+}
+
+// expandBatchSHA256x8 is ExpandBatchContext with SHA-256, expanding the inputs by groups of 8 with expandXMDSHA256x8.
+func (p *PreparedXMD) expandBatchSHA256x8(ctx context.Context, inputs [][]byte, length uint) ([][]byte, error) {
+	out := make([][]byte, 0, len(inputs))
+
+	for i := 0; i < len(inputs); i += sha256Lanes {
+		if err := ctx.Err(); err != nil {
+			Wipe(out...)
+			return nil, err
+		}
+
+		out = append(out, expandXMDSHA256x8(inputs[i:min(i+sha256Lanes, len(inputs))], p.dstPrime, length)...)
+	}
+
+	return out, nil
+}
+
+// ExpandXMDHash implements expand_message_xmd with the hash function h, for those that are not a crypto.Hash, e.g. the
+// legacy Keccak-256, identified by name in the ExpandHook, e.g. "KECCAK-256". h is reset before use.
+func ExpandXMDHash(name string, h hash.Hash, input, dst []byte, length uint) []byte {
+	if observe := ObserveExpand(); observe != nil {
+		defer observe(xmdPrefix+name, 1, length, time.Now())
+	}
+
+	return expandXMD(0, h, input, DstPrime(VetDSTXMD(h, dst)), length)
+}
+
+// expandXMD implements expand_message_xmd with the state h of id, which is 0 if h is not a crypto.Hash.
+func expandXMD(id crypto.Hash, h hash.Hash, input, dstPrime []byte, length uint) []byte {
+	ell := math.Ceil(float64(length) / float64(h.Size()))
+	if ell > 255 || length > math.MaxUint16 || len(dstPrime) > math.MaxUint8+1 {
+		panic(ErrLengthTooLarge)
 	}
 
-	zPad := make([]byte, blockSize)
 	lib := I2OSP(length, 2)
 	zeroByte := []byte{0}
-	dstPrime := DstPrime(dst)
 
-	// Hash to b0
-	b0 := _hash(h, zPad, input, lib, zeroByte, dstPrime)
+	// Hash to b0, starting from the state after absorbing Z_pad
+	absorbZPad(id, h)
+	b0 := _write(h, input, lib, zeroByte, dstPrime)
+	defer Wipe(b0)
 
 	// Hash to b1
 	b1 := _hash(h, b0, []byte{1}, dstPrime)
 
 	// ell < 2 means the hash function's output length is sufficient
 	if ell < 2 {
+		Wipe(b1[length:])
 		return b1[0:length]
 	}
 
 	// Only if we need to expand the hash output, we keep on hashing
+	defer Wipe(b1)
+
 	return xmd(h, b0, b1, dstPrime, uint(ell), length)
 }
 
-// DstPrime length-suffix-encodes dst.
+// DstPrime returns DST_prime, the length-suffix encoding of dst, in a new buffer. The backing array of dst is never
+// written to, even if it has spare capacity, so callers can reuse their buffers.
 func DstPrime(dst []byte) []byte {
-	return append(dst, I2OSP(uint(len(dst)), 1)[0])
+	dstPrime := make([]byte, len(dst)+1)
+	copy(dstPrime, dst)
+	dstPrime[len(dst)] = I2OSP(uint(len(dst)), 1)[0]
+
+	return dstPrime
 }
 
 // xmd expands the message digest until it reaches the desirable length.
@@ -66,8 +204,11 @@ func xmd(h hash.Hash, b0, b1, dstPrime []byte, ell, length uint) []byte {
 		xor := xorSlices(bi, b0)
 		bi = _hash(h, xor, []byte{byte(i)}, dstPrime)
 		uniformBytes = append(uniformBytes, bi...)
+		Wipe(xor)
 	}
 
+	Wipe(bi, uniformBytes[length:cap(uniformBytes)])
+
 	return uniformBytes[0:length]
 }
 
@@ -88,7 +229,7 @@ func VetDSTXMD(h hash.Hash, dst []byte) []byte {
 	}
 
 	if h.Size() > dstMaxLength {
-		panic(fmt.Sprintf("hash output size is too long %v / %d / %d", h, h.Size(), dstMaxLength))
+		panic(ErrDSTHashTooLong)
 	}
 
 	// If the tag length exceeds 255 bytes, compute a shorter tag by hashing it
@@ -97,7 +238,11 @@ func VetDSTXMD(h hash.Hash, dst []byte) []byte {
 
 func _hash(h hash.Hash, input ...[]byte) []byte {
 	h.Reset()
+	return _write(h, input...)
+}
 
+// _write absorbs the input in the current state of h, and returns the digest.
+func _write(h hash.Hash, input ...[]byte) []byte {
 	for _, i := range input {
 		_, _ = h.Write(i)
 	}
@@ -10,50 +10,160 @@
 package internal
 
 import (
-	"errors"
+	"io"
 	"math"
+	"time"
 
 	"github.com/bytemare/hash"
 )
 
-var errXOFHighOutput = errors.New("XOF dst hashing is too long")
+// The rates in bytes of SHAKE128 and SHAKE256, which identify them by their block size.
+const (
+	shake128Rate = 168
+	shake256Rate = 136
+)
+
+// XOF is the state of an extendable-output function, whose output is read as an io.Reader.
+type XOF interface {
+	io.Writer
+	io.Reader
+	Reset()
+}
+
+// ExtendableXOF adapts a *hash.ExtendableHash to XOF. The functions of this package use the *hash.ExtendableHash
+// directly, so that the output size of BLAKE2X is set before the input is absorbed.
+type ExtendableXOF struct {
+	*hash.ExtendableHash
+}
+
+// Read fills p with the output of the function. Since *hash.ExtendableHash reads at least Size() bytes at a time, a
+// shorter p is filled with the first bytes of a read of Size() bytes, and the remaining ones are lost.
+func (x ExtendableXOF) Read(p []byte) (int, error) {
+	return copy(p, x.ExtendableHash.Read(max(len(p), x.Size()))), nil
+}
 
 // ExpandXOF implements expand_message_xof as specified in RFC 9380 section 5.3.2.
-func ExpandXOF(ext *hash.ExtendableHash, input, dst []byte, length uint) []byte {
+func ExpandXOF(x XOF, input, dst []byte, length uint) []byte {
 	if length > math.MaxUint16 {
-		panic(errLengthTooLarge)
+		panic(ErrLengthTooLarge)
 	}
 
-	dst = VetXofDST(ext, dst)
+	if observe := ObserveExpand(); observe != nil {
+		defer observe("XOF:"+xofName(x), 1, length, time.Now())
+	}
+
+	dst = VetXofDST(x, dst)
 	len2o := I2OSP(length, 2)
 	dstLen2o := I2OSP(uint(len(dst)), 1)
 
-	ext.SetOutputSize(int(length))
+	if ext, ok := x.(ExtendableXOF); ok {
+		ext.SetOutputSize(int(length))
+		return ext.Hash(input, len2o, dst, dstLen2o)
+	}
 
-	return ext.Hash(input, len2o, dst, dstLen2o)
+	return xofHash(x, int(length), input, len2o, dst, dstLen2o)
 }
 
 // VetXofDST computes a shorter tag for dst if the tag length exceeds 255 bytes.
-func VetXofDST(x *hash.ExtendableHash, dst []byte) []byte {
+func VetXofDST(x XOF, dst []byte) []byte {
 	if len(dst) <= dstMaxLength {
 		return dst
 	}
 
-	size := checkXOFSecurityLevel(x)
-	x.SetOutputSize(size)
+	if ext, ok := x.(ExtendableXOF); ok {
+		size := checkXOFSecurityLevel(ext.ExtendableHash)
+		ext.SetOutputSize(size)
 
-	return x.Hash([]byte(dstLongPrefix), dst)
+		return ext.Hash([]byte(dstLongPrefix), dst)
+	}
+
+	return xofHash(x, securityLengthXOF(xofSecurityLevel(x)), []byte(dstLongPrefix), dst)
 }
 
-// checkXOFSecurityLength return the desired output length to shorten the DST, or panics if the XOFs security level is
-// too high for the expected output length.
+// checkXOFSecurityLevel returns the desired output length to shorten the DST, or panics with ErrInsufficientSecurity if
+// the XOFs security level is too high for its output length.
 func checkXOFSecurityLevel(x *hash.ExtendableHash) int {
-	k := x.Algorithm().SecurityLevel()
-
-	size := int(math.Ceil(float64(2*k) / float64(8)))
+	size := securityLengthXOF(x.Algorithm().SecurityLevel())
 	if size > x.Size()*8 {
-		panic(errXOFHighOutput)
+		panic(ErrInsufficientSecurity)
 	}
 
 	return size
 }
+
+// securityLengthXOF returns ceil(2 * k / 8), the length of the shortened DST for the security level k.
+func securityLengthXOF(k int) int {
+	return int(math.Ceil(float64(2*k) / float64(8)))
+}
+
+// xofSecurityLevel returns the security level of x, as reported by a SecurityLevel() int method, or inferred from
+// the block size for SHAKE128 and SHAKE256, with the same levels as for their *hash.ExtendableHash. The block size
+// tells SHAKE256 apart from SHA3-256 only because the latter can't be read as an XOF. It panics with
+// ErrUnsupportedHash otherwise.
+func xofSecurityLevel(x XOF) int {
+	if s, ok := x.(interface{ SecurityLevel() int }); ok {
+		return s.SecurityLevel()
+	}
+
+	if b, ok := x.(interface{ BlockSize() int }); ok {
+		switch b.BlockSize() {
+		case shake128Rate:
+			return hash.SHAKE128.SecurityLevel()
+		case shake256Rate:
+			return hash.SHAKE256.SecurityLevel()
+		}
+	}
+
+	panic(ErrUnsupportedHash)
+}
+
+// xofName returns the name of x, as reported by its *hash.ExtendableHash algorithm, or inferred from the block size
+// for SHAKE128 and SHAKE256, and "unknown" otherwise.
+func xofName(x XOF) string {
+	if ext, ok := x.(ExtendableXOF); ok {
+		return ext.Algorithm().String()
+	}
+
+	if b, ok := x.(interface{ BlockSize() int }); ok {
+		switch b.BlockSize() {
+		case shake128Rate:
+			return hash.SHAKE128.String()
+		case shake256Rate:
+			return hash.SHAKE256.String()
+		}
+	}
+
+	return "unknown"
+}
+
+// isFixedKeccak returns whether x reports the digest size and block size of a fixed-output SHA-3 or Keccak function,
+// i.e. a rate of 200 bytes minus twice the digest size, as the SHA-3 states of golang.org/x/crypto/sha3 can be read.
+func isFixedKeccak(x XOF) bool {
+	s, ok := x.(interface {
+		BlockSize() int
+		Size() int
+	})
+
+	return ok && s.Size() > 0 && s.BlockSize() == 200-2*s.Size()
+}
+
+// xofHash resets x, absorbs the input, and returns size bytes of output. It panics with ErrUnsupportedHash if x is a
+// fixed-output hash function.
+func xofHash(x XOF, size int, input ...[]byte) []byte {
+	if isFixedKeccak(x) {
+		panic(ErrUnsupportedHash)
+	}
+
+	x.Reset()
+
+	for _, in := range input {
+		_, _ = x.Write(in)
+	}
+
+	out := make([]byte, size)
+	if _, err := io.ReadFull(x, out); err != nil {
+		panic(err)
+	}
+
+	return out
+}
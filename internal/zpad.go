@@ -0,0 +1,70 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !purego
+
+package internal
+
+import (
+	"crypto"
+	"encoding"
+	"hash"
+	"sync"
+)
+
+// zPadState holds the marshaled state of a hash function after absorbing Z_pad, i.e. one block of zeros.
+type zPadState struct {
+	state []byte
+	once  sync.Once
+}
+
+// zPadStates caches the Z_pad midstate for each crypto.Hash.
+var zPadStates [crypto.BLAKE2b_512 + 1]zPadState
+
+// absorbZPad sets h to its state after absorbing Z_pad. Instead of compressing a zero block on each call, the
+// precomputed midstate is restored if the hash implementation supports state marshaling, as the stdlib SHA-2 and SHA-3
+// implementations do. The following blocks are then processed by the stdlib's optimized (e.g. SHA-NI or ARMv8 SHA
+// extensions) block functions. The midstate is not cached if id is 0, i.e. if h is not a crypto.Hash.
+func absorbZPad(id crypto.Hash, h hash.Hash) {
+	if id != 0 && int(id) < len(zPadStates) {
+		z := &zPadStates[id]
+		z.once.Do(func() {
+			z.state = marshalZPadState(id)
+		})
+
+		if u, ok := h.(encoding.BinaryUnmarshaler); ok && z.state != nil && u.UnmarshalBinary(z.state) == nil {
+			return
+		}
+	}
+
+	writeZPad(h)
+}
+
+// marshalZPadState returns the marshaled state of id after absorbing Z_pad, or nil if the state can't be marshaled.
+func marshalZPadState(id crypto.Hash) []byte {
+	h := id.New()
+
+	m, ok := h.(encoding.BinaryMarshaler)
+	if !ok {
+		return nil
+	}
+
+	writeZPad(h)
+
+	state, err := m.MarshalBinary()
+	if err != nil {
+		return nil
+	}
+
+	return state
+}
+
+func writeZPad(h hash.Hash) {
+	h.Reset()
+	_, _ = h.Write(zeros(h.BlockSize()))
+}
@@ -0,0 +1,22 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build purego
+
+package internal
+
+import (
+	"crypto"
+	"hash"
+)
+
+// absorbZPad sets h to its state after absorbing Z_pad, i.e. one block of zeros.
+func absorbZPad(_ crypto.Hash, h hash.Hash) {
+	h.Reset()
+	_, _ = h.Write(zeros(h.BlockSize()))
+}
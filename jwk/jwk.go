@@ -0,0 +1,110 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+// Package jwk exports the points output by the hash-to-curve suites as public JSON Web Keys (RFC 7517), so they can be
+// published in JOSE structures: EC keys (RFC 7518) with the "P-256", "P-384", "P-521", and "secp256k1" (RFC 8812)
+// curves, and OKP keys (RFC 8037) with the "Ed25519" and "X25519" curves for the edwards25519 and curve25519 suites.
+//
+// ristretto255 has no registered JWK curve. The "Ed448" and "X448" curves of RFC 8037 are not supported, since this
+// module doesn't hash to the 448-bit curves.
+package jwk
+
+import (
+	"crypto/ecdsa"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/bytemare/hash2curve"
+	"github.com/bytemare/hash2curve/internal/pubkey"
+)
+
+const (
+	// KeyTypeEC is the "kty" of the keys on Weierstrass curves.
+	KeyTypeEC = "EC"
+
+	// KeyTypeOKP is the "kty" of the octet key pairs, i.e. the keys on edwards25519 and curve25519.
+	KeyTypeOKP = "OKP"
+)
+
+// ErrUnsupportedCurve indicates a curve or a group that has no JWK representation.
+var ErrUnsupportedCurve = pubkey.ErrUnsupportedCurve
+
+var (
+	// ecCurves maps the CURVE_IDs of the Weierstrass curves to their JWK "crv".
+	ecCurves = map[string]string{
+		"P256":      "P-256",
+		"P384":      "P-384",
+		"P521":      "P-521",
+		"secp256k1": "secp256k1",
+	}
+
+	// okpCurves maps the CURVE_IDs of edwards25519 and curve25519 to their JWK "crv".
+	okpCurves = map[string]string{
+		"edwards25519": "Ed25519",
+		"curve25519":   "X25519",
+	}
+)
+
+// Key is a public JSON Web Key, and marshals to JSON with encoding/json. The coordinates are base64url-encoded without
+// padding, and Y is empty for OKP keys.
+type Key struct {
+	Kty string `json:"kty"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y,omitempty"`
+}
+
+// FromSuite returns the JWK of the point in the encoding output by the suite, i.e. the compressed or uncompressed
+// SEC 1 encoding for the Weierstrass curves, and the 32-byte encoding for edwards25519 and curve25519. It returns an
+// error wrapping ErrUnsupportedCurve for the ristretto255 suites, hash2curve.ErrInvalidSuite if the suite is invalid,
+// and hash2curve.ErrInvalidPoint if the point is invalid or is the point at infinity.
+func FromSuite(s hash2curve.Suite, point []byte) (*Key, error) {
+	if !s.Available() {
+		return nil, fmt.Errorf("%w: %s", hash2curve.ErrInvalidSuite, s)
+	}
+
+	curve := s.SuiteID().Curve
+
+	x, y, err := pubkey.Coordinates(curve, point)
+	if err != nil {
+		return nil, err
+	}
+
+	if y == nil {
+		return &Key{Kty: KeyTypeOKP, Crv: okpCurves[curve], X: encode(x)}, nil
+	}
+
+	return &Key{Kty: KeyTypeEC, Crv: ecCurves[curve], X: encode(x), Y: encode(y)}, nil
+}
+
+// FromECDSA returns the JWK of an ECDSA public key on the P-256, P-384, or P-521 curves of crypto/elliptic, e.g. as
+// returned by nist.HashToP256PublicKey. It returns an error wrapping ErrUnsupportedCurve for other curves.
+func FromECDSA(pub *ecdsa.PublicKey) (*Key, error) {
+	params := pub.Curve.Params()
+
+	switch params.Name {
+	case "P-256", "P-384", "P-521":
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedCurve, params.Name)
+	}
+
+	byteLen := (params.BitSize + 7) / 8
+
+	return &Key{
+		Kty: KeyTypeEC,
+		Crv: params.Name,
+		X:   encode(pub.X.FillBytes(make([]byte, byteLen))),
+		Y:   encode(pub.Y.FillBytes(make([]byte, byteLen))),
+	}, nil
+}
+
+func encode(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
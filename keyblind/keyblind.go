@@ -0,0 +1,234 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+// Package keyblind derives the blinding scalars and points of key-blinded signatures, as in
+// https://datatracker.ietf.org/doc/draft-irtf-cfrg-signature-key-blinding, for Ed25519 and ECDSA over P-256.
+//
+// For Ed25519, the blinding scalar of a blind key skB and a context ctx is the lower half of SHA-512(skB || ctx),
+// clamped as the secret scalar of an Ed25519 private key in RFC 8032. For ECDSA, it is the hash-to-scalar of this
+// module HashToScalar(skB, DST = "ECDSA Key Blind" || ctx) of P256_XMD:SHA-256_SSWU_RO_. Blinding a key multiplies it
+// by that scalar, in constant time for the private keys, and the blinded private and public keys match.
+package keyblind
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/sha512"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"filippo.io/edwards25519"
+	"filippo.io/nistec"
+
+	"github.com/bytemare/hash2curve"
+	"github.com/bytemare/hash2curve/internal/field/fn256"
+	"github.com/bytemare/hash2curve/nist"
+)
+
+const (
+	// ECDSALabel is the prefix of the DST of the ECDSA blinding scalars.
+	ECDSALabel = "ECDSA Key Blind"
+
+	p256ScalarLength = fn256.ElementLength
+)
+
+var (
+	// ErrZeroScalar indicates a blinding scalar of zero, which would blind any key to the identity, and happens with
+	// negligible probability.
+	ErrZeroScalar = errors.New("the blinding scalar is zero")
+
+	// ErrInvalidKey indicates a key that can't be blinded, e.g. an invalid encoding or a key on another curve.
+	ErrInvalidKey = errors.New("invalid key")
+)
+
+// ECDSADST returns the DST of the ECDSA blinding scalars for the context, i.e. "ECDSA Key Blind" || ctx.
+func ECDSADST(ctx []byte) []byte {
+	return append([]byte(ECDSALabel), ctx...)
+}
+
+// Ed25519BlindingScalar returns the blinding scalar of the blind key skB and the context for Ed25519, i.e. the lower
+// half of SHA-512(skB || ctx) clamped as in RFC 8032, which is never zero. It returns an error wrapping ErrInvalidKey
+// if skB is not of ed25519.SeedSize bytes, as an Ed25519 private key.
+func Ed25519BlindingScalar(skB, ctx []byte) (*edwards25519.Scalar, error) {
+	if len(skB) != ed25519.SeedSize {
+		return nil, fmt.Errorf("%w: the blind key must be %d bytes", ErrInvalidKey, ed25519.SeedSize)
+	}
+
+	h := sha512.New()
+	h.Write(skB)
+	h.Write(ctx)
+
+	digest := h.Sum(nil)
+	defer hash2curve.Wipe(digest)
+
+	bk, err := edwards25519.NewScalar().SetBytesWithClamping(digest[:32])
+	if err != nil {
+		panic(err)
+	}
+
+	return bk, nil
+}
+
+// Ed25519BlindingPoint returns the blinding scalar of skB and the context multiplied by the base point, or the error
+// of Ed25519BlindingScalar.
+func Ed25519BlindingPoint(skB, ctx []byte) (*edwards25519.Point, error) {
+	bk, err := Ed25519BlindingScalar(skB, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return new(edwards25519.Point).ScalarBaseMult(bk), nil
+}
+
+// Ed25519BlindPublicKey returns the public key blinded with skB and the context. It returns an error wrapping
+// ErrInvalidKey if the public key is not the encoding of a point, or the error of Ed25519BlindingScalar.
+func Ed25519BlindPublicKey(pk ed25519.PublicKey, skB, ctx []byte) (ed25519.PublicKey, error) {
+	p, err := new(edwards25519.Point).SetBytes(pk)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidKey, err)
+	}
+
+	bk, err := Ed25519BlindingScalar(skB, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.ScalarMult(bk, p).Bytes(), nil
+}
+
+// Ed25519BlindSecretScalar returns the secret scalar of the private key, i.e. its clamped SHA-512 expansion as in
+// RFC 8032, blinded with skB and the context. Its multiple of the base point is Ed25519BlindPublicKey of the public
+// key. It returns an error wrapping ErrInvalidKey if the private key is not of ed25519.PrivateKeySize bytes, or the
+// error of Ed25519BlindingScalar.
+func Ed25519BlindSecretScalar(sk ed25519.PrivateKey, skB, ctx []byte) (*edwards25519.Scalar, error) {
+	if len(sk) != ed25519.PrivateKeySize {
+		return nil, fmt.Errorf("%w: the private key must be %d bytes", ErrInvalidKey, ed25519.PrivateKeySize)
+	}
+
+	bk, err := Ed25519BlindingScalar(skB, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	digest := sha512.Sum512(sk.Seed())
+	defer hash2curve.Wipe(digest[:])
+
+	s, err := edwards25519.NewScalar().SetBytesWithClamping(digest[:32])
+	if err != nil {
+		panic(err)
+	}
+
+	return s.Multiply(s, bk), nil
+}
+
+// P256BlindingScalar returns the blinding scalar of the blind key skB and the context for ECDSA over P-256, or
+// ErrZeroScalar.
+func P256BlindingScalar(skB, ctx []byte) (*big.Int, error) {
+	bk := nist.HashToScalarP256(skB, ECDSADST(ctx))
+	if bk.Sign() == 0 {
+		return nil, ErrZeroScalar
+	}
+
+	return bk, nil
+}
+
+// P256BlindingPoint returns the blinding scalar of skB and the context multiplied by the base point, or
+// ErrZeroScalar.
+func P256BlindingPoint(skB, ctx []byte) (*nistec.P256Point, error) {
+	bk, err := P256BlindingScalar(skB, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return nistec.NewP256Point().ScalarBaseMult(bk.FillBytes(make([]byte, p256ScalarLength)))
+}
+
+// P256BlindPublicKey returns the P-256 public key blinded with skB and the context. It returns an error wrapping
+// ErrInvalidKey if the key is not a valid P-256 key, and ErrZeroScalar.
+func P256BlindPublicKey(pk *ecdsa.PublicKey, skB, ctx []byte) (*ecdsa.PublicKey, error) {
+	if pk.Curve != elliptic.P256() {
+		return nil, fmt.Errorf("%w: not a P-256 key", ErrInvalidKey)
+	}
+
+	key, err := pk.ECDH()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidKey, err)
+	}
+
+	p, err := nistec.NewP256Point().SetBytes(key.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidKey, err)
+	}
+
+	bk, err := P256BlindingScalar(skB, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = p.ScalarMult(p, bk.FillBytes(make([]byte, p256ScalarLength))); err != nil {
+		return nil, err
+	}
+
+	return p256PublicKey(p), nil
+}
+
+// P256BlindPrivateKey returns the P-256 private key blinded with skB and the context, whose public key is
+// P256BlindPublicKey of the public key, and which signs with crypto/ecdsa. It returns an error wrapping ErrInvalidKey
+// if the key is not a valid P-256 key, and ErrZeroScalar.
+func P256BlindPrivateKey(sk *ecdsa.PrivateKey, skB, ctx []byte) (*ecdsa.PrivateKey, error) {
+	order := nist.OrderP256()
+
+	if sk.Curve != elliptic.P256() || sk.D == nil || sk.D.Sign() <= 0 || sk.D.Cmp(order) >= 0 {
+		return nil, fmt.Errorf("%w: not a P-256 private key", ErrInvalidKey)
+	}
+
+	bk, err := P256BlindingScalar(skB, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var b, d [p256ScalarLength]byte
+
+	defer clear(b[:])
+	defer clear(d[:])
+
+	var blinding, key fn256.Element
+
+	defer blinding.Zero()
+	defer key.Zero()
+
+	bk.FillBytes(b[:])
+	blinding.SetBytes(&b)
+	sk.D.FillBytes(d[:])
+	key.SetBytes(&d)
+
+	// The product is computed in constant time in the scalar field, and only its encoding is converted to a big.Int.
+	d = key.Multiply(&key, &blinding).Bytes()
+
+	p, err := nistec.NewP256Point().ScalarBaseMult(d[:])
+	if err != nil {
+		return nil, err
+	}
+
+	return &ecdsa.PrivateKey{PublicKey: *p256PublicKey(p), D: new(big.Int).SetBytes(d[:])}, nil
+}
+
+func p256PublicKey(p *nistec.P256Point) *ecdsa.PublicKey {
+	encoding := p.Bytes()
+	byteLen := (len(encoding) - 1) / 2
+
+	return &ecdsa.PublicKey{
+		Curve: elliptic.P256(),
+		X:     new(big.Int).SetBytes(encoding[1 : 1+byteLen]),
+		Y:     new(big.Int).SetBytes(encoding[1+byteLen:]),
+	}
+}
@@ -0,0 +1,163 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+// Package m511 implements hashing to the M-511 Montgomery curve y^2 = x^3 + 530438 * x^2 + x over GF(2^511 - 187),
+// with Elligator 2, for a security level of 256 bits. The suites follow the construction of the RFC 9380 curve25519
+// suites, but are not specified by RFC 9380. Points are added on the birationally equivalent twisted Edwards curve.
+package m511
+
+import (
+	"crypto"
+	"math/big"
+
+	"github.com/bytemare/hash2curve"
+	"github.com/bytemare/hash2curve/internal/edwards"
+)
+
+const (
+	// H2C represents the hash-to-curve string identifier.
+	H2C = "M511_XMD:SHA-512_ELL2_RO_"
+
+	// E2C represents the encode-to-curve string identifier.
+	E2C = "M511_XMD:SHA-512_ELL2_NU_"
+
+	// EncodingLength is the length of the point encodings.
+	EncodingLength = 64
+
+	// securityLevel is the security level k, in bits.
+	securityLevel = 256
+
+	// log2Cofactor is the base 2 logarithm of the cofactor h_eff = 8.
+	log2Cofactor = 3
+)
+
+var suite = newSuite()
+
+func newSuite() *edwards.Suite {
+	prime := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 511), big.NewInt(187))
+	order, _ := new(big.Int).SetString(
+		"10724754759635747624044531514068121842070756627434833028965540808827675062043", 10)
+	order.Add(new(big.Int).Lsh(big.NewInt(1), 508), order)
+
+	// The first non-square of RFC 9380's find_z_ell2 is 2, since p = 5 mod 8.
+	c := edwards.NewCurve(prime, big.NewInt(530438), big.NewInt(1), big.NewInt(2), log2Cofactor)
+
+	return edwards.NewSuite(c, order, crypto.SHA512, securityLevel)
+}
+
+type disallowEqual [0]func()
+
+// Point is a point on M-511, internally represented in affine coordinates on the birationally equivalent twisted
+// Edwards curve, on which the addition law is complete.
+type Point struct {
+	_    disallowEqual
+	x, y big.Int
+}
+
+func newPoint(x, y *big.Int) *Point {
+	p := &Point{}
+	p.x.Set(x)
+	p.y.Set(y)
+
+	return p
+}
+
+// Coordinates returns the affine coordinates (x, y) of the point on M-511, and false for the point at infinity.
+func (p *Point) Coordinates() (x, y *big.Int, ok bool) {
+	return suite.EdwardsToMontgomery(&p.x, &p.y)
+}
+
+// Bytes returns the EncodingLength bytes little-endian encoding of x, with the parity of y in the most significant
+// bit. The point at infinity is encoded as x = 0 with the parity bit set.
+func (p *Point) Bytes() []byte {
+	return suite.EncodeMontgomery(&p.x, &p.y)
+}
+
+// SetBytes sets p to the point encoded as by Bytes, and returns p. It returns an error wrapping
+// hash2curve.ErrInvalidPoint if the encoding is invalid or not that of a point on the curve. The point may not be in
+// the prime-order subgroup.
+func (p *Point) SetBytes(b []byte) (*Point, error) {
+	x, y, err := suite.DecodeMontgomery(b)
+	if err != nil {
+		return nil, err
+	}
+
+	p.x.Set(x)
+	p.y.Set(y)
+
+	return p, nil
+}
+
+// Add sets p to p1 + p2, and returns p.
+func (p *Point) Add(p1, p2 *Point) *Point {
+	x, y := suite.Add(&p1.x, &p1.y, &p2.x, &p2.y)
+	p.x.Set(x)
+	p.y.Set(y)
+
+	return p
+}
+
+// ClearCofactor sets p to [h_eff]q, e.g. to move the outputs of MapToCurve into the prime-order subgroup, and
+// returns p.
+func (p *Point) ClearCofactor(q *Point) *Point {
+	x, y := suite.ClearCofactor(&q.x, &q.y)
+	p.x.Set(x)
+	p.y.Set(y)
+
+	return p
+}
+
+// Equal returns whether p and q are the same point.
+func (p *Point) Equal(q *Point) bool {
+	return p.x.Cmp(&q.x) == 0 && p.y.Cmp(&q.y) == 0
+}
+
+// HashToCurve implements hash-to-curve mapping to M-511 of input with dst.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func HashToCurve(input, dst []byte) *Point {
+	return newPoint(suite.HashToCurve(input, dst))
+}
+
+// EncodeToCurve implements encode-to-curve mapping to M-511 of input with dst.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func EncodeToCurve(input, dst []byte) *Point {
+	return newPoint(suite.EncodeToCurve(input, dst))
+}
+
+// HashToScalar returns a safe mapping of the arbitrary input to a scalar for the prime-order group of M-511.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func HashToScalar(input, dst []byte) *big.Int {
+	return suite.HashToScalar(input, dst)
+}
+
+// MapToCurve implements Elligator 2 for M-511, without clearing the cofactor. It panics with
+// hash2curve.ErrNonCanonical if fe is not a canonical field element.
+func MapToCurve(fe *big.Int) *Point {
+	if !suite.Field.IsCanonical(fe) {
+		panic(hash2curve.ErrNonCanonical)
+	}
+
+	return newPoint(suite.MapToCurve(fe))
+}
+
+// Order returns a copy of the order of the prime-order group of M-511, i.e. the modulus of HashToScalar.
+func Order() *big.Int {
+	return suite.Order()
+}
+
+// FieldPrime returns a copy of the prime of the field of M-511.
+func FieldPrime() *big.Int {
+	return suite.FieldPrime()
+}
+
+// Cofactor returns a copy of the cofactor of M-511, which clears the outputs of MapToCurve into the prime-order group.
+func Cofactor() *big.Int {
+	return suite.Cofactor()
+}
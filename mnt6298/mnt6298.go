@@ -0,0 +1,284 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+// Package mnt6298 implements hashing to the groups G1 and G2 of MNT6-298, which forms with MNT4-298 the cycle of
+// pairing-friendly curves used by recursive SNARKs, with the simplified SWU map and expand_message_xmd with SHA-256,
+// for a security level of 128 bits. G1 is the curve y^2 = x^3 + 11 * x + B over GF(p), of prime order r, and G2 is the
+// subgroup of order r of its quadratic twist over GF(p^3) = GF(p)[u] / (u^3 - 5). The base field of each curve of the
+// cycle is the scalar field of the other. The suites follow the construction of the RFC 9380 BLS12-381 suites, but are
+// not specified by RFC 9380.
+package mnt6298
+
+import (
+	"crypto"
+	"math/big"
+
+	"github.com/bytemare/hash2curve"
+	"github.com/bytemare/hash2curve/internal/extension"
+	"github.com/bytemare/hash2curve/internal/weierstrass"
+)
+
+const (
+	// G1H2C represents the hash-to-curve string identifier for G1.
+	G1H2C = "MNT6298G1_XMD:SHA-256_SSWU_RO_"
+
+	// G1E2C represents the encode-to-curve string identifier for G1.
+	G1E2C = "MNT6298G1_XMD:SHA-256_SSWU_NU_"
+
+	// G2H2C represents the hash-to-curve string identifier for G2.
+	G2H2C = "MNT6298G2_XMD:SHA-256_SSWU_RO_"
+
+	// G2E2C represents the encode-to-curve string identifier for G2.
+	G2E2C = "MNT6298G2_XMD:SHA-256_SSWU_NU_"
+
+	// securityLevel is the security level k, in bits.
+	securityLevel = 128
+
+	fieldPrime = "475922286169261325753349249653048451545124878552823515553267735739164647307408490559963137"
+	groupOrder = "475922286169261325753349249653048451545124879242694725395555128576210262817955800483758081"
+	coeffB     = "106700080510851735677967319632585352256454251201367587890185989362936000262606668469523074"
+	g2Cofactor = "226502022472576270196498690498308461791828762732602586162207535351960270082712694977333372361549" +
+		"082214519252261735048131889018501404377856786623430385820659037970876666767495659520"
+
+	// nonResidue is β in GF(p^3) = GF(p)[u] / (u^3 - β), and the twist is y^2 = x^3 + A * u^2 * x + B * β.
+	nonResidue = 5
+)
+
+var g1, g2 = newSuites()
+
+func newSuites() (g1, g2 *weierstrass.Suite) {
+	p, _ := new(big.Int).SetString(fieldPrime, 10)
+	r, _ := new(big.Int).SetString(groupOrder, 10)
+	b, _ := new(big.Int).SetString(coeffB, 10)
+	h, _ := new(big.Int).SetString(g2Cofactor, 10)
+	a := big.NewInt(11)
+	beta := big.NewInt(nonResidue)
+
+	// The Z are those of RFC 9380's find_z_sswu, which tries 1, -1, 2, -2, ... in GF(p), and u, -u, u + 1, -(u + 1),
+	// ... in GF(p^3): 10 and -(u + 9).
+	fp := extension.NewField(p, 1, beta)
+	c1 := weierstrass.NewCurve(fp, fp.Element(a), fp.Element(b), fp.Element(big.NewInt(10)), big.NewInt(1))
+
+	fp3 := extension.NewField(p, 3, beta)
+	a2 := fp3.Element(big.NewInt(0), big.NewInt(0), a)
+	b2 := fp3.Element(new(big.Int).Mul(b, beta))
+	c2 := weierstrass.NewCurve(fp3, a2, b2, fp3.Element(big.NewInt(-9), big.NewInt(-1)), h)
+
+	return weierstrass.NewSuite(c1, r, crypto.SHA256, securityLevel),
+		weierstrass.NewSuite(c2, r, crypto.SHA256, securityLevel)
+}
+
+type disallowEqual [0]func()
+
+// G1 is a point of G1, in affine coordinates, or the point at infinity.
+type G1 struct {
+	_        disallowEqual
+	X, Y     big.Int
+	infinity bool
+}
+
+func newG1(p *weierstrass.Point) *G1 {
+	q := &G1{infinity: p.Infinity}
+	q.X.Set(p.X[0])
+	q.Y.Set(p.Y[0])
+
+	return q
+}
+
+func (p *G1) point() *weierstrass.Point {
+	return &weierstrass.Point{
+		X:        extension.Element{new(big.Int).Set(&p.X)},
+		Y:        extension.Element{new(big.Int).Set(&p.Y)},
+		Infinity: p.infinity,
+	}
+}
+
+// IsInfinity returns whether p is the point at infinity, i.e. the identity.
+func (p *G1) IsInfinity() bool {
+	return p.infinity
+}
+
+// IsInSubgroup returns whether p is on the curve and in the subgroup of order r.
+func (p *G1) IsInSubgroup() bool {
+	return g1.IsInSubgroup(p.point())
+}
+
+// Add sets p to p1 + p2, and returns p.
+func (p *G1) Add(p1, p2 *G1) *G1 {
+	*p = *newG1(g1.Add(p1.point(), p2.point()))
+	return p
+}
+
+// Equal returns whether p and q are the same point.
+func (p *G1) Equal(q *G1) bool {
+	if p.infinity || q.infinity {
+		return p.infinity == q.infinity
+	}
+
+	return p.X.Cmp(&q.X) == 0 && p.Y.Cmp(&q.Y) == 0
+}
+
+// Bytes returns the SEC 1 compressed encoding of the point, or the single byte 0x00 for the point at infinity.
+func (p *G1) Bytes() []byte {
+	return g1.EncodeSEC1(p.point(), true)
+}
+
+// BytesUncompressed returns the SEC 1 uncompressed encoding 0x04 || x || y of the point, or the single byte 0x00 for
+// the point at infinity.
+func (p *G1) BytesUncompressed() []byte {
+	return g1.EncodeSEC1(p.point(), false)
+}
+
+// G2 is a point of G2, in affine coordinates, or the point at infinity. The coordinates are elements
+// c0 + c1 * u + c2 * u^2 of GF(p^3), represented by [c0, c1, c2].
+type G2 struct {
+	_        disallowEqual
+	X, Y     [3]big.Int
+	infinity bool
+}
+
+func newG2(p *weierstrass.Point) *G2 {
+	q := &G2{infinity: p.Infinity}
+	for i := range q.X {
+		q.X[i].Set(p.X[i])
+		q.Y[i].Set(p.Y[i])
+	}
+
+	return q
+}
+
+func (p *G2) point() *weierstrass.Point {
+	return &weierstrass.Point{
+		X:        g2.Field.Element(&p.X[0], &p.X[1], &p.X[2]),
+		Y:        g2.Field.Element(&p.Y[0], &p.Y[1], &p.Y[2]),
+		Infinity: p.infinity,
+	}
+}
+
+// IsInfinity returns whether p is the point at infinity, i.e. the identity.
+func (p *G2) IsInfinity() bool {
+	return p.infinity
+}
+
+// IsInSubgroup returns whether p is on the twist and in the subgroup of order r.
+func (p *G2) IsInSubgroup() bool {
+	for i := range p.X {
+		if !g2.Field.Base.IsCanonical(&p.X[i]) || !g2.Field.Base.IsCanonical(&p.Y[i]) {
+			return false
+		}
+	}
+
+	return g2.IsInSubgroup(p.point())
+}
+
+// Add sets p to p1 + p2, and returns p.
+func (p *G2) Add(p1, p2 *G2) *G2 {
+	*p = *newG2(g2.Add(p1.point(), p2.point()))
+	return p
+}
+
+// Equal returns whether p and q are the same point.
+func (p *G2) Equal(q *G2) bool {
+	if p.infinity || q.infinity {
+		return p.infinity == q.infinity
+	}
+
+	for i := range p.X {
+		if p.X[i].Cmp(&q.X[i]) != 0 || p.Y[i].Cmp(&q.Y[i]) != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Bytes returns the encoding x || y of the point, with the coefficients of each coordinate in GF(p^3) in the order of
+// X and Y as fixed-length big-endian integers, or all zeros for the point at infinity.
+func (p *G2) Bytes() []byte {
+	return g2.EncodeXY(p.point())
+}
+
+// ClearCofactor sets p to [h_eff]q, e.g. to move the outputs of MapToG2 into G2, and returns p.
+func (p *G2) ClearCofactor(q *G2) *G2 {
+	*p = *newG2(g2.ClearCofactor(q.point()))
+	return p
+}
+
+// HashToG1 implements hash-to-curve mapping to G1 of input with dst.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func HashToG1(input, dst []byte) *G1 {
+	return newG1(g1.HashToCurve(input, dst))
+}
+
+// EncodeToG1 implements encode-to-curve mapping to G1 of input with dst.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func EncodeToG1(input, dst []byte) *G1 {
+	return newG1(g1.EncodeToCurve(input, dst))
+}
+
+// HashToG2 implements hash-to-curve mapping to G2 of input with dst, hashing to GF(p^3).
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func HashToG2(input, dst []byte) *G2 {
+	return newG2(g2.HashToCurve(input, dst))
+}
+
+// EncodeToG2 implements encode-to-curve mapping to G2 of input with dst, hashing to GF(p^3).
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func EncodeToG2(input, dst []byte) *G2 {
+	return newG2(g2.EncodeToCurve(input, dst))
+}
+
+// HashToScalar returns a safe mapping of the arbitrary input to a scalar modulo r.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func HashToScalar(input, dst []byte) *big.Int {
+	return g1.HashToScalar(input, dst)
+}
+
+// MapToG1 implements the simplified SWU map to the curve of G1, of prime order. It panics with
+// hash2curve.ErrNonCanonical if fe is not a canonical element of GF(p).
+func MapToG1(fe *big.Int) *G1 {
+	if !g1.Field.Base.IsCanonical(fe) {
+		panic(hash2curve.ErrNonCanonical)
+	}
+
+	return newG1(g1.MapToCurve(extension.Element{fe}))
+}
+
+// MapToG2 implements the simplified SWU map to the twist, without clearing the cofactor, of
+// fe[0] + fe[1] * u + fe[2] * u^2. It panics with hash2curve.ErrNonCanonical if fe is not a canonical element of
+// GF(p^3).
+func MapToG2(fe [3]*big.Int) *G2 {
+	u := extension.Element(fe[:])
+	if !g2.Field.IsCanonical(u) {
+		panic(hash2curve.ErrNonCanonical)
+	}
+
+	return newG2(g2.MapToCurve(u))
+}
+
+// Order returns a copy of the order r of G1 and G2, i.e. the modulus of HashToScalar.
+func Order() *big.Int {
+	return g1.Order()
+}
+
+// FieldPrime returns a copy of the prime p of the base field of MNT6-298, over which G2 is defined by an extension.
+func FieldPrime() *big.Int {
+	return g1.FieldPrime()
+}
+
+// CofactorG1 returns a copy of the cofactor of G1, which clears the outputs of MapToG1 into G1.
+func CofactorG1() *big.Int {
+	return g1.Cofactor()
+}
+
+// CofactorG2 returns a copy of the cofactor of G2, which clears the outputs of MapToG2 into G2.
+func CofactorG2() *big.Int {
+	return g2.Cofactor()
+}
@@ -0,0 +1,137 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+package nist
+
+import (
+	"math/big"
+
+	"filippo.io/nistec"
+
+	"github.com/bytemare/hash2curve"
+)
+
+// HashToP256Batch returns HashToP256(input, dst) for each of the inputs, sharing a single field inversion across the
+// mappings of the batch, which is cheaper than hashing them one by one.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func HashToP256Batch(inputs [][]byte, dst []byte) []*nistec.P256Point {
+	return must(p256.hashXMDBatch(inputs, dst))
+}
+
+// EncodeToP256Batch returns EncodeToP256(input, dst) for each of the inputs, as HashToP256Batch does.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func EncodeToP256Batch(inputs [][]byte, dst []byte) []*nistec.P256Point {
+	return must(p256.encodeXMDBatch(inputs, dst))
+}
+
+// MapToCurveP256Batch returns MapToCurveP256(fe) for each of the field elements, sharing a single field inversion
+// across the batch.
+func MapToCurveP256Batch(fes []*big.Int) []*nistec.P256Point {
+
+	for _, fe := range fes {
+		p256.checkCanonical(fe)
+	}
+
+	return must(p256.map2curveBatch(fes))
+}
+
+// HashToP384Batch returns HashToP384(input, dst) for each of the inputs, as HashToP256Batch does.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func HashToP384Batch(inputs [][]byte, dst []byte) []*nistec.P384Point {
+	return must(p384.hashXMDBatch(inputs, dst))
+}
+
+// EncodeToP384Batch returns EncodeToP384(input, dst) for each of the inputs, as HashToP256Batch does.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func EncodeToP384Batch(inputs [][]byte, dst []byte) []*nistec.P384Point {
+	return must(p384.encodeXMDBatch(inputs, dst))
+}
+
+// MapToCurveP384Batch returns MapToCurveP384(fe) for each of the field elements, as MapToCurveP256Batch does.
+func MapToCurveP384Batch(fes []*big.Int) []*nistec.P384Point {
+
+	for _, fe := range fes {
+		p384.checkCanonical(fe)
+	}
+
+	return must(p384.map2curveBatch(fes))
+}
+
+// HashToP521Batch returns HashToP521(input, dst) for each of the inputs, as HashToP256Batch does.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func HashToP521Batch(inputs [][]byte, dst []byte) []*nistec.P521Point {
+	return must(p521.hashXMDBatch(inputs, dst))
+}
+
+// EncodeToP521Batch returns EncodeToP521(input, dst) for each of the inputs, as HashToP256Batch does.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func EncodeToP521Batch(inputs [][]byte, dst []byte) []*nistec.P521Point {
+	return must(p521.encodeXMDBatch(inputs, dst))
+}
+
+// MapToCurveP521Batch returns MapToCurveP521(fe) for each of the field elements, as MapToCurveP256Batch does.
+func MapToCurveP521Batch(fes []*big.Int) []*nistec.P521Point {
+
+	for _, fe := range fes {
+		p521.checkCanonical(fe)
+	}
+
+	return must(p521.map2curveBatch(fes))
+}
+
+// hashToFieldBatch returns the count field elements of hash_to_field for each of the inputs, in order. The inputs are
+// expanded together with hash2curve.ExpandXMDBatch, which hashes several at once with multi-lane SHA-256 when possible.
+func (c *nistCurve[point]) hashToFieldBatch(inputs [][]byte, dst []byte, count uint) []*big.Int {
+	u := make([]*big.Int, 0, uint(len(inputs))*count)
+
+	for _, uniform := range hash2curve.ExpandXMDBatch(c.hash, inputs, dst, count*c.secLength) {
+		for i := range count {
+			u = append(u, c.reducer.Reduce(uniform[i*c.secLength:(i+1)*c.secLength]))
+		}
+
+		hash2curve.Wipe(uniform)
+	}
+
+	return u
+}
+
+func (c *nistCurve[point]) encodeXMDBatch(inputs [][]byte, dst []byte) ([]point, error) {
+	// We can save cofactor clearing because it is 1.
+	return c.map2curveBatch(c.hashToFieldBatch(inputs, dst, 1))
+}
+
+func (c *nistCurve[point]) hashXMDBatch(inputs [][]byte, dst []byte) ([]point, error) {
+	q, err := c.map2curveBatch(c.hashToFieldBatch(inputs, dst, 2))
+	if err != nil {
+		return nil, err
+	}
+
+	// We can save cofactor clearing because it is 1.
+	out := make([]point, len(inputs))
+	for i := range out {
+		out[i] = q[2*i].Add(q[2*i], q[2*i+1])
+	}
+
+	return out, nil
+}
+
+func (c *nistCurve[point]) map2curveBatch(fes []*big.Int) ([]point, error) {
+	xs, ys := c.sswu.MapBatch(fes)
+	out := make([]point, len(fes))
+
+	for i := range out {
+		var err error
+		if out[i], err = c.affineToPoint(xs[i], ys[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return out, nil
+}
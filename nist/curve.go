@@ -0,0 +1,371 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+package nist
+
+import (
+	"crypto"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/bytemare/hash2curve"
+	"github.com/bytemare/hash2curve/internal"
+	"github.com/bytemare/hash2curve/internal/field"
+)
+
+// defaultSecurityLevel is the security level k of a Curve whose parameters don't set it, in bits.
+const defaultSecurityLevel = 128
+
+// ErrInvalidCurveParams indicates curve parameters that can't be used with the Simplified SWU method.
+var ErrInvalidCurveParams = errors.New("invalid curve parameters")
+
+// Point is the interface of the points of a Curve, as implemented by the filippo.io/nistec points.
+type Point[P any] interface {
+	// Add sets the receiver to p1 + p2, and returns it.
+	Add(p1, p2 P) P
+
+	// Bytes returns the SEC 1 uncompressed encoding of the point.
+	Bytes() []byte
+
+	// SetBytes sets the receiver to the point encoded in SEC 1 format, and must return an error if the point is not on
+	// the curve.
+	SetBytes(b []byte) (P, error)
+}
+
+// CurveParams holds the parameters of a short Weierstrass curve y^2 = x^3 + A * x + B of prime order, and of its
+// RFC 9380 suite using the Simplified SWU method with expand_message_xmd.
+type CurveParams struct {
+	// Prime is the characteristic of the base field, which must be 3 mod 4.
+	Prime *big.Int
+
+	// A is the a coefficient of the curve, which defaults to -3 if nil.
+	A *big.Int
+
+	// B is the b coefficient of the curve.
+	B *big.Int
+
+	// Order is the order of the group, used for hash-to-scalar.
+	Order *big.Int
+
+	// Z is the Z of the Simplified SWU method for the curve, which must meet the criteria of find_z_sswu in RFC 9380,
+	// Appendix H.2.
+	Z int
+
+	// Hash is the hash function of expand_message_xmd.
+	Hash crypto.Hash
+
+	// SecurityLength is the length L of each element in hash_to_field.
+	SecurityLength uint
+
+	// SecurityLevel is the target security level k of the suite, in bits, which defaults to 128 if zero. If it is set,
+	// or if the strict security policy of hash2curve.SetStrictSecurity is enabled, Hash and SecurityLength must meet
+	// it, as reported by hash2curve.ValidateSecurityLevel.
+	SecurityLevel uint
+
+	// LimbArithmetic selects the constant-time limb arithmetic for the base field, for the primes of at most 576 bits.
+	// The conversions from and to big.Int stay variable-time, and hashing is about 1.7 times slower than with the
+	// default big.Int arithmetic.
+	LimbArithmetic bool
+}
+
+// Curve implements the RFC 9380 pipeline over any implementation of the curve's points, e.g. in hardware or from
+// another library, with cofactor 1.
+type Curve[P Point[P]] struct {
+	curve nistCurve[P]
+}
+
+// NewCurve returns a Curve for the parameters, building points with newPoint. It returns an error wrapping
+// ErrInvalidCurveParams, hash2curve.ErrInvalidParameters, or hash2curve.ErrInsufficientSecurity if the parameters are
+// invalid.
+func NewCurve[P Point[P]](params CurveParams, newPoint func() P) (*Curve[P], error) {
+	if err := params.validate(); err != nil {
+		return nil, err
+	}
+
+	c := &Curve[P]{}
+	c.curve.setCurveParams(params.Prime, params.B, newPoint)
+
+	if params.LimbArithmetic {
+		c.curve.field = field.NewLimbBackedField(params.Prime)
+	}
+
+	c.curve.setMapping(params.Hash, params.Z, params.SecurityLength, params.securityLevel())
+	c.curve.groupOrder.Set(params.Order)
+
+	if params.A != nil {
+		c.curve.field.Mod(c.curve.a.Set(params.A))
+	}
+
+	return c, nil
+}
+
+// FieldElement is the interface of the elements of the fields generated by genfield, whose pointer type *E implements
+// the arithmetic, e.g. the Element type of a package generated with cmd/genfield for the prime of a Curve.
+type FieldElement[E any] interface {
+	*E
+	Set(x *E) *E
+	SetBig(x *big.Int) *E
+	Big() *big.Int
+	One() *E
+	Add(x, y *E) *E
+	Subtract(x, y *E) *E
+	Negate(x *E) *E
+	Multiply(x, y *E) *E
+	Square(x *E) *E
+	Invert(x *E) *E
+	Sqrt(x *E) int
+	Select(a, b *E, cond int) *E
+	IsZero() int
+	Sgn0() int
+}
+
+// NewCurveWithField is NewCurve with the Simplified SWU map computed with the constant-time arithmetic of a field
+// generated with cmd/genfield for the prime of the parameters, e.g. NewCurveWithField[*nistec.P256Point,
+// fp256.Element](params, nistec.NewP256Point). The conversions of the field elements from and to big.Int, and
+// HashToScalar, are not constant-time. It returns an error wrapping ErrInvalidCurveParams if the field does not have
+// the prime of the parameters.
+func NewCurveWithField[P Point[P], E any, F FieldElement[E]](params CurveParams, newPoint func() P) (*Curve[P], error) {
+	c, err := NewCurve(params, newPoint)
+	if err != nil {
+		return nil, err
+	}
+
+	// The prime is zero in the field only if it is its modulus, since it is prime.
+	if F(F(new(E)).SetBig(params.Prime)).IsZero() != 1 {
+		return nil, fmt.Errorf("%w: the generated field does not have the prime of the curve", ErrInvalidCurveParams)
+	}
+
+	c.curve.mapper = internal.NewSSWUBig[E, F](&c.curve.a, &c.curve.b, &c.curve.mapping.z)
+
+	return c, nil
+}
+
+// Order returns a copy of the order of the group, i.e. the modulus of HashToScalar.
+func (c *Curve[P]) Order() *big.Int {
+	return new(big.Int).Set(&c.curve.groupOrder)
+}
+
+// FieldPrime returns a copy of the prime of the field of the curve.
+func (c *Curve[P]) FieldPrime() *big.Int {
+	return new(big.Int).Set(c.curve.field.Order())
+}
+
+func (p *CurveParams) validate() error {
+	three, four := big.NewInt(3), big.NewInt(4)
+
+	switch {
+	case p.Prime == nil || p.Prime.Cmp(three) <= 0 || new(big.Int).Mod(p.Prime, four).Cmp(three) != 0:
+		return fmt.Errorf("%w: the prime must be 3 mod 4", ErrInvalidCurveParams)
+	case p.A != nil && new(big.Int).Mod(p.A, p.Prime).Sign() == 0:
+		return fmt.Errorf("%w: A must not be zero", ErrInvalidCurveParams)
+	case p.B == nil || p.B.Sign() <= 0 || p.B.Cmp(p.Prime) >= 0:
+		return fmt.Errorf("%w: B must be a non-zero field element", ErrInvalidCurveParams)
+	case p.Order == nil || p.Order.Cmp(big.NewInt(1)) <= 0:
+		return fmt.Errorf("%w: the group order must be larger than 1", ErrInvalidCurveParams)
+	case p.Z == 0:
+		return fmt.Errorf("%w: Z must not be zero", ErrInvalidCurveParams)
+	case !p.Hash.Available():
+		return fmt.Errorf("%w: the hash function is not available", ErrInvalidCurveParams)
+	}
+
+	if err := p.validateZ(); err != nil {
+		return err
+	}
+
+	if err := hash2curve.ValidateHashToField(2, 1, p.SecurityLength, p.Prime); err != nil {
+		return err
+	}
+
+	if p.SecurityLevel != 0 || hash2curve.StrictSecurity() {
+		k := p.securityLevel()
+
+		for _, modulo := range []*big.Int{p.Prime, p.Order} {
+			if err := hash2curve.ValidateSecurityLevel(p.Hash, p.SecurityLength, modulo, k); err != nil {
+				return err
+			}
+		}
+	}
+
+	return hash2curve.ValidateHashToField(1, 1, p.SecurityLength, p.Order)
+}
+
+// validateZ checks the criteria of find_z_sswu in RFC 9380, Appendix H.2, for Z: it must be a non-square other than
+// -1, g(x) - Z must be irreducible, and g(B / (Z * A)) must be square, with g(x) = x^3 + A * x + B.
+func (p *CurveParams) validateZ() error {
+	a := big.NewInt(-3)
+	if p.A != nil {
+		a.Set(p.A)
+	}
+
+	a.Mod(a, p.Prime)
+	z := new(big.Int).Mod(big.NewInt(int64(p.Z)), p.Prime)
+
+	switch {
+	case big.Jacobi(z, p.Prime) != -1:
+		return fmt.Errorf("%w: Z must be a non-square", ErrInvalidCurveParams)
+	case z.Cmp(new(big.Int).Sub(p.Prime, big.NewInt(1))) == 0:
+		return fmt.Errorf("%w: Z must not be -1", ErrInvalidCurveParams)
+	case cubicHasRoot(a, new(big.Int).Sub(p.B, z), p.Prime):
+		return fmt.Errorf("%w: g(x) - Z must be irreducible", ErrInvalidCurveParams)
+	}
+
+	// x = B / (Z * A), and g(x) = (x^2 + A) * x + B.
+	x := new(big.Int).Mul(z, a)
+	x.ModInverse(x, p.Prime).Mul(x, p.B).Mod(x, p.Prime)
+	gx := new(big.Int).Mul(x, x)
+	gx.Add(gx, a).Mul(gx, x).Add(gx, p.B).Mod(gx, p.Prime)
+
+	if big.Jacobi(gx, p.Prime) == -1 {
+		return fmt.Errorf("%w: g(B / (Z * A)) must be square", ErrInvalidCurveParams)
+	}
+
+	return nil
+}
+
+// cubicHasRoot returns whether x^3 + a * x + c has a root modulo the prime, i.e. if gcd(x^prime - x, x^3 + a * x + c)
+// is not constant.
+func cubicHasRoot(a, c, prime *big.Int) bool {
+	f := poly{new(big.Int).Mod(c, prime), a, new(big.Int), big.NewInt(1)}
+
+	// r = x^prime mod f, with square-and-multiply.
+	r, x := poly{big.NewInt(1)}, poly{new(big.Int), big.NewInt(1)}
+	for i := prime.BitLen() - 1; i >= 0; i-- {
+		r = r.mul(r, prime).mod(f, prime)
+		if prime.Bit(i) == 1 {
+			r = r.mul(x, prime).mod(f, prime)
+		}
+	}
+
+	// g = r - x.
+	g := append(r, make(poly, max(0, 2-len(r)))...)
+	g[1] = new(big.Int).Sub(g[1], big.NewInt(1))
+	g[1].Mod(g[1], prime)
+	g = g.trim()
+
+	for len(g) != 0 {
+		f, g = g, f.mod(g, prime)
+	}
+
+	return len(f) > 1
+}
+
+// poly is a polynomial over GF(p), with the coefficients in increasing degree and no leading zero.
+type poly []*big.Int
+
+func (u poly) trim() poly {
+	for len(u) != 0 && u[len(u)-1].Sign() == 0 {
+		u = u[:len(u)-1]
+	}
+
+	return u
+}
+
+// mul returns u * v.
+func (u poly) mul(v poly, prime *big.Int) poly {
+	res := make(poly, len(u)+len(v))
+	for i := range res {
+		res[i] = new(big.Int)
+	}
+
+	for i, ui := range u {
+		for j, vj := range v {
+			res[i+j].Add(res[i+j], new(big.Int).Mul(ui, vj))
+		}
+	}
+
+	for _, c := range res {
+		c.Mod(c, prime)
+	}
+
+	return res.trim()
+}
+
+// mod returns u mod v, for a non-zero v.
+func (u poly) mod(v poly, prime *big.Int) poly {
+	res := make(poly, len(u))
+	for i, c := range u {
+		res[i] = new(big.Int).Mod(c, prime)
+	}
+
+	res = res.trim()
+	inv := new(big.Int).ModInverse(v[len(v)-1], prime)
+
+	for len(res) >= len(v) {
+		k := new(big.Int).Mul(res[len(res)-1], inv)
+		shift := len(res) - len(v)
+
+		for i, c := range v {
+			res[shift+i].Sub(res[shift+i], new(big.Int).Mul(k, c)).Mod(res[shift+i], prime)
+		}
+
+		res = res.trim()
+	}
+
+	return res
+}
+
+// securityLevel returns the target security level k of the suite, in bits.
+func (p *CurveParams) securityLevel() uint {
+	if p.SecurityLevel == 0 {
+		return defaultSecurityLevel
+	}
+
+	return p.SecurityLevel
+}
+
+// HashToCurve implements hash-to-curve mapping to the curve of input with dst.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func (c *Curve[P]) HashToCurve(input, dst []byte) P {
+	return must(c.curve.hashXMD(input, dst))
+}
+
+// EncodeToCurve implements encode-to-curve mapping to the curve of input with dst.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func (c *Curve[P]) EncodeToCurve(input, dst []byte) P {
+	return must(c.curve.encodeXMD(input, dst))
+}
+
+// HashToScalar returns a safe mapping of the arbitrary input to a scalar of the group.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes. The hash function and expansion
+// length default to those of the curve, and can be overridden with options.
+func (c *Curve[P]) HashToScalar(input, dst []byte, opts ...ScalarOption) *big.Int {
+	return c.curve.hashToScalar(input, dst, opts)
+}
+
+// HashToScalars returns count scalars of the group from a single expansion of the input, see [hash2curve].
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func (c *Curve[P]) HashToScalars(input, dst []byte, count uint, opts ...ScalarOption) []*big.Int {
+	return c.curve.hashToScalars(input, dst, count, opts)
+}
+
+// MapToCurve implements the map_to_curve function, mapping the field element to a curve point.
+func (c *Curve[P]) MapToCurve(fe *big.Int) P {
+	c.curve.checkCanonical(fe)
+	return must(c.curve.map2curve(fe))
+}
+
+// TryHashToCurve is HashToCurve returning an error instead of panicking, e.g. when serving untrusted input: the errors
+// of hash2curve.ValidateDST and hash2curve.ValidateInput for input and dst, or one wrapping hash2curve.ErrInvalidPoint
+// if the point implementation rejects a mapped point, which happens if it does not match the curve parameters.
+func (c *Curve[P]) TryHashToCurve(input, dst []byte) (P, error) {
+	return c.curve.tryHashXMD(input, dst)
+}
+
+// TryEncodeToCurve is EncodeToCurve returning an error instead of panicking, as TryHashToCurve does.
+func (c *Curve[P]) TryEncodeToCurve(input, dst []byte) (P, error) {
+	return c.curve.tryEncodeXMD(input, dst)
+}
+
+// TryMapToCurve is MapToCurve returning hash2curve.ErrNonCanonical instead of panicking if fe is not a canonical field
+// element, or an error wrapping hash2curve.ErrInvalidPoint if the point implementation rejects the mapped point.
+func (c *Curve[P]) TryMapToCurve(fe *big.Int) (P, error) {
+	return c.curve.tryMapToCurve(fe)
+}
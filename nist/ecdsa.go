@@ -0,0 +1,147 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+package nist
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"filippo.io/nistec"
+
+	"github.com/bytemare/hash2curve"
+)
+
+// maxDerivationAttempts bounds the number of candidate scalars of the ECDSA key derivation.
+const maxDerivationAttempts = 256
+
+// ErrKeyDerivation indicates that no valid private key could be derived, which happens with negligible probability.
+var ErrKeyDerivation = errors.New("could not derive a non-zero private key")
+
+// DeriveECDSAPrivateKeyP256 deterministically derives a P-256 ECDSA private key from the input, e.g. a seed, and dst
+// with HashToScalarP256. If the scalar is zero, the derivation is retried with a one-byte counter from 1 to 255
+// appended to the input, and ErrKeyDerivation is returned if all attempts fail.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func DeriveECDSAPrivateKeyP256(input, dst []byte) (*ecdsa.PrivateKey, error) {
+	return deriveECDSA(elliptic.P256(), nistec.NewP256Point, HashToScalarP256, input, dst)
+}
+
+// DeriveECDSAPrivateKeyP384 deterministically derives a P-384 ECDSA private key from the input, e.g. a seed, and dst
+// with HashToScalarP384. If the scalar is zero, the derivation is retried with a one-byte counter from 1 to 255
+// appended to the input, and ErrKeyDerivation is returned if all attempts fail.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func DeriveECDSAPrivateKeyP384(input, dst []byte) (*ecdsa.PrivateKey, error) {
+	return deriveECDSA(elliptic.P384(), nistec.NewP384Point, HashToScalarP384, input, dst)
+}
+
+// DeriveECDSAPrivateKeyP521 deterministically derives a P-521 ECDSA private key from the input, e.g. a seed, and dst
+// with HashToScalarP521. If the scalar is zero, the derivation is retried with a one-byte counter from 1 to 255
+// appended to the input, and ErrKeyDerivation is returned if all attempts fail.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func DeriveECDSAPrivateKeyP521(input, dst []byte) (*ecdsa.PrivateKey, error) {
+	return deriveECDSA(elliptic.P521(), nistec.NewP521Point, HashToScalarP521, input, dst)
+}
+
+// HashToP256PublicKey implements hash-to-curve mapping to NIST P-256 of input with dst, and returns the point as a
+// P-256 ECDSA public key, e.g. for crypto/x509 or JOSE libraries. It returns an error wrapping
+// hash2curve.ErrInvalidPoint if the point is the point at infinity, which is not a valid public key.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func HashToP256PublicKey(input, dst []byte) (*ecdsa.PublicKey, error) {
+	return publicKey(elliptic.P256(), HashToP256(input, dst).Bytes())
+}
+
+// EncodeToP256PublicKey implements encode-to-curve mapping to NIST P-256 of input with dst, and returns the point as
+// a P-256 ECDSA public key, as HashToP256PublicKey does.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func EncodeToP256PublicKey(input, dst []byte) (*ecdsa.PublicKey, error) {
+	return publicKey(elliptic.P256(), EncodeToP256(input, dst).Bytes())
+}
+
+// HashToP384PublicKey implements hash-to-curve mapping to NIST P-384 of input with dst, and returns the point as a
+// P-384 ECDSA public key, as HashToP256PublicKey does.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func HashToP384PublicKey(input, dst []byte) (*ecdsa.PublicKey, error) {
+	return publicKey(elliptic.P384(), HashToP384(input, dst).Bytes())
+}
+
+// EncodeToP384PublicKey implements encode-to-curve mapping to NIST P-384 of input with dst, and returns the point as
+// a P-384 ECDSA public key, as HashToP256PublicKey does.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func EncodeToP384PublicKey(input, dst []byte) (*ecdsa.PublicKey, error) {
+	return publicKey(elliptic.P384(), EncodeToP384(input, dst).Bytes())
+}
+
+// HashToP521PublicKey implements hash-to-curve mapping to NIST P-521 of input with dst, and returns the point as a
+// P-521 ECDSA public key, as HashToP256PublicKey does.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func HashToP521PublicKey(input, dst []byte) (*ecdsa.PublicKey, error) {
+	return publicKey(elliptic.P521(), HashToP521(input, dst).Bytes())
+}
+
+// EncodeToP521PublicKey implements encode-to-curve mapping to NIST P-521 of input with dst, and returns the point as
+// a P-521 ECDSA public key, as HashToP256PublicKey does.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func EncodeToP521PublicKey(input, dst []byte) (*ecdsa.PublicKey, error) {
+	return publicKey(elliptic.P521(), EncodeToP521(input, dst).Bytes())
+}
+
+// publicKey returns the ECDSA public key of the uncompressed SEC 1 encoding of a point on curve.
+func publicKey(curve elliptic.Curve, encoding []byte) (*ecdsa.PublicKey, error) {
+	if len(encoding) == 1 {
+		return nil, fmt.Errorf("%w: the point at infinity is not a valid public key", hash2curve.ErrInvalidPoint)
+	}
+
+	x, y := affineCoordinates(encoding)
+
+	return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+}
+
+type baseMultiplier[P any] interface {
+	EncodedPoint
+	ScalarBaseMult(scalar []byte) (P, error)
+}
+
+func deriveECDSA[P baseMultiplier[P]](
+	curve elliptic.Curve,
+	newPoint func() P,
+	hashToScalar func(input, dst []byte, opts ...ScalarOption) *big.Int,
+	input, dst []byte,
+) (*ecdsa.PrivateKey, error) {
+	byteLen := (curve.Params().N.BitLen() + 7) / 8
+	candidate := append(make([]byte, 0, len(input)+1), input...)
+
+	for counter := range maxDerivationAttempts {
+		if counter > 0 {
+			candidate = append(candidate[:len(input)], byte(counter))
+		}
+
+		d := hashToScalar(candidate, dst)
+		if d.Sign() == 0 {
+			continue
+		}
+
+		p, err := newPoint().ScalarBaseMult(d.FillBytes(make([]byte, byteLen)))
+		if err != nil {
+			return nil, err
+		}
+
+		pub, err := publicKey(curve, p.Bytes())
+		if err != nil {
+			return nil, err
+		}
+
+		return &ecdsa.PrivateKey{PublicKey: *pub, D: d}, nil
+	}
+
+	return nil, ErrKeyDerivation
+}
@@ -0,0 +1,77 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+package nist
+
+import (
+	"crypto/elliptic"
+	"math/big"
+)
+
+// The helpers in this file return affine coordinates to be used with the curves of crypto/elliptic, for code bases
+// that have not yet migrated to filippo.io/nistec or crypto/ecdh. The point at infinity is returned as (0, 0), as in
+// crypto/elliptic.
+
+// HashToP256Elliptic implements hash-to-curve mapping to NIST P-256 of input with dst, and returns the affine
+// coordinates of the point on elliptic.P256().
+func HashToP256Elliptic(input, dst []byte) (x, y *big.Int) {
+	return affineCoordinates(HashToP256(input, dst).Bytes())
+}
+
+// EncodeToP256Elliptic implements encode-to-curve mapping to NIST P-256 of input with dst, and returns the affine
+// coordinates of the point on elliptic.P256().
+func EncodeToP256Elliptic(input, dst []byte) (x, y *big.Int) {
+	return affineCoordinates(EncodeToP256(input, dst).Bytes())
+}
+
+// HashToP384Elliptic implements hash-to-curve mapping to NIST P-384 of input with dst, and returns the affine
+// coordinates of the point on elliptic.P384().
+func HashToP384Elliptic(input, dst []byte) (x, y *big.Int) {
+	return affineCoordinates(HashToP384(input, dst).Bytes())
+}
+
+// EncodeToP384Elliptic implements encode-to-curve mapping to NIST P-384 of input with dst, and returns the affine
+// coordinates of the point on elliptic.P384().
+func EncodeToP384Elliptic(input, dst []byte) (x, y *big.Int) {
+	return affineCoordinates(EncodeToP384(input, dst).Bytes())
+}
+
+// HashToP521Elliptic implements hash-to-curve mapping to NIST P-521 of input with dst, and returns the affine
+// coordinates of the point on elliptic.P521().
+func HashToP521Elliptic(input, dst []byte) (x, y *big.Int) {
+	return affineCoordinates(HashToP521(input, dst).Bytes())
+}
+
+// EncodeToP521Elliptic implements encode-to-curve mapping to NIST P-521 of input with dst, and returns the affine
+// coordinates of the point on elliptic.P521().
+func EncodeToP521Elliptic(input, dst []byte) (x, y *big.Int) {
+	return affineCoordinates(EncodeToP521(input, dst).Bytes())
+}
+
+// MarshalCompressed returns the SEC 1 compressed encoding of the point (x, y) on curve, as elliptic.MarshalCompressed
+// does, except for the point at infinity (0, 0), which is encoded as the single byte 0x00, as in filippo.io/nistec.
+func MarshalCompressed(curve elliptic.Curve, x, y *big.Int) []byte {
+	if x.Sign() == 0 && y.Sign() == 0 {
+		return []byte{0}
+	}
+
+	return elliptic.MarshalCompressed(curve, x, y)
+}
+
+// affineCoordinates decodes the uncompressed SEC 1 encoding of a point, returning (0, 0) for the point at infinity.
+func affineCoordinates(encoding []byte) (x, y *big.Int) {
+	if len(encoding) == 1 {
+		return new(big.Int), new(big.Int)
+	}
+
+	byteLen := (len(encoding) - 1) / 2
+
+	return new(big.Int).SetBytes(encoding[1 : 1+byteLen]), new(big.Int).SetBytes(encoding[1+byteLen:])
+}
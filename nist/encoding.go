@@ -0,0 +1,59 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+package nist
+
+// EncodedPoint is a filippo.io/nistec point, which has both SEC 1 encodings.
+type EncodedPoint interface {
+	Bytes() []byte
+	BytesCompressed() []byte
+}
+
+// Encodings holds the encodings and the affine coordinates of a point.
+type Encodings struct {
+	// Compressed is the SEC 1 compressed encoding, or the single byte 0x00 for the identity.
+	Compressed []byte
+
+	// Uncompressed is the SEC 1 uncompressed encoding, or the single byte 0x00 for the identity.
+	Uncompressed []byte
+
+	// X and Y are the fixed-width big-endian affine coordinates, and are nil for the identity.
+	X, Y []byte
+
+	// Identity is whether the point is the point at infinity.
+	Identity bool
+}
+
+// Encode returns the encodings and affine coordinates of the point, e.g. as returned by HashToP256.
+func Encode[P EncodedPoint](p P) Encodings {
+	uncompressed := p.Bytes()
+
+	if len(uncompressed) == 1 {
+		return Encodings{
+			Compressed:   uncompressed,
+			Uncompressed: uncompressed,
+			Identity:     true,
+		}
+	}
+
+	byteLen := (len(uncompressed) - 1) / 2
+
+	return Encodings{
+		Compressed:   p.BytesCompressed(),
+		Uncompressed: uncompressed,
+		X:            uncompressed[1 : 1+byteLen : 1+byteLen],
+		Y:            uncompressed[1+byteLen:],
+	}
+}
+
+// IsIdentity returns whether the point is the point at infinity.
+func IsIdentity[P EncodedPoint](p P) bool {
+	return len(p.Bytes()) == 1
+}
@@ -0,0 +1,151 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build ignore
+
+// This program generates constants.go, holding the precomputed limbs of the P-256, P-384, and P-521 constants and of
+// the values derived from them. Run it with go generate.
+package main
+
+import (
+	"bytes"
+	"crypto/elliptic"
+	"fmt"
+	"go/format"
+	"log"
+	"math/big"
+	"os"
+	"strings"
+)
+
+const header = `// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Code generated by gen_constants.go. DO NOT EDIT.
+
+//go:build !nomathbig
+
+package nist
+
+import "github.com/bytemare/hash2curve/internal/field"
+
+// The constants of the NIST curves, and those derived from them for the field, sqrt_ratio, and the reduction of
+// hash_to_field, as little-endian 64-bit limbs.
+var (
+`
+
+// curve holds the parameters of a curve, taken from crypto/elliptic, and its Z and L of RFC 9380 section 8.2.
+type curve struct {
+	name      string
+	params    *elliptic.CurveParams
+	z         int64
+	secLength uint
+}
+
+type constant struct {
+	name, comment string
+	value         *big.Int
+}
+
+func constants(c curve) []constant {
+	p := c.params.P
+	one, two, three := big.NewInt(1), big.NewInt(2), big.NewInt(3)
+
+	if p.Bit(0) != 1 || p.Bit(1) != 1 {
+		log.Fatalf("%s: p is not 3 mod 4", c.name)
+	}
+
+	z := new(big.Int).Mod(big.NewInt(c.z), p)
+	if big.Jacobi(z, p) != -1 {
+		log.Fatalf("%s: Z is a square", c.name)
+	}
+
+	// sqrt(-Z) = (-Z)^((p+1)/4), since p = 3 mod 4.
+	exp := new(big.Int).Add(p, one)
+	exp.Rsh(exp, 2)
+	negZ := new(big.Int).Neg(big.NewInt(c.z))
+	c2 := new(big.Int).Exp(negZ, exp, p)
+
+	if new(big.Int).Exp(c2, two, p).Cmp(negZ) != 0 {
+		log.Fatalf("%s: -Z is not a square", c.name)
+	}
+
+	// mu = floor(2^(8 * L) / p), for the Barrett reduction of the L bytes of hash_to_field.
+	mu := new(big.Int).Lsh(one, 8*c.secLength)
+	mu.Quo(mu, p)
+
+	return []constant{
+		{"prime", "p", p},
+		{"pMinus1div2", "(p - 1) / 2", new(big.Int).Rsh(p, 1)},
+		{"pMinus2", "p - 2", new(big.Int).Sub(p, two)},
+		{"exp", "(p + 1) / 4", exp},
+		{"a", "A = -3", new(big.Int).Sub(p, three)},
+		{"b", "B", c.params.B},
+		{"order", "the group order n", c.params.N},
+		{"z", fmt.Sprintf("Z = %d", c.z), z},
+		{"c1", "(p - 3) / 4", new(big.Int).Rsh(p, 2)},
+		{"c2", "sqrt(-Z)", c2},
+		{"mu", fmt.Sprintf("floor(2^(8 * %d) / p)", c.secLength), mu},
+	}
+}
+
+func limbs(v *big.Int) []string {
+	var l []string
+
+	mask := new(big.Int).SetUint64(^uint64(0))
+	for i := 0; i == 0 || i < v.BitLen(); i += 64 {
+		l = append(l, fmt.Sprintf("%#016x", new(big.Int).And(new(big.Int).Rsh(v, uint(i)), mask).Uint64()))
+	}
+
+	return l
+}
+
+func main() {
+	var buf bytes.Buffer
+
+	buf.WriteString(header)
+
+	for _, c := range []curve{
+		{"p256", elliptic.P256().Params(), -10, 48},
+		{"p384", elliptic.P384().Params(), -12, 72},
+		{"p521", elliptic.P521().Params(), -4, 98},
+	} {
+		fmt.Fprintf(&buf, "\t%sConstants = curveConstants{\n", c.name)
+
+		for _, k := range constants(c) {
+			fmt.Fprintf(&buf, "\t\t// %s.\n\t\t%s: field.IntFromLimbs(\n", k.comment, k.name)
+
+			l := limbs(k.value)
+			for len(l) > 0 {
+				n := min(len(l), 4)
+				fmt.Fprintf(&buf, "\t\t\t%s,\n", strings.Join(l[:n], ", "))
+				l = l[n:]
+			}
+
+			buf.WriteString("\t\t),\n")
+		}
+
+		fmt.Fprintf(&buf, "\t\tsecLength: %d,\n\t}\n", c.secLength)
+	}
+
+	buf.WriteString(")\n")
+
+	out, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err = os.WriteFile("constants.go", out, 0o600); err != nil {
+		log.Fatal(err)
+	}
+}
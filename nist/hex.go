@@ -0,0 +1,84 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+package nist
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"filippo.io/nistec"
+
+	"github.com/bytemare/hash2curve"
+)
+
+// HashToP256Hex returns the hex-encoded SEC 1 compressed encoding of HashToP256(input, dst).
+func HashToP256Hex(input, dst []byte) string {
+	return hex.EncodeToString(HashToP256(input, dst).BytesCompressed())
+}
+
+// EncodeToP256Hex returns the hex-encoded SEC 1 compressed encoding of EncodeToP256(input, dst).
+func EncodeToP256Hex(input, dst []byte) string {
+	return hex.EncodeToString(EncodeToP256(input, dst).BytesCompressed())
+}
+
+// DecodeP256Hex decodes a hex-encoded SEC 1 encoding of a P-256 point, and returns an error wrapping
+// hash2curve.ErrInvalidPoint if it is not valid hex or not a valid point.
+func DecodeP256Hex(s string) (*nistec.P256Point, error) {
+	return decodeHex(s, nistec.NewP256Point)
+}
+
+// HashToP384Hex returns the hex-encoded SEC 1 compressed encoding of HashToP384(input, dst).
+func HashToP384Hex(input, dst []byte) string {
+	return hex.EncodeToString(HashToP384(input, dst).BytesCompressed())
+}
+
+// EncodeToP384Hex returns the hex-encoded SEC 1 compressed encoding of EncodeToP384(input, dst).
+func EncodeToP384Hex(input, dst []byte) string {
+	return hex.EncodeToString(EncodeToP384(input, dst).BytesCompressed())
+}
+
+// DecodeP384Hex decodes a hex-encoded SEC 1 encoding of a P-384 point, and returns an error wrapping
+// hash2curve.ErrInvalidPoint if it is not valid hex or not a valid point.
+func DecodeP384Hex(s string) (*nistec.P384Point, error) {
+	return decodeHex(s, nistec.NewP384Point)
+}
+
+// HashToP521Hex returns the hex-encoded SEC 1 compressed encoding of HashToP521(input, dst).
+func HashToP521Hex(input, dst []byte) string {
+	return hex.EncodeToString(HashToP521(input, dst).BytesCompressed())
+}
+
+// EncodeToP521Hex returns the hex-encoded SEC 1 compressed encoding of EncodeToP521(input, dst).
+func EncodeToP521Hex(input, dst []byte) string {
+	return hex.EncodeToString(EncodeToP521(input, dst).BytesCompressed())
+}
+
+// DecodeP521Hex decodes a hex-encoded SEC 1 encoding of a P-521 point, and returns an error wrapping
+// hash2curve.ErrInvalidPoint if it is not valid hex or not a valid point.
+func DecodeP521Hex(s string) (*nistec.P521Point, error) {
+	return decodeHex(s, nistec.NewP521Point)
+}
+
+func decodeHex[P Point[P]](s string, newPoint func() P) (P, error) {
+	var p P
+
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return p, fmt.Errorf("%w: %w", hash2curve.ErrInvalidPoint, err)
+	}
+
+	p, err = newPoint().SetBytes(b)
+	if err != nil {
+		return p, fmt.Errorf("%w: %w", hash2curve.ErrInvalidPoint, err)
+	}
+
+	return p, nil
+}
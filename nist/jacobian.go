@@ -0,0 +1,238 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+package nist
+
+import (
+	"math/big"
+
+	"github.com/bytemare/hash2curve"
+)
+
+// JacobianPoint is a point in Jacobian coordinates, i.e. the affine point (X / Z^2, Y / Z^3), or the point at infinity
+// if Z is 0. The coordinates are reduced modulo the field prime.
+//
+// The Jacobian outputs skip the conversion to affine coordinates and the SEC 1 round-trip of the point outputs, for
+// callers that continue with their own arithmetic. They are not constant-time.
+type JacobianPoint struct {
+	X, Y, Z big.Int
+}
+
+// IsIdentity returns whether p is the point at infinity.
+func (p *JacobianPoint) IsIdentity() bool {
+	return p.Z.Sign() == 0
+}
+
+// HashToP256Jacobian is HashToP256 returning the point in Jacobian coordinates.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func HashToP256Jacobian(input, dst []byte) *JacobianPoint {
+	return p256.hashXMDJacobian(input, dst)
+}
+
+// EncodeToP256Jacobian is EncodeToP256 returning the point in Jacobian coordinates.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func EncodeToP256Jacobian(input, dst []byte) *JacobianPoint {
+	return p256.encodeXMDJacobian(input, dst)
+}
+
+// HashToP384Jacobian is HashToP384 returning the point in Jacobian coordinates.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func HashToP384Jacobian(input, dst []byte) *JacobianPoint {
+	return p384.hashXMDJacobian(input, dst)
+}
+
+// EncodeToP384Jacobian is EncodeToP384 returning the point in Jacobian coordinates.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func EncodeToP384Jacobian(input, dst []byte) *JacobianPoint {
+	return p384.encodeXMDJacobian(input, dst)
+}
+
+// HashToP521Jacobian is HashToP521 returning the point in Jacobian coordinates.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func HashToP521Jacobian(input, dst []byte) *JacobianPoint {
+	return p521.hashXMDJacobian(input, dst)
+}
+
+// EncodeToP521Jacobian is EncodeToP521 returning the point in Jacobian coordinates.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func EncodeToP521Jacobian(input, dst []byte) *JacobianPoint {
+	return p521.encodeXMDJacobian(input, dst)
+}
+
+// HashToCurveJacobian is HashToCurve returning the point in Jacobian coordinates, without using the point
+// implementation.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func (c *Curve[P]) HashToCurveJacobian(input, dst []byte) *JacobianPoint {
+	return c.curve.hashXMDJacobian(input, dst)
+}
+
+// EncodeToCurveJacobian is EncodeToCurve returning the point in Jacobian coordinates, without using the point
+// implementation.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func (c *Curve[P]) EncodeToCurveJacobian(input, dst []byte) *JacobianPoint {
+	return c.curve.encodeXMDJacobian(input, dst)
+}
+
+func (c *nistCurve[point]) encodeXMDJacobian(input, dst []byte) *JacobianPoint {
+	u := hash2curve.HashToFieldXMD(c.hash, input, dst, 1, 1, c.secLength, c.field.Order())
+	// We can save cofactor clearing because it is 1.
+	return c.map2curveJacobian(u[0])
+}
+
+func (c *nistCurve[point]) hashXMDJacobian(input, dst []byte) *JacobianPoint {
+	u := hash2curve.HashToFieldXMD(c.hash, input, dst, 2, 1, c.secLength, c.field.Order())
+
+	var q0, q1 *JacobianPoint
+
+	if c.parallel || parallelMapping.Load() {
+		done := make(chan struct{})
+
+		go func() {
+			q1 = c.map2curveJacobian(u[1])
+
+			close(done)
+		}()
+
+		q0 = c.map2curveJacobian(u[0])
+		<-done
+	} else {
+		q0 = c.map2curveJacobian(u[0])
+		q1 = c.map2curveJacobian(u[1])
+	}
+
+	// We can save cofactor clearing because it is 1.
+	return c.addJacobian(q0, q1)
+}
+
+func (c *nistCurve[point]) map2curveJacobian(fe *big.Int) *JacobianPoint {
+	x, y, z := c.sswu.MapJacobian(fe)
+
+	p := new(JacobianPoint)
+	p.X.Set(x)
+	p.Y.Set(y)
+	p.Z.Set(z)
+
+	return p
+}
+
+// addJacobian returns p + q, with the add-2007-bl formulas.
+func (c *nistCurve[point]) addJacobian(p, q *JacobianPoint) *JacobianPoint {
+	switch {
+	case p.IsIdentity():
+		return q
+	case q.IsIdentity():
+		return p
+	}
+
+	f := &c.field
+
+	var z1z1, z2z2, u1, u2, s1, s2, h, i, j, rr, v big.Int
+
+	f.Square(&z1z1, &p.Z) // Z1Z1 = Z1^2
+	f.Square(&z2z2, &q.Z) // Z2Z2 = Z2^2
+	f.Mul(&u1, &p.X, &z2z2)
+	f.Mul(&u2, &q.X, &z1z1)
+	f.Mul(&s1, &p.Y, &q.Z)
+	f.Mul(&s1, &s1, &z2z2) // S1 = Y1 * Z2 * Z2Z2
+	f.Mul(&s2, &q.Y, &p.Z)
+	f.Mul(&s2, &s2, &z1z1) // S2 = Y2 * Z1 * Z1Z1
+	f.Sub(&h, &u2, &u1)    // H = U2 - U1
+	f.Sub(&rr, &s2, &s1)
+	f.Add(&rr, &rr, &rr) // r = 2 * (S2 - S1)
+
+	if f.IsZero(&h) {
+		if f.IsZero(&rr) {
+			return c.doubleJacobian(p)
+		}
+
+		return new(JacobianPoint)
+	}
+
+	r := new(JacobianPoint)
+
+	f.Add(&i, &h, &h)
+	f.Square(&i, &i) // I = (2 * H)^2
+	f.Mul(&j, &h, &i)
+	f.Mul(&v, &u1, &i) // V = U1 * I
+
+	// X3 = r^2 - J - 2 * V
+	f.Square(&r.X, &rr)
+	f.Sub(&r.X, &r.X, &j)
+	f.Sub(&r.X, &r.X, &v)
+	f.Sub(&r.X, &r.X, &v)
+
+	// Y3 = r * (V - X3) - 2 * S1 * J
+	f.Sub(&v, &v, &r.X)
+	f.Mul(&r.Y, &rr, &v)
+	f.Mul(&j, &j, &s1)
+	f.Add(&j, &j, &j)
+	f.Sub(&r.Y, &r.Y, &j)
+
+	// Z3 = ((Z1 + Z2)^2 - Z1Z1 - Z2Z2) * H
+	f.Add(&r.Z, &p.Z, &q.Z)
+	f.Square(&r.Z, &r.Z)
+	f.Sub(&r.Z, &r.Z, &z1z1)
+	f.Sub(&r.Z, &r.Z, &z2z2)
+	f.Mul(&r.Z, &r.Z, &h)
+
+	return r
+}
+
+// doubleJacobian returns 2 * p, with the dbl-2007-bl formulas for any A.
+func (c *nistCurve[point]) doubleJacobian(p *JacobianPoint) *JacobianPoint {
+	f := &c.field
+	if p.IsIdentity() || f.IsZero(&p.Y) {
+		return new(JacobianPoint)
+	}
+
+	var xx, yy, yyyy, zz, s, m, tv big.Int
+
+	r := new(JacobianPoint)
+
+	f.Square(&xx, &p.X)
+	f.Square(&yy, &p.Y)
+	f.Square(&yyyy, &yy)
+	f.Square(&zz, &p.Z)
+
+	// S = 2 * ((X + YY)^2 - XX - YYYY)
+	f.Add(&s, &p.X, &yy)
+	f.Square(&s, &s)
+	f.Sub(&s, &s, &xx)
+	f.Sub(&s, &s, &yyyy)
+	f.Add(&s, &s, &s)
+
+	// M = 3 * XX + A * ZZ^2
+	f.Add(&m, &xx, &xx)
+	f.Add(&m, &m, &xx)
+	f.Square(&tv, &zz)
+	f.Mul(&tv, &tv, &c.a)
+	f.Add(&m, &m, &tv)
+
+	// X3 = M^2 - 2 * S
+	f.Square(&r.X, &m)
+	f.Sub(&r.X, &r.X, &s)
+	f.Sub(&r.X, &r.X, &s)
+
+	// Y3 = M * (S - X3) - 8 * YYYY
+	f.Sub(&tv, &s, &r.X)
+	f.Mul(&r.Y, &m, &tv)
+	f.Add(&yyyy, &yyyy, &yyyy)
+	f.Add(&yyyy, &yyyy, &yyyy)
+	f.Add(&yyyy, &yyyy, &yyyy)
+	f.Sub(&r.Y, &r.Y, &yyyy)
+
+	// Z3 = (Y + Z)^2 - YY - ZZ
+	f.Add(&r.Z, &p.Y, &p.Z)
+	f.Square(&r.Z, &r.Z)
+	f.Sub(&r.Z, &r.Z, &yy)
+	f.Sub(&r.Z, &r.Z, &zz)
+
+	return r
+}
@@ -6,13 +6,19 @@
 // LICENSE file in the root directory of this source tree or at
 // https://spdx.org/licenses/MIT.html
 
+//go:build !nomathbig
+
 // Package nist implements RFC9380 for the NIST P-256, P-384, P-521 groups, and returns points from filippo.io/nistec.
 package nist
 
+//go:generate go run gen_constants.go
+
 import (
 	"crypto"
+	"errors"
+	"fmt"
 	"math/big"
-	"sync"
+	"sync/atomic"
 
 	"filippo.io/nistec"
 
@@ -44,63 +50,217 @@ const (
 // HashToP256 implements hash-to-curve mapping to NIST P-256 of input with dst.
 // The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
 func HashToP256(input, dst []byte) *nistec.P256Point {
-	initOnceP256.Do(initP256)
-	return p256.hashXMD(input, dst)
+	return must(p256.hashXMD(input, dst))
 }
 
 // EncodeToP256 implements encode-to-curve mapping to NIST P-256 of input with dst.
 // The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
 func EncodeToP256(input, dst []byte) *nistec.P256Point {
-	initOnceP256.Do(initP256)
-	return p256.encodeXMD(input, dst)
+	return must(p256.encodeXMD(input, dst))
 }
 
 // HashToScalarP256 returns a safe mapping of the arbitrary input to a scalar for the NIST P-256 group.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes. The hash function and expansion
+// length default to those of the P256 suite, and can be overridden with options.
+func HashToScalarP256(input, dst []byte, opts ...ScalarOption) *big.Int {
+	return p256.hashToScalar(input, dst, opts)
+}
+
+// HashToScalarsP256 returns count scalars for NIST P-256 from one expansion, with the options of HashToScalarP256.
 // The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
-func HashToScalarP256(input, dst []byte) *big.Int {
-	initOnceP256.Do(initP256)
-	return hash2curve.HashToFieldXMD(p256.hash, input, dst, 1, 1, p256.secLength, &p256.groupOrder)[0]
+func HashToScalarsP256(input, dst []byte, count uint, opts ...ScalarOption) []*big.Int {
+	return p256.hashToScalars(input, dst, count, opts)
 }
 
 // HashToP384 implements hash-to-curve mapping to NIST P-384 of input with dst.
 // The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
 func HashToP384(input, dst []byte) *nistec.P384Point {
-	initOnceP384.Do(initP384)
-	return p384.hashXMD(input, dst)
+	return must(p384.hashXMD(input, dst))
 }
 
 // EncodeToP384 implements encode-to-curve mapping to NIST P-384 of input with dst.
 // The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
 func EncodeToP384(input, dst []byte) *nistec.P384Point {
-	initOnceP384.Do(initP384)
-	return p384.encodeXMD(input, dst)
+	return must(p384.encodeXMD(input, dst))
 }
 
 // HashToScalarP384 returns a safe mapping of the arbitrary input to a scalar for the NIST P-384 group.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes. The hash function and expansion
+// length default to those of the P384 suite, and can be overridden with options.
+func HashToScalarP384(input, dst []byte, opts ...ScalarOption) *big.Int {
+	return p384.hashToScalar(input, dst, opts)
+}
+
+// HashToScalarsP384 returns count scalars for NIST P-384 from one expansion, with the options of HashToScalarP384.
 // The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
-func HashToScalarP384(input, dst []byte) *big.Int {
-	initOnceP384.Do(initP384)
-	return hash2curve.HashToFieldXMD(p384.hash, input, dst, 1, 1, p384.secLength, &p384.groupOrder)[0]
+func HashToScalarsP384(input, dst []byte, count uint, opts ...ScalarOption) []*big.Int {
+	return p384.hashToScalars(input, dst, count, opts)
 }
 
 // HashToP521 implements hash-to-curve mapping to NIST P-521 of input with dst.
 // The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
 func HashToP521(input, dst []byte) *nistec.P521Point {
-	initOnceP521.Do(initP521)
-	return p521.hashXMD(input, dst)
+	return must(p521.hashXMD(input, dst))
 }
 
 // EncodeToP521 implements encode-to-curve mapping to NIST P-521 of input with dst.
 // The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
 func EncodeToP521(input, dst []byte) *nistec.P521Point {
-	initOnceP521.Do(initP521)
-	return p521.encodeXMD(input, dst)
+	return must(p521.encodeXMD(input, dst))
 }
 
 // HashToScalarP521 returns a safe mapping of the arbitrary input to a scalar for the NIST P-521 group.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes. The hash function and expansion
+// length default to those of the P521 suite, and can be overridden with options.
+func HashToScalarP521(input, dst []byte, opts ...ScalarOption) *big.Int {
+	return p521.hashToScalar(input, dst, opts)
+}
+
+// HashToScalarsP521 returns count scalars for NIST P-521 from one expansion, with the options of HashToScalarP521.
 // The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
-func HashToScalarP521(input, dst []byte) *big.Int {
-	return hash2curve.HashToFieldXMD(p521.hash, input, dst, 1, 1, p521.secLength, &p521.groupOrder)[0]
+func HashToScalarsP521(input, dst []byte, count uint, opts ...ScalarOption) []*big.Int {
+	return p521.hashToScalars(input, dst, count, opts)
+}
+
+// MapToCurveP256 implements the map_to_curve function for NIST P-256, mapping the field element to a curve point.
+func MapToCurveP256(fe *big.Int) *nistec.P256Point {
+	p256.checkCanonical(fe)
+
+	return must(p256.map2curve(fe))
+}
+
+// MapToCurveP384 implements the map_to_curve function for NIST P-384, mapping the field element to a curve point.
+func MapToCurveP384(fe *big.Int) *nistec.P384Point {
+	p384.checkCanonical(fe)
+
+	return must(p384.map2curve(fe))
+}
+
+// MapToCurveP521 implements the map_to_curve function for NIST P-521, mapping the field element to a curve point.
+func MapToCurveP521(fe *big.Int) *nistec.P521Point {
+	p521.checkCanonical(fe)
+
+	return must(p521.map2curve(fe))
+}
+
+// TryHashToP256 is HashToP256 returning an error instead of panicking, e.g. when serving untrusted input: the errors
+// of hash2curve.ValidateDST and hash2curve.ValidateInput for input and dst, or one wrapping hash2curve.ErrInvalidPoint
+// if mapping failed, which the Simplified SWU map rules out for the built-in curves.
+func TryHashToP256(input, dst []byte) (*nistec.P256Point, error) {
+	return p256.tryHashXMD(input, dst)
+}
+
+// TryEncodeToP256 is EncodeToP256 returning an error instead of panicking, as TryHashToP256 does.
+func TryEncodeToP256(input, dst []byte) (*nistec.P256Point, error) {
+	return p256.tryEncodeXMD(input, dst)
+}
+
+// TryMapToCurveP256 is MapToCurveP256 returning hash2curve.ErrNonCanonical instead of panicking if fe is not a
+// canonical field element, or an error wrapping hash2curve.ErrInvalidPoint if mapping failed.
+func TryMapToCurveP256(fe *big.Int) (*nistec.P256Point, error) {
+	return p256.tryMapToCurve(fe)
+}
+
+// TryHashToP384 is HashToP384 returning an error instead of panicking, e.g. when serving untrusted input: the errors
+// of hash2curve.ValidateDST and hash2curve.ValidateInput for input and dst, or one wrapping hash2curve.ErrInvalidPoint
+// if mapping failed, which the Simplified SWU map rules out for the built-in curves.
+func TryHashToP384(input, dst []byte) (*nistec.P384Point, error) {
+	return p384.tryHashXMD(input, dst)
+}
+
+// TryEncodeToP384 is EncodeToP384 returning an error instead of panicking, as TryHashToP384 does.
+func TryEncodeToP384(input, dst []byte) (*nistec.P384Point, error) {
+	return p384.tryEncodeXMD(input, dst)
+}
+
+// TryMapToCurveP384 is MapToCurveP384 returning hash2curve.ErrNonCanonical instead of panicking if fe is not a
+// canonical field element, or an error wrapping hash2curve.ErrInvalidPoint if mapping failed.
+func TryMapToCurveP384(fe *big.Int) (*nistec.P384Point, error) {
+	return p384.tryMapToCurve(fe)
+}
+
+// TryHashToP521 is HashToP521 returning an error instead of panicking, e.g. when serving untrusted input: the errors
+// of hash2curve.ValidateDST and hash2curve.ValidateInput for input and dst, or one wrapping hash2curve.ErrInvalidPoint
+// if mapping failed, which the Simplified SWU map rules out for the built-in curves.
+func TryHashToP521(input, dst []byte) (*nistec.P521Point, error) {
+	return p521.tryHashXMD(input, dst)
+}
+
+// TryEncodeToP521 is EncodeToP521 returning an error instead of panicking, as TryHashToP521 does.
+func TryEncodeToP521(input, dst []byte) (*nistec.P521Point, error) {
+	return p521.tryEncodeXMD(input, dst)
+}
+
+// TryMapToCurveP521 is MapToCurveP521 returning hash2curve.ErrNonCanonical instead of panicking if fe is not a
+// canonical field element, or an error wrapping hash2curve.ErrInvalidPoint if mapping failed.
+func TryMapToCurveP521(fe *big.Int) (*nistec.P521Point, error) {
+	return p521.tryMapToCurve(fe)
+}
+
+// OrderP256 returns a copy of the order of the NIST P-256 group, i.e. the modulus of HashToScalarP256.
+func OrderP256() *big.Int {
+	return new(big.Int).Set(&p256.groupOrder)
+}
+
+// FieldPrimeP256 returns a copy of the prime of the field of NIST P-256.
+func FieldPrimeP256() *big.Int {
+	return new(big.Int).Set(p256.field.Order())
+}
+
+// OrderP384 returns a copy of the order of the NIST P-384 group, i.e. the modulus of HashToScalarP384.
+func OrderP384() *big.Int {
+	return new(big.Int).Set(&p384.groupOrder)
+}
+
+// FieldPrimeP384 returns a copy of the prime of the field of NIST P-384.
+func FieldPrimeP384() *big.Int {
+	return new(big.Int).Set(p384.field.Order())
+}
+
+// OrderP521 returns a copy of the order of the NIST P-521 group, i.e. the modulus of HashToScalarP521.
+func OrderP521() *big.Int {
+	return new(big.Int).Set(&p521.groupOrder)
+}
+
+// FieldPrimeP521 returns a copy of the prime of the field of NIST P-521.
+func FieldPrimeP521() *big.Int {
+	return new(big.Int).Set(p521.field.Order())
+}
+
+// Cofactor returns the cofactor of the NIST curves, which is 1 since they have prime order.
+func Cofactor() *big.Int {
+	return big.NewInt(1)
+}
+
+// ScalarOption overrides a parameter of hash-to-scalar, for protocols that deviate from the suite defaults.
+type ScalarOption func(*mapping)
+
+// WithHash overrides the hash function used by expand_message_xmd in hash-to-scalar. With the strict security policy
+// of hash2curve.SetStrictSecurity, hash-to-scalar panics with an error wrapping hash2curve.ErrInsufficientSecurity if
+// h outputs fewer bits than twice the security level of the curve, i.e. 256 bits for P-256, 384 for P-384, and 512 for
+// P-521.
+func WithHash(h crypto.Hash) ScalarOption {
+	return func(m *mapping) {
+		m.hash = h
+	}
+}
+
+// WithSecurityLength overrides the expansion length L of hash-to-scalar, which must be at least
+// ceil((ceil(log2(n)) + 128) / 8) for the group order n. With the strict security policy of
+// hash2curve.SetStrictSecurity, it must be at least ceil((ceil(log2(n)) + k) / 8) for the security level k of the
+// curve, i.e. 128 bits for P-256, 192 for P-384, and 256 for P-521, or hash-to-scalar panics with an error wrapping
+// hash2curve.ErrInsufficientSecurity.
+func WithSecurityLength(length uint) ScalarOption {
+	return func(m *mapping) {
+		m.secLength = length
+	}
+}
+
+// SetParallelMapping enables or disables computing the two map_to_curve evaluations of hash-to-curve in parallel
+// goroutines, for all NIST curves. This can cut the latency of the random oracle suites at the cost of a goroutine per
+// call. P-521, where mapping is the most expensive, always maps in parallel.
+func SetParallelMapping(enabled bool) {
+	parallelMapping.Store(enabled)
 }
 
 /*
@@ -108,152 +268,226 @@ func HashToScalarP521(input, dst []byte) *big.Int {
 */
 
 var (
-	initOnceP256 sync.Once
-	initOnceP384 sync.Once
-	initOnceP521 sync.Once
+	p256 = newPrecomputedCurve(&p256Constants, nistec.NewP256Point, crypto.SHA256, 128, false)
+	p384 = newPrecomputedCurve(&p384Constants, nistec.NewP384Point, crypto.SHA384, 192, false)
+	p521 = newPrecomputedCurve(&p521Constants, nistec.NewP521Point, crypto.SHA512, 256, true)
 
-	p256 nistCurve[*nistec.P256Point]
-	p384 nistCurve[*nistec.P384Point]
-	p521 nistCurve[*nistec.P521Point]
+	// nistA is the a = -3 coefficient of the NIST curves.
+	nistA = big.NewInt(-3)
 
-	nistWa = big.NewInt(-3)
+	parallelMapping atomic.Bool
 )
 
-func initP256() {
-	primeP256 := new(big.Int).SetBytes([]byte{
-		255, 255, 255, 255, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 0,
-		0, 0, 0, 0, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255,
-	})
-	b := new(big.Int).SetBytes([]byte{
-		90, 198, 53, 216, 170, 58, 147, 231, 179, 235, 189, 85, 118, 152, 134, 188,
-		101, 29, 6, 176, 204, 83, 176, 246, 59, 206, 60, 62, 39, 210, 96, 75,
-	})
-	p256.groupOrder = *new(big.Int).SetBytes([]byte{
-		255, 255, 255, 255, 0, 0, 0, 0, 255, 255, 255, 255, 255, 255, 255, 255,
-		188, 230, 250, 173, 167, 23, 158, 132, 243, 185, 202, 194, 252, 99, 37, 81,
-	})
-
-	p256.setCurveParams(primeP256, b, nistec.NewP256Point)
-	p256.setMapping(crypto.SHA256, -10, 48)
-}
-
-func initP384() {
-	primeP384 := new(big.Int).SetBytes([]byte{
-		255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255,
-		255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 254, 255, 255,
-		255, 255, 0, 0, 0, 0, 0, 0, 0, 0, 255, 255, 255, 255,
-	})
-	b := new(big.Int).SetBytes([]byte{
-		179, 49, 47, 167, 226, 62, 231, 228, 152, 142, 5, 107, 227, 248, 45, 25,
-		24, 29, 156, 110, 254, 129, 65, 18, 3, 20, 8, 143, 80, 19, 135, 90, 198,
-		86, 57, 141, 138, 46, 209, 157, 42, 133, 200, 237, 211, 236, 42, 239,
-	})
-	p256.groupOrder = *new(big.Int).SetBytes([]byte{
-		255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255,
-		255, 255, 255, 255, 255, 255, 255, 199, 99, 77, 129, 244, 55, 45, 223, 88, 26,
-		13, 178, 72, 176, 167, 122, 236, 236, 25, 106, 204, 197, 41, 115,
-	})
-
-	p384.setCurveParams(primeP384, b, nistec.NewP384Point)
-	p384.setMapping(crypto.SHA384, -12, 72)
-}
-
-func initP521() {
-	primeP521 := new(big.Int).SetBytes([]byte{
-		1, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255,
-		255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255,
-		255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255,
-		255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255,
-	})
-	b := new(big.Int).SetBytes([]byte{
-		81, 149, 62, 185, 97, 142, 28, 154, 31, 146, 154, 33, 160, 182, 133, 64,
-		238, 162, 218, 114, 91, 153, 179, 21, 243, 184, 180, 137, 145, 142, 241, 9,
-		225, 86, 25, 57, 81, 236, 126, 147, 123, 22, 82, 192, 189, 59, 177, 191,
-		7, 53, 115, 223, 136, 61, 44, 52, 241, 239, 69, 31, 212, 107, 80, 63, 0,
-	})
-	p256.groupOrder = *new(big.Int).SetBytes([]byte{
-		1, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255,
-		255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 250,
-		81, 134, 135, 131, 191, 47, 150, 107, 127, 204, 1, 72, 247, 9, 165, 208, 59,
-		181, 201, 184, 137, 156, 71, 174, 187, 111, 183, 30, 145, 56, 100, 9,
-	})
-
-	p521.setCurveParams(primeP521, b, nistec.NewP521Point)
-	p521.setMapping(crypto.SHA512, -4, 98)
-}
-
-type nistECPoint[point any] interface {
-	Add(p1, p2 point) point
-	Bytes() []byte
-	SetBytes(b []byte) (point, error)
+// curveConstants holds the generated constants of a NIST curve, reduced, and those derived from them.
+type curveConstants struct {
+	prime, pMinus1div2, pMinus2, exp *big.Int // the field
+	a, b, order, z                   *big.Int // the curve, and Z of the Simplified SWU map
+	c1, c2                           *big.Int // the constants of sqrt_ratio
+	mu                               *big.Int // the Barrett constant of the reduction of hash_to_field
+	secLength                        uint
+}
+
+// newPrecomputedCurve returns the curve of the generated constants, without parsing nor computing any of them.
+func newPrecomputedCurve[point Point[point]](
+	c *curveConstants,
+	newPoint func() point,
+	hash crypto.Hash,
+	k uint,
+	parallel bool,
+) *nistCurve[point] {
+	curve := &nistCurve[point]{
+		groupOrder: *c.order,
+		field:      field.NewPrecomputedField(c.prime, c.pMinus1div2, c.pMinus2, c.exp),
+		a:          *c.a,
+		b:          *c.b,
+		newPoint:   newPoint,
+		reducer:    field.NewPrecomputedReducer(c.prime, c.mu, c.secLength),
+		mapping: mapping{
+			z:         *c.z,
+			hash:      hash,
+			secLength: c.secLength,
+			k:         k,
+			parallel:  parallel,
+		},
+	}
+	curve.sswu = internal.NewPrecomputedSSWU(&curve.field, c.a, c.b, c.z, c.c1, c.c2)
+	curve.mapper = curve.sswu
+
+	return curve
+}
+
+// mapper implements the Simplified SWU map to the affine coordinates of a point.
+type mapper interface {
+	Map(fe *big.Int) (x, y *big.Int)
 }
 
 type mapping struct {
 	z         big.Int
 	hash      crypto.Hash
 	secLength uint
+	k         uint // the target security level, in bits
+	parallel  bool
 }
 
-type nistCurve[point nistECPoint[point]] struct {
+type nistCurve[point Point[point]] struct {
 	groupOrder big.Int
 	field      field.Field
+	a          big.Int
 	b          big.Int
 	newPoint   func() point
+	reducer    *field.Reducer // the reducer of hash_to_field to the base field, for the batches
+	sswu       *internal.SSWU // the parameters of the Simplified SWU map, set with the mapping
+	mapper     mapper         // the map of map2curve, which is sswu unless the curve has a generated field
 	mapping
 }
 
-func (c *nistCurve[point]) setMapping(hash crypto.Hash, z int, secLength uint) {
+func (c *nistCurve[point]) setMapping(hash crypto.Hash, z int, secLength, k uint) {
 	c.mapping.hash = hash
 	c.mapping.secLength = secLength
-	c.mapping.z = *big.NewInt(int64(z))
+	c.mapping.k = k
+	// Z is stored reduced, since the constant-time selection in the field works on canonical encodings.
+	c.mapping.z = *c.field.Mod(big.NewInt(int64(z)))
+	c.reducer = field.NewReducer(c.field.Order(), secLength)
+	c.sswu = internal.NewSSWU(&c.field, &c.a, &c.b, &c.mapping.z)
+	c.mapper = c.sswu
 }
 
 func (c *nistCurve[point]) setCurveParams(prime, b *big.Int, newPoint func() point) {
 	c.field = field.NewField(prime)
+	c.a = *c.field.Mod(new(big.Int).Set(nistA))
 	c.b = *b
 	c.newPoint = newPoint
 }
 
-func (c *nistCurve[point]) encodeXMD(input, dst []byte) point {
+func (c *nistCurve[point]) encodeXMD(input, dst []byte) (point, error) {
 	u := hash2curve.HashToFieldXMD(c.hash, input, dst, 1, 1, c.secLength, c.field.Order())
-	q := c.map2curve(u[0])
 	// We can save cofactor clearing because it is 1.
-	return q
+	return c.map2curve(u[0])
 }
 
-func (c *nistCurve[point]) hashXMD(input, dst []byte) point {
+func (c *nistCurve[point]) hashXMD(input, dst []byte) (point, error) {
 	u := hash2curve.HashToFieldXMD(c.hash, input, dst, 2, 1, c.secLength, c.field.Order())
-	q0 := c.map2curve(u[0])
-	q1 := c.map2curve(u[1])
+
+	var (
+		q0, q1     point
+		err0, err1 error
+	)
+
+	if c.parallel || parallelMapping.Load() {
+		q0, q1, err0, err1 = c.map2curveParallel(u[0], u[1])
+	} else {
+		q0, err0 = c.map2curve(u[0])
+		q1, err1 = c.map2curve(u[1])
+	}
+
+	if err := errors.Join(err0, err1); err != nil {
+		return q0, err
+	}
 
 	// We can save cofactor clearing because it is 1.
-	return q0.Add(q0, q1)
+	return q0.Add(q0, q1), nil
+}
+
+// tryHashXMD is hashXMD returning an error instead of panicking on invalid input.
+func (c *nistCurve[point]) tryHashXMD(input, dst []byte) (point, error) {
+	if err := c.validateInput(input, dst, 2); err != nil {
+		var zero point
+		return zero, err
+	}
+
+	return c.hashXMD(input, dst)
+}
+
+// tryEncodeXMD is encodeXMD returning an error instead of panicking on invalid input.
+func (c *nistCurve[point]) tryEncodeXMD(input, dst []byte) (point, error) {
+	if err := c.validateInput(input, dst, 1); err != nil {
+		var zero point
+		return zero, err
+	}
+
+	return c.encodeXMD(input, dst)
 }
 
-func (c *nistCurve[point]) map2curve(fe *big.Int) point {
-	x, y := internal.MapToCurveSSWU(&c.field, nistWa, &c.b, &c.z, fe)
+// validateInput returns the error hash_to_field with count elements would panic with for the input and dst.
+func (c *nistCurve[point]) validateInput(input, dst []byte, count uint) error {
+	if err := hash2curve.ValidateDST(dst, false); err != nil {
+		return err
+	}
+
+	return hash2curve.ValidateInput(input, count*c.secLength)
+}
+
+// tryMapToCurve is map2curve returning ErrNonCanonical instead of panicking if fe is not a canonical field element.
+func (c *nistCurve[point]) tryMapToCurve(fe *big.Int) (point, error) {
+	if !c.field.IsCanonical(fe) {
+		var zero point
+		return zero, hash2curve.ErrNonCanonical
+	}
+
+	return c.map2curve(fe)
+}
+
+func (c *nistCurve[point]) hashToScalar(input, dst []byte, opts []ScalarOption) *big.Int {
+	return c.hashToScalars(input, dst, 1, opts)[0]
+}
+
+func (c *nistCurve[point]) hashToScalars(input, dst []byte, count uint, opts []ScalarOption) []*big.Int {
+	m := c.mapping
+	for _, opt := range opts {
+		opt(&m)
+	}
+
+	if len(opts) != 0 && hash2curve.StrictSecurity() {
+		if err := hash2curve.ValidateSecurityLevel(m.hash, m.secLength, &c.groupOrder, c.k); err != nil {
+			panic(err)
+		}
+	}
+
+	return hash2curve.HashToFieldXMD(m.hash, input, dst, count, 1, m.secLength, &c.groupOrder)
+}
+
+func (c *nistCurve[point]) checkCanonical(fe *big.Int) {
+	if !c.field.IsCanonical(fe) {
+		panic(hash2curve.ErrNonCanonical)
+	}
+}
+
+func (c *nistCurve[point]) map2curve(fe *big.Int) (point, error) {
+	x, y := c.mapper.Map(fe)
 	return c.affineToPoint(x, y)
 }
 
-var (
-	decompressed256 = [65]byte{0x04}
-	decompressed384 = [97]byte{0x04}
-	decompressed521 = [133]byte{0x04}
-)
+// map2curveParallel maps u0 and u1 concurrently, since both evaluations are independent.
+func (c *nistCurve[point]) map2curveParallel(u0, u1 *big.Int) (q0, q1 point, err0, err1 error) {
+	done := make(chan struct{})
 
-func (c *nistCurve[point]) affineToPoint(pxc, pyc *big.Int) point {
-	var decompressed []byte
+	go func() {
+		q1, err1 = c.map2curve(u1)
+
+		close(done)
+	}()
+
+	q0, err0 = c.map2curve(u0)
+	<-done
+
+	return q0, q1, err0, err1
+}
+
+// affineToPoint returns the point of affine coordinates (pxc, pyc), or an error wrapping hash2curve.ErrInvalidPoint if
+// the point implementation rejects them. The Simplified SWU map always yields canonical coordinates satisfying the
+// curve equation, so this can't fail for the built-in curves, but it can for a Curve whose parameters don't match those
+// of its point implementation.
+func (c *nistCurve[point]) affineToPoint(pxc, pyc *big.Int) (point, error) {
+	// The buffer is local so that concurrent mappings don't share state, and fits the uncompressed P-521 encoding.
+	var buf [133]byte
 
 	byteLen := c.field.ByteLen()
-	switch byteLen {
-	case 32:
-		decompressed = decompressed256[:]
-	case 48:
-		decompressed = decompressed384[:]
-	case 66:
-		decompressed = decompressed521[:]
-	default:
-		panic("invalid byte length")
+
+	var decompressed []byte
+	if n := 1 + 2*byteLen; n <= len(buf) {
+		decompressed = buf[:n]
+	} else {
+		decompressed = make([]byte, n)
 	}
 
 	decompressed[0] = 0x04
@@ -261,6 +495,15 @@ func (c *nistCurve[point]) affineToPoint(pxc, pyc *big.Int) point {
 	pyc.FillBytes(decompressed[1+byteLen:])
 
 	p, err := c.newPoint().SetBytes(decompressed)
+	if err != nil {
+		return p, fmt.Errorf("%w: %w", hash2curve.ErrInvalidPoint, err)
+	}
+
+	return p, nil
+}
+
+// must returns p, or panics with err if it is not nil.
+func must[P any](p P, err error) P {
 	if err != nil {
 		panic(err)
 	}
@@ -0,0 +1,56 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+package nist
+
+import (
+	"math/big"
+
+	"github.com/bytemare/hash2curve"
+)
+
+// HashToScalarP256Bytes returns HashToScalarP256(input, dst, opts...) as a 32-byte big-endian encoding, zero-padded to
+// the size of the scalars, as crypto/ecdh.P256().NewPrivateKey and HSM interfaces expect it.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func HashToScalarP256Bytes(input, dst []byte, opts ...ScalarOption) [32]byte {
+	var out [32]byte
+
+	fillScalar(out[:], HashToScalarP256(input, dst, opts...))
+
+	return out
+}
+
+// HashToScalarP384Bytes returns HashToScalarP384(input, dst, opts...) as a 48-byte big-endian encoding, zero-padded to
+// the size of the scalars, as crypto/ecdh.P384().NewPrivateKey and HSM interfaces expect it.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func HashToScalarP384Bytes(input, dst []byte, opts ...ScalarOption) [48]byte {
+	var out [48]byte
+
+	fillScalar(out[:], HashToScalarP384(input, dst, opts...))
+
+	return out
+}
+
+// HashToScalarP521Bytes returns HashToScalarP521(input, dst, opts...) as a 66-byte big-endian encoding, zero-padded to
+// the size of the scalars, as crypto/ecdh.P521().NewPrivateKey and HSM interfaces expect it.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func HashToScalarP521Bytes(input, dst []byte, opts ...ScalarOption) [66]byte {
+	var out [66]byte
+
+	fillScalar(out[:], HashToScalarP521(input, dst, opts...))
+
+	return out
+}
+
+// fillScalar writes the scalar to out, and wipes it.
+func fillScalar(out []byte, s *big.Int) {
+	s.FillBytes(out)
+	hash2curve.WipeInts(s)
+}
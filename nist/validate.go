@@ -0,0 +1,56 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+package nist
+
+import (
+	"errors"
+	"fmt"
+
+	"filippo.io/nistec"
+
+	"github.com/bytemare/hash2curve"
+)
+
+var errIdentity = errors.New("the point is the identity")
+
+// ValidateP256 returns an error wrapping hash2curve.ErrInvalidPoint if the SEC 1 encoding, compressed or uncompressed,
+// is not that of a P-256 point on the curve, or if it is the identity. P-256 has prime order, so all points on the
+// curve are in the prime-order group.
+func ValidateP256(encoded []byte) error {
+	return validate(encoded, nistec.NewP256Point)
+}
+
+// ValidateP384 returns an error wrapping hash2curve.ErrInvalidPoint if the SEC 1 encoding, compressed or uncompressed,
+// is not that of a P-384 point on the curve, or if it is the identity. P-384 has prime order, so all points on the
+// curve are in the prime-order group.
+func ValidateP384(encoded []byte) error {
+	return validate(encoded, nistec.NewP384Point)
+}
+
+// ValidateP521 returns an error wrapping hash2curve.ErrInvalidPoint if the SEC 1 encoding, compressed or uncompressed,
+// is not that of a P-521 point on the curve, or if it is the identity. P-521 has prime order, so all points on the
+// curve are in the prime-order group.
+func ValidateP521(encoded []byte) error {
+	return validate(encoded, nistec.NewP521Point)
+}
+
+func validate[P Point[P]](encoded []byte, newPoint func() P) error {
+	p, err := newPoint().SetBytes(encoded)
+	if err != nil {
+		return fmt.Errorf("%w: %w", hash2curve.ErrInvalidPoint, err)
+	}
+
+	if len(p.Bytes()) == 1 {
+		return fmt.Errorf("%w: %w", hash2curve.ErrInvalidPoint, errIdentity)
+	}
+
+	return nil
+}
@@ -0,0 +1,300 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package nobig
+
+import (
+	"crypto"
+	_ "crypto/sha256" // registers crypto.SHA256
+	_ "crypto/sha512" // registers crypto.SHA512
+	"encoding/hex"
+	"fmt"
+	"slices"
+
+	"filippo.io/edwards25519"
+	"filippo.io/edwards25519/field"
+	"filippo.io/nistec"
+
+	"github.com/bytemare/hash2curve/internal"
+	"github.com/bytemare/hash2curve/internal/curve25519"
+	"github.com/bytemare/hash2curve/internal/field/fn256"
+	"github.com/bytemare/hash2curve/internal/field/fn256k1"
+	"github.com/bytemare/hash2curve/internal/field/fn384"
+	"github.com/bytemare/hash2curve/internal/field/fn521"
+	"github.com/bytemare/hash2curve/internal/field/fp256"
+	"github.com/bytemare/hash2curve/internal/field/fp256k1"
+	"github.com/bytemare/hash2curve/internal/field/fp384"
+	"github.com/bytemare/hash2curve/internal/field/fp521"
+	"github.com/bytemare/hash2curve/internal/secp256k1"
+)
+
+const (
+	// secp256k1Length is the length of the encoding of secp256k1 field elements and scalars.
+	secp256k1Length = 32
+
+	// ristretto255Length is the length of the uniform strings mapped to ristretto255 elements and scalars, and of
+	// edwards25519 scalars before their reduction.
+	ristretto255Length = 64
+)
+
+// curves maps the suite identifiers, without their encoding type, to their curve.
+var curves = map[string]*curve{
+	"P256_XMD:SHA-256_SSWU_": hashToField(
+		crypto.SHA256, 48, nistEncoder(nistec.NewP256Point, p256Map), p256Scalar,
+	),
+	"P384_XMD:SHA-384_SSWU_": hashToField(
+		crypto.SHA384, 72, nistEncoder(nistec.NewP384Point, p384Map), p384Scalar,
+	),
+	"P521_XMD:SHA-512_SSWU_": hashToField(
+		crypto.SHA512, 98, nistEncoder(nistec.NewP521Point, p521Map), p521Scalar,
+	),
+	"curve25519_XMD:SHA-512_ELL2_":   hashToField(crypto.SHA512, 48, edwards25519Encoder(true), edwards25519Scalar),
+	"edwards25519_XMD:SHA-512_ELL2_": hashToField(crypto.SHA512, 48, edwards25519Encoder(false), edwards25519Scalar),
+	"secp256k1_XMD:SHA-256_SSWU_":    hashToField(crypto.SHA256, 48, secp256k1Encode, secp256k1Scalar),
+	"ristretto255_XMD:SHA-512_R255MAP_": {
+		encode:       ristretto255Encode,
+		scalar:       ristretto255Scalar,
+		hash:         crypto.SHA512,
+		roLength:     ristretto255Length,
+		nuLength:     ristretto255Length / 2,
+		scalarLength: ristretto255Length,
+	},
+}
+
+// The Simplified SWU maps of the NIST curves y^2 = x^3 - 3 * x + B, with their Z of RFC 9380 section 8.2.
+var (
+	p256SSWU = newSSWU[fp256.Element](
+		"5ac635d8aa3a93e7b3ebbd55769886bc651d06b0cc53b0f63bce3c3e27d2604b",
+		10,
+	)
+	p384SSWU = newSSWU[fp384.Element](
+		"b3312fa7e23ee7e4988e056be3f82d19181d9c6efe8141120314088f5013875ac656398d8a2ed19d2a85c8edd3ec2aef",
+		12,
+	)
+	p521SSWU = newSSWU[fp521.Element](
+		"0051953eb9618e1c9a1f929a21a0b68540eea2da725b99b315f3b8b489918ef109e156193951ec7e937b1652c0bd3bb1bf07357"+
+			"3df883d2c34f1ef451fd46b503f00",
+		4,
+	)
+)
+
+// wideElement is a generated field element, which SetWideBytes reduces from uniform bytes.
+type wideElement[E any] interface {
+	internal.FieldElement[E]
+	SetWideBytes(b []byte) *E
+}
+
+// newSSWU returns the Simplified SWU map of the curve y^2 = x^3 - 3 * x + b, with b in hexadecimal, and Z = -z.
+func newSSWU[E any, F wideElement[E]](b string, z byte) *internal.SSWUElement[E, F] {
+	bb, err := hex.DecodeString(b)
+	if err != nil {
+		panic(err)
+	}
+
+	var ae, be, ze E
+
+	F(&ae).Negate(F(&ae).SetWideBytes([]byte{3}))
+	F(&be).SetWideBytes(bb)
+	F(&ze).Negate(F(&ze).SetWideBytes([]byte{z}))
+
+	return internal.NewSSWUElement[E, F](&ae, &be, &ze)
+}
+
+// uncompressed returns the SEC 1 uncompressed encoding 0x04 || x || y.
+func uncompressed(x, y []byte) []byte {
+	return slices.Concat([]byte{4}, x, y)
+}
+
+func p256Map(uniform []byte) []byte {
+	var u, x, y fp256.Element
+
+	p256SSWU.MapElement(&x, &y, u.SetWideBytes(uniform))
+	xb, yb := x.Bytes(), y.Bytes()
+
+	return uncompressed(xb[:], yb[:])
+}
+
+func p384Map(uniform []byte) []byte {
+	var u, x, y fp384.Element
+
+	p384SSWU.MapElement(&x, &y, u.SetWideBytes(uniform))
+	xb, yb := x.Bytes(), y.Bytes()
+
+	return uncompressed(xb[:], yb[:])
+}
+
+func p521Map(uniform []byte) []byte {
+	var u, x, y fp521.Element
+
+	p521SSWU.MapElement(&x, &y, u.SetWideBytes(uniform))
+	xb, yb := x.Bytes(), y.Bytes()
+
+	return uncompressed(xb[:], yb[:])
+}
+
+func p256Scalar(uniform []byte) []byte {
+	b := new(fn256.Element).SetWideBytes(uniform).Bytes()
+	return b[:]
+}
+
+func p384Scalar(uniform []byte) []byte {
+	b := new(fn384.Element).SetWideBytes(uniform).Bytes()
+	return b[:]
+}
+
+func p521Scalar(uniform []byte) []byte {
+	b := new(fn521.Element).SetWideBytes(uniform).Bytes()
+	return b[:]
+}
+
+// nistPoint is a nistec point.
+type nistPoint[P any] interface {
+	*P
+	SetBytes(b []byte) (*P, error)
+	Add(p, q *P) *P
+	BytesCompressed() []byte
+}
+
+// nistEncoder returns the encoder of a NIST curve, decoding the uncompressed outputs of mapToCurve into nistec points
+// to add them.
+func nistEncoder[P any, Q nistPoint[P]](
+	newPoint func() Q,
+	mapToCurve func(uniform []byte) []byte,
+) func([]byte, bool) []byte {
+	decode := func(uniform []byte) Q {
+		p, err := newPoint().SetBytes(mapToCurve(uniform))
+		if err != nil {
+			panic(fmt.Errorf("%w: %w", internal.ErrInvalidPoint, err))
+		}
+
+		return p
+	}
+
+	return func(uniform []byte, randomOracle bool) []byte {
+		if !randomOracle {
+			return decode(uniform).BytesCompressed()
+		}
+
+		half := len(uniform) / 2
+		p := decode(uniform[:half])
+
+		p.Add(p, decode(uniform[half:]))
+
+		return p.BytesCompressed()
+	}
+}
+
+func secp256k1Encode(uniform []byte, randomOracle bool) []byte {
+	var u fp256k1.Element
+
+	half := len(uniform)
+	if randomOracle {
+		half /= 2
+	}
+
+	q := secp256k1.MapToIsoCurve(u.SetWideBytes(uniform[:half]))
+	if randomOracle {
+		q.Add(secp256k1.MapToIsoCurve(u.SetWideBytes(uniform[half:])))
+	}
+
+	x, y, isIdentity := secp256k1.Isogeny(&q.X, &q.Y)
+	out := make([]byte, 1+secp256k1Length)
+
+	if isIdentity == 1 {
+		return out
+	}
+
+	xb := x.Bytes()
+	out[0] = 2 | byte(y.Sgn0())
+	copy(out[1:], xb[:])
+
+	return out
+}
+
+func secp256k1Scalar(uniform []byte) []byte {
+	b := new(fn256k1.Element).SetWideBytes(uniform).Bytes()
+	return b[:]
+}
+
+// edwards25519Encoder returns the encoder of edwards25519, or of curve25519 with the Montgomery u-coordinate of the
+// points.
+func edwards25519Encoder(montgomery bool) func([]byte, bool) []byte {
+	return func(uniform []byte, randomOracle bool) []byte {
+		var (
+			u    field.Element
+			p, q edwards25519.Point
+		)
+
+		half := len(uniform)
+		if randomOracle {
+			half /= 2
+		}
+
+		curve25519.Elligator2Edwards(&p, curve25519.WideElement(&u, uniform[:half]))
+
+		if randomOracle {
+			curve25519.Elligator2Edwards(&q, curve25519.WideElement(&u, uniform[half:]))
+			p.Add(&p, &q)
+		}
+
+		p.MultByCofactor(&p)
+
+		if montgomery {
+			return p.BytesMontgomery()
+		}
+
+		return p.Bytes()
+	}
+}
+
+// edwards25519Scalar reduces the big-endian uniform bytes, zero-extended to 64 bytes in little-endian.
+func edwards25519Scalar(uniform []byte) []byte {
+	var wide [ristretto255Length]byte
+	defer clear(wide[:])
+
+	for i, b := range uniform {
+		wide[len(uniform)-1-i] = b
+	}
+
+	s, err := edwards25519.NewScalar().SetUniformBytes(wide[:])
+	if err != nil {
+		panic(err)
+	}
+
+	return s.Bytes()
+}
+
+func ristretto255Element(uniform []byte) *edwards25519.Point {
+	t, err := new(field.Element).SetBytes(uniform)
+	if err != nil {
+		panic(err)
+	}
+
+	return curve25519.MapToPoint(t)
+}
+
+// ristretto255Encode implements the R255MAP suites: the RO suites add the mappings of both halves of the 64 bytes, as
+// the ristretto255 element derivation function of RFC 9496 does, and the NU suites map 32 bytes once.
+func ristretto255Encode(uniform []byte, randomOracle bool) []byte {
+	if !randomOracle {
+		return curve25519.Encode(ristretto255Element(uniform))
+	}
+
+	p := ristretto255Element(uniform[:ristretto255Length/2])
+
+	return curve25519.Encode(p.Add(p, ristretto255Element(uniform[ristretto255Length/2:])))
+}
+
+func ristretto255Scalar(uniform []byte) []byte {
+	s, err := edwards25519.NewScalar().SetUniformBytes(uniform)
+	if err != nil {
+		panic(err)
+	}
+
+	return s.Bytes()
+}
@@ -0,0 +1,147 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package nobig implements all the suites of the suite package on fixed-limb field arithmetic, for targets where
+// math/big is unwanted, e.g. WASM plugins and embedded firmware. Built with the nomathbig build tag, neither the
+// package nor its dependencies import math/big, nor crypto/rand, which depends on it, so that blinding isn't
+// available. The outputs are those of the suite package with the default options.
+//
+// The nomathbig build tag excludes the packages working on math/big, so that only this package, and the
+// expand_message and suite registry APIs of the hash2curve package, remain.
+package nobig
+
+import (
+	"crypto"
+	"fmt"
+	"strings"
+
+	"github.com/bytemare/hash2curve/internal"
+)
+
+// The errors returned by this package, which are those of the hash2curve package.
+var (
+	// ErrZeroLengthDST is hash2curve.ErrZeroLengthDST.
+	ErrZeroLengthDST = internal.ErrZeroLengthDST
+
+	// ErrInputTooLong is hash2curve.ErrInputTooLong.
+	ErrInputTooLong = internal.ErrInputTooLong
+
+	// ErrInvalidSuite is hash2curve.ErrInvalidSuite.
+	ErrInvalidSuite = internal.ErrInvalidSuite
+)
+
+const (
+	encodingRandomOracle = "RO_"
+	encodingNonUniform   = "NU_"
+)
+
+// Suite is a hash-to-curve suite. It is safe for concurrent use.
+type Suite struct {
+	curve        *curve
+	id           string
+	randomOracle bool
+}
+
+// New returns the suite for the identifier, e.g. "P256_XMD:SHA-256_SSWU_RO_", among those of hash2curve.AllSuites. It
+// returns an error wrapping ErrInvalidSuite otherwise.
+func New(id string) (*Suite, error) {
+	prefix, randomOracle := strings.CutSuffix(id, encodingRandomOracle)
+	if !randomOracle {
+		var ok bool
+		if prefix, ok = strings.CutSuffix(id, encodingNonUniform); !ok {
+			return nil, fmt.Errorf("%w: %q is not implemented", ErrInvalidSuite, id)
+		}
+	}
+
+	c, ok := curves[prefix]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q is not implemented", ErrInvalidSuite, id)
+	}
+
+	return &Suite{curve: c, id: id, randomOracle: randomOracle}, nil
+}
+
+// ID returns the suite identifier.
+func (s *Suite) ID() string {
+	return s.id
+}
+
+// Hash maps the input to a point with the suite's encoding, i.e. hash_to_curve for RO suites and encode_to_curve for
+// NU suites, and returns the encoded point. It returns ErrZeroLengthDST if the DST is empty or nil, and
+// ErrInputTooLong if the input exceeds the limits set with hash2curve.SetInputLimits.
+func (s *Suite) Hash(input, dst []byte) ([]byte, error) {
+	length := s.curve.nuLength
+	if s.randomOracle {
+		length = s.curve.roLength
+	}
+
+	uniform, err := s.expand(input, dst, length)
+	if err != nil {
+		return nil, err
+	}
+
+	defer internal.Wipe(uniform)
+
+	return s.curve.encode(uniform, s.randomOracle), nil
+}
+
+// HashToScalar returns a safe mapping of the arbitrary input to an encoded scalar of the prime-order group. It returns
+// the errors of Hash.
+func (s *Suite) HashToScalar(input, dst []byte) ([]byte, error) {
+	uniform, err := s.expand(input, dst, s.curve.scalarLength)
+	if err != nil {
+		return nil, err
+	}
+
+	defer internal.Wipe(uniform)
+
+	return s.curve.scalar(uniform), nil
+}
+
+func (s *Suite) expand(input, dst []byte, length uint) ([]byte, error) {
+	if err := internal.ValidateDST(dst, false); err != nil {
+		return nil, err
+	}
+
+	if err := internal.ValidateInput(input, length); err != nil {
+		return nil, err
+	}
+
+	return internal.ExpandXMD(s.curve.hash, input, dst, length), nil
+}
+
+// curve implements the suites of a curve on the uniform bytes of expand_message_xmd.
+type curve struct {
+	// encode maps the uniform bytes of one field element, or of two for the RO suites, to an encoded point.
+	encode func(uniform []byte, randomOracle bool) []byte
+
+	// scalar reduces the uniform bytes of one element to an encoded scalar.
+	scalar func(uniform []byte) []byte
+
+	hash         crypto.Hash
+	roLength     uint
+	nuLength     uint
+	scalarLength uint
+}
+
+// hashToField returns the curve of a suite using hash_to_field with elements of secLength bytes.
+func hashToField(
+	h crypto.Hash,
+	secLength uint,
+	encode func(uniform []byte, randomOracle bool) []byte,
+	scalar func(uniform []byte) []byte,
+) *curve {
+	return &curve{
+		encode:       encode,
+		scalar:       scalar,
+		hash:         h,
+		roLength:     2 * secLength,
+		nuLength:     secLength,
+		scalarLength: secLength,
+	}
+}
@@ -0,0 +1,79 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+package hash2curve
+
+import (
+	"crypto"
+	"fmt"
+	"math/big"
+
+	"github.com/bytemare/hash2curve/internal"
+	"github.com/bytemare/hash2curve/internal/field"
+)
+
+// maxNonceKeyLength is the largest secret key length, as it's encoded on 2 bytes.
+const maxNonceKeyLength = 1<<16 - 1
+
+var (
+	errNonceKey   = fmt.Errorf("%w: the secret key must be between 1 and 65535 bytes", ErrInvalidParameters)
+	errNonceOrder = fmt.Errorf("%w: the group order must be larger than 2", ErrInvalidParameters)
+)
+
+// DeriveNonce deterministically derives a secret scalar in [1, order - 1] from the secret key and the message, e.g. a
+// per-signature nonce, as an alternative to RFC 6979 built on expand_message_xmd, and returns its fixed-width
+// big-endian encoding, of the byte length of the order. dst must be specific to the protocol and to this usage, and not
+// shared with hash_to_curve or hash_to_field calls on the same inputs.
+//
+// It computes expand_message_xmd(I2OSP(len(key), 2) || key || message, dst, L), with
+// L = ceil((ceil(log2(order)) + 128) / 8), and maps the result to the integer 1 + (OS2IP(uniform) mod (order - 1)),
+// whose bias is negligible. The reduction is the constant-time Barrett reduction of hash_to_field, and the addition is
+// done on the fixed-width encoding. Since the key is length-prefixed, distinct (key, message) pairs are distinct
+// inputs. Signatures deriving a single nonce from the key and the message can't vary it, and, as with RFC 6979, they
+// must include all the signed data, and the public key if it's not bound otherwise, in the message.
+//
+// It panics if the key is empty or longer than 65535 bytes, if the order is not larger than 2, or if dst or the
+// input are invalid for ExpandXMD. The intermediate values are wiped after use.
+func DeriveNonce(id crypto.Hash, key, message, dst []byte, order *big.Int) []byte {
+	if len(key) == 0 || len(key) > maxNonceKeyLength {
+		panic(errNonceKey)
+	}
+
+	if order == nil || order.Cmp(big.NewInt(2)) <= 0 {
+		panic(errNonceOrder)
+	}
+
+	input := make([]byte, 0, 2+len(key)+len(message))
+	input = append(input, internal.I2OSP(uint(len(key)), 2)...)
+	input = append(input, key...)
+	input = append(input, message...)
+
+	defer Wipe(input)
+
+	length := uint((order.BitLen() + minSecurityBits + 7) / 8)
+	uniform := ExpandXMD(id, input, dst, length)
+
+	defer Wipe(uniform)
+
+	k := field.NewReducer(new(big.Int).Sub(order, big.NewInt(1)), length).Reduce(uniform)
+	defer internal.WipeInt(k)
+
+	// k <= order - 2, so k + 1 fits in the byte length of the order.
+	nonce := k.FillBytes(make([]byte, (order.BitLen()+7)/8))
+	carry := uint16(1)
+
+	for i := len(nonce) - 1; i >= 0; i-- {
+		sum := uint16(nonce[i]) + carry
+		nonce[i] = byte(sum)
+		carry = sum >> 8
+	}
+
+	return nonce
+}
@@ -0,0 +1,94 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package octet implements the I2OSP and OS2IP primitives of RFC 8017, as used in RFC 9380, for arbitrary lengths.
+package octet
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/big"
+)
+
+const uint64Length = 8
+
+var (
+	// ErrLengthNonPositive indicates a requested output length that is zero or negative.
+	ErrLengthNonPositive = errors.New("length must be positive")
+
+	// ErrIntegerNegative indicates a negative integer, which can't be encoded.
+	ErrIntegerNegative = errors.New("integer is negative")
+
+	// ErrIntegerTooLarge indicates an integer that does not fit in the requested length, i.e. x >= 256^length.
+	ErrIntegerTooLarge = errors.New("integer too large for length")
+)
+
+// I2OSP returns the big-endian encoding of x on length bytes.
+func I2OSP(x *big.Int, length int) ([]byte, error) {
+	if length <= 0 {
+		return nil, ErrLengthNonPositive
+	}
+
+	if x.Sign() < 0 {
+		return nil, ErrIntegerNegative
+	}
+
+	if (x.BitLen()+7)/8 > length {
+		return nil, ErrIntegerTooLarge
+	}
+
+	return x.FillBytes(make([]byte, length)), nil
+}
+
+// I2OSPUint64 returns the big-endian encoding of x on length bytes. length can be larger than 8, in which case the
+// output is left-padded with zeros.
+func I2OSPUint64(x uint64, length int) ([]byte, error) {
+	if length <= 0 {
+		return nil, ErrLengthNonPositive
+	}
+
+	if length < uint64Length && x>>(8*uint(length)) != 0 {
+		return nil, ErrIntegerTooLarge
+	}
+
+	var buf [uint64Length]byte
+
+	binary.BigEndian.PutUint64(buf[:], x)
+
+	out := make([]byte, length)
+	if length >= uint64Length {
+		copy(out[length-uint64Length:], buf[:])
+	} else {
+		copy(out, buf[uint64Length-length:])
+	}
+
+	return out, nil
+}
+
+// OS2IP returns the non-negative integer encoded in big-endian in b. An empty input yields 0.
+func OS2IP(b []byte) *big.Int {
+	return new(big.Int).SetBytes(b)
+}
+
+// OS2IPUint64 returns the integer encoded in big-endian in b, which must fit in 64 bits. Leading zero bytes are
+// allowed, so b can be of any length.
+func OS2IPUint64(b []byte) (uint64, error) {
+	for len(b) > uint64Length {
+		if b[0] != 0 {
+			return 0, ErrIntegerTooLarge
+		}
+
+		b = b[1:]
+	}
+
+	var buf [uint64Length]byte
+
+	copy(buf[uint64Length-len(b):], b)
+
+	return binary.BigEndian.Uint64(buf[:]), nil
+}
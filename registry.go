@@ -0,0 +1,103 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package hash2curve
+
+const modulePath = "github.com/bytemare/hash2curve"
+
+// SuiteDescriptor describes a suite implemented by this module.
+type SuiteDescriptor struct {
+	// ID is the suite identifier, e.g. "P256_XMD:SHA-256_SSWU_RO_".
+	ID string `json:"id"`
+
+	// Curve is the CURVE_ID, e.g. "P256".
+	Curve string `json:"curve"`
+
+	// Hash is the HASH_ID, e.g. "XMD:SHA-256".
+	Hash string `json:"hash"`
+
+	// Map is the MAP_ID, e.g. "SSWU".
+	Map string `json:"map"`
+
+	// Encoding is the ENC_VAR, "RO" for hash_to_curve and "NU" for encode_to_curve.
+	Encoding string `json:"encoding"`
+
+	// Package is the import path of the package implementing the suite.
+	Package string `json:"package"`
+
+	// Cofactor is the scalar h_eff by which mapped points are multiplied to clear the cofactor, and 1 for prime-order
+	// curves and groups. It is 0 if h_eff doesn't fit in 64 bits, as for the G2 suites of pairing-friendly curves,
+	// whose packages return it.
+	Cofactor uint64 `json:"cofactor"`
+
+	// SecurityLevel is the target security level k of the suite, in bits.
+	SecurityLevel uint `json:"securityLevel"`
+
+	// SecurityLength is the length L of each element in hash_to_field, i.e. SecurityLength(p, k) for the field prime
+	// p, and the 64 bytes of uniform input of the ristretto255 map.
+	SecurityLength uint `json:"securityLength"`
+
+	// RandomOracle is true for hash_to_curve suites, which are indifferentiable from a random oracle.
+	RandomOracle bool `json:"randomOracle"`
+}
+
+// implementedCurves lists the curves implemented in this module, in order, with the HASH_ID of their suites, the
+// package implementing them, their h_eff, k, and L. All of them provide both the RO and NU variants. The suites of RFC
+// 9380 and RFC 9496 come first, in the order of the Suite values. h_eff is 0 if it doesn't fit in 64 bits.
+var implementedCurves = []struct {
+	curve, hash, pkg string
+	cofactor         uint64
+	k, length        uint
+}{
+	{"P256", "XMD:SHA-256", "nist", 1, 128, 48},
+	{"P384", "XMD:SHA-384", "nist", 1, 192, 72},
+	{"P521", "XMD:SHA-512", "nist", 1, 256, 98},
+	{"curve25519", "XMD:SHA-512", "edwards25519", 8, 128, 48},
+	{"edwards25519", "XMD:SHA-512", "edwards25519", 8, 128, 48},
+	{"secp256k1", "XMD:SHA-256", "secp256k1", 1, 128, 48},
+	{"ristretto255", "XMD:SHA-512", "ristretto255", 1, 128, 64},
+	{"E521", "XMD:SHA-512", "e521", 4, 256, 98},
+	{"curve41417", "XMD:SHA-512", "curve41417", 8, 192, 76},
+	{"M511", "XMD:SHA-512", "m511", 8, 256, 96},
+	{"MNT4298G1", "XMD:SHA-256", "mnt4298", 1, 128, 54},
+	{"MNT4298G2", "XMD:SHA-256", "mnt4298", 0, 128, 54},
+	{"MNT6298G1", "XMD:SHA-256", "mnt6298", 1, 128, 54},
+	{"MNT6298G2", "XMD:SHA-256", "mnt6298", 0, 128, 54},
+	{"BLS24315G1", "XMD:SHA-256", "bls24315", 3452012412914368512, 128, 56},
+	{"BLS24315G2", "XMD:SHA-256", "bls24315", 0, 128, 56},
+	{"secp192r1", "XMD:SHA-256", "secp192r1", 1, 128, 40},
+	{"secp224k1", "XMD:SHA-256", "secp224k1", 1, 128, 44},
+	{"secp256k1", "XMD:KECCAK-256", "secp256k1", 1, 128, 48},
+	{"BN254G1", "XMD:KECCAK-256", "bn254", 1, 128, 48},
+}
+
+// Suites returns the descriptors of all suites implemented in this module, with the RO variant before the NU variant
+// of each curve.
+func Suites() []SuiteDescriptor {
+	suites := make([]SuiteDescriptor, 0, 2*len(implementedCurves))
+
+	for _, c := range implementedCurves {
+		for _, encoding := range []string{EncodingRandomOracle, EncodingNonUniform} {
+			id := SuiteID{Curve: c.curve, Hash: c.hash, Map: registeredSuites[c.curve].mapID, Encoding: encoding}
+			suites = append(suites, SuiteDescriptor{
+				ID:             id.String(),
+				Curve:          id.Curve,
+				Hash:           id.Hash,
+				Map:            id.Map,
+				Encoding:       id.Encoding,
+				Package:        modulePath + "/" + c.pkg,
+				Cofactor:       c.cofactor,
+				SecurityLevel:  c.k,
+				SecurityLength: c.length,
+				RandomOracle:   encoding == EncodingRandomOracle,
+			})
+		}
+	}
+
+	return suites
+}
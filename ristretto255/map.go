@@ -0,0 +1,39 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+package ristretto255
+
+import (
+	"fmt"
+
+	"filippo.io/edwards25519/field"
+	"github.com/gtank/ristretto255"
+
+	"github.com/bytemare/hash2curve"
+	"github.com/bytemare/hash2curve/internal/curve25519"
+)
+
+var errMapOutsideTheCurve = fmt.Errorf("%w: ristretto255 map output", hash2curve.ErrInvalidPoint)
+
+// MapToGroup applies the ristretto255 one-way map of RFC 9496 section 4.3.4 once to the 32 bytes in b, whose most
+// significant bit is ignored, and returns the resulting Element.
+func MapToGroup(b []byte) *ristretto255.Element {
+	t, err := new(field.Element).SetBytes(b)
+	if err != nil {
+		panic(err)
+	}
+
+	e := ristretto255.NewElement()
+	if err = e.Decode(curve25519.Encode(curve25519.MapToPoint(t))); err != nil {
+		panic(fmt.Errorf("%w: %w", errMapOutsideTheCurve, err))
+	}
+
+	return e
+}
@@ -6,34 +6,97 @@
 // LICENSE file in the root directory of this source tree or at
 // https://spdx.org/licenses/MIT.html
 
+//go:build !nomathbig
+
 // Package ristretto255 implements RFC9380 for the ristretto255 group, and returns points and scalar from
 // github.com/gtank/ristretto255.
 package ristretto255
 
 import (
 	"crypto"
+	"math/big"
 
 	"github.com/gtank/ristretto255"
 
 	"github.com/bytemare/hash2curve"
 )
 
+const (
+	// H2C represents the hash-to-group string identifier for ristretto255.
+	H2C = "ristretto255_XMD:SHA-512_R255MAP_RO_"
+
+	// E2C represents the encode-to-group string identifier for ristretto255.
+	E2C = "ristretto255_XMD:SHA-512_R255MAP_NU_"
+
+	encodeLength = 32
+
+	// scalarUniformLength is the number of uniform bytes reduced to a Scalar.
+	scalarUniformLength = 64
+)
+
 // HashToGroup returns a safe mapping of the arbitrary input to an Element in the Ristretto255 group.
 // The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
 func HashToGroup(input, dst []byte) *ristretto255.Element {
 	uniform := hash2curve.ExpandXMD(crypto.SHA512, input, dst, 64)
+	defer hash2curve.Wipe(uniform)
+
 	return ristretto255.NewElement().FromUniformBytes(uniform)
 }
 
-// EncodeToGroup returns a non-uniform mapping of the arbitrary input to an Element in the Ristretto255 group.
+// EncodeToGroup returns a non-uniform mapping of the arbitrary input to an Element in the Ristretto255 group. It
+// expands 32 bytes and applies the one-way map once, which is cheaper than HashToGroup but not indifferentiable from
+// a random oracle.
 // The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
 func EncodeToGroup(input, dst []byte) *ristretto255.Element {
-	return HashToGroup(input, dst)
+	uniform := hash2curve.ExpandXMD(crypto.SHA512, input, dst, encodeLength)
+	defer hash2curve.Wipe(uniform)
+
+	return MapToGroup(uniform)
 }
 
 // HashToScalar returns a safe mapping of the arbitrary input to a Scalar.
 // The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
 func HashToScalar(input, dst []byte) *ristretto255.Scalar {
-	uniform := hash2curve.ExpandXMD(crypto.SHA512, input, dst, 64)
-	return ristretto255.NewScalar().FromUniformBytes(uniform)
+	return HashToScalars(input, dst, 1)[0]
 }
+
+// HashToScalars returns count Scalars from a single expansion of the input, see [hash2curve]. count must be positive.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func HashToScalars(input, dst []byte, count uint) []*ristretto255.Scalar {
+	if count == 0 {
+		panic(hash2curve.ErrInvalidParameters)
+	}
+
+	uniform := hash2curve.ExpandXMD(crypto.SHA512, input, dst, count*scalarUniformLength)
+	defer hash2curve.Wipe(uniform)
+
+	res := make([]*ristretto255.Scalar, count)
+	for i := range count {
+		res[i] = ristretto255.NewScalar().FromUniformBytes(
+			uniform[i*scalarUniformLength : (i+1)*scalarUniformLength],
+		)
+	}
+
+	return res
+}
+
+// Order returns a copy of the prime order 2^252 + 27742317777372353535851937790883648493 of the ristretto255 group,
+// i.e. the modulus of the Scalars.
+func Order() *big.Int {
+	return new(big.Int).Set(order)
+}
+
+// FieldPrime returns a copy of the prime 2^255 - 19 of the field of the underlying edwards25519 curve.
+func FieldPrime() *big.Int {
+	return new(big.Int).Set(fieldPrime)
+}
+
+// Cofactor returns the cofactor of the ristretto255 group, which is 1 since it has prime order.
+func Cofactor() *big.Int {
+	return big.NewInt(1)
+}
+
+var (
+	order, _      = new(big.Int).SetString("1000000000000000000000000000000014def9dea2f79cd65812631a5cf5d3ed", 16)
+	fieldPrime, _ = new(big.Int).SetString("7fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffed", 16)
+)
@@ -0,0 +1,38 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+package ristretto255
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gtank/ristretto255"
+
+	"github.com/bytemare/hash2curve"
+)
+
+var errIdentity = errors.New("the element is the identity")
+
+// Validate returns an error wrapping hash2curve.ErrInvalidPoint if the encoding is not the canonical encoding of a
+// ristretto255 element, or if it is the identity. Since ristretto255 is a prime-order group, all valid encodings are
+// of elements of the group.
+func Validate(encoded []byte) error {
+	e := ristretto255.NewElement()
+	if err := e.Decode(encoded); err != nil {
+		return fmt.Errorf("%w: %w", hash2curve.ErrInvalidPoint, err)
+	}
+
+	if e.Equal(ristretto255.NewElement()) == 1 {
+		return fmt.Errorf("%w: %w", hash2curve.ErrInvalidPoint, errIdentity)
+	}
+
+	return nil
+}
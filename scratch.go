@@ -0,0 +1,45 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package hash2curve
+
+import (
+	"crypto"
+
+	"github.com/bytemare/hash2curve/internal"
+)
+
+// Scratch is caller-owned working memory for expand_message_xmd with a fixed length hash function: the hash state, the
+// DST prime, and the intermediate digests. It is allocated once with NewScratch, and reused by ExpandXMDInto and the
+// scratch variants of the curve packages, e.g. edwards25519.HashToCurveScratch, which then don't allocate, for users
+// that need deterministic memory behavior, e.g. on embedded or real-time systems. The scratch is wiped after each use.
+//
+// Allocations still happen if blinding is enabled, if an ExpandHook is set, or on error paths, and the inputs may
+// escape to the heap, as they are written to the hash state through its interface. A Scratch must not be used
+// concurrently, i.e. use one per goroutine.
+type Scratch struct {
+	xmd *internal.XMDScratch
+}
+
+// NewScratch returns a Scratch for expand_message_xmd with id. It panics with ErrUnsupportedHash if id is not
+// available.
+func NewScratch(id crypto.Hash) *Scratch {
+	return &Scratch{xmd: internal.NewXMDScratch(id)}
+}
+
+// Hash returns the hash function of the scratch.
+func (s *Scratch) Hash() crypto.Hash {
+	return s.xmd.Hash()
+}
+
+// ExpandXMDInto sets out to ExpandXMD(s.Hash(), input, dst, len(out)), using the working memory of s instead of
+// allocating. The requirements of ExpandXMD apply.
+func ExpandXMDInto(s *Scratch, out, input, dst []byte) {
+	checkInput(input, dst, uint(len(out)))
+	s.xmd.ExpandInto(out, input, dst)
+}
@@ -0,0 +1,166 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+// Package secp224k1 implements hashing to secp224k1, the Koblitz curve y^2 = x^3 + 5 of SEC 2, with the
+// Shallue-van de Woestijne map and expand_message_xmd with SHA-256, for the legacy deployments, such as smart cards and
+// HSMs, that still require deterministic point derivation on this curve. Since A = 0, the simplified SWU map would
+// need an isogenous curve, which is not defined for secp224k1. The curve offers about 112 bits of security, but the
+// hash_to_field lengths are those of the 128 bits minimum of this module. The suites follow the construction of the
+// RFC 9380 secp256k1 suites, but are not specified by RFC 9380.
+package secp224k1
+
+import (
+	"crypto"
+	"math/big"
+
+	"github.com/bytemare/hash2curve"
+	"github.com/bytemare/hash2curve/internal/extension"
+	"github.com/bytemare/hash2curve/internal/weierstrass"
+)
+
+const (
+	// H2C represents the hash-to-curve string identifier.
+	H2C = "secp224k1_XMD:SHA-256_SVDW_RO_"
+
+	// E2C represents the encode-to-curve string identifier.
+	E2C = "secp224k1_XMD:SHA-256_SVDW_NU_"
+
+	// securityLevel is the security level k, in bits, used for the hash_to_field lengths.
+	securityLevel = 128
+
+	fieldPrime = "fffffffffffffffffffffffffffffffffffffffffffffffeffffe56d"
+	groupOrder = "010000000000000000000000000001dce8d2ec6184caf0a971769fb1f7"
+)
+
+var suite = newSuite()
+
+func newSuite() *weierstrass.Suite {
+	p, _ := new(big.Int).SetString(fieldPrime, 16)
+	r, _ := new(big.Int).SetString(groupOrder, 16)
+
+	// Z = -1 is the first value accepted by RFC 9380's find_z_svdw.
+	fp := extension.NewField(p, 1, big.NewInt(1))
+	c := weierstrass.NewCurveSVDW(fp, fp.New(), fp.Element(big.NewInt(5)), fp.Element(big.NewInt(-1)), big.NewInt(1))
+
+	return weierstrass.NewSuite(c, r, crypto.SHA256, securityLevel)
+}
+
+type disallowEqual [0]func()
+
+// Point is a point on secp224k1, in affine coordinates, or the point at infinity.
+type Point struct {
+	_        disallowEqual
+	X, Y     big.Int
+	infinity bool
+}
+
+func newPoint(p *weierstrass.Point) *Point {
+	q := &Point{infinity: p.Infinity}
+	q.X.Set(p.X[0])
+	q.Y.Set(p.Y[0])
+
+	return q
+}
+
+func (p *Point) point() *weierstrass.Point {
+	return &weierstrass.Point{
+		X:        extension.Element{new(big.Int).Set(&p.X)},
+		Y:        extension.Element{new(big.Int).Set(&p.Y)},
+		Infinity: p.infinity,
+	}
+}
+
+// IsInfinity returns whether p is the point at infinity, i.e. the identity.
+func (p *Point) IsInfinity() bool {
+	return p.infinity
+}
+
+// Bytes returns the 29-byte SEC 1 compressed encoding of the point, or the single byte 0x00 for the point at infinity.
+func (p *Point) Bytes() []byte {
+	return suite.EncodeSEC1(p.point(), true)
+}
+
+// BytesUncompressed returns the 57-byte SEC 1 uncompressed encoding 0x04 || x || y of the point, or the single byte
+// 0x00 for the point at infinity.
+func (p *Point) BytesUncompressed() []byte {
+	return suite.EncodeSEC1(p.point(), false)
+}
+
+// SetBytes sets p to the point with the compressed or uncompressed SEC 1 encoding, and returns p. It returns an error
+// wrapping hash2curve.ErrInvalidPoint if the encoding is invalid or not that of a point on the curve.
+func (p *Point) SetBytes(b []byte) (*Point, error) {
+	q, err := suite.DecodeSEC1(b)
+	if err != nil {
+		return nil, err
+	}
+
+	*p = *newPoint(q)
+
+	return p, nil
+}
+
+// Add sets p to p1 + p2, and returns p.
+func (p *Point) Add(p1, p2 *Point) *Point {
+	*p = *newPoint(suite.Add(p1.point(), p2.point()))
+	return p
+}
+
+// Equal returns whether p and q are the same point.
+func (p *Point) Equal(q *Point) bool {
+	if p.infinity || q.infinity {
+		return p.infinity == q.infinity
+	}
+
+	return p.X.Cmp(&q.X) == 0 && p.Y.Cmp(&q.Y) == 0
+}
+
+// HashToCurve implements hash-to-curve mapping to secp224k1 of input with dst.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func HashToCurve(input, dst []byte) *Point {
+	return newPoint(suite.HashToCurve(input, dst))
+}
+
+// EncodeToCurve implements encode-to-curve mapping to secp224k1 of input with dst.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func EncodeToCurve(input, dst []byte) *Point {
+	return newPoint(suite.EncodeToCurve(input, dst))
+}
+
+// HashToScalar returns a safe mapping of the arbitrary input to a scalar for the prime-order group of secp224k1.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func HashToScalar(input, dst []byte) *big.Int {
+	return suite.HashToScalar(input, dst)
+}
+
+// MapToCurve implements the Shallue-van de Woestijne map to secp224k1. It panics with hash2curve.ErrNonCanonical if fe
+// is not a canonical field element.
+func MapToCurve(fe *big.Int) *Point {
+	if !suite.Field.Base.IsCanonical(fe) {
+		panic(hash2curve.ErrNonCanonical)
+	}
+
+	return newPoint(suite.MapToCurve(extension.Element{fe}))
+}
+
+// Order returns a copy of the order of the prime-order group of secp224k1, i.e. the modulus of HashToScalar.
+func Order() *big.Int {
+	return suite.Order()
+}
+
+// FieldPrime returns a copy of the prime of the field of secp224k1.
+func FieldPrime() *big.Int {
+	return suite.FieldPrime()
+}
+
+// Cofactor returns a copy of the cofactor of secp224k1, by which the outputs of MapToCurve are cleared
+// into the prime-order group.
+func Cofactor() *big.Int {
+	return suite.Cofactor()
+}
@@ -0,0 +1,96 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build ignore
+
+// This program generates constants.go, holding the precomputed limbs of the secp256k1 field and group order, and of
+// the field constants derived from them. Run it with go generate.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"log"
+	"math/big"
+	"os"
+	"strings"
+)
+
+const header = `// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Code generated by gen_constants.go. DO NOT EDIT.
+
+//go:build !nomathbig
+
+package secp256k1
+
+import "github.com/bytemare/hash2curve/internal/field"
+
+// The field and group order of secp256k1, as little-endian 64-bit limbs.
+var (
+`
+
+var (
+	p = hexInt("fffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f")
+	n = hexInt("fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141")
+)
+
+func hexInt(s string) *big.Int {
+	i, ok := new(big.Int).SetString(s, 16)
+	if !ok {
+		log.Fatalf("invalid constant %q", s)
+	}
+
+	return i
+}
+
+func limbs(v *big.Int) string {
+	l := make([]string, 4)
+
+	mask := new(big.Int).SetUint64(^uint64(0))
+	for i := range l {
+		l[i] = fmt.Sprintf("%#016x", new(big.Int).And(new(big.Int).Rsh(v, uint(64*i)), mask).Uint64())
+	}
+
+	return "field.IntFromLimbs(\n" + strings.Join(l, ", ") + ",\n)"
+}
+
+func main() {
+	if p.Bit(0) != 1 || p.Bit(1) != 1 {
+		log.Fatal("p is not 3 mod 4")
+	}
+
+	var buf bytes.Buffer
+
+	buf.WriteString(header)
+	buf.WriteString("// field order: 2^256 - 2^32 - 977.\n")
+	buf.WriteString("// The field constants are (p - 1) / 2, p - 2, and (p + 1) / 4.\n")
+	fmt.Fprintf(&buf, "fp = field.NewPrecomputedField(\n%s,\n%s,\n%s,\n%s,\n)\n\n",
+		limbs(p),
+		limbs(new(big.Int).Rsh(p, 1)),
+		limbs(new(big.Int).Sub(p, big.NewInt(2))),
+		limbs(new(big.Int).Rsh(new(big.Int).Add(p, big.NewInt(1)), 2)),
+	)
+	fmt.Fprintf(&buf, "// group order: 2^256 - 432420386565659656852420866394968145599.\norder = %s\n)\n", limbs(n))
+
+	out, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err = os.WriteFile("constants.go", out, 0o600); err != nil {
+		log.Fatal(err)
+	}
+}
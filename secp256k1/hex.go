@@ -0,0 +1,93 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+package secp256k1
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/bytemare/hash2curve"
+	"github.com/bytemare/hash2curve/internal/field/fp256k1"
+)
+
+var (
+	errEncodingLength = errors.New("invalid encoding length")
+	errEncodingPrefix = errors.New("invalid encoding prefix")
+	errNotOnCurve     = errors.New("x is not the coordinate of a point on the curve")
+)
+
+// HashToCurveHex returns the hex-encoded SEC 1 compressed encoding of HashToCurve(input, dst).
+func HashToCurveHex(input, dst []byte) string {
+	return hex.EncodeToString(HashToCurve(input, dst).Bytes())
+}
+
+// EncodeToCurveHex returns the hex-encoded SEC 1 compressed encoding of EncodeToCurve(input, dst).
+func EncodeToCurveHex(input, dst []byte) string {
+	return hex.EncodeToString(EncodeToCurve(input, dst).Bytes())
+}
+
+// DecodeHex decodes a hex-encoded compressed encoding of a point, as returned by Bytes, and returns an error wrapping
+// hash2curve.ErrInvalidPoint if it is not valid hex or not a valid point.
+func DecodeHex(s string) (*Point, error) {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", hash2curve.ErrInvalidPoint, err)
+	}
+
+	return new(Point).SetBytes(b)
+}
+
+// SetBytes sets p to the point with the 33-byte compressed encoding in b, as returned by Bytes, and returns p. The
+// all-zero encoding is the point at infinity. It returns an error wrapping hash2curve.ErrInvalidPoint if the encoding
+// is invalid or is not that of a point on the curve.
+func (p *Point) SetBytes(b []byte) (*Point, error) {
+	if len(b) != 1+scalarLength {
+		return nil, fmt.Errorf("%w: %w", hash2curve.ErrInvalidPoint, errEncodingLength)
+	}
+
+	var xb [fp256k1.ElementLength]byte
+	copy(xb[:], b[1:])
+
+	var x, y, t, seven fp256k1.Element
+
+	if _, canonical := x.SetBytes(&xb); !canonical {
+		return nil, fmt.Errorf("%w: %w", hash2curve.ErrInvalidPoint, hash2curve.ErrNonCanonical)
+	}
+
+	switch b[0] {
+	case 0:
+		if x.IsZero() != 1 {
+			return nil, fmt.Errorf("%w: %w", hash2curve.ErrInvalidPoint, errEncodingPrefix)
+		}
+
+		return p.set(new(big.Int), new(big.Int)), nil
+	case 2, 3:
+	default:
+		return nil, fmt.Errorf("%w: %w", hash2curve.ErrInvalidPoint, errEncodingPrefix)
+	}
+
+	// y^2 = x^3 + 7.
+	seven.SetBytes(&[fp256k1.ElementLength]byte{31: 7})
+	t.Square(&x)
+	t.Multiply(&t, &x)
+	t.Add(&t, &seven)
+
+	if y.Sqrt(&t) != 1 {
+		return nil, fmt.Errorf("%w: %w", hash2curve.ErrInvalidPoint, errNotOnCurve)
+	}
+
+	var negY fp256k1.Element
+	y.Select(negY.Negate(&y), &y, y.Sgn0()^int(b[0]&1))
+
+	return p.set(x.Big(), y.Big()), nil
+}
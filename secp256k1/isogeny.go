@@ -0,0 +1,165 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+package secp256k1
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/bytemare/hash2curve"
+	"github.com/bytemare/hash2curve/internal/field/fp256k1"
+	iso "github.com/bytemare/hash2curve/internal/secp256k1"
+)
+
+// IsogenySource is the provenance of the constants of the 3-isogeny map.
+const IsogenySource = "RFC 9380, Appendix E.1"
+
+var errIsogeny = errors.New("the isogeny does not map E' to secp256k1")
+
+// Isogeny holds the constants of the 3-isogeny map from the curve E': y'^2 = x'^3 + A' * x' + B' to secp256k1, which
+// the simplified SWU map uses since secp256k1 has A = 0. The map is
+//
+//	x = XNum(x') / XDen(x'), y = y' * YNum(x') / YDen(x')
+//
+// with the coefficients of each polynomial in increasing degree, i.e. k_(i,0), k_(i,1), and so on, including the
+// leading 1 of the monic denominators. The values are copies of the constants of the implementation.
+type Isogeny struct {
+	A, B   *big.Int
+	Z      *big.Int
+	XNum   []*big.Int
+	XDen   []*big.Int
+	YNum   []*big.Int
+	YDen   []*big.Int
+	Source string
+}
+
+// IsogenyConstants returns the constants of the 3-isogeny map and of the simplified SWU map on E'.
+func IsogenyConstants() *Isogeny {
+	one := big.NewInt(1)
+
+	return &Isogeny{
+		A:      iso.IsoA.Big(),
+		B:      iso.IsoB.Big(),
+		Z:      iso.Z.Big(),
+		XNum:   bigs(&iso.K10, &iso.K11, &iso.K12, &iso.K13),
+		XDen:   append(bigs(&iso.K20, &iso.K21), one),
+		YNum:   bigs(&iso.K30, &iso.K31, &iso.K32, &iso.K33),
+		YDen:   append(bigs(&iso.K40, &iso.K41, &iso.K42), new(big.Int).Set(one)),
+		Source: IsogenySource,
+	}
+}
+
+func bigs(elements ...*fp256k1.Element) []*big.Int {
+	res := make([]*big.Int, len(elements))
+	for i, e := range elements {
+		res[i] = e.Big()
+	}
+
+	return res
+}
+
+// VerifyIsogeny checks the constants of the implementation: that E' is an elliptic curve with A' * B' != 0, and that
+// the rational maps send E' to secp256k1, i.e. that the polynomial identity
+//
+//	(x'^3 + A' * x' + B') * YNum^2 * XDen^3 = (XNum^3 + 7 * XDen^3) * YDen^2
+//
+// holds over the base field. It returns an error wrapping hash2curve.ErrSelfTest otherwise.
+func VerifyIsogeny() error {
+	return IsogenyConstants().Verify()
+}
+
+// Verify is VerifyIsogeny for the constants of i, e.g. to check a table copied from another source.
+func (i *Isogeny) Verify() error {
+	p := fp.Order()
+
+	if i.A.Sign() == 0 || i.B.Sign() == 0 {
+		return fmt.Errorf("%w: secp256k1: %w: A' * B' = 0", hash2curve.ErrSelfTest, errIsogeny)
+	}
+
+	// 4 * A'^3 + 27 * B'^2 != 0, i.e. E' is not singular.
+	d := new(big.Int).Exp(i.A, big.NewInt(3), p)
+	d.Mul(d, big.NewInt(4))
+	d.Add(d, new(big.Int).Mul(big.NewInt(27), new(big.Int).Exp(i.B, big.NewInt(2), p)))
+
+	if d.Mod(d, p).Sign() == 0 {
+		return fmt.Errorf("%w: secp256k1: %w: E' is singular", hash2curve.ErrSelfTest, errIsogeny)
+	}
+
+	curve := []*big.Int{i.B, i.A, big.NewInt(0), big.NewInt(1)}
+	xDen3 := polyMul(p, i.XDen, polyMul(p, i.XDen, i.XDen))
+	left := polyMul(p, curve, polyMul(p, polyMul(p, i.YNum, i.YNum), xDen3))
+
+	xNum3 := polyMul(p, i.XNum, polyMul(p, i.XNum, i.XNum))
+	right := polyMul(p, polyAdd(p, xNum3, polyMul(p, []*big.Int{big.NewInt(7)}, xDen3)), polyMul(p, i.YDen, i.YDen))
+
+	if !polyEqual(left, right) {
+		return fmt.Errorf("%w: secp256k1: %w", hash2curve.ErrSelfTest, errIsogeny)
+	}
+
+	return nil
+}
+
+// polyMul returns the product of the polynomials a and b modulo p, with coefficients in increasing degree.
+func polyMul(p *big.Int, a, b []*big.Int) []*big.Int {
+	res := make([]*big.Int, len(a)+len(b)-1)
+	for k := range res {
+		res[k] = new(big.Int)
+	}
+
+	var t big.Int
+
+	for i, ai := range a {
+		for j, bj := range b {
+			res[i+j].Add(res[i+j], t.Mul(ai, bj))
+		}
+	}
+
+	for _, c := range res {
+		c.Mod(c, p)
+	}
+
+	return res
+}
+
+// polyAdd returns the sum of the polynomials a and b modulo p.
+func polyAdd(p *big.Int, a, b []*big.Int) []*big.Int {
+	if len(a) < len(b) {
+		a, b = b, a
+	}
+
+	res := make([]*big.Int, len(a))
+	for k := range a {
+		res[k] = new(big.Int).Set(a[k])
+		if k < len(b) {
+			res[k].Add(res[k], b[k])
+		}
+
+		res[k].Mod(res[k], p)
+	}
+
+	return res
+}
+
+// polyEqual returns whether the reduced polynomials a and b are equal, ignoring null leading coefficients.
+func polyEqual(a, b []*big.Int) bool {
+	if len(a) < len(b) {
+		a, b = b, a
+	}
+
+	for k := range a {
+		if k < len(b) && a[k].Cmp(b[k]) != 0 || k >= len(b) && a[k].Sign() != 0 {
+			return false
+		}
+	}
+
+	return true
+}
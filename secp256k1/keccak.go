@@ -0,0 +1,48 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+package secp256k1
+
+import (
+	"math/big"
+
+	"github.com/bytemare/hash2curve"
+)
+
+const (
+	// H2CKeccak256 represents the hash-to-curve string identifier for secp256k1 with expand_message_xmd over the legacy
+	// Keccak-256 of Ethereum.
+	H2CKeccak256 = "secp256k1_XMD:KECCAK-256_SSWU_RO_"
+
+	// E2CKeccak256 represents the encode-to-curve string identifier for secp256k1 with expand_message_xmd over the
+	// legacy Keccak-256 of Ethereum.
+	E2CKeccak256 = "secp256k1_XMD:KECCAK-256_SSWU_NU_"
+)
+
+// HashToCurveKeccak256 is HashToCurve with expand_message_xmd over the legacy Keccak-256 instead of SHA-256, as in the
+// H2CKeccak256 suite, for compatibility with Ethereum contracts that can only afford the Keccak-256 of the EVM. This
+// suite is not specified by RFC 9380, and its outputs differ from those of HashToCurve.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func HashToCurveKeccak256(input, dst []byte) *Point {
+	return hashToCurve(hash2curve.ExpandXMDKeccak256, input, dst)
+}
+
+// EncodeToCurveKeccak256 is EncodeToCurve with expand_message_xmd over the legacy Keccak-256, as in the E2CKeccak256
+// suite.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func EncodeToCurveKeccak256(input, dst []byte) *Point {
+	return encodeToCurve(hash2curve.ExpandXMDKeccak256, input, dst)
+}
+
+// HashToScalarKeccak256 is HashToScalar with expand_message_xmd over the legacy Keccak-256.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func HashToScalarKeccak256(input, dst []byte) *big.Int {
+	return hash2curve.HashToFieldXMDKeccak256(input, dst, 1, 1, secLength, order)[0]
+}
@@ -6,17 +6,21 @@
 // LICENSE file in the root directory of this source tree or at
 // https://spdx.org/licenses/MIT.html
 
+//go:build !nomathbig
+
 // Package secp256k1 implements RFC9380 for the secp256k1 group.
 package secp256k1
 
+//go:generate go run gen_constants.go
+
 import (
 	"crypto"
 	"math"
 	"math/big"
 
 	"github.com/bytemare/hash2curve"
-	"github.com/bytemare/hash2curve/internal"
-	"github.com/bytemare/hash2curve/internal/field"
+	"github.com/bytemare/hash2curve/internal/field/fp256k1"
+	"github.com/bytemare/hash2curve/internal/secp256k1"
 )
 
 const (
@@ -51,13 +55,127 @@ func (p *Point) Bytes() []byte {
 	return output[:]
 }
 
+// BytesUncompressed returns the 65-byte SEC 1 uncompressed encoding 0x04 || x || y of the point, or the single byte
+// 0x00 for the point at infinity.
+func (p *Point) BytesUncompressed() []byte {
+	if p.isIdentity() {
+		return []byte{0}
+	}
+
+	output := make([]byte, 1+2*scalarLength)
+	output[0] = 4
+	p.X.FillBytes(output[1 : 1+scalarLength])
+	p.Y.FillBytes(output[1+scalarLength:])
+
+	return output
+}
+
+// BytesXY returns the 64-byte encoding x || y of the point, i.e. the uncompressed encoding without its prefix, as used
+// for public keys in Ethereum. The point at infinity is encoded to 64 zero bytes.
+func (p *Point) BytesXY() []byte {
+	output := make([]byte, 2*scalarLength)
+	p.X.FillBytes(output[:scalarLength])
+	p.Y.FillBytes(output[scalarLength:])
+
+	return output
+}
+
+// Add sets p to the sum of p1 and p2 using affine formulas, and returns p. The point at infinity is (0, 0).
+// This is not constant-time.
+func (p *Point) Add(p1, p2 *Point) *Point {
+	switch {
+	case p1.isIdentity():
+		return p.set(&p2.X, &p2.Y)
+	case p2.isIdentity():
+		return p.set(&p1.X, &p1.Y)
+	}
+
+	var t0, t1, ll, x, y big.Int
+
+	if fp.AreEqual(&p1.X, &p2.X) {
+		if !fp.AreEqual(&p1.Y, &p2.Y) || p1.Y.Sign() == 0 {
+			return p.set(new(big.Int), new(big.Int))
+		}
+
+		// Doubling: l = 3x^2 / 2y.
+		fp.Square(&t0, &p1.X)
+		fp.Add(&t1, &t0, &t0)
+		fp.Add(&t0, &t1, &t0)
+		fp.Add(&t1, &p1.Y, &p1.Y)
+	} else {
+		// l = (y2 - y1) / (x2 - x1).
+		fp.Sub(&t0, &p2.Y, &p1.Y)
+		fp.Sub(&t1, &p2.X, &p1.X)
+	}
+
+	fp.Inv(&t1, &t1)
+	fp.Mul(&ll, &t0, &t1)
+
+	fp.Square(&x, &ll)     // l^2
+	fp.Sub(&x, &x, &p1.X)  // l^2 - x1
+	fp.Sub(&x, &x, &p2.X)  // x3 = l^2 - x1 - x2
+	fp.Sub(&t0, &p1.X, &x) // x1 - x3
+	fp.Mul(&y, &t0, &ll)   // l(x1 - x3)
+	fp.Sub(&y, &y, &p1.Y)  // y3 = l(x1 - x3) - y1
+
+	return p.set(&x, &y)
+}
+
+// ScalarMult sets p to [s]q, with s reduced modulo the group order, and returns p. Like Add, this is not
+// constant-time, and must not be used with secret scalars where timing matters.
+func (p *Point) ScalarMult(s *big.Int, q *Point) *Point {
+	k := new(big.Int).Mod(s, order)
+
+	var r, base Point
+
+	base.set(&q.X, &q.Y)
+
+	for i := k.BitLen() - 1; i >= 0; i-- {
+		r.Add(&r, &r)
+
+		if k.Bit(i) == 1 {
+			r.Add(&r, &base)
+		}
+	}
+
+	return p.set(&r.X, &r.Y)
+}
+
+// Negate sets p to -q, and returns p.
+func (p *Point) Negate(q *Point) *Point {
+	if q.isIdentity() {
+		return p.set(new(big.Int), new(big.Int))
+	}
+
+	var y big.Int
+
+	fp.Neg(&y, &q.Y)
+
+	return p.set(&q.X, &y)
+}
+
+func (p *Point) isIdentity() bool {
+	return p.X.Sign() == 0 && p.Y.Sign() == 0
+}
+
+func (p *Point) set(x, y *big.Int) *Point {
+	p.X.Set(x)
+	p.Y.Set(y)
+
+	return p
+}
+
 // HashToCurve implements hash-to-curve mapping to secp256k1 of input with dst.
 // The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
 func HashToCurve(input, dst []byte) *Point {
-	u := hash2curve.HashToFieldXMD(crypto.SHA256, input, dst, 2, 1, secLength, fp.Order())
-	q0 := map2IsoCurve(u[0])
-	q1 := map2IsoCurve(u[1])
-	q0.add(q1)
+	return hashToCurve(expandSHA256, input, dst)
+}
+
+func hashToCurve(expand expander, input, dst []byte) *Point {
+	u := hashToField(expand, input, dst, 2)
+	q0 := secp256k1.MapToIsoCurve(&u[0])
+	q1 := secp256k1.MapToIsoCurve(&u[1])
+	q0.Add(q1)
 
 	return isogeny3iso(q0)
 }
@@ -65,81 +183,126 @@ func HashToCurve(input, dst []byte) *Point {
 // EncodeToCurve implements encode-to-curve mapping to secp256k1 of input with dst.
 // The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
 func EncodeToCurve(input, dst []byte) *Point {
-	u := hash2curve.HashToFieldXMD(crypto.SHA256, input, dst, 1, 1, secLength, fp.Order())
-	q0 := map2IsoCurve(u[0])
+	return encodeToCurve(expandSHA256, input, dst)
+}
+
+func encodeToCurve(expand expander, input, dst []byte) *Point {
+	u := hashToField(expand, input, dst, 1)
+	q0 := secp256k1.MapToIsoCurve(&u[0])
 
 	return isogeny3iso(q0)
 }
 
-// HashToScalar returns a safe mapping of the arbitrary input to a scalar for the prime-order group of secp256k1.
+// TryHashToCurve is HashToCurve returning an error instead of panicking, e.g. when serving untrusted input: the errors
+// of hash2curve.ValidateDST and hash2curve.ValidateInput for input and dst.
+func TryHashToCurve(input, dst []byte) (*Point, error) {
+	if err := validateInput(input, dst, 2); err != nil {
+		return nil, err
+	}
+
+	return HashToCurve(input, dst), nil
+}
+
+// TryEncodeToCurve is EncodeToCurve returning an error instead of panicking, as TryHashToCurve does.
+func TryEncodeToCurve(input, dst []byte) (*Point, error) {
+	if err := validateInput(input, dst, 1); err != nil {
+		return nil, err
+	}
+
+	return EncodeToCurve(input, dst), nil
+}
+
+// TryHashToScalar is HashToScalar returning an error instead of panicking, as TryHashToCurve does.
+func TryHashToScalar(input, dst []byte) (*big.Int, error) {
+	if err := validateInput(input, dst, 1); err != nil {
+		return nil, err
+	}
+
+	return HashToScalar(input, dst), nil
+}
+
+// validateInput returns the error hash_to_field with count elements would panic with for the input and dst.
+func validateInput(input, dst []byte, count uint) error {
+	if err := hash2curve.ValidateDST(dst, false); err != nil {
+		return err
+	}
+
+	return hash2curve.ValidateInput(input, count*secLength)
+}
+
+// HashToField implements hash_to_field of the input with dst to the base field of secp256k1, as in the hashing suites,
+// and returns count canonical field elements: HashToCurve maps the first two with MapToCurve and adds the results,
+// and EncodeToCurve maps the first one.
 // The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
-func HashToScalar(input, dst []byte) *big.Int {
-	s := hash2curve.HashToFieldXMD(crypto.SHA256, input, dst, 1, 1, secLength, fn.Order())[0]
+func HashToField(input, dst []byte, count uint) []*big.Int {
+	u := hashToField(expandSHA256, input, dst, count)
+	res := make([]*big.Int, count)
 
-	// If necessary, build a buffer of right size, so it gets correctly interpreted.
-	bytes := s.Bytes()
+	for i := range u {
+		res[i] = u[i].Big()
+	}
+
+	return res
+}
 
-	length := scalarLength
-	if l := length - len(bytes); l > 0 {
-		buf := make([]byte, l, length)
-		buf = append(buf, bytes...)
-		bytes = buf
+// expander is the signature of expand_message_xmd with its hash function set.
+type expander func(input, dst []byte, length uint) []byte
+
+func expandSHA256(input, dst []byte, length uint) []byte {
+	return hash2curve.ExpandXMD(crypto.SHA256, input, dst, length)
+}
+
+// hashToField implements hash_to_field to the base field with the expander, reducing the uniform bytes with limb
+// arithmetic.
+func hashToField(expand expander, input, dst []byte, count uint) []fp256k1.Element {
+	uniform := expand(input, dst, count*secLength)
+	defer hash2curve.Wipe(uniform)
+
+	u := make([]fp256k1.Element, count)
+
+	for i := range count {
+		u[i].SetWideBytes(uniform[i*secLength : (i+1)*secLength])
 	}
 
-	return new(big.Int).SetBytes(bytes)
+	return u
 }
 
-// add uses an affine add because the others are tailored for a = 0 and b = 7.
-func (p *Point) add(element *Point) *Point {
-	var t0, t1, ll, x, y big.Int
-	x1, y1 := &p.X, &p.Y
-	x2, y2 := &element.X, &element.Y
+// HashToScalar returns a safe mapping of the arbitrary input to a scalar for the prime-order group of secp256k1.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func HashToScalar(input, dst []byte) *big.Int {
+	return HashToScalars(input, dst, 1)[0]
+}
 
-	fp.Sub(&t0, y2, y1)   // (y2-y1)
-	fp.Sub(&t1, x2, x1)   // (x2-x1)
-	fp.Inv(&t1, &t1)      // 1/(x2-x1)
-	fp.Mul(&ll, &t0, &t1) // l = (y2-y1)/(x2-x1).
+// HashToScalars returns count scalars for secp256k1 from a single expansion of the input, see [hash2curve].
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func HashToScalars(input, dst []byte, count uint) []*big.Int {
+	return hash2curve.HashToFieldXMD(crypto.SHA256, input, dst, count, 1, secLength, order)
+}
 
-	fp.Square(&t0, &ll)  // l^2
-	fp.Sub(&t0, &t0, x1) // l^2-x1
-	fp.Sub(&x, &t0, x2)  // X' = l^2-x1-x2
+// MapToCurve implements the map_to_curve function for secp256k1, mapping the field element to a point on the
+// 3-isogenous curve and applying the isogeny map to secp256k1.
+func MapToCurve(fe *big.Int) *Point {
+	if !fp.IsCanonical(fe) {
+		panic(hash2curve.ErrNonCanonical)
+	}
 
-	fp.Sub(&t0, x1, &x)   // x1-x3
-	fp.Mul(&t0, &t0, &ll) // l(x1-x3)
-	fp.Sub(&y, &t0, y1)   // y3 = l(x1-x3)-y1.
+	return isogeny3iso(map2IsoCurve(fe))
+}
 
-	p.X.Set(&x)
-	p.Y.Set(&y)
+// Order returns a copy of the order of the group of secp256k1, i.e. the modulus of HashToScalar.
+func Order() *big.Int {
+	return new(big.Int).Set(order)
+}
 
-	return p
+// FieldPrime returns a copy of the prime of the field of secp256k1.
+func FieldPrime() *big.Int {
+	return new(big.Int).Set(fp.Order())
 }
 
-var (
-	// field order: 2^256 - 2^32 - 977
-	// = 115792089237316195423570985008687907853269984665640564039457584007908834671663
-	// = 0xfffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f.
-	fp = field.NewField(new(big.Int).SetBytes([]byte{
-		255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255,
-		255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 254, 255, 255, 252, 47,
-	}))
-
-	// group order: 2^256 - 432420386565659656852420866394968145599
-	// = 115792089237316195423570985008687907852837564279074904382605163141518161494337
-	// = xfffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141.
-	fn = field.NewField(new(big.Int).SetBytes([]byte{
-		255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 254,
-		186, 174, 220, 230, 175, 72, 160, 59, 191, 210, 94, 140, 208, 54, 65, 65,
-	}))
-
-	mapZ = new(big.Int).Mod(big.NewInt(-11), fp.Order())
-
-	// 0x3f8731abdd661adca08a5558f0f5d272e953d363cb6f0e5d405447c01a444533.
-	secp256k13ISOA = new(big.Int).SetBytes([]byte{
-		63, 135, 49, 171, 221, 102, 26, 220, 160, 138, 85, 88, 240, 245, 210, 114,
-		233, 83, 211, 99, 203, 111, 14, 93, 64, 84, 71, 192, 26, 68, 69, 51,
-	})
-	secp256k13ISOB = new(big.Int).SetBytes([]byte{6, 235}) // 1771.
-)
+// Cofactor returns the cofactor of secp256k1, which is 1 since the curve has prime order.
+func Cofactor() *big.Int {
+	return big.NewInt(1)
+}
 
 func newPoint(x, y *big.Int) *Point {
 	return &Point{
@@ -148,90 +311,20 @@ func newPoint(x, y *big.Int) *Point {
 	}
 }
 
-func map2IsoCurve(fe *big.Int) *Point {
-	x, y := internal.MapToCurveSSWU(&fp, secp256k13ISOA, secp256k13ISOB, mapZ, fe)
-	return newPoint(x, y)
+func map2IsoCurve(fe *big.Int) *secp256k1.IsoPoint {
+	var u fp256k1.Element
+	u.SetBig(fe)
+
+	return secp256k1.MapToIsoCurve(&u)
 }
 
-func isogeny3iso(e *Point) *Point {
-	x, y, isIdentity := isogenySecp256k13iso(&e.X, &e.Y)
+func isogeny3iso(e *secp256k1.IsoPoint) *Point {
+	x, y, isIdentity := secp256k1.Isogeny(&e.X, &e.Y)
 
-	if isIdentity {
+	if isIdentity == 1 {
 		return newPoint(new(big.Int), new(big.Int))
 	}
 
 	// We can save cofactor clearing because it is 1.
-	return newPoint(x, y)
-}
-
-func stringToInt(s string) *big.Int {
-	i, _ := new(big.Int).SetString(s, 0)
-	return i
-}
-
-var (
-	_k10 = stringToInt("0x8e38e38e38e38e38e38e38e38e38e38e38e38e38e38e38e38e38e38daaaaa8c7")
-	_k11 = stringToInt("0x07d3d4c80bc321d5b9f315cea7fd44c5d595d2fc0bf63b92dfff1044f17c6581")
-	_k12 = stringToInt("0x534c328d23f234e6e2a413deca25caece4506144037c40314ecbd0b53d9dd262")
-	_k13 = stringToInt("0x8e38e38e38e38e38e38e38e38e38e38e38e38e38e38e38e38e38e38daaaaa88c")
-	_k20 = stringToInt("0xd35771193d94918a9ca34ccbb7b640dd86cd409542f8487d9fe6b745781eb49b")
-	_k21 = stringToInt("0xedadc6f64383dc1df7c4b2d51b54225406d36b641f5e41bbc52a56612a8c6d14")
-	_k30 = stringToInt("0x4bda12f684bda12f684bda12f684bda12f684bda12f684bda12f684b8e38e23c")
-	_k31 = stringToInt("0xc75e0c32d5cb7c0fa9d0a54b12a0a6d5647ab046d686da6fdffc90fc201d71a3")
-	_k32 = stringToInt("0x29a6194691f91a73715209ef6512e576722830a201be2018a765e85a9ecee931")
-	_k33 = stringToInt("0x2f684bda12f684bda12f684bda12f684bda12f684bda12f684bda12f38e38d84")
-	_k40 = stringToInt("0xfffffffffffffffffffffffffffffffffffffffffffffffffffffffefffff93b")
-	_k41 = stringToInt("0x7a06534bb8bdb49fd5e9e6632722c2989467c1bfc8e8d978dfb425d2685c2573")
-	_k42 = stringToInt("0x6484aa716545ca2cf3a70c3fa8fe337e0a3d21162f0d6299a7bf8192bfd2a76f")
-)
-
-// isogenySecp256k13iso is a 3-degree isogeny from secp256k1 3-ISO to the secp256k1 elliptic curve.
-func isogenySecp256k13iso(x, y *big.Int) (px, py *big.Int, isIdentity bool) {
-	var x2, x3, k11, k12, k13, k21, k31, k32, k33, k41, k42 big.Int
-	fp.Mul(&x2, x, x)
-	fp.Mul(&x3, &x2, x)
-
-	// x_num, x_den
-	var xNum big.Int
-	fp.Mul(&k13, _k13, &x3) // _k(1,3) * x'^3
-	fp.Mul(&k12, _k12, &x2) // _k(1,2) * x'^2
-	fp.Mul(&k11, _k11, x)   // _k(1,1) * x'
-	fp.Add(&xNum, &k13, &k12)
-	fp.Add(&xNum, &xNum, &k11)
-	fp.Add(&xNum, &xNum, _k10)
-
-	var xDen big.Int
-	fp.Mul(&k21, _k21, x) // _k(2,1) * x'
-	fp.Add(&xDen, &x2, &k21)
-	fp.Add(&xDen, &xDen, _k20)
-
-	// y_num, y_den
-	var yNum big.Int
-	fp.Mul(&k33, _k33, &x3) // _k(3,3) * x'^3
-	fp.Mul(&k32, _k32, &x2) // _k(3,2) * x'^2
-	fp.Mul(&k31, _k31, x)   // _k(3,1) * x'
-	fp.Add(&yNum, &k33, &k32)
-	fp.Add(&yNum, &yNum, &k31)
-	fp.Add(&yNum, &yNum, _k30)
-
-	var yDen big.Int
-	fp.Mul(&k42, _k42, &x2) // _k(4,2) * x'^2
-	fp.Mul(&k41, _k41, x)   // _k(4,1) * x'
-	fp.Add(&yDen, &x3, &k42)
-	fp.Add(&yDen, &yDen, &k41)
-	fp.Add(&yDen, &yDen, _k40)
-
-	// final x, y
-	px, py = new(big.Int), new(big.Int)
-
-	fp.Inv(px, &xDen)
-	isIdentity = fp.IsZero(px)
-	fp.Mul(px, px, &xNum)
-
-	fp.Inv(py, &yDen)
-	isIdentity = isIdentity || fp.IsZero(py)
-	fp.Mul(py, py, &yNum)
-	fp.Mul(py, py, y)
-
-	return px, py, isIdentity
+	return newPoint(x.Big(), y.Big())
 }
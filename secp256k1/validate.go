@@ -0,0 +1,36 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+package secp256k1
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/bytemare/hash2curve"
+)
+
+var errIdentity = errors.New("the point is the identity")
+
+// Validate returns an error wrapping hash2curve.ErrInvalidPoint if the 33-byte compressed encoding is not that of a
+// point on the curve, or if it is the identity. secp256k1 has prime order, so all points on the curve are in the
+// prime-order group.
+func Validate(encoded []byte) error {
+	p, err := new(Point).SetBytes(encoded)
+	if err != nil {
+		return err
+	}
+
+	if p.isIdentity() {
+		return fmt.Errorf("%w: %w", hash2curve.ErrInvalidPoint, errIdentity)
+	}
+
+	return nil
+}
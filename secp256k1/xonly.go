@@ -0,0 +1,63 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+package secp256k1
+
+import (
+	"fmt"
+
+	"github.com/bytemare/hash2curve"
+)
+
+// XOnlyLength is the length of the x-only encoding of points, as in BIP-340.
+const XOnlyLength = 32
+
+// HashToCurveXOnly returns the 32-byte x-only encoding of HashToCurve(input, dst), for BIP-340 (Schnorr, Taproot)
+// protocols, which identify a point with its x-coordinate and implicitly use the point with an even y-coordinate.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func HashToCurveXOnly(input, dst []byte) []byte {
+	return HashToCurve(input, dst).BytesXOnly()
+}
+
+// EncodeToCurveXOnly returns the 32-byte x-only encoding of EncodeToCurve(input, dst), as HashToCurveXOnly.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func EncodeToCurveXOnly(input, dst []byte) []byte {
+	return EncodeToCurve(input, dst).BytesXOnly()
+}
+
+// BytesXOnly returns the 32-byte x-only encoding of the point as defined in BIP-340, i.e. its x-coordinate. It encodes
+// both p and -p, which SetXOnlyBytes decodes to the one with an even y-coordinate, as returned by NormalizeEvenY. The
+// point at infinity has no x-only encoding, and is encoded to 32 zero bytes, which SetXOnlyBytes rejects.
+func (p *Point) BytesXOnly() []byte {
+	return p.X.FillBytes(make([]byte, XOnlyLength))
+}
+
+// NormalizeEvenY sets p to q if q has an even y-coordinate, and to -q otherwise, and returns p. This is the point
+// represented by the x-only encoding of q in BIP-340.
+func (p *Point) NormalizeEvenY(q *Point) *Point {
+	p.set(&q.X, &q.Y)
+
+	if p.Y.Bit(0) == 1 {
+		fp.Neg(&p.Y, &p.Y)
+	}
+
+	return p
+}
+
+// SetXOnlyBytes sets p to the point with the even y-coordinate and the 32-byte x-coordinate in b, i.e. lift_x in
+// BIP-340, and returns p. It returns an error wrapping hash2curve.ErrInvalidPoint if b is not the x-coordinate of a
+// point on the curve.
+func (p *Point) SetXOnlyBytes(b []byte) (*Point, error) {
+	if len(b) != XOnlyLength {
+		return nil, fmt.Errorf("%w: %w", hash2curve.ErrInvalidPoint, errEncodingLength)
+	}
+
+	return p.SetBytes(append([]byte{2}, b...))
+}
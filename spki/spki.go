@@ -0,0 +1,125 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+// Package spki exports the points output by the hash-to-curve suites as ASN.1 DER SubjectPublicKeyInfo structures
+// (RFC 5280), and their PEM encodings, for X.509-based infrastructure: id-ecPublicKey with the named curves P-256,
+// P-384, P-521 (RFC 5480), and secp256k1 (SEC 2), with uncompressed points, and id-Ed25519 and id-X25519 (RFC 8410)
+// for the edwards25519 and curve25519 suites.
+//
+// ristretto255 has no registered algorithm identifier.
+package spki
+
+import (
+	"crypto/ecdh"
+	"crypto/ed25519"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"fmt"
+
+	"github.com/bytemare/hash2curve"
+	"github.com/bytemare/hash2curve/internal/pubkey"
+)
+
+// PEMType is the type of the PEM blocks of public keys.
+const PEMType = "PUBLIC KEY"
+
+// ErrUnsupportedCurve indicates a curve or a group that has no SubjectPublicKeyInfo representation.
+var ErrUnsupportedCurve = pubkey.ErrUnsupportedCurve
+
+var (
+	// oidECPublicKey is id-ecPublicKey, from RFC 5480.
+	oidECPublicKey = asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}
+
+	// oidSecp256k1 is the secp256k1 named curve, from SEC 2.
+	oidSecp256k1 = asn1.ObjectIdentifier{1, 3, 132, 0, 10}
+
+	// ecdhCurves maps the CURVE_IDs of the NIST curves to their crypto/ecdh implementation.
+	ecdhCurves = map[string]ecdh.Curve{
+		"P256": ecdh.P256(),
+		"P384": ecdh.P384(),
+		"P521": ecdh.P521(),
+	}
+)
+
+// subjectPublicKeyInfo is the SubjectPublicKeyInfo structure of RFC 5280.
+type subjectPublicKeyInfo struct {
+	Algorithm pkix.AlgorithmIdentifier
+	PublicKey asn1.BitString
+}
+
+// FromSuite returns the DER encoding of the SubjectPublicKeyInfo of the point in the encoding output by the suite,
+// i.e. the compressed or uncompressed SEC 1 encoding for the Weierstrass curves, and the 32-byte encoding for
+// edwards25519 and curve25519. It returns an error wrapping ErrUnsupportedCurve for the ristretto255 suites,
+// hash2curve.ErrInvalidSuite if the suite is invalid, and hash2curve.ErrInvalidPoint if the point is invalid or is the
+// point at infinity.
+func FromSuite(s hash2curve.Suite, point []byte) ([]byte, error) {
+	if !s.Available() {
+		return nil, fmt.Errorf("%w: %s", hash2curve.ErrInvalidSuite, s)
+	}
+
+	curve := s.SuiteID().Curve
+
+	x, y, err := pubkey.Coordinates(curve, point)
+	if err != nil {
+		return nil, err
+	}
+
+	var pub any
+
+	switch curve {
+	case "secp256k1":
+		return secp256k1SPKI(uncompressed(x, y))
+	case "edwards25519":
+		pub = ed25519.PublicKey(x)
+	case "curve25519":
+		if pub, err = ecdh.X25519().NewPublicKey(x); err != nil {
+			return nil, fmt.Errorf("%w: %w", hash2curve.ErrInvalidPoint, err)
+		}
+	default:
+		if pub, err = ecdhCurves[curve].NewPublicKey(uncompressed(x, y)); err != nil {
+			return nil, fmt.Errorf("%w: %w", hash2curve.ErrInvalidPoint, err)
+		}
+	}
+
+	return x509.MarshalPKIXPublicKey(pub)
+}
+
+// PEM returns the PEM encoding of FromSuite(s, point), in a "PUBLIC KEY" block.
+func PEM(s hash2curve.Suite, point []byte) ([]byte, error) {
+	der, err := FromSuite(s, point)
+	if err != nil {
+		return nil, err
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: PEMType, Bytes: der}), nil
+}
+
+// secp256k1SPKI builds the SubjectPublicKeyInfo of secp256k1 keys, which crypto/x509 doesn't support.
+func secp256k1SPKI(point []byte) ([]byte, error) {
+	curve, err := asn1.Marshal(oidSecp256k1)
+	if err != nil {
+		return nil, err
+	}
+
+	return asn1.Marshal(subjectPublicKeyInfo{
+		Algorithm: pkix.AlgorithmIdentifier{
+			Algorithm:  oidECPublicKey,
+			Parameters: asn1.RawValue{FullBytes: curve},
+		},
+		PublicKey: asn1.BitString{Bytes: point, BitLength: 8 * len(point)},
+	})
+}
+
+// uncompressed returns the uncompressed SEC 1 encoding 0x04 || x || y.
+func uncompressed(x, y []byte) []byte {
+	return append(append([]byte{4}, x...), y...)
+}
@@ -0,0 +1,181 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+package hash2curve
+
+import (
+	"crypto"
+	"io"
+	"math"
+	"math/big"
+
+	"github.com/bytemare/hash"
+	"golang.org/x/crypto/hkdf"
+	"golang.org/x/crypto/sha3"
+
+	"github.com/bytemare/hash2curve/internal"
+)
+
+// keccak256Size is the digest size of Keccak-256.
+const keccak256Size = 32
+
+// Expander identifies an expand_message function: expand_message_xmd with a fixed-length hash function or with the
+// legacy Keccak-256, or expand_message_xof with an extendable-output function. It also identifies the HKDF expansion of
+// ExpandHKDF, which is not expand_message, for the protocols that predate RFC 9380. The zero value is not usable.
+type Expander struct {
+	info   string
+	xof    hash.Hash
+	xmd    crypto.Hash
+	hkdf   crypto.Hash
+	keccak bool
+}
+
+// XMD returns the expand_message_xmd Expander using the fixed-length hash function.
+func XMD(id crypto.Hash) Expander {
+	return Expander{xmd: id}
+}
+
+// XMDKeccak256 returns the expand_message_xmd Expander using the legacy Keccak-256, as ExpandXMDKeccak256.
+func XMDKeccak256() Expander {
+	return Expander{keccak: true}
+}
+
+// XOF returns the expand_message_xof Expander using the extendable-output function.
+func XOF(id hash.Hash) Expander {
+	return Expander{xof: id}
+}
+
+// HKDF returns the Expander of ExpandHKDF with the hash function and info, whose caveats apply: it is not
+// expand_message, and is only meant for migrations that must reproduce legacy outputs.
+func HKDF(id crypto.Hash, info []byte) Expander {
+	return Expander{hkdf: id, info: string(info)}
+}
+
+// Expand returns length bytes of expand_message with input and dst, or of HKDF for an Expander returned by HKDF.
+func (e Expander) Expand(input, dst []byte, length uint) []byte {
+	switch {
+	case e.xof != 0:
+		return ExpandXOF(ExtendableXOF(e.xof.GetXOF()), input, dst, length)
+	case e.hkdf != 0:
+		return ExpandHKDF(e.hkdf, input, dst, []byte(e.info), length)
+	case e.keccak:
+		return ExpandXMDKeccak256(input, dst, length)
+	default:
+		return ExpandXMD(e.xmd, input, dst, length)
+	}
+}
+
+// MaxLength returns the maximum number of bytes the Expander can output.
+func (e Expander) MaxLength() uint {
+	switch {
+	case e.xof != 0:
+		return math.MaxUint16
+	case e.hkdf != 0:
+		return 255 * uint(e.hkdf.Size())
+	case e.keccak:
+		return 255 * keccak256Size
+	default:
+		return internal.MaxLengthXMD(e.xmd)
+	}
+}
+
+// NewExpandReader returns a reader producing the output of expand_message on demand, for protocols that consume an
+// a priori unknown number of uniform bytes, e.g. with rejection sampling.
+//
+// Since expand_message binds its output to the requested length, the stream is the output for MaxLength() bytes, and
+// the reader returns io.EOF after that. Reading n bytes therefore yields the first n bytes of
+// e.Expand(msg, dst, e.MaxLength()), which differ from e.Expand(msg, dst, n).
+//
+// The output is computed as it is read: by digest blocks with XMD and HKDF, and by squeezing the function with XOF.
+// The module-wide input length limit applies to msg, but the expansion limit does not apply to the stream.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func NewExpandReader(e Expander, msg, dst []byte) io.Reader {
+	checkInput(msg, dst, 0)
+	return e.reader(msg, dst, e.MaxLength())
+}
+
+// reader returns a reader over the length bytes of expand_message with msg and dst.
+func (e Expander) reader(msg, dst []byte, length uint) io.Reader {
+	switch {
+	case e.hkdf != 0:
+		// The output of HKDF doesn't depend on its length, so it is read as it is derived.
+		return io.LimitReader(hkdf.New(e.hkdf.New, msg, dst, []byte(e.info)), int64(length))
+	case e.xof != 0:
+		return internal.NewXOFReader(internal.ExtendableXOF{ExtendableHash: e.xof.GetXOF()}, msg, dst, length)
+	case e.keccak:
+		return internal.NewXMDHashReader(sha3.NewLegacyKeccak256(), msg, dst, length)
+	default:
+		return internal.NewXMDReader(e.xmd, msg, dst, length)
+	}
+}
+
+// FieldStream produces the elements of hash_to_field one at a time, to derive many field elements, e.g. for polynomial
+// commitments, without materializing the whole expansion and all the elements at once. It is not safe for concurrent
+// use.
+type FieldStream struct {
+	reader    io.Reader
+	modulo    *big.Int
+	buf       []byte
+	ext       uint
+	remaining uint
+}
+
+// NewFieldStream returns a FieldStream over the count elements of GF(modulo^ext) hash_to_field returns for input and
+// dst with the Expander, i.e. HashToFieldXMD or HashToFieldXOF, whose parameter requirements apply: the total
+// expansion count * ext * securityLength can't exceed that of expand_message.
+//
+// The expansion is computed as the elements are read, as with NewExpandReader, so that memory is bounded by a digest
+// and an element.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func NewFieldStream(e Expander, input, dst []byte, count, ext, securityLength uint, modulo *big.Int) *FieldStream {
+	checkHashToField(count, ext, securityLength, modulo)
+
+	length := count * ext * securityLength
+	checkInput(input, dst, length)
+
+	if length > e.MaxLength() {
+		panic(ErrLengthTooLarge)
+	}
+
+	return &FieldStream{
+		reader:    e.reader(input, dst, length),
+		modulo:    new(big.Int).Set(modulo),
+		buf:       make([]byte, securityLength),
+		ext:       ext,
+		remaining: count,
+	}
+}
+
+// Remaining returns the number of elements left in the stream.
+func (s *FieldStream) Remaining() uint {
+	return s.remaining
+}
+
+// Next returns the ext coordinates of the next element, or nil once the count elements have been returned.
+func (s *FieldStream) Next() []*big.Int {
+	if s.remaining == 0 {
+		return nil
+	}
+
+	res := make([]*big.Int, s.ext)
+	for i := range res {
+		if _, err := io.ReadFull(s.reader, s.buf); err != nil {
+			panic(err)
+		}
+
+		res[i] = reduce(s.buf, s.modulo)
+	}
+
+	if s.remaining--; s.remaining == 0 {
+		Wipe(s.buf)
+	}
+
+	return res
+}
@@ -0,0 +1,282 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package hash2curve
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const (
+	// EncodingRandomOracle is the ENC_VAR of hash_to_curve suites.
+	EncodingRandomOracle = "RO"
+
+	// EncodingNonUniform is the ENC_VAR of encode_to_curve suites.
+	EncodingNonUniform = "NU"
+
+	// ExpanderXMD is the expander tag of HASH_IDs using expand_message_xmd.
+	ExpanderXMD = "XMD"
+
+	// ExpanderXOF is the expander tag of HASH_IDs using expand_message_xof.
+	ExpanderXOF = "XOF"
+
+	suiteIDSeparator = "_"
+	hashIDSeparator  = ":"
+)
+
+var (
+	// ErrSuiteIDFormat indicates a suite identifier that is not of the form CURVE_ID_HASH_ID_MAP_ID_ENC_VAR_.
+	ErrSuiteIDFormat = errors.New("malformed suite identifier")
+
+	// ErrSuiteIDCurve indicates an unregistered CURVE_ID.
+	ErrSuiteIDCurve = errors.New("unknown curve identifier")
+
+	// ErrSuiteIDHash indicates a HASH_ID that is malformed or not registered for the curve.
+	ErrSuiteIDHash = errors.New("hash identifier does not match the curve")
+
+	// ErrSuiteIDMap indicates a MAP_ID not registered for the curve.
+	ErrSuiteIDMap = errors.New("map identifier does not match the curve")
+
+	// ErrSuiteIDEncoding indicates an ENC_VAR that is neither RO nor NU.
+	ErrSuiteIDEncoding = errors.New("unknown encoding variant")
+)
+
+// SuiteIDError reports an invalid suite identifier, the faulty component, and the reason.
+type SuiteIDError struct {
+	Err       error
+	ID        string
+	Component string
+}
+
+// Error implements the error interface.
+func (e *SuiteIDError) Error() string {
+	if e.Component == "" {
+		return fmt.Sprintf("invalid suite ID %q: %v", e.ID, e.Err)
+	}
+
+	return fmt.Sprintf("invalid suite ID %q: %v: %q", e.ID, e.Err, e.Component)
+}
+
+// Is reports whether target is ErrInvalidSuite, which all suite identifier errors match.
+func (e *SuiteIDError) Is(target error) bool {
+	return target == ErrInvalidSuite
+}
+
+// Unwrap returns the underlying sentinel error.
+func (e *SuiteIDError) Unwrap() error {
+	return e.Err
+}
+
+// SuiteID holds the components of a hash-to-curve suite identifier, as defined in RFC 9380 section 8.10.
+type SuiteID struct {
+	// Curve is the CURVE_ID, e.g. "P256".
+	Curve string
+
+	// Hash is the HASH_ID, i.e. the expander and hash function, e.g. "XMD:SHA-256".
+	Hash string
+
+	// Map is the MAP_ID, e.g. "SSWU".
+	Map string
+
+	// Encoding is the ENC_VAR, either "RO" or "NU".
+	Encoding string
+}
+
+type suiteParams struct {
+	hashes []string
+	mapID  string
+}
+
+// registeredSuites maps the CURVE_IDs of the suites in RFC 9380 (and ristretto255 from RFC 9496), and of those of the
+// other curves implemented in this module, to their parameters. Some curves have suites with several hash functions.
+var registeredSuites = map[string]suiteParams{
+	"P256":         {[]string{"XMD:SHA-256"}, "SSWU"},
+	"P384":         {[]string{"XMD:SHA-384"}, "SSWU"},
+	"P521":         {[]string{"XMD:SHA-512"}, "SSWU"},
+	"curve25519":   {[]string{"XMD:SHA-512"}, "ELL2"},
+	"edwards25519": {[]string{"XMD:SHA-512"}, "ELL2"},
+	"curve448":     {[]string{"XOF:SHAKE256"}, "ELL2"},
+	"edwards448":   {[]string{"XOF:SHAKE256"}, "ELL2"},
+	"secp256k1":    {[]string{"XMD:SHA-256", "XMD:KECCAK-256"}, "SSWU"},
+	"BLS12381G1":   {[]string{"XMD:SHA-256"}, "SSWU"},
+	"BLS12381G2":   {[]string{"XMD:SHA-256"}, "SSWU"},
+	"ristretto255": {[]string{"XMD:SHA-512"}, "R255MAP"},
+	"decaf448":     {[]string{"XOF:SHAKE256"}, "D448MAP"},
+	"E521":         {[]string{"XMD:SHA-512"}, "ELL2"},
+	"curve41417":   {[]string{"XMD:SHA-512"}, "ELL2"},
+	"M511":         {[]string{"XMD:SHA-512"}, "ELL2"},
+	"MNT4298G1":    {[]string{"XMD:SHA-256"}, "SSWU"},
+	"MNT4298G2":    {[]string{"XMD:SHA-256"}, "SSWU"},
+	"MNT6298G1":    {[]string{"XMD:SHA-256"}, "SSWU"},
+	"MNT6298G2":    {[]string{"XMD:SHA-256"}, "SSWU"},
+	"BLS24315G1":   {[]string{"XMD:SHA-256"}, "SVDW"},
+	"BLS24315G2":   {[]string{"XMD:SHA-256"}, "SVDW"},
+	"secp192r1":    {[]string{"XMD:SHA-256"}, "SSWU"},
+	"secp224k1":    {[]string{"XMD:SHA-256"}, "SVDW"},
+	"BN254G1":      {[]string{"XMD:KECCAK-256"}, "SVDW"},
+}
+
+// String returns the suite identifier string CURVE_ID "_" HASH_ID "_" MAP_ID "_" ENC_VAR "_".
+func (s SuiteID) String() string {
+	return s.Curve + suiteIDSeparator + s.Hash + suiteIDSeparator + s.Map + suiteIDSeparator + s.Encoding +
+		suiteIDSeparator
+}
+
+// Validate checks that the components are those of a registered suite, and returns a *SuiteIDError otherwise.
+func (s SuiteID) Validate() error {
+	id := s.String()
+
+	params, ok := registeredSuites[s.Curve]
+	if !ok {
+		return &SuiteIDError{ID: id, Component: s.Curve, Err: ErrSuiteIDCurve}
+	}
+
+	if !slices.Contains(params.hashes, s.Hash) {
+		return &SuiteIDError{ID: id, Component: s.Hash, Err: ErrSuiteIDHash}
+	}
+
+	if s.Map != params.mapID {
+		return &SuiteIDError{ID: id, Component: s.Map, Err: ErrSuiteIDMap}
+	}
+
+	if s.Encoding != EncodingRandomOracle && s.Encoding != EncodingNonUniform {
+		return &SuiteIDError{ID: id, Component: s.Encoding, Err: ErrSuiteIDEncoding}
+	}
+
+	return nil
+}
+
+// NewSuiteID validates the components and returns the corresponding suite identifier string.
+func NewSuiteID(curve, hashID, mapID, encoding string) (string, error) {
+	s := SuiteID{Curve: curve, Hash: hashID, Map: mapID, Encoding: encoding}
+	if err := s.Validate(); err != nil {
+		return "", err
+	}
+
+	return s.String(), nil
+}
+
+// HashID returns the HASH_ID for the expander tag and hash function name, e.g. "XMD:SHA-256".
+func HashID(expander, hashName string) string {
+	return expander + hashIDSeparator + hashName
+}
+
+// Expander returns the expander tag of the HASH_ID, i.e. ExpanderXMD or ExpanderXOF, or an empty string if the HASH_ID
+// is malformed.
+func (s SuiteID) Expander() string {
+	expander, _, ok := strings.Cut(s.Hash, hashIDSeparator)
+	if !ok {
+		return ""
+	}
+
+	return expander
+}
+
+// HashName returns the hash function name of the HASH_ID, e.g. "SHA-256", or an empty string if the HASH_ID is
+// malformed.
+func (s SuiteID) HashName() string {
+	_, name, _ := strings.Cut(s.Hash, hashIDSeparator)
+	return name
+}
+
+// RandomOracle returns whether the ENC_VAR is that of hash_to_curve suites.
+func (s SuiteID) RandomOracle() bool {
+	return s.Encoding == EncodingRandomOracle
+}
+
+// ParseSuiteID decomposes the suite identifier into its components, checking only its structure and not whether the
+// suite is registered, so it also accepts suites this package does not know of. The HASH_ID must be of the form
+// EXPANDER ":" HASH_NAME with an XMD or XOF expander. The result can be reassembled with SuiteID.String, and checked
+// with SuiteID.Validate.
+func ParseSuiteID(id string) (*SuiteID, error) {
+	components := strings.Split(id, suiteIDSeparator)
+
+	// A trailing separator yields an empty last component.
+	if len(components) != 5 || components[4] != "" {
+		return nil, &SuiteIDError{ID: id, Err: ErrSuiteIDFormat}
+	}
+
+	for _, c := range components[:4] {
+		if c == "" {
+			return nil, &SuiteIDError{ID: id, Err: ErrSuiteIDFormat}
+		}
+	}
+
+	s := &SuiteID{
+		Curve:    components[0],
+		Hash:     components[1],
+		Map:      components[2],
+		Encoding: components[3],
+	}
+
+	if expander := s.Expander(); (expander != ExpanderXMD && expander != ExpanderXOF) || s.HashName() == "" {
+		return nil, &SuiteIDError{ID: id, Component: s.Hash, Err: ErrSuiteIDHash}
+	}
+
+	return s, nil
+}
+
+// ValidateSuiteID parses the suite identifier and checks it against the registered suites, returning its components
+// or a *SuiteIDError.
+func ValidateSuiteID(id string) (*SuiteID, error) {
+	s, err := ParseSuiteID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.Validate(); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// MarshalText implements encoding.TextMarshaler, returning the suite identifier string if the suite is valid.
+func (s SuiteID) MarshalText() ([]byte, error) {
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, parsing and validating the suite identifier string.
+func (s *SuiteID) UnmarshalText(text []byte) error {
+	id, err := ValidateSuiteID(string(text))
+	if err != nil {
+		return err
+	}
+
+	*s = *id
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, encoding the suite as its identifier string.
+func (s SuiteID) MarshalJSON() ([]byte, error) {
+	text, err := s.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(string(text))
+}
+
+// UnmarshalJSON implements json.Unmarshaler, decoding and validating a suite identifier string.
+func (s *SuiteID) UnmarshalJSON(data []byte) error {
+	var id string
+	if err := json.Unmarshal(data, &id); err != nil {
+		return err
+	}
+
+	return s.UnmarshalText([]byte(id))
+}
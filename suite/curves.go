@@ -0,0 +1,368 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+package suite
+
+import (
+	"crypto"
+	"math/big"
+
+	ed "filippo.io/edwards25519"
+	"filippo.io/nistec"
+	"github.com/gtank/ristretto255"
+
+	"github.com/bytemare/hash2curve"
+	"github.com/bytemare/hash2curve/bls24315"
+	"github.com/bytemare/hash2curve/bn254"
+	"github.com/bytemare/hash2curve/curve41417"
+	"github.com/bytemare/hash2curve/e521"
+	"github.com/bytemare/hash2curve/edwards25519"
+	"github.com/bytemare/hash2curve/m511"
+	"github.com/bytemare/hash2curve/mnt4298"
+	"github.com/bytemare/hash2curve/mnt6298"
+	"github.com/bytemare/hash2curve/nist"
+	h2cristretto255 "github.com/bytemare/hash2curve/ristretto255"
+	"github.com/bytemare/hash2curve/secp192r1"
+	"github.com/bytemare/hash2curve/secp224k1"
+	"github.com/bytemare/hash2curve/secp256k1"
+)
+
+// point is the common interface of the curve-specific points used by the suites.
+type point interface {
+	add(q point) point
+	clearCofactor() point
+	encode(e Encoding) []byte
+}
+
+// curve holds the parameters of a curve and its default suite parameters.
+type curve struct {
+	mapToCurve   func(u []*big.Int) point // maps the m coordinates of an element of GF(p^m)
+	encodeScalar func(s *big.Int) []byte
+	expand       hash2curve.Expander // the expand_message function, if not expand_message_xmd with hash
+	field        *big.Int
+	order        *big.Int
+	hash         crypto.Hash
+	secLength    uint
+	scalarLength uint // the length L of hash-to-scalar, if not secLength
+	k            uint // the target security level, in bits
+	degree       uint // the extension degree m of the field, if not 1
+	cofactor     uint64
+	littleEndian bool // whether encodeScalar returns little-endian scalars
+}
+
+// curveKey identifies the curve of a suite by its CURVE_ID and HASH_ID, since a curve can have suites with several
+// hash functions.
+type curveKey struct {
+	curve, hash string
+}
+
+// m returns the extension degree of the field of the curve.
+func (c *curve) m() uint {
+	return max(c.degree, 1)
+}
+
+func fixedLength(length int) func(s *big.Int) []byte {
+	return func(s *big.Int) []byte {
+		return s.FillBytes(make([]byte, length))
+	}
+}
+
+// curves maps the CURVE_IDs and HASH_IDs to their implementation. ristretto255 is handled separately since it does not
+// use hash_to_field.
+var curves = map[curveKey]*curve{
+	{"P256", "XMD:SHA-256"}: {
+		mapToCurve:   func(u []*big.Int) point { return (*p256Point)(nist.MapToCurveP256(u[0])) },
+		encodeScalar: fixedLength(32),
+		field:        nist.FieldPrimeP256(),
+		order:        nist.OrderP256(),
+		hash:         crypto.SHA256,
+		secLength:    48,
+		k:            128,
+		cofactor:     1,
+	},
+	{"P384", "XMD:SHA-384"}: {
+		mapToCurve:   func(u []*big.Int) point { return (*p384Point)(nist.MapToCurveP384(u[0])) },
+		encodeScalar: fixedLength(48),
+		field:        nist.FieldPrimeP384(),
+		order:        nist.OrderP384(),
+		hash:         crypto.SHA384,
+		secLength:    72,
+		k:            192,
+		cofactor:     1,
+	},
+	{"P521", "XMD:SHA-512"}: {
+		mapToCurve:   func(u []*big.Int) point { return (*p521Point)(nist.MapToCurveP521(u[0])) },
+		encodeScalar: fixedLength(66),
+		field:        nist.FieldPrimeP521(),
+		order:        nist.OrderP521(),
+		hash:         crypto.SHA512,
+		secLength:    98,
+		k:            256,
+		cofactor:     1,
+	},
+	{"curve25519", "XMD:SHA-512"}:   edwards25519Curve(true),
+	{"edwards25519", "XMD:SHA-512"}: edwards25519Curve(false),
+	{"secp256k1", "XMD:SHA-256"}: {
+		mapToCurve:   func(u []*big.Int) point { return (*secp256k1Point)(secp256k1.MapToCurve(u[0])) },
+		encodeScalar: fixedLength(32),
+		field:        secp256k1.FieldPrime(),
+		order:        secp256k1.Order(),
+		hash:         crypto.SHA256,
+		secLength:    48,
+		k:            128,
+		cofactor:     1,
+	},
+	{"E521", "XMD:SHA-512"}: otherCurve(&curve{
+		mapToCurve:   func(u []*big.Int) point { return newPackagePoint(e521.MapToCurve(u[0])) },
+		field:        e521.FieldPrime(),
+		order:        e521.Order(),
+		hash:         crypto.SHA512,
+		k:            256,
+		littleEndian: true,
+	}, e521.Cofactor()),
+	{"curve41417", "XMD:SHA-512"}: otherCurve(&curve{
+		mapToCurve:   func(u []*big.Int) point { return newPackagePoint(curve41417.MapToCurve(u[0])) },
+		field:        curve41417.FieldPrime(),
+		order:        curve41417.Order(),
+		hash:         crypto.SHA512,
+		k:            192,
+		littleEndian: true,
+	}, curve41417.Cofactor()),
+	{"M511", "XMD:SHA-512"}: otherCurve(&curve{
+		mapToCurve:   func(u []*big.Int) point { return newPackagePoint(m511.MapToCurve(u[0])) },
+		field:        m511.FieldPrime(),
+		order:        m511.Order(),
+		hash:         crypto.SHA512,
+		k:            256,
+		littleEndian: true,
+	}, m511.Cofactor()),
+	{"MNT4298G1", "XMD:SHA-256"}: otherCurve(&curve{
+		mapToCurve: func(u []*big.Int) point { return newPackagePoint(mnt4298.MapToG1(u[0])) },
+		field:      mnt4298.FieldPrime(),
+		order:      mnt4298.Order(),
+		hash:       crypto.SHA256,
+		k:          128,
+	}, mnt4298.CofactorG1()),
+	{"MNT4298G2", "XMD:SHA-256"}: otherCurve(&curve{
+		mapToCurve: func(u []*big.Int) point { return newPackagePoint(mnt4298.MapToG2([2]*big.Int(u))) },
+		field:      mnt4298.FieldPrime(),
+		order:      mnt4298.Order(),
+		hash:       crypto.SHA256,
+		k:          128,
+		degree:     2,
+	}, mnt4298.CofactorG2()),
+	{"MNT6298G1", "XMD:SHA-256"}: otherCurve(&curve{
+		mapToCurve: func(u []*big.Int) point { return newPackagePoint(mnt6298.MapToG1(u[0])) },
+		field:      mnt6298.FieldPrime(),
+		order:      mnt6298.Order(),
+		hash:       crypto.SHA256,
+		k:          128,
+	}, mnt6298.CofactorG1()),
+	{"MNT6298G2", "XMD:SHA-256"}: otherCurve(&curve{
+		mapToCurve: func(u []*big.Int) point { return newPackagePoint(mnt6298.MapToG2([3]*big.Int(u))) },
+		field:      mnt6298.FieldPrime(),
+		order:      mnt6298.Order(),
+		hash:       crypto.SHA256,
+		k:          128,
+		degree:     3,
+	}, mnt6298.CofactorG2()),
+	{"BLS24315G1", "XMD:SHA-256"}: otherCurve(&curve{
+		mapToCurve: func(u []*big.Int) point { return newPackagePoint(bls24315.MapToG1(u[0])) },
+		field:      bls24315.FieldPrime(),
+		order:      bls24315.Order(),
+		hash:       crypto.SHA256,
+		k:          128,
+	}, bls24315.CofactorG1()),
+	{"BLS24315G2", "XMD:SHA-256"}: otherCurve(&curve{
+		mapToCurve: func(u []*big.Int) point { return newPackagePoint(bls24315.MapToG2([4]*big.Int(u))) },
+		field:      bls24315.FieldPrime(),
+		order:      bls24315.Order(),
+		hash:       crypto.SHA256,
+		k:          128,
+		degree:     4,
+	}, bls24315.CofactorG2()),
+	{"secp192r1", "XMD:SHA-256"}: otherCurve(&curve{
+		mapToCurve: func(u []*big.Int) point { return newPackagePoint(secp192r1.MapToCurve(u[0])) },
+		field:      secp192r1.FieldPrime(),
+		order:      secp192r1.Order(),
+		hash:       crypto.SHA256,
+		k:          128,
+	}, secp192r1.Cofactor()),
+	{"secp224k1", "XMD:SHA-256"}: otherCurve(&curve{
+		mapToCurve: func(u []*big.Int) point { return newPackagePoint(secp224k1.MapToCurve(u[0])) },
+		field:      secp224k1.FieldPrime(),
+		order:      secp224k1.Order(),
+		hash:       crypto.SHA256,
+		k:          128,
+	}, secp224k1.Cofactor()),
+	{"secp256k1", "XMD:KECCAK-256"}: {
+		mapToCurve:   func(u []*big.Int) point { return (*secp256k1Point)(secp256k1.MapToCurve(u[0])) },
+		encodeScalar: fixedLength(32),
+		expand:       hash2curve.XMDKeccak256(),
+		field:        secp256k1.FieldPrime(),
+		order:        secp256k1.Order(),
+		secLength:    48,
+		k:            128,
+		cofactor:     1,
+	},
+	{"BN254G1", "XMD:KECCAK-256"}: otherCurve(&curve{
+		mapToCurve: func(u []*big.Int) point { return newPackagePoint(bn254.MapToCurve(u[0])) },
+		expand:     hash2curve.XMDKeccak256(),
+		field:      bn254.FieldPrime(),
+		order:      bn254.Order(),
+		k:          128,
+	}, bn254.Cofactor()),
+}
+
+func edwards25519Curve(montgomery bool) *curve {
+	return &curve{
+		mapToCurve: func(u []*big.Int) point {
+			return &edwardsPoint{Point: edwards25519.MapToCurve(u[0]), montgomery: montgomery}
+		},
+		encodeScalar: func(s *big.Int) []byte {
+			b := s.FillBytes(make([]byte, 32))
+			reverse(b)
+
+			return b
+		},
+		field:        edwards25519.FieldPrime(),
+		order:        edwards25519.Order(),
+		hash:         crypto.SHA512,
+		secLength:    48,
+		k:            128,
+		cofactor:     8,
+		littleEndian: true,
+	}
+}
+
+func reverse(b []byte) {
+	for i := range len(b) / 2 {
+		b[i], b[len(b)-1-i] = b[len(b)-1-i], b[i]
+	}
+}
+
+type p256Point nistec.P256Point
+
+func (p *p256Point) add(q point) point {
+	return (*p256Point)((*nistec.P256Point)(p).Add((*nistec.P256Point)(p), (*nistec.P256Point)(q.(*p256Point))))
+}
+
+func (p *p256Point) clearCofactor() point { return p }
+
+func (p *p256Point) encode(e Encoding) []byte {
+	if e == Uncompressed {
+		return (*nistec.P256Point)(p).Bytes()
+	}
+
+	return (*nistec.P256Point)(p).BytesCompressed()
+}
+
+type p384Point nistec.P384Point
+
+func (p *p384Point) add(q point) point {
+	return (*p384Point)((*nistec.P384Point)(p).Add((*nistec.P384Point)(p), (*nistec.P384Point)(q.(*p384Point))))
+}
+
+func (p *p384Point) clearCofactor() point { return p }
+
+func (p *p384Point) encode(e Encoding) []byte {
+	if e == Uncompressed {
+		return (*nistec.P384Point)(p).Bytes()
+	}
+
+	return (*nistec.P384Point)(p).BytesCompressed()
+}
+
+type p521Point nistec.P521Point
+
+func (p *p521Point) add(q point) point {
+	return (*p521Point)((*nistec.P521Point)(p).Add((*nistec.P521Point)(p), (*nistec.P521Point)(q.(*p521Point))))
+}
+
+func (p *p521Point) clearCofactor() point { return p }
+
+func (p *p521Point) encode(e Encoding) []byte {
+	if e == Uncompressed {
+		return (*nistec.P521Point)(p).Bytes()
+	}
+
+	return (*nistec.P521Point)(p).BytesCompressed()
+}
+
+type secp256k1Point secp256k1.Point
+
+func (p *secp256k1Point) add(q point) point {
+	return (*secp256k1Point)((*secp256k1.Point)(p).Add((*secp256k1.Point)(p), (*secp256k1.Point)(q.(*secp256k1Point))))
+}
+
+func (p *secp256k1Point) clearCofactor() point { return p }
+
+func (p *secp256k1Point) encode(e Encoding) []byte {
+	if e != Uncompressed {
+		return (*secp256k1.Point)(p).Bytes()
+	}
+
+	return (*secp256k1.Point)(p).BytesUncompressed()
+}
+
+// edwardsPoint holds a point of edwards25519, which is encoded as its Montgomery u-coordinate for curve25519.
+type edwardsPoint struct {
+	*ed.Point
+	montgomery bool
+}
+
+func (p *edwardsPoint) add(q point) point {
+	p.Add(p.Point, q.(*edwardsPoint).Point)
+	return p
+}
+
+func (p *edwardsPoint) clearCofactor() point {
+	p.MultByCofactor(p.Point)
+	return p
+}
+
+func (p *edwardsPoint) encode(_ Encoding) []byte {
+	if p.montgomery {
+		return p.BytesMontgomery()
+	}
+
+	return p.Bytes()
+}
+
+// ristretto255UniformLength is the length of the uniform strings mapped to ristretto255 elements and scalars.
+const ristretto255UniformLength = 64
+
+// ristretto255Hash implements the R255MAP suites, which expand uniform bytes instead of using hash_to_field.
+func ristretto255Hash(expand boundExpander, input []byte, randomOracle bool) []byte {
+	if randomOracle {
+		uniform := expand(input, ristretto255UniformLength)
+		defer hash2curve.Wipe(uniform)
+
+		return ristretto255.NewElement().FromUniformBytes(uniform).Encode(nil)
+	}
+
+	uniform := expand(input, 32)
+	defer hash2curve.Wipe(uniform)
+
+	return h2cristretto255.MapToGroup(uniform).Encode(nil)
+}
+
+func ristretto255Scalars(expand boundExpander, input []byte, count uint) [][]byte {
+	uniform := expand(input, count*ristretto255UniformLength)
+	defer hash2curve.Wipe(uniform)
+
+	res := make([][]byte, count)
+	for i := range count {
+		u := uniform[i*ristretto255UniformLength : (i+1)*ristretto255UniformLength]
+		res[i] = ristretto255.NewScalar().FromUniformBytes(u).Encode(nil)
+	}
+
+	return res
+}
@@ -0,0 +1,81 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+package suite
+
+import (
+	"github.com/bytemare/hash2curve"
+	"github.com/bytemare/hash2curve/internal"
+)
+
+// Hasher is a Suite bound to a DST, for repeated hashing with the same parameters. The hash function, the DST prime,
+// and the curve constants are resolved once at construction instead of on each call. It is safe for concurrent use.
+type Hasher struct {
+	suite  *Suite
+	expand boundExpander
+}
+
+// NewHasher returns a Hasher for the suite identifier and the DST, with the options applied. It returns an error if
+// the identifier is invalid, if the suite is not implemented, or if the DST is empty or too short under the strict DST
+// policy.
+func NewHasher(id string, dst []byte, opts ...Option) (*Hasher, error) {
+	s, err := New(id, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Hasher(dst)
+}
+
+// Hasher returns a Hasher for the suite bound to the DST. It returns an error if the DST is empty, or if it is too
+// short under the strict DST policy.
+func (s *Suite) Hasher(dst []byte) (*Hasher, error) {
+	if err := hash2curve.ValidateDST(dst, s.strictDST); err != nil {
+		return nil, err
+	}
+
+	h := &Hasher{suite: s}
+
+	if s.xmd != 0 {
+		h.expand = internal.PrepareXMD(s.xmd, dst).Expand
+	} else {
+		h.expand = s.bind(append([]byte(nil), dst...))
+	}
+
+	return h, nil
+}
+
+// Suite returns the underlying suite.
+func (h *Hasher) Suite() *Suite {
+	return h.suite
+}
+
+// HashToCurve implements hash_to_curve of the input with the suite's curve, hash function, and mapping, and returns the
+// encoded point. For a NU suite, this is the RO suite with the same parameters.
+func (h *Hasher) HashToCurve(input []byte) []byte {
+	return h.suite.hash(h.expand, input, true, nil)
+}
+
+// EncodeToCurve implements encode_to_curve of the input with the suite's curve, hash function, and mapping, and returns
+// the encoded point. For a RO suite, this is the NU suite with the same parameters.
+func (h *Hasher) EncodeToCurve(input []byte) []byte {
+	return h.suite.hash(h.expand, input, false, nil)
+}
+
+// HashToScalar returns a safe mapping of the arbitrary input to an encoded scalar of the prime-order group.
+func (h *Hasher) HashToScalar(input []byte) []byte {
+	return h.suite.hashToScalar(h.expand, input)
+}
+
+// HashToScalars returns count independent encoded scalars of the prime-order group from a single expansion of the
+// input, as Suite.HashToScalars.
+func (h *Hasher) HashToScalars(input []byte, count uint) [][]byte {
+	return h.suite.hashToScalars(h.expand, input, count)
+}
@@ -0,0 +1,157 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+package suite
+
+import (
+	"crypto"
+
+	"github.com/bytemare/hash2curve"
+)
+
+// Encoding selects the output encoding of points.
+type Encoding byte
+
+const (
+	// Compressed is the default encoding: SEC 1 compressed points for Weierstrass curves, and the canonical 32-byte
+	// encodings for edwards25519 and ristretto255.
+	Compressed Encoding = iota
+
+	// Uncompressed selects SEC 1 uncompressed points for Weierstrass curves. It has no effect on edwards25519 and
+	// ristretto255.
+	Uncompressed
+)
+
+// ScalarByteOrder selects the byte order of encoded scalars.
+type ScalarByteOrder byte
+
+const (
+	// NativeByteOrder is the default byte order of the group: big-endian for Weierstrass curves, and little-endian for
+	// edwards25519, curve25519, and ristretto255.
+	NativeByteOrder ScalarByteOrder = iota
+
+	// BigEndian selects big-endian scalars, e.g. for the SEC 1 conventions.
+	BigEndian
+
+	// LittleEndian selects little-endian scalars, e.g. for dalek-style libraries.
+	LittleEndian
+)
+
+// Option configures a deviation from the suite's defaults.
+type Option func(*config)
+
+type config struct {
+	expand         hash2curve.Expander
+	xmd            crypto.Hash // the hash function of the default expand_message_xmd, or 0 when overridden
+	secLength      uint
+	secLevel       uint
+	maxInput       uint
+	clearer        CofactorClearer
+	encoding       Encoding
+	scalarOrder    ScalarByteOrder
+	clearCofactor  bool
+	strictDST      bool
+	strictSecurity bool
+}
+
+// WithExpander overrides the expand_message function of the suite, e.g. with hash2curve.XOF, or hash2curve.HKDF to
+// reproduce the outputs of protocols specified before RFC 9380.
+func WithExpander(expand hash2curve.Expander) Option {
+	return func(c *config) {
+		c.expand = expand
+		c.xmd = 0
+	}
+}
+
+// WithSecurityLength overrides the length L of each element in hash_to_field, for hashing to the curve and to
+// scalars. It has no effect on ristretto255, which always expands 64 bytes.
+func WithSecurityLength(length uint) Option {
+	return func(c *config) {
+		c.secLength = length
+	}
+}
+
+// WithSecurityLevel sets the length L of each element in hash_to_field to ceil((ceil(log2(p)) + k) / 8) for the
+// security level of k bits, as computed by hash2curve.SecurityLength for the suite's field, and takes precedence over
+// WithSecurityLength. k must be at least 128. It has no effect on ristretto255, which always expands 64 bytes.
+func WithSecurityLevel(k uint) Option {
+	return func(c *config) {
+		c.secLevel = k
+	}
+}
+
+// WithOutputEncoding sets the encoding of output points.
+func WithOutputEncoding(encoding Encoding) Option {
+	return func(c *config) {
+		c.encoding = encoding
+	}
+}
+
+// WithScalarByteOrder sets the byte order of the scalars of HashToScalar and HashToScalars, instead of that of the
+// group, so that callers don't reverse them manually.
+func WithScalarByteOrder(order ScalarByteOrder) Option {
+	return func(c *config) {
+		c.scalarOrder = order
+	}
+}
+
+// WithCofactorClearing enables or disables cofactor clearing, which is enabled by default. Disabling it only has an
+// effect on curves with a cofactor larger than 1, and returns the raw mapped points, which may not be in the
+// prime-order subgroup. It replaces any previous WithCofactorClearer.
+func WithCofactorClearing(enabled bool) Option {
+	return func(c *config) {
+		c.clearCofactor = enabled
+		c.clearer = nil
+	}
+}
+
+// CofactorClearer implements a custom cofactor clearing method, e.g. using an endomorphism instead of the
+// multiplication by h_eff. It receives the raw mapped point in the suite's output encoding, and returns the encoding of
+// the point with the cofactor cleared.
+type CofactorClearer interface {
+	ClearCofactor(encoded []byte) []byte
+}
+
+// WithCofactorClearer replaces the suite's cofactor clearing with the custom method. It has no effect on ristretto255,
+// which has no cofactor.
+func WithCofactorClearer(clearer CofactorClearer) Option {
+	return func(c *config) {
+		c.clearCofactor = true
+		c.clearer = clearer
+	}
+}
+
+// WithStrictDST enables or disables the strict DST policy for the suite, which rejects DSTs shorter than the 16 bytes
+// recommended by RFC 9380 with hash2curve.ErrShortDST. It only applies to the suite.
+func WithStrictDST(enabled bool) Option {
+	return func(c *config) {
+		c.strictDST = enabled
+	}
+}
+
+// WithStrictSecurity enables or disables the strict security policy for the suite, which rejects a length L of
+// hash_to_field, set with WithSecurityLength or WithSecurityLevel, below the target security level of the curve, e.g.
+// 256 bits for P-521, with an error wrapping hash2curve.ErrInsufficientSecurity. The hash function of the default
+// expand_message_xmd is checked as well, but not the expanders set with WithExpander. The module-wide policy set with
+// hash2curve.SetStrictSecurity applies in addition.
+func WithStrictSecurity(enabled bool) Option {
+	return func(c *config) {
+		c.strictSecurity = enabled
+	}
+}
+
+// WithMaxInputLength caps the length of the input messages accepted by the suite, to bound the resources used on
+// untrusted input. Longer inputs are rejected with hash2curve.ErrInputTooLong. A zero length disables the limit, which
+// is the default. The module-wide limits set with hash2curve.SetInputLimits apply in addition.
+func WithMaxInputLength(length uint) Option {
+	return func(c *config) {
+		c.maxInput = length
+	}
+}
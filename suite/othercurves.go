@@ -0,0 +1,79 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+package suite
+
+import (
+	"math/big"
+
+	"github.com/bytemare/hash2curve"
+)
+
+// otherCurve completes the parameters of a curve that is not in RFC 9380 as its package sets them: L is that of the
+// field for hash_to_field and that of the group order for hash-to-scalar, and scalars have the length of the order.
+// h_eff is left at 0 if it doesn't fit in 64 bits.
+func otherCurve(c *curve, cofactor *big.Int) *curve {
+	c.secLength = hash2curve.SecurityLength(c.field, c.k)
+	c.scalarLength = hash2curve.SecurityLength(c.order, c.k)
+	c.encodeScalar = fixedLength((c.order.BitLen() + 7) / 8)
+
+	if c.littleEndian {
+		c.encodeScalar = func(s *big.Int) []byte {
+			b := s.FillBytes(make([]byte, (c.order.BitLen()+7)/8))
+			reverse(b)
+
+			return b
+		}
+	}
+
+	if cofactor.IsUint64() {
+		c.cofactor = cofactor.Uint64()
+	}
+
+	return c
+}
+
+// packagePointer is the pointer to a point of the package of a curve that is not in RFC 9380.
+type packagePointer[T any] interface {
+	*T
+	Add(p1, p2 *T) *T
+	Bytes() []byte
+}
+
+// packagePoint adapts the points of the packages of the curves that are not in RFC 9380, which implement
+// ClearCofactor if their cofactor is not 1, and BytesUncompressed if they have SEC 1 encodings.
+type packagePoint[T any, P packagePointer[T]] struct {
+	p P
+}
+
+func newPackagePoint[T any, P packagePointer[T]](p P) point {
+	return packagePoint[T, P]{p: p}
+}
+
+func (p packagePoint[T, P]) add(q point) point {
+	p.p.Add(p.p, q.(packagePoint[T, P]).p)
+	return p
+}
+
+func (p packagePoint[T, P]) clearCofactor() point {
+	if c, ok := any(p.p).(interface{ ClearCofactor(q *T) *T }); ok {
+		c.ClearCofactor(p.p)
+	}
+
+	return p
+}
+
+func (p packagePoint[T, P]) encode(e Encoding) []byte {
+	if u, ok := any(p.p).(interface{ BytesUncompressed() []byte }); ok && e == Uncompressed {
+		return u.BytesUncompressed()
+	}
+
+	return p.p.Bytes()
+}
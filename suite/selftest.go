@@ -0,0 +1,163 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+package suite
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/bytemare/hash2curve"
+)
+
+// katInput and katDSTPrefix are the input and the DST prefix of the known-answer tests, as in the RFC 9380 test
+// vectors.
+const (
+	katInput     = "abc"
+	katDSTPrefix = "QUUX-V01-CS02-with-"
+)
+
+// kat is the known-answer test of a suite, with the compressed encoding of the point and the encoded scalar for
+// katInput and the DST katDSTPrefix || suite ID. The points are those of RFC 9380 appendix J, except for the
+// curve25519 and ristretto255 suites which it doesn't cover, and the scalars have been checked against the
+// hash_to_field implementation.
+type kat struct {
+	point  string
+	scalar string
+}
+
+var (
+	errKATPoint  = errors.New("unexpected point")
+	errKATScalar = errors.New("unexpected scalar")
+	errKATPanic  = errors.New("panic")
+)
+
+var kats = [...]kat{
+	hash2curve.P256SHA256SSWURO: {
+		point:  "020bb8b87485551aa43ed54f009230450b492fead5f1cc91658775dac4a3388a0f",
+		scalar: "fc85b6dac2e8be7343454b82c1bd5dad62cf42331f3fa060ff7407d79e15be6b",
+	},
+	hash2curve.P256SHA256SSWUNU: {
+		point:  "02fc3f5d734e8dce41ddac49f47dd2b8a57257522a865c124ed02b92b5237befa4",
+		scalar: "08536fc53220301da264515f370cb8eaf6de3a2f163f2ad565758f3b3faccdcf",
+	},
+	hash2curve.P384SHA384SSWURO: {
+		point: "02e02fc1a5f44a7519419dd314e29863f30df55a514da2d655775a81d413003c4d" +
+			"4e7fd59af0826dfaad4200ac6f60abe1",
+		scalar: "fc34f24a4fb2f7bc762e2569901db79e27799e6b4070a1ca64e9792a8e47f0c1" +
+			"f26b312d07f263fc60cfd2385fb06385",
+	},
+	hash2curve.P384SHA384SSWUNU: {
+		point: "021f08108b87e703c86c872ab3eb198a19f2b708237ac4be53d7929fb4bd519458" +
+			"3f40d052f32df66afe5249c9915d139b",
+		scalar: "9fa3abf517300a1ae4e1ff4f0751732258c1e94582e7ebcd230fc06b17253346" +
+			"9e8182a992d54b436820ae8120fdf4de",
+	},
+	hash2curve.P521SHA512SSWURO: {
+		point: "03002f89a1677b28054b50d15e1f81ed6669b5a2158211118ebdef8a6efc77f8ccaa" +
+			"528f698214e4340155abc1fa08f8f613ef14a043717503d57e267d57155cf784a4",
+		scalar: "0125185d593a8cdef7196f3b3d77d0dacd4485140a55aae6ca4573c27e2ee99959" +
+			"10a57fd10d6b2d5090d1de9c578fb47b6e797125336b99e8f05dc8866a459fc8d8",
+	},
+	hash2curve.P521SHA512SSWUNU: {
+		point: "0300c720ab56aa5a7a4c07a7732a0a4e1b909e32d063ae1b58db5f0eb5e09f08a988" +
+			"4bff55a2bef4668f715788e692c18c1915cd034a6b998311fcf46924ce66a2be9a",
+		scalar: "00c7db17b594df83dafa74f9d7365f57ef86bf82449e9a43673d0c648ad668a17d" +
+			"e2315ab37de68609ab761ac3663d6155ddcecc0ff0fcb59f2cb0dbf7bc71c3c016",
+	},
+	hash2curve.Curve25519SHA512ELL2RO: {
+		point:  "6d52bc6a6b822e43de0bd75d91600a7bcc72ca0a2b69de72588fd4f2f119442b",
+		scalar: "2a9ba89b294f83be8908f381ab19ecf914198a0c41bc50a637a0427582c9820b",
+	},
+	hash2curve.Curve25519SHA512ELL2NU: {
+		point:  "26a0f950b4c925464b893bf48d571a447aa4aefc62423366a80f907d0b95227c",
+		scalar: "a336dc4ee73f144b6cb5012577019a6f12f34b9fd48654f6d89c0a97a2302c02",
+	},
+	hash2curve.Edwards25519SHA512ELL2RO: {
+		point:  "31558a26887f23fb8218f143e69d5f0af2e7831130bd5b432ef23883b895839a",
+		scalar: "0580c9dfded98e624220b80a64a3c8d420b9196f5ff4ac93c563132a732f0c0e",
+	},
+	hash2curve.Edwards25519SHA512ELL2NU: {
+		point:  "42fa27c8f5a1ae0aa38bb59d5938e5145622ba5dedd11d11736fa2f9502d7367",
+		scalar: "51ebdfb1ecb206da8724489fec3b7e2f9602c748649add1ee5e639326474c008",
+	},
+	hash2curve.Secp256k1SHA256SSWURO: {
+		point:  "023377e01eab42db296b512293120c6cee72b6ecf9f9205760bd9ff11fb3cb2c4b",
+		scalar: "0c58c538f86c981e737271dfd1870d084a8c59556c13c1c20cc62a73c50b965f",
+	},
+	hash2curve.Secp256k1SHA256SSWUNU: {
+		point:  "033f3b5842033fff837d504bb4ce2a372bfeadbdbd84a1d2b678b6e1d7ee426b9d",
+		scalar: "6ff6610ea4621c4ca6813a7683d3d362e8765ebffa0ee7ae73eeb912fd526a3d",
+	},
+	hash2curve.Ristretto255SHA512R255MAPRO: {
+		point:  "627b997b104ee62543358e22576c75a98dff9dc5f348d5ab228689735d77b258",
+		scalar: "8f8b308d38917d2022a9ec4d3faf1dccc8fe71fd48b6efd03660ce1d490b230b",
+	},
+	hash2curve.Ristretto255SHA512R255MAPNU: {
+		point:  "ccec4753b4c2b3d11785cea058bf1feb821c5707ccfeb759fb65deb506e9ff48",
+		scalar: "2d9d5ad7a2f5d3c1f1dced2906e81e34e33836eacecf8294036d0f63a75e6301",
+	},
+}
+
+// SelfTest runs the embedded known-answer tests of the suites, or of all the implemented suites if none is given, on
+// their default parameters, as the start-up self-checks of cryptographic modules required by FIPS-like operational
+// requirements. It returns an error wrapping hash2curve.ErrSelfTest and identifying the suite on the first mismatch or
+// failure, e.g. if the module-wide input limits reject the test input, and nil if all tests pass.
+func SelfTest(suites ...hash2curve.Suite) error {
+	if len(suites) == 0 {
+		suites = hash2curve.AllSuites()
+	}
+
+	for _, id := range suites {
+		if err := selfTest(id); err != nil {
+			return fmt.Errorf("%w: %s: %w", hash2curve.ErrSelfTest, id, err)
+		}
+	}
+
+	return nil
+}
+
+// selfTest runs the known-answer test of the suite, and returns the panic value as the error if it panics.
+func selfTest(id hash2curve.Suite) (err error) {
+	s, err := For(id)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			if e, ok := r.(error); ok {
+				err = e
+			} else {
+				err = fmt.Errorf("%w: %v", errKATPanic, r)
+			}
+		}
+	}()
+
+	dst := []byte(katDSTPrefix + s.ID())
+	test := kats[id]
+
+	if !matchHex(s.Hash([]byte(katInput), dst), test.point) {
+		return errKATPoint
+	}
+
+	if !matchHex(s.HashToScalar([]byte(katInput), dst), test.scalar) {
+		return errKATScalar
+	}
+
+	return nil
+}
+
+func matchHex(b []byte, expected string) bool {
+	e, err := hex.DecodeString(expected)
+	return err == nil && bytes.Equal(b, e)
+}
@@ -0,0 +1,423 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+// Package suite provides a uniform interface to all the hash-to-curve suites implemented in this module, selected by
+// their identifier, and configurable with options for documented deviations from the RFC 9380 parameters. Points and
+// scalars are returned in their byte encodings.
+package suite
+
+import (
+	"crypto"
+	"fmt"
+	"math"
+	"math/big"
+	"time"
+
+	"github.com/bytemare/hash2curve"
+	"github.com/bytemare/hash2curve/internal"
+	"github.com/bytemare/hash2curve/internal/field"
+)
+
+const ristretto255ID = "ristretto255"
+
+// Suite is a configured hash-to-curve suite. It is safe for concurrent use.
+type Suite struct {
+	curve        *curve
+	fieldReducer *field.Reducer
+	orderReducer *field.Reducer
+	id           hash2curve.SuiteID
+	scalarLength uint // the length L of hash-to-scalar
+	config
+}
+
+// New returns the suite for the identifier, e.g. "P256_XMD:SHA-256_SSWU_RO_", with the options applied. It returns an
+// error if the identifier is invalid or if the suite is not implemented.
+func New(id string, opts ...Option) (*Suite, error) {
+	sid, err := hash2curve.ValidateSuiteID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Suite{id: *sid}
+	s.clearCofactor = true
+
+	if sid.Curve != ristretto255ID {
+		c, ok := curves[curveKey{sid.Curve, sid.Hash}]
+		if !ok {
+			return nil, fmt.Errorf("%w: %q is not implemented", hash2curve.ErrInvalidSuite, id)
+		}
+
+		s.curve = c
+		s.secLength = c.secLength
+
+		if c.expand != (hash2curve.Expander{}) {
+			s.expand = c.expand
+		} else {
+			s.expand = hash2curve.XMD(c.hash)
+			s.xmd = c.hash
+		}
+	} else {
+		s.expand = hash2curve.XMD(crypto.SHA512)
+		s.xmd = crypto.SHA512
+	}
+
+	for _, opt := range opts {
+		opt(&s.config)
+	}
+
+	if s.curve != nil {
+		// The curves with a distinct L for hash-to-scalar use it unless L is overridden.
+		s.scalarLength = s.secLength
+		if s.curve.scalarLength != 0 && s.secLength == s.curve.secLength && s.secLevel == 0 {
+			s.scalarLength = s.curve.scalarLength
+		}
+
+		if s.secLevel != 0 {
+			s.secLength = hash2curve.SecurityLength(s.curve.field, s.secLevel)
+			s.scalarLength = s.secLength
+		}
+
+		if err = hash2curve.ValidateHashToField(2, s.curve.m(), s.secLength, s.curve.field); err != nil {
+			return nil, err
+		}
+
+		if s.strictSecurity || hash2curve.StrictSecurity() {
+			if err = s.validateSecurity(); err != nil {
+				return nil, err
+			}
+		}
+
+		s.fieldReducer = field.NewReducer(s.curve.field, s.secLength)
+		s.orderReducer = field.NewReducer(s.curve.order, s.scalarLength)
+	}
+
+	return s, nil
+}
+
+// validateSecurity checks that the hash function of expand_message_xmd and L meet the security level of the curve, for
+// both the field and the group order, or only L if the expander is not expand_message_xmd with a crypto.Hash.
+func (s *Suite) validateSecurity() error {
+	for _, m := range []struct {
+		modulo *big.Int
+		length uint
+	}{{s.curve.field, s.secLength}, {s.curve.order, s.scalarLength}} {
+		modulo, length := m.modulo, m.length
+		if s.xmd != 0 {
+			if err := hash2curve.ValidateSecurityLevel(s.xmd, length, modulo, s.curve.k); err != nil {
+				return err
+			}
+		} else if minLength := hash2curve.SecurityLength(modulo, s.curve.k); length < minLength {
+			return fmt.Errorf(
+				"%w: L must be at least %d bytes for %d bits of security, got %d",
+				hash2curve.ErrInsufficientSecurity, minLength, s.curve.k, length,
+			)
+		}
+	}
+
+	return nil
+}
+
+// For returns the suite enumerated by id, with the options applied. Unlike New, it can't fail on the identifier, and
+// only returns an error if the options are invalid, or if id is not a valid hash2curve.Suite value.
+func For(id hash2curve.Suite, opts ...Option) (*Suite, error) {
+	if !id.Available() {
+		return nil, fmt.Errorf("%w: %s", hash2curve.ErrInvalidSuite, id)
+	}
+
+	return New(id.String(), opts...)
+}
+
+// ID returns the suite identifier.
+func (s *Suite) ID() string {
+	return s.id.String()
+}
+
+// Cofactor returns the h_eff of the suite, by which mapped points are multiplied to clear the cofactor, and 1 for
+// prime-order curves and groups.
+func (s *Suite) Cofactor() uint64 {
+	if s.curve == nil {
+		return 1
+	}
+
+	return s.curve.cofactor
+}
+
+// SecurityLength returns the length L of each element in hash_to_field, and 64 for ristretto255. Hash-to-scalar uses
+// the same L, except for the curves that are not in RFC 9380, whose packages compute it for the group order.
+func (s *Suite) SecurityLength() uint {
+	if s.curve == nil {
+		return ristretto255UniformLength
+	}
+
+	return s.secLength
+}
+
+// RandomOracle returns whether the suite is a hash_to_curve (RO) suite, or an encode_to_curve (NU) one otherwise.
+func (s *Suite) RandomOracle() bool {
+	return s.id.Encoding == hash2curve.EncodingRandomOracle
+}
+
+// Hash maps the input to a point with the suite's encoding, i.e. hash_to_curve for RO suites and encode_to_curve for
+// NU suites, and returns the encoded point.
+// The DST must not be empty or nil, and is recommended to be at least 16 bytes long, which the strict DST policy
+// enforces.
+func (s *Suite) Hash(input, dst []byte) []byte {
+	return s.hash(s.bind(dst), input, s.RandomOracle(), nil)
+}
+
+// HashHedged is Hash mixing in the auxiliary randomness aux, i.e. hedged hashing, with the expansion of
+// hash2curve.ExpandXMDHedged for the suite's expander. The DST requirements of Hash apply, and it panics if aux is
+// longer than 65535 bytes.
+func (s *Suite) HashHedged(input, aux, dst []byte) []byte {
+	s.checkInput(input)
+
+	if err := hash2curve.ValidateDST(dst, s.strictDST); err != nil {
+		panic(err)
+	}
+
+	hedged := hash2curve.HedgedInput(input, aux)
+	defer hash2curve.Wipe(hedged)
+
+	return s.hash(s.bind(hash2curve.HedgedDST(dst)), hedged, s.RandomOracle(), nil)
+}
+
+// HashToScalar returns a safe mapping of the arbitrary input to an encoded scalar of the prime-order group.
+// The DST must not be empty or nil, and is recommended to be at least 16 bytes long, which the strict DST policy
+// enforces.
+func (s *Suite) HashToScalar(input, dst []byte) []byte {
+	return s.hashToScalar(s.bind(dst), input)
+}
+
+// ValidateInput returns hash2curve.ErrInputTooLong if the input is longer than the suite's limit or the module-wide
+// one, so that untrusted input can be rejected before hashing, which panics in that case.
+func (s *Suite) ValidateInput(input []byte) error {
+	if s.maxInput != 0 && uint(len(input)) > s.maxInput {
+		return hash2curve.ErrInputTooLong
+	}
+
+	return hash2curve.ValidateInput(input, 0)
+}
+
+// TryHash is Hash returning an error instead of panicking on invalid input, e.g. when serving untrusted input: the
+// errors of hash2curve.ValidateDST with the suite's strict DST policy, of ValidateInput, and of
+// hash2curve.ValidateInput for the expansion length.
+func (s *Suite) TryHash(input, dst []byte) ([]byte, error) {
+	length := s.SecurityLength()
+
+	switch {
+	case s.curve != nil && s.RandomOracle():
+		length *= 2 * s.curve.m()
+	case s.curve != nil:
+		length *= s.curve.m()
+	case !s.RandomOracle():
+		length = 32
+	}
+
+	if err := s.validate(input, dst, 1, length); err != nil {
+		return nil, err
+	}
+
+	return s.Hash(input, dst), nil
+}
+
+// TryHashToScalar is HashToScalar returning an error instead of panicking on invalid input, as TryHash does.
+func (s *Suite) TryHashToScalar(input, dst []byte) ([]byte, error) {
+	scalars, err := s.TryHashToScalars(input, dst, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	return scalars[0], nil
+}
+
+// TryHashToScalars is HashToScalars returning an error instead of panicking on invalid input, as TryHash does, with
+// hash2curve.ErrInvalidParameters if count is 0, and hash2curve.ErrLengthTooLarge if count elements can't be expanded
+// at once.
+func (s *Suite) TryHashToScalars(input, dst []byte, count uint) ([][]byte, error) {
+	if count == 0 {
+		return nil, hash2curve.ErrInvalidParameters
+	}
+
+	length := s.SecurityLength()
+	if s.curve != nil {
+		length = s.scalarLength
+	}
+
+	if err := s.validate(input, dst, count, length); err != nil {
+		return nil, err
+	}
+
+	return s.HashToScalars(input, dst, count), nil
+}
+
+// validate returns the error expanding count elements of length bytes of the input with dst would panic with.
+func (s *Suite) validate(input, dst []byte, count, length uint) error {
+	if err := hash2curve.ValidateDST(dst, s.strictDST); err != nil {
+		return err
+	}
+
+	if err := s.ValidateInput(input); err != nil {
+		return err
+	}
+
+	if count > math.MaxUint16/length {
+		return hash2curve.ErrLengthTooLarge
+	}
+
+	return hash2curve.ValidateInput(input, count*length)
+}
+
+func (s *Suite) checkInput(input []byte) {
+	if err := s.ValidateInput(input); err != nil {
+		panic(err)
+	}
+}
+
+// boundExpander is an expand_message function with a fixed DST.
+type boundExpander func(input []byte, length uint) []byte
+
+// bind panics with hash2curve.ErrZeroLengthDST or hash2curve.ErrShortDST if the DST is rejected.
+func (s *Suite) bind(dst []byte) boundExpander {
+	if err := hash2curve.ValidateDST(dst, s.strictDST); err != nil {
+		panic(err)
+	}
+
+	return func(input []byte, length uint) []byte {
+		return s.expand.Expand(input, dst, length)
+	}
+}
+
+func (s *Suite) hash(expand boundExpander, input []byte, randomOracle bool, trace *Trace) []byte {
+	s.checkInput(input)
+
+	if observe := internal.ObserveHash(); observe != nil {
+		operation := hash2curve.OperationEncodeToCurve
+		if randomOracle {
+			operation = hash2curve.OperationHashToCurve
+		}
+
+		defer observe(s.ID(), operation, time.Now())
+	}
+
+	if s.curve == nil {
+		return ristretto255Hash(expand, input, randomOracle)
+	}
+
+	var p point
+
+	m := s.curve.m()
+
+	if randomOracle {
+		u := s.hashToField(expand, input, 2*m, s.secLength, s.fieldReducer)
+		p = s.curve.mapToCurve(u[:m])
+		q1 := s.curve.mapToCurve(u[m:])
+
+		if trace != nil {
+			trace.U, trace.Q0, trace.Q1 = u, p.encode(s.encoding), q1.encode(s.encoding)
+		}
+
+		p = p.add(q1)
+	} else {
+		u := s.hashToField(expand, input, m, s.secLength, s.fieldReducer)
+		p = s.curve.mapToCurve(u)
+
+		if trace != nil {
+			trace.U, trace.Q0 = u, p.encode(s.encoding)
+		}
+	}
+
+	switch {
+	case s.clearer != nil:
+		return s.clearer.ClearCofactor(p.encode(s.encoding))
+	case s.clearCofactor:
+		p = p.clearCofactor()
+	}
+
+	return p.encode(s.encoding)
+}
+
+// HashToScalars returns count encoded scalars from one expansion of the input, see [hash2curve]. count must be > 0.
+// The DST must not be empty or nil, and is recommended to be at least 16 bytes long, which the strict DST policy
+// enforces.
+func (s *Suite) HashToScalars(input, dst []byte, count uint) [][]byte {
+	return s.hashToScalars(s.bind(dst), input, count)
+}
+
+func (s *Suite) hashToScalar(expand boundExpander, input []byte) []byte {
+	return s.hashToScalars(expand, input, 1)[0]
+}
+
+func (s *Suite) hashToScalars(expand boundExpander, input []byte, count uint) [][]byte {
+	s.checkInput(input)
+
+	if count == 0 {
+		panic(hash2curve.ErrInvalidParameters)
+	}
+
+	if observe := internal.ObserveHash(); observe != nil {
+		defer observe(s.ID(), hash2curve.OperationHashToScalar, time.Now())
+	}
+
+	var res [][]byte
+
+	if s.curve == nil {
+		res = ristretto255Scalars(expand, input, count)
+	} else {
+		scalars := s.hashToField(expand, input, count, s.scalarLength, s.orderReducer)
+		res = make([][]byte, count)
+
+		for i, sc := range scalars {
+			res[i] = s.curve.encodeScalar(sc)
+		}
+	}
+
+	if s.reverseScalars() {
+		for _, sc := range res {
+			reverse(sc)
+		}
+	}
+
+	return res
+}
+
+// reverseScalars reports whether the byte order set with WithScalarByteOrder differs from that of the group.
+func (s *Suite) reverseScalars() bool {
+	littleEndian := s.curve == nil || s.curve.littleEndian
+
+	switch s.scalarOrder {
+	case BigEndian:
+		return littleEndian
+	case LittleEndian:
+		return !littleEndian
+	default:
+		return false
+	}
+}
+
+// hashToField implements hash_to_field to count elements of length bytes with the cached Barrett reducer of the
+// modulus. Over GF(p^m), count is that of the coordinates, in the order of the elements.
+func (s *Suite) hashToField(
+	expand boundExpander,
+	input []byte,
+	count, length uint,
+	reducer *field.Reducer,
+) []*big.Int {
+	uniform := expand(input, count*length)
+	defer hash2curve.Wipe(uniform)
+
+	res := make([]*big.Int, count)
+
+	for i := range count {
+		offset := i * length
+		res[i] = reducer.Reduce(uniform[offset : offset+length])
+	}
+
+	return res
+}
@@ -0,0 +1,39 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+package suite
+
+import "math/big"
+
+// Trace holds the intermediate values of a hashing, with the fields of the RFC 9380 test vectors. It is meant for
+// debugging and for validating new suites, and must not be used to derive secrets.
+type Trace struct {
+	// U holds the field elements output by hash_to_field, one for NU suites and two for RO suites, or their m
+	// coordinates in order over GF(p^m).
+	U []*big.Int
+
+	// Q0 and Q1 are the encodings of map_to_curve(U[0]) and map_to_curve(U[1]), i.e. after the isogeny map if any,
+	// but before cofactor clearing. Q1 is nil for NU suites.
+	Q0, Q1 []byte
+
+	// P is the encoded output point, as returned by Hash.
+	P []byte
+}
+
+// Trace is Hash, but also returns the intermediate values of the computation. The ristretto255 suites do not use
+// hash_to_field nor map_to_curve, and only set P.
+// The DST must not be empty or nil, and is recommended to be at least 16 bytes long, which the strict DST policy
+// enforces.
+func (s *Suite) Trace(input, dst []byte) *Trace {
+	t := new(Trace)
+	t.P = s.hash(s.bind(dst), input, s.RandomOracle(), t)
+
+	return t
+}
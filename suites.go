@@ -0,0 +1,154 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package hash2curve
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Suite enumerates the suites of RFC 9380 and RFC 9496 implemented in this module, which Suites lists first and in
+// order, for compile-time checked configuration and exhaustive switches instead of identifier strings. The suites of
+// the other curves are only selected by their identifier. The zero value is not a valid suite.
+type Suite uint8
+
+const (
+	// P256SHA256SSWURO is P256_XMD:SHA-256_SSWU_RO_.
+	P256SHA256SSWURO Suite = iota + 1
+
+	// P256SHA256SSWUNU is P256_XMD:SHA-256_SSWU_NU_.
+	P256SHA256SSWUNU
+
+	// P384SHA384SSWURO is P384_XMD:SHA-384_SSWU_RO_.
+	P384SHA384SSWURO
+
+	// P384SHA384SSWUNU is P384_XMD:SHA-384_SSWU_NU_.
+	P384SHA384SSWUNU
+
+	// P521SHA512SSWURO is P521_XMD:SHA-512_SSWU_RO_.
+	P521SHA512SSWURO
+
+	// P521SHA512SSWUNU is P521_XMD:SHA-512_SSWU_NU_.
+	P521SHA512SSWUNU
+
+	// Curve25519SHA512ELL2RO is curve25519_XMD:SHA-512_ELL2_RO_.
+	Curve25519SHA512ELL2RO
+
+	// Curve25519SHA512ELL2NU is curve25519_XMD:SHA-512_ELL2_NU_.
+	Curve25519SHA512ELL2NU
+
+	// Edwards25519SHA512ELL2RO is edwards25519_XMD:SHA-512_ELL2_RO_.
+	Edwards25519SHA512ELL2RO
+
+	// Edwards25519SHA512ELL2NU is edwards25519_XMD:SHA-512_ELL2_NU_.
+	Edwards25519SHA512ELL2NU
+
+	// Secp256k1SHA256SSWURO is secp256k1_XMD:SHA-256_SSWU_RO_.
+	Secp256k1SHA256SSWURO
+
+	// Secp256k1SHA256SSWUNU is secp256k1_XMD:SHA-256_SSWU_NU_.
+	Secp256k1SHA256SSWUNU
+
+	// Ristretto255SHA512R255MAPRO is ristretto255_XMD:SHA-512_R255MAP_RO_.
+	Ristretto255SHA512R255MAPRO
+
+	// Ristretto255SHA512R255MAPNU is ristretto255_XMD:SHA-512_R255MAP_NU_.
+	Ristretto255SHA512R255MAPNU
+
+	maxSuite
+)
+
+// suiteDescriptors holds the descriptors of the Suite values, in order.
+var suiteDescriptors = Suites()[:maxSuite-1]
+
+// AllSuites returns all the valid Suite values, in order.
+func AllSuites() []Suite {
+	suites := make([]Suite, 0, maxSuite-1)
+	for s := P256SHA256SSWURO; s < maxSuite; s++ {
+		suites = append(suites, s)
+	}
+
+	return suites
+}
+
+// ParseSuite returns the Suite for the suite identifier, e.g. "P256_XMD:SHA-256_SSWU_RO_". It returns an error wrapping
+// ErrInvalidSuite if the identifier does not match a Suite value, including for the suites of the curves that are not
+// in RFC 9380 or RFC 9496.
+func ParseSuite(id string) (Suite, error) {
+	for i, d := range suiteDescriptors {
+		if d.ID == id {
+			return Suite(i + 1), nil
+		}
+	}
+
+	return 0, fmt.Errorf("%w: %q is not implemented", ErrInvalidSuite, id)
+}
+
+// Available returns whether s is a valid Suite value.
+func (s Suite) Available() bool {
+	return s > 0 && s < maxSuite
+}
+
+// String returns the suite identifier, or "Suite(n)" if s is not valid.
+func (s Suite) String() string {
+	if !s.Available() {
+		return "Suite(" + strconv.Itoa(int(s)) + ")"
+	}
+
+	return suiteDescriptors[s-1].ID
+}
+
+// Descriptor returns the descriptor of the suite. It panics with ErrInvalidSuite if s is not valid.
+func (s Suite) Descriptor() SuiteDescriptor {
+	if !s.Available() {
+		panic(ErrInvalidSuite)
+	}
+
+	return suiteDescriptors[s-1]
+}
+
+// SuiteID returns the components of the suite identifier. It panics with ErrInvalidSuite if s is not valid.
+func (s Suite) SuiteID() SuiteID {
+	d := s.Descriptor()
+
+	return SuiteID{Curve: d.Curve, Hash: d.Hash, Map: d.Map, Encoding: d.Encoding}
+}
+
+// RandomOracle returns whether the suite is a hash_to_curve (RO) suite, or an encode_to_curve (NU) one otherwise. It
+// panics with ErrInvalidSuite if s is not valid.
+func (s Suite) RandomOracle() bool {
+	return s.Descriptor().RandomOracle
+}
+
+// SecurityLength returns the length L of each element in hash_to_field for the suite, as in its descriptor. It panics
+// with ErrInvalidSuite if s is not valid.
+func (s Suite) SecurityLength() uint {
+	return s.Descriptor().SecurityLength
+}
+
+// MarshalText implements encoding.TextMarshaler, returning the suite identifier string if the suite is valid.
+func (s Suite) MarshalText() ([]byte, error) {
+	if !s.Available() {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidSuite, s)
+	}
+
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, parsing the suite identifier string.
+func (s *Suite) UnmarshalText(text []byte) error {
+	suite, err := ParseSuite(string(text))
+	if err != nil {
+		return err
+	}
+
+	*s = suite
+
+	return nil
+}
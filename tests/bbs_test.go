@@ -0,0 +1,129 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package hash2curve_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"testing"
+
+	"github.com/bytemare/hash2curve/bbs"
+)
+
+var bbsCiphersuites = []bbs.Ciphersuite{bbs.BLS12381SHAKE256, bbs.BLS12381SHA256}
+
+func TestBBS_Ciphersuite(t *testing.T) {
+	if bbs.BLS12381SHAKE256.String() != bbs.IDSHAKE256 || bbs.BLS12381SHA256.String() != bbs.IDSHA256 {
+		t.Fatal("unexpected ciphersuite identifier")
+	}
+
+	if !bytes.Equal(bbs.BLS12381SHA256.APIID(), []byte(bbs.IDSHA256+"H2G_HM2S_")) {
+		t.Fatal("unexpected api_id")
+	}
+
+	if bbs.Ciphersuite(0).Available() || bbs.Ciphersuite(3).Available() {
+		t.Fatal("expected unavailable ciphersuite")
+	}
+
+	if hasPanic, _ := expectPanic(nil, func() {
+		_ = bbs.Ciphersuite(0).HashToScalar([]byte("input"), []byte("dst"))
+	}); !hasPanic {
+		t.Fatal("expected panic on invalid ciphersuite")
+	}
+}
+
+func TestBBS_HashToScalar(t *testing.T) {
+	// The h2s fixtures of draft-irtf-cfrg-bbs-signatures-07, for the default DST api_id || "H2S_".
+	msg, _ := hex.DecodeString("9872ad089e452c7b6e283dfac2a80d58e8d0ff71cc4d5e310a1debdda4a45f02")
+
+	for _, test := range []struct {
+		dst, scalar string
+		c           bbs.Ciphersuite
+	}{
+		{
+			c: bbs.BLS12381SHAKE256,
+			dst: "4242535f424c53313233383147315f584f463a5348414b452d3235365f535357555f524f5f4832475f484d32535f48" +
+				"32535f",
+			scalar: "0500031f786fde5326aa9370dd7ffe9535ec7a52cf2b8f432cad5d9acfb73cd3",
+		},
+		{
+			c: bbs.BLS12381SHA256,
+			dst: "4242535f424c53313233383147315f584d443a5348412d3235365f535357555f524f5f4832475f484d32535f4832" +
+				"535f",
+			scalar: "0f90cbee27beb214e6545becb8404640d3612da5d6758dffeccd77ed7169807c",
+		},
+	} {
+		t.Run(test.c.String(), func(t *testing.T) {
+			dst := bbs.HashToScalarDST(test.c.APIID())
+			if hex.EncodeToString(dst) != test.dst {
+				t.Fatalf("unexpected dst %x", dst)
+			}
+
+			scalar := test.c.HashToScalar(msg, dst).FillBytes(make([]byte, 32))
+			if got := hex.EncodeToString(scalar); got != test.scalar {
+				t.Fatalf("want %s, got %s", test.scalar, got)
+			}
+		})
+	}
+}
+
+func TestBBS_CreateGenerators(t *testing.T) {
+	for _, c := range bbsCiphersuites {
+		t.Run(c.String(), func(t *testing.T) {
+			apiID := c.APIID()
+			seeds := c.GeneratorSeeds(5, nil, apiID)
+
+			var calls int
+			generators := bbs.CreateGenerators(c, 5, nil, apiID, func(input, dst []byte) []byte {
+				if !bytes.Equal(dst, bbs.GeneratorDST(apiID)) {
+					t.Fatal("unexpected generator dst")
+				}
+
+				if !bytes.Equal(input, seeds[calls]) {
+					t.Fatalf("unexpected generator seed %d", calls)
+				}
+
+				calls++
+
+				return input
+			})
+
+			if len(generators) != 5 || calls != 5 {
+				t.Fatalf("unexpected number of generators %d", len(generators))
+			}
+
+			for i := 1; i < len(seeds); i++ {
+				if len(seeds[i]) != bbs.ExpandLength || bytes.Equal(seeds[i-1], seeds[i]) {
+					t.Fatal("unexpected seed")
+				}
+			}
+
+			// create_generators with the literal DSTs of the draft, on expand_message directly.
+			seedDST := []byte(c.String() + "H2G_HM2S_SIG_GENERATOR_SEED_")
+			v := c.ExpandMessage([]byte(c.String()+"H2G_HM2S_MESSAGE_GENERATOR_SEED"), seedDST, 48)
+
+			for i := range seeds {
+				v = c.ExpandMessage(binary.BigEndian.AppendUint64(v, uint64(i+1)), seedDST, 48)
+				if !bytes.Equal(v, seeds[i]) {
+					t.Fatalf("unexpected seed %d", i)
+				}
+			}
+
+			if !bytes.Equal(bbs.GeneratorDST(apiID), []byte(c.String()+"H2G_HM2S_SIG_GENERATOR_DST_")) {
+				t.Fatal("unexpected generator dst")
+			}
+
+			other := c.GeneratorSeeds(5, []byte("custom seed"), apiID)
+			if bytes.Equal(other[0], seeds[0]) {
+				t.Fatal("expected different seeds for a different generator seed")
+			}
+		})
+	}
+}
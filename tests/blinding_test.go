@@ -0,0 +1,105 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+package hash2curve_test
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/bytemare/hash2curve"
+	"github.com/bytemare/hash2curve/edwards25519"
+	"github.com/bytemare/hash2curve/golden"
+	"github.com/bytemare/hash2curve/nist"
+	"github.com/bytemare/hash2curve/secp256k1"
+)
+
+func TestBlinding(t *testing.T) {
+	inputs := [][]byte{nil, []byte("abc"), []byte("password")}
+	dst := []byte("QUUX-V01-CS02-with-blinding")
+
+	hash := func() [][]byte {
+		var out [][]byte
+
+		for _, input := range inputs {
+			out = append(out,
+				nist.HashToP256(input, dst).Bytes(),
+				nist.EncodeToP384(input, dst).Bytes(),
+				nist.HashToP521(input, dst).Bytes(),
+				secp256k1.HashToCurve(input, dst).Bytes(),
+				secp256k1.EncodeToCurve(input, dst).Bytes(),
+				edwards25519.HashToCurve(input, dst).Bytes(),
+				edwards25519.EncodeToCurve(input, dst).Bytes(),
+			)
+		}
+
+		for _, p := range nist.HashToP256Batch(inputs, dst) {
+			out = append(out, p.Bytes())
+		}
+
+		return out
+	}
+
+	plain := hash()
+
+	if hash2curve.Blinding() {
+		t.Fatal("blinding is enabled by default")
+	}
+
+	hash2curve.SetBlinding(true)
+	defer hash2curve.SetBlinding(false)
+
+	if !hash2curve.Blinding() {
+		t.Fatal("blinding is not enabled")
+	}
+
+	for i, p := range hash() {
+		if !bytes.Equal(p, plain[i]) {
+			t.Fatalf("blinding changes output %d", i)
+		}
+	}
+
+	if err := golden.Check(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestBlinding_Jacobian(t *testing.T) {
+	input, dst := []byte("password"), []byte("QUUX-V01-CS02-with-blinding")
+	prime := nist.FieldPrimeP256()
+
+	affine := func(p *nist.JacobianPoint) (x, y *big.Int) {
+		zInv := new(big.Int).ModInverse(&p.Z, prime)
+		zInv2 := new(big.Int).Mul(zInv, zInv)
+		x = new(big.Int).Mul(&p.X, zInv2)
+		y = new(big.Int).Mul(&p.Y, zInv2.Mul(zInv2, zInv))
+
+		return x.Mod(x, prime), y.Mod(y, prime)
+	}
+
+	x, y := affine(nist.EncodeToP256Jacobian(input, dst))
+
+	hash2curve.SetBlinding(true)
+	defer hash2curve.SetBlinding(false)
+
+	p1, p2 := nist.EncodeToP256Jacobian(input, dst), nist.EncodeToP256Jacobian(input, dst)
+
+	// The projective coordinates are randomized, but not the affine point.
+	if p1.Z.Cmp(&p2.Z) == 0 {
+		t.Fatal("the Jacobian coordinates are not randomized")
+	}
+
+	for _, p := range []*nist.JacobianPoint{p1, p2} {
+		if px, py := affine(p); px.Cmp(x) != 0 || py.Cmp(y) != 0 {
+			t.Fatal("blinding changes the point")
+		}
+	}
+}
@@ -0,0 +1,158 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+package hash2curve_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/bytemare/hash2curve"
+	"github.com/bytemare/hash2curve/bls24315"
+)
+
+// TestBLS24315 checks the BLS24-315 suites against values computed with an independent implementation, for the
+// message "abc" and the DST "QUUX-V01-CS02-with-" followed by the suite identifier.
+func TestBLS24315(t *testing.T) {
+	for _, test := range []struct {
+		hash func(input, dst []byte) ([]*big.Int, bool)
+		name string
+		id   string
+		want []string
+	}{
+		{
+			name: "HashToG1",
+			id:   bls24315.G1H2C,
+			hash: func(input, dst []byte) ([]*big.Int, bool) {
+				p := bls24315.HashToG1(input, dst)
+				return []*big.Int{&p.X, &p.Y}, p.IsInSubgroup()
+			},
+			want: []string{
+				"0x35e561f0f438d342c48753936f5f5dc46098ac907d18c80015fa748c19e5a1c63085c34d5f31bd0",
+				"0x2d56c5cdcbe79ee1767d3809c6f9670f1e8957f68d77ff03b1dc0fa59853d1fda2d652ac2fb6fea",
+			},
+		},
+		{
+			name: "EncodeToG1",
+			id:   bls24315.G1E2C,
+			hash: func(input, dst []byte) ([]*big.Int, bool) {
+				p := bls24315.EncodeToG1(input, dst)
+				return []*big.Int{&p.X, &p.Y}, p.IsInSubgroup()
+			},
+			want: []string{
+				"0x2b97fdf1a22c568de6f5e6a3b1b4e15cb35b2a2d26e66e84f5e2d2ca9e2877422e4cb63750751dc",
+				"0x74d4d0bc5e831d8cde4610d6e530514d6f0f9edbec86a8b8109dbebb406e4c6effe4531a84a6f",
+			},
+		},
+		{
+			name: "HashToG2",
+			id:   bls24315.G2H2C,
+			hash: func(input, dst []byte) ([]*big.Int, bool) {
+				p := bls24315.HashToG2(input, dst)
+				return coordinates(p.X[:], p.Y[:]), p.IsInSubgroup()
+			},
+			want: []string{
+				"0x2d8993be3b1e78c753d407ffa647b980083b23615322e039d2661268b7d700308302ff9e60071d9",
+				"0x3859da258e19e8f5fe7f9f5880837553d39dffee27a182670c307aa4d98ca5427e941094eec2e02",
+				"0x3115967336d053b6953659dee63ee01148400321690fb2ee1c1e4def5823d0ee2822772c44db219",
+				"0x1e13e66950e6d2a24f33764ca15eea73ab2bb3368cebe75654a3af7ae65e7036e2065ba8ddff370",
+				"0xbeb82840cc6850e424541395e57b96fbccb13ef3e26246fb562d09ca972abef939639e7d85a134",
+				"0x4a6992278ac8ca351ef8d452154a98f089e5a6215b22b160f7069aae6abf291e39b02eece44ff86",
+				"0x1588ec9c8d616c3ecd45eba7cb0fc5140546e958fe5ac9c295d6b0aeb410e166a43c6be4287a61f",
+				"0x2a869b6671525d6cc5d32009b1d936ed609ecdf5ea302c0c045969346d6ff50a57d98b53969ffe2",
+			},
+		},
+		{
+			name: "EncodeToG2",
+			id:   bls24315.G2E2C,
+			hash: func(input, dst []byte) ([]*big.Int, bool) {
+				p := bls24315.EncodeToG2(input, dst)
+				return coordinates(p.X[:], p.Y[:]), p.IsInSubgroup()
+			},
+			want: []string{
+				"0x2ea2820cb8265199777077c9f7d0c2ae72aeff1802c343b63756c09adc5690a56f73e5d66f7ff0c",
+				"0x1f4bf0379b61ea5a153ac3fe149fbbcdce7690b9c5b60f36c0bb2a00b1206168c8bc6f98818fd68",
+				"0x4a75be72691958a27c87151cc4b435c7a99b62cadb0de06269c625e43e9b46acc8b06ec430e583d",
+				"0x44a03742eff5f49f29199c0c30f7f37a3d8584295aa11456e028a04e5b1e90b035ec72942d5d572",
+				"0xf1a1fd10f5021bfabbefd40e682724545908d177f5c02b3a392d7f3c0c7c3ed51e37b57b95b457",
+				"0x1937cba3ec11d2c685f47ba8ba957a3612f5b77ecd9a3c450d43904ca6dbed0ccb7c023a11bbf99",
+				"0x3a5e2a261bacda75ed9b9975c032101d9875c3752bd63e542a3e4345dc469b0f3e9725d80687077",
+				"0x47b94faac0241090f00233a58b7994605722f879cdac03df817770f3638ef7b556e5214c24cd2df",
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			got, inSubgroup := test.hash([]byte("abc"), []byte("QUUX-V01-CS02-with-"+test.id))
+			if len(got) != len(test.want) {
+				t.Fatalf("expected %d coordinates, got %d", len(test.want), len(got))
+			}
+
+			for i, w := range test.want {
+				want, _ := new(big.Int).SetString(w, 0)
+				if got[i].Cmp(want) != 0 {
+					t.Fatalf("unexpected coordinate %d: want %s, got 0x%x", i, w, got[i])
+				}
+			}
+
+			if !inSubgroup {
+				t.Fatal("expected a point in the prime-order subgroup")
+			}
+		})
+	}
+}
+
+func TestBLS24315_Points(t *testing.T) {
+	input := []byte("input")
+	dst := []byte("QUUX-V01-CS02-with-points")
+
+	// The mapped points are not in the subgroups before clearing the cofactors.
+	if bls24315.MapToG1(big.NewInt(3)).IsInSubgroup() {
+		t.Fatal("unexpected point in G1 before clearing the cofactor")
+	}
+
+	u := [4]*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(3), big.NewInt(4)}
+	if bls24315.MapToG2(u).IsInSubgroup() {
+		t.Fatal("unexpected point in G2 before clearing the cofactor")
+	}
+
+	// The exceptional input u = 0 is mapped too.
+	zero := [4]*big.Int{big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0)}
+	if p := bls24315.MapToG2(zero); p.IsInfinity() {
+		t.Fatal("unexpected point at infinity")
+	}
+
+	p1 := bls24315.HashToG1(input, dst)
+	sum := new(bls24315.G1).Add(p1, bls24315.EncodeToG1(input, dst))
+
+	if !sum.IsInSubgroup() || sum.Equal(p1) {
+		t.Fatal("expected a different point in the subgroup")
+	}
+
+	p2 := bls24315.HashToG2(input, dst)
+	if !p2.Equal(bls24315.HashToG2(input, dst)) || p2.Equal(bls24315.EncodeToG2(input, dst)) {
+		t.Fatal("unexpected hashing output")
+	}
+
+	p2.X[3].Add(&p2.X[3], big.NewInt(1))
+
+	if p2.IsInSubgroup() {
+		t.Fatal("unexpected point off the twist in G2")
+	}
+
+	r := new(big.Int).Exp(big.NewInt(-3218079743), big.NewInt(8), nil)
+	r.Sub(r, new(big.Int).Exp(big.NewInt(-3218079743), big.NewInt(4), nil)).Add(r, big.NewInt(1))
+
+	if s := bls24315.HashToScalar(input, dst); s.Cmp(r) >= 0 {
+		t.Fatal("expected a scalar reduced modulo the order")
+	}
+
+	expectPanic(hash2curve.ErrNonCanonical, func() { bls24315.MapToG1(big.NewInt(-1)) })
+	expectPanic(hash2curve.ErrNonCanonical, func() { bls24315.MapToG2([4]*big.Int{}) })
+}
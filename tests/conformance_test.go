@@ -0,0 +1,149 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+package hash2curve_test
+
+import (
+	"crypto"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bytemare/hash"
+
+	"github.com/bytemare/hash2curve"
+	"github.com/bytemare/hash2curve/conformance"
+	"github.com/bytemare/hash2curve/vectorgen"
+)
+
+func TestConformance_HashToCurve(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join(hashToCurveVectorsFileLocation, "*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, file := range files {
+		id := strings.Replace(strings.TrimSuffix(filepath.Base(file), ".json"), "-", ":", 1)
+
+		s, err := vectorgen.Lookup(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		report, err := conformance.RunFile(s, file)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err = report.Err(); err != nil || report.Vectors == 0 || report.Suite != id {
+			t.Fatalf("%s: unexpected report %+v (%v)", id, report, err)
+		}
+	}
+}
+
+func TestConformance_Failures(t *testing.T) {
+	s, err := vectorgen.Lookup("P256_XMD:SHA-256_SSWU_RO_")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	file := filepath.Join(hashToCurveVectorsFileLocation, "P256_XMD-SHA-256_SSWU_RO_.json")
+
+	// An implementation with a wrong final point.
+	broken := *s
+	broken.Encode = func(input, dst []byte) (x, y *big.Int) {
+		x, y = s.Encode(input, dst)
+		return x, new(big.Int).Add(y, big.NewInt(1))
+	}
+
+	report, err := conformance.RunFile(&broken, file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if report.Passed() || len(report.Failures) != report.Vectors || report.Failures[0].Field != "P.y" ||
+		!errors.Is(report.Err(), conformance.ErrNonConformant) {
+		t.Fatalf("unexpected report %+v", report)
+	}
+
+	// Vectors of another suite.
+	other, err := vectorgen.Lookup("P256_XMD:SHA-256_SSWU_NU_")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = conformance.RunFile(other, file); !errors.Is(err, conformance.ErrSuiteMismatch) {
+		t.Fatalf("want %v, got %v", conformance.ErrSuiteMismatch, err)
+	}
+
+	if _, err = conformance.Run(s, strings.NewReader("{")); !errors.Is(err, conformance.ErrMalformedVectors) {
+		t.Fatalf("want %v, got %v", conformance.ErrMalformedVectors, err)
+	}
+}
+
+func TestConformance_Expand(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join(expandMessageVectorFiles, "*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, file := range files {
+		f, err := os.Open(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var expand conformance.ExpandFunc
+
+		switch {
+		case strings.Contains(file, "SHA256"):
+			expand = func(input, dst []byte, length uint) []byte {
+				return hash2curve.ExpandXMD(crypto.SHA256, input, dst, length)
+			}
+		case strings.Contains(file, "SHA512"):
+			expand = func(input, dst []byte, length uint) []byte {
+				return hash2curve.ExpandXMD(crypto.SHA512, input, dst, length)
+			}
+		case strings.Contains(file, "SHAKE128"):
+			expand = func(input, dst []byte, length uint) []byte {
+				return hash2curve.ExpandXOF(hash2curve.ExtendableXOF(hash.SHAKE128.GetXOF()), input, dst, length)
+			}
+		default:
+			expand = func(input, dst []byte, length uint) []byte {
+				return hash2curve.ExpandXOF(hash2curve.ExtendableXOF(hash.SHAKE256.GetXOF()), input, dst, length)
+			}
+		}
+
+		report, err := conformance.RunExpand(expand, f)
+		_ = f.Close()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err = report.Err(); err != nil || report.Vectors == 0 {
+			t.Fatalf("%s: unexpected report %+v (%v)", file, report, err)
+		}
+
+		// The expansion with another hash function must fail.
+		f, _ = os.Open(file)
+		report, err = conformance.RunExpand(func(input, dst []byte, length uint) []byte {
+			return hash2curve.ExpandXMD(crypto.SHA384, input, dst, length)
+		}, f)
+		_ = f.Close()
+
+		if err != nil || report.Passed() {
+			t.Fatalf("%s: expected failures", file)
+		}
+	}
+}
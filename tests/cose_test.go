@@ -0,0 +1,146 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+package hash2curve_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/bytemare/hash2curve"
+	"github.com/bytemare/hash2curve/cose"
+	"github.com/bytemare/hash2curve/group"
+	"github.com/bytemare/hash2curve/nist"
+	"github.com/bytemare/hash2curve/suite"
+)
+
+func TestCOSE_FromSuite(t *testing.T) {
+	input := []byte("abc")
+
+	curves := map[string]struct{ kty, crv int }{
+		"P256":         {cose.KeyTypeEC2, cose.CurveP256},
+		"P384":         {cose.KeyTypeEC2, cose.CurveP384},
+		"P521":         {cose.KeyTypeEC2, cose.CurveP521},
+		"secp256k1":    {cose.KeyTypeEC2, cose.CurveSecp256k1},
+		"edwards25519": {cose.KeyTypeOKP, cose.CurveEd25519},
+		"curve25519":   {cose.KeyTypeOKP, cose.CurveX25519},
+	}
+
+	for _, id := range hash2curve.AllSuites() {
+		t.Run(id.String(), func(t *testing.T) {
+			dst := []byte("QUUX-V01-CS02-with-" + id.String())
+
+			s, err := suite.For(id, suite.WithOutputEncoding(suite.Uncompressed))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			point := s.Hash(input, dst)
+
+			expected, ok := curves[id.SuiteID().Curve]
+			if !ok {
+				if _, err = cose.FromSuite(id, point); !errors.Is(err, cose.ErrUnsupportedCurve) {
+					t.Fatalf("expected %v, got %v", cose.ErrUnsupportedCurve, err)
+				}
+
+				return
+			}
+
+			k, err := cose.FromSuite(id, point)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if k.Kty != expected.kty || k.Crv != expected.crv || k.D != nil {
+				t.Fatalf("unexpected key %+v", k)
+			}
+
+			// A map of 3 or 4 entries: kty, crv, and x, and y for EC2 keys.
+			want := []byte{0xa3, 0x01, byte(k.Kty), 0x20, byte(k.Crv), 0x21}
+			want = append(cborBytes(want, k.X), k.X...)
+
+			if k.Kty == cose.KeyTypeEC2 {
+				want[0] = 0xa4
+				want = append(cborBytes(append(want, 0x22), k.Y), k.Y...)
+
+				if !bytes.Equal(append(append([]byte{4}, k.X...), k.Y...), point) {
+					t.Fatal("unexpected coordinates")
+				}
+			} else if !bytes.Equal(k.X, point) || k.Y != nil {
+				t.Fatal("unexpected OKP key")
+			}
+
+			if !bytes.Equal(k.Marshal(), want) {
+				t.Fatalf("unexpected encoding %x", k.Marshal())
+			}
+		})
+	}
+}
+
+func TestCOSE_PrivateKey(t *testing.T) {
+	input, dst := []byte("seed"), []byte("QUUX-V01-CS02-with-P256_XMD:SHA-256_SSWU_RO_")
+
+	s, err := suite.For(hash2curve.P256SHA256SSWURO)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scalar := s.HashToScalar(input, dst)
+
+	k, err := cose.PrivateKey(hash2curve.P256SHA256SSWURO, scalar)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	priv, err := nist.DeriveECDSAPrivateKeyP256(input, dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(k.D, scalar) || !bytes.Equal(k.X, priv.X.FillBytes(make([]byte, 32))) ||
+		!bytes.Equal(k.Y, priv.Y.FillBytes(make([]byte, 32))) {
+		t.Fatal("unexpected private key")
+	}
+
+	public := k.Public()
+	if public.D != nil || !bytes.Equal(public.X, k.X) || !bytes.Equal(public.Y, k.Y) {
+		t.Fatal("unexpected public key")
+	}
+
+	// The private key appends the d entry to the map of the public key.
+	encoded, publicEncoded := k.Marshal(), public.Marshal()
+	if encoded[0] != 0xa5 || publicEncoded[0] != 0xa4 ||
+		!bytes.Equal(encoded[1:], append(publicEncoded[1:], append([]byte{0x23, 0x58, 0x20}, scalar...)...)) {
+		t.Fatalf("unexpected encoding %x", encoded)
+	}
+
+	for _, test := range []struct {
+		expected error
+		scalar   []byte
+		suite    hash2curve.Suite
+	}{
+		{hash2curve.ErrInvalidSuite, scalar, 0},
+		{cose.ErrUnsupportedCurve, scalar, hash2curve.Edwards25519SHA512ELL2RO},
+		{cose.ErrUnsupportedCurve, scalar, hash2curve.Ristretto255SHA512R255MAPRO},
+		{group.ErrInvalidScalar, scalar[1:], hash2curve.P256SHA256SSWURO},
+		{group.ErrInvalidScalar, make([]byte, 32), hash2curve.P256SHA256SSWURO},
+		{group.ErrInvalidScalar, bytes.Repeat([]byte{0xff}, 32), hash2curve.Secp256k1SHA256SSWURO},
+	} {
+		if _, err = cose.PrivateKey(test.suite, test.scalar); !errors.Is(err, test.expected) {
+			t.Fatalf("expected %v for %s, got %v", test.expected, test.suite, err)
+		}
+	}
+}
+
+// cborBytes appends the CBOR head of a byte string of the length of v, for lengths from 24 to 255.
+func cborBytes(b, v []byte) []byte {
+	return append(b, 0x58, byte(len(v)))
+}
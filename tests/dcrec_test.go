@@ -0,0 +1,93 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+package hash2curve_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	secp "github.com/decred/dcrd/dcrec/secp256k1/v4"
+
+	"github.com/bytemare/hash2curve"
+	"github.com/bytemare/hash2curve/dcrec"
+	"github.com/bytemare/hash2curve/secp256k1"
+)
+
+func TestDcrec_PublicKey(t *testing.T) {
+	for _, test := range []struct {
+		hash   func(input, dst []byte) *secp256k1.Point
+		pubKey func(input, dst []byte) *secp.PublicKey
+		name   string
+	}{
+		{name: "hash", hash: secp256k1.HashToCurve, pubKey: dcrec.HashToPublicKey},
+		{name: "encode", hash: secp256k1.EncodeToCurve, pubKey: dcrec.EncodeToPublicKey},
+	} {
+		p := test.hash(suiteInput, suiteDST)
+		pk := test.pubKey(suiteInput, suiteDST)
+
+		if !pk.IsOnCurve() {
+			t.Fatalf("%s: the public key is not on the curve", test.name)
+		}
+
+		if !bytes.Equal(pk.SerializeCompressed(), p.Bytes()) {
+			t.Fatalf("%s: expected %x, got %x", test.name, p.Bytes(), pk.SerializeCompressed())
+		}
+
+		if !bytes.Equal(pk.SerializeUncompressed(), p.BytesUncompressed()) {
+			t.Fatalf("%s: unexpected uncompressed encoding", test.name)
+		}
+
+		parsed, err := secp.ParsePubKey(p.Bytes())
+		if err != nil || !parsed.IsEqual(pk) {
+			t.Fatalf("%s: the public key differs from the parsed encoding: %v", test.name, err)
+		}
+
+		if !bytes.Equal(dcrec.FromPublicKey(pk).Bytes(), p.Bytes()) {
+			t.Fatalf("%s: unexpected point from the public key", test.name)
+		}
+	}
+}
+
+func TestDcrec_JacobianPoint(t *testing.T) {
+	p := secp256k1.HashToCurve(suiteInput, suiteDST)
+	j := dcrec.JacobianPoint(p)
+
+	var sum, double secp.JacobianPoint
+
+	// 2P computed by dcrec matches 2P computed by this module.
+	secp.AddNonConst(j, j, &sum)
+	secp.DoubleNonConst(j, &double)
+
+	want := new(secp256k1.Point).Add(p, p).Bytes()
+	if got := dcrec.FromJacobianPoint(&sum).Bytes(); !bytes.Equal(got, want) {
+		t.Fatalf("expected %x, got %x", want, got)
+	}
+
+	if got := dcrec.FromJacobianPoint(&double).Bytes(); !bytes.Equal(got, want) {
+		t.Fatalf("expected %x, got %x", want, got)
+	}
+
+	// The point at infinity.
+	identity := new(secp256k1.Point)
+
+	if j = dcrec.JacobianPoint(identity); !j.Z.IsZero() {
+		t.Fatal("expected Z = 0 for the point at infinity")
+	}
+
+	if q := dcrec.FromJacobianPoint(j); q.X.Sign() != 0 || q.Y.Sign() != 0 {
+		t.Fatal("expected the point at infinity")
+	}
+
+	if _, err := dcrec.PublicKey(identity); !errors.Is(err, hash2curve.ErrInvalidPoint) {
+		t.Fatalf("expected %q, got %v", hash2curve.ErrInvalidPoint, err)
+	}
+}
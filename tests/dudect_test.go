@@ -0,0 +1,224 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig && dudect
+
+package hash2curve_test
+
+import (
+	"crypto"
+	"crypto/rand"
+	"math"
+	"math/big"
+	"slices"
+	"testing"
+	"time"
+
+	"filippo.io/edwards25519/field"
+
+	"github.com/bytemare/hash2curve"
+	"github.com/bytemare/hash2curve/edwards25519"
+	"github.com/bytemare/hash2curve/internal/field/fp256k1"
+	"github.com/bytemare/hash2curve/nist"
+	"github.com/bytemare/hash2curve/secp256k1"
+)
+
+// These tests implement a dudect-style timing leak detection (Reparaz, Balasch, Verbauwhede, "Dude, is my code
+// constant time?"): the target is timed on a fixed input class and a random input class, interleaved at random, and a
+// Welch t-test on the two timing distributions flags significant differences. They are slow and sensitive to noise, so
+// they only run with the dudect build tag, e.g. go test -tags=dudect -run TestConstantTime ./tests.
+
+const (
+	dudectMeasurements = 20000
+	dudectRepetitions  = 4
+	dudectPercentile   = 0.9
+
+	// dudectThreshold is the t statistic above which a leak is considered certain, as in the dudect reference.
+	dudectThreshold = 10
+)
+
+type timingTarget struct {
+	run  func(in []byte)
+	name string
+	// constantTime is false for targets built on math/big, which are measured and reported but not enforced.
+	constantTime bool
+	inputLength  int
+}
+
+var dudectDST = []byte("QUUX-V01-CS02-with-dudect")
+
+var (
+	secp256k1P = new(big.Int).SetBytes(fp256k1.Order())
+	p256P, _   = new(big.Int).SetString("ffffffff00000001000000000000000000000000ffffffffffffffffffffffff", 16)
+)
+
+func fp256k1FromBytes(in []byte) *fp256k1.Element {
+	var b [fp256k1.ElementLength]byte
+	copy(b[:], in)
+	e, _ := new(fp256k1.Element).SetBytes(&b)
+
+	return e
+}
+
+func reduced(in []byte, p *big.Int) *big.Int {
+	return new(big.Int).Mod(new(big.Int).SetBytes(in), p)
+}
+
+var timingTargets = []timingTarget{
+	{
+		name:         "ExpandXMD",
+		constantTime: true,
+		inputLength:  64,
+		run: func(in []byte) {
+			_ = hash2curve.ExpandXMD(crypto.SHA256, in, dudectDST, 128)
+		},
+	},
+	{
+		name:        "HashToFieldXMD",
+		inputLength: 64,
+		run: func(in []byte) {
+			_ = hash2curve.HashToFieldXMD(crypto.SHA256, in, dudectDST, 2, 1, 48, secp256k1P)
+		},
+	},
+	{
+		name:         "fp256k1 SqrtRatio",
+		constantTime: true,
+		inputLength:  64,
+		run: func(in []byte) {
+			u, v := fp256k1FromBytes(in[:32]), fp256k1FromBytes(in[32:])
+			c2 := new(fp256k1.Element).One()
+			new(fp256k1.Element).SqrtRatio(u, v, c2)
+		},
+	},
+	{
+		name:         "fp256k1 Invert",
+		constantTime: true,
+		inputLength:  32,
+		run: func(in []byte) {
+			new(fp256k1.Element).Invert(fp256k1FromBytes(in))
+		},
+	},
+	{
+		name:         "Elligator2",
+		constantTime: true,
+		inputLength:  32,
+		run: func(in []byte) {
+			e, _ := new(field.Element).SetBytes(in)
+			_ = edwards25519.Elligator2Edwards(e)
+		},
+	},
+	{
+		name:        "SSWU secp256k1",
+		inputLength: 32,
+		run: func(in []byte) {
+			_ = secp256k1.MapToCurve(reduced(in, secp256k1P))
+		},
+	},
+	{
+		name:        "SSWU P-256",
+		inputLength: 32,
+		run: func(in []byte) {
+			_ = nist.MapToCurveP256(reduced(in, p256P))
+		},
+	},
+}
+
+// welch accumulates the online mean and variance of two classes of measurements.
+type welch struct {
+	mean, m2 [2]float64
+	n        [2]float64
+}
+
+func (w *welch) push(class int, x float64) {
+	w.n[class]++
+	delta := x - w.mean[class]
+	w.mean[class] += delta / w.n[class]
+	w.m2[class] += delta * (x - w.mean[class])
+}
+
+func (w *welch) t() float64 {
+	v0 := w.m2[0] / (w.n[0] - 1)
+	v1 := w.m2[1] / (w.n[1] - 1)
+
+	return (w.mean[0] - w.mean[1]) / math.Sqrt(v0/w.n[0]+v1/w.n[1])
+}
+
+func randomBytes(t *testing.T, n int) []byte {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		t.Fatal(err)
+	}
+
+	return b
+}
+
+// measure times the target on interleaved fixed and random inputs, and returns the t statistic over the measurements
+// below the cropping percentile.
+func measure(t *testing.T, target *timingTarget) float64 {
+	fixed := randomBytes(t, target.inputLength)
+	classes := randomBytes(t, dudectMeasurements)
+	inputs := make([][]byte, dudectMeasurements)
+
+	for i := range inputs {
+		classes[i] &= 1
+		if classes[i] == 0 {
+			inputs[i] = fixed
+		} else {
+			inputs[i] = randomBytes(t, target.inputLength)
+		}
+	}
+
+	timings := make([]float64, dudectMeasurements)
+
+	for i, in := range inputs {
+		start := time.Now()
+		for range dudectRepetitions {
+			target.run(in)
+		}
+
+		timings[i] = float64(time.Since(start))
+	}
+
+	sorted := slices.Clone(timings)
+	slices.Sort(sorted)
+	cutoff := sorted[int(dudectPercentile*float64(len(sorted)))]
+
+	var w welch
+
+	for i, x := range timings {
+		if x <= cutoff {
+			w.push(int(classes[i]), x)
+		}
+	}
+
+	return w.t()
+}
+
+func TestConstantTime(t *testing.T) {
+	for i := range timingTargets {
+		target := &timingTargets[i]
+
+		t.Run(target.name, func(t *testing.T) {
+			// Warm up caches, pools and lazy initialisations.
+			for range 100 {
+				target.run(randomBytes(t, target.inputLength))
+			}
+
+			tStat := math.Abs(measure(t, target))
+			t.Logf("|t| = %.2f", tStat)
+
+			if tStat > dudectThreshold {
+				if target.constantTime {
+					t.Errorf("timing leak detected: |t| = %.2f > %d", tStat, dudectThreshold)
+				} else {
+					t.Logf("timing difference detected on a non constant-time target")
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+package hash2curve_test
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/bytemare/hash2curve"
+	"github.com/bytemare/hash2curve/edgecases"
+	"github.com/bytemare/hash2curve/edwards25519"
+	"github.com/bytemare/hash2curve/nist"
+	"github.com/bytemare/hash2curve/secp256k1"
+)
+
+var edgeCaseMaps = map[string]func(u *big.Int) []byte{
+	nist.H2CP256:     func(u *big.Int) []byte { return nist.MapToCurveP256(u).BytesCompressed() },
+	nist.H2CP384:     func(u *big.Int) []byte { return nist.MapToCurveP384(u).BytesCompressed() },
+	nist.H2CP521:     func(u *big.Int) []byte { return nist.MapToCurveP521(u).BytesCompressed() },
+	secp256k1.H2C:    func(u *big.Int) []byte { return secp256k1.MapToCurve(u).Bytes() },
+	edwards25519.H2C: func(u *big.Int) []byte { return edwards25519.MapToCurve(u).Bytes() },
+}
+
+func edgeCaseExpand(test *edgecases.Test, msg, dst []byte) []byte {
+	switch test.Operation {
+	case edgecases.ExpandMessageXMD:
+		return hash2curve.ExpandXMD(mapXMD(test.Hash), msg, dst, test.Length)
+	case edgecases.ExpandMessageXOF:
+		return hash2curve.ExpandXOF(hash2curve.ExtendableXOF(mapXOF(test.Hash).GetXOF()), msg, dst, test.Length)
+	default:
+		panic(fmt.Sprintf("unexpected operation %q", test.Operation))
+	}
+}
+
+func runEdgeCase(t *testing.T, test *edgecases.Test) {
+	var output []byte
+
+	f := func() {
+		switch test.Operation {
+		case edgecases.ExpandMessageXMD, edgecases.ExpandMessageXOF:
+			msg, err := test.MsgBytes()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			dst, err := test.DSTBytes()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			output = edgeCaseExpand(test, msg, dst)
+		case edgecases.MapToCurve:
+			u, err := test.FieldElement()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			output = edgeCaseMaps[test.Suite](u)
+		default:
+			t.Fatalf("unexpected operation %q", test.Operation)
+		}
+	}
+
+	if test.Result == edgecases.Invalid {
+		if hasPanic, err := expectPanic(nil, f); !hasPanic {
+			t.Fatalf("expected failure: %v", err)
+		}
+
+		return
+	}
+
+	f()
+
+	if !test.CheckOutput(output) {
+		t.Fatalf("unexpected output %x", output)
+	}
+}
+
+func TestEdgeCases(t *testing.T) {
+	groups, err := edgecases.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(groups) == 0 {
+		t.Fatal("no test groups in corpus")
+	}
+
+	for _, group := range groups {
+		for _, test := range group.Tests {
+			t.Run(fmt.Sprintf("%s/%d %s %s%s", group.Name, test.ID, test.Comment, test.Hash, test.Suite), func(t *testing.T) {
+				runEdgeCase(t, &test)
+			})
+		}
+	}
+}
+
+func TestEdgeCases_IdentityAfterCofactorClearing(t *testing.T) {
+	group, err := edgecases.LoadFile("map_to_curve.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range group.Tests {
+		if !test.HasFlag(edgecases.FlagIdentityAfterCofactorClearing) {
+			continue
+		}
+
+		u, err := test.FieldElement()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		p := edwards25519.MapToCurve(u)
+		if p.MultByCofactor(p).Equal(edwards25519.MapToCurve(big.NewInt(0)).Subtract(p, p)) != 1 {
+			t.Fatalf("expected identity after cofactor clearing for test %d", test.ID)
+		}
+	}
+}
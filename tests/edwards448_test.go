@@ -0,0 +1,76 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+package hash2curve_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bytemare/hash"
+
+	"github.com/bytemare/hash2curve"
+	"github.com/bytemare/hash2curve/edwards448"
+)
+
+var edwards448DST = []byte("QUUX-V01-CS02-with-edwards448_XOF:SHAKE256_ELL2_RO_")
+
+func TestEdwards448_HashToScalar(t *testing.T) {
+	order := edwards448.Order()
+	if order.BitLen() != 446 {
+		t.Fatalf("expected a 446-bit order, got %d", order.BitLen())
+	}
+
+	for _, input := range []string{"", "abc", "abcdef0123456789"} {
+		expected := hash2curve.HashToFieldXOF(hash.SHAKE256.GetXOF(), []byte(input), edwards448DST, 3, 1, 84, order)
+
+		if edwards448.HashToScalar([]byte(input), edwards448DST).Cmp(expected[0]) == 0 {
+			t.Fatal("expected HashToScalar to differ from the first element of a longer expansion")
+		}
+
+		for i, s := range edwards448.HashToScalars([]byte(input), edwards448DST, 3) {
+			if s.Cmp(expected[i]) != 0 {
+				t.Fatalf("unexpected scalar %d for %q", i, input)
+			}
+		}
+
+		single := hash2curve.HashToFieldXOF(hash.SHAKE256.GetXOF(), []byte(input), edwards448DST, 1, 1, 84, order)
+		s := edwards448.HashToScalar([]byte(input), edwards448DST)
+
+		if s.Cmp(single[0]) != 0 {
+			t.Fatalf("unexpected scalar for %q", input)
+		}
+
+		enc := edwards448.HashToScalarBytes([]byte(input), edwards448DST)
+		if littleEndianScalar(enc[:]).Cmp(s) != 0 {
+			t.Fatalf("unexpected scalar encoding for %q", input)
+		}
+
+		// The HashToScalar of OPRF(decaf448, SHAKE-256) in RFC 9497 reduces 64 little-endian bytes instead.
+		shake := hash2curve.ExtendableXOF(hash.SHAKE256.GetXOF())
+		uniform := hash2curve.ExpandXOF(shake, []byte(input), edwards448DST, 64)
+		if oprf := littleEndianScalar(uniform); oprf.Mod(oprf, order).Cmp(s) == 0 {
+			t.Fatalf("unexpected RFC 9497 scalar for %q", input)
+		}
+	}
+}
+
+func TestEdwards448_HashToScalarErrors(t *testing.T) {
+	if hasPanic, err := expectPanic(hash2curve.ErrZeroLengthDST, func() {
+		edwards448.HashToScalar([]byte("input"), nil)
+	}); !hasPanic {
+		t.Fatalf("expected panic: %v", err)
+	}
+
+	err := panicError(func() { edwards448.HashToScalars([]byte("input"), edwards448DST, 0) })
+	if !errors.Is(err, hash2curve.ErrInvalidParameters) {
+		t.Fatalf("expected %v, got %v", hash2curve.ErrInvalidParameters, err)
+	}
+}
@@ -0,0 +1,213 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+package hash2curve_test
+
+import (
+	"bytes"
+	"crypto/ecdh"
+	"crypto/rand"
+	"encoding/hex"
+	"slices"
+	"testing"
+
+	"filippo.io/edwards25519"
+	"filippo.io/edwards25519/field"
+
+	h2cedwards25519 "github.com/bytemare/hash2curve/edwards25519"
+)
+
+func randomFieldElement(t *testing.T) *field.Element {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		t.Fatal(err)
+	}
+
+	e, err := new(field.Element).SetBytes(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return e
+}
+
+func TestElligator2_Inverse(t *testing.T) {
+	for i := range 256 {
+		p := h2cedwards25519.Elligator2Edwards(randomFieldElement(t))
+
+		if !h2cedwards25519.IsRepresentable(p) {
+			t.Fatalf("%d: map output is not representable", i)
+		}
+
+		representative, ok := h2cedwards25519.Representative(p, byte(i))
+		if !ok {
+			t.Fatalf("%d: no representative", i)
+		}
+
+		if representative[31]>>7 != byte(i>>1)&1 {
+			t.Fatalf("%d: the tweak is not applied to the top bit", i)
+		}
+
+		r, err := new(field.Element).SetBytes(representative)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if h2cedwards25519.Elligator2Edwards(r).Equal(p) != 1 {
+			t.Fatalf("%d: the representative does not map back to the point", i)
+		}
+	}
+
+	// r = 0 maps to the Montgomery point (0, 0).
+	zero := h2cedwards25519.Elligator2Edwards(new(field.Element).Zero())
+	if representative, ok := h2cedwards25519.Representative(zero, 0); !ok || representative[0] != 0 {
+		t.Fatal("unexpected representative for r = 0")
+	}
+
+	if h2cedwards25519.IsRepresentable(edwards25519.NewIdentityPoint()) {
+		t.Fatal("the identity must not be representable")
+	}
+}
+
+func TestElligator2_Representability(t *testing.T) {
+	representable := 0
+
+	for i := range 256 {
+		s := edwards25519.NewScalar()
+		if _, err := s.SetBytesWithClamping(randomFieldElement(t).Bytes()); err != nil {
+			t.Fatal(err)
+		}
+
+		p := new(edwards25519.Point).ScalarBaseMult(s)
+
+		representative, ok := h2cedwards25519.Representative(p, byte(i))
+		if ok != h2cedwards25519.IsRepresentable(p) {
+			t.Fatal("inconsistent representability")
+		}
+
+		if !ok {
+			continue
+		}
+
+		representable++
+
+		r, _ := new(field.Element).SetBytes(representative)
+		if h2cedwards25519.Elligator2Edwards(r).Equal(p) != 1 {
+			t.Fatalf("%d: the representative does not map back to the point", i)
+		}
+	}
+
+	// About half of the points are representable.
+	if representable < 64 || representable > 192 {
+		t.Fatalf("unexpected number of representable points: %d / 256", representable)
+	}
+}
+
+func TestCurve25519_X25519Output(t *testing.T) {
+	// From RFC 9380 appendix J.5, with the u-coordinates in big-endian.
+	for _, test := range []struct {
+		f        func(input, dst []byte) []byte
+		dst, msg string
+		u        string
+	}{
+		{
+			f:   h2cedwards25519.HashToCurve25519,
+			dst: "QUUX-V01-CS02-with-" + h2cedwards25519.H2C25519,
+			u:   "2de3780abb67e861289f5749d16d3e217ffa722192d16bbd9d1bfb9d112b98c0",
+		},
+		{
+			f:   h2cedwards25519.EncodeToCurve25519,
+			dst: "QUUX-V01-CS02-with-" + h2cedwards25519.E2C25519,
+			u:   "1bb913f0c9daefa0b3375378ffa534bda5526c97391952a7789eb976edfe4d08",
+		},
+	} {
+		expected, err := hex.DecodeString(test.u)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		slices.Reverse(expected)
+
+		u := test.f([]byte(test.msg), []byte(test.dst))
+		if !bytes.Equal(u, expected) {
+			t.Fatalf("%s: want %x, got %x", test.dst, expected, u)
+		}
+
+		// The output is a valid X25519 public value.
+		pub, err := ecdh.X25519().NewPublicKey(u)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		priv, err := ecdh.X25519().GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err = priv.ECDH(pub); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestEdwards25519_Into(t *testing.T) {
+	dst := []byte("QUUX-V01-CS02-with-edwards25519_XMD:SHA-512_ELL2_RO_")
+	p := edwards25519.NewIdentityPoint()
+
+	var encoding [32]byte
+
+	for _, input := range [][]byte{nil, []byte("abc"), []byte("abcdef0123456789")} {
+		want := h2cedwards25519.HashToCurve(input, dst)
+		if q := h2cedwards25519.HashToCurveInto(p, &encoding, input, dst); q != p || q.Equal(want) != 1 ||
+			!bytes.Equal(encoding[:], want.Bytes()) {
+			t.Fatal("unexpected hash-to-curve")
+		}
+
+		want = h2cedwards25519.EncodeToCurve(input, dst)
+		if q := h2cedwards25519.EncodeToCurveInto(p, &encoding, input, dst); q != p || q.Equal(want) != 1 ||
+			!bytes.Equal(encoding[:], want.Bytes()) {
+			t.Fatal("unexpected encode-to-curve")
+		}
+
+		if h2cedwards25519.HashToCurveInto(p, nil, input, dst).Equal(h2cedwards25519.HashToCurve(input, dst)) != 1 {
+			t.Fatal("unexpected hash-to-curve without encoding")
+		}
+	}
+
+	// The points are stored in sink, since they would otherwise not escape once inlined.
+	var sink *edwards25519.Point
+
+	into := testing.AllocsPerRun(10, func() { sink = h2cedwards25519.HashToCurveInto(p, &encoding, nil, dst) })
+	allocating := testing.AllocsPerRun(10, func() { sink = h2cedwards25519.HashToCurve(nil, dst) })
+
+	if sink == nil {
+		t.Fatal("unexpected nil point")
+	}
+
+	if into >= allocating {
+		t.Fatalf("expected fewer allocations, got %v and %v", into, allocating)
+	}
+}
+
+// TestEdwards25519_HashToScalarKAT pins the outputs of HashToScalar, computed independently as the little-endian
+// encoding of OS2IP(expand_message_xmd(msg, DST, 48)) mod l with hashlib in Python 3, after the fix of the byte order
+// of the group order, which was reduced modulo its little-endian encoding read as big-endian.
+func TestEdwards25519_HashToScalarKAT(t *testing.T) {
+	dst := []byte("QUUX-V01-CS02-with-edwards25519_XMD:SHA-512_ELL2_RO_")
+
+	for input, expected := range map[string]string{
+		"":    "a0b01287bb42c29d5ff26836cf7fd9f4af6e4119a27707e8d5ab4410dcc5e708",
+		"abc": "0580c9dfded98e624220b80a64a3c8d420b9196f5ff4ac93c563132a732f0c0e",
+	} {
+		if s := hex.EncodeToString(h2cedwards25519.HashToScalar([]byte(input), dst).Bytes()); s != expected {
+			t.Fatalf("unexpected scalar for %q: expected %s, got %s", input, expected, s)
+		}
+	}
+}
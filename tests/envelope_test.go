@@ -0,0 +1,94 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+package hash2curve_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/bytemare/hash2curve/envelope"
+	"github.com/bytemare/hash2curve/nist"
+	"github.com/bytemare/hash2curve/ristretto255"
+	"github.com/bytemare/hash2curve/suite"
+)
+
+func TestEnvelope_RoundTrip(t *testing.T) {
+	for _, id := range []string{nist.H2CP256, nist.E2CP521, ristretto255.H2C} {
+		s, err := suite.New(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		e := envelope.Hash(s, suiteInput, suiteDST)
+
+		encoded, err := envelope.Marshal(e)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		decoded, err := envelope.Unmarshal(encoded)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if decoded.Suite != id || decoded.DSTHash != e.DSTHash ||
+			!bytes.Equal(decoded.Point, s.Hash(suiteInput, suiteDST)) {
+			t.Fatalf("%s: unexpected envelope %+v", id, decoded)
+		}
+
+		if err = decoded.Verify(id, suiteDST); err != nil {
+			t.Fatal(err)
+		}
+
+		if err = decoded.Verify(nist.H2CP384, suiteDST); !errors.Is(err, envelope.ErrMismatch) {
+			t.Fatalf("expected %q, got %v", envelope.ErrMismatch, err)
+		}
+
+		if err = decoded.Verify(id, []byte("another DST of the application")); !errors.Is(err, envelope.ErrMismatch) {
+			t.Fatalf("expected %q, got %v", envelope.ErrMismatch, err)
+		}
+	}
+}
+
+func TestEnvelope_Invalid(t *testing.T) {
+	e := envelope.New(nist.H2CP256, suiteDST, nist.HashToP256(suiteInput, suiteDST).BytesCompressed())
+
+	encoded, err := e.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	badVersion := append([]byte{envelope.Version + 1}, encoded[1:]...)
+	badSuite := bytes.Replace(encoded, []byte("P256"), []byte("P000"), 1)
+
+	for name, data := range map[string][]byte{
+		"empty":     nil,
+		"version":   badVersion,
+		"suite":     badSuite,
+		"truncated": encoded[:len(encoded)-1],
+		"trailing":  append(append([]byte(nil), encoded...), 0),
+		"header":    encoded[:2+len(nist.H2CP256)+10],
+	} {
+		if _, err = envelope.Unmarshal(data); !errors.Is(err, envelope.ErrInvalidEnvelope) {
+			t.Fatalf("%s: expected %q, got %v", name, envelope.ErrInvalidEnvelope, err)
+		}
+	}
+
+	for name, e := range map[string]*envelope.Envelope{
+		"suite": envelope.New("not a suite", suiteDST, []byte{1}),
+		"point": envelope.New(nist.H2CP256, suiteDST, nil),
+	} {
+		if _, err = envelope.Marshal(e); !errors.Is(err, envelope.ErrInvalidEnvelope) {
+			t.Fatalf("%s: expected %q, got %v", name, envelope.ErrInvalidEnvelope, err)
+		}
+	}
+}
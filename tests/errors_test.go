@@ -0,0 +1,100 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+package hash2curve_test
+
+import (
+	"crypto"
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/bytemare/hash2curve"
+	"github.com/bytemare/hash2curve/bbs"
+	"github.com/bytemare/hash2curve/edwards25519"
+	"github.com/bytemare/hash2curve/nist"
+	"github.com/bytemare/hash2curve/secp256k1"
+	"github.com/bytemare/hash2curve/vectorgen"
+)
+
+// panicError returns the error f panics with, or nil.
+func panicError(f func()) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err, _ = r.(error)
+		}
+	}()
+
+	f()
+
+	return nil
+}
+
+func TestErrors_Sentinels(t *testing.T) {
+	dst := []byte("QUUX-V01-CS02-with-sentinel-errors")
+	huge := new(big.Int).Lsh(big.NewInt(1), 600)
+
+	for _, test := range []struct {
+		f    func()
+		err  error
+		name string
+	}{
+		{
+			name: "zero-length DST",
+			err:  hash2curve.ErrZeroLengthDST,
+			f:    func() { hash2curve.ExpandXMD(crypto.SHA256, nil, nil, 32) },
+		},
+		{
+			name: "length too large",
+			err:  hash2curve.ErrLengthTooLarge,
+			f:    func() { hash2curve.ExpandXMD(crypto.SHA256, nil, dst, math.MaxUint16+1) },
+		},
+		{
+			name: "invalid hash_to_field parameters",
+			err:  hash2curve.ErrInvalidParameters,
+			f:    func() { hash2curve.HashToFieldXMD(crypto.SHA256, nil, dst, 0, 1, 48, big.NewInt(7)) },
+		},
+		{
+			name: "non-canonical P-256",
+			err:  hash2curve.ErrNonCanonical,
+			f:    func() { nist.MapToCurveP256(huge) },
+		},
+		{
+			name: "non-canonical secp256k1",
+			err:  hash2curve.ErrNonCanonical,
+			f:    func() { secp256k1.MapToCurve(huge) },
+		},
+		{
+			name: "non-canonical edwards25519",
+			err:  hash2curve.ErrNonCanonical,
+			f:    func() { edwards25519.MapToCurve(huge) },
+		},
+		{
+			name: "invalid BBS ciphersuite",
+			err:  hash2curve.ErrInvalidSuite,
+			f:    func() { bbs.Ciphersuite(0).APIID() },
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if err := panicError(test.f); !errors.Is(err, test.err) {
+				t.Fatalf("want %v, got %v", test.err, err)
+			}
+		})
+	}
+
+	if _, err := hash2curve.ValidateSuiteID("P256_XMD:SHA-512_SSWU_RO_"); !errors.Is(err, hash2curve.ErrInvalidSuite) {
+		t.Fatalf("want %v, got %v", hash2curve.ErrInvalidSuite, err)
+	}
+
+	if _, err := vectorgen.Lookup("unknown"); !errors.Is(err, hash2curve.ErrInvalidSuite) {
+		t.Fatalf("want %v, got %v", hash2curve.ErrInvalidSuite, err)
+	}
+}
@@ -0,0 +1,90 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+package hash2curve_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/bytemare/hash2curve"
+	"github.com/bytemare/hash2curve/bn254"
+	"github.com/bytemare/hash2curve/ethereum"
+	"github.com/bytemare/hash2curve/secp256k1"
+)
+
+func TestEthereum_Address(t *testing.T) {
+	// The generator is the public key of the private key 1, with a well-known address.
+	g, err := secp256k1.DecodeHex("0279be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	address, err := ethereum.Address(g)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := hex.EncodeToString(address[:]); got != "7e5f4552091a69125d5dfcb7b8c2659029395bdf" {
+		t.Fatalf("unexpected address %s", got)
+	}
+}
+
+func TestEthereum_PublicKey(t *testing.T) {
+	p := secp256k1.HashToCurveKeccak256(suiteInput, suiteDST)
+
+	pk, err := ethereum.PublicKey(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !pk.Curve.IsOnCurve(pk.X, pk.Y) || pk.X.Cmp(&p.X) != 0 || pk.Y.Cmp(&p.Y) != 0 {
+		t.Fatal("unexpected public key")
+	}
+
+	b, err := ethereum.PublicKeyBytes(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(b) != ethereum.PublicKeyLength || !bytes.Equal(append([]byte{4}, b...), p.BytesUncompressed()) {
+		t.Fatalf("unexpected public key encoding %x", b)
+	}
+
+	identity := new(secp256k1.Point)
+
+	if _, err = ethereum.PublicKey(identity); !errors.Is(err, hash2curve.ErrInvalidPoint) {
+		t.Fatalf("expected %q, got %v", hash2curve.ErrInvalidPoint, err)
+	}
+
+	if _, err = ethereum.PublicKeyBytes(identity); !errors.Is(err, hash2curve.ErrInvalidPoint) {
+		t.Fatalf("expected %q, got %v", hash2curve.ErrInvalidPoint, err)
+	}
+
+	if _, err = ethereum.Address(identity); !errors.Is(err, hash2curve.ErrInvalidPoint) {
+		t.Fatalf("expected %q, got %v", hash2curve.ErrInvalidPoint, err)
+	}
+}
+
+func TestEthereum_G1(t *testing.T) {
+	p := bn254.HashToCurve(suiteInput, suiteDST)
+
+	b := ethereum.G1Bytes(p)
+	if len(b) != ethereum.G1Length {
+		t.Fatalf("unexpected length %d", len(b))
+	}
+
+	q, err := new(bn254.Point).SetBytes(b)
+	if err != nil || !q.Equal(p) {
+		t.Fatalf("the encoding does not decode to the point: %v", err)
+	}
+}
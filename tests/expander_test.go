@@ -6,22 +6,30 @@
 // LICENSE file in the root directory of this source tree or at
 // https://spdx.org/licenses/MIT.html
 
+//go:build !nomathbig
+
 package hash2curve_test
 
 import (
 	"bytes"
+	"context"
 	"crypto"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"math"
+	"math/big"
 	"os"
 	"path/filepath"
 	"strconv"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/bytemare/hash"
+	"golang.org/x/crypto/sha3"
 
 	"github.com/bytemare/hash2curve"
 	"github.com/bytemare/hash2curve/internal"
@@ -42,11 +50,440 @@ func TestExpander_ZeroDST(t *testing.T) {
 	_ = hash2curve.ExpandXMD(xmd1, msg, zeroDST, length)
 
 	xof1 := hash.SHAKE128
-	_ = hash2curve.ExpandXOF(xof1.GetXOF(), msg, zeroDST, length)
+	_ = hash2curve.ExpandXOF(hash2curve.ExtendableXOF(xof1.GetXOF()), msg, zeroDST, length)
 
 	t.Fatal("expected panic on zero length DST")
 }
 
+func TestExpander_StrictDST(t *testing.T) {
+	msg := []byte("test")
+	shortDST := []byte("short DST")
+	length := uint(32)
+
+	if err := hash2curve.ValidateDST(shortDST, false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := hash2curve.ValidateDST(shortDST, true); !errors.Is(err, hash2curve.ErrShortDST) {
+		t.Fatalf("expected %v, got %v", hash2curve.ErrShortDST, err)
+	}
+
+	if err := hash2curve.ValidateDST(nil, true); !errors.Is(err, hash2curve.ErrZeroLengthDST) {
+		t.Fatalf("expected %v, got %v", hash2curve.ErrZeroLengthDST, err)
+	}
+
+	// Short DSTs are only rejected with strict set.
+	_ = hash2curve.ExpandXMD(crypto.SHA256, msg, shortDST, length)
+	_ = hash2curve.ExpandXOF(hash2curve.ExtendableXOF(hash.SHAKE128.GetXOF()), msg, shortDST, length)
+}
+
+func TestExpander_InputLimits(t *testing.T) {
+	dst := []byte("QUUX-V01-CS02-with-input-limits")
+	input := bytes.Repeat([]byte{1}, 100)
+
+	hash2curve.SetInputLimits(64, 128)
+	defer hash2curve.SetInputLimits(0, 0)
+
+	if err := hash2curve.ValidateInput(input[:64], 128); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := hash2curve.ValidateInput(input, 32); !errors.Is(err, hash2curve.ErrInputTooLong) {
+		t.Fatalf("expected %v, got %v", hash2curve.ErrInputTooLong, err)
+	}
+
+	if err := hash2curve.ValidateInput(nil, 129); !errors.Is(err, hash2curve.ErrLengthTooLarge) {
+		t.Fatalf("expected %v, got %v", hash2curve.ErrLengthTooLarge, err)
+	}
+
+	if hasPanic, err := expectPanic(hash2curve.ErrInputTooLong, func() {
+		_ = hash2curve.ExpandXMD(crypto.SHA256, input, dst, 32)
+	}); !hasPanic {
+		t.Fatalf("expected panic: %v", err)
+	}
+
+	if hasPanic, err := expectPanic(hash2curve.ErrInputTooLong, func() {
+		_ = hash2curve.NewExpandReader(hash2curve.XMD(crypto.SHA256), input, dst)
+	}); !hasPanic {
+		t.Fatalf("expected panic: %v", err)
+	}
+
+	if panicked, _ := hasPanic(func() {
+		_ = hash2curve.ExpandXOF(hash2curve.ExtendableXOF(hash.SHAKE128.GetXOF()), nil, dst, 256)
+	}); !panicked {
+		t.Fatal("expected panic on an expansion over the limit")
+	}
+
+	_ = hash2curve.ExpandXMD(crypto.SHA256, input[:64], dst, 128)
+}
+
+func TestExpander_Try(t *testing.T) {
+	dst := []byte("QUUX-V01-CS02-with-input-limits")
+	input := bytes.Repeat([]byte{1}, 100)
+	xof := hash2curve.ExtendableXOF(hash.SHAKE128.GetXOF())
+
+	out, err := hash2curve.TryExpandXMD(crypto.SHA256, input, dst, 32)
+	if err != nil || !bytes.Equal(out, hash2curve.ExpandXMD(crypto.SHA256, input, dst, 32)) {
+		t.Fatalf("unexpected expansion: %v", err)
+	}
+
+	out, err = hash2curve.TryExpandXOF(xof, input, dst, 32)
+	if err != nil || !bytes.Equal(out, hash2curve.ExpandXOF(xof, input, dst, 32)) {
+		t.Fatalf("unexpected expansion: %v", err)
+	}
+
+	hash2curve.SetInputLimits(64, 128)
+	defer hash2curve.SetInputLimits(0, 0)
+
+	for _, test := range []struct {
+		expected error
+		input    []byte
+		dst      []byte
+		length   uint
+	}{
+		{hash2curve.ErrZeroLengthDST, nil, nil, 32},
+		{hash2curve.ErrInputTooLong, input, dst, 32},
+		{hash2curve.ErrLengthTooLarge, nil, dst, 129},
+	} {
+		if _, err = hash2curve.TryExpandXMD(crypto.SHA256, test.input, test.dst, test.length); !errors.Is(
+			err, test.expected) {
+			t.Fatalf("expected %v, got %v", test.expected, err)
+		}
+
+		if _, err = hash2curve.TryExpandXOF(xof, test.input, test.dst, test.length); !errors.Is(err, test.expected) {
+			t.Fatalf("expected %v, got %v", test.expected, err)
+		}
+	}
+
+	// Lengths over what expand_message supports are rejected without limits.
+	hash2curve.SetInputLimits(0, 0)
+
+	if _, err = hash2curve.TryExpandXMD(crypto.SHA256, nil, dst, 255*32+1); !errors.Is(
+		err, hash2curve.ErrLengthTooLarge) {
+		t.Fatalf("expected %v, got %v", hash2curve.ErrLengthTooLarge, err)
+	}
+
+	if _, err = hash2curve.TryExpandXOF(xof, nil, dst, math.MaxUint16+1); !errors.Is(err, hash2curve.ErrLengthTooLarge) {
+		t.Fatalf("expected %v, got %v", hash2curve.ErrLengthTooLarge, err)
+	}
+}
+
+func TestExpander_XMDBatch(t *testing.T) {
+	inputs := [][]byte{nil, []byte("abc"), bytes.Repeat([]byte("a"), 1000)}
+
+	for _, dst := range [][]byte{[]byte("QUUX-V01-CS02-with-expander-SHA256-128"), bytes.Repeat([]byte("b"), 300)} {
+		for _, length := range []uint{0x20, 0x80} {
+			out := hash2curve.ExpandXMDBatch(crypto.SHA256, inputs, dst, length)
+			if len(out) != len(inputs) {
+				t.Fatalf("expected %d outputs, got %d", len(inputs), len(out))
+			}
+
+			for i, input := range inputs {
+				if !bytes.Equal(out[i], hash2curve.ExpandXMD(crypto.SHA256, input, dst, length)) {
+					t.Fatalf("batch output %d mismatch", i)
+				}
+			}
+		}
+	}
+
+	if out := hash2curve.ExpandXMDBatch(crypto.SHA256, nil, []byte("dst"), 32); len(out) != 0 {
+		t.Fatal("expected an empty batch")
+	}
+
+	if hasPanic, err := expectPanic(hash2curve.ErrZeroLengthDST, func() {
+		_ = hash2curve.ExpandXMDBatch(crypto.SHA256, inputs, nil, 32)
+	}); !hasPanic {
+		t.Fatalf("expected panic: %v", err)
+	}
+}
+
+func TestExpander_XMDBatchSHA256x8(t *testing.T) {
+	// The multi-lane SHA-256 is only used by default without the SHA extensions, and isn't available without AVX2.
+	defer internal.ForceSHA256x8(internal.ForceSHA256x8(true))
+
+	// Lengths around the block boundaries of the b_0 message, with and without the l_i_b_str and DST suffixes.
+	var inputs [][]byte
+	for _, l := range []int{0, 1, 3, 55, 56, 63, 64, 65, 100, 119, 120, 128, 1000} {
+		inputs = append(inputs, bytes.Repeat([]byte{byte(l)}, l))
+	}
+
+	for _, dst := range [][]byte{[]byte("QUUX-V01-CS02-with-expander-SHA256-128"), bytes.Repeat([]byte("b"), 300)} {
+		for _, length := range []uint{1, 0x20, 0x30, 0x80, 255 * 32} {
+			for n := range len(inputs) + 1 {
+				out := hash2curve.ExpandXMDBatch(crypto.SHA256, inputs[:n], dst, length)
+				if len(out) != n {
+					t.Fatalf("expected %d outputs, got %d", n, len(out))
+				}
+
+				for i, input := range inputs[:n] {
+					if !bytes.Equal(out[i], hash2curve.ExpandXMD(crypto.SHA256, input, dst, length)) {
+						t.Fatalf("batch output %d of %d mismatch for length %d", i, n, length)
+					}
+				}
+			}
+		}
+	}
+
+	ctx := &countdownContext{Context: context.Background(), n: 1}
+	if out, err := hash2curve.ExpandXMDBatchContext(ctx, crypto.SHA256, inputs, inputs[1], 32); !errors.Is(
+		err, context.Canceled) || out != nil {
+		t.Fatalf("expected %v and no outputs, got %v", context.Canceled, err)
+	}
+
+	if hasPanic, err := expectPanic(hash2curve.ErrLengthTooLarge, func() {
+		_ = hash2curve.ExpandXMDBatch(crypto.SHA256, inputs, inputs[1], 256*32)
+	}); !hasPanic {
+		t.Fatalf("expected panic: %v", err)
+	}
+}
+
+// countdownContext is a context whose Err starts returning context.Canceled after n calls.
+type countdownContext struct {
+	context.Context
+	n int
+}
+
+func (c *countdownContext) Err() error {
+	if c.n == 0 {
+		return context.Canceled
+	}
+
+	c.n--
+
+	return nil
+}
+
+func TestExpander_XMDBatchContext(t *testing.T) {
+	inputs := [][]byte{nil, []byte("abc"), bytes.Repeat([]byte("a"), 1000)}
+	dst := []byte("QUUX-V01-CS02-with-expander-SHA256-128")
+	want := hash2curve.ExpandXMDBatch(crypto.SHA256, inputs, dst, 32)
+
+	out, err := hash2curve.ExpandXMDBatchContext(context.Background(), crypto.SHA256, inputs, dst, 32)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := range want {
+		if !bytes.Equal(out[i], want[i]) {
+			t.Fatalf("batch output %d mismatch", i)
+		}
+	}
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	expired, cancelExpired := context.WithDeadline(context.Background(), time.Now())
+	defer cancelExpired()
+
+	for _, test := range []struct {
+		ctx  context.Context
+		want error
+	}{
+		{ctx: cancelled, want: context.Canceled},
+		{ctx: expired, want: context.DeadlineExceeded},
+		{ctx: &countdownContext{Context: context.Background(), n: 2}, want: context.Canceled},
+	} {
+		if out, err = hash2curve.ExpandXMDBatchContext(test.ctx, crypto.SHA256, inputs, dst, 32); !errors.Is(
+			err, test.want) || out != nil {
+			t.Fatalf("expected %v and no outputs, got %v", test.want, err)
+		}
+	}
+
+	if hasPanic, err := expectPanic(hash2curve.ErrZeroLengthDST, func() {
+		_, _ = hash2curve.ExpandXMDBatchContext(context.Background(), crypto.SHA256, inputs, nil, 32)
+	}); !hasPanic {
+		t.Fatalf("expected panic: %v", err)
+	}
+}
+
+func TestExpander_DSTNotAliased(t *testing.T) {
+	msg := []byte("abc")
+	backing := []byte("QUUX-V01-CS02-with-expander-SHA256-128#")
+	dst := backing[:len(backing)-1]
+	expected := hash2curve.ExpandXMD(crypto.SHA256, msg, []byte(string(dst)), 32)
+
+	for _, f := range []func(){
+		func() { _ = hash2curve.ExpandXMD(crypto.SHA256, msg, dst, 32) },
+		func() { _ = hash2curve.ExpandXMDBatch(crypto.SHA256, [][]byte{msg}, dst, 32) },
+		func() { _ = hash2curve.NewExpandReader(hash2curve.XMD(crypto.SHA256), msg, dst) },
+		func() { _ = hash2curve.DSTPrime(dst) },
+	} {
+		f()
+
+		if backing[len(backing)-1] != '#' {
+			t.Fatal("the spare capacity of the DST was written to")
+		}
+	}
+
+	if !bytes.Equal(hash2curve.ExpandXMD(crypto.SHA256, msg, dst, 32), expected) {
+		t.Fatal("unexpected output")
+	}
+
+	// The XOF stream is computed on the first read, after the caller modified its buffers.
+	xof := hash2curve.XOF(hash.SHAKE128)
+	dst = []byte("QUUX-V01-CS02-with-expander-SHAKE128")
+	want := make([]byte, 32)
+	_, _ = io.ReadFull(hash2curve.NewExpandReader(xof, msg, dst), want)
+
+	r := hash2curve.NewExpandReader(xof, msg, dst)
+	dst[0], msg[0] = 'X', 'X'
+
+	got := make([]byte, 32)
+	if _, err := io.ReadFull(r, got); err != nil || !bytes.Equal(got, want) {
+		t.Fatal("the stream depends on the caller's buffers after its creation")
+	}
+}
+
+func TestExpander_ExpandMessage(t *testing.T) {
+	msg := []byte("abc")
+	dst := []byte("QUUX-V01-CS02-with-expand-message")
+
+	for _, h := range []hash.Hash{hash.SHA256, hash.SHA512, hash.SHA3_256, hash.SHAKE128, hash.SHAKE256} {
+		var expected []byte
+		if h.Type() == hash.FixedOutputLength {
+			expected = hash2curve.ExpandXMD(crypto.Hash(h), msg, dst, 80)
+		} else {
+			expected = hash2curve.ExpandXOF(hash2curve.ExtendableXOF(h.GetXOF()), msg, dst, 80)
+		}
+
+		if !bytes.Equal(hash2curve.ExpandMessage(h, msg, dst, 80), expected) {
+			t.Fatalf("%s: unexpected output", h)
+		}
+	}
+
+	for _, h := range []hash.Hash{0, 42} {
+		if hasPanic, err := expectPanic(hash2curve.ErrUnsupportedHash, func() {
+			_ = hash2curve.ExpandMessage(h, msg, dst, 32)
+		}); !hasPanic {
+			t.Fatalf("expected panic: %v", err)
+		}
+	}
+}
+
+type securityLevelXOF struct {
+	sha3.ShakeHash
+}
+
+func (securityLevelXOF) BlockSize() int     { return 0 }
+func (securityLevelXOF) SecurityLevel() int { return 128 }
+
+// unknownXOF is an XOF of unknown security level.
+type unknownXOF struct{}
+
+func (unknownXOF) Write(p []byte) (int, error) { return len(p), nil }
+func (unknownXOF) Read(p []byte) (int, error)  { return len(p), nil }
+func (unknownXOF) Reset()                      {}
+
+func TestExpander_XOFInterface(t *testing.T) {
+	msg := []byte("abc")
+	longDST := bytes.Repeat([]byte("a"), 300)
+
+	for _, test := range []struct {
+		reference hash.Hash
+		new       func() sha3.ShakeHash
+	}{
+		{hash.SHAKE128, sha3.NewShake128},
+		{hash.SHAKE256, sha3.NewShake256},
+	} {
+		for _, dst := range [][]byte{[]byte("QUUX-V01-CS02-with-expander-SHAKE"), longDST} {
+			expected := hash2curve.ExpandXOF(hash2curve.ExtendableXOF(test.reference.GetXOF()), msg, dst, 80)
+
+			x := test.new()
+			_, _ = x.Write([]byte("state to be reset"))
+
+			if !bytes.Equal(hash2curve.ExpandXOF(x, msg, dst, 80), expected) {
+				t.Fatalf("%s: unexpected output with a DST of length %d", test.reference, len(dst))
+			}
+		}
+	}
+
+	expected := hash2curve.ExpandXOF(hash2curve.ExtendableXOF(hash.SHAKE128.GetXOF()), msg, longDST, 32)
+	if !bytes.Equal(hash2curve.ExpandXOF(securityLevelXOF{sha3.NewShake128()}, msg, longDST, 32), expected) {
+		t.Fatal("unexpected output with an explicit security level")
+	}
+
+	// Fixed-output hash functions are not XOFs, even if they can be read, e.g. with the block size of SHAKE256.
+	for _, h := range []any{sha3.New256(), sha3.New512(), sha3.NewLegacyKeccak256()} {
+		if x, ok := h.(hash2curve.XOFState); ok {
+			for _, dst := range [][]byte{[]byte("dst"), longDST} {
+				if hasPanic, err := expectPanic(hash2curve.ErrUnsupportedHash, func() {
+					hash2curve.ExpandXOF(x, msg, dst, 32)
+				}); !hasPanic {
+					t.Fatalf("expected panic: %v", err)
+				}
+			}
+		}
+	}
+
+	_ = hash2curve.ExpandXOF(unknownXOF{}, msg, []byte("dst"), 32)
+
+	if hasPanic, err := expectPanic(hash2curve.ErrUnsupportedHash, func() {
+		hash2curve.ExpandXOF(unknownXOF{}, msg, longDST, 32)
+	}); !hasPanic {
+		t.Fatalf("expected panic: %v", err)
+	}
+
+	// The adapted *hash.ExtendableHash reads as an io.Reader.
+	x := hash2curve.ExtendableXOF(hash.SHAKE128.GetXOF())
+	_, _ = x.Write([]byte("abc"))
+
+	out, expected := make([]byte, 48), make([]byte, 48)
+	sha3.ShakeSum128(expected, []byte("abc"))
+
+	if _, err := x.Read(out); err != nil || !bytes.Equal(out, expected) {
+		t.Fatal("unexpected output of the adapted XOF")
+	}
+}
+
+func TestExpander_Hedged(t *testing.T) {
+	msg := []byte("abc")
+	aux := bytes.Repeat([]byte{0x2a}, 32)
+	dst := []byte("QUUX-V01-CS02-with-expander-SHA256-128")
+	hedgedInput := append(append([]byte{0, 32}, aux...), msg...)
+	hedgedDST := append([]byte("HEDGED-"), dst...)
+
+	if !bytes.Equal(hash2curve.HedgedInput(msg, aux), hedgedInput) ||
+		!bytes.Equal(hash2curve.HedgedDST(dst), hedgedDST) {
+		t.Fatal("unexpected hedged input or DST")
+	}
+
+	out := hash2curve.ExpandXMDHedged(crypto.SHA256, msg, aux, dst, 32)
+	if !bytes.Equal(out, hash2curve.ExpandXMD(crypto.SHA256, hedgedInput, hedgedDST, 32)) {
+		t.Fatal("unexpected hedged XMD output")
+	}
+
+	// The hedged expansion is separated from the plain one, even with the same input.
+	if bytes.Equal(out, hash2curve.ExpandXMD(crypto.SHA256, hedgedInput, dst, 32)) {
+		t.Fatal("hedged and plain expansions collide")
+	}
+
+	if bytes.Equal(out, hash2curve.ExpandXMDHedged(crypto.SHA256, msg, aux[1:], dst, 32)) {
+		t.Fatal("the auxiliary randomness is not mixed in")
+	}
+
+	xof := hash2curve.ExpandXOFHedged(hash2curve.ExtendableXOF(hash.SHAKE128.GetXOF()), msg, aux, dst, 32)
+	if !bytes.Equal(xof, hash2curve.ExpandXOF(hash2curve.ExtendableXOF(hash.SHAKE128.GetXOF()), hedgedInput, hedgedDST, 32)) {
+		t.Fatal("unexpected hedged XOF output")
+	}
+
+	for _, f := range []func(){
+		func() { hash2curve.ExpandXMDHedged(crypto.SHA256, msg, aux, nil, 32) },
+		func() {
+			hash2curve.ExpandXOFHedged(hash2curve.ExtendableXOF(hash.SHAKE128.GetXOF()), msg, aux, nil, 32)
+		},
+	} {
+		if hasPanic, err := expectPanic(hash2curve.ErrZeroLengthDST, f); !hasPanic {
+			t.Fatalf("expected panic: %v", err)
+		}
+	}
+
+	if hasPanic, err := expectPanic(nil, func() {
+		hash2curve.ExpandXMDHedged(crypto.SHA256, msg, make([]byte, 1<<16), dst, 32)
+	}); !hasPanic {
+		t.Fatalf("expected panic: %v", err)
+	}
+}
+
 func TestExpander_LongDST(t *testing.T) {
 	msg := []byte("test")
 	longDST := []byte(
@@ -59,7 +496,127 @@ func TestExpander_LongDST(t *testing.T) {
 	_ = hash2curve.ExpandXMD(xmd1, msg, longDST, length)
 
 	xof1 := hash.SHAKE128
-	_ = hash2curve.ExpandXOF(xof1.GetXOF(), msg, longDST, length)
+	_ = hash2curve.ExpandXOF(hash2curve.ExtendableXOF(xof1.GetXOF()), msg, longDST, length)
+}
+
+func TestExpander_ReduceDST(t *testing.T) {
+	msg := []byte("test")
+	prefix := []byte("H2C-OVERSIZE-DST-")
+	shortDST := []byte("QUUX-V01-CS02-with-expander-SHA256-128")
+	longDST := bytes.Repeat([]byte("a"), 256)
+
+	if !bytes.Equal(hash2curve.ReduceDSTXMD(crypto.SHA256, shortDST), shortDST) ||
+		!bytes.Equal(hash2curve.ReduceDSTXOF(sha3.NewShake128(), shortDST), shortDST) {
+		t.Fatal("expected short DSTs to be kept")
+	}
+
+	// H("H2C-OVERSIZE-DST-" || DST) for XMD, and 2 * k / 8 = 32 bytes of output for SHAKE128.
+	h := crypto.SHA256.New()
+	h.Write(prefix)
+	h.Write(longDST)
+
+	shake := sha3.NewShake128()
+	shake.Write(prefix)
+	shake.Write(longDST)
+
+	xofTag := make([]byte, 32)
+	_, _ = shake.Read(xofTag)
+
+	xmd := hash2curve.ReduceDSTXMD(crypto.SHA256, longDST)
+	if !bytes.Equal(xmd, h.Sum(nil)) {
+		t.Fatalf("unexpected XMD tag %x", xmd)
+	}
+
+	xof := hash2curve.ReduceDSTXOF(hash2curve.ExtendableXOF(hash.SHAKE128.GetXOF()), longDST)
+	if !bytes.Equal(xof, xofTag) {
+		t.Fatalf("unexpected XOF tag %x", xof)
+	}
+
+	// The reduced tags yield the same expansions as the oversize DSTs.
+	if !bytes.Equal(hash2curve.ExpandXMD(crypto.SHA256, msg, longDST, 32),
+		hash2curve.ExpandXMD(crypto.SHA256, msg, xmd, 32)) {
+		t.Fatal("expected the same XMD expansion with the reduced tag")
+	}
+
+	if !bytes.Equal(hash2curve.ExpandXOF(sha3.NewShake128(), msg, longDST, 32),
+		hash2curve.ExpandXOF(sha3.NewShake128(), msg, xof, 32)) {
+		t.Fatal("expected the same XOF expansion with the reduced tag")
+	}
+
+	// An output of 3 bytes can't hold the 2 * k bits of the reduced tag.
+	short := hash.SHAKE128.GetXOF()
+	short.SetOutputSize(3)
+
+	if hasPanic, err := expectPanic(hash2curve.ErrInsufficientSecurity, func() {
+		_ = hash2curve.ReduceDSTXOF(hash2curve.ExtendableXOF(short), longDST)
+	}); !hasPanic {
+		t.Fatal(err)
+	}
+}
+
+func TestExpander_MsgPrime(t *testing.T) {
+	dst := []byte("QUUX-V01-CS02-with-expander-SHA256-128")
+
+	// Z_pad is a block of 64 zero bytes for SHA-256, followed by msg, I2OSP(32, 2), 0, and DST_prime.
+	xmd := hash2curve.MsgPrimeXMD(crypto.SHA256, []byte("abc"), dst, 32)
+	want := append(make([]byte, 64), "abc\x00\x20\x00"...)
+	want = append(want, hash2curve.DSTPrime(dst)...)
+
+	if !bytes.Equal(xmd, want) {
+		t.Fatalf("unexpected msg_prime %x", xmd)
+	}
+
+	xof := hash2curve.MsgPrimeXOF([]byte("abc"), dst, 32)
+	if !bytes.Equal(xof, append([]byte("abc\x00\x20"), hash2curve.DSTPrime(dst)...)) {
+		t.Fatalf("unexpected msg_prime %x", xof)
+	}
+
+	for _, f := range []func(){
+		func() { _ = hash2curve.DSTPrime(bytes.Repeat([]byte("a"), 256)) },
+		func() { _ = hash2curve.MsgPrimeXOF(nil, dst, math.MaxUint16+1) },
+	} {
+		if hasPanic, err := expectPanic(hash2curve.ErrLengthTooLarge, f); !hasPanic {
+			t.Fatalf("expected panic: %v", err)
+		}
+	}
+}
+
+func TestExpander_HKDF(t *testing.T) {
+	// RFC 5869 test case 1, with the salt as DST.
+	ikm := bytes.Repeat([]byte{0x0b}, 22)
+	salt, _ := hex.DecodeString("000102030405060708090a0b0c")
+	info, _ := hex.DecodeString("f0f1f2f3f4f5f6f7f8f9")
+	want, _ := hex.DecodeString(
+		"3cb25f25faacd57a90434f64d0362f2a2d2d0a90cf1a5a4c5db02d56ecc4c5bf34007208d5b887185865")
+
+	if got := hash2curve.ExpandHKDF(crypto.SHA256, ikm, salt, info, 42); !bytes.Equal(got, want) {
+		t.Fatalf("unexpected output %x", got)
+	}
+
+	if got := hash2curve.HKDF(crypto.SHA256, info).Expand(ikm, salt, 42); !bytes.Equal(got, want) {
+		t.Fatalf("unexpected Expander output %x", got)
+	}
+
+	if got := hash2curve.HKDF(crypto.SHA256, info).MaxLength(); got != 255*32 {
+		t.Fatalf("unexpected maximum length %d", got)
+	}
+
+	xmd := hash2curve.ExpandXMD(crypto.SHA256, ikm, salt, 32)
+	if bytes.Equal(hash2curve.ExpandHKDF(crypto.SHA256, ikm, salt, nil, 32), xmd) {
+		t.Fatal("expected HKDF to differ from expand_message_xmd")
+	}
+
+	for _, test := range []struct {
+		err error
+		f   func()
+	}{
+		{hash2curve.ErrZeroLengthDST, func() { _ = hash2curve.ExpandHKDF(crypto.SHA256, ikm, nil, info, 32) }},
+		{hash2curve.ErrLengthTooLarge, func() { _ = hash2curve.ExpandHKDF(crypto.SHA256, ikm, salt, info, 255*32+1) }},
+	} {
+		if hasPanic, err := expectPanic(test.err, test.f); !hasPanic {
+			t.Fatalf("expected panic: %v", err)
+		}
+	}
 }
 
 func TestExpander_XMDHighLength(t *testing.T) {
@@ -78,10 +635,45 @@ func TestExpander_XOFHighLength(t *testing.T) {
 	}()
 
 	length := uint(math.MaxUint16 + 1)
-	_ = hash2curve.ExpandXOF(hash.SHAKE128.GetXOF(), []byte("input"), []byte("dst"), length)
+	_ = hash2curve.ExpandXOF(hash2curve.ExtendableXOF(hash.SHAKE128.GetXOF()), []byte("input"), []byte("dst"), length)
 	t.Fatal("expected panic on extremely high requested output length")
 }
 
+func TestExpander_XMDConcurrent(t *testing.T) {
+	dst := []byte("QUUX-V01-CS02-with-expander-SHA256-128")
+	expected := make([][]byte, 16)
+
+	for i := range expected {
+		expected[i] = hash2curve.ExpandXMD(crypto.SHA256, []byte{byte(i)}, dst, uint(32*(i+1)))
+	}
+
+	var wg sync.WaitGroup
+
+	errs := make(chan error, 16*len(expected))
+
+	for range 16 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for i := range expected {
+				x := hash2curve.ExpandXMD(crypto.SHA256, []byte{byte(i)}, dst, uint(32*(i+1)))
+				if !bytes.Equal(x, expected[i]) {
+					errs <- fmt.Errorf("unexpected output for input %d", i)
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Fatal(err)
+	}
+}
+
 type vector struct {
 	dstPrime     []byte
 	msg          []byte
@@ -177,42 +769,19 @@ func isXMD(id string) bool {
 	return id == "SHA256" || id == "SHA512"
 }
 
-func concatenate(input ...[]byte) []byte {
-	length := 0
-	for _, b := range input {
-		length += len(b)
-	}
-
-	buf := make([]byte, 0, length)
-
-	for _, in := range input {
-		buf = append(buf, in...)
-	}
-
-	return buf
-}
-
 func msgPrime(h hash.Hash, input, dst []byte, length uint) []byte {
-	lib := internal.I2OSP(length, 2)
-	dstPrime := internal.DstPrime(dst)
-
 	if h.Type() == hash.ExtendableOutputFunction {
-		return concatenate(input, lib, dstPrime)
+		return hash2curve.MsgPrimeXOF(input, dst, length)
 	}
 
-	zPad := make([]byte, h.BlockSize())
-	zeroByte := []byte{0}
-
-	return concatenate(zPad, input, lib, zeroByte, dstPrime)
+	return hash2curve.MsgPrimeXMD(crypto.Hash(h), input, dst, length)
 }
 
 func (s *set) dst() []byte {
 	if isXMD(s.Hash) {
-		h := mapXMD(s.Hash)
-		return internal.VetDSTXMD(h.New(), []byte(s.DST))
+		return hash2curve.ReduceDSTXMD(mapXMD(s.Hash), []byte(s.DST))
 	} else {
-		h := mapXOF(s.Hash)
-		return internal.VetXofDST(h.GetXOF(), []byte(s.DST))
+		return hash2curve.ReduceDSTXOF(hash2curve.ExtendableXOF(mapXOF(s.Hash).GetXOF()), []byte(s.DST))
 	}
 }
 
@@ -227,7 +796,7 @@ func (s *set) run(t *testing.T) {
 				t.Fatalf("%d : %v", i, err)
 			}
 
-			dstPrime := internal.DstPrime(dst)
+			dstPrime := hash2curve.DSTPrime(dst)
 			if !bytes.Equal(v.dstPrime, dstPrime) {
 				t.Fatalf("%d : invalid DST prime.\ngot : %v\nwant: %v", i, dstPrime, v.dstPrime)
 			}
@@ -241,7 +810,7 @@ func (s *set) run(t *testing.T) {
 			if isXMD(s.Hash) {
 				x = hash2curve.ExpandXMD(mapXMD(s.Hash), v.msg, dst, v.lenInBytes)
 			} else {
-				x = hash2curve.ExpandXOF(mapXOF(s.Hash).GetXOF(), v.msg, dst, v.lenInBytes)
+				x = hash2curve.ExpandXOF(hash2curve.ExtendableXOF(mapXOF(s.Hash).GetXOF()), v.msg, dst, v.lenInBytes)
 			}
 
 			if !bytes.Equal(v.uniformBytes, x) {
@@ -298,3 +867,38 @@ func TestExpander_Vectors(t *testing.T) {
 		t.Fatalf("error opening set vectorStrings: %v", err)
 	}
 }
+
+func TestWipe(t *testing.T) {
+	b := []byte("secret")
+	hash2curve.Wipe(b)
+
+	if !bytes.Equal(b, make([]byte, 6)) {
+		t.Fatal("expected the buffer to be wiped")
+	}
+
+	x := new(big.Int).SetBytes(bytes.Repeat([]byte{0xff}, 40))
+	words := x.Bits()
+
+	hash2curve.WipeInts(x, nil)
+
+	if x.Sign() != 0 {
+		t.Fatal("expected the integer to be 0")
+	}
+
+	for _, w := range words {
+		if w != 0 {
+			t.Fatal("expected the integer words to be wiped")
+		}
+	}
+
+	// Wiping the intermediate buffers must not alter the outputs.
+	dst := []byte("QUUX-V01-CS02-with-expander-SHA256-128")
+	for _, length := range []uint{0x20, 0x80} {
+		first := hash2curve.ExpandXMD(crypto.SHA256, []byte("abc"), dst, length)
+		second := hash2curve.ExpandXMD(crypto.SHA256, []byte("abc"), dst, length)
+
+		if !bytes.Equal(first, second) || bytes.Equal(first, make([]byte, length)) {
+			t.Fatal("unexpected expansion output")
+		}
+	}
+}
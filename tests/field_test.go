@@ -0,0 +1,299 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package hash2curve_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/bytemare/hash2curve/internal/field"
+)
+
+func TestField_BatchInvert(t *testing.T) {
+	p, _ := new(big.Int).SetString("ffffffff00000001000000000000000000000000ffffffffffffffffffffffff", 16)
+	f := field.NewField(p)
+
+	x := make([]*big.Int, 7)
+	for i := range x {
+		r, err := rand.Int(rand.Reader, p)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		x[i] = r
+	}
+
+	x[2].SetInt64(0)
+	x[6].SetInt64(0)
+
+	want := make([]*big.Int, len(x))
+	for i, e := range x {
+		want[i] = new(big.Int)
+		f.Inv(want[i], e)
+	}
+
+	res := make([]*big.Int, len(x))
+	for i := range res {
+		res[i] = new(big.Int)
+	}
+
+	f.BatchInvert(res, x)
+
+	for i := range res {
+		if res[i].Cmp(want[i]) != 0 {
+			t.Fatalf("unexpected inverse at index %d", i)
+		}
+	}
+
+	// In place, aliasing the input.
+	f.BatchInvert(x, x)
+
+	for i := range x {
+		if x[i].Cmp(want[i]) != 0 {
+			t.Fatalf("unexpected in-place inverse at index %d", i)
+		}
+	}
+
+	f.BatchInvert(nil, nil)
+}
+
+func TestField_CondMovSgn0(t *testing.T) {
+	p, _ := new(big.Int).SetString("ffffffff00000001000000000000000000000000ffffffffffffffffffffffff", 16)
+	f := field.NewField(p)
+
+	x := big.NewInt(3)
+	y := new(big.Int).Sub(p, big.NewInt(1))
+
+	res := new(big.Int)
+	if f.CondMov(res, x, y, true); res.Cmp(y) != 0 {
+		t.Fatal("expected y")
+	}
+
+	if f.CondMov(res, x, y, false); res.Cmp(x) != 0 {
+		t.Fatal("expected x")
+	}
+
+	// Aliasing the output.
+	if f.CondMov(x, x, y, true); x.Cmp(y) != 0 {
+		t.Fatal("expected y in place")
+	}
+
+	for _, e := range []*big.Int{big.NewInt(0), big.NewInt(1), big.NewInt(2), y, new(big.Int).Sub(p, big.NewInt(2))} {
+		if f.Sgn0(e) != e.Bit(0) {
+			t.Fatalf("unexpected sgn0 for %s", e)
+		}
+	}
+
+	// The fixed-width encodings are not allocated, and neither is res once it has the capacity of an element.
+	if allocs := testing.AllocsPerRun(100, func() {
+		f.CondMov(res, x, y, true)
+		f.CondMov(res, x, y, false)
+		_ = f.Sgn0(y)
+	}); allocs != 0 {
+		t.Fatalf("unexpected %v allocations", allocs)
+	}
+}
+
+func TestField_Reducer(t *testing.T) {
+	moduli := []string{
+		"ffffffff00000001000000000000000000000000ffffffffffffffffffffffff",
+		"1000000000000000000000000000000014def9dea2f79cd65812631a5cf5d3ed",
+		"fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141",
+		"01ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff" +
+			"ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+	}
+
+	for _, m := range moduli {
+		modulus, _ := new(big.Int).SetString(m, 16)
+
+		for _, length := range []uint{48, 64, 98, 128} {
+			if 8*length < uint(modulus.BitLen()) {
+				continue
+			}
+
+			r := field.NewReducer(modulus, length)
+			inputs := [][]byte{make([]byte, length), bytes.Repeat([]byte{0xff}, int(length))}
+
+			for range 32 {
+				in := make([]byte, length)
+				if _, err := rand.Read(in); err != nil {
+					t.Fatal(err)
+				}
+
+				inputs = append(inputs, in)
+			}
+
+			for _, in := range inputs {
+				want := new(big.Int).SetBytes(in)
+				want.Mod(want, modulus)
+
+				if got := r.Reduce(in); got.Cmp(want) != 0 {
+					t.Fatalf("reduction mismatch for %x mod %s", in, m)
+				}
+			}
+		}
+	}
+}
+
+func TestField_SquareRoot(t *testing.T) {
+	// 2^511 - 187 = 5 mod 8, and the prime of MNT4-298 = 1 mod 8, for Tonelli-Shanks.
+	mnt4, _ := new(big.Int).SetString(
+		"475922286169261325753349249653048451545124879242694725395555128576210262817955800483758081", 10)
+
+	for _, p := range []*big.Int{
+		new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 511), big.NewInt(187)),
+		mnt4,
+		big.NewInt(17),
+	} {
+		f := field.NewField(p)
+
+		for range 32 {
+			r, err := rand.Int(rand.Reader, p)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			sq, root, check := new(big.Int), new(big.Int), new(big.Int)
+			f.Square(sq, r)
+
+			if !f.IsSquare(sq) && sq.Sign() != 0 {
+				t.Fatal("expected a square")
+			}
+
+			f.SquareRoot(root, sq)
+			f.Square(check, root)
+
+			if check.Cmp(sq) != 0 {
+				t.Fatalf("invalid square root modulo %d", p)
+			}
+		}
+	}
+}
+
+func TestField_LimbBacked(t *testing.T) {
+	for _, p := range []*big.Int{
+		new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 255), big.NewInt(19)),
+		new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 521), big.NewInt(1)),
+		new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 640), big.NewInt(1)), // not supported by the limbs
+	} {
+		f, l := field.NewField(p), field.NewLimbBackedField(p)
+
+		for range 16 {
+			x, err := rand.Int(rand.Reader, p)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			y, err := rand.Int(rand.Reader, p)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var want, got big.Int
+
+			f.Add(&want, x, y)
+			l.Add(&got, x, y)
+
+			if want.Cmp(&got) != 0 {
+				t.Fatalf("add mismatch modulo %x", p)
+			}
+
+			if f.Sub(&want, x, y).Cmp(l.Sub(&got, x, y)) != 0 {
+				t.Fatalf("sub mismatch modulo %x", p)
+			}
+
+			f.Mul(&want, x, y)
+			l.Mul(&got, x, y)
+
+			if want.Cmp(&got) != 0 {
+				t.Fatalf("mul mismatch modulo %x", p)
+			}
+
+			if f.Exponent(&want, x, y).Cmp(l.Exponent(&got, x, y)) != 0 {
+				t.Fatalf("exponent mismatch modulo %x", p)
+			}
+		}
+	}
+}
+
+func TestField_Limbs(t *testing.T) {
+	// Primes of 1 to 9 limbs, with the largest supported one, 2^576 - 789.
+	for _, p := range []*big.Int{
+		big.NewInt(17),
+		new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 64), big.NewInt(59)),
+		new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 255), big.NewInt(19)),
+		new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 521), big.NewInt(1)),
+		new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 576), big.NewInt(789)),
+	} {
+		f := field.NewLimbField(p)
+		pMinus1 := new(big.Int).Sub(p, big.NewInt(1))
+		pMinus2 := new(big.Int).Sub(p, big.NewInt(2))
+
+		for i := range 64 {
+			x, err := rand.Int(rand.Reader, p)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			y, err := rand.Int(rand.Reader, p)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// The edge values 0 and p - 1 are covered first.
+			switch i {
+			case 0:
+				x.SetInt64(0)
+			case 1:
+				x.Set(pMinus1)
+				y.Set(pMinus1)
+			}
+
+			var a, b, e field.Element
+
+			f.SetBig(&a, x)
+			f.SetBig(&b, y)
+
+			for _, test := range []struct {
+				got  *field.Element
+				want *big.Int
+				name string
+			}{
+				{name: "add", got: f.Add(&e, &a, &b), want: new(big.Int).Add(x, y)},
+				{name: "sub", got: f.Sub(new(field.Element), &a, &b), want: new(big.Int).Sub(x, y)},
+				{name: "neg", got: f.Neg(new(field.Element), &a), want: new(big.Int).Neg(x)},
+				{name: "mul", got: f.Mul(new(field.Element), &a, &b), want: new(big.Int).Mul(x, y)},
+				{name: "inv", got: f.Inv(new(field.Element), &a), want: new(big.Int).Exp(x, pMinus2, p)},
+			} {
+				if got := f.Big(new(big.Int), test.got); got.Cmp(test.want.Mod(test.want, p)) != 0 {
+					t.Fatalf("%s mismatch modulo %x: want %x, got %x", test.name, p, test.want, got)
+				}
+			}
+
+			encoded := f.Bytes(&a)
+			if len(encoded) != f.ByteLen() || new(big.Int).SetBytes(encoded).Cmp(x) != 0 {
+				t.Fatalf("unexpected encoding %x of %x", encoded, x)
+			}
+
+			if d, ok := f.SetBytes(new(field.Element), encoded); !ok || f.Equal(d, &a) != 1 {
+				t.Fatalf("unexpected decoding of %x", encoded)
+			}
+		}
+
+		if _, ok := f.SetBytes(new(field.Element), p.FillBytes(make([]byte, f.ByteLen()))); ok {
+			t.Fatal("expected the encoding of p to be rejected")
+		}
+	}
+
+	if ok, err := expectPanic(nil, func() { field.NewLimbField(big.NewInt(16)) }); !ok {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,76 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+package hash2curve_test
+
+import (
+	"crypto"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/bytemare/hash2curve"
+)
+
+type fieldHasherTest struct {
+	modulo                     *big.Int
+	name                       string
+	id                         crypto.Hash
+	count, ext, securityLength uint
+}
+
+func fieldHasherTests() []fieldHasherTest {
+	p256, _ := new(big.Int).SetString("ffffffff00000001000000000000000000000000ffffffffffffffffffffffff", 16)
+	p521 := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 521), big.NewInt(1))
+	p25519 := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 255), big.NewInt(19))
+
+	return []fieldHasherTest{
+		{name: "P256", id: crypto.SHA256, count: 2, ext: 1, securityLength: 48, modulo: p256},
+		{name: "P521", id: crypto.SHA512, count: 2, ext: 1, securityLength: 98, modulo: p521},
+		{name: "edwards25519", id: crypto.SHA512, count: 1, ext: 1, securityLength: 48, modulo: p25519},
+		{name: "ext2", id: crypto.SHA256, count: 2, ext: 2, securityLength: 64, modulo: p25519},
+	}
+}
+
+func TestFieldHasher(t *testing.T) {
+	for _, test := range fieldHasherTests() {
+		t.Run(test.name, func(t *testing.T) {
+			f := hash2curve.NewFieldHasher(test.id, test.count, test.ext, test.securityLength, test.modulo)
+
+			for _, input := range [][]byte{nil, []byte("abc"), scratchInput} {
+				expected := hash2curve.HashToFieldXMD(
+					test.id, input, scratchDST, test.count, test.ext, test.securityLength, test.modulo,
+				)
+
+				elements := f.HashToField(input, scratchDST)
+				if len(elements) != len(expected) {
+					t.Fatalf("expected %d elements, got %d", len(expected), len(elements))
+				}
+
+				for i, e := range elements {
+					if e.Cmp(expected[i]) != 0 {
+						t.Fatalf("element %d: expected %x, got %x", i, expected[i], e)
+					}
+				}
+			}
+
+			if allocs := testing.AllocsPerRun(10, func() { f.HashToField(scratchInput, scratchDST) }); allocs != 0 {
+				t.Errorf("expected no allocations, got %v", allocs)
+			}
+		})
+	}
+}
+
+func TestFieldHasher_InvalidParameters(t *testing.T) {
+	err := panicError(func() { hash2curve.NewFieldHasher(crypto.SHA256, 0, 1, 48, big.NewInt(7)) })
+	if !errors.Is(err, hash2curve.ErrInvalidParameters) {
+		t.Fatalf("expected %q, got %v", hash2curve.ErrInvalidParameters, err)
+	}
+}
@@ -0,0 +1,177 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+package hash2curve_test
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/bytemare/hash2curve"
+	"github.com/bytemare/hash2curve/internal/field/fp256k1"
+	"github.com/bytemare/hash2curve/secp256k1"
+)
+
+var secp256k1Fp = new(big.Int).SetBytes(fp256k1.Order())
+
+func randomFp256k1(t *testing.T) (*fp256k1.Element, *big.Int) {
+	i, err := rand.Int(rand.Reader, secp256k1Fp)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return new(fp256k1.Element).SetBig(i), i
+}
+
+func TestFp256k1_Arithmetic(t *testing.T) {
+	edges := []*big.Int{
+		big.NewInt(0),
+		big.NewInt(1),
+		new(big.Int).Sub(secp256k1Fp, big.NewInt(1)),
+		new(big.Int).Lsh(big.NewInt(1), 255),
+	}
+
+	for i := range 200 {
+		a, ab := randomFp256k1(t)
+		b, bb := randomFp256k1(t)
+
+		if i < len(edges)*len(edges) {
+			ab, bb = edges[i%len(edges)], edges[i/len(edges)]
+			a, b = new(fp256k1.Element).SetBig(ab), new(fp256k1.Element).SetBig(bb)
+		}
+
+		check := func(name string, got *fp256k1.Element, want *big.Int) {
+			want.Mod(want, secp256k1Fp)
+			if got.Big().Cmp(want) != 0 {
+				t.Fatalf("%s(%x, %x): want %x, got %x", name, ab, bb, want, got.Big())
+			}
+		}
+
+		check("add", new(fp256k1.Element).Add(a, b), new(big.Int).Add(ab, bb))
+		check("sub", new(fp256k1.Element).Subtract(a, b), new(big.Int).Sub(ab, bb))
+		check("mul", new(fp256k1.Element).Multiply(a, b), new(big.Int).Mul(ab, bb))
+		check("neg", new(fp256k1.Element).Negate(a), new(big.Int).Neg(ab))
+
+		inv := new(big.Int).ModInverse(ab, secp256k1Fp)
+		if inv == nil {
+			inv = new(big.Int)
+		}
+
+		check("inv", new(fp256k1.Element).Invert(a), inv)
+
+		var s fp256k1.Element
+		isSquare := s.Sqrt(a) == 1
+
+		if (big.Jacobi(ab, secp256k1Fp) >= 0) != isSquare {
+			t.Fatalf("sqrt(%x): unexpected square status %v", ab, isSquare)
+		}
+
+		if isSquare {
+			check("sqrt", new(fp256k1.Element).Square(&s), new(big.Int).Set(ab))
+		}
+	}
+}
+
+func TestFp256k1_SetBytes(t *testing.T) {
+	var b [fp256k1.ElementLength]byte
+
+	copy(b[:], fp256k1.Order())
+
+	e, canonical := new(fp256k1.Element).SetBytes(&b)
+	if canonical || e.IsZero() != 1 {
+		t.Fatal("expected the field order to be non-canonical and reduced to 0")
+	}
+
+	b[31]--
+
+	e, canonical = new(fp256k1.Element).SetBytes(&b)
+	if !canonical || e.Bytes() != b {
+		t.Fatal("expected p-1 to be canonical and to round-trip")
+	}
+}
+
+func TestFp256k1_BatchInvert(t *testing.T) {
+	in := make([]*fp256k1.Element, 6)
+	for i := range in {
+		in[i], _ = randomFp256k1(t)
+	}
+
+	in[0].Zero()
+	in[3].Zero()
+
+	want := make([]*fp256k1.Element, len(in))
+	for i, e := range in {
+		want[i] = new(fp256k1.Element).Invert(e)
+	}
+
+	// In place, aliasing the input.
+	fp256k1.BatchInvert(in, in)
+
+	for i := range in {
+		if in[i].Equal(want[i]) != 1 {
+			t.Fatalf("unexpected inverse at index %d", i)
+		}
+	}
+
+	fp256k1.BatchInvert(nil, nil)
+}
+
+func TestFp256k1_SetWideBytes(t *testing.T) {
+	for _, length := range []int{33, 48, 64} {
+		inputs := [][]byte{bytes.Repeat([]byte{0xff}, length), make([]byte, length)}
+
+		for range 16 {
+			b := make([]byte, length)
+			if _, err := rand.Read(b); err != nil {
+				t.Fatal(err)
+			}
+
+			inputs = append(inputs, b)
+		}
+
+		for _, b := range inputs {
+			want := new(big.Int).SetBytes(b)
+			want.Mod(want, secp256k1Fp)
+
+			if got := new(fp256k1.Element).SetWideBytes(b).Big(); got.Cmp(want) != 0 {
+				t.Fatalf("unexpected reduction of %x", b)
+			}
+		}
+	}
+}
+
+func TestSecp256k1_HashToField(t *testing.T) {
+	dst := []byte("QUUX-V01-CS02-with-secp256k1_XMD:SHA-256_SSWU_RO_")
+	input := []byte("abc")
+
+	u := secp256k1.HashToField(input, dst, 2)
+	expected := hash2curve.HashToFieldXMD(crypto.SHA256, input, dst, 2, 1, 48, primeSecp256k1)
+
+	for i := range u {
+		if u[i].Cmp(expected[i]) != 0 {
+			t.Fatalf("u[%d] mismatch", i)
+		}
+	}
+
+	p := secp256k1.MapToCurve(u[0])
+	p.Add(p, secp256k1.MapToCurve(u[1]))
+
+	if !bytes.Equal(p.Bytes(), secp256k1.HashToCurve(input, dst).Bytes()) {
+		t.Fatal("hash_to_curve mismatch")
+	}
+
+	nu := secp256k1.HashToField(input, dst, 1)
+	if !bytes.Equal(secp256k1.MapToCurve(nu[0]).Bytes(), secp256k1.EncodeToCurve(input, dst).Bytes()) {
+		t.Fatal("encode_to_curve mismatch")
+	}
+}
@@ -6,9 +6,12 @@
 // LICENSE file in the root directory of this source tree or at
 // https://spdx.org/licenses/MIT.html
 
+//go:build !nomathbig
+
 package hash2curve_test
 
 import (
+	"bytes"
 	"crypto"
 	"math"
 	"math/big"
@@ -17,6 +20,10 @@ import (
 	"github.com/bytemare/hash"
 
 	"github.com/bytemare/hash2curve"
+	"github.com/bytemare/hash2curve/edwards25519"
+	"github.com/bytemare/hash2curve/nist"
+	"github.com/bytemare/hash2curve/ristretto255"
+	"github.com/bytemare/hash2curve/secp256k1"
 )
 
 func fuzzTestSkipInput(t *testing.T, dst []byte, length uint) {
@@ -51,6 +58,12 @@ func fuzzTestSkipXMDInput(t *testing.T, h uint, dst []byte, length uint) {
 	}
 }
 
+func fuzzTestSkipHashToField(t *testing.T, count, ext, securityLength uint, modulo int64) {
+	if err := hash2curve.ValidateHashToField(count, ext, securityLength, big.NewInt(modulo)); err != nil {
+		t.Skip(err)
+	}
+}
+
 func FuzzExpandXMD(f *testing.F) {
 	f.Fuzz(func(t *testing.T, h uint, input, dst []byte, length uint) {
 		fuzzTestSkipXMDInput(t, h, dst, length)
@@ -61,6 +74,7 @@ func FuzzExpandXMD(f *testing.F) {
 func FuzzHashToFieldXMD(f *testing.F) {
 	f.Fuzz(func(t *testing.T, id uint, input, dst []byte, count, ext, securityLength uint, modulo int64) {
 		fuzzTestSkipXMDInput(t, id, dst, count*ext*securityLength)
+		fuzzTestSkipHashToField(t, count, ext, securityLength, modulo)
 		_ = hash2curve.HashToFieldXMD(crypto.Hash(id), input, dst, count, ext, securityLength, big.NewInt(modulo))
 	})
 }
@@ -84,13 +98,14 @@ func fuzzTestSkipXOFInput(t *testing.T, h uint, dst []byte, length uint) {
 func FuzzExpandXOF(f *testing.F) {
 	f.Fuzz(func(t *testing.T, h uint, input, dst []byte, length uint) {
 		fuzzTestSkipXOFInput(t, h, dst, length)
-		_ = hash2curve.ExpandXOF(hash.Hash(h).GetXOF(), input, dst, length)
+		_ = hash2curve.ExpandXOF(hash2curve.ExtendableXOF(hash.Hash(h).GetXOF()), input, dst, length)
 	})
 }
 
 func FuzzHashToFieldXOF(f *testing.F) {
 	f.Fuzz(func(t *testing.T, id uint, input, dst []byte, count, ext, securityLength uint, modulo int64) {
 		fuzzTestSkipXOFInput(t, id, dst, count*ext*securityLength)
+		fuzzTestSkipHashToField(t, count, ext, securityLength, modulo)
 		_ = hash2curve.HashToFieldXOF(
 			hash.Hash(id).GetXOF(),
 			input,
@@ -102,3 +117,128 @@ func FuzzHashToFieldXOF(f *testing.F) {
 		)
 	})
 }
+
+// curveMapping is a hash_to_curve or encode_to_curve function of a curve package returning the point's encoding, and
+// the validation function of the package.
+type curveMapping struct {
+	hash     func(input, dst []byte) []byte
+	validate func(encoded []byte) error
+	name     string
+}
+
+// fuzzCurveMappings checks the invariants of the mappings: the output decodes to a point on the curve and in the
+// prime-order subgroup, is deterministic, and differs across DSTs.
+func fuzzCurveMappings(f *testing.F, mappings ...curveMapping) {
+	f.Add([]byte("abc"), []byte("QUUX-V01-CS02-with-fuzzing"), []byte("QUUX-V01-CS02-with-fuzzing-2"))
+	f.Fuzz(func(t *testing.T, input, dst1, dst2 []byte) {
+		if len(dst1) == 0 || len(dst2) == 0 {
+			t.Skip("zero length dst")
+		}
+
+		for _, m := range mappings {
+			p := m.hash(input, dst1)
+
+			if err := m.validate(p); err != nil {
+				t.Fatalf("%s: invalid output %x: %v", m.name, p, err)
+			}
+
+			if !bytes.Equal(p, m.hash(input, dst1)) {
+				t.Fatalf("%s: non-deterministic output", m.name)
+			}
+
+			if !bytes.Equal(dst1, dst2) && bytes.Equal(p, m.hash(input, dst2)) {
+				t.Fatalf("%s: same output with different DSTs", m.name)
+			}
+		}
+	})
+}
+
+func FuzzP256(f *testing.F) {
+	fuzzCurveMappings(f,
+		curveMapping{
+			name:     nist.H2CP256,
+			hash:     func(i, d []byte) []byte { return nist.HashToP256(i, d).BytesCompressed() },
+			validate: nist.ValidateP256,
+		},
+		curveMapping{
+			name:     nist.E2CP256,
+			hash:     func(i, d []byte) []byte { return nist.EncodeToP256(i, d).BytesCompressed() },
+			validate: nist.ValidateP256,
+		},
+	)
+}
+
+func FuzzP384(f *testing.F) {
+	fuzzCurveMappings(f,
+		curveMapping{
+			name:     nist.H2CP384,
+			hash:     func(i, d []byte) []byte { return nist.HashToP384(i, d).BytesCompressed() },
+			validate: nist.ValidateP384,
+		},
+		curveMapping{
+			name:     nist.E2CP384,
+			hash:     func(i, d []byte) []byte { return nist.EncodeToP384(i, d).BytesCompressed() },
+			validate: nist.ValidateP384,
+		},
+	)
+}
+
+func FuzzP521(f *testing.F) {
+	fuzzCurveMappings(f,
+		curveMapping{
+			name:     nist.H2CP521,
+			hash:     func(i, d []byte) []byte { return nist.HashToP521(i, d).BytesCompressed() },
+			validate: nist.ValidateP521,
+		},
+		curveMapping{
+			name:     nist.E2CP521,
+			hash:     func(i, d []byte) []byte { return nist.EncodeToP521(i, d).BytesCompressed() },
+			validate: nist.ValidateP521,
+		},
+	)
+}
+
+func FuzzSecp256k1(f *testing.F) {
+	fuzzCurveMappings(f,
+		curveMapping{
+			name:     secp256k1.H2C,
+			hash:     func(i, d []byte) []byte { return secp256k1.HashToCurve(i, d).Bytes() },
+			validate: secp256k1.Validate,
+		},
+		curveMapping{
+			name:     secp256k1.E2C,
+			hash:     func(i, d []byte) []byte { return secp256k1.EncodeToCurve(i, d).Bytes() },
+			validate: secp256k1.Validate,
+		},
+	)
+}
+
+func FuzzEdwards25519(f *testing.F) {
+	fuzzCurveMappings(f,
+		curveMapping{
+			name:     edwards25519.H2C,
+			hash:     func(i, d []byte) []byte { return edwards25519.HashToCurve(i, d).Bytes() },
+			validate: edwards25519.Validate,
+		},
+		curveMapping{
+			name:     edwards25519.E2C,
+			hash:     func(i, d []byte) []byte { return edwards25519.EncodeToCurve(i, d).Bytes() },
+			validate: edwards25519.Validate,
+		},
+	)
+}
+
+func FuzzRistretto255(f *testing.F) {
+	fuzzCurveMappings(f,
+		curveMapping{
+			name:     ristretto255.H2C,
+			hash:     func(i, d []byte) []byte { return ristretto255.HashToGroup(i, d).Encode(nil) },
+			validate: ristretto255.Validate,
+		},
+		curveMapping{
+			name:     ristretto255.E2C,
+			hash:     func(i, d []byte) []byte { return ristretto255.EncodeToGroup(i, d).Encode(nil) },
+			validate: ristretto255.Validate,
+		},
+	)
+}
@@ -0,0 +1,325 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+package hash2curve_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"math/big"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bytemare/hash2curve/genfield"
+	"github.com/bytemare/hash2curve/internal/field/fp256"
+)
+
+func TestGenfield_Golden(t *testing.T) {
+	const args = "-prime 0xffffffff00000001000000000000000000000000ffffffffffffffffffffffff " +
+		"-package fp256 -out fp256.go"
+
+	config := genfield.Config{
+		Prime:   fp256.Modulus(),
+		Package: "fp256",
+		Command: "genfield " + args,
+	}
+
+	for file, generate := range map[string]func(genfield.Config) ([]byte, error){
+		"fp256.go":     genfield.Generate,
+		"fp256_big.go": genfield.GenerateBig,
+	} {
+		src, err := generate(config)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		golden, err := os.ReadFile("../internal/field/fp256/" + file)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(src, golden) {
+			t.Fatalf("the generated %s is out of date, run go generate", file)
+		}
+	}
+}
+
+func TestGenfield_Primes(t *testing.T) {
+	for _, test := range []struct {
+		prime   string
+		hasSqrt bool
+	}{
+		{"0x7fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffed", false},
+		{"0xfffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f", true},
+		{new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 521), big.NewInt(1)).Text(10), true},
+		{"65537", false},
+	} {
+		p, _ := new(big.Int).SetString(test.prime, 0)
+
+		src, err := genfield.Generate(genfield.Config{Prime: p, Package: "field"})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err = parser.ParseFile(token.NewFileSet(), "", src, 0); err != nil {
+			t.Fatal(err)
+		}
+
+		if strings.Contains(string(src), "math/big") {
+			t.Fatalf("%s: the field depends on math/big", test.prime)
+		}
+
+		bigSrc, err := genfield.GenerateBig(genfield.Config{Prime: p, Package: "field"})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err = parser.ParseFile(token.NewFileSet(), "", bigSrc, 0); err != nil {
+			t.Fatal(err)
+		}
+
+		if strings.Contains(string(src), "func (e *Element) Sqrt") != test.hasSqrt {
+			t.Fatalf("%s: unexpected Sqrt", test.prime)
+		}
+	}
+
+	for _, c := range []genfield.Config{
+		{Prime: nil, Package: "field"},
+		{Prime: big.NewInt(3), Package: "field"},
+		{Prime: big.NewInt(91), Package: "field"},
+		{Prime: new(big.Int).Lsh(big.NewInt(1), 600), Package: "field"},
+		{Prime: big.NewInt(65537), Package: "not a name"},
+	} {
+		if _, err := genfield.Generate(c); err == nil {
+			t.Fatalf("expected an error for %+v", c)
+		}
+
+		if _, err := genfield.GenerateBig(c); err == nil {
+			t.Fatalf("expected an error for %+v", c)
+		}
+	}
+}
+
+func randomFp256(t *testing.T) (*fp256.Element, *big.Int) {
+	i, err := rand.Int(rand.Reader, fp256.Modulus())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return new(fp256.Element).SetBig(i), i
+}
+
+func TestGenfield_Arithmetic(t *testing.T) {
+	p := fp256.Modulus()
+
+	for range 64 {
+		x, xi := randomFp256(t)
+		y, yi := randomFp256(t)
+
+		for _, test := range []struct {
+			got  *fp256.Element
+			want *big.Int
+		}{
+			{new(fp256.Element).Add(x, y), new(big.Int).Add(xi, yi)},
+			{new(fp256.Element).Subtract(x, y), new(big.Int).Sub(xi, yi)},
+			{new(fp256.Element).Negate(x), new(big.Int).Neg(xi)},
+			{new(fp256.Element).Multiply(x, y), new(big.Int).Mul(xi, yi)},
+			{new(fp256.Element).Square(x), new(big.Int).Mul(xi, xi)},
+			{new(fp256.Element).Invert(x), new(big.Int).ModInverse(xi, p)},
+		} {
+			if test.got.Big().Cmp(test.want.Mod(test.want, p)) != 0 {
+				t.Fatal("arithmetic mismatch")
+			}
+		}
+
+		var r fp256.Element
+		if isSquare := r.Sqrt(x); isSquare != (big.Jacobi(xi, p)+1)/2 && xi.Sign() != 0 {
+			t.Fatal("unexpected square detection")
+		} else if isSquare == 1 && new(fp256.Element).Square(&r).Equal(x) != 1 {
+			t.Fatal("invalid square root")
+		}
+
+		if x.Sgn0() != int(xi.Bit(0)) {
+			t.Fatal("unexpected sgn0")
+		}
+	}
+
+	// Non-canonical encodings are reduced.
+	var b [fp256.ElementLength]byte
+	for i := range b {
+		b[i] = 0xff
+	}
+
+	e, canonical := new(fp256.Element).SetBytes(&b)
+	if canonical || e.Big().Cmp(new(big.Int).Mod(new(big.Int).SetBytes(b[:]), p)) != 0 {
+		t.Fatal("unexpected non-canonical decoding")
+	}
+
+	wide := bytes.Repeat([]byte{0xff}, 3*fp256.ElementLength-1)
+	if new(fp256.Element).SetWideBytes(wide).Big().Cmp(new(big.Int).Mod(new(big.Int).SetBytes(wide), p)) != 0 {
+		t.Fatal("unexpected wide reduction")
+	}
+
+	if new(fp256.Element).One().Big().Cmp(big.NewInt(1)) != 0 || new(fp256.Element).IsZero() != 1 {
+		t.Fatal("unexpected constants")
+	}
+}
+
+// widthTest is the arithmetic test run against the field generated in each package of TestGenfield_Widths.
+const widthTest = `package %s
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+)
+
+func TestArithmetic(t *testing.T) {
+	p := Modulus()
+
+	for range 32 {
+		xi, _ := rand.Int(rand.Reader, p)
+		yi, _ := rand.Int(rand.Reader, p)
+		x, y := new(Element).SetBig(xi), new(Element).SetBig(yi)
+
+		for _, test := range []struct {
+			got  *Element
+			want *big.Int
+		}{
+			{new(Element).Add(x, y), new(big.Int).Add(xi, yi)},
+			{new(Element).Subtract(x, y), new(big.Int).Sub(xi, yi)},
+			{new(Element).Negate(x), new(big.Int).Neg(xi)},
+			{new(Element).Multiply(x, y), new(big.Int).Mul(xi, yi)},
+			{new(Element).Square(x), new(big.Int).Mul(xi, xi)},
+			{new(Element).Invert(x), new(big.Int).ModInverse(xi, p)},
+		} {
+			if test.got.Big().Cmp(test.want.Mod(test.want, p)) != 0 {
+				t.Fatal("arithmetic mismatch")
+			}
+		}
+
+		b := x.Bytes()
+		if e, canonical := new(Element).SetBytes(&b); !canonical || e.Equal(x) != 1 {
+			t.Fatal("encoding mismatch")
+		}
+
+		if x.Sgn0() != int(xi.Bit(0)) || new(Element).Select(x, y, 1).Equal(x) != 1 {
+			t.Fatal("unexpected sgn0 or select")
+		}
+
+		wide := make([]byte, 2*ElementLength+3)
+		_, _ = rand.Read(wide)
+
+		if new(Element).SetWideBytes(wide).Big().Cmp(new(big.Int).Mod(new(big.Int).SetBytes(wide), p)) != 0 {
+			t.Fatal("wide reduction mismatch")
+		}
+	%s}
+}
+`
+
+// widthSqrt is the square root test of the fields with a Sqrt.
+const widthSqrt = `
+		var r Element
+		if isSquare := r.Sqrt(x); isSquare != (big.Jacobi(xi, p)+1)/2 && xi.Sign() != 0 {
+			t.Fatal("unexpected square detection")
+		} else if isSquare == 1 && new(Element).Square(&r).Equal(x) != 1 {
+			t.Fatal("invalid square root")
+		}
+`
+
+// widthPrimes returns a prime of each supported number of limbs, the largest below 2^(64*limbs) that is 3 mod 4, and
+// the primes of curve25519 and P-521.
+func widthPrimes() []*big.Int {
+	primes := make([]*big.Int, 0, 11)
+
+	for limbs := 1; limbs <= 9; limbs++ {
+		p := new(big.Int).Lsh(big.NewInt(1), uint(64*limbs))
+		p.Sub(p, big.NewInt(1))
+
+		for !p.ProbablyPrime(20) {
+			p.Sub(p, big.NewInt(4))
+		}
+
+		primes = append(primes, p)
+	}
+
+	p25519 := new(big.Int).Lsh(big.NewInt(1), 255)
+	p521 := new(big.Int).Lsh(big.NewInt(1), 521)
+
+	return append(primes, p25519.Sub(p25519, big.NewInt(19)), p521.Sub(p521, big.NewInt(1)))
+}
+
+// TestGenfield_Widths compiles and tests the generated fields of all widths, from 1 to 9 limbs, in a scratch module.
+func TestGenfield_Widths(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds a module for each width")
+	}
+
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("the go command is not available")
+	}
+
+	dir := t.TempDir()
+	if err = os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module widths\n\ngo 1.22\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, p := range widthPrimes() {
+		pkg := fmt.Sprintf("field%d", i)
+
+		src, err := genfield.Generate(genfield.Config{Prime: p, Package: pkg})
+		if err != nil {
+			t.Fatalf("%s: %v", p.Text(16), err)
+		}
+
+		bigSrc, err := genfield.GenerateBig(genfield.Config{Prime: p, Package: pkg})
+		if err != nil {
+			t.Fatalf("%s: %v", p.Text(16), err)
+		}
+
+		sqrt := ""
+		if p.Bit(1) == 1 {
+			sqrt = widthSqrt
+		}
+
+		test := fmt.Sprintf(widthTest, pkg, sqrt)
+
+		if err = os.Mkdir(filepath.Join(dir, pkg), 0o700); err != nil {
+			t.Fatal(err)
+		}
+
+		if err = os.WriteFile(filepath.Join(dir, pkg, pkg+".go"), src, 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		if err = os.WriteFile(filepath.Join(dir, pkg, pkg+"_big.go"), bigSrc, 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		if err = os.WriteFile(filepath.Join(dir, pkg, pkg+"_test.go"), []byte(test), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cmd := exec.Command(goBin, "test", "./...")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOWORK=off", "GOFLAGS=-mod=mod")
+
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("%v\n%s", err, out)
+	}
+}
@@ -0,0 +1,81 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+package hash2curve_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bytemare/hash2curve"
+	"github.com/bytemare/hash2curve/golden"
+)
+
+func TestGolden_Check(t *testing.T) {
+	if err := golden.Check(); err != nil {
+		t.Fatal(err)
+	}
+
+	records, err := golden.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	covered := make(map[string]bool)
+	for _, r := range records {
+		covered[r.Suite] = true
+	}
+
+	for _, id := range hash2curve.AllSuites() {
+		if !covered[id.String()] {
+			t.Fatalf("no golden records for %s", id)
+		}
+	}
+}
+
+func TestGolden_Mismatch(t *testing.T) {
+	records, err := golden.Generate(
+		[]hash2curve.Suite{hash2curve.P256SHA256SSWURO},
+		[][]byte{[]byte("abc")},
+		[][]byte{[]byte("QUUX-V01-CS02-with-P256_XMD:SHA-256_SSWU_RO_")},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(records) != 2 {
+		t.Fatalf("expected 2 records, got %d", len(records))
+	}
+
+	for _, r := range records {
+		if err = r.Check(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	changed := records[0]
+	changed.Output = records[1].Output
+
+	unknown := records[0]
+	unknown.Operation = "map_to_curve"
+
+	invalid := records[0]
+	invalid.DST = ""
+
+	for _, r := range []golden.Record{changed, unknown, invalid} {
+		if err = r.Check(); !errors.Is(err, golden.ErrMismatch) {
+			t.Fatalf("expected %v, got %v", golden.ErrMismatch, err)
+		}
+	}
+
+	if _, err = golden.Generate([]hash2curve.Suite{0}, nil, nil); !errors.Is(err, hash2curve.ErrInvalidSuite) {
+		t.Fatalf("expected %v, got %v", hash2curve.ErrInvalidSuite, err)
+	}
+}
@@ -0,0 +1,292 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+package hash2curve_test
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"slices"
+	"testing"
+
+	"github.com/bytemare/hash2curve"
+	h2cedwards25519 "github.com/bytemare/hash2curve/edwards25519"
+	"github.com/bytemare/hash2curve/group"
+	"github.com/bytemare/hash2curve/nist"
+	h2cristretto255 "github.com/bytemare/hash2curve/ristretto255"
+	"github.com/bytemare/hash2curve/secp256k1"
+)
+
+var groupDST = []byte("QUUX-V01-CS02-with-group-test")
+
+func testGroups() []*group.Group {
+	return []*group.Group{
+		group.P256(), group.P384(), group.P521(), group.Edwards25519(), group.Secp256k1(), group.Ristretto255(),
+	}
+}
+
+func TestGroup_Arithmetic(t *testing.T) {
+	for _, g := range testGroups() {
+		t.Run(g.Name(), func(t *testing.T) {
+			a := g.HashToScalar([]byte("a"), groupDST)
+			b := g.HashToScalar([]byte("b"), groupDST)
+
+			// (a+b)G = aG + bG
+			left := g.Base().Multiply(a.Copy().Add(b))
+			right := g.Base().Multiply(a).Add(g.Base().Multiply(b))
+
+			if !left.Equal(right) {
+				t.Fatal("expected (a+b)G = aG + bG")
+			}
+
+			// (a-b)G = aG - bG
+			left = g.Base().Multiply(a.Copy().Subtract(b))
+			right = g.Base().Multiply(a).Subtract(g.Base().Multiply(b))
+
+			if !left.Equal(right) {
+				t.Fatal("expected (a-b)G = aG - bG")
+			}
+
+			// (-a)G = -(aG)
+			if !g.Base().Multiply(a.Copy().Negate()).Equal(g.Base().Multiply(a).Negate()) {
+				t.Fatal("expected (-a)G = -(aG)")
+			}
+
+			// P - P = P + (-P) = O
+			p := g.HashToElement([]byte("p"), groupDST)
+			if !p.Copy().Subtract(p).IsIdentity() || !p.Copy().Add(p.Copy().Negate()).IsIdentity() {
+				t.Fatal("expected P - P to be the identity")
+			}
+
+			if p.IsIdentity() || !g.NewElement().IsIdentity() || !g.NewElement().Add(p).Equal(p) {
+				t.Fatal("unexpected identity behaviour")
+			}
+
+			// a * a^-1 = 1, and [order]G = O via (order-1)G + G
+			one := g.NewScalar()
+			if err := one.Decode(scalarEncoding(g, big.NewInt(1))); err != nil {
+				t.Fatal(err)
+			}
+
+			if !a.Copy().Multiply(a.Copy().Invert()).Equal(one) || !g.NewScalar().Invert().IsZero() {
+				t.Fatal("unexpected scalar inversion")
+			}
+
+			last := g.NewScalar()
+			if err := last.Decode(scalarEncoding(g, new(big.Int).Sub(g.Order(), big.NewInt(1)))); err != nil {
+				t.Fatal(err)
+			}
+
+			if !g.Base().Multiply(last).Add(g.Base()).IsIdentity() {
+				t.Fatal("expected [order]G to be the identity")
+			}
+
+			if !g.Base().Multiply(g.NewScalar()).IsIdentity() {
+				t.Fatal("expected [0]G to be the identity")
+			}
+		})
+	}
+}
+
+func TestGroup_Encoding(t *testing.T) {
+	for _, g := range testGroups() {
+		t.Run(g.Name(), func(t *testing.T) {
+			p := g.HashToElement([]byte("p"), groupDST)
+			enc := p.Encode()
+
+			if len(enc) != g.ElementLength() {
+				t.Fatalf("expected %d bytes, got %d", g.ElementLength(), len(enc))
+			}
+
+			q := g.NewElement()
+			if err := q.Decode(enc); err != nil {
+				t.Fatal(err)
+			}
+
+			if !q.Equal(p) || !bytes.Equal(q.Encode(), enc) {
+				t.Fatal("expected the decoded element to match")
+			}
+
+			if err := q.Decode(g.NewElement().Encode()); !errors.Is(err, hash2curve.ErrInvalidPoint) {
+				t.Fatalf("expected the identity to be rejected, got %v", err)
+			}
+
+			if err := q.Decode(enc[1:]); err == nil {
+				t.Fatal("expected a truncated encoding to be rejected")
+			}
+
+			if !q.Equal(p) {
+				t.Fatal("expected a failed decoding to leave the element unchanged")
+			}
+
+			s := g.HashToScalar([]byte("s"), groupDST)
+			sEnc := s.Encode()
+
+			if len(sEnc) != g.ScalarLength() {
+				t.Fatalf("expected %d bytes, got %d", g.ScalarLength(), len(sEnc))
+			}
+
+			u := g.NewScalar()
+			if err := u.Decode(sEnc); err != nil || !u.Equal(s) || u.BigInt().Cmp(s.BigInt()) != 0 {
+				t.Fatalf("expected the decoded scalar to match: %v", err)
+			}
+
+			if err := u.Decode(scalarEncoding(g, g.Order())); !errors.Is(err, group.ErrInvalidScalar) {
+				t.Fatalf("expected the order to be rejected, got %v", err)
+			}
+
+			if err := u.Decode(sEnc[1:]); !errors.Is(err, group.ErrInvalidScalar) {
+				t.Fatalf("expected a truncated scalar to be rejected, got %v", err)
+			}
+		})
+	}
+}
+
+func TestGroup_Hashing(t *testing.T) {
+	input := []byte("input")
+
+	tests := []struct {
+		group  *group.Group
+		hash   []byte
+		encode []byte
+		scalar *big.Int
+	}{
+		{
+			group.P256(),
+			nist.HashToP256(input, groupDST).BytesCompressed(),
+			nist.EncodeToP256(input, groupDST).BytesCompressed(),
+			nist.HashToScalarP256(input, groupDST),
+		},
+		{
+			group.P384(),
+			nist.HashToP384(input, groupDST).BytesCompressed(),
+			nist.EncodeToP384(input, groupDST).BytesCompressed(),
+			nist.HashToScalarP384(input, groupDST),
+		},
+		{
+			group.P521(),
+			nist.HashToP521(input, groupDST).BytesCompressed(),
+			nist.EncodeToP521(input, groupDST).BytesCompressed(),
+			nist.HashToScalarP521(input, groupDST),
+		},
+		{
+			group.Edwards25519(),
+			h2cedwards25519.HashToCurve(input, groupDST).Bytes(),
+			h2cedwards25519.EncodeToCurve(input, groupDST).Bytes(),
+			littleEndianScalar(h2cedwards25519.HashToScalar(input, groupDST).Bytes()),
+		},
+		{
+			group.Secp256k1(),
+			secp256k1.HashToCurve(input, groupDST).Bytes(),
+			secp256k1.EncodeToCurve(input, groupDST).Bytes(),
+			secp256k1.HashToScalar(input, groupDST),
+		},
+		{
+			group.Ristretto255(),
+			h2cristretto255.HashToGroup(input, groupDST).Encode(nil),
+			h2cristretto255.EncodeToGroup(input, groupDST).Encode(nil),
+			littleEndianScalar(h2cristretto255.HashToScalar(input, groupDST).Encode(nil)),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.group.Name(), func(t *testing.T) {
+			if !bytes.Equal(test.group.HashToElement(input, groupDST).Encode(), test.hash) {
+				t.Fatal("unexpected hash-to-element")
+			}
+
+			if !bytes.Equal(test.group.EncodeToElement(input, groupDST).Encode(), test.encode) {
+				t.Fatal("unexpected encode-to-element")
+			}
+
+			if test.group.HashToScalar(input, groupDST).BigInt().Cmp(test.scalar) != 0 {
+				t.Fatal("unexpected hash-to-scalar")
+			}
+		})
+	}
+}
+
+func TestGroup_Mismatch(t *testing.T) {
+	p256, p384 := group.P256(), group.P384()
+
+	for _, f := range []func(){
+		func() { p256.Base().Add(p384.Base()) },
+		func() { p256.Base().Add(group.Ristretto255().Base()) },
+		func() { p256.Base().Multiply(p384.NewScalar()) },
+		func() { p256.NewScalar().Add(p384.NewScalar()) },
+		func() { group.Edwards25519().Base().Equal(group.Ristretto255().Base()) },
+	} {
+		if hasPanic, err := expectPanic(group.ErrGroupMismatch, f); !hasPanic {
+			t.Fatalf("expected panic: %v", err)
+		}
+	}
+}
+
+func TestGroup_ForSuite(t *testing.T) {
+	for _, s := range hash2curve.AllSuites() {
+		if s.SuiteID().Curve == "curve25519" {
+			continue
+		}
+
+		g, err := group.ForSuite(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if g.Name() != s.SuiteID().Curve {
+			t.Fatalf("unexpected group %s for %s", g.Name(), s)
+		}
+	}
+
+	for _, s := range []hash2curve.Suite{hash2curve.Curve25519SHA512ELL2RO, hash2curve.Curve25519SHA512ELL2NU, 0} {
+		if _, err := group.ForSuite(s); !errors.Is(err, hash2curve.ErrInvalidSuite) {
+			t.Fatalf("expected an error for %d, got %v", s, err)
+		}
+	}
+}
+
+func TestSecp256k1_ScalarMult(t *testing.T) {
+	g := group.Secp256k1().Base().Encode()
+
+	base, err := new(secp256k1.Point).SetBytes(g)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !isSecp256k1Identity(new(secp256k1.Point).ScalarMult(secp256k1.Order(), base)) {
+		t.Fatal("expected [n]G to be the identity")
+	}
+
+	double := new(secp256k1.Point).Add(base, base)
+	triple := new(secp256k1.Point).Add(double, base)
+
+	if !bytes.Equal(new(secp256k1.Point).ScalarMult(big.NewInt(3), base).Bytes(), triple.Bytes()) {
+		t.Fatal("expected [3]G = G + G + G")
+	}
+
+	if !isSecp256k1Identity(new(secp256k1.Point).Add(triple, new(secp256k1.Point).Negate(triple))) {
+		t.Fatal("expected P + (-P) to be the identity")
+	}
+}
+
+func isSecp256k1Identity(p *secp256k1.Point) bool {
+	return p.X.Sign() == 0 && p.Y.Sign() == 0
+}
+
+// scalarEncoding returns the encoding of v in the byte order of the group, without reduction.
+func scalarEncoding(g *group.Group, v *big.Int) []byte {
+	b := v.FillBytes(make([]byte, g.ScalarLength()))
+	if g.Name() == "edwards25519" || g.Name() == "ristretto255" {
+		slices.Reverse(b)
+	}
+
+	return b
+}
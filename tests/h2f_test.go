@@ -0,0 +1,344 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+package hash2curve_test
+
+import (
+	"bytes"
+	"crypto"
+	"errors"
+	"math/big"
+	"slices"
+	"testing"
+
+	"github.com/bytemare/hash"
+	"golang.org/x/crypto/sha3"
+
+	"github.com/bytemare/hash2curve"
+	"github.com/bytemare/hash2curve/nist"
+	"github.com/bytemare/hash2curve/secp256k1"
+)
+
+func TestHashToField_InvalidParameters(t *testing.T) {
+	p256 := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(189))
+	dst := []byte("QUUX-V01-CS02-with-hash-to-field")
+
+	for _, test := range []struct {
+		modulo                     *big.Int
+		name                       string
+		count, ext, securityLength uint
+	}{
+		{name: "zero count", count: 0, ext: 1, securityLength: 48, modulo: p256},
+		{name: "zero ext", count: 1, ext: 0, securityLength: 48, modulo: p256},
+		{name: "nil modulo", count: 1, ext: 1, securityLength: 48, modulo: nil},
+		{name: "modulo 1", count: 1, ext: 1, securityLength: 48, modulo: big.NewInt(1)},
+		{name: "negative modulo", count: 1, ext: 1, securityLength: 48, modulo: big.NewInt(-7)},
+		{name: "zero security length", count: 1, ext: 1, securityLength: 0, modulo: p256},
+		{name: "short security length", count: 1, ext: 1, securityLength: 47, modulo: p256},
+		{name: "too long", count: 2000, ext: 1, securityLength: 48, modulo: p256},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			err := hash2curve.ValidateHashToField(test.count, test.ext, test.securityLength, test.modulo)
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+
+			if hasPanic, panicErr := expectPanic(err, func() {
+				_ = hash2curve.HashToFieldXMD(crypto.SHA256, nil, dst, test.count, test.ext, test.securityLength,
+					test.modulo)
+			}); !hasPanic {
+				t.Fatal(panicErr)
+			}
+
+			if hasPanic, panicErr := expectPanic(err, func() {
+				_ = hash2curve.HashToFieldXOF(hash.SHAKE256.GetXOF(), nil, dst, test.count, test.ext,
+					test.securityLength, test.modulo)
+			}); !hasPanic {
+				t.Fatal(panicErr)
+			}
+		})
+	}
+
+	if err := hash2curve.ValidateHashToField(2, 1, 48, p256); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestValidateSecurityLevel(t *testing.T) {
+	p521 := nist.FieldPrimeP521()
+
+	for _, test := range []struct {
+		err            error
+		name           string
+		h              crypto.Hash
+		securityLength uint
+		modulo         *big.Int
+		k              uint
+	}{
+		{nil, "P-521", crypto.SHA512, 98, p521, 256},
+		{nil, "P-521 at 192 bits", crypto.SHA384, 90, p521, 192},
+		{hash2curve.ErrInsufficientSecurity, "SHA-256 for 256 bits", crypto.SHA256, 98, p521, 256},
+		{hash2curve.ErrInsufficientSecurity, "short L", crypto.SHA512, 97, p521, 256},
+		{hash2curve.ErrInvalidParameters, "nil modulo", crypto.SHA512, 98, nil, 256},
+		{hash2curve.ErrUnsupportedHash, "unavailable hash", crypto.MD4, 98, p521, 128},
+	} {
+		if err := hash2curve.ValidateSecurityLevel(test.h, test.securityLength, test.modulo, test.k); !errors.Is(
+			err, test.err) {
+			t.Fatalf("%s: want %v, got %v", test.name, test.err, err)
+		}
+	}
+}
+
+func TestHashToField_Bytes(t *testing.T) {
+	p521, _ := new(big.Int).SetString("01ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"+
+		"ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", 16)
+	dst := []byte("QUUX-V01-CS02-with-hash-to-field-bytes")
+	input := []byte("abc")
+
+	for _, modulo := range []*big.Int{big.NewInt(251), p521} {
+		byteLen := (modulo.BitLen() + 7) / 8
+		secLength := uint((modulo.BitLen() + 128 + 7) / 8)
+
+		xmd := hash2curve.HashToFieldXMD(crypto.SHA512, input, dst, 3, 1, secLength, modulo)
+		xmdBytes := hash2curve.HashToFieldXMDBytes(crypto.SHA512, input, dst, 3, 1, secLength, modulo)
+		xof := hash2curve.HashToFieldXOF(hash.SHAKE256.GetXOF(), input, dst, 3, 1, secLength, modulo)
+		xofBytes := hash2curve.HashToFieldXOFBytes(hash.SHAKE256.GetXOF(), input, dst, 3, 1, secLength, modulo)
+
+		for i := range 3 {
+			for _, pair := range []struct {
+				e *big.Int
+				b []byte
+			}{{xmd[i], xmdBytes[i]}, {xof[i], xofBytes[i]}} {
+				if len(pair.b) != byteLen {
+					t.Fatalf("expected %d bytes, got %d", byteLen, len(pair.b))
+				}
+
+				if new(big.Int).SetBytes(pair.b).Cmp(pair.e) != 0 {
+					t.Fatal("encoding mismatch")
+				}
+			}
+		}
+	}
+}
+
+func TestHashToField_Extension(t *testing.T) {
+	p := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 255), big.NewInt(19))
+	dst := []byte("QUUX-V01-CS02-with-hash-to-field-extension")
+	input := []byte("abc")
+
+	// The coordinates of 2 elements of GF(p^3) are those of 6 elements of GF(p), from the same expansion.
+	ext := hash2curve.HashToFieldXMD(crypto.SHA256, input, dst, 2, 3, 48, p)
+	flat := hash2curve.HashToFieldXMD(crypto.SHA256, input, dst, 6, 1, 48, p)
+
+	if len(ext) != 6 {
+		t.Fatalf("expected 6 coordinates, got %d", len(ext))
+	}
+
+	for i := range ext {
+		if ext[i].Cmp(flat[i]) != 0 {
+			t.Fatalf("unexpected coordinate %d", i)
+		}
+	}
+
+	if xof := hash2curve.HashToFieldXOF(hash.SHAKE128.GetXOF(), input, dst, 2, 2, 48, p); len(xof) != 4 {
+		t.Fatalf("expected 4 coordinates, got %d", len(xof))
+	}
+}
+
+func TestHashToField_ExpandForCurve(t *testing.T) {
+	p256 := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(189))
+	p521 := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 521), big.NewInt(1))
+	dst := []byte("QUUX-V01-CS02-with-expand-for-curve")
+	input := []byte("abc")
+
+	for _, test := range []struct {
+		modulo *big.Int
+		k      uint
+		length uint
+	}{
+		{p256, 128, 48},
+		{p521, 256, 98},
+		{p521, 128, 82},
+	} {
+		if l := hash2curve.SecurityLength(test.modulo, test.k); l != test.length {
+			t.Fatalf("want %d, got %d", test.length, l)
+		}
+
+		uniform := hash2curve.ExpandForCurve(hash.SHA512, input, dst, test.modulo, test.k, 2, 1)
+		expected := hash2curve.ExpandXMD(crypto.SHA512, input, dst, 2*test.length)
+
+		if len(uniform) != 2 || !bytes.Equal(append(uniform[0], uniform[1]...), expected) {
+			t.Fatalf("unexpected uniform strings for L = %d", test.length)
+		}
+
+		elements := hash2curve.HashToFieldXMD(crypto.SHA512, input, dst, 2, 1, test.length, test.modulo)
+		for i, u := range uniform {
+			if new(big.Int).Mod(new(big.Int).SetBytes(u), test.modulo).Cmp(elements[i]) != 0 {
+				t.Fatal("uniform strings don't reduce to the hash_to_field elements")
+			}
+		}
+	}
+
+	shake256 := hash2curve.ExtendableXOF(hash.SHAKE256.GetXOF())
+	if xof := hash2curve.ExpandForCurve(hash.SHAKE256, input, dst, p256, 128, 1, 2); len(xof) != 2 ||
+		!bytes.Equal(append(xof[0], xof[1]...), hash2curve.ExpandXOF(shake256, input, dst, 96)) {
+		t.Fatal("unexpected XOF uniform strings")
+	}
+
+	for _, f := range []func(){
+		func() { hash2curve.ExpandForCurve(hash.SHA256, input, dst, p256, 64, 2, 1) },
+		func() { hash2curve.ExpandForCurve(hash.SHA256, input, dst, nil, 128, 2, 1) },
+		func() { hash2curve.ExpandForCurve(hash.SHA256, input, dst, p256, 128, 0, 1) },
+	} {
+		if hasPanic, err := expectPanic(nil, f); !hasPanic {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestHashToField_Montgomery(t *testing.T) {
+	// The BLS12-381 base field, with 6 limbs.
+	p, _ := new(big.Int).SetString("1a0111ea397fe69a4b1ba7b6434bacd764774b84f38512bf6730d2a0f6b0f6241eabfffeb153ffffb9"+
+		"feffffffffaaab", 16)
+	dst := []byte("QUUX-V01-CS02-with-hash-to-field-montgomery")
+
+	// One in Montgomery form is R mod p.
+	r := new(big.Int).Lsh(big.NewInt(1), 384)
+	r.Mod(r, p)
+
+	if got := limbsToInt(hash2curve.ToMontgomery(big.NewInt(1), p)); got.Cmp(r) != 0 {
+		t.Fatalf("unexpected Montgomery form of one: %x", got)
+	}
+
+	elements := hash2curve.HashToFieldXMD(crypto.SHA256, []byte("abc"), dst, 2, 1, 64, p)
+	montgomery := hash2curve.HashToFieldXMDMontgomery(crypto.SHA256, []byte("abc"), dst, 2, 1, 64, p)
+	montgomeryXOF := hash2curve.HashToFieldXOFMontgomery(hash.SHAKE128.GetXOF(), []byte("abc"), dst, 2, 1, 64, p)
+	rInv := new(big.Int).ModInverse(r, p)
+
+	for i, e := range elements {
+		if len(montgomery[i]) != 6 || len(montgomeryXOF[i]) != 6 {
+			t.Fatal("expected 6 limbs")
+		}
+
+		// Converting back with R^-1 must yield the element.
+		back := limbsToInt(montgomery[i])
+		back.Mul(back, rInv).Mod(back, p)
+
+		if back.Cmp(e) != 0 {
+			t.Fatal("unexpected Montgomery form")
+		}
+	}
+}
+
+func limbsToInt(limbs []uint64) *big.Int {
+	res := new(big.Int)
+	for i := len(limbs) - 1; i >= 0; i-- {
+		res.Lsh(res, 64).Or(res, new(big.Int).SetUint64(limbs[i]))
+	}
+
+	return res
+}
+
+func TestHashToField_XOFState(t *testing.T) {
+	dst := []byte("QUUX-V01-CS02-with-hash-to-field-xof-state")
+	input := []byte("abc")
+	p := nist.FieldPrimeP384()
+
+	want := hash2curve.HashToFieldXOF(hash.SHAKE256.GetXOF(), input, dst, 2, 2, 72, p)
+	got := hash2curve.HashToFieldXOFState(sha3.NewShake256(), input, dst, 2, 2, 72, p)
+
+	if len(got) != len(want) {
+		t.Fatalf("want %d elements, got %d", len(want), len(got))
+	}
+
+	for i := range want {
+		if got[i].Cmp(want[i]) != 0 {
+			t.Fatalf("element %d: want %x, got %x", i, want[i], got[i])
+		}
+	}
+
+	wantBytes := hash2curve.HashToFieldXOFBytes(hash.SHAKE256.GetXOF(), input, dst, 2, 2, 72, p)
+	gotBytes := hash2curve.HashToFieldXOFStateBytes(sha3.NewShake256(), input, dst, 2, 2, 72, p)
+	wantMontgomery := hash2curve.HashToFieldXOFMontgomery(hash.SHAKE256.GetXOF(), input, dst, 2, 2, 72, p)
+	gotMontgomery := hash2curve.HashToFieldXOFStateMontgomery(sha3.NewShake256(), input, dst, 2, 2, 72, p)
+
+	for i := range wantBytes {
+		if !bytes.Equal(gotBytes[i], wantBytes[i]) || !slices.Equal(gotMontgomery[i], wantMontgomery[i]) {
+			t.Fatalf("element %d: unexpected encoding", i)
+		}
+	}
+}
+
+func TestDeriveNonce(t *testing.T) {
+	order := secp256k1.Order()
+	dst := []byte("QUUX-V01-CS02-with-nonce-derivation")
+	key := []byte("secret key")
+	message := []byte("message")
+
+	nonce := hash2curve.DeriveNonce(crypto.SHA256, key, message, dst, order)
+	if len(nonce) != 32 {
+		t.Fatalf("want a 32-byte nonce, got %d bytes", len(nonce))
+	}
+
+	k := new(big.Int).SetBytes(nonce)
+	if k.Sign() <= 0 || k.Cmp(order) >= 0 {
+		t.Fatalf("nonce out of range: %s", k)
+	}
+
+	input := append(append([]byte{0, byte(len(key))}, key...), message...)
+	want := new(big.Int).SetBytes(hash2curve.ExpandXMD(crypto.SHA256, input, dst, 48))
+	want.Mod(want, new(big.Int).Sub(order, big.NewInt(1)))
+	want.Add(want, big.NewInt(1))
+
+	if k.Cmp(want) != 0 {
+		t.Fatalf("want %s, got %s", want, k)
+	}
+
+	if !bytes.Equal(nonce, hash2curve.DeriveNonce(crypto.SHA256, key, message, dst, order)) {
+		t.Fatal("nonce derivation is not deterministic")
+	}
+
+	// Moving bytes between the key and the message must change the nonce.
+	if bytes.Equal(nonce, hash2curve.DeriveNonce(crypto.SHA256, key[:6], append(key[6:], message...), dst, order)) {
+		t.Fatal("key and message are not separated")
+	}
+
+	// The smallest valid order only has 1 and 2 as nonces, encoded on a single byte.
+	if n := hash2curve.DeriveNonce(crypto.SHA256, key, message, dst, big.NewInt(3)); len(n) != 1 ||
+		n[0] < 1 || n[0] > 2 {
+		t.Fatalf("nonce out of range: %x", n)
+	}
+
+	// The nonces of the order 257 are in [1, 256], whose encoding holds the carry of the addition in its first byte.
+	for i := range 64 {
+		m := []byte{byte(i)}
+		if n := hash2curve.DeriveNonce(crypto.SHA256, key, m, dst, big.NewInt(257)); len(n) != 2 ||
+			n[0] > 1 || n[0] == 1 && n[1] != 0 || n[0] == 0 && n[1] == 0 {
+			t.Fatalf("nonce out of range: %x", n)
+		}
+	}
+
+	for _, test := range []struct {
+		order *big.Int
+		name  string
+		key   []byte
+	}{
+		{name: "empty key", key: nil, order: order},
+		{name: "long key", key: make([]byte, 1<<16), order: order},
+		{name: "nil order", key: key, order: nil},
+		{name: "small order", key: key, order: big.NewInt(2)},
+	} {
+		if hasPanic, err := expectPanic(nil, func() {
+			_ = hash2curve.DeriveNonce(crypto.SHA256, test.key, message, dst, test.order)
+		}); !hasPanic {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+	}
+}
@@ -0,0 +1,223 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+package hash2curve_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/bytemare/hash2curve"
+	"github.com/bytemare/hash2curve/nist"
+	"github.com/bytemare/hash2curve/secp256k1"
+)
+
+func TestNIST_Hex(t *testing.T) {
+	input := []byte("abc")
+	dst := []byte("QUUX-V01-CS02-with-hex-helpers")
+
+	for _, test := range []struct {
+		hash, encode func(input, dst []byte) string
+		decode       func(s string) ([]byte, error)
+		expected     []byte
+	}{
+		{
+			hash:   nist.HashToP256Hex,
+			encode: nist.EncodeToP256Hex,
+			decode: func(s string) ([]byte, error) {
+				p, err := nist.DecodeP256Hex(s)
+				if err != nil {
+					return nil, err
+				}
+
+				return p.BytesCompressed(), nil
+			},
+			expected: nist.HashToP256(input, dst).BytesCompressed(),
+		},
+		{
+			hash:   nist.HashToP384Hex,
+			encode: nist.EncodeToP384Hex,
+			decode: func(s string) ([]byte, error) {
+				p, err := nist.DecodeP384Hex(s)
+				if err != nil {
+					return nil, err
+				}
+
+				return p.BytesCompressed(), nil
+			},
+			expected: nist.HashToP384(input, dst).BytesCompressed(),
+		},
+		{
+			hash:   nist.HashToP521Hex,
+			encode: nist.EncodeToP521Hex,
+			decode: func(s string) ([]byte, error) {
+				p, err := nist.DecodeP521Hex(s)
+				if err != nil {
+					return nil, err
+				}
+
+				return p.BytesCompressed(), nil
+			},
+			expected: nist.HashToP521(input, dst).BytesCompressed(),
+		},
+	} {
+		s := test.hash(input, dst)
+		if s != hex.EncodeToString(test.expected) {
+			t.Fatal("unexpected hex encoding")
+		}
+
+		decoded, err := test.decode(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(decoded, test.expected) {
+			t.Fatal("unexpected decoded point")
+		}
+
+		if _, err = test.decode(test.encode(input, dst)); err != nil {
+			t.Fatal(err)
+		}
+
+		for _, invalid := range []string{"zz", "", "04", "02" + s[2:len(s)-2]} {
+			if _, err = test.decode(invalid); !errors.Is(err, hash2curve.ErrInvalidPoint) {
+				t.Fatalf("expected error on %q, got %v", invalid, err)
+			}
+		}
+	}
+}
+
+func TestSecp256k1_Hex(t *testing.T) {
+	dst := []byte("QUUX-V01-CS02-with-secp256k1_XMD:SHA-256_SSWU_RO_")
+
+	for _, input := range []string{"", "abc", "abcdef0123456789"} {
+		p := secp256k1.HashToCurve([]byte(input), dst)
+
+		s := secp256k1.HashToCurveHex([]byte(input), dst)
+		if s != hex.EncodeToString(p.Bytes()) {
+			t.Fatal("unexpected hex encoding")
+		}
+
+		decoded, err := secp256k1.DecodeHex(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(decoded.Bytes(), p.Bytes()) || decoded.X.Cmp(&p.X) != 0 || decoded.Y.Cmp(&p.Y) != 0 {
+			t.Fatal("unexpected decoded point")
+		}
+
+		e := secp256k1.EncodeToCurve([]byte(input), dst)
+		if decoded, err = secp256k1.DecodeHex(secp256k1.EncodeToCurveHex([]byte(input), dst)); err != nil ||
+			decoded.Y.Cmp(&e.Y) != 0 {
+			t.Fatal("unexpected decoded point")
+		}
+	}
+
+	identity, err := secp256k1.DecodeHex(hex.EncodeToString(make([]byte, 33)))
+	if err != nil || identity.X.Sign() != 0 || identity.Y.Sign() != 0 {
+		t.Fatal("expected the point at infinity")
+	}
+
+	notOnCurve := make([]byte, 33)
+	notOnCurve[0], notOnCurve[32] = 2, 5 // 5^3 + 7 = 132 is not a square mod p.
+
+	nonCanonical := bytes.Repeat([]byte{0xff}, 33)
+	nonCanonical[0] = 2
+
+	badPrefix := make([]byte, 33)
+	badPrefix[0] = 4
+
+	zeroPrefixed := make([]byte, 33)
+	zeroPrefixed[32] = 1
+
+	for _, invalid := range []string{
+		"zz",
+		"02",
+		hex.EncodeToString(notOnCurve),
+		hex.EncodeToString(nonCanonical),
+		hex.EncodeToString(badPrefix),
+		hex.EncodeToString(zeroPrefixed),
+	} {
+		if _, err = secp256k1.DecodeHex(invalid); !errors.Is(err, hash2curve.ErrInvalidPoint) {
+			t.Fatalf("expected error on %q, got %v", invalid, err)
+		}
+	}
+}
+
+func TestSecp256k1_XOnly(t *testing.T) {
+	dst := []byte("QUUX-V01-CS02-with-secp256k1_XMD:SHA-256_SSWU_RO_")
+
+	// lift_x of the generator's x-coordinate is the generator, which has an even y-coordinate.
+	gx, _ := hex.DecodeString("79be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798")
+	gy, _ := new(big.Int).SetString("483ada7726a3c4655da4fbfc0e1108a8fd17b448a68554199c47d08ffb10d4b8", 16)
+
+	g, err := new(secp256k1.Point).SetXOnlyBytes(gx)
+	if err != nil || g.Y.Cmp(gy) != 0 || !bytes.Equal(g.BytesXOnly(), gx) {
+		t.Fatalf("unexpected lift_x of the generator: %v", err)
+	}
+
+	for _, input := range []string{"", "abc", "q128_qqqqqqqqqqqqqqqqqqqqqqqqq"} {
+		p := secp256k1.HashToCurve([]byte(input), dst)
+
+		xOnly := secp256k1.HashToCurveXOnly([]byte(input), dst)
+		if len(xOnly) != secp256k1.XOnlyLength || !bytes.Equal(xOnly, p.X.FillBytes(make([]byte, 32))) {
+			t.Fatal("unexpected x-only encoding")
+		}
+
+		lifted, err := new(secp256k1.Point).SetXOnlyBytes(xOnly)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		normalized := new(secp256k1.Point).NormalizeEvenY(p)
+		if lifted.Y.Bit(0) != 0 || lifted.Y.Cmp(&normalized.Y) != 0 || lifted.X.Cmp(&p.X) != 0 {
+			t.Fatal("lift_x must return the point with the even y-coordinate")
+		}
+
+		if p.Y.Bit(0) == 0 && normalized.Y.Cmp(&p.Y) != 0 {
+			t.Fatal("a point with an even y-coordinate must be unchanged")
+		}
+
+		e := secp256k1.EncodeToCurve([]byte(input), dst)
+		if !bytes.Equal(secp256k1.EncodeToCurveXOnly([]byte(input), dst), e.BytesXOnly()) {
+			t.Fatal("unexpected x-only encoding")
+		}
+	}
+
+	for _, invalid := range [][]byte{nil, make([]byte, 31), make([]byte, 32), bytes.Repeat([]byte{0xff}, 32)} {
+		if _, err = new(secp256k1.Point).SetXOnlyBytes(invalid); !errors.Is(err, hash2curve.ErrInvalidPoint) {
+			t.Fatalf("expected error on %x, got %v", invalid, err)
+		}
+	}
+}
+
+func TestSecp256k1_Uncompressed(t *testing.T) {
+	dst := []byte("QUUX-V01-CS02-with-secp256k1_XMD:SHA-256_SSWU_RO_")
+	p := secp256k1.HashToCurve([]byte("abc"), dst)
+
+	xy := p.BytesXY()
+	if len(xy) != 64 || !bytes.Equal(xy[:32], p.X.FillBytes(make([]byte, 32))) ||
+		!bytes.Equal(xy[32:], p.Y.FillBytes(make([]byte, 32))) {
+		t.Fatal("unexpected x || y encoding")
+	}
+
+	if u := p.BytesUncompressed(); len(u) != 65 || u[0] != 4 || !bytes.Equal(u[1:], xy) {
+		t.Fatal("unexpected uncompressed encoding")
+	}
+
+	identity := new(secp256k1.Point)
+	if !bytes.Equal(identity.BytesUncompressed(), []byte{0}) || !bytes.Equal(identity.BytesXY(), make([]byte, 64)) {
+		t.Fatal("unexpected encodings of the point at infinity")
+	}
+}
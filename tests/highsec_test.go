@@ -0,0 +1,253 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+package hash2curve_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/bytemare/hash2curve"
+	"github.com/bytemare/hash2curve/curve41417"
+	"github.com/bytemare/hash2curve/e521"
+	"github.com/bytemare/hash2curve/m511"
+)
+
+type highSecCurve struct {
+	hashToCurve   func(input, dst []byte) []byte
+	encodeToCurve func(input, dst []byte) []byte
+	hashToScalar  func(input, dst []byte) *big.Int
+	roundTrip     func(b []byte) ([]byte, error)
+	add           func(p1, p2 []byte) []byte
+	mapToCurve    func(fe *big.Int)
+	prime         *big.Int
+	order         *big.Int
+	name          string
+	h2c, e2c      string
+	wantRO        string
+	wantNU        string
+	identity      []byte
+	length        int
+}
+
+func powerOfTwo(n uint, offset string) *big.Int {
+	o, _ := new(big.Int).SetString(offset, 10)
+	return o.Add(new(big.Int).Lsh(big.NewInt(1), n), o)
+}
+
+func highSecCurves() []highSecCurve {
+	e521Identity := make([]byte, e521.EncodingLength)
+	e521Identity[0] = 1
+	c41417Identity := make([]byte, curve41417.EncodingLength)
+	c41417Identity[0] = 1
+	m511Identity := make([]byte, m511.EncodingLength)
+	m511Identity[m511.EncodingLength-1] = 0x80
+
+	return []highSecCurve{
+		{
+			name:   "E-521",
+			h2c:    e521.H2C,
+			e2c:    e521.E2C,
+			length: e521.EncodingLength,
+			prime:  powerOfTwo(521, "-1"),
+			order: powerOfTwo(519,
+				"-337554763258501705789107630418782636071904961214051226618635150085779108655765"),
+			identity:      e521Identity,
+			hashToCurve:   func(input, dst []byte) []byte { return e521.HashToCurve(input, dst).Bytes() },
+			encodeToCurve: func(input, dst []byte) []byte { return e521.EncodeToCurve(input, dst).Bytes() },
+			hashToScalar:  e521.HashToScalar,
+			roundTrip: func(b []byte) ([]byte, error) {
+				p, err := new(e521.Point).SetBytes(b)
+				if err != nil {
+					return nil, err
+				}
+
+				return p.Bytes(), nil
+			},
+			add: func(b1, b2 []byte) []byte {
+				p1, _ := new(e521.Point).SetBytes(b1)
+				p2, _ := new(e521.Point).SetBytes(b2)
+
+				return new(e521.Point).Add(p1, p2).Bytes()
+			},
+			mapToCurve: func(fe *big.Int) { e521.MapToCurve(fe) },
+			wantRO: "bc43c47f202729673b8ed4363f6dd48c662c26726b40ba40639b81234c65b9a8f14fd9acdbfd66319397dd6fa3bcde04" +
+				"553cae06ba05c2e95fb45a4ea8e237cdc100",
+			wantNU: "8045a7c6e47997d1f4425b8bf872800977a375e1dd77c307ff03df31ce6dc6386c42c98db3124e1467b15f505ba2a004" +
+				"764382392b1bc7422f1d6c6e0d7ce5fee881",
+		},
+		{
+			name:          "Curve41417",
+			h2c:           curve41417.H2C,
+			e2c:           curve41417.E2C,
+			length:        curve41417.EncodingLength,
+			prime:         powerOfTwo(414, "-17"),
+			order:         powerOfTwo(411, "-33364140863755142520810177694098385178984727200411208589594759"),
+			identity:      c41417Identity,
+			hashToCurve:   func(input, dst []byte) []byte { return curve41417.HashToCurve(input, dst).Bytes() },
+			encodeToCurve: func(input, dst []byte) []byte { return curve41417.EncodeToCurve(input, dst).Bytes() },
+			hashToScalar:  curve41417.HashToScalar,
+			roundTrip: func(b []byte) ([]byte, error) {
+				p, err := new(curve41417.Point).SetBytes(b)
+				if err != nil {
+					return nil, err
+				}
+
+				return p.Bytes(), nil
+			},
+			add: func(b1, b2 []byte) []byte {
+				p1, _ := new(curve41417.Point).SetBytes(b1)
+				p2, _ := new(curve41417.Point).SetBytes(b2)
+
+				return new(curve41417.Point).Add(p1, p2).Bytes()
+			},
+			mapToCurve: func(fe *big.Int) { curve41417.MapToCurve(fe) },
+			wantRO: "6a3949e5cdb8f9cf5f30a7a87fc374ab026a74187de1b9c5cc2f4f4e04eacd035db1790ae1ca274a40686a8e8e7134" +
+				"25e0109704",
+			wantNU: "8d4eed47bfcffb7ca334d2025d21f62974f9578fcc655a7e6d9a1170d6be68535f7eb0dcc7aaefe8ec9fb6fe25a57570" +
+				"9e4b9fbd",
+		},
+		{
+			name:   "M-511",
+			h2c:    m511.H2C,
+			e2c:    m511.E2C,
+			length: m511.EncodingLength,
+			prime:  powerOfTwo(511, "-187"),
+			order: powerOfTwo(508,
+				"10724754759635747624044531514068121842070756627434833028965540808827675062043"),
+			identity:      m511Identity,
+			hashToCurve:   func(input, dst []byte) []byte { return m511.HashToCurve(input, dst).Bytes() },
+			encodeToCurve: func(input, dst []byte) []byte { return m511.EncodeToCurve(input, dst).Bytes() },
+			hashToScalar:  m511.HashToScalar,
+			roundTrip: func(b []byte) ([]byte, error) {
+				p, err := new(m511.Point).SetBytes(b)
+				if err != nil {
+					return nil, err
+				}
+
+				return p.Bytes(), nil
+			},
+			add: func(b1, b2 []byte) []byte {
+				p1, _ := new(m511.Point).SetBytes(b1)
+				p2, _ := new(m511.Point).SetBytes(b2)
+
+				return new(m511.Point).Add(p1, p2).Bytes()
+			},
+			mapToCurve: func(fe *big.Int) { m511.MapToCurve(fe) },
+			wantRO: "97671421de58e406dc0235166f172f658706cdae931703b372d6f3a89ea3d6c9b19e864ca7e4b550da99e99f424bfc9a" +
+				"bc6359a1b5f7cad76eaee4b0b1bf43a9",
+			wantNU: "bb57930cc0e7a795a11fe7f209cd0338d2684aba84370f45a25ddd762c8887642dd2c8def8d9d40a6e987b4bdb7ba6dd" +
+				"3bbb623692e38d592cc67675f9d08a29",
+		},
+	}
+}
+
+// scalarMultBytes returns the encoding of n times the encoded point, with double-and-add on the encodings.
+func scalarMultBytes(c *highSecCurve, p []byte, n *big.Int) []byte {
+	r := c.identity
+	for i := n.BitLen() - 1; i >= 0; i-- {
+		r = c.add(r, r)
+		if n.Bit(i) == 1 {
+			r = c.add(r, p)
+		}
+	}
+
+	return r
+}
+
+func littleEndian(v *big.Int, length int) []byte {
+	b := v.FillBytes(make([]byte, length))
+	for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+		b[i], b[j] = b[j], b[i]
+	}
+
+	return b
+}
+
+func TestHighSecurityCurves(t *testing.T) {
+	input := []byte("abc")
+
+	for _, c := range highSecCurves() {
+		t.Run(c.name, func(t *testing.T) {
+			dstRO := []byte("QUUX-V01-CS02-with-" + c.h2c)
+			dstNU := []byte("QUUX-V01-CS02-with-" + c.e2c)
+
+			// Reference values computed with an independent implementation.
+			ro := c.hashToCurve(input, dstRO)
+			if hex.EncodeToString(ro) != c.wantRO {
+				t.Fatalf("unexpected hash-to-curve output %x", ro)
+			}
+
+			nu := c.encodeToCurve(input, dstNU)
+			if hex.EncodeToString(nu) != c.wantNU {
+				t.Fatalf("unexpected encode-to-curve output %x", nu)
+			}
+
+			if len(ro) != c.length {
+				t.Fatalf("unexpected encoding length %d", len(ro))
+			}
+
+			if bytes.Equal(ro, c.hashToCurve(input, dstNU)) {
+				t.Fatal("expected different points for different DSTs")
+			}
+
+			// The outputs are in the prime-order subgroup.
+			for _, p := range [][]byte{ro, nu} {
+				if !bytes.Equal(scalarMultBytes(&c, p, c.order), c.identity) {
+					t.Fatal("expected a point of prime order")
+				}
+			}
+
+			for _, b := range [][]byte{ro, nu, c.identity, make([]byte, c.length)} {
+				got, err := c.roundTrip(b)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if !bytes.Equal(got, b) {
+					t.Fatalf("unexpected round trip of %x: %x", b, got)
+				}
+			}
+
+			if s := c.hashToScalar(input, dstRO); s.Sign() < 0 || s.Cmp(c.order) >= 0 {
+				t.Fatal("expected a reduced scalar")
+			}
+
+			expectPanic(hash2curve.ErrNonCanonical, func() { c.mapToCurve(c.prime) })
+		})
+	}
+}
+
+func TestHighSecurityCurves_InvalidEncoding(t *testing.T) {
+	for _, c := range highSecCurves() {
+		t.Run(c.name, func(t *testing.T) {
+			invalid := [][]byte{
+				nil, make([]byte, c.length-1), make([]byte, c.length+1), littleEndian(c.prime, c.length),
+			}
+
+			// Some small coordinates are not that of a point on the curve.
+			for i := int64(2); len(invalid) < 5; i++ {
+				b := littleEndian(big.NewInt(i), c.length)
+				if _, err := c.roundTrip(b); err != nil {
+					invalid = append(invalid, b)
+				}
+			}
+
+			for _, b := range invalid {
+				if _, err := c.roundTrip(b); !errors.Is(err, hash2curve.ErrInvalidPoint) {
+					t.Fatalf("expected an invalid point error for %x, got %v", b, err)
+				}
+			}
+		})
+	}
+}
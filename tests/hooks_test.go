@@ -0,0 +1,172 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+package hash2curve_test
+
+import (
+	"crypto"
+	"sync"
+	"testing"
+
+	"github.com/bytemare/hash"
+
+	"github.com/bytemare/hash2curve"
+	"github.com/bytemare/hash2curve/suite"
+)
+
+// hookRecorder records the events of the hooks.
+type hookRecorder struct {
+	expand []hash2curve.ExpandEvent
+	hash   []hash2curve.HashEvent
+	mu     sync.Mutex
+}
+
+func (r *hookRecorder) hooks() *hash2curve.Hooks {
+	return &hash2curve.Hooks{
+		Expand: func(e hash2curve.ExpandEvent) {
+			r.mu.Lock()
+			defer r.mu.Unlock()
+
+			r.expand = append(r.expand, e)
+		},
+		Hash: func(e hash2curve.HashEvent) {
+			r.mu.Lock()
+			defer r.mu.Unlock()
+
+			r.hash = append(r.hash, e)
+		},
+	}
+}
+
+// reset returns the recorded events and clears them.
+func (r *hookRecorder) reset() ([]hash2curve.ExpandEvent, []hash2curve.HashEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	expand, h := r.expand, r.hash
+	r.expand, r.hash = nil, nil
+
+	return expand, h
+}
+
+func TestHooks(t *testing.T) {
+	msg := []byte("abc")
+	dst := []byte("QUUX-V01-CS02-with-hooks")
+	r := new(hookRecorder)
+
+	hash2curve.SetHooks(r.hooks())
+	defer hash2curve.SetHooks(nil)
+
+	for _, test := range []struct {
+		run    func()
+		name   string
+		want   string
+		count  uint
+		length uint
+	}{
+		{
+			name:   "XMD",
+			run:    func() { _ = hash2curve.ExpandXMD(crypto.SHA256, msg, dst, 48) },
+			want:   "XMD:SHA-256",
+			count:  1,
+			length: 48,
+		},
+		{
+			name:   "XOF",
+			run:    func() { _ = hash2curve.ExpandXOF(hash2curve.ExtendableXOF(hash.SHAKE128.GetXOF()), msg, dst, 64) },
+			want:   "XOF:SHAKE128",
+			count:  1,
+			length: 64,
+		},
+		{
+			name:   "batch",
+			run:    func() { _ = hash2curve.ExpandXMDBatch(crypto.SHA512, [][]byte{msg, msg, msg}, dst, 32) },
+			want:   "XMD:SHA-512",
+			count:  3,
+			length: 32,
+		},
+		{
+			name:   "Keccak-256",
+			run:    func() { _ = hash2curve.ExpandXMDKeccak256(msg, dst, 32) },
+			want:   "XMD:KECCAK-256",
+			count:  1,
+			length: 32,
+		},
+		{
+			name:   "HKDF",
+			run:    func() { _ = hash2curve.ExpandHKDF(crypto.SHA256, msg, dst, nil, 32) },
+			want:   "HKDF:SHA-256",
+			count:  1,
+			length: 32,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			test.run()
+
+			expand, h := r.reset()
+			if len(expand) != 1 || len(h) != 0 {
+				t.Fatalf("expected a single expansion event, got %v and %v", expand, h)
+			}
+
+			if e := expand[0]; e.Expander != test.want || e.Count != test.count || e.Length != test.length ||
+				e.Duration < 0 {
+				t.Fatalf("unexpected event %+v", e)
+			}
+		})
+	}
+
+	s, err := suite.New("P256_XMD:SHA-256_SSWU_RO_")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hasher, err := s.Hasher(dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range []struct {
+		run       func()
+		name      string
+		operation string
+	}{
+		{name: "Hash", run: func() { _ = s.Hash(msg, dst) }, operation: hash2curve.OperationHashToCurve},
+		{
+			name:      "HashToScalar",
+			run:       func() { _ = s.HashToScalar(msg, dst) },
+			operation: hash2curve.OperationHashToScalar,
+		},
+		{name: "Hasher", run: func() { _ = hasher.EncodeToCurve(msg) }, operation: hash2curve.OperationEncodeToCurve},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			test.run()
+
+			expand, h := r.reset()
+			if len(h) != 1 || h[0].Suite != s.ID() || h[0].Operation != test.operation || h[0].Duration < 0 {
+				t.Fatalf("unexpected events %+v", h)
+			}
+
+			if len(expand) != 1 || expand[0].Expander != "XMD:SHA-256" {
+				t.Fatalf("unexpected expansion events %+v", expand)
+			}
+		})
+	}
+
+	// Removing the hooks stops the events, as does setting nil callbacks.
+	for _, hooks := range []*hash2curve.Hooks{nil, {}} {
+		hash2curve.SetHooks(hooks)
+
+		_ = s.Hash(msg, dst)
+
+		if expand, h := r.reset(); len(expand) != 0 || len(h) != 0 {
+			t.Fatalf("unexpected events after removing the hooks: %v, %v", expand, h)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+package hash2curve_test
+
+import (
+	"crypto/tls"
+	"errors"
+	"testing"
+
+	"github.com/bytemare/hash2curve"
+	"github.com/bytemare/hash2curve/cose"
+	"github.com/bytemare/hash2curve/iana"
+)
+
+func TestIANA_NamedGroup(t *testing.T) {
+	groups := map[string]iana.NamedGroup{
+		"P256":       iana.NamedGroup(tls.CurveP256),
+		"P384":       iana.NamedGroup(tls.CurveP384),
+		"P521":       iana.NamedGroup(tls.CurveP521),
+		"curve25519": iana.NamedGroup(tls.X25519),
+		"secp256k1":  iana.NamedGroupSecp256k1,
+	}
+
+	for _, id := range hash2curve.AllSuites() {
+		t.Run(id.String(), func(t *testing.T) {
+			expected, ok := groups[id.SuiteID().Curve]
+
+			g, err := iana.NamedGroupOf(id)
+			if !ok {
+				if !errors.Is(err, iana.ErrUnsupportedCurve) {
+					t.Fatalf("expected %v, got %v", iana.ErrUnsupportedCurve, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if g != expected {
+				t.Fatalf("expected %s, got %s", expected, g)
+			}
+
+			s, err := iana.SuiteForNamedGroup(g, id.RandomOracle())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if s != id {
+				t.Fatalf("expected %s, got %s", id, s)
+			}
+		})
+	}
+
+	// X448 has no suite in this module.
+	if _, err := iana.SuiteForNamedGroup(iana.NamedGroup(30), true); !errors.Is(err, iana.ErrUnsupportedCurve) {
+		t.Fatalf("expected %v, got %v", iana.ErrUnsupportedCurve, err)
+	}
+
+	if _, err := iana.NamedGroupOf(0); !errors.Is(err, hash2curve.ErrInvalidSuite) {
+		t.Fatalf("expected %v, got %v", hash2curve.ErrInvalidSuite, err)
+	}
+
+	if s := iana.NamedGroupSecp256r1.String(); s != "secp256r1" {
+		t.Fatalf("unexpected name %q", s)
+	}
+
+	if s := iana.NamedGroup(30).String(); s != "NamedGroup(30)" {
+		t.Fatalf("unexpected name %q", s)
+	}
+}
+
+func TestIANA_COSECurve(t *testing.T) {
+	curves := map[string]int{
+		"P256":         cose.CurveP256,
+		"P384":         cose.CurveP384,
+		"P521":         cose.CurveP521,
+		"secp256k1":    cose.CurveSecp256k1,
+		"edwards25519": cose.CurveEd25519,
+		"curve25519":   cose.CurveX25519,
+	}
+
+	for _, id := range hash2curve.AllSuites() {
+		t.Run(id.String(), func(t *testing.T) {
+			expected, ok := curves[id.SuiteID().Curve]
+
+			crv, err := iana.COSECurve(id)
+			if !ok {
+				if !errors.Is(err, iana.ErrUnsupportedCurve) {
+					t.Fatalf("expected %v, got %v", iana.ErrUnsupportedCurve, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if crv != expected {
+				t.Fatalf("expected %d, got %d", expected, crv)
+			}
+
+			s, err := iana.SuiteForCOSECurve(crv, id.RandomOracle())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if s != id {
+				t.Fatalf("expected %s, got %s", id, s)
+			}
+		})
+	}
+
+	// Ed448 has no suite in this module.
+	if _, err := iana.SuiteForCOSECurve(7, false); !errors.Is(err, iana.ErrUnsupportedCurve) {
+		t.Fatalf("expected %v, got %v", iana.ErrUnsupportedCurve, err)
+	}
+
+	if _, err := iana.COSECurve(0); !errors.Is(err, hash2curve.ErrInvalidSuite) {
+		t.Fatalf("expected %v, got %v", hash2curve.ErrInvalidSuite, err)
+	}
+}
@@ -0,0 +1,70 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+package hash2curve_test
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/bytemare/hash2curve"
+	"github.com/bytemare/hash2curve/secp256k1"
+)
+
+func TestIsogeny_Verify(t *testing.T) {
+	if err := secp256k1.VerifyIsogeny(); err != nil {
+		t.Fatal(err)
+	}
+
+	iso := secp256k1.IsogenyConstants()
+	if iso.Source != secp256k1.IsogenySource || len(iso.XNum) != 4 || len(iso.XDen) != 3 || len(iso.YNum) != 4 ||
+		len(iso.YDen) != 4 {
+		t.Fatal("unexpected isogeny table")
+	}
+
+	// The values of RFC 9380, Appendix E.1.
+	for name, test := range map[string]struct {
+		value    *big.Int
+		expected string
+	}{
+		"A'":      {iso.A, "3f8731abdd661adca08a5558f0f5d272e953d363cb6f0e5d405447c01a444533"},
+		"B'":      {iso.B, "6eb"},
+		"k_(1,0)": {iso.XNum[0], "8e38e38e38e38e38e38e38e38e38e38e38e38e38e38e38e38e38e38daaaaa8c7"},
+		"k_(4,2)": {iso.YDen[2], "6484aa716545ca2cf3a70c3fa8fe337e0a3d21162f0d6299a7bf8192bfd2a76f"},
+	} {
+		if got := test.value.Text(16); got != test.expected {
+			t.Fatalf("%s: expected %s, got %s", name, test.expected, got)
+		}
+	}
+
+	// The returned values are copies.
+	iso.XNum[1].SetInt64(1)
+
+	if err := secp256k1.VerifyIsogeny(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestIsogeny_Tampered(t *testing.T) {
+	for name, tamper := range map[string]func(iso *secp256k1.Isogeny){
+		"XNum": func(iso *secp256k1.Isogeny) { iso.XNum[1].Add(iso.XNum[1], big.NewInt(1)) },
+		"YDen": func(iso *secp256k1.Isogeny) { iso.YDen[0].Add(iso.YDen[0], big.NewInt(1)) },
+		"B":    func(iso *secp256k1.Isogeny) { iso.B.SetInt64(7) },
+		"A":    func(iso *secp256k1.Isogeny) { iso.A.SetInt64(0) },
+	} {
+		iso := secp256k1.IsogenyConstants()
+		tamper(iso)
+
+		if err := iso.Verify(); !errors.Is(err, hash2curve.ErrSelfTest) {
+			t.Fatalf("%s: expected %q, got %v", name, hash2curve.ErrSelfTest, err)
+		}
+	}
+}
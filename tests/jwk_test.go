@@ -0,0 +1,164 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+package hash2curve_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/bytemare/hash2curve"
+	"github.com/bytemare/hash2curve/jwk"
+	"github.com/bytemare/hash2curve/nist"
+	"github.com/bytemare/hash2curve/suite"
+)
+
+func TestJWK_FromSuite(t *testing.T) {
+	input := []byte("abc")
+
+	curves := map[string]struct{ kty, crv string }{
+		"P256":         {jwk.KeyTypeEC, "P-256"},
+		"P384":         {jwk.KeyTypeEC, "P-384"},
+		"P521":         {jwk.KeyTypeEC, "P-521"},
+		"secp256k1":    {jwk.KeyTypeEC, "secp256k1"},
+		"edwards25519": {jwk.KeyTypeOKP, "Ed25519"},
+		"curve25519":   {jwk.KeyTypeOKP, "X25519"},
+	}
+
+	for _, id := range hash2curve.AllSuites() {
+		t.Run(id.String(), func(t *testing.T) {
+			dst := []byte("QUUX-V01-CS02-with-" + id.String())
+
+			expected, ok := curves[id.SuiteID().Curve]
+			if !ok {
+				s, err := suite.For(id)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if _, err = jwk.FromSuite(id, s.Hash(input, dst)); !errors.Is(err, jwk.ErrUnsupportedCurve) {
+					t.Fatalf("expected %v, got %v", jwk.ErrUnsupportedCurve, err)
+				}
+
+				return
+			}
+
+			compressed, err := suite.For(id)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			uncompressed, err := suite.For(id, suite.WithOutputEncoding(suite.Uncompressed))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			k1, err := jwk.FromSuite(id, compressed.Hash(input, dst))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			k2, err := jwk.FromSuite(id, uncompressed.Hash(input, dst))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if *k1 != *k2 || k1.Kty != expected.kty || k1.Crv != expected.crv {
+				t.Fatalf("unexpected keys %+v and %+v", k1, k2)
+			}
+
+			x, err := base64.RawURLEncoding.DecodeString(k1.X)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			switch expected.kty {
+			case jwk.KeyTypeOKP:
+				if k1.Y != "" || !bytes.Equal(x, compressed.Hash(input, dst)) {
+					t.Fatal("unexpected OKP key")
+				}
+			case jwk.KeyTypeEC:
+				y, err := base64.RawURLEncoding.DecodeString(k1.Y)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if !bytes.Equal(append(append([]byte{4}, x...), y...), uncompressed.Hash(input, dst)) {
+					t.Fatal("unexpected EC key")
+				}
+			}
+		})
+	}
+}
+
+func TestJWK_FromECDSA(t *testing.T) {
+	input, dst := []byte("abc"), []byte("QUUX-V01-CS02-with-P256_XMD:SHA-256_SSWU_RO_")
+
+	pub, err := nist.HashToP256PublicKey(input, dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	k1, err := jwk.FromECDSA(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	k2, err := jwk.FromSuite(hash2curve.P256SHA256SSWURO, nist.HashToP256(input, dst).BytesCompressed())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if *k1 != *k2 {
+		t.Fatalf("expected %+v, got %+v", k2, k1)
+	}
+
+	encoded, err := json.Marshal(k1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded map[string]string
+	if err = json.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(decoded) != 4 || decoded["kty"] != "EC" || decoded["crv"] != "P-256" || decoded["x"] != k1.X ||
+		decoded["y"] != k1.Y {
+		t.Fatalf("unexpected JSON %s", encoded)
+	}
+}
+
+func TestJWK_Errors(t *testing.T) {
+	p256 := nist.HashToP256([]byte("abc"), []byte("QUUX-V01-CS02-with-jwk")).BytesCompressed()
+	secp256k1Point := make([]byte, 65)
+	secp256k1Point[0] = 4
+
+	for _, test := range []struct {
+		expected error
+		point    []byte
+		suite    hash2curve.Suite
+	}{
+		{hash2curve.ErrInvalidSuite, p256, 0},
+		{hash2curve.ErrInvalidPoint, p256[1:], hash2curve.P256SHA256SSWURO},
+		{hash2curve.ErrInvalidPoint, []byte{0}, hash2curve.P256SHA256SSWURO},
+		{hash2curve.ErrInvalidPoint, make([]byte, 33), hash2curve.Secp256k1SHA256SSWURO},
+		{hash2curve.ErrInvalidPoint, secp256k1Point, hash2curve.Secp256k1SHA256SSWURO},
+		{hash2curve.ErrInvalidPoint, make([]byte, 31), hash2curve.Curve25519SHA512ELL2RO},
+		{hash2curve.ErrInvalidPoint, make([]byte, 33), hash2curve.Edwards25519SHA512ELL2RO},
+	} {
+		if _, err := jwk.FromSuite(test.suite, test.point); !errors.Is(err, test.expected) {
+			t.Fatalf("expected %v for %s, got %v", test.expected, test.suite, err)
+		}
+	}
+}
@@ -0,0 +1,241 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+package hash2curve_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/bytemare/hash2curve"
+	"github.com/bytemare/hash2curve/bn254"
+	"github.com/bytemare/hash2curve/secp256k1"
+)
+
+// The Keccak-256 suites are not specified by RFC 9380: the following values were generated by the standalone Python
+// implementation in vectors/keccak/generate.py, with Python 3.11.7, for the DST "QUUX-V01-CS02-with-" followed by the
+// suite identifier. For secp256k1, it only computes the hash_to_field outputs, which the tests map with MapToCurve.
+
+func TestExpandXMDKeccak256(t *testing.T) {
+	dst := []byte("QUUX-V01-CS02-with-expander-KECCAK256")
+
+	for _, test := range []struct {
+		want   string
+		length uint
+	}{
+		{
+			length: 32,
+			want:   "db411ae98fd908939cfd55ba2bf72ac70b24c29f2b81bdfaa35cc63d0984ca9b",
+		},
+		{
+			length: 200,
+			want: "c627dcd271dc66c1d12e9e8eeea4bea5e52fe2b43a5fde3cc899eeee7c06684c51afbca7233e17e0dba1f35e4d1b9a2c" +
+				"fedec512cf36a447aab36afc9055bb91b72ef058e01e60df5a99fd86d4aedf30087d64d274d3588418160f70c27ae20f" +
+				"a01da0a3750d5c70a01688153eb33eb21455e3c6b47e5b50ed260d4651edfae6350a6448df01f2e2f0485367192308b8" +
+				"e51f6a081f7ecff375ba5dd9bf81fb464e40b58853a5067dbd2be7cfb32006b33d0ce8dcda8f6a646c179038be02a69b" +
+				"7f2dfd59a17a358b",
+		},
+	} {
+		want, _ := hex.DecodeString(test.want)
+		if got := hash2curve.ExpandXMDKeccak256([]byte("abc"), dst, test.length); !bytes.Equal(got, want) {
+			t.Fatalf("unexpected output for length %d: want %x, got %x", test.length, want, got)
+		}
+
+		if got := hash2curve.XMDKeccak256().Expand([]byte("abc"), dst, test.length); !bytes.Equal(got, want) {
+			t.Fatalf("unexpected Expander output for length %d: want %x, got %x", test.length, want, got)
+		}
+	}
+
+	if hasPanic, err := expectPanic(hash2curve.ErrZeroLengthDST, func() {
+		_ = hash2curve.ExpandXMDKeccak256([]byte("abc"), nil, 32)
+	}); !hasPanic {
+		t.Fatal(err)
+	}
+}
+
+func TestSecp256k1_Keccak256(t *testing.T) {
+	msg := []byte("abc")
+	mapped := func(u ...string) *secp256k1.Point {
+		p := secp256k1.MapToCurve(hexInt(t, u[0]))
+		if len(u) == 2 {
+			p.Add(p, secp256k1.MapToCurve(hexInt(t, u[1])))
+		}
+
+		return p
+	}
+
+	want := mapped(
+		"f94b7d927b5e2ff3d5d898513cefafdc2502480015cdbcef20026d24254f5826",
+		"188850d1eba70cd94bbc8ae7b561f04a453a2ed8b8290663bbd547421900749a",
+	)
+	if got := secp256k1.HashToCurveKeccak256(msg, []byte("QUUX-V01-CS02-with-"+secp256k1.H2CKeccak256)); !bytes.Equal(
+		got.Bytes(), want.Bytes()) {
+		t.Fatalf("unexpected hash-to-curve point: want %x, got %x", want.Bytes(), got.Bytes())
+	}
+
+	want = mapped("ddb98ec999301702a01151462495d2a771931fffa30162a28c233f2e1d2a86db")
+	if got := secp256k1.EncodeToCurveKeccak256(msg, []byte("QUUX-V01-CS02-with-"+secp256k1.E2CKeccak256)); !bytes.Equal(
+		got.Bytes(), want.Bytes()) {
+		t.Fatalf("unexpected encode-to-curve point: want %x, got %x", want.Bytes(), got.Bytes())
+	}
+
+	wantScalar := hexInt(t, "20f8b5e07175c2f565e5c76fa09b6e8fa9e40114187e2db49e96c06306e4cb65")
+	if got := secp256k1.HashToScalarKeccak256(msg, []byte("QUUX-V01-CS02-with-"+secp256k1.H2CKeccak256)); got.Cmp(
+		wantScalar) != 0 {
+		t.Fatalf("unexpected scalar: want %x, got %x", wantScalar, got)
+	}
+
+	// The SHA-256 suite must yield another point for the same input.
+	if bytes.Equal(secp256k1.HashToCurve(msg, []byte(secp256k1.H2C)).Bytes(),
+		secp256k1.HashToCurveKeccak256(msg, []byte(secp256k1.H2C)).Bytes()) {
+		t.Fatal("expected different points for SHA-256 and Keccak-256")
+	}
+}
+
+func TestBN254(t *testing.T) {
+	for _, test := range []struct {
+		hash func(input, dst []byte) *bn254.Point
+		name string
+		id   string
+		msg  string
+		x, y string
+	}{
+		{
+			name: "HashToCurve/empty",
+			id:   bn254.H2C,
+			hash: bn254.HashToCurve,
+			msg:  "",
+			x:    "0c112533eaaa53fc814ee3ee0c23c45264bd0d24524d4af3a4abaea2fa9bc358",
+			y:    "1418c14cf13dcb591a3ef964d0214db0936bc5efb568e1d168f6d66fadd34006",
+		},
+		{
+			name: "HashToCurve/abc",
+			id:   bn254.H2C,
+			hash: bn254.HashToCurve,
+			msg:  "abc",
+			x:    "1810adf4e1884db8b8ceb08ff576172d17456ce8eb4f0bffb878daa8470acb90",
+			y:    "20926f6ce4d67301e6d5329a650da7074bb48088b363092eebd7472250635bc7",
+		},
+		{
+			name: "HashToCurve/abcdef0123456789",
+			id:   bn254.H2C,
+			hash: bn254.HashToCurve,
+			msg:  "abcdef0123456789",
+			x:    "1abea9c004ea5b29ed7f4132c3f069758fc3ba6ad8f858a1e68f2423fb510410",
+			y:    "2f66f3d9a593a17d5bac7e50e813f33741cdf01181eb3331767d6c99c8bceb01",
+		},
+		{
+			name: "EncodeToCurve/empty",
+			id:   bn254.E2C,
+			hash: bn254.EncodeToCurve,
+			msg:  "",
+			x:    "04e1b89c962bf8e9d94844c219eb7eb9148df4afa1ba8b8b3918fed5ba6e6149",
+			y:    "29f9e25610c6a41e4b0aa90eedabbaac5752d093af0bfc277a80cd9dc6a90d2b",
+		},
+		{
+			name: "EncodeToCurve/abc",
+			id:   bn254.E2C,
+			hash: bn254.EncodeToCurve,
+			msg:  "abc",
+			x:    "1fa7e47f70849e034e5de33148ea8afc57fde596dbed20f401a9b16b20678883",
+			y:    "27942b4a4c0f470f1a265b2537690fcb2286a8f2e409bb884493657b03dd7f81",
+		},
+		{
+			name: "EncodeToCurve/abcdef0123456789",
+			id:   bn254.E2C,
+			hash: bn254.EncodeToCurve,
+			msg:  "abcdef0123456789",
+			x:    "158e9430e27b28455426661b5c5e3b4859afc90e469c3e879c0f59468795d7d5",
+			y:    "1aa87e85d6aedf87c263426378b8b2f3e4dd5f0aa1b39e7946fe84f02dc9be51",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			p := test.hash([]byte(test.msg), []byte("QUUX-V01-CS02-with-"+test.id))
+
+			want, _ := hex.DecodeString(test.x + test.y)
+			if !bytes.Equal(p.Bytes(), want) {
+				t.Fatalf("unexpected point: want %x, got %x", want, p.Bytes())
+			}
+
+			q, err := new(bn254.Point).SetBytes(want)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !q.Equal(p) {
+				t.Fatal("unexpected round trip")
+			}
+		})
+	}
+}
+
+func TestBN254_Points(t *testing.T) {
+	p := bn254.HashToCurve([]byte("abc"), []byte("QUUX-V01-CS02-with-"+bn254.H2C))
+
+	// The point at infinity is encoded as 64 zero bytes.
+	neg, err := new(bn254.Point).SetBytes(negateXY(p.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if inf := new(bn254.Point).Add(p, neg); !inf.IsInfinity() || !bytes.Equal(inf.Bytes(), make([]byte, 64)) {
+		t.Fatalf("expected the point at infinity, got %x", inf.Bytes())
+	}
+
+	if q, err := new(bn254.Point).SetBytes(make([]byte, 64)); err != nil || !q.IsInfinity() || q.Equal(p) {
+		t.Fatalf("expected the point at infinity, got %v", err)
+	}
+
+	offCurve := p.Bytes()
+	offCurve[63] ^= 1
+
+	nonCanonical := p.Bytes()
+	copy(nonCanonical[:32], hexInt(t, "30644e72e131a029b85045b68181585d97816a916871ca8d3c208c16d87cfd47").Bytes())
+
+	for _, b := range [][]byte{offCurve, nonCanonical, p.Bytes()[:63], {0}} {
+		if _, err := new(bn254.Point).SetBytes(b); !errors.Is(err, hash2curve.ErrInvalidPoint) {
+			t.Fatalf("expected an invalid point error for %x, got %v", b, err)
+		}
+	}
+
+	if hasPanic, err := expectPanic(hash2curve.ErrNonCanonical, func() {
+		_ = bn254.MapToCurve(hexInt(t, "30644e72e131a029b85045b68181585d97816a916871ca8d3c208c16d87cfd47"))
+	}); !hasPanic {
+		t.Fatal(err)
+	}
+
+	if s := bn254.HashToScalar([]byte("abc"), []byte("QUUX-V01-CS02-with-"+bn254.H2C)); s.Sign() == 0 {
+		t.Fatal("unexpected zero scalar")
+	}
+}
+
+func hexInt(t *testing.T, s string) *big.Int {
+	t.Helper()
+
+	i, ok := new(big.Int).SetString(s, 16)
+	if !ok {
+		t.Fatalf("invalid hexadecimal integer %q", s)
+	}
+
+	return i
+}
+
+// negateXY returns the encoding of the opposite of the point encoded as x || y over the BN254 base field.
+func negateXY(b []byte) []byte {
+	p := bn254.FieldPrime()
+	y := new(big.Int).SetBytes(b[32:])
+	out := bytes.Clone(b)
+	new(big.Int).Sub(p, y).FillBytes(out[32:])
+
+	return out
+}
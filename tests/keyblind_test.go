@@ -0,0 +1,199 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+package hash2curve_test
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"errors"
+	"math/big"
+	"slices"
+	"testing"
+
+	"filippo.io/edwards25519"
+
+	h2cedwards25519 "github.com/bytemare/hash2curve/edwards25519"
+	"github.com/bytemare/hash2curve/keyblind"
+	"github.com/bytemare/hash2curve/nist"
+)
+
+var (
+	blindKey     = []byte("0123456789abcdef0123456789abcdef")
+	blindContext = []byte("test context")
+)
+
+func TestKeyBlind_Ed25519(t *testing.T) {
+	pk, sk, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bk, err := keyblind.Ed25519BlindingScalar(blindKey, blindContext)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The blinding scalar is the lower half of SHA-512(skB || ctx), clamped, and reduced modulo the group order.
+	digest := sha512.Sum512(append(slices.Clone(blindKey), blindContext...))
+	digest[0] &= 248
+	digest[31] &= 127
+	digest[31] |= 64
+
+	clamped := slices.Clone(digest[:32])
+	slices.Reverse(clamped)
+
+	want := new(big.Int).Mod(new(big.Int).SetBytes(clamped), h2cedwards25519.Order())
+
+	got := slices.Clone(bk.Bytes())
+	slices.Reverse(got)
+
+	if new(big.Int).SetBytes(got).Cmp(want) != 0 {
+		t.Fatal("unexpected blinding scalar")
+	}
+
+	point, err := keyblind.Ed25519BlindingPoint(blindKey, blindContext)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if point.Equal(new(edwards25519.Point).ScalarBaseMult(bk)) != 1 {
+		t.Fatal("unexpected blinding point")
+	}
+
+	blindedPK, err := keyblind.Ed25519BlindPublicKey(pk, blindKey, blindContext)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	blindedSK, err := keyblind.Ed25519BlindSecretScalar(sk, blindKey, blindContext)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(new(edwards25519.Point).ScalarBaseMult(blindedSK).Bytes(), blindedPK) {
+		t.Fatal("the blinded keys don't match")
+	}
+
+	if bytes.Equal(blindedPK, pk) {
+		t.Fatal("the public key is not blinded")
+	}
+
+	other, err := keyblind.Ed25519BlindPublicKey(pk, blindKey, []byte("other context"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if bytes.Equal(other, blindedPK) {
+		t.Fatal("expected different keys for different contexts")
+	}
+
+	// 2 is not the y coordinate of a point.
+	invalid := make([]byte, ed25519.PublicKeySize)
+	invalid[0] = 2
+
+	_, err = keyblind.Ed25519BlindPublicKey(invalid, blindKey, blindContext)
+	if !errors.Is(err, keyblind.ErrInvalidKey) {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	_, err = keyblind.Ed25519BlindSecretScalar(sk[:32], blindKey, blindContext)
+	if !errors.Is(err, keyblind.ErrInvalidKey) {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if _, err = keyblind.Ed25519BlindingScalar(blindKey[:31], blindContext); !errors.Is(err, keyblind.ErrInvalidKey) {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
+
+func TestKeyBlind_P256(t *testing.T) {
+	sk, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bk, err := keyblind.P256BlindingScalar(blindKey, blindContext)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if bk.Cmp(nist.HashToScalarP256(blindKey, keyblind.ECDSADST(blindContext))) != 0 ||
+		!bytes.Equal(keyblind.ECDSADST(blindContext), []byte("ECDSA Key Blind"+string(blindContext))) {
+		t.Fatal("unexpected blinding scalar")
+	}
+
+	point, err := keyblind.P256BlindingPoint(blindKey, blindContext)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The blinding point is the base point blinded as a public key.
+	params := elliptic.P256().Params()
+
+	base, err := keyblind.P256BlindPublicKey(
+		&ecdsa.PublicKey{Curve: elliptic.P256(), X: params.Gx, Y: params.Gy}, blindKey, blindContext)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if enc := nist.Encode(point); new(big.Int).SetBytes(enc.X).Cmp(base.X) != 0 ||
+		new(big.Int).SetBytes(enc.Y).Cmp(base.Y) != 0 {
+		t.Fatal("unexpected blinding point")
+	}
+
+	blindedPK, err := keyblind.P256BlindPublicKey(&sk.PublicKey, blindKey, blindContext)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	blindedSK, err := keyblind.P256BlindPrivateKey(sk, blindKey, blindContext)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !blindedSK.PublicKey.Equal(blindedPK) || blindedPK.Equal(&sk.PublicKey) {
+		t.Fatal("unexpected blinded keys")
+	}
+
+	if d := new(big.Int).Mul(bk, sk.D); d.Mod(d, nist.OrderP256()).Cmp(blindedSK.D) != 0 {
+		t.Fatal("unexpected blinded private key")
+	}
+
+	digest := sha256.Sum256([]byte("message"))
+
+	signature, err := ecdsa.SignASN1(rand.Reader, blindedSK, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !ecdsa.VerifyASN1(blindedPK, digest[:], signature) || ecdsa.VerifyASN1(&sk.PublicKey, digest[:], signature) {
+		t.Fatal("unexpected signature verification")
+	}
+
+	p384, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = keyblind.P256BlindPublicKey(&p384.PublicKey, blindKey, blindContext)
+	if !errors.Is(err, keyblind.ErrInvalidKey) {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if _, err = keyblind.P256BlindPrivateKey(p384, blindKey, blindContext); !errors.Is(err, keyblind.ErrInvalidKey) {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
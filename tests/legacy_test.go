@@ -0,0 +1,195 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+package hash2curve_test
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/bytemare/hash2curve"
+	"github.com/bytemare/hash2curve/secp192r1"
+	"github.com/bytemare/hash2curve/secp224k1"
+)
+
+// legacyPoint abstracts over the points of the legacy curve packages.
+type legacyPoint interface {
+	coordinates() (x, y *big.Int)
+	bytes(compressed bool) []byte
+	roundTrip(b []byte) ([]byte, error)
+}
+
+type secp192r1Point struct{ *secp192r1.Point }
+
+func (p secp192r1Point) coordinates() (x, y *big.Int) { return &p.X, &p.Y }
+
+func (p secp192r1Point) bytes(compressed bool) []byte {
+	if compressed {
+		return p.Bytes()
+	}
+
+	return p.BytesUncompressed()
+}
+
+func (p secp192r1Point) roundTrip(b []byte) ([]byte, error) {
+	q, err := new(secp192r1.Point).SetBytes(b)
+	if err != nil {
+		return nil, err
+	}
+
+	return q.BytesUncompressed(), nil
+}
+
+type secp224k1Point struct{ *secp224k1.Point }
+
+func (p secp224k1Point) coordinates() (x, y *big.Int) { return &p.X, &p.Y }
+
+func (p secp224k1Point) bytes(compressed bool) []byte {
+	if compressed {
+		return p.Bytes()
+	}
+
+	return p.BytesUncompressed()
+}
+
+func (p secp224k1Point) roundTrip(b []byte) ([]byte, error) {
+	q, err := new(secp224k1.Point).SetBytes(b)
+	if err != nil {
+		return nil, err
+	}
+
+	return q.BytesUncompressed(), nil
+}
+
+// TestLegacyCurves checks the secp192r1 and secp224k1 suites against values computed with an independent
+// implementation, for the message "abc" and the DST "QUUX-V01-CS02-with-" followed by the suite identifier, and the
+// SEC 1 encodings of the points.
+func TestLegacyCurves(t *testing.T) {
+	for _, test := range []struct {
+		hash func(input, dst []byte) legacyPoint
+		name string
+		id   string
+		x, y string
+	}{
+		{
+			name: "secp192r1/HashToCurve",
+			id:   secp192r1.H2C,
+			hash: func(input, dst []byte) legacyPoint { return secp192r1Point{secp192r1.HashToCurve(input, dst)} },
+			x:    "0x1cf0c1a302f7072b7abd328e8b15d85e7f4d152534acb2ef",
+			y:    "0x0adda183178834602ad4444697d7c35873be87d081a0d762",
+		},
+		{
+			name: "secp192r1/EncodeToCurve",
+			id:   secp192r1.E2C,
+			hash: func(input, dst []byte) legacyPoint { return secp192r1Point{secp192r1.EncodeToCurve(input, dst)} },
+			x:    "0x4fbc8b473f31e4c8c9083458cf8257b1b786633f3a56783d",
+			y:    "0xcd4deb402e9bb40ca2b7739803fd1d0f4d707cafe4797887",
+		},
+		{
+			name: "secp224k1/HashToCurve",
+			id:   secp224k1.H2C,
+			hash: func(input, dst []byte) legacyPoint { return secp224k1Point{secp224k1.HashToCurve(input, dst)} },
+			x:    "0x3f60ebb858fa5048568b083f3e01106709f09297be8af6db07539554",
+			y:    "0x7f2be1136e54f16605e364697ada33a0ad7665bf49ca62f020adc55c",
+		},
+		{
+			name: "secp224k1/EncodeToCurve",
+			id:   secp224k1.E2C,
+			hash: func(input, dst []byte) legacyPoint { return secp224k1Point{secp224k1.EncodeToCurve(input, dst)} },
+			x:    "0x3e1ec0f354d38582cd0fdff770345635893c0475b3e8b152b66d9860",
+			y:    "0x69cf968bf32e867f38eae72c53dc364ee03100a5c3e48ab98e0f20f7",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			p := test.hash([]byte("abc"), []byte("QUUX-V01-CS02-with-"+test.id))
+
+			x, y := p.coordinates()
+			wantX, _ := new(big.Int).SetString(test.x, 0)
+			wantY, _ := new(big.Int).SetString(test.y, 0)
+
+			if x.Cmp(wantX) != 0 || y.Cmp(wantY) != 0 {
+				t.Fatalf("unexpected point: want (%s, %s), got (0x%x, 0x%x)", test.x, test.y, x, y)
+			}
+
+			uncompressed := p.bytes(false)
+			for _, b := range [][]byte{p.bytes(true), uncompressed} {
+				got, err := p.roundTrip(b)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if !bytes.Equal(got, uncompressed) {
+					t.Fatalf("unexpected round trip of %x", b)
+				}
+			}
+
+			// Flipping the sign, off-curve coordinates, and truncated encodings are rejected or decode another point.
+			flipped := p.bytes(true)
+			flipped[0] ^= 1
+
+			if got, err := p.roundTrip(flipped); err != nil || bytes.Equal(got, uncompressed) {
+				t.Fatalf("expected the opposite point, got %x, %v", got, err)
+			}
+
+			offCurve := p.bytes(false)
+			offCurve[len(offCurve)-1] ^= 1
+
+			for _, b := range [][]byte{offCurve, uncompressed[:len(uncompressed)-1], {5}, {0, 0}} {
+				if _, err := p.roundTrip(b); !errors.Is(err, hash2curve.ErrInvalidPoint) {
+					t.Fatalf("expected an invalid point error for %x, got %v", b, err)
+				}
+			}
+		})
+	}
+}
+
+func TestLegacyCurves_Points(t *testing.T) {
+	input := []byte("input")
+	dst := []byte("QUUX-V01-CS02-with-points")
+
+	p := secp192r1.HashToCurve(input, dst)
+	sum := new(secp192r1.Point).Add(p, secp192r1.EncodeToCurve(input, dst))
+
+	if sum.Equal(p) || sum.IsInfinity() {
+		t.Fatal("expected a different point")
+	}
+
+	infinity, err := new(secp224k1.Point).SetBytes([]byte{0})
+	if err != nil || !infinity.IsInfinity() || !bytes.Equal(infinity.Bytes(), []byte{0}) {
+		t.Fatalf("expected the point at infinity, got %v", err)
+	}
+
+	q := secp224k1.MapToCurve(big.NewInt(0))
+	if !new(secp224k1.Point).Add(q, infinity).Equal(q) {
+		t.Fatal("expected the identity to be neutral")
+	}
+
+	// The field element p is not canonical, and its encoding is rejected.
+	prime := secp192r1.FieldPrime()
+	nonCanonical := append([]byte{2}, prime.Bytes()...)
+
+	if _, err = new(secp192r1.Point).SetBytes(nonCanonical); !errors.Is(err, hash2curve.ErrInvalidPoint) {
+		t.Fatalf("expected an invalid point error, got %v", err)
+	}
+
+	if s := secp192r1.HashToScalar(input, dst); s.Cmp(secp192r1.Order()) >= 0 {
+		t.Fatal("expected a scalar reduced modulo the order")
+	}
+
+	if ok, err := expectPanic(hash2curve.ErrNonCanonical, func() { secp192r1.MapToCurve(prime) }); !ok {
+		t.Fatal(err)
+	}
+
+	if ok, err := expectPanic(hash2curve.ErrNonCanonical, func() { secp224k1.MapToCurve(big.NewInt(-1)) }); !ok {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,224 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+package hash2curve_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/bytemare/hash2curve"
+	"github.com/bytemare/hash2curve/mnt4298"
+	"github.com/bytemare/hash2curve/mnt6298"
+)
+
+func coordinates(x, y []big.Int) []*big.Int {
+	res := make([]*big.Int, 0, len(x)+len(y))
+	for i := range x {
+		res = append(res, &x[i])
+	}
+
+	for i := range y {
+		res = append(res, &y[i])
+	}
+
+	return res
+}
+
+// TestMNT checks the MNT4-298 and MNT6-298 suites against values computed with an independent implementation, for
+// the message "abc" and the DST "QUUX-V01-CS02-with-" followed by the suite identifier.
+func TestMNT(t *testing.T) {
+	for _, test := range []struct {
+		hash func(input, dst []byte) ([]*big.Int, bool)
+		name string
+		id   string
+		want []string
+	}{
+		{
+			name: "mnt4298/HashToG1",
+			id:   mnt4298.G1H2C,
+			hash: func(input, dst []byte) ([]*big.Int, bool) {
+				p := mnt4298.HashToG1(input, dst)
+				return []*big.Int{&p.X, &p.Y}, p.IsInSubgroup()
+			},
+			want: []string{
+				"0x166f2a55b6433be3f826b9f25673bde57ca8f67aa9528fcd4ad3c07766ca963a5f60db7f47b",
+				"0x18048536d60f0a214266b4c9292484355c428aaf3449d45ad969fe03e64f55da61d5954a1b6",
+			},
+		},
+		{
+			name: "mnt4298/EncodeToG1",
+			id:   mnt4298.G1E2C,
+			hash: func(input, dst []byte) ([]*big.Int, bool) {
+				p := mnt4298.EncodeToG1(input, dst)
+				return []*big.Int{&p.X, &p.Y}, p.IsInSubgroup()
+			},
+			want: []string{
+				"0x170cb0775376b894616a581f6a4a1011f8269b93615772b6c55658ca738b9959527a5d9b20b",
+				"0x37e40d31ecb580b63335fd6ee8b67c065769b82f0987fa1a5c5535f142025b7c2af1a75f0fd",
+			},
+		},
+		{
+			name: "mnt4298/HashToG2",
+			id:   mnt4298.G2H2C,
+			hash: func(input, dst []byte) ([]*big.Int, bool) {
+				p := mnt4298.HashToG2(input, dst)
+				return coordinates(p.X[:], p.Y[:]), p.IsInSubgroup()
+			},
+			want: []string{
+				"0x310309ad5027971eec1ca889a7fc5c620efaf8b92779830c4e6f9329666be8873f0d49f03fa",
+				"0x23c64386fa3e448d8f69af30a541a37e2cdb8e0ce3743c830926a8292a59296fdc2185c30e7",
+				"0x36ca82d5e143242dced2f7bc1a9cb9d33854dbe3f38e0863d302a677dce162fc471a091c939",
+				"0x150ee8c24a7dc46aa843acf93870551b524f4f0e2d3692d2944e56cb2c799f361ef07001e1e",
+			},
+		},
+		{
+			name: "mnt4298/EncodeToG2",
+			id:   mnt4298.G2E2C,
+			hash: func(input, dst []byte) ([]*big.Int, bool) {
+				p := mnt4298.EncodeToG2(input, dst)
+				return coordinates(p.X[:], p.Y[:]), p.IsInSubgroup()
+			},
+			want: []string{
+				"0x17dd44d914dcc244c0a59220ba0660d6ce24f0d652523626bffa8650a6b0230df145a35e2ba",
+				"0x14a2dd99d978cdefa2e69919b38b588300da6660eac2fc30c23dc2cb5183e9c4c11d901d578",
+				"0x14eed321e77fec7daf6f0eaad88d3fb7d966b9f398fbe40be3ea88910e3b33c98f596b1baff",
+				"0x3208c1c1f2e1808c7fff0c96bea90b8d0e88ab9c129c749942037f0f35e105a40eaaff76819",
+			},
+		},
+		{
+			name: "mnt6298/HashToG1",
+			id:   mnt6298.G1H2C,
+			hash: func(input, dst []byte) ([]*big.Int, bool) {
+				p := mnt6298.HashToG1(input, dst)
+				return []*big.Int{&p.X, &p.Y}, p.IsInSubgroup()
+			},
+			want: []string{
+				"0x220a1a926584bd396aa4911fa4d2deae2a0e95da09e10ef344e3ba6495214d965afd71b4ebd",
+				"0x63a65dfa05df74244081d2969689891b0145122b5505a6905998c4b04e56d418426a327b1a",
+			},
+		},
+		{
+			name: "mnt6298/EncodeToG1",
+			id:   mnt6298.G1E2C,
+			hash: func(input, dst []byte) ([]*big.Int, bool) {
+				p := mnt6298.EncodeToG1(input, dst)
+				return []*big.Int{&p.X, &p.Y}, p.IsInSubgroup()
+			},
+			want: []string{
+				"0xd12f64c4423530bfe226c5c62592395ebc4e99f54574c07b74055dbb7610cdb69b4cbdfea9",
+				"0x1860707c88df2fc6cd98c6e38666499fa4caeee6699ee9864a231f43dc68848138b2a4f81e1",
+			},
+		},
+		{
+			name: "mnt6298/HashToG2",
+			id:   mnt6298.G2H2C,
+			hash: func(input, dst []byte) ([]*big.Int, bool) {
+				p := mnt6298.HashToG2(input, dst)
+				return coordinates(p.X[:], p.Y[:]), p.IsInSubgroup()
+			},
+			want: []string{
+				"0x2b87f1658802101cd5ba9255005bcbc061e7b80e6f1df24e5b6bbbf2be13eb17962b1a0e888",
+				"0x28023d046acefd045871f9951d8f461c160c1050b9401db07d39aa6da5bcf03cf43d4664a01",
+				"0x17cefb8e16b2b1397b6ec7825f226753e2e9bf5fb892ea7cdd898fb8a5d572cf9ee54020ae6",
+				"0x3b86305c73ab8a3d88efc72e4a9579dc054001cd93b89073b68f5243cd3c864e8c884a547ae",
+				"0x1113b690b97437c930d7fbc99dba0d015d47de530244e19c4bd744649760c2a210abb2cad57",
+				"0x35e16a6d5ac05e4f84f756bb480266eacce7d738bb9cf3eaeb132f20f0ff0bd0831c633e3f7",
+			},
+		},
+		{
+			name: "mnt6298/EncodeToG2",
+			id:   mnt6298.G2E2C,
+			hash: func(input, dst []byte) ([]*big.Int, bool) {
+				p := mnt6298.EncodeToG2(input, dst)
+				return coordinates(p.X[:], p.Y[:]), p.IsInSubgroup()
+			},
+			want: []string{
+				"0x17f0c62a2d1fa386d55818ae0bb23fcd4ced116228b2f9d3b484da2e9f860dbc8c708021fd2",
+				"0x23208d657b6325415c5061d5eaf565dcbf99fd9f8b3b395145537513ce16616d316902978d7",
+				"0x391b202e6614e2a8ee150b9114c4363d624699b78ef47852e2f423198b15ed994cf61545ee8",
+				"0x2a9b0e56b7d91b32a355266fc7217ba6fa0f9a9a80c91fe30cc55dce89d9410ca3b8fc2b7f1",
+				"0xbb7fbb31d963cd8276d6393b33d7503c47b5cc6339f935fb818670e7b263b344a137866ca2",
+				"0x34ccb7aa6b2359aa8f359a6fe98bddd1596ae7bd27b3548d3706565c648b93a6cde35608c24",
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			got, inSubgroup := test.hash([]byte("abc"), []byte("QUUX-V01-CS02-with-"+test.id))
+			if len(got) != len(test.want) {
+				t.Fatalf("expected %d coordinates, got %d", len(test.want), len(got))
+			}
+
+			for i, w := range test.want {
+				want, _ := new(big.Int).SetString(w, 0)
+				if got[i].Cmp(want) != 0 {
+					t.Fatalf("unexpected coordinate %d: want %s, got 0x%x", i, w, got[i])
+				}
+			}
+
+			if !inSubgroup {
+				t.Fatal("expected a point in the prime-order subgroup")
+			}
+		})
+	}
+}
+
+func TestMNT_Points(t *testing.T) {
+	input := []byte("input")
+	dst := []byte("QUUX-V01-CS02-with-points")
+
+	p1 := mnt4298.HashToG1(input, dst)
+	if p1.Equal(mnt4298.HashToG1(input, []byte("QUUX-V01-CS02-with-other"))) {
+		t.Fatal("expected different points for different DSTs")
+	}
+
+	if !new(mnt4298.G1).Add(p1, p1).IsInSubgroup() {
+		t.Fatal("expected the sum to be in the subgroup")
+	}
+
+	// The points of the twists are not in G2 before clearing the cofactor.
+	u := [2]*big.Int{big.NewInt(1), big.NewInt(2)}
+	if mnt4298.MapToG2(u).IsInSubgroup() {
+		t.Fatal("unexpected point in G2 before clearing the cofactor")
+	}
+
+	v := [3]*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(3)}
+	if mnt6298.MapToG2(v).IsInSubgroup() {
+		t.Fatal("unexpected point in G2 before clearing the cofactor")
+	}
+
+	// G1 has a prime order.
+	if !mnt6298.MapToG1(big.NewInt(7)).IsInSubgroup() {
+		t.Fatal("expected a point in G1")
+	}
+
+	p2 := mnt6298.EncodeToG2(input, dst)
+	p2.Y[0].Add(&p2.Y[0], big.NewInt(1))
+
+	if p2.IsInSubgroup() {
+		t.Fatal("unexpected point off the twist in G2")
+	}
+
+	sum := new(mnt6298.G2).Add(mnt6298.HashToG2(input, dst), mnt6298.EncodeToG2(input, dst))
+	if !sum.IsInSubgroup() || sum.IsInfinity() {
+		t.Fatal("expected the sum to be in the subgroup")
+	}
+
+	// The order of MNT6-298 is the prime of the base field of MNT4-298.
+	p4, _ := new(big.Int).SetString(
+		"475922286169261325753349249653048451545124879242694725395555128576210262817955800483758081", 10)
+	if s := mnt6298.HashToScalar(input, dst); s.Cmp(p4) >= 0 {
+		t.Fatal("expected a scalar reduced modulo the order")
+	}
+
+	expectPanic(hash2curve.ErrNonCanonical, func() { mnt4298.MapToG1(p4) })
+	expectPanic(hash2curve.ErrNonCanonical, func() { mnt4298.MapToG2([2]*big.Int{big.NewInt(0), p4}) })
+	expectPanic(hash2curve.ErrNonCanonical, func() { mnt6298.MapToG2([3]*big.Int{big.NewInt(0), nil, nil}) })
+}
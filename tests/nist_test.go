@@ -0,0 +1,920 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+package hash2curve_test
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"errors"
+	"math/big"
+	"testing"
+
+	"filippo.io/nistec"
+
+	"github.com/bytemare/hash2curve"
+	"github.com/bytemare/hash2curve/internal/field/fp256"
+	"github.com/bytemare/hash2curve/nist"
+)
+
+func TestNIST_ParallelMapping(t *testing.T) {
+	dst := []byte("QUUX-V01-CS02-with-P256_XMD:SHA-256_SSWU_RO_")
+	inputs := [][]byte{nil, []byte("abc"), []byte("abcdef0123456789")}
+
+	type outputs struct{ p256, p384, p521 []byte }
+
+	hash := func() []outputs {
+		out := make([]outputs, len(inputs))
+		for i, input := range inputs {
+			out[i] = outputs{
+				p256: nist.HashToP256(input, dst).Bytes(),
+				p384: nist.HashToP384(input, dst).Bytes(),
+				p521: nist.HashToP521(input, dst).Bytes(),
+			}
+		}
+
+		return out
+	}
+
+	sequential := hash()
+
+	nist.SetParallelMapping(true)
+	defer nist.SetParallelMapping(false)
+
+	parallel := hash()
+
+	for i := range inputs {
+		if !bytes.Equal(sequential[i].p256, parallel[i].p256) ||
+			!bytes.Equal(sequential[i].p384, parallel[i].p384) ||
+			!bytes.Equal(sequential[i].p521, parallel[i].p521) {
+			t.Fatalf("parallel mapping differs for input %d", i)
+		}
+	}
+}
+
+func TestNIST_Elliptic(t *testing.T) {
+	dst := []byte("QUUX-V01-CS02-with-elliptic")
+	input := []byte("abc")
+
+	for _, test := range []struct {
+		curve      elliptic.Curve
+		hash       func(input, dst []byte) (x, y *big.Int)
+		encode     func(input, dst []byte) (x, y *big.Int)
+		compressed func() ([]byte, []byte)
+		name       string
+	}{
+		{
+			name:   "P256",
+			curve:  elliptic.P256(),
+			hash:   nist.HashToP256Elliptic,
+			encode: nist.EncodeToP256Elliptic,
+			compressed: func() ([]byte, []byte) {
+				return nist.HashToP256(input, dst).BytesCompressed(), nist.EncodeToP256(input, dst).BytesCompressed()
+			},
+		},
+		{
+			name:   "P384",
+			curve:  elliptic.P384(),
+			hash:   nist.HashToP384Elliptic,
+			encode: nist.EncodeToP384Elliptic,
+			compressed: func() ([]byte, []byte) {
+				return nist.HashToP384(input, dst).BytesCompressed(), nist.EncodeToP384(input, dst).BytesCompressed()
+			},
+		},
+		{
+			name:   "P521",
+			curve:  elliptic.P521(),
+			hash:   nist.HashToP521Elliptic,
+			encode: nist.EncodeToP521Elliptic,
+			compressed: func() ([]byte, []byte) {
+				return nist.HashToP521(input, dst).BytesCompressed(), nist.EncodeToP521(input, dst).BytesCompressed()
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			hx, hy := test.hash(input, dst)
+			ex, ey := test.encode(input, dst)
+			hc, ec := test.compressed()
+
+			if !test.curve.IsOnCurve(hx, hy) || !test.curve.IsOnCurve(ex, ey) {
+				t.Fatal("point is not on the curve")
+			}
+
+			if !bytes.Equal(nist.MarshalCompressed(test.curve, hx, hy), hc) ||
+				!bytes.Equal(nist.MarshalCompressed(test.curve, ex, ey), ec) {
+				t.Fatal("unexpected compressed encoding")
+			}
+		})
+	}
+
+	if !bytes.Equal(nist.MarshalCompressed(elliptic.P256(), new(big.Int), new(big.Int)), []byte{0}) {
+		t.Fatal("unexpected encoding of the point at infinity")
+	}
+}
+
+func TestNIST_PublicKey(t *testing.T) {
+	dst := []byte("QUUX-V01-CS02-with-public-key")
+	input := []byte("abc")
+
+	for _, test := range []struct {
+		curve    elliptic.Curve
+		hash     func(input, dst []byte) (*ecdsa.PublicKey, error)
+		encode   func(input, dst []byte) (*ecdsa.PublicKey, error)
+		hashXY   func(input, dst []byte) (x, y *big.Int)
+		encodeXY func(input, dst []byte) (x, y *big.Int)
+		name     string
+	}{
+		{
+			name:     "P256",
+			curve:    elliptic.P256(),
+			hash:     nist.HashToP256PublicKey,
+			encode:   nist.EncodeToP256PublicKey,
+			hashXY:   nist.HashToP256Elliptic,
+			encodeXY: nist.EncodeToP256Elliptic,
+		},
+		{
+			name:     "P384",
+			curve:    elliptic.P384(),
+			hash:     nist.HashToP384PublicKey,
+			encode:   nist.EncodeToP384PublicKey,
+			hashXY:   nist.HashToP384Elliptic,
+			encodeXY: nist.EncodeToP384Elliptic,
+		},
+		{
+			name:     "P521",
+			curve:    elliptic.P521(),
+			hash:     nist.HashToP521PublicKey,
+			encode:   nist.EncodeToP521PublicKey,
+			hashXY:   nist.HashToP521Elliptic,
+			encodeXY: nist.EncodeToP521Elliptic,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			for _, f := range []struct {
+				key func(input, dst []byte) (*ecdsa.PublicKey, error)
+				xy  func(input, dst []byte) (x, y *big.Int)
+			}{{test.hash, test.hashXY}, {test.encode, test.encodeXY}} {
+				pub, err := f.key(input, dst)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				x, y := f.xy(input, dst)
+				if pub.Curve != test.curve || pub.X.Cmp(x) != 0 || pub.Y.Cmp(y) != 0 {
+					t.Fatal("unexpected public key")
+				}
+
+				der, err := x509.MarshalPKIXPublicKey(pub)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				parsed, err := x509.ParsePKIXPublicKey(der)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if !pub.Equal(parsed) {
+					t.Fatal("expected the public key to survive a PKIX round-trip")
+				}
+			}
+		})
+	}
+}
+
+func TestNIST_HashToScalarOptions(t *testing.T) {
+	dst := []byte("QUUX-V01-CS02-with-hash-to-scalar")
+	input := []byte("abc")
+
+	for _, test := range []struct {
+		hashToScalar func(input, dst []byte, opts ...nist.ScalarOption) *big.Int
+		order        string
+		name         string
+		hash         crypto.Hash
+		secLength    uint
+	}{
+		{
+			name:         "P256",
+			hashToScalar: nist.HashToScalarP256,
+			order:        "ffffffff00000000ffffffffffffffffbce6faada7179e84f3b9cac2fc632551",
+			hash:         crypto.SHA256,
+			secLength:    48,
+		},
+		{
+			name:         "P384",
+			hashToScalar: nist.HashToScalarP384,
+			order: "ffffffffffffffffffffffffffffffffffffffffffffffffc7634d81f4372ddf" +
+				"581a0db248b0a77aecec196accc52973",
+			hash:      crypto.SHA384,
+			secLength: 72,
+		},
+		{
+			name:         "P521",
+			hashToScalar: nist.HashToScalarP521,
+			order: "01ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff" +
+				"fa51868783bf2f966b7fcc0148f709a5d03bb5c9b8899c47aebb6fb71e91386409",
+			hash:      crypto.SHA512,
+			secLength: 98,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			order, _ := new(big.Int).SetString(test.order, 16)
+
+			expected := hash2curve.HashToFieldXMD(test.hash, input, dst, 1, 1, test.secLength, order)[0]
+			if test.hashToScalar(input, dst).Cmp(expected) != 0 {
+				t.Fatal("unexpected default hash-to-scalar")
+			}
+
+			expected = hash2curve.HashToFieldXMD(crypto.SHA512, input, dst, 1, 1, 128, order)[0]
+			s := test.hashToScalar(input, dst, nist.WithHash(crypto.SHA512), nist.WithSecurityLength(128))
+
+			if s.Cmp(expected) != 0 {
+				t.Fatal("unexpected hash-to-scalar with overrides")
+			}
+		})
+	}
+}
+
+// TestNIST_HashToScalarKAT pins the outputs of HashToScalar, computed independently as
+// OS2IP(expand_message_xmd(msg, DST, L)) mod n with hashlib in Python 3, after the fix of the P-384 and P-521 group
+// orders overwriting the one of P-256. P-384 and P-521 are hashed first, so that the P-256 outputs would catch it.
+func TestNIST_HashToScalarKAT(t *testing.T) {
+	for _, test := range []struct {
+		hashToScalar func(input, dst []byte, opts ...nist.ScalarOption) *big.Int
+		dst          string
+		empty, abc   string
+	}{
+		{
+			hashToScalar: nist.HashToScalarP384,
+			dst:          "QUUX-V01-CS02-with-P384_XMD:SHA-384_SSWU_RO_",
+			empty: "541a0092c6d40626c0890f9d64e9d6a46b498b9f2aa821b1f06d8799a7e66e22" +
+				"b99becdf653e64ef9ecb12ecff21bed0",
+			abc: "fc34f24a4fb2f7bc762e2569901db79e27799e6b4070a1ca64e9792a8e47f0c1" +
+				"f26b312d07f263fc60cfd2385fb06385",
+		},
+		{
+			hashToScalar: nist.HashToScalarP521,
+			dst:          "QUUX-V01-CS02-with-P521_XMD:SHA-512_SSWU_RO_",
+			empty: "18b92b27243757f222f39a9733a08ff6c77f3794b33912faa9958e5093b87dd4" +
+				"c60f024dc259bbf4a6219b51d8e2d0c2628dd818785872c496d2cfac1dfaef2f21",
+			abc: "125185d593a8cdef7196f3b3d77d0dacd4485140a55aae6ca4573c27e2ee9995" +
+				"910a57fd10d6b2d5090d1de9c578fb47b6e797125336b99e8f05dc8866a459fc8d8",
+		},
+		{
+			hashToScalar: nist.HashToScalarP256,
+			dst:          "QUUX-V01-CS02-with-P256_XMD:SHA-256_SSWU_RO_",
+			empty:        "600e9f806e6766d4e33183869e7a68cdd9ad77f81aeb564afc810c20108afa27",
+			abc:          "fc85b6dac2e8be7343454b82c1bd5dad62cf42331f3fa060ff7407d79e15be6b",
+		},
+	} {
+		for input, expected := range map[string]string{"": test.empty, "abc": test.abc} {
+			if s := test.hashToScalar([]byte(input), []byte(test.dst)).Text(16); s != expected {
+				t.Fatalf("%s: unexpected scalar for %q: expected %s, got %s", test.dst, input, expected, s)
+			}
+		}
+	}
+}
+
+func TestNIST_HashToScalarBytes(t *testing.T) {
+	dst := []byte("QUUX-V01-CS02-with-scalar-bytes")
+
+	for _, input := range [][]byte{nil, []byte("abc"), []byte("abcdef0123456789")} {
+		s256 := nist.HashToScalarP256Bytes(input, dst)
+		if !bytes.Equal(s256[:], nist.HashToScalarP256(input, dst).FillBytes(make([]byte, 32))) {
+			t.Fatal("unexpected P-256 scalar")
+		}
+
+		if _, err := ecdh.P256().NewPrivateKey(s256[:]); err != nil {
+			t.Fatal(err)
+		}
+
+		opt := nist.WithHash(crypto.SHA512)
+		s384 := nist.HashToScalarP384Bytes(input, dst, opt)
+
+		if !bytes.Equal(s384[:], nist.HashToScalarP384(input, dst, opt).FillBytes(make([]byte, 48))) {
+			t.Fatal("unexpected P-384 scalar")
+		}
+
+		if _, err := ecdh.P384().NewPrivateKey(s384[:]); err != nil {
+			t.Fatal(err)
+		}
+
+		s521 := nist.HashToScalarP521Bytes(input, dst)
+		if !bytes.Equal(s521[:], nist.HashToScalarP521(input, dst).FillBytes(make([]byte, 66))) {
+			t.Fatal("unexpected P-521 scalar")
+		}
+
+		if _, err := ecdh.P521().NewPrivateKey(s521[:]); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func p256CurveParams() nist.CurveParams {
+	params := elliptic.P256().Params()
+
+	return nist.CurveParams{
+		Prime:          params.P,
+		B:              params.B,
+		Order:          params.N,
+		Z:              -10,
+		Hash:           crypto.SHA256,
+		SecurityLength: 48,
+	}
+}
+
+func TestNIST_Curve(t *testing.T) {
+	dst := []byte("QUUX-V01-CS02-with-P256_XMD:SHA-256_SSWU_RO_")
+
+	c, err := nist.NewCurve(p256CurveParams(), nistec.NewP256Point)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, input := range [][]byte{nil, []byte("abc"), []byte("abcdef0123456789")} {
+		if !bytes.Equal(c.HashToCurve(input, dst).Bytes(), nist.HashToP256(input, dst).Bytes()) {
+			t.Fatal("hash-to-curve mismatch")
+		}
+
+		if !bytes.Equal(c.EncodeToCurve(input, dst).Bytes(), nist.EncodeToP256(input, dst).Bytes()) {
+			t.Fatal("encode-to-curve mismatch")
+		}
+
+		if c.HashToScalar(input, dst).Cmp(nist.HashToScalarP256(input, dst)) != 0 {
+			t.Fatal("hash-to-scalar mismatch")
+		}
+	}
+
+	u := big.NewInt(42)
+	if !bytes.Equal(c.MapToCurve(u).Bytes(), nist.MapToCurveP256(u).Bytes()) {
+		t.Fatal("map-to-curve mismatch")
+	}
+
+	// An explicit A = -3 is equivalent to the default.
+	params := p256CurveParams()
+	params.A = big.NewInt(-3)
+
+	explicit, err := nist.NewCurve(params, nistec.NewP256Point)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(explicit.HashToCurve(nil, dst).Bytes(), c.HashToCurve(nil, dst).Bytes()) {
+		t.Fatal("explicit A mismatch")
+	}
+
+	// The limb arithmetic doesn't change the outputs.
+	params = p256CurveParams()
+	params.LimbArithmetic = true
+
+	limbs, err := nist.NewCurve(params, nistec.NewP256Point)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, input := range [][]byte{nil, []byte("abc")} {
+		if !bytes.Equal(limbs.HashToCurve(input, dst).Bytes(), c.HashToCurve(input, dst).Bytes()) ||
+			!bytes.Equal(limbs.EncodeToCurve(input, dst).Bytes(), c.EncodeToCurve(input, dst).Bytes()) {
+			t.Fatal("limb arithmetic mismatch")
+		}
+	}
+}
+
+func TestNIST_CurveWithField(t *testing.T) {
+	dst := []byte("QUUX-V01-CS02-with-P256_XMD:SHA-256_SSWU_RO_")
+
+	c, err := nist.NewCurveWithField[*nistec.P256Point, fp256.Element](p256CurveParams(), nistec.NewP256Point)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, input := range [][]byte{nil, []byte("abc"), []byte("abcdef0123456789")} {
+		if !bytes.Equal(c.HashToCurve(input, dst).Bytes(), nist.HashToP256(input, dst).Bytes()) {
+			t.Fatal("hash-to-curve mismatch")
+		}
+
+		if !bytes.Equal(c.EncodeToCurve(input, dst).Bytes(), nist.EncodeToP256(input, dst).Bytes()) {
+			t.Fatal("encode-to-curve mismatch")
+		}
+	}
+
+	// 0 is the exceptional case of the map.
+	p := fp256.Modulus()
+	for _, u := range []*big.Int{big.NewInt(0), big.NewInt(1), big.NewInt(42), new(big.Int).Sub(p, big.NewInt(1))} {
+		if !bytes.Equal(c.MapToCurve(u).Bytes(), nist.MapToCurveP256(u).Bytes()) {
+			t.Fatalf("map-to-curve mismatch for %s", u)
+		}
+	}
+
+	for range 32 {
+		u, err := rand.Int(rand.Reader, p)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(c.MapToCurve(u).Bytes(), nist.MapToCurveP256(u).Bytes()) {
+			t.Fatalf("map-to-curve mismatch for %s", u)
+		}
+	}
+
+	// The field must have the prime of the curve.
+	p384 := elliptic.P384().Params()
+	params := nist.CurveParams{
+		Prime:          p384.P,
+		B:              p384.B,
+		Order:          p384.N,
+		Z:              -12,
+		Hash:           crypto.SHA384,
+		SecurityLength: 72,
+	}
+
+	_, err = nist.NewCurveWithField[*nistec.P384Point, fp256.Element](params, nistec.NewP384Point)
+	if !errors.Is(err, nist.ErrInvalidCurveParams) {
+		t.Fatalf("expected %v, got %v", nist.ErrInvalidCurveParams, err)
+	}
+}
+
+func TestNIST_CurveInvalidParams(t *testing.T) {
+	for name, mutate := range map[string]func(p *nist.CurveParams){
+		"nil prime":      func(p *nist.CurveParams) { p.Prime = nil },
+		"prime 1 mod 4":  func(p *nist.CurveParams) { p.Prime = big.NewInt(13) },
+		"zero A":         func(p *nist.CurveParams) { p.A = big.NewInt(0) },
+		"zero B":         func(p *nist.CurveParams) { p.B = big.NewInt(0) },
+		"nil order":      func(p *nist.CurveParams) { p.Order = nil },
+		"zero Z":         func(p *nist.CurveParams) { p.Z = 0 },
+		"square Z":       func(p *nist.CurveParams) { p.Z = 4 },
+		"Z = -1":         func(p *nist.CurveParams) { p.Z = -1 },
+		"reducible g-Z":  func(p *nist.CurveParams) { p.Z = -2 },
+		"non-square g":   func(p *nist.CurveParams) { p.Z = -7 },
+		"no hash":        func(p *nist.CurveParams) { p.Hash = 0 },
+		"short security": func(p *nist.CurveParams) { p.SecurityLength = 32 },
+	} {
+		params := p256CurveParams()
+		mutate(&params)
+
+		_, err := nist.NewCurve(params, nistec.NewP256Point)
+		if !errors.Is(err, nist.ErrInvalidCurveParams) && !errors.Is(err, hash2curve.ErrInvalidParameters) {
+			t.Fatalf("%s: unexpected error %v", name, err)
+		}
+	}
+}
+
+// TestNIST_CurveZ checks that the Z of RFC 9380 for the NIST curves are the first accepted by NewCurve in the order of
+// find_z_sswu, i.e. 1, -1, 2, -2, and so on.
+func TestNIST_CurveZ(t *testing.T) {
+	p384, p521 := elliptic.P384().Params(), elliptic.P521().Params()
+
+	for _, test := range []struct {
+		params nist.CurveParams
+		z      int
+	}{
+		{p256CurveParams(), -10},
+		{nist.CurveParams{
+			Prime: p384.P, B: p384.B, Order: p384.N, Hash: crypto.SHA384, SecurityLength: 72,
+		}, -12},
+		{nist.CurveParams{
+			Prime: p521.P, B: p521.B, Order: p521.N, Hash: crypto.SHA512, SecurityLength: 98,
+		}, -4},
+	} {
+		accepted := 0
+
+		// The parameters are validated independently of the points.
+	search:
+		for i := 1; i <= 16; i++ {
+			for _, z := range []int{i, -i} {
+				test.params.Z = z
+
+				_, err := nist.NewCurve(test.params, nistec.NewP256Point)
+				if err == nil {
+					accepted = z
+					break search
+				}
+
+				if !errors.Is(err, nist.ErrInvalidCurveParams) {
+					t.Fatal(err)
+				}
+			}
+		}
+
+		if accepted != test.z {
+			t.Fatalf("expected Z = %d, got %d", test.z, accepted)
+		}
+	}
+}
+
+func TestNIST_Try(t *testing.T) {
+	dst := []byte("QUUX-V01-CS02-with-P256_XMD:SHA-256_SSWU_RO_")
+	input := []byte("abc")
+
+	for name, f := range map[string]func(input, dst []byte) ([]byte, []byte, error){
+		"P256 RO": func(input, dst []byte) ([]byte, []byte, error) {
+			p, err := nist.TryHashToP256(input, dst)
+			return pointBytes(p, err), nist.HashToP256(input, dst).Bytes(), err
+		},
+		"P256 NU": func(input, dst []byte) ([]byte, []byte, error) {
+			p, err := nist.TryEncodeToP256(input, dst)
+			return pointBytes(p, err), nist.EncodeToP256(input, dst).Bytes(), err
+		},
+		"P384 RO": func(input, dst []byte) ([]byte, []byte, error) {
+			p, err := nist.TryHashToP384(input, dst)
+			return pointBytes(p, err), nist.HashToP384(input, dst).Bytes(), err
+		},
+		"P384 NU": func(input, dst []byte) ([]byte, []byte, error) {
+			p, err := nist.TryEncodeToP384(input, dst)
+			return pointBytes(p, err), nist.EncodeToP384(input, dst).Bytes(), err
+		},
+		"P521 RO": func(input, dst []byte) ([]byte, []byte, error) {
+			p, err := nist.TryHashToP521(input, dst)
+			return pointBytes(p, err), nist.HashToP521(input, dst).Bytes(), err
+		},
+		"P521 NU": func(input, dst []byte) ([]byte, []byte, error) {
+			p, err := nist.TryEncodeToP521(input, dst)
+			return pointBytes(p, err), nist.EncodeToP521(input, dst).Bytes(), err
+		},
+	} {
+		got, want, err := f(input, dst)
+		if err != nil {
+			t.Fatalf("%s: unexpected error %v", name, err)
+		}
+
+		if !bytes.Equal(got, want) {
+			t.Fatalf("%s: unexpected point", name)
+		}
+	}
+
+	if _, err := nist.TryHashToP256(input, nil); !errors.Is(err, hash2curve.ErrZeroLengthDST) {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if _, err := nist.TryEncodeToP384(input, nil); !errors.Is(err, hash2curve.ErrZeroLengthDST) {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	hash2curve.SetInputLimits(2, 0)
+	_, err := nist.TryHashToP521(input, dst)
+	hash2curve.SetInputLimits(0, 0)
+
+	if !errors.Is(err, hash2curve.ErrInputTooLong) {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	u := big.NewInt(42)
+	if p, err := nist.TryMapToCurveP256(u); err != nil || !bytes.Equal(p.Bytes(), nist.MapToCurveP256(u).Bytes()) {
+		t.Fatal("unexpected map-to-curve")
+	}
+
+	if _, err := nist.TryMapToCurveP521(elliptic.P521().Params().P); !errors.Is(err, hash2curve.ErrNonCanonical) {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
+
+func TestNIST_TryMismatchedCurve(t *testing.T) {
+	dst := []byte("QUUX-V01-CS02-with-P256_XMD:SHA-256_SSWU_RO_")
+
+	// Points mapped on a curve with another B, or over a larger field, are rejected by the P-256 implementation.
+	// Z = -10 meets the criteria of find_z_sswu for B + 5.
+	wrongB := p256CurveParams()
+	wrongB.B = new(big.Int).Add(wrongB.B, big.NewInt(5))
+
+	p384 := elliptic.P384().Params()
+	wrongField := nist.CurveParams{
+		Prime:          p384.P,
+		B:              p384.B,
+		Order:          p384.N,
+		Z:              -12,
+		Hash:           crypto.SHA384,
+		SecurityLength: 72,
+	}
+
+	for name, params := range map[string]nist.CurveParams{"B": wrongB, "field": wrongField} {
+		c, err := nist.NewCurve(params, nistec.NewP256Point)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err = c.TryHashToCurve(nil, dst); !errors.Is(err, hash2curve.ErrInvalidPoint) {
+			t.Fatalf("%s: unexpected error %v", name, err)
+		}
+
+		if _, err = c.TryEncodeToCurve(nil, dst); !errors.Is(err, hash2curve.ErrInvalidPoint) {
+			t.Fatalf("%s: unexpected error %v", name, err)
+		}
+
+		if _, err = c.TryMapToCurve(big.NewInt(42)); !errors.Is(err, hash2curve.ErrInvalidPoint) {
+			t.Fatalf("%s: unexpected error %v", name, err)
+		}
+
+		if panicked, _ := hasPanic(func() { c.HashToCurve(nil, dst) }); !panicked {
+			t.Fatalf("%s: expected a panic", name)
+		}
+	}
+}
+
+func pointBytes(p interface{ Bytes() []byte }, err error) []byte {
+	if err != nil {
+		return nil
+	}
+
+	return p.Bytes()
+}
+
+func TestNIST_Encodings(t *testing.T) {
+	dst := []byte("QUUX-V01-CS02-with-P256_XMD:SHA-256_SSWU_RO_")
+	p := nist.HashToP384([]byte("abc"), dst)
+	e := nist.Encode(p)
+
+	if e.Identity || nist.IsIdentity(p) {
+		t.Fatal("unexpected identity")
+	}
+
+	if !bytes.Equal(e.Compressed, p.BytesCompressed()) || !bytes.Equal(e.Uncompressed, p.Bytes()) {
+		t.Fatal("unexpected encodings")
+	}
+
+	x, y := nist.HashToP384Elliptic([]byte("abc"), dst)
+	if len(e.X) != 48 || len(e.Y) != 48 || new(big.Int).SetBytes(e.X).Cmp(x) != 0 ||
+		new(big.Int).SetBytes(e.Y).Cmp(y) != 0 {
+		t.Fatal("unexpected coordinates")
+	}
+
+	identity := nistec.NewP256Point()
+	e256 := nist.Encode(identity)
+
+	if !e256.Identity || !nist.IsIdentity(identity) || e256.X != nil || e256.Y != nil ||
+		!bytes.Equal(e256.Compressed, []byte{0}) || !bytes.Equal(e256.Uncompressed, []byte{0}) {
+		t.Fatal("unexpected identity encodings")
+	}
+}
+
+func TestNIST_DeriveECDSAPrivateKey(t *testing.T) {
+	dst := []byte("QUUX-V01-CS02-with-ecdsa-key-derivation")
+	seed := []byte("seed")
+
+	for _, test := range []struct {
+		curve  elliptic.Curve
+		derive func(input, dst []byte) (*ecdsa.PrivateKey, error)
+		scalar func(input, dst []byte, opts ...nist.ScalarOption) *big.Int
+	}{
+		{elliptic.P256(), nist.DeriveECDSAPrivateKeyP256, nist.HashToScalarP256},
+		{elliptic.P384(), nist.DeriveECDSAPrivateKeyP384, nist.HashToScalarP384},
+		{elliptic.P521(), nist.DeriveECDSAPrivateKeyP521, nist.HashToScalarP521},
+	} {
+		key, err := test.derive(seed, dst)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if key.D.Cmp(test.scalar(seed, dst)) != 0 {
+			t.Fatal("unexpected private scalar")
+		}
+
+		again, _ := test.derive(seed, dst)
+		if !key.Equal(again) {
+			t.Fatal("expected a deterministic derivation")
+		}
+
+		if _, err = key.ECDH(); err != nil {
+			t.Fatalf("invalid key: %v", err)
+		}
+
+		digest := sha256.Sum256([]byte("message"))
+
+		sig, err := ecdsa.SignASN1(rand.Reader, key, digest[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !ecdsa.VerifyASN1(&key.PublicKey, digest[:], sig) {
+			t.Fatal("signature verification failed")
+		}
+	}
+}
+
+// jacobianBytes returns the SEC 1 uncompressed encoding of the affine point of p, over the field of prime.
+func jacobianBytes(p *nist.JacobianPoint, prime *big.Int) []byte {
+	if p.IsIdentity() {
+		return []byte{0}
+	}
+
+	byteLen := (prime.BitLen() + 7) / 8
+	zInv := new(big.Int).ModInverse(&p.Z, prime)
+	zInv2 := new(big.Int).Mul(zInv, zInv)
+	x := new(big.Int).Mul(&p.X, zInv2)
+	y := new(big.Int).Mul(&p.Y, zInv2.Mul(zInv2, zInv))
+
+	out := make([]byte, 1+2*byteLen)
+	out[0] = 4
+	x.Mod(x, prime).FillBytes(out[1 : 1+byteLen])
+	y.Mod(y, prime).FillBytes(out[1+byteLen:])
+
+	return out
+}
+
+func TestNIST_Jacobian(t *testing.T) {
+	dst := []byte("QUUX-V01-CS02-with-P256_XMD:SHA-256_SSWU_RO_")
+
+	c, err := nist.NewCurve(p256CurveParams(), nistec.NewP256Point)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	check := func(t *testing.T) {
+		for _, input := range [][]byte{nil, []byte("abc"), []byte("abcdef0123456789")} {
+			for _, test := range []struct {
+				name          string
+				expected, got []byte
+			}{
+				{
+					"HashToP256",
+					nist.HashToP256(input, dst).Bytes(),
+					jacobianBytes(nist.HashToP256Jacobian(input, dst), nist.FieldPrimeP256()),
+				},
+				{
+					"EncodeToP256",
+					nist.EncodeToP256(input, dst).Bytes(),
+					jacobianBytes(nist.EncodeToP256Jacobian(input, dst), nist.FieldPrimeP256()),
+				},
+				{
+					"HashToP384",
+					nist.HashToP384(input, dst).Bytes(),
+					jacobianBytes(nist.HashToP384Jacobian(input, dst), nist.FieldPrimeP384()),
+				},
+				{
+					"EncodeToP384",
+					nist.EncodeToP384(input, dst).Bytes(),
+					jacobianBytes(nist.EncodeToP384Jacobian(input, dst), nist.FieldPrimeP384()),
+				},
+				{
+					"HashToP521",
+					nist.HashToP521(input, dst).Bytes(),
+					jacobianBytes(nist.HashToP521Jacobian(input, dst), nist.FieldPrimeP521()),
+				},
+				{
+					"EncodeToP521",
+					nist.EncodeToP521(input, dst).Bytes(),
+					jacobianBytes(nist.EncodeToP521Jacobian(input, dst), nist.FieldPrimeP521()),
+				},
+				{
+					"Curve.HashToCurve",
+					c.HashToCurve(input, dst).Bytes(),
+					jacobianBytes(c.HashToCurveJacobian(input, dst), c.FieldPrime()),
+				},
+				{
+					"Curve.EncodeToCurve",
+					c.EncodeToCurve(input, dst).Bytes(),
+					jacobianBytes(c.EncodeToCurveJacobian(input, dst), c.FieldPrime()),
+				},
+			} {
+				if !bytes.Equal(test.expected, test.got) {
+					t.Fatalf("%s: unexpected Jacobian point for input %q", test.name, input)
+				}
+			}
+		}
+	}
+
+	t.Run("sequential", check)
+
+	nist.SetParallelMapping(true)
+	defer nist.SetParallelMapping(false)
+
+	t.Run("parallel", check)
+}
+
+func TestNIST_Batch(t *testing.T) {
+	dst := []byte("QUUX-V01-CS02-with-P256_XMD:SHA-256_SSWU_RO_")
+	inputs := [][]byte{nil, []byte("abc"), []byte("abcdef0123456789"), []byte("abc")}
+
+	for _, test := range []struct {
+		name   string
+		batch  [][]byte
+		single func(input, dst []byte) []byte
+	}{
+		{
+			"HashToP256", batchBytes(nist.HashToP256Batch(inputs, dst)),
+			func(input, dst []byte) []byte { return nist.HashToP256(input, dst).Bytes() },
+		},
+		{
+			"EncodeToP256", batchBytes(nist.EncodeToP256Batch(inputs, dst)),
+			func(input, dst []byte) []byte { return nist.EncodeToP256(input, dst).Bytes() },
+		},
+		{
+			"HashToP384", batchBytes(nist.HashToP384Batch(inputs, dst)),
+			func(input, dst []byte) []byte { return nist.HashToP384(input, dst).Bytes() },
+		},
+		{
+			"EncodeToP384", batchBytes(nist.EncodeToP384Batch(inputs, dst)),
+			func(input, dst []byte) []byte { return nist.EncodeToP384(input, dst).Bytes() },
+		},
+		{
+			"HashToP521", batchBytes(nist.HashToP521Batch(inputs, dst)),
+			func(input, dst []byte) []byte { return nist.HashToP521(input, dst).Bytes() },
+		},
+		{
+			"EncodeToP521", batchBytes(nist.EncodeToP521Batch(inputs, dst)),
+			func(input, dst []byte) []byte { return nist.EncodeToP521(input, dst).Bytes() },
+		},
+	} {
+		for i, input := range inputs {
+			if !bytes.Equal(test.batch[i], test.single(input, dst)) {
+				t.Fatalf("%s: unexpected point for input %d", test.name, i)
+			}
+		}
+	}
+
+	fes := []*big.Int{big.NewInt(0), big.NewInt(1), new(big.Int).Sub(nist.FieldPrimeP256(), big.NewInt(1))}
+	for i, p := range nist.MapToCurveP256Batch(fes) {
+		if !bytes.Equal(p.Bytes(), nist.MapToCurveP256(fes[i]).Bytes()) {
+			t.Fatalf("MapToCurveP256Batch: unexpected point for element %d", i)
+		}
+	}
+
+	for i, p := range nist.MapToCurveP384Batch(fes[:2]) {
+		if !bytes.Equal(p.Bytes(), nist.MapToCurveP384(fes[i]).Bytes()) {
+			t.Fatalf("MapToCurveP384Batch: unexpected point for element %d", i)
+		}
+	}
+
+	for i, p := range nist.MapToCurveP521Batch(fes[:2]) {
+		if !bytes.Equal(p.Bytes(), nist.MapToCurveP521(fes[i]).Bytes()) {
+			t.Fatalf("MapToCurveP521Batch: unexpected point for element %d", i)
+		}
+	}
+
+	if len(nist.HashToP256Batch(nil, dst)) != 0 || len(nist.MapToCurveP256Batch(nil)) != 0 {
+		t.Fatal("expected empty batches")
+	}
+
+	if hasPanic, err := expectPanic(hash2curve.ErrNonCanonical, func() {
+		_ = nist.MapToCurveP256Batch([]*big.Int{big.NewInt(1), nist.FieldPrimeP256()})
+	}); !hasPanic {
+		t.Fatalf("expected panic: %v", err)
+	}
+}
+
+// batchBytes returns the SEC 1 uncompressed encodings of the points.
+func batchBytes[P interface{ Bytes() []byte }](points []P) [][]byte {
+	out := make([][]byte, len(points))
+	for i, p := range points {
+		out[i] = p.Bytes()
+	}
+
+	return out
+}
+
+func TestNIST_StrictSecurity(t *testing.T) {
+	dst := []byte("QUUX-V01-CS02-with-P521_XMD:SHA-512_SSWU_RO_")
+	weak := []nist.ScalarOption{nist.WithHash(crypto.SHA256)}
+
+	if nist.HashToScalarP521([]byte("abc"), dst, weak...) == nil {
+		t.Fatal("expected a scalar")
+	}
+
+	params := p256CurveParams()
+	params.SecurityLevel = 192
+
+	if _, err := nist.NewCurve(params, nistec.NewP256Point); !errors.Is(err, hash2curve.ErrInsufficientSecurity) {
+		t.Fatalf("want %v, got %v", hash2curve.ErrInsufficientSecurity, err)
+	}
+
+	params.Hash, params.SecurityLength = crypto.SHA384, 56
+	if _, err := nist.NewCurve(params, nistec.NewP256Point); err != nil {
+		t.Fatal(err)
+	}
+
+	hash2curve.SetStrictSecurity(true)
+	defer hash2curve.SetStrictSecurity(false)
+
+	for _, f := range []func(){
+		func() { nist.HashToScalarP521([]byte("abc"), dst, weak...) },
+		func() { nist.HashToScalarP384([]byte("abc"), dst, nist.WithSecurityLength(64)) },
+	} {
+		if err := panicError(f); !errors.Is(err, hash2curve.ErrInsufficientSecurity) {
+			t.Fatalf("want %v, got %v", hash2curve.ErrInsufficientSecurity, err)
+		}
+	}
+
+	if nist.HashToScalarP521([]byte("abc"), dst) == nil || nist.HashToScalarP256([]byte("abc"), dst,
+		nist.WithHash(crypto.SHA512), nist.WithSecurityLength(64)) == nil {
+		t.Fatal("expected a scalar")
+	}
+
+	params = p256CurveParams()
+	params.Hash = crypto.SHA224
+
+	if _, err := nist.NewCurve(params, nistec.NewP256Point); !errors.Is(err, hash2curve.ErrInsufficientSecurity) {
+		t.Fatalf("want %v, got %v", hash2curve.ErrInsufficientSecurity, err)
+	}
+}
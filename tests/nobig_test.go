@@ -0,0 +1,109 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+package hash2curve_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/bytemare/hash2curve"
+	"github.com/bytemare/hash2curve/nobig"
+	"github.com/bytemare/hash2curve/suite"
+)
+
+func testNobigSuite(t *testing.T, id hash2curve.Suite) {
+	reference, err := suite.For(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := nobig.New(id.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if s.ID() != id.String() {
+		t.Fatalf("unexpected ID %q", s.ID())
+	}
+
+	for i := range 16 {
+		input := bytes.Repeat([]byte{byte(i)}, i*7)
+		dst := []byte(fmt.Sprintf("nobig test DST %d", i))
+
+		point, err := s.Hash(input, dst)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(point, reference.Hash(input, dst)) {
+			t.Fatalf("%d: the point differs from the suite package", i)
+		}
+
+		scalar, err := s.HashToScalar(input, dst)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(scalar, reference.HashToScalar(input, dst)) {
+			t.Fatalf("%d: the scalar differs from the suite package", i)
+		}
+	}
+}
+
+func TestNobig_Suites(t *testing.T) {
+	for _, blinding := range []bool{false, true} {
+		hash2curve.SetBlinding(blinding)
+
+		for _, id := range hash2curve.AllSuites() {
+			t.Run(fmt.Sprintf("%s/blinding=%v", id, blinding), func(t *testing.T) {
+				testNobigSuite(t, id)
+			})
+		}
+	}
+
+	hash2curve.SetBlinding(false)
+}
+
+func TestNobig_Errors(t *testing.T) {
+	for _, id := range []string{
+		"", "P256_XMD:SHA-256_SSWU_", "P256_XMD:SHA-256_SSWU_XX_", "P224_XMD:SHA-256_SSWU_RO_",
+	} {
+		if _, err := nobig.New(id); !errors.Is(err, hash2curve.ErrInvalidSuite) {
+			t.Fatalf("%q: expected ErrInvalidSuite, got %v", id, err)
+		}
+	}
+
+	s, err := nobig.New(hash2curve.P256SHA256SSWURO.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = s.Hash(suiteInput, nil); !errors.Is(err, hash2curve.ErrZeroLengthDST) {
+		t.Fatalf("expected ErrZeroLengthDST, got %v", err)
+	}
+
+	if _, err = s.HashToScalar(suiteInput, []byte{}); !errors.Is(err, nobig.ErrZeroLengthDST) {
+		t.Fatalf("expected ErrZeroLengthDST, got %v", err)
+	}
+
+	hash2curve.SetInputLimits(4, 0)
+	defer hash2curve.SetInputLimits(0, 0)
+
+	if _, err = s.Hash(suiteInput, suiteDST); !errors.Is(err, nobig.ErrInputTooLong) {
+		t.Fatalf("expected ErrInputTooLong, got %v", err)
+	}
+
+	if _, err = s.HashToScalar(suiteInput, suiteDST); !errors.Is(err, hash2curve.ErrInputTooLong) {
+		t.Fatalf("expected ErrInputTooLong, got %v", err)
+	}
+}
@@ -0,0 +1,135 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package hash2curve_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/bytemare/hash2curve/nobig"
+)
+
+// nobigVectors holds the fields of the RFC 9380 vector files used by the nobig tests, which also run with the
+// nomathbig build tag and thus can't use the math/big based vector tests.
+type nobigVectors struct {
+	Ciphersuite string `json:"ciphersuite"`
+	Curve       string `json:"curve"`
+	Dst         string `json:"dst"`
+	Vectors     []struct {
+		P struct {
+			X string `json:"x"`
+			Y string `json:"y"`
+		} `json:"P"`
+		Msg string `json:"msg"`
+	} `json:"vectors"`
+}
+
+// nobigHex decodes the big-endian 0x-prefixed hexadecimal integer to length bytes.
+func nobigHex(t *testing.T, s string, length int) []byte {
+	s = strings.TrimPrefix(s, "0x")
+	s = strings.Repeat("0", 2*length-len(s)) + s
+
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return b
+}
+
+// nobigEncoding returns the encoding of the affine point in the output format of nobig.
+func nobigEncoding(t *testing.T, curve, x, y string) []byte {
+	switch curve {
+	case "edwards25519":
+		b := nobigHex(t, y, 32)
+		slices.Reverse(b)
+		b[31] |= nobigHex(t, x, 32)[31] & 1 << 7
+
+		return b
+	default:
+		length := map[string]int{"NIST P-256": 32, "NIST P-384": 48, "NIST P-521": 66, "secp256k1": 32}[curve]
+		if length == 0 {
+			t.Fatalf("unexpected curve %q", curve)
+		}
+
+		return append([]byte{2 | nobigHex(t, y, length)[length-1]&1}, nobigHex(t, x, length)...)
+	}
+}
+
+func TestNobig_Vectors(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("vectors", "h2c", "*.json"))
+	if err != nil || len(files) == 0 {
+		t.Fatalf("no vector files: %v", err)
+	}
+
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var v nobigVectors
+		if err = json.Unmarshal(data, &v); err != nil {
+			t.Fatal(err)
+		}
+
+		t.Run(v.Ciphersuite, func(t *testing.T) {
+			s, err := nobig.New(v.Ciphersuite)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, vector := range v.Vectors {
+				p, err := s.Hash([]byte(vector.Msg), []byte(v.Dst))
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if !bytes.Equal(p, nobigEncoding(t, v.Curve, vector.P.X, vector.P.Y)) {
+					t.Fatalf("unexpected point for %q", vector.Msg)
+				}
+			}
+		})
+	}
+}
+
+// TestNobig_Dependencies checks that the nobig package builds with the nomathbig build tag, without math/big or
+// crypto/rand among its dependencies.
+func TestNobig_Dependencies(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs the go command")
+	}
+
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("the go command is not available")
+	}
+
+	if out, err := exec.Command(goBin, "build", "-tags", "nomathbig", "../nobig").CombinedOutput(); err != nil {
+		t.Fatalf("%v\n%s", err, out)
+	}
+
+	out, err := exec.Command(goBin, "list", "-deps", "-tags", "nomathbig", "../nobig").Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, dep := range strings.Fields(string(out)) {
+		if dep == "math/big" || dep == "crypto/rand" {
+			t.Fatalf("nobig depends on %s with the nomathbig build tag", dep)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package hash2curve_test
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/bytemare/hash2curve/internal"
+	"github.com/bytemare/hash2curve/octet"
+)
+
+func TestOctet_MatchesInternalI2OSP(t *testing.T) {
+	for _, v := range I2OSPVectors {
+		b, err := octet.I2OSPUint64(uint64(v.value), int(v.size))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(b, internal.I2OSP(v.value, v.size)) {
+			t.Fatalf("I2OSPUint64(%d, %d): unexpected encoding %x", v.value, v.size, b)
+		}
+
+		bb, err := octet.I2OSP(new(big.Int).SetUint64(uint64(v.value)), int(v.size))
+		if err != nil || !bytes.Equal(b, bb) {
+			t.Fatalf("I2OSP(%d, %d): unexpected encoding %x (%v)", v.value, v.size, bb, err)
+		}
+	}
+}
+
+func TestOctet_RoundTrip(t *testing.T) {
+	for _, length := range []int{1, 2, 7, 8, 9, 16, 66} {
+		x := uint64(math.MaxUint64)
+		if length < 8 {
+			x = 1<<(8*length) - 1
+		}
+
+		b, err := octet.I2OSPUint64(x, length)
+		if err != nil || len(b) != length {
+			t.Fatalf("length %d: %v", length, err)
+		}
+
+		y, err := octet.OS2IPUint64(b)
+		if err != nil || y != x {
+			t.Fatalf("length %d: want %d, got %d (%v)", length, x, y, err)
+		}
+
+		if octet.OS2IP(b).Uint64() != x {
+			t.Fatalf("length %d: OS2IP mismatch", length)
+		}
+	}
+
+	big521 := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 521), big.NewInt(1))
+
+	b, err := octet.I2OSP(big521, 66)
+	if err != nil || octet.OS2IP(b).Cmp(big521) != 0 {
+		t.Fatalf("unexpected 66-byte round trip (%v)", err)
+	}
+}
+
+func TestOctet_Errors(t *testing.T) {
+	for _, test := range []struct {
+		f   func() error
+		err error
+	}{
+		{func() error { _, err := octet.I2OSPUint64(1, 0); return err }, octet.ErrLengthNonPositive},
+		{func() error { _, err := octet.I2OSPUint64(256, 1); return err }, octet.ErrIntegerTooLarge},
+		{func() error { _, err := octet.I2OSP(big.NewInt(1), -1); return err }, octet.ErrLengthNonPositive},
+		{func() error { _, err := octet.I2OSP(big.NewInt(-1), 1); return err }, octet.ErrIntegerNegative},
+		{func() error { _, err := octet.I2OSP(big.NewInt(1<<16), 2); return err }, octet.ErrIntegerTooLarge},
+		{func() error { _, err := octet.OS2IPUint64(make([]byte, 9)); return err }, nil},
+		{
+			func() error { _, err := octet.OS2IPUint64([]byte{1, 0, 0, 0, 0, 0, 0, 0, 0}); return err },
+			octet.ErrIntegerTooLarge,
+		},
+	} {
+		if err := test.f(); !errors.Is(err, test.err) {
+			t.Fatalf("want %v, got %v", test.err, err)
+		}
+	}
+}
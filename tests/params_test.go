@@ -0,0 +1,150 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+package hash2curve_test
+
+import (
+	"crypto/elliptic"
+	"math/big"
+	"slices"
+	"testing"
+
+	"filippo.io/nistec"
+
+	"github.com/bytemare/hash2curve/bls24315"
+	"github.com/bytemare/hash2curve/bn254"
+	"github.com/bytemare/hash2curve/curve41417"
+	"github.com/bytemare/hash2curve/e521"
+	"github.com/bytemare/hash2curve/edwards25519"
+	"github.com/bytemare/hash2curve/m511"
+	"github.com/bytemare/hash2curve/mnt4298"
+	"github.com/bytemare/hash2curve/mnt6298"
+	"github.com/bytemare/hash2curve/nist"
+	"github.com/bytemare/hash2curve/ristretto255"
+	"github.com/bytemare/hash2curve/secp192r1"
+	"github.com/bytemare/hash2curve/secp224k1"
+	"github.com/bytemare/hash2curve/secp256k1"
+)
+
+type curveParameters struct {
+	order, fieldPrime, cofactor func() *big.Int
+	hashToScalar                func(input, dst []byte) *big.Int
+}
+
+// littleEndianScalar returns the integer of a little-endian scalar encoding.
+func littleEndianScalar(b []byte) *big.Int {
+	b = slices.Clone(b)
+	slices.Reverse(b)
+
+	return new(big.Int).SetBytes(b)
+}
+
+func TestCurveParameters(t *testing.T) {
+	for name, test := range map[string]curveParameters{
+		"P256": {nist.OrderP256, nist.FieldPrimeP256, nist.Cofactor, func(input, dst []byte) *big.Int {
+			return nist.HashToScalarP256(input, dst)
+		}},
+		"P384": {nist.OrderP384, nist.FieldPrimeP384, nist.Cofactor, func(input, dst []byte) *big.Int {
+			return nist.HashToScalarP384(input, dst)
+		}},
+		"P521": {nist.OrderP521, nist.FieldPrimeP521, nist.Cofactor, func(input, dst []byte) *big.Int {
+			return nist.HashToScalarP521(input, dst)
+		}},
+		"edwards25519": {
+			edwards25519.Order, edwards25519.FieldPrime, edwards25519.Cofactor, func(input, dst []byte) *big.Int {
+				return littleEndianScalar(edwards25519.HashToScalar(input, dst).Bytes())
+			},
+		},
+		"ristretto255": {
+			ristretto255.Order, ristretto255.FieldPrime, ristretto255.Cofactor, func(input, dst []byte) *big.Int {
+				return littleEndianScalar(ristretto255.HashToScalar(input, dst).Encode(nil))
+			},
+		},
+		"secp256k1":  {secp256k1.Order, secp256k1.FieldPrime, secp256k1.Cofactor, secp256k1.HashToScalar},
+		"secp192r1":  {secp192r1.Order, secp192r1.FieldPrime, secp192r1.Cofactor, secp192r1.HashToScalar},
+		"secp224k1":  {secp224k1.Order, secp224k1.FieldPrime, secp224k1.Cofactor, secp224k1.HashToScalar},
+		"BN254":      {bn254.Order, bn254.FieldPrime, bn254.Cofactor, bn254.HashToScalar},
+		"Curve41417": {curve41417.Order, curve41417.FieldPrime, curve41417.Cofactor, curve41417.HashToScalar},
+		"E-521":      {e521.Order, e521.FieldPrime, e521.Cofactor, e521.HashToScalar},
+		"M-511":      {m511.Order, m511.FieldPrime, m511.Cofactor, m511.HashToScalar},
+		"MNT4-298":   {mnt4298.Order, mnt4298.FieldPrime, mnt4298.CofactorG2, mnt4298.HashToScalar},
+		"MNT6-298":   {mnt6298.Order, mnt6298.FieldPrime, mnt6298.CofactorG2, mnt6298.HashToScalar},
+		"BLS24-315":  {bls24315.Order, bls24315.FieldPrime, bls24315.CofactorG1, bls24315.HashToScalar},
+	} {
+		t.Run(name, func(t *testing.T) {
+			order, prime := test.order(), test.fieldPrime()
+			if !order.ProbablyPrime(20) || !prime.ProbablyPrime(20) {
+				t.Fatal("expected prime parameters")
+			}
+
+			if test.cofactor().Sign() <= 0 {
+				t.Fatal("expected a positive cofactor")
+			}
+
+			// The accessors return copies.
+			order.SetInt64(0)
+			prime.SetInt64(0)
+
+			if test.order().Sign() == 0 || test.fieldPrime().Sign() == 0 {
+				t.Fatal("the parameters were modified")
+			}
+
+			s := test.hashToScalar([]byte("abc"), []byte("QUUX-V01-CS02-with-parameters"))
+			if s.Cmp(test.order()) >= 0 {
+				t.Fatal("expected a scalar reduced modulo the order")
+			}
+		})
+	}
+}
+
+func TestCurveParameters_Known(t *testing.T) {
+	for _, test := range []struct {
+		curve        elliptic.Curve
+		order, prime *big.Int
+	}{
+		{elliptic.P256(), nist.OrderP256(), nist.FieldPrimeP256()},
+		{elliptic.P384(), nist.OrderP384(), nist.FieldPrimeP384()},
+		{elliptic.P521(), nist.OrderP521(), nist.FieldPrimeP521()},
+	} {
+		if test.order.Cmp(test.curve.Params().N) != 0 || test.prime.Cmp(test.curve.Params().P) != 0 {
+			t.Fatalf("%s: unexpected parameters", test.curve.Params().Name)
+		}
+	}
+
+	c, err := nist.NewCurve(p256CurveParams(), nistec.NewP256Point)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c.Order().Cmp(nist.OrderP256()) != 0 || c.FieldPrime().Cmp(nist.FieldPrimeP256()) != 0 {
+		t.Fatal("unexpected custom curve parameters")
+	}
+
+	// ristretto255 is the prime-order subgroup of edwards25519, of cofactor 8.
+	if ristretto255.Order().Cmp(edwards25519.Order()) != 0 || edwards25519.Cofactor().Cmp(big.NewInt(8)) != 0 {
+		t.Fatal("unexpected edwards25519 parameters")
+	}
+
+	// MNT4-298 and MNT6-298 form a cycle, with the group order of each being the field prime of the other.
+	if mnt4298.Order().Cmp(mnt6298.FieldPrime()) != 0 || mnt6298.Order().Cmp(mnt4298.FieldPrime()) != 0 {
+		t.Fatal("unexpected MNT parameters")
+	}
+
+	// The G1 of MNT curves has prime order.
+	if mnt4298.CofactorG1().Cmp(big.NewInt(1)) != 0 || mnt6298.CofactorG1().Cmp(big.NewInt(1)) != 0 {
+		t.Fatal("unexpected MNT G1 cofactors")
+	}
+
+	// p = h1 * r + x for BLS24-315, with x = -3218079743.
+	p := new(big.Int).Mul(bls24315.CofactorG1(), bls24315.Order())
+	if p.Add(p, big.NewInt(-3218079743)).Cmp(bls24315.FieldPrime()) != 0 {
+		t.Fatal("unexpected BLS24-315 parameters")
+	}
+}
@@ -6,14 +6,19 @@
 // LICENSE file in the root directory of this source tree or at
 // https://spdx.org/licenses/MIT.html
 
+//go:build !nomathbig
+
 package hash2curve_test
 
 import (
 	"bytes"
+	"crypto/rand"
 	"encoding/hex"
 	"fmt"
 	"testing"
 
+	ristretto "github.com/gtank/ristretto255"
+
 	"github.com/bytemare/hash2curve/ristretto255"
 )
 
@@ -82,8 +87,21 @@ func TestRistretto_HashToGroup(t *testing.T) {
 	}
 }
 
+var ristrettoE2gTests = []ristrettoH2gTest{
+	{
+		input:          "68656c6c6f",
+		dst:            "564f50524630362d48617368546f47726f75702d000001",
+		encodedElement: "b8760bc677a5ed323d8061a60e93e841b6d01bd6a4a651a5429cb03b6267251c",
+	},
+	{
+		input:          "776f726c64",
+		dst:            "564f50524630362d48617368546f47726f75702d000001",
+		encodedElement: "5e1c4ede3c2ce55d6a91c45efc5de0d32a7eadad5d1a5837273fae95ccf63e0a",
+	},
+}
+
 func TestRistretto_EncodeToGroup(t *testing.T) {
-	for i, test := range ristrettoH2gTests {
+	for i, test := range ristrettoE2gTests {
 		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
 			v, err := test.decode()
 			if err != nil {
@@ -101,3 +119,38 @@ func TestRistretto_EncodeToGroup(t *testing.T) {
 		})
 	}
 }
+
+// TestRistretto_MapToGroup checks the single map against FromUniformBytes, which computes MAP(b0) + MAP(b1): with
+// b1 = 0, MAP(b0) = FromUniformBytes(b0 || 0) - MAP(0), and MAP(0) = FromUniformBytes(0 || 0) / 2.
+func TestRistretto_MapToGroup(t *testing.T) {
+	// (l + 1) / 2, the inverse of 2 modulo the group order, in little-endian.
+	half, _ := hex.DecodeString("f7e97a2e8d31092c6bce7b51ef7c6f0a00000000000000000000000000000008")
+
+	invTwo := ristretto.NewScalar()
+	if err := invTwo.Decode(half); err != nil {
+		t.Fatal(err)
+	}
+
+	zero := make([]byte, 64)
+	map0 := ristretto.NewElement().FromUniformBytes(zero)
+	map0.ScalarMult(invTwo, map0)
+
+	for i := range 32 {
+		b := make([]byte, 64)
+		if _, err := rand.Read(b[:32]); err != nil {
+			t.Fatal(err)
+		}
+
+		if i == 0 {
+			// The most significant bit must be ignored.
+			b[31] |= 0x80
+		}
+
+		expected := ristretto.NewElement().FromUniformBytes(b)
+		expected.Subtract(expected, map0)
+
+		if ristretto255.MapToGroup(b[:32]).Equal(expected) != 1 {
+			t.Fatalf("unexpected mapping for %x", b[:32])
+		}
+	}
+}
@@ -0,0 +1,127 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+package hash2curve_test
+
+import (
+	"bytes"
+	"crypto"
+	"errors"
+	"strings"
+	"testing"
+
+	ed "filippo.io/edwards25519"
+
+	"github.com/bytemare/hash2curve"
+	"github.com/bytemare/hash2curve/edwards25519"
+)
+
+var (
+	scratchInput = []byte("abcdef0123456789")
+	scratchDST   = []byte("QUUX-V01-CS02-with-scratch")
+	scratchLong  = []byte(strings.Repeat("a", 256))
+)
+
+func TestScratch_ExpandXMD(t *testing.T) {
+	for _, id := range []crypto.Hash{crypto.SHA256, crypto.SHA384, crypto.SHA512, crypto.SHA3_256, crypto.SHA3_512} {
+		s := hash2curve.NewScratch(id)
+		if s.Hash() != id {
+			t.Fatalf("expected %s, got %s", id, s.Hash())
+		}
+
+		for _, dst := range [][]byte{scratchDST, scratchLong} {
+			for _, length := range []uint{0, 1, 32, 48, 96, 255} {
+				out := make([]byte, length)
+				hash2curve.ExpandXMDInto(s, out, scratchInput, dst)
+
+				if expected := hash2curve.ExpandXMD(id, scratchInput, dst, length); !bytes.Equal(out, expected) {
+					t.Fatalf("%s %d: expected %x, got %x", id, length, expected, out)
+				}
+			}
+		}
+	}
+}
+
+func TestScratch_Allocations(t *testing.T) {
+	s := hash2curve.NewScratch(crypto.SHA512)
+	out := make([]byte, 128)
+
+	var (
+		p        ed.Point
+		encoding [32]byte
+	)
+
+	for name, f := range map[string]func(){
+		"ExpandXMDInto":      func() { hash2curve.ExpandXMDInto(s, out, scratchInput, scratchDST) },
+		"ExpandXMDInto/long": func() { hash2curve.ExpandXMDInto(s, out, scratchInput, scratchLong) },
+		"HashToCurveScratch": func() {
+			edwards25519.HashToCurveScratch(s, &p, &encoding, scratchInput, scratchDST)
+		},
+		"EncodeToCurveScratch": func() {
+			edwards25519.EncodeToCurveScratch(s, &p, &encoding, scratchInput, scratchDST)
+		},
+	} {
+		if allocs := testing.AllocsPerRun(10, f); allocs != 0 {
+			t.Errorf("%s: expected no allocations, got %v", name, allocs)
+		}
+	}
+}
+
+func TestScratch_Edwards25519(t *testing.T) {
+	s := hash2curve.NewScratch(crypto.SHA512)
+
+	var (
+		p        ed.Point
+		encoding [32]byte
+	)
+
+	edwards25519.HashToCurveScratch(s, &p, &encoding, scratchInput, scratchDST)
+
+	if expected := edwards25519.HashToCurve(scratchInput, scratchDST); p.Equal(expected) != 1 ||
+		!bytes.Equal(encoding[:], expected.Bytes()) {
+		t.Fatal("unexpected hash_to_curve output")
+	}
+
+	edwards25519.EncodeToCurveScratch(s, &p, &encoding, scratchInput, scratchDST)
+
+	if expected := edwards25519.EncodeToCurve(scratchInput, scratchDST); p.Equal(expected) != 1 ||
+		!bytes.Equal(encoding[:], expected.Bytes()) {
+		t.Fatal("unexpected encode_to_curve output")
+	}
+
+	wrong := hash2curve.NewScratch(crypto.SHA256)
+
+	if err := panicError(func() {
+		edwards25519.HashToCurveScratch(wrong, &p, nil, scratchInput, scratchDST)
+	}); !errors.Is(err, hash2curve.ErrUnsupportedHash) {
+		t.Fatalf("expected %q, got %v", hash2curve.ErrUnsupportedHash, err)
+	}
+}
+
+func TestScratch_Errors(t *testing.T) {
+	err := panicError(func() { hash2curve.NewScratch(crypto.MD5SHA1) })
+	if !errors.Is(err, hash2curve.ErrUnsupportedHash) {
+		t.Fatalf("expected %q, got %v", hash2curve.ErrUnsupportedHash, err)
+	}
+
+	s := hash2curve.NewScratch(crypto.SHA256)
+
+	if err := panicError(func() {
+		hash2curve.ExpandXMDInto(s, make([]byte, 32), scratchInput, nil)
+	}); !errors.Is(err, hash2curve.ErrZeroLengthDST) {
+		t.Fatalf("expected %q, got %v", hash2curve.ErrZeroLengthDST, err)
+	}
+
+	if err := panicError(func() {
+		hash2curve.ExpandXMDInto(s, make([]byte, 256*32), scratchInput, scratchDST)
+	}); !errors.Is(err, hash2curve.ErrLengthTooLarge) {
+		t.Fatalf("expected %q, got %v", hash2curve.ErrLengthTooLarge, err)
+	}
+}
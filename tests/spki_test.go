@@ -0,0 +1,151 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+package hash2curve_test
+
+import (
+	"bytes"
+	"crypto/ecdh"
+	"crypto/ed25519"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"errors"
+	"testing"
+
+	"github.com/bytemare/hash2curve"
+	"github.com/bytemare/hash2curve/spki"
+	"github.com/bytemare/hash2curve/suite"
+)
+
+func TestSPKI_FromSuite(t *testing.T) {
+	input := []byte("abc")
+
+	for _, id := range hash2curve.AllSuites() {
+		t.Run(id.String(), func(t *testing.T) {
+			dst := []byte("QUUX-V01-CS02-with-" + id.String())
+
+			compressed, err := suite.For(id)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			uncompressed, err := suite.For(id, suite.WithOutputEncoding(suite.Uncompressed))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			point := uncompressed.Hash(input, dst)
+
+			der, err := spki.FromSuite(id, compressed.Hash(input, dst))
+
+			switch curve := id.SuiteID().Curve; curve {
+			case "ristretto255":
+				if !errors.Is(err, spki.ErrUnsupportedCurve) {
+					t.Fatalf("expected %v, got %v", spki.ErrUnsupportedCurve, err)
+				}
+
+				return
+			case "secp256k1":
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				var info struct {
+					Algorithm pkix.AlgorithmIdentifier
+					PublicKey asn1.BitString
+				}
+
+				var oid asn1.ObjectIdentifier
+
+				if _, err = asn1.Unmarshal(der, &info); err != nil {
+					t.Fatal(err)
+				}
+
+				if _, err = asn1.Unmarshal(info.Algorithm.Parameters.FullBytes, &oid); err != nil {
+					t.Fatal(err)
+				}
+
+				if !info.Algorithm.Algorithm.Equal(asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}) ||
+					!oid.Equal(asn1.ObjectIdentifier{1, 3, 132, 0, 10}) ||
+					!bytes.Equal(info.PublicKey.RightAlign(), point) {
+					t.Fatal("unexpected secp256k1 SubjectPublicKeyInfo")
+				}
+			default:
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				parsed, err := x509.ParsePKIXPublicKey(der)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if !bytes.Equal(parsedKeyBytes(t, parsed), point) {
+					t.Fatalf("unexpected public key for %s", curve)
+				}
+			}
+
+			block, rest := pem.Decode(mustPEM(t, id, point))
+			if block == nil || len(rest) != 0 || block.Type != spki.PEMType || !bytes.Equal(block.Bytes, der) {
+				t.Fatal("unexpected PEM encoding")
+			}
+		})
+	}
+
+	if _, err := spki.FromSuite(0, nil); !errors.Is(err, hash2curve.ErrInvalidSuite) {
+		t.Fatalf("expected %v, got %v", hash2curve.ErrInvalidSuite, err)
+	}
+
+	if _, err := spki.PEM(hash2curve.P256SHA256SSWURO, []byte{0}); !errors.Is(err, hash2curve.ErrInvalidPoint) {
+		t.Fatalf("expected %v, got %v", hash2curve.ErrInvalidPoint, err)
+	}
+}
+
+func mustPEM(t *testing.T, id hash2curve.Suite, point []byte) []byte {
+	t.Helper()
+
+	b, err := spki.PEM(id, point)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return b
+}
+
+// parsedKeyBytes returns the point encoding of a public key parsed by crypto/x509, i.e. the uncompressed SEC 1
+// encoding for ECDSA keys, and the 32-byte encoding for Ed25519 and X25519 keys.
+func parsedKeyBytes(t *testing.T, key any) []byte {
+	t.Helper()
+
+	switch k := key.(type) {
+	case ed25519.PublicKey:
+		return k
+	case *ecdh.PublicKey:
+		return k.Bytes()
+	default:
+		type ecdhConverter interface {
+			ECDH() (*ecdh.PublicKey, error)
+		}
+
+		c, ok := key.(ecdhConverter)
+		if !ok {
+			t.Fatalf("unexpected key type %T", key)
+		}
+
+		e, err := c.ECDH()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return e.Bytes()
+	}
+}
@@ -0,0 +1,97 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+package hash2curve_test
+
+import (
+	"crypto/elliptic"
+	"math/big"
+	"testing"
+
+	"github.com/bytemare/hash2curve/internal"
+	"github.com/bytemare/hash2curve/internal/field"
+)
+
+type sswuTest struct {
+	p, a, b, z *big.Int
+	name       string
+}
+
+func sswuTests() []sswuTest {
+	p256 := elliptic.P256().Params()
+
+	return []sswuTest{
+		// p = 3 mod 4, with the constants of sqrt_ratio_3mod4.
+		{name: "P256", p: p256.P, a: big.NewInt(-3), b: p256.B, z: big.NewInt(-10)},
+		// p = 1 mod 4, with the generic sqrt_ratio of the field, on a toy curve with a Z meeting the criteria of
+		// RFC 9380 appendix H.2.
+		{name: "toy", p: big.NewInt(10009), a: big.NewInt(3), b: big.NewInt(5), z: big.NewInt(21)},
+	}
+}
+
+func onCurve(fp *field.Field, a, b, x, y *big.Int) bool {
+	var left, right, t big.Int
+
+	fp.Square(&left, y)
+	fp.Square(&right, x)
+	fp.Mul(&right, &right, x)
+	fp.Mul(&t, a, x)
+	fp.Add(&right, &right, &t)
+	fp.Add(&right, &right, b)
+
+	return left.Cmp(&right) == 0
+}
+
+func TestSSWU(t *testing.T) {
+	for _, test := range sswuTests() {
+		fp := field.NewField(test.p)
+		a := fp.Mod(new(big.Int).Set(test.a))
+		b := fp.Mod(new(big.Int).Set(test.b))
+		z := fp.Mod(new(big.Int).Set(test.z))
+		s := internal.NewSSWU(&fp, a, b, z)
+
+		fes := make([]*big.Int, 0, 32)
+		for i := range int64(32) {
+			fes = append(fes, fp.Mod(new(big.Int).Sub(big.NewInt(i*i*7919), big.NewInt(i))))
+		}
+
+		xs, ys := s.MapBatch(fes)
+
+		for i, fe := range fes {
+			x, y := s.Map(fe)
+			if !onCurve(&fp, a, b, x, y) {
+				t.Fatalf("%s: the mapping of %v is not on the curve", test.name, fe)
+			}
+
+			if x.Cmp(xs[i]) != 0 || y.Cmp(ys[i]) != 0 {
+				t.Fatalf("%s: the batch mapping of %v differs", test.name, fe)
+			}
+
+			// The Jacobian point (X, Y, Z) is the affine point (X / Z^2, Y / Z^3).
+			jx, jy, jz := s.MapJacobian(fe)
+
+			var z2, z3 big.Int
+
+			fp.Square(&z2, jz)
+			fp.Mul(&z3, &z2, jz)
+			fp.Mul(&z2, &z2, x)
+			fp.Mul(&z3, &z3, y)
+
+			if jx.Cmp(&z2) != 0 || jy.Cmp(&z3) != 0 {
+				t.Fatalf("%s: the Jacobian mapping of %v differs", test.name, fe)
+			}
+
+			// The deprecated form returns the same point.
+			if dx, dy := internal.MapToCurveSSWU(&fp, a, b, z, fe); dx.Cmp(x) != 0 || dy.Cmp(y) != 0 {
+				t.Fatalf("%s: the deprecated mapping of %v differs", test.name, fe)
+			}
+		}
+	}
+}
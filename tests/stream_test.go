@@ -0,0 +1,179 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+package hash2curve_test
+
+import (
+	"bytes"
+	"crypto"
+	"errors"
+	"io"
+	"math"
+	"math/big"
+	"runtime"
+	"testing"
+
+	"github.com/bytemare/hash"
+
+	"github.com/bytemare/hash2curve"
+)
+
+func TestExpandReader(t *testing.T) {
+	msg := []byte("stream input")
+	dst := []byte("QUUX-V01-CS02-with-expander-stream")
+
+	for _, e := range []hash2curve.Expander{
+		hash2curve.XMD(crypto.SHA256),
+		hash2curve.XMD(crypto.SHA512),
+		hash2curve.XOF(hash.SHAKE128),
+		hash2curve.XOF(hash.BLAKE2XB),
+		hash2curve.XMDKeccak256(),
+		hash2curve.HKDF(crypto.SHA256, []byte("info")),
+	} {
+		want := e.Expand(msg, dst, e.MaxLength())
+
+		for _, chunk := range []int{1, 7, 32, 100, 4096} {
+			r := hash2curve.NewExpandReader(e, msg, dst)
+			got := make([]byte, 0, len(want))
+			buf := make([]byte, chunk)
+
+			for {
+				n, err := r.Read(buf)
+				got = append(got, buf[:n]...)
+
+				if errors.Is(err, io.EOF) {
+					break
+				}
+
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if !bytes.Equal(got, want) {
+				t.Fatalf("stream mismatch with chunks of %d bytes", chunk)
+			}
+		}
+
+		prefix := make([]byte, 48)
+		if _, err := io.ReadFull(hash2curve.NewExpandReader(e, msg, dst), prefix); err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(prefix, want[:48]) {
+			t.Fatal("unexpected stream prefix")
+		}
+	}
+
+	if hasPanic, err := expectPanic(hash2curve.ErrZeroLengthDST, func() {
+		_ = hash2curve.NewExpandReader(hash2curve.XMD(crypto.SHA256), msg, nil)
+	}); !hasPanic {
+		t.Fatal(err)
+	}
+}
+
+func TestExpandReader_Lazy(t *testing.T) {
+	msg := []byte("stream input")
+	dst := []byte("QUUX-V01-CS02-with-expander-stream")
+
+	// Reading a few bytes must not compute the 65535 bytes of the stream.
+	for _, e := range []hash2curve.Expander{hash2curve.XOF(hash.SHAKE128), hash2curve.XOF(hash.BLAKE2XB)} {
+		var before, after runtime.MemStats
+
+		runtime.ReadMemStats(&before)
+
+		if _, err := io.ReadFull(hash2curve.NewExpandReader(e, msg, dst), make([]byte, 64)); err != nil {
+			t.Fatal(err)
+		}
+
+		runtime.ReadMemStats(&after)
+
+		if allocated := after.TotalAlloc - before.TotalAlloc; allocated > math.MaxUint16/4 {
+			t.Fatalf("reading 64 bytes allocated %d bytes", allocated)
+		}
+	}
+}
+
+func TestFieldStream(t *testing.T) {
+	msg := []byte("stream input")
+	dst := []byte("QUUX-V01-CS02-with-field-stream")
+	p256 := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(189))
+
+	for _, test := range []struct {
+		hashToField func(count, ext uint) []*big.Int
+		name        string
+		expander    hash2curve.Expander
+		count, ext  uint
+	}{
+		{
+			name:     "SHA-256",
+			expander: hash2curve.XMD(crypto.SHA256),
+			hashToField: func(count, ext uint) []*big.Int {
+				return hash2curve.HashToFieldXMD(crypto.SHA256, msg, dst, count, ext, 48, p256)
+			},
+			count: 170,
+			ext:   1,
+		},
+		{
+			name:     "SHA-512",
+			expander: hash2curve.XMD(crypto.SHA512),
+			hashToField: func(count, ext uint) []*big.Int {
+				return hash2curve.HashToFieldXMD(crypto.SHA512, msg, dst, count, ext, 48, p256)
+			},
+			count: 100,
+			ext:   2,
+		},
+		{
+			name:     "SHAKE128",
+			expander: hash2curve.XOF(hash.SHAKE128),
+			hashToField: func(count, ext uint) []*big.Int {
+				return hash2curve.HashToFieldXOF(hash.SHAKE128.GetXOF(), msg, dst, count, ext, 48, p256)
+			},
+			count: 1000,
+			ext:   1,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			want := test.hashToField(test.count, test.ext)
+			s := hash2curve.NewFieldStream(test.expander, msg, dst, test.count, test.ext, 48, p256)
+
+			for i := range test.count {
+				if s.Remaining() != test.count-i {
+					t.Fatalf("unexpected remaining count %d at element %d", s.Remaining(), i)
+				}
+
+				for j, e := range s.Next() {
+					if e.Cmp(want[i*test.ext+uint(j)]) != 0 {
+						t.Fatalf("unexpected coordinate %d of element %d", j, i)
+					}
+				}
+			}
+
+			if s.Remaining() != 0 || s.Next() != nil {
+				t.Fatal("expected the end of the stream")
+			}
+		})
+	}
+
+	// SHA-256 can't expand 171 elements of 48 bytes.
+	if hasPanic, err := expectPanic(hash2curve.ErrLengthTooLarge, func() {
+		_ = hash2curve.NewFieldStream(hash2curve.XMD(crypto.SHA256), msg, dst, 171, 1, 48, p256)
+	}); !hasPanic {
+		t.Fatal(err)
+	}
+
+	if err := hash2curve.ValidateHashToField(0, 1, 48, p256); err == nil {
+		t.Fatal("expected an error")
+	} else if hasPanic, err := expectPanic(err, func() {
+		_ = hash2curve.NewFieldStream(hash2curve.XMD(crypto.SHA256), msg, dst, 0, 1, 48, p256)
+	}); !hasPanic {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,1041 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+package hash2curve_test
+
+import (
+	"bytes"
+	"crypto"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	ed "filippo.io/edwards25519"
+	"github.com/bytemare/hash"
+
+	"github.com/bytemare/hash2curve"
+	"github.com/bytemare/hash2curve/bls24315"
+	"github.com/bytemare/hash2curve/bn254"
+	"github.com/bytemare/hash2curve/curve41417"
+	"github.com/bytemare/hash2curve/e521"
+	"github.com/bytemare/hash2curve/edwards25519"
+	"github.com/bytemare/hash2curve/m511"
+	"github.com/bytemare/hash2curve/mnt4298"
+	"github.com/bytemare/hash2curve/mnt6298"
+	"github.com/bytemare/hash2curve/nist"
+	"github.com/bytemare/hash2curve/ristretto255"
+	"github.com/bytemare/hash2curve/secp192r1"
+	"github.com/bytemare/hash2curve/secp224k1"
+	"github.com/bytemare/hash2curve/secp256k1"
+	"github.com/bytemare/hash2curve/suite"
+)
+
+var (
+	suiteInput = []byte("input data")
+	suiteDST   = []byte("suite options test DST")
+)
+
+type suiteReference struct {
+	hash   func(input, dst []byte) []byte
+	scalar func(input, dst []byte) []byte
+}
+
+func suiteReferences() map[string]suiteReference {
+	return map[string]suiteReference{
+		nist.H2CP256: {
+			hash:   func(i, d []byte) []byte { return nist.HashToP256(i, d).BytesCompressed() },
+			scalar: func(i, d []byte) []byte { return nist.HashToScalarP256(i, d).FillBytes(make([]byte, 32)) },
+		},
+		nist.E2CP256: {
+			hash:   func(i, d []byte) []byte { return nist.EncodeToP256(i, d).BytesCompressed() },
+			scalar: func(i, d []byte) []byte { return nist.HashToScalarP256(i, d).FillBytes(make([]byte, 32)) },
+		},
+		nist.H2CP384: {
+			hash:   func(i, d []byte) []byte { return nist.HashToP384(i, d).BytesCompressed() },
+			scalar: func(i, d []byte) []byte { return nist.HashToScalarP384(i, d).FillBytes(make([]byte, 48)) },
+		},
+		nist.H2CP521: {
+			hash:   func(i, d []byte) []byte { return nist.HashToP521(i, d).BytesCompressed() },
+			scalar: func(i, d []byte) []byte { return nist.HashToScalarP521(i, d).FillBytes(make([]byte, 66)) },
+		},
+		edwards25519.H2C: {
+			hash:   func(i, d []byte) []byte { return edwards25519.HashToCurve(i, d).Bytes() },
+			scalar: func(i, d []byte) []byte { return edwards25519.HashToScalar(i, d).Bytes() },
+		},
+		edwards25519.E2C: {
+			hash:   func(i, d []byte) []byte { return edwards25519.EncodeToCurve(i, d).Bytes() },
+			scalar: func(i, d []byte) []byte { return edwards25519.HashToScalar(i, d).Bytes() },
+		},
+		edwards25519.H2C25519: {
+			hash:   edwards25519.HashToCurve25519,
+			scalar: func(i, d []byte) []byte { return edwards25519.HashToScalar(i, d).Bytes() },
+		},
+		secp256k1.H2C: {
+			hash:   func(i, d []byte) []byte { return secp256k1.HashToCurve(i, d).Bytes() },
+			scalar: func(i, d []byte) []byte { return secp256k1.HashToScalar(i, d).FillBytes(make([]byte, 32)) },
+		},
+		secp256k1.E2C: {
+			hash:   func(i, d []byte) []byte { return secp256k1.EncodeToCurve(i, d).Bytes() },
+			scalar: func(i, d []byte) []byte { return secp256k1.HashToScalar(i, d).FillBytes(make([]byte, 32)) },
+		},
+		ristretto255.H2C: {
+			hash:   func(i, d []byte) []byte { return ristretto255.HashToGroup(i, d).Encode(nil) },
+			scalar: func(i, d []byte) []byte { return ristretto255.HashToScalar(i, d).Encode(nil) },
+		},
+		ristretto255.E2C: {
+			hash:   func(i, d []byte) []byte { return ristretto255.EncodeToGroup(i, d).Encode(nil) },
+			scalar: func(i, d []byte) []byte { return ristretto255.HashToScalar(i, d).Encode(nil) },
+		},
+		nist.E2CP384: {
+			hash:   func(i, d []byte) []byte { return nist.EncodeToP384(i, d).BytesCompressed() },
+			scalar: func(i, d []byte) []byte { return nist.HashToScalarP384(i, d).FillBytes(make([]byte, 48)) },
+		},
+		nist.E2CP521: {
+			hash:   func(i, d []byte) []byte { return nist.EncodeToP521(i, d).BytesCompressed() },
+			scalar: func(i, d []byte) []byte { return nist.HashToScalarP521(i, d).FillBytes(make([]byte, 66)) },
+		},
+		edwards25519.E2C25519: {
+			hash:   edwards25519.EncodeToCurve25519,
+			scalar: func(i, d []byte) []byte { return edwards25519.HashToScalar(i, d).Bytes() },
+		},
+		e521.H2C: packageReference(
+			func(i, d []byte) []byte { return e521.HashToCurve(i, d).Bytes() },
+			e521.HashToScalar, e521.Order(), true),
+		e521.E2C: packageReference(
+			func(i, d []byte) []byte { return e521.EncodeToCurve(i, d).Bytes() },
+			e521.HashToScalar, e521.Order(), true),
+		curve41417.H2C: packageReference(
+			func(i, d []byte) []byte { return curve41417.HashToCurve(i, d).Bytes() },
+			curve41417.HashToScalar, curve41417.Order(), true),
+		curve41417.E2C: packageReference(
+			func(i, d []byte) []byte { return curve41417.EncodeToCurve(i, d).Bytes() },
+			curve41417.HashToScalar, curve41417.Order(), true),
+		m511.H2C: packageReference(
+			func(i, d []byte) []byte { return m511.HashToCurve(i, d).Bytes() },
+			m511.HashToScalar, m511.Order(), true),
+		m511.E2C: packageReference(
+			func(i, d []byte) []byte { return m511.EncodeToCurve(i, d).Bytes() },
+			m511.HashToScalar, m511.Order(), true),
+		mnt4298.G1H2C: packageReference(
+			func(i, d []byte) []byte { return mnt4298.HashToG1(i, d).Bytes() },
+			mnt4298.HashToScalar, mnt4298.Order(), false),
+		mnt4298.G1E2C: packageReference(
+			func(i, d []byte) []byte { return mnt4298.EncodeToG1(i, d).Bytes() },
+			mnt4298.HashToScalar, mnt4298.Order(), false),
+		mnt4298.G2H2C: packageReference(
+			func(i, d []byte) []byte { return mnt4298.HashToG2(i, d).Bytes() },
+			mnt4298.HashToScalar, mnt4298.Order(), false),
+		mnt4298.G2E2C: packageReference(
+			func(i, d []byte) []byte { return mnt4298.EncodeToG2(i, d).Bytes() },
+			mnt4298.HashToScalar, mnt4298.Order(), false),
+		mnt6298.G1H2C: packageReference(
+			func(i, d []byte) []byte { return mnt6298.HashToG1(i, d).Bytes() },
+			mnt6298.HashToScalar, mnt6298.Order(), false),
+		mnt6298.G1E2C: packageReference(
+			func(i, d []byte) []byte { return mnt6298.EncodeToG1(i, d).Bytes() },
+			mnt6298.HashToScalar, mnt6298.Order(), false),
+		mnt6298.G2H2C: packageReference(
+			func(i, d []byte) []byte { return mnt6298.HashToG2(i, d).Bytes() },
+			mnt6298.HashToScalar, mnt6298.Order(), false),
+		mnt6298.G2E2C: packageReference(
+			func(i, d []byte) []byte { return mnt6298.EncodeToG2(i, d).Bytes() },
+			mnt6298.HashToScalar, mnt6298.Order(), false),
+		bls24315.G1H2C: packageReference(
+			func(i, d []byte) []byte { return bls24315.HashToG1(i, d).Bytes() },
+			bls24315.HashToScalar, bls24315.Order(), false),
+		bls24315.G1E2C: packageReference(
+			func(i, d []byte) []byte { return bls24315.EncodeToG1(i, d).Bytes() },
+			bls24315.HashToScalar, bls24315.Order(), false),
+		bls24315.G2H2C: packageReference(
+			func(i, d []byte) []byte { return bls24315.HashToG2(i, d).Bytes() },
+			bls24315.HashToScalar, bls24315.Order(), false),
+		bls24315.G2E2C: packageReference(
+			func(i, d []byte) []byte { return bls24315.EncodeToG2(i, d).Bytes() },
+			bls24315.HashToScalar, bls24315.Order(), false),
+		secp192r1.H2C: packageReference(
+			func(i, d []byte) []byte { return secp192r1.HashToCurve(i, d).Bytes() },
+			secp192r1.HashToScalar, secp192r1.Order(), false),
+		secp192r1.E2C: packageReference(
+			func(i, d []byte) []byte { return secp192r1.EncodeToCurve(i, d).Bytes() },
+			secp192r1.HashToScalar, secp192r1.Order(), false),
+		secp224k1.H2C: packageReference(
+			func(i, d []byte) []byte { return secp224k1.HashToCurve(i, d).Bytes() },
+			secp224k1.HashToScalar, secp224k1.Order(), false),
+		secp224k1.E2C: packageReference(
+			func(i, d []byte) []byte { return secp224k1.EncodeToCurve(i, d).Bytes() },
+			secp224k1.HashToScalar, secp224k1.Order(), false),
+		secp256k1.H2CKeccak256: packageReference(
+			func(i, d []byte) []byte { return secp256k1.HashToCurveKeccak256(i, d).Bytes() },
+			secp256k1.HashToScalarKeccak256, secp256k1.Order(), false),
+		secp256k1.E2CKeccak256: packageReference(
+			func(i, d []byte) []byte { return secp256k1.EncodeToCurveKeccak256(i, d).Bytes() },
+			secp256k1.HashToScalarKeccak256, secp256k1.Order(), false),
+		bn254.H2C: packageReference(
+			func(i, d []byte) []byte { return bn254.HashToCurve(i, d).Bytes() },
+			bn254.HashToScalar, bn254.Order(), false),
+		bn254.E2C: packageReference(
+			func(i, d []byte) []byte { return bn254.EncodeToCurve(i, d).Bytes() },
+			bn254.HashToScalar, bn254.Order(), false),
+	}
+}
+
+// packageReference returns the reference of a suite of a curve that is not in RFC 9380, whose scalars are encoded with
+// the length of the group order.
+func packageReference(
+	hash func(input, dst []byte) []byte,
+	scalar func(input, dst []byte) *big.Int,
+	order *big.Int,
+	littleEndian bool,
+) suiteReference {
+	return suiteReference{
+		hash: hash,
+		scalar: func(i, d []byte) []byte {
+			b := scalar(i, d).FillBytes(make([]byte, (order.BitLen()+7)/8))
+			if littleEndian {
+				slices.Reverse(b)
+			}
+
+			return b
+		},
+	}
+}
+
+func TestSuite_Defaults(t *testing.T) {
+	for id, ref := range suiteReferences() {
+		s, err := suite.New(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if s.ID() != id {
+			t.Fatalf("%s: unexpected ID %q", id, s.ID())
+		}
+
+		if got, want := s.Hash(suiteInput, suiteDST), ref.hash(suiteInput, suiteDST); !bytes.Equal(got, want) {
+			t.Fatalf("%s: hash mismatch\n\twant %x\n\tgot  %x", id, want, got)
+		}
+
+		got, want := s.HashToScalar(suiteInput, suiteDST), ref.scalar(suiteInput, suiteDST)
+		if !bytes.Equal(got, want) {
+			t.Fatalf("%s: scalar mismatch\n\twant %x\n\tgot  %x", id, want, got)
+		}
+	}
+}
+
+// TestSuite_AllRegistered walks the registry, and checks that the suite package hashes through each suite as the
+// package of its curve does.
+func TestSuite_AllRegistered(t *testing.T) {
+	references := suiteReferences()
+
+	for _, d := range hash2curve.Suites() {
+		s, err := suite.New(d.ID)
+		if err != nil {
+			t.Fatalf("%s: %v", d.ID, err)
+		}
+
+		if s.RandomOracle() != d.RandomOracle || s.SecurityLength() != d.SecurityLength {
+			t.Fatalf("%s: inconsistent descriptor %+v", d.ID, d)
+		}
+
+		ref, ok := references[d.ID]
+		if !ok {
+			t.Fatalf("%s: no reference", d.ID)
+		}
+
+		if got, want := s.Hash(suiteInput, suiteDST), ref.hash(suiteInput, suiteDST); !bytes.Equal(got, want) {
+			t.Fatalf("%s: hash mismatch\n\twant %x\n\tgot  %x", d.ID, want, got)
+		}
+
+		got, want := s.HashToScalar(suiteInput, suiteDST), ref.scalar(suiteInput, suiteDST)
+		if !bytes.Equal(got, want) {
+			t.Fatalf("%s: scalar mismatch\n\twant %x\n\tgot  %x", d.ID, want, got)
+		}
+	}
+}
+
+func TestSuite_Invalid(t *testing.T) {
+	for _, id := range []string{"", "P256_XMD:SHA-256_SSWU_RO", "BLS12381G1_XMD:SHA-256_SSWU_RO_"} {
+		if _, err := suite.New(id); !errors.Is(err, hash2curve.ErrInvalidSuite) {
+			t.Fatalf("%q: expected %v, got %v", id, hash2curve.ErrInvalidSuite, err)
+		}
+	}
+
+	if _, err := suite.New(nist.H2CP256, suite.WithSecurityLength(16)); !errors.Is(err,
+		hash2curve.ErrInvalidParameters) {
+		t.Fatalf("expected %v, got %v", hash2curve.ErrInvalidParameters, err)
+	}
+}
+
+func TestSuite_Options(t *testing.T) {
+	def, _ := suite.New(nist.H2CP256)
+	reference := def.Hash(suiteInput, suiteDST)
+
+	// Uncompressed encoding.
+	s, _ := suite.New(nist.H2CP256, suite.WithOutputEncoding(suite.Uncompressed))
+	if want := nist.HashToP256(suiteInput, suiteDST).Bytes(); !bytes.Equal(s.Hash(suiteInput, suiteDST), want) {
+		t.Fatal("unexpected uncompressed encoding")
+	}
+
+	s, _ = suite.New(secp256k1.H2C, suite.WithOutputEncoding(suite.Uncompressed))
+	if out := s.Hash(suiteInput, suiteDST); len(out) != 65 || out[0] != 4 {
+		t.Fatalf("unexpected uncompressed secp256k1 encoding %x", out)
+	}
+
+	// Explicit default expander, and a different one.
+	s, _ = suite.New(nist.H2CP256, suite.WithExpander(hash2curve.XMD(crypto.SHA256)))
+	if !bytes.Equal(s.Hash(suiteInput, suiteDST), reference) {
+		t.Fatal("explicit default expander changed the output")
+	}
+
+	s, _ = suite.New(nist.H2CP256, suite.WithExpander(hash2curve.XOF(hash.SHAKE128)))
+	if bytes.Equal(s.Hash(suiteInput, suiteDST), reference) {
+		t.Fatal("expander override had no effect")
+	}
+
+	s, _ = suite.New(nist.H2CP256, suite.WithExpander(hash2curve.HKDF(crypto.SHA256, nil)))
+	if bytes.Equal(s.Hash(suiteInput, suiteDST), reference) {
+		t.Fatal("HKDF expander override had no effect")
+	}
+
+	// Security length.
+	s, _ = suite.New(nist.H2CP256, suite.WithSecurityLength(64))
+	if bytes.Equal(s.Hash(suiteInput, suiteDST), reference) {
+		t.Fatal("security length override had no effect")
+	}
+
+	// Cofactor clearing.
+	s, _ = suite.New(edwards25519.H2C, suite.WithCofactorClearing(false))
+	cleared := edwards25519.HashToCurve(suiteInput, suiteDST)
+
+	p, err := new(ed.Point).SetBytes(s.Hash(suiteInput, suiteDST))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if p.MultByCofactor(p).Equal(cleared) != 1 {
+		t.Fatal("expected the uncleared point to clear to the reference")
+	}
+
+	s, _ = suite.New(nist.H2CP256, suite.WithCofactorClearing(false))
+	if !bytes.Equal(s.Hash(suiteInput, suiteDST), reference) {
+		t.Fatal("cofactor clearing must not affect prime-order curves")
+	}
+}
+
+func TestSuite_Hasher(t *testing.T) {
+	dst := []byte("QUUX-V01-CS02-with-reusable-hasher")
+	references := suiteReferences()
+
+	for _, pair := range [][2]string{
+		{nist.H2CP256, nist.E2CP256},
+		{edwards25519.H2C, edwards25519.E2C},
+		{secp256k1.H2C, secp256k1.E2C},
+		{ristretto255.H2C, ristretto255.E2C},
+	} {
+		ro, nu := references[pair[0]], references[pair[1]]
+
+		for _, opts := range [][]suite.Option{nil, {suite.WithExpander(hash2curve.XMD(crypto.SHA512))}} {
+			h, err := suite.NewHasher(pair[0], dst, opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			s := h.Suite()
+
+			if !bytes.Equal(h.HashToCurve(suiteInput), s.Hash(suiteInput, dst)) {
+				t.Fatalf("%s: hasher and suite differ", pair[0])
+			}
+
+			if !bytes.Equal(h.HashToScalar(suiteInput), s.HashToScalar(suiteInput, dst)) {
+				t.Fatalf("%s: scalar mismatch", pair[0])
+			}
+
+			if opts != nil {
+				continue
+			}
+
+			if !bytes.Equal(h.HashToCurve(suiteInput), ro.hash(suiteInput, dst)) {
+				t.Fatalf("%s: hash_to_curve mismatch", pair[0])
+			}
+
+			if !bytes.Equal(h.EncodeToCurve(suiteInput), nu.hash(suiteInput, dst)) {
+				t.Fatalf("%s: encode_to_curve mismatch", pair[1])
+			}
+		}
+	}
+
+	if _, err := suite.NewHasher(nist.H2CP256, nil); !errors.Is(err, hash2curve.ErrZeroLengthDST) {
+		t.Fatalf("expected %v, got %v", hash2curve.ErrZeroLengthDST, err)
+	}
+
+	long := bytes.Repeat([]byte("a"), 300)
+
+	h, err := suite.NewHasher(nist.H2CP256, long)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(h.HashToCurve(suiteInput), nist.HashToP256(suiteInput, long).BytesCompressed()) {
+		t.Fatal("oversize DST mismatch")
+	}
+}
+
+func TestSuite_StrictDST(t *testing.T) {
+	shortDST := []byte("short DST")
+
+	lax, err := suite.New(nist.H2CP256)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(lax.Hash(suiteInput, shortDST), nist.HashToP256(suiteInput, shortDST).BytesCompressed()) {
+		t.Fatal("unexpected output with a short DST")
+	}
+
+	strict, err := suite.New(nist.H2CP256, suite.WithStrictDST(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = strict.Hasher(shortDST); !errors.Is(err, hash2curve.ErrShortDST) {
+		t.Fatalf("expected %v, got %v", hash2curve.ErrShortDST, err)
+	}
+
+	if _, err = suite.NewHasher(ristretto255.H2C, shortDST, suite.WithStrictDST(true)); !errors.Is(
+		err, hash2curve.ErrShortDST) {
+		t.Fatalf("expected %v, got %v", hash2curve.ErrShortDST, err)
+	}
+
+	for _, f := range []func(){
+		func() { strict.Hash(suiteInput, shortDST) },
+		func() { strict.HashToScalar(suiteInput, shortDST) },
+		func() { strict.Trace(suiteInput, shortDST) },
+	} {
+		if hasPanic, err := expectPanic(hash2curve.ErrShortDST, f); !hasPanic {
+			t.Fatalf("expected panic: %v", err)
+		}
+	}
+
+	if !bytes.Equal(strict.Hash(suiteInput, suiteDST), lax.Hash(suiteInput, suiteDST)) {
+		t.Fatal("unexpected output with a long enough DST")
+	}
+
+	// The policy of a suite doesn't apply to the others.
+	if _, err = lax.Hasher(shortDST); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
+
+func TestSuite_MaxInputLength(t *testing.T) {
+	input := bytes.Repeat([]byte{1}, 33)
+
+	s, err := suite.New(edwards25519.H2C, suite.WithMaxInputLength(32))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = s.ValidateInput(input[:32]); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !bytes.Equal(s.Hash(input[:32], suiteDST), edwards25519.HashToCurve(input[:32], suiteDST).Bytes()) {
+		t.Fatal("unexpected output under the limit")
+	}
+
+	if err = s.ValidateInput(input); !errors.Is(err, hash2curve.ErrInputTooLong) {
+		t.Fatalf("expected %v, got %v", hash2curve.ErrInputTooLong, err)
+	}
+
+	h, err := s.Hasher(suiteDST)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, f := range []func(){
+		func() { s.Hash(input, suiteDST) },
+		func() { s.HashToScalar(input, suiteDST) },
+		func() { h.HashToCurve(input) },
+		func() { h.HashToScalar(input) },
+	} {
+		if hasPanic, err := expectPanic(hash2curve.ErrInputTooLong, f); !hasPanic {
+			t.Fatalf("expected panic: %v", err)
+		}
+	}
+
+	unlimited, _ := suite.New(edwards25519.H2C)
+
+	hash2curve.SetInputLimits(16, 0)
+	defer hash2curve.SetInputLimits(0, 0)
+
+	if err = unlimited.ValidateInput(input[:17]); !errors.Is(err, hash2curve.ErrInputTooLong) {
+		t.Fatalf("expected %v, got %v", hash2curve.ErrInputTooLong, err)
+	}
+}
+
+func TestSuite_Try(t *testing.T) {
+	input := bytes.Repeat([]byte{1}, 32)
+
+	for _, id := range hash2curve.AllSuites() {
+		s, err := suite.For(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		p, err := s.TryHash(input, suiteDST)
+		if err != nil || !bytes.Equal(p, s.Hash(input, suiteDST)) {
+			t.Fatalf("%s: unexpected hash: %v", id, err)
+		}
+
+		sc, err := s.TryHashToScalar(input, suiteDST)
+		if err != nil || !bytes.Equal(sc, s.HashToScalar(input, suiteDST)) {
+			t.Fatalf("%s: unexpected scalar: %v", id, err)
+		}
+
+		if _, err = s.TryHash(input, nil); !errors.Is(err, hash2curve.ErrZeroLengthDST) {
+			t.Fatalf("%s: expected %v, got %v", id, hash2curve.ErrZeroLengthDST, err)
+		}
+
+		if _, err = s.TryHashToScalars(input, suiteDST, 0); !errors.Is(err, hash2curve.ErrInvalidParameters) {
+			t.Fatalf("%s: expected %v, got %v", id, hash2curve.ErrInvalidParameters, err)
+		}
+
+		if _, err = s.TryHashToScalars(input, suiteDST, 1<<16); !errors.Is(err, hash2curve.ErrLengthTooLarge) {
+			t.Fatalf("%s: expected %v, got %v", id, hash2curve.ErrLengthTooLarge, err)
+		}
+
+		// The expansion length of the encoding is checked against the module-wide limit.
+		hash2curve.SetInputLimits(16, s.SecurityLength())
+
+		if _, err = s.TryHash(input, suiteDST); !errors.Is(err, hash2curve.ErrInputTooLong) {
+			t.Fatalf("%s: expected %v, got %v", id, hash2curve.ErrInputTooLong, err)
+		}
+
+		_, err = s.TryHash(nil, suiteDST)
+		if expected := s.RandomOracle() && !strings.HasPrefix(id.String(), "ristretto255"); expected !=
+			errors.Is(err, hash2curve.ErrLengthTooLarge) {
+			t.Fatalf("%s: unexpected error %v", id, err)
+		}
+
+		if _, err = s.TryHashToScalars(nil, suiteDST, 2); !errors.Is(err, hash2curve.ErrLengthTooLarge) {
+			t.Fatalf("%s: expected %v, got %v", id, hash2curve.ErrLengthTooLarge, err)
+		}
+
+		hash2curve.SetInputLimits(0, 0)
+	}
+
+	strict, _ := suite.New(nist.H2CP256, suite.WithStrictDST(true), suite.WithMaxInputLength(16))
+
+	if _, err := strict.TryHash(nil, []byte("short")); !errors.Is(err, hash2curve.ErrShortDST) {
+		t.Fatalf("expected %v, got %v", hash2curve.ErrShortDST, err)
+	}
+
+	if _, err := strict.TryHashToScalar(input, suiteDST); !errors.Is(err, hash2curve.ErrInputTooLong) {
+		t.Fatalf("expected %v, got %v", hash2curve.ErrInputTooLong, err)
+	}
+}
+
+func TestTry_Curves(t *testing.T) {
+	input := []byte("abc")
+
+	for name, try := range map[string]func(input, dst []byte) ([]byte, []byte, error){
+		"edwards25519 RO": func(input, dst []byte) ([]byte, []byte, error) {
+			p, err := edwards25519.TryHashToCurve(input, dst)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			return p.Bytes(), edwards25519.HashToCurve(input, dst).Bytes(), nil
+		},
+		"edwards25519 NU": func(input, dst []byte) ([]byte, []byte, error) {
+			p, err := edwards25519.TryEncodeToCurve(input, dst)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			return p.Bytes(), edwards25519.EncodeToCurve(input, dst).Bytes(), nil
+		},
+		"edwards25519 scalar": func(input, dst []byte) ([]byte, []byte, error) {
+			s, err := edwards25519.TryHashToScalar(input, dst)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			return s.Bytes(), edwards25519.HashToScalar(input, dst).Bytes(), nil
+		},
+		"secp256k1 RO": func(input, dst []byte) ([]byte, []byte, error) {
+			p, err := secp256k1.TryHashToCurve(input, dst)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			return p.Bytes(), secp256k1.HashToCurve(input, dst).Bytes(), nil
+		},
+		"secp256k1 NU": func(input, dst []byte) ([]byte, []byte, error) {
+			p, err := secp256k1.TryEncodeToCurve(input, dst)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			return p.Bytes(), secp256k1.EncodeToCurve(input, dst).Bytes(), nil
+		},
+		"secp256k1 scalar": func(input, dst []byte) ([]byte, []byte, error) {
+			s, err := secp256k1.TryHashToScalar(input, dst)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			return s.Bytes(), secp256k1.HashToScalar(input, dst).Bytes(), nil
+		},
+	} {
+		got, want, err := try(input, suiteDST)
+		if err != nil || !bytes.Equal(got, want) {
+			t.Fatalf("%s: unexpected output: %v", name, err)
+		}
+
+		if _, _, err = try(input, nil); !errors.Is(err, hash2curve.ErrZeroLengthDST) {
+			t.Fatalf("%s: expected %v, got %v", name, hash2curve.ErrZeroLengthDST, err)
+		}
+
+		hash2curve.SetInputLimits(2, 0)
+		_, _, err = try(input, suiteDST)
+		hash2curve.SetInputLimits(0, 0)
+
+		if !errors.Is(err, hash2curve.ErrInputTooLong) {
+			t.Fatalf("%s: expected %v, got %v", name, hash2curve.ErrInputTooLong, err)
+		}
+	}
+}
+
+func TestSuite_HashToScalars(t *testing.T) {
+	const count = 3
+
+	fixed := func(scalars []*big.Int, length int) [][]byte {
+		res := make([][]byte, len(scalars))
+		for i, sc := range scalars {
+			res[i] = sc.FillBytes(make([]byte, length))
+		}
+
+		return res
+	}
+
+	references := map[string]func(i, d []byte, n uint) [][]byte{
+		nist.H2CP256:  func(i, d []byte, n uint) [][]byte { return fixed(nist.HashToScalarsP256(i, d, n), 32) },
+		nist.H2CP384:  func(i, d []byte, n uint) [][]byte { return fixed(nist.HashToScalarsP384(i, d, n), 48) },
+		nist.H2CP521:  func(i, d []byte, n uint) [][]byte { return fixed(nist.HashToScalarsP521(i, d, n), 66) },
+		secp256k1.H2C: func(i, d []byte, n uint) [][]byte { return fixed(secp256k1.HashToScalars(i, d, n), 32) },
+		edwards25519.H2C: func(i, d []byte, n uint) [][]byte {
+			var res [][]byte
+			for _, sc := range edwards25519.HashToScalars(i, d, n) {
+				res = append(res, sc.Bytes())
+			}
+
+			return res
+		},
+		ristretto255.H2C: func(i, d []byte, n uint) [][]byte {
+			var res [][]byte
+			for _, sc := range ristretto255.HashToScalars(i, d, n) {
+				res = append(res, sc.Encode(nil))
+			}
+
+			return res
+		},
+	}
+
+	for id, ref := range references {
+		s, err := suite.New(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		single := s.HashToScalars(suiteInput, suiteDST, 1)
+		if len(single) != 1 || !bytes.Equal(single[0], s.HashToScalar(suiteInput, suiteDST)) ||
+			!bytes.Equal(ref(suiteInput, suiteDST, 1)[0], single[0]) {
+			t.Fatalf("%s: a single scalar must match HashToScalar", id)
+		}
+
+		scalars := s.HashToScalars(suiteInput, suiteDST, count)
+		expected := ref(suiteInput, suiteDST, count)
+
+		if len(scalars) != count || len(expected) != count {
+			t.Fatalf("%s: unexpected number of scalars", id)
+		}
+
+		for i := range count {
+			if !bytes.Equal(scalars[i], expected[i]) {
+				t.Fatalf("%s: scalar %d mismatch", id, i)
+			}
+
+			for j := range i {
+				if bytes.Equal(scalars[i], scalars[j]) {
+					t.Fatalf("%s: scalars %d and %d are equal", id, i, j)
+				}
+			}
+		}
+
+		h, _ := s.Hasher(suiteDST)
+		if got := h.HashToScalars(suiteInput, count); !bytes.Equal(got[count-1], scalars[count-1]) {
+			t.Fatalf("%s: hasher mismatch", id)
+		}
+
+		if hasPanic, err := expectPanic(hash2curve.ErrInvalidParameters, func() {
+			s.HashToScalars(suiteInput, suiteDST, 0)
+		}); !hasPanic {
+			t.Fatalf("%s: expected panic: %v", id, err)
+		}
+	}
+
+	// The scalars are hash_to_field of a single expansion modulo the group order.
+	fields := hash2curve.HashToFieldXMD(crypto.SHA256, suiteInput, suiteDST, count, 1, 48, nist.OrderP256())
+
+	for i, sc := range nist.HashToScalarsP256(suiteInput, suiteDST, count) {
+		if sc.Cmp(fields[i]) != 0 {
+			t.Fatalf("P-256 scalar %d mismatch", i)
+		}
+	}
+}
+
+type edwards25519Clearer struct {
+	calls int
+}
+
+func (c *edwards25519Clearer) ClearCofactor(encoded []byte) []byte {
+	c.calls++
+
+	p, err := new(ed.Point).SetBytes(encoded)
+	if err != nil {
+		panic(err)
+	}
+
+	return p.MultByCofactor(p).Bytes()
+}
+
+func TestSuite_CofactorPolicy(t *testing.T) {
+	for _, d := range hash2curve.Suites() {
+		s, err := suite.New(d.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if s.Cofactor() != d.Cofactor {
+			t.Fatalf("%s: want cofactor %d, got %d", d.ID, d.Cofactor, s.Cofactor())
+		}
+	}
+
+	clearer := &edwards25519Clearer{}
+
+	custom, err := suite.New(edwards25519.H2C, suite.WithCofactorClearer(clearer))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(custom.Hash(suiteInput, suiteDST), edwards25519.HashToCurve(suiteInput, suiteDST).Bytes()) ||
+		clearer.calls != 1 {
+		t.Fatal("unexpected custom cofactor clearing")
+	}
+
+	// The last cofactor option wins.
+	raw, _ := suite.New(edwards25519.H2C, suite.WithCofactorClearer(clearer), suite.WithCofactorClearing(false))
+	if bytes.Equal(raw.Hash(suiteInput, suiteDST), custom.Hash(suiteInput, suiteDST)) || clearer.calls != 2 {
+		t.Fatal("expected the raw mapped point")
+	}
+}
+
+func traceVectorPoint(t *testing.T, curve, x, y string) []byte {
+	if curve == "edwards25519" {
+		return vectorToEdwards25519(t, x, y).Bytes()
+	}
+
+	xb, yb := vectorToBig(x, y)
+
+	var l int
+
+	switch curve {
+	case "NIST P-384":
+		l = 48
+	case "NIST P-521":
+		l = 66
+	default:
+		l = 32
+	}
+
+	out := make([]byte, 1+2*l)
+	out[0] = 4
+	xb.FillBytes(out[1 : 1+l])
+	yb.FillBytes(out[1+l:])
+
+	return out
+}
+
+func TestSuite_Trace(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join(hashToCurveVectorsFileLocation, "*.json"))
+	if err != nil || len(files) == 0 {
+		t.Fatalf("no vector files: %v", err)
+	}
+
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var v h2cVectors
+		if err = json.Unmarshal(data, &v); err != nil {
+			t.Fatal(err)
+		}
+
+		s, err := suite.New(v.Ciphersuite, suite.WithOutputEncoding(suite.Uncompressed))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, vector := range v.Vectors {
+			trace := s.Trace([]byte(vector.Msg), []byte(v.Dst))
+
+			if len(trace.U) != len(vector.U) {
+				t.Fatalf("%s: unexpected number of field elements", v.Ciphersuite)
+			}
+
+			for i, u := range vector.U {
+				expected, _ := new(big.Int).SetString(u, 0)
+				if trace.U[i].Cmp(expected) != 0 {
+					t.Fatalf("%s: unexpected u[%d]", v.Ciphersuite, i)
+				}
+			}
+
+			q0 := vector.Q0
+			if !s.RandomOracle() {
+				q0 = vector.Q
+			}
+
+			if !bytes.Equal(trace.Q0, traceVectorPoint(t, v.Curve, q0.X, q0.Y)) {
+				t.Fatalf("%s: unexpected Q0", v.Ciphersuite)
+			}
+
+			if s.RandomOracle() && !bytes.Equal(trace.Q1, traceVectorPoint(t, v.Curve, vector.Q1.X, vector.Q1.Y)) {
+				t.Fatalf("%s: unexpected Q1", v.Ciphersuite)
+			}
+
+			if !s.RandomOracle() && trace.Q1 != nil {
+				t.Fatalf("%s: unexpected Q1 for a NU suite", v.Ciphersuite)
+			}
+
+			if !bytes.Equal(trace.P, traceVectorPoint(t, v.Curve, vector.P.X, vector.P.Y)) ||
+				!bytes.Equal(trace.P, s.Hash([]byte(vector.Msg), []byte(v.Dst))) {
+				t.Fatalf("%s: unexpected P", v.Ciphersuite)
+			}
+		}
+	}
+
+	r, _ := suite.New("ristretto255_XMD:SHA-512_R255MAP_RO_")
+	if trace := r.Trace(suiteInput, suiteDST); trace.U != nil || trace.Q0 != nil ||
+		!bytes.Equal(trace.P, r.Hash(suiteInput, suiteDST)) {
+		t.Fatal("unexpected ristretto255 trace")
+	}
+}
+
+func TestSuite_HashHedged(t *testing.T) {
+	aux := bytes.Repeat([]byte{0x2a}, 32)
+
+	for id, ref := range suiteReferences() {
+		s, err := suite.New(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := ref.hash(hash2curve.HedgedInput(suiteInput, aux), hash2curve.HedgedDST(suiteDST))
+		if got := s.HashHedged(suiteInput, aux, suiteDST); !bytes.Equal(got, want) {
+			t.Fatalf("%s: hedged hash mismatch\n\twant %x\n\tgot  %x", id, want, got)
+		}
+
+		if bytes.Equal(s.HashHedged(suiteInput, aux[1:], suiteDST), want) {
+			t.Fatalf("%s: the auxiliary randomness is not mixed in", id)
+		}
+	}
+
+	s, err := suite.New("P256_XMD:SHA-256_SSWU_RO_", suite.WithStrictDST(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if hasPanic, err := expectPanic(hash2curve.ErrShortDST, func() {
+		_ = s.HashHedged(suiteInput, aux, []byte("short"))
+	}); !hasPanic {
+		t.Fatal(err)
+	}
+}
+
+func TestSuite_SecurityLevel(t *testing.T) {
+	for _, test := range []struct {
+		id     string
+		k      uint
+		length uint
+	}{
+		{"P256_XMD:SHA-256_SSWU_RO_", 128, 48},
+		{"P521_XMD:SHA-512_SSWU_RO_", 256, 98},
+		{"P521_XMD:SHA-512_SSWU_RO_", 192, 90},
+		{"ristretto255_XMD:SHA-512_R255MAP_RO_", 192, 64},
+	} {
+		s, err := suite.New(test.id, suite.WithSecurityLength(1), suite.WithSecurityLevel(test.k))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if s.SecurityLength() != test.length {
+			t.Fatalf("%s: want %d, got %d", test.id, test.length, s.SecurityLength())
+		}
+
+		ref, err := suite.New(test.id, suite.WithSecurityLength(test.length))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(s.Hash(suiteInput, suiteDST), ref.Hash(suiteInput, suiteDST)) {
+			t.Fatalf("%s: hash mismatch", test.id)
+		}
+	}
+
+	if _, err := suite.New("P256_XMD:SHA-256_SSWU_RO_", suite.WithSecurityLevel(64)); !errors.Is(
+		err, hash2curve.ErrInvalidParameters) {
+		t.Fatalf("want %v, got %v", hash2curve.ErrInvalidParameters, err)
+	}
+}
+
+func TestSuite_StrictSecurity(t *testing.T) {
+	// P-521 targets 256 bits, so that 192 bits of security are rejected in strict mode only.
+	weak := []suite.Option{suite.WithSecurityLevel(192)}
+
+	if _, err := suite.New(nist.H2CP521, weak...); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := suite.New(nist.H2CP521, append(weak, suite.WithStrictSecurity(true))...); !errors.Is(
+		err, hash2curve.ErrInsufficientSecurity) {
+		t.Fatalf("want %v, got %v", hash2curve.ErrInsufficientSecurity, err)
+	}
+
+	// Custom expanders are not checked, but L is.
+	if _, err := suite.New(nist.H2CP521, suite.WithStrictSecurity(true),
+		suite.WithExpander(hash2curve.XMD(crypto.SHA256))); err != nil {
+		t.Fatal(err)
+	}
+
+	strict := []suite.Option{
+		suite.WithStrictSecurity(true), suite.WithExpander(hash2curve.XMD(crypto.SHA512)), suite.WithSecurityLength(90),
+	}
+
+	if _, err := suite.New(nist.H2CP521, strict...); !errors.Is(err, hash2curve.ErrInsufficientSecurity) {
+		t.Fatalf("want %v, got %v", hash2curve.ErrInsufficientSecurity, err)
+	}
+
+	hash2curve.SetStrictSecurity(true)
+	defer hash2curve.SetStrictSecurity(false)
+
+	if !hash2curve.StrictSecurity() {
+		t.Fatal("expected the strict security policy to be enabled")
+	}
+
+	for _, d := range hash2curve.Suites() {
+		if _, err := suite.New(d.ID); err != nil {
+			t.Fatalf("%s: unexpected error with the defaults: %v", d.ID, err)
+		}
+	}
+
+	if _, err := suite.New(nist.H2CP384, suite.WithSecurityLength(64)); !errors.Is(
+		err, hash2curve.ErrInsufficientSecurity) {
+		t.Fatalf("want %v, got %v", hash2curve.ErrInsufficientSecurity, err)
+	}
+}
+
+func TestSuite_SelfTest(t *testing.T) {
+	if err := suite.SelfTest(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := suite.SelfTest(hash2curve.P256SHA256SSWURO, hash2curve.Ristretto255SHA512R255MAPNU); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := suite.SelfTest(0); !errors.Is(err, hash2curve.ErrSelfTest) ||
+		!errors.Is(err, hash2curve.ErrInvalidSuite) {
+		t.Fatalf("expected an invalid suite to fail, got %v", err)
+	}
+
+	// The test input is 3 bytes long, and is rejected under a lower module-wide limit.
+	hash2curve.SetInputLimits(2, 0)
+	defer hash2curve.SetInputLimits(0, 0)
+
+	if err := suite.SelfTest(); !errors.Is(err, hash2curve.ErrSelfTest) ||
+		!errors.Is(err, hash2curve.ErrInputTooLong) {
+		t.Fatalf("expected the self-test to fail, got %v", err)
+	}
+}
+
+func TestSuite_ScalarByteOrder(t *testing.T) {
+	reversed := func(b []byte) []byte {
+		r := make([]byte, len(b))
+		for i := range b {
+			r[i] = b[len(b)-1-i]
+		}
+
+		return r
+	}
+
+	for _, test := range []struct {
+		id           string
+		littleEndian bool
+	}{
+		{nist.H2CP256, false},
+		{nist.H2CP521, false},
+		{secp256k1.H2C, false},
+		{edwards25519.H2C, true},
+		{ristretto255.H2C, true},
+	} {
+		def, _ := suite.New(test.id)
+		native := def.HashToScalar(suiteInput, suiteDST)
+
+		le, _ := suite.New(test.id, suite.WithScalarByteOrder(suite.LittleEndian))
+		be, _ := suite.New(test.id, suite.WithScalarByteOrder(suite.BigEndian))
+		explicit, _ := suite.New(test.id, suite.WithScalarByteOrder(suite.NativeByteOrder))
+
+		wantLE, wantBE := native, reversed(native)
+		if !test.littleEndian {
+			wantLE, wantBE = wantBE, wantLE
+		}
+
+		if !bytes.Equal(le.HashToScalar(suiteInput, suiteDST), wantLE) {
+			t.Fatalf("%s: unexpected little-endian scalar", test.id)
+		}
+
+		if !bytes.Equal(be.HashToScalar(suiteInput, suiteDST), wantBE) {
+			t.Fatalf("%s: unexpected big-endian scalar", test.id)
+		}
+
+		if !bytes.Equal(explicit.HashToScalar(suiteInput, suiteDST), native) {
+			t.Fatalf("%s: unexpected native scalar", test.id)
+		}
+
+		scalars := def.HashToScalars(suiteInput, suiteDST, 2)
+		for i, sc := range le.HashToScalars(suiteInput, suiteDST, 2) {
+			want := scalars[i]
+			if !test.littleEndian {
+				want = reversed(want)
+			}
+
+			if !bytes.Equal(sc, want) {
+				t.Fatalf("%s: unexpected little-endian scalar %d", test.id, i)
+			}
+		}
+	}
+}
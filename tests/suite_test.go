@@ -0,0 +1,357 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+package hash2curve_test
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/bytemare/hash2curve"
+	"github.com/bytemare/hash2curve/bls24315"
+	"github.com/bytemare/hash2curve/bn254"
+	"github.com/bytemare/hash2curve/curve41417"
+	"github.com/bytemare/hash2curve/e521"
+	"github.com/bytemare/hash2curve/edwards25519"
+	"github.com/bytemare/hash2curve/m511"
+	"github.com/bytemare/hash2curve/mnt4298"
+	"github.com/bytemare/hash2curve/mnt6298"
+	"github.com/bytemare/hash2curve/nist"
+	"github.com/bytemare/hash2curve/ristretto255"
+	"github.com/bytemare/hash2curve/secp192r1"
+	"github.com/bytemare/hash2curve/secp224k1"
+	"github.com/bytemare/hash2curve/secp256k1"
+	"github.com/bytemare/hash2curve/suite"
+	"github.com/bytemare/hash2curve/vectorgen"
+)
+
+func TestSuiteID_Registered(t *testing.T) {
+	for _, s := range vectorgen.Suites() {
+		id, err := hash2curve.ValidateSuiteID(s.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if id.String() != s.ID {
+			t.Fatalf("round trip: want %q, got %q", s.ID, id.String())
+		}
+
+		built, err := hash2curve.NewSuiteID(id.Curve, id.Hash, id.Map, id.Encoding)
+		if err != nil || built != s.ID {
+			t.Fatalf("construction: want %q, got %q (%v)", s.ID, built, err)
+		}
+	}
+}
+
+func TestSuiteID_Invalid(t *testing.T) {
+	for _, test := range []struct {
+		err       error
+		id        string
+		component string
+	}{
+		{hash2curve.ErrSuiteIDFormat, "", ""},
+		{hash2curve.ErrSuiteIDFormat, "P256_XMD:SHA-256_SSWU_RO", ""},
+		{hash2curve.ErrSuiteIDFormat, "P256_XMD:SHA-256_SSWU__", ""},
+		{hash2curve.ErrSuiteIDFormat, "P256_XMD:SHA-256_SSWU_RO_extra_", ""},
+		{hash2curve.ErrSuiteIDCurve, "P255_XMD:SHA-256_SSWU_RO_", "P255"},
+		{hash2curve.ErrSuiteIDHash, "P256_XMD:SHA-512_SSWU_RO_", "XMD:SHA-512"},
+		{hash2curve.ErrSuiteIDHash, "edwards448_XMD:SHAKE256_ELL2_NU_", "XMD:SHAKE256"},
+		{hash2curve.ErrSuiteIDMap, "secp256k1_XMD:SHA-256_ELL2_RO_", "ELL2"},
+		{hash2curve.ErrSuiteIDEncoding, "P384_XMD:SHA-384_SSWU_RP_", "RP"},
+	} {
+		_, err := hash2curve.ValidateSuiteID(test.id)
+		if !errors.Is(err, test.err) {
+			t.Fatalf("%q: want %v, got %v", test.id, test.err, err)
+		}
+
+		var suiteErr *hash2curve.SuiteIDError
+		if !errors.As(err, &suiteErr) || suiteErr.ID != test.id || suiteErr.Component != test.component {
+			t.Fatalf("%q: unexpected error details %#v", test.id, suiteErr)
+		}
+	}
+}
+
+func TestSuiteID_Parse(t *testing.T) {
+	for _, test := range []struct {
+		id, curve, expander, hashName, mapID, encoding string
+	}{
+		{"secp256k1_XMD:SHA-256_SSWU_RO_", "secp256k1", "XMD", "SHA-256", "SSWU", "RO"},
+		{"edwards448_XOF:SHAKE256_ELL2_NU_", "edwards448", "XOF", "SHAKE256", "ELL2", "NU"},
+		{"curve1174_XMD:BLAKE2b_ELL2_XX_", "curve1174", "XMD", "BLAKE2b", "ELL2", "XX"},
+	} {
+		s, err := hash2curve.ParseSuiteID(test.id)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if s.Curve != test.curve || s.Expander() != test.expander || s.HashName() != test.hashName ||
+			s.Map != test.mapID || s.Encoding != test.encoding || s.RandomOracle() != (test.encoding == "RO") {
+			t.Fatalf("%q: unexpected components %+v", test.id, s)
+		}
+
+		rebuilt := hash2curve.SuiteID{
+			Curve:    test.curve,
+			Hash:     hash2curve.HashID(test.expander, test.hashName),
+			Map:      test.mapID,
+			Encoding: test.encoding,
+		}
+		if rebuilt != *s || rebuilt.String() != test.id {
+			t.Fatalf("%q: reassembled as %q", test.id, rebuilt.String())
+		}
+	}
+
+	for _, id := range []string{"P256_SHA-256_SSWU_RO_", "P256_XYZ:SHA-256_SSWU_RO_", "P256_XMD:_SSWU_RO_"} {
+		if _, err := hash2curve.ParseSuiteID(id); !errors.Is(err, hash2curve.ErrSuiteIDHash) {
+			t.Fatalf("%q: want %v, got %v", id, hash2curve.ErrSuiteIDHash, err)
+		}
+	}
+
+	if _, err := hash2curve.ParseSuiteID("P256_XMD:SHA-256_SSWU_RO"); !errors.Is(err, hash2curve.ErrSuiteIDFormat) {
+		t.Fatalf("want %v, got %v", hash2curve.ErrSuiteIDFormat, err)
+	}
+
+	if s := (hash2curve.SuiteID{Hash: "SHA-256"}); s.Expander() != "" || s.HashName() != "" {
+		t.Fatalf("unexpected components of a malformed HASH_ID: %q %q", s.Expander(), s.HashName())
+	}
+}
+
+func TestSuites_Registry(t *testing.T) {
+	// The G2 suites of the pairing-friendly curves have a cofactor of 0, since h_eff doesn't fit in 64 bits.
+	type registered struct {
+		pkg      string
+		cofactor uint64
+	}
+
+	ids := map[string]registered{
+		nist.H2CP256:           {"nist", 1},
+		nist.E2CP256:           {"nist", 1},
+		nist.H2CP384:           {"nist", 1},
+		nist.E2CP384:           {"nist", 1},
+		nist.H2CP521:           {"nist", 1},
+		nist.E2CP521:           {"nist", 1},
+		edwards25519.H2C25519:  {"edwards25519", 8},
+		edwards25519.E2C25519:  {"edwards25519", 8},
+		edwards25519.H2C:       {"edwards25519", 8},
+		edwards25519.E2C:       {"edwards25519", 8},
+		secp256k1.H2C:          {"secp256k1", 1},
+		secp256k1.E2C:          {"secp256k1", 1},
+		ristretto255.H2C:       {"ristretto255", 1},
+		ristretto255.E2C:       {"ristretto255", 1},
+		e521.H2C:               {"e521", 4},
+		e521.E2C:               {"e521", 4},
+		curve41417.H2C:         {"curve41417", 8},
+		curve41417.E2C:         {"curve41417", 8},
+		m511.H2C:               {"m511", 8},
+		m511.E2C:               {"m511", 8},
+		mnt4298.G1H2C:          {"mnt4298", 1},
+		mnt4298.G1E2C:          {"mnt4298", 1},
+		mnt4298.G2H2C:          {"mnt4298", 0},
+		mnt4298.G2E2C:          {"mnt4298", 0},
+		mnt6298.G1H2C:          {"mnt6298", 1},
+		mnt6298.G1E2C:          {"mnt6298", 1},
+		mnt6298.G2H2C:          {"mnt6298", 0},
+		mnt6298.G2E2C:          {"mnt6298", 0},
+		bls24315.G1H2C:         {"bls24315", 3452012412914368512},
+		bls24315.G1E2C:         {"bls24315", 3452012412914368512},
+		bls24315.G2H2C:         {"bls24315", 0},
+		bls24315.G2E2C:         {"bls24315", 0},
+		secp192r1.H2C:          {"secp192r1", 1},
+		secp192r1.E2C:          {"secp192r1", 1},
+		secp224k1.H2C:          {"secp224k1", 1},
+		secp224k1.E2C:          {"secp224k1", 1},
+		secp256k1.H2CKeccak256: {"secp256k1", 1},
+		secp256k1.E2CKeccak256: {"secp256k1", 1},
+		bn254.H2C:              {"bn254", 1},
+		bn254.E2C:              {"bn254", 1},
+	}
+
+	suites := hash2curve.Suites()
+	if len(suites) != len(ids) {
+		t.Fatalf("want %d suites, got %d", len(ids), len(suites))
+	}
+
+	for _, s := range suites {
+		want, ok := ids[s.ID]
+		if !ok {
+			t.Fatalf("unexpected suite %q", s.ID)
+		}
+
+		if s.Package != "github.com/bytemare/hash2curve/"+want.pkg {
+			t.Fatalf("%q: unexpected package %q", s.ID, s.Package)
+		}
+
+		if s.Cofactor != want.cofactor {
+			t.Fatalf("%q: unexpected cofactor %d", s.ID, s.Cofactor)
+		}
+
+		id, err := hash2curve.ValidateSuiteID(s.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if id.Curve != s.Curve || id.Hash != s.Hash || id.Map != s.Map || id.Encoding != s.Encoding ||
+			s.RandomOracle != (s.Encoding == hash2curve.EncodingRandomOracle) {
+			t.Fatalf("%q: inconsistent descriptor %+v", s.ID, s)
+		}
+	}
+}
+
+func TestSuiteID_Marshaling(t *testing.T) {
+	type config struct {
+		Suite hash2curve.SuiteID `json:"suite"`
+	}
+
+	for _, s := range hash2curve.Suites() {
+		var c config
+		if err := json.Unmarshal([]byte(`{"suite":"`+s.ID+`"}`), &c); err != nil {
+			t.Fatal(err)
+		}
+
+		if c.Suite.String() != s.ID {
+			t.Fatalf("want %q, got %q", s.ID, c.Suite.String())
+		}
+
+		out, err := json.Marshal(c)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if string(out) != `{"suite":"`+s.ID+`"}` {
+			t.Fatalf("unexpected encoding %s", out)
+		}
+
+		text, err := c.Suite.MarshalText()
+		if err != nil || string(text) != s.ID {
+			t.Fatalf("unexpected text encoding %q (%v)", text, err)
+		}
+	}
+
+	var c config
+	err := json.Unmarshal([]byte(`{"suite":"P256_XMD:SHA-512_SSWU_RO_"}`), &c)
+	if !errors.Is(err, hash2curve.ErrSuiteIDHash) {
+		t.Fatalf("want %v, got %v", hash2curve.ErrSuiteIDHash, err)
+	}
+
+	if err := json.Unmarshal([]byte(`{"suite":1}`), &c); err == nil {
+		t.Fatal("expected an error on a non-string suite")
+	}
+
+	c.Suite = hash2curve.SuiteID{Curve: "P256", Hash: "XMD:SHA-256", Map: "SSWU", Encoding: "XX"}
+	if _, err := json.Marshal(c); !errors.Is(err, hash2curve.ErrSuiteIDEncoding) {
+		t.Fatalf("want %v, got %v", hash2curve.ErrSuiteIDEncoding, err)
+	}
+}
+
+func TestSuite_Enum(t *testing.T) {
+	descriptors := hash2curve.Suites()
+	all := hash2curve.AllSuites()
+
+	// The enumeration holds the suites of the RFCs, which the registry lists first.
+	if len(all) != 14 || len(descriptors) < len(all) {
+		t.Fatalf("want 14 of %d suites, got %d", len(descriptors), len(all))
+	}
+
+	for i, s := range all {
+		if !s.Available() || s.String() != descriptors[i].ID || s.Descriptor() != descriptors[i] ||
+			s.SuiteID().String() != descriptors[i].ID || s.RandomOracle() != descriptors[i].RandomOracle {
+			t.Fatalf("%q: inconsistent enumeration %d", descriptors[i].ID, s)
+		}
+
+		parsed, err := hash2curve.ParseSuite(descriptors[i].ID)
+		if err != nil || parsed != s {
+			t.Fatalf("%q: want %d, got %d (%v)", descriptors[i].ID, s, parsed, err)
+		}
+
+		text, err := s.MarshalText()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var u hash2curve.Suite
+		if err = u.UnmarshalText(text); err != nil || u != s {
+			t.Fatalf("%q: text round trip failed (%v)", text, err)
+		}
+
+		h, err := suite.For(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if h.ID() != descriptors[i].ID {
+			t.Fatalf("want %q, got %q", descriptors[i].ID, h.ID())
+		}
+	}
+
+	invalid := hash2curve.Suite(0)
+	if invalid.Available() || invalid.String() != "Suite(0)" {
+		t.Fatalf("unexpected invalid suite %q", invalid)
+	}
+
+	if _, err := invalid.MarshalText(); !errors.Is(err, hash2curve.ErrInvalidSuite) {
+		t.Fatalf("want %v, got %v", hash2curve.ErrInvalidSuite, err)
+	}
+
+	if _, err := suite.For(invalid); !errors.Is(err, hash2curve.ErrInvalidSuite) {
+		t.Fatalf("want %v, got %v", hash2curve.ErrInvalidSuite, err)
+	}
+
+	if _, err := hash2curve.ParseSuite("P256_XMD:SHA-512_SSWU_RO_"); !errors.Is(err, hash2curve.ErrInvalidSuite) {
+		t.Fatalf("want %v, got %v", hash2curve.ErrInvalidSuite, err)
+	}
+
+	if hasPanic, err := expectPanic(hash2curve.ErrInvalidSuite, func() {
+		_ = invalid.Descriptor()
+	}); !hasPanic {
+		t.Fatal(err)
+	}
+}
+
+func TestSuite_SecurityLength(t *testing.T) {
+	primes := map[string]*big.Int{
+		"P256":         nist.FieldPrimeP256(),
+		"P384":         nist.FieldPrimeP384(),
+		"P521":         nist.FieldPrimeP521(),
+		"curve25519":   edwards25519.FieldPrime(),
+		"edwards25519": edwards25519.FieldPrime(),
+		"secp256k1":    secp256k1.FieldPrime(),
+	}
+
+	for _, s := range hash2curve.AllSuites() {
+		d := s.Descriptor()
+		if s.SecurityLength() != d.SecurityLength {
+			t.Fatalf("%q: want %d, got %d", d.ID, d.SecurityLength, s.SecurityLength())
+		}
+
+		h, err := suite.For(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if h.SecurityLength() != d.SecurityLength {
+			t.Fatalf("%q: the suite package expands %d bytes, want %d", d.ID, h.SecurityLength(), d.SecurityLength)
+		}
+
+		if p, ok := primes[d.Curve]; ok && hash2curve.SecurityLength(p, d.SecurityLevel) != d.SecurityLength {
+			t.Fatalf("%q: want %d, got %d", d.ID, d.SecurityLength, hash2curve.SecurityLength(p, d.SecurityLevel))
+		}
+	}
+
+	// RFC 9380 section 8.8.1 for BLS12-381, and 8.5 for curve448.
+	bls12381, _ := new(big.Int).SetString("1a0111ea397fe69a4b1ba7b6434bacd764774b84f38512bf6730d2a0f6b0f6241eabfff"+
+		"eb153ffffb9feffffffffaaab", 16)
+	p448 := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 448), new(big.Int).Lsh(big.NewInt(1), 224))
+	p448.Sub(p448, big.NewInt(1))
+
+	if hash2curve.SecurityLength(bls12381, 128) != 64 || hash2curve.SecurityLength(p448, 224) != 84 {
+		t.Fatal("unexpected L")
+	}
+}
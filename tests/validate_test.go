@@ -0,0 +1,111 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+package hash2curve_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	ed "filippo.io/edwards25519"
+	"filippo.io/nistec"
+
+	"github.com/bytemare/hash2curve"
+	"github.com/bytemare/hash2curve/edwards25519"
+	"github.com/bytemare/hash2curve/nist"
+	"github.com/bytemare/hash2curve/ristretto255"
+	"github.com/bytemare/hash2curve/secp256k1"
+)
+
+func TestValidate(t *testing.T) {
+	input := []byte("input")
+	dst := []byte("QUUX-V01-CS02-with-validation")
+
+	// The order 2 point (0, -1) of edwards25519.
+	lowOrder := append([]byte{0xec}, bytes.Repeat([]byte{0xff}, 30)...)
+	lowOrder = append(lowOrder, 0x7f)
+
+	torsion, err := new(ed.Point).SetBytes(lowOrder)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	edPoint := edwards25519.HashToCurve(input, dst)
+
+	// The non-canonical encoding of the identity, with y = p + 1.
+	nonCanonical := append([]byte{0xee}, bytes.Repeat([]byte{0xff}, 30)...)
+	nonCanonical = append(nonCanonical, 0x7f)
+
+	for _, test := range []struct {
+		validate func([]byte) error
+		name     string
+		valid    [][]byte
+		invalid  [][]byte
+	}{
+		{
+			name:     "P256",
+			validate: nist.ValidateP256,
+			valid: [][]byte{
+				nist.HashToP256(input, dst).BytesCompressed(),
+				nist.EncodeToP256(input, dst).Bytes(),
+			},
+			invalid: [][]byte{nil, {0}, nistec.NewP256Point().Bytes(), make([]byte, 33)},
+		},
+		{
+			name:     "P384",
+			validate: nist.ValidateP384,
+			valid:    [][]byte{nist.HashToP384(input, dst).BytesCompressed()},
+			invalid:  [][]byte{nil, {0}, append([]byte{2}, bytes.Repeat([]byte{0xff}, 48)...)},
+		},
+		{
+			name:     "P521",
+			validate: nist.ValidateP521,
+			valid:    [][]byte{nist.HashToP521(input, dst).Bytes()},
+			invalid:  [][]byte{nil, {0}, {4, 1}},
+		},
+		{
+			name:     "secp256k1",
+			validate: secp256k1.Validate,
+			valid:    [][]byte{secp256k1.HashToCurve(input, dst).Bytes()},
+			invalid:  [][]byte{nil, make([]byte, 33), append([]byte{5}, make([]byte, 32)...)},
+		},
+		{
+			name:     "edwards25519",
+			validate: edwards25519.Validate,
+			valid:    [][]byte{edPoint.Bytes(), edwards25519.EncodeToCurve(input, dst).Bytes()},
+			invalid: [][]byte{
+				nil,
+				ed.NewIdentityPoint().Bytes(),
+				lowOrder,
+				nonCanonical,
+				new(ed.Point).Add(edPoint, torsion).Bytes(),
+			},
+		},
+		{
+			name:     "ristretto255",
+			validate: ristretto255.Validate,
+			valid:    [][]byte{ristretto255.HashToGroup(input, dst).Encode(nil)},
+			invalid:  [][]byte{nil, make([]byte, 32), bytes.Repeat([]byte{0xff}, 32)},
+		},
+	} {
+		for _, v := range test.valid {
+			if err := test.validate(v); err != nil {
+				t.Fatalf("%s: unexpected error on %x: %v", test.name, v, err)
+			}
+		}
+
+		for _, v := range test.invalid {
+			if err := test.validate(v); !errors.Is(err, hash2curve.ErrInvalidPoint) {
+				t.Fatalf("%s: want %v on %x, got %v", test.name, hash2curve.ErrInvalidPoint, v, err)
+			}
+		}
+	}
+}
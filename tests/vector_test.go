@@ -6,6 +6,8 @@
 // LICENSE file in the root directory of this source tree or at
 // https://spdx.org/licenses/MIT.html
 
+//go:build !nomathbig
+
 package hash2curve_test
 
 import (
@@ -79,6 +81,10 @@ type h2cVector struct {
 		X string `json:"x"`
 		Y string `json:"y"`
 	} `json:"P"`
+	Q struct {
+		X string `json:"x"`
+		Y string `json:"y"`
+	} `json:"Q"`
 	Q0 struct {
 		X string `json:"x"`
 		Y string `json:"y"`
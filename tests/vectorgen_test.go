@@ -0,0 +1,91 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+package hash2curve_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/bytemare/hash2curve/vectorgen"
+)
+
+func TestVectorGen_RFCVectors(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join(hashToCurveVectorsFileLocation, "*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, file := range files {
+		t.Run(filepath.Base(file), func(t *testing.T) {
+			val, err := os.ReadFile(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var expected vectorgen.Vectors
+			if err = json.Unmarshal(val, &expected); err != nil {
+				t.Fatal(err)
+			}
+
+			suite, err := vectorgen.Lookup(expected.Ciphersuite)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			messages := make([]string, len(expected.Vectors))
+			for i, v := range expected.Vectors {
+				messages[i] = v.Msg
+			}
+
+			if expected.Dst != vectorgen.DefaultDST(suite.ID) {
+				t.Fatalf("unexpected default dst %q", vectorgen.DefaultDST(suite.ID))
+			}
+
+			generated := suite.Generate(expected.Dst, messages...)
+			if !reflect.DeepEqual(generated, &expected) {
+				var b bytes.Buffer
+				_ = generated.Write(&b)
+				t.Fatalf("generated vectors do not match\n%s", b.String())
+			}
+		})
+	}
+}
+
+func TestVectorGen_UnknownSuite(t *testing.T) {
+	if _, err := vectorgen.Lookup("unknown"); err == nil {
+		t.Fatal("expected error on unknown suite")
+	}
+}
+
+func TestVectorGen_RoundTrip(t *testing.T) {
+	suite, err := vectorgen.Lookup(vectorgen.Suites()[0].ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	generated := suite.Generate("dst", vectorgen.DefaultMessages...)
+
+	var b bytes.Buffer
+	if err = generated.Write(&b); err != nil {
+		t.Fatal(err)
+	}
+
+	var v h2cVectors
+	if err = json.Unmarshal(b.Bytes(), &v); err != nil {
+		t.Fatal(err)
+	}
+
+	v.runCiphersuite(t)
+}
@@ -0,0 +1,187 @@
+# SPDX-License-Identifier: MIT
+#
+# Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+#
+# This source code is licensed under the MIT license found in the
+# LICENSE file in the root directory of this source tree or at
+# https://spdx.org/licenses/MIT.html
+
+"""Generates the Keccak-256 vectors of tests/keccak_test.go, which RFC 9380 does not specify.
+
+This is a standalone implementation, written from the Keccak reference and RFC 9380, that shares no code with the Go
+module: Keccak-256 with the legacy 0x01 padding of Ethereum, expand_message_xmd, hash_to_field, and the Shallue-van de
+Woestijne map of RFC 9380 section 6.6.1. It only depends on the standard library of Python 3.8 or later, and the
+values in the tests were generated with Python 3.11.7.
+
+Usage: python3 tests/vectors/keccak/generate.py
+"""
+
+RC = [
+    0x0000000000000001, 0x0000000000008082, 0x800000000000808A, 0x8000000080008000,
+    0x000000000000808B, 0x0000000080000001, 0x8000000080008081, 0x8000000000008009,
+    0x000000000000008A, 0x0000000000000088, 0x0000000080008009, 0x000000008000000A,
+    0x000000008000808B, 0x800000000000008B, 0x8000000000008089, 0x8000000000008003,
+    0x8000000000008002, 0x8000000000000080, 0x000000000000800A, 0x800000008000000A,
+    0x8000000080008081, 0x8000000000008080, 0x0000000080000001, 0x8000000080008008,
+]
+ROTATIONS = [[0, 36, 3, 41, 18], [1, 44, 10, 45, 2], [62, 6, 43, 15, 61], [28, 55, 25, 21, 56], [27, 20, 39, 8, 14]]
+MASK = (1 << 64) - 1
+RATE = 136
+
+BN254_P = 21888242871839275222246405745257275088696311157297823662689037894645226208583
+SECP256K1_P = 2**256 - 2**32 - 977
+SECP256K1_N = 0xFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEBAAEDCE6AF48A03BBFD25E8CD0364141
+L = 48
+
+
+def rol(x, n):
+    return ((x << n) | (x >> (64 - n))) & MASK if n else x
+
+
+def keccak_f(a):
+    for rc in RC:
+        c = [a[x][0] ^ a[x][1] ^ a[x][2] ^ a[x][3] ^ a[x][4] for x in range(5)]
+        d = [c[(x - 1) % 5] ^ rol(c[(x + 1) % 5], 1) for x in range(5)]
+        a = [[a[x][y] ^ d[x] for y in range(5)] for x in range(5)]
+        b = [[0] * 5 for _ in range(5)]
+        for x in range(5):
+            for y in range(5):
+                b[y][(2 * x + 3 * y) % 5] = rol(a[x][y], ROTATIONS[x][y])
+        a = [[b[x][y] ^ (~b[(x + 1) % 5][y] & b[(x + 2) % 5][y]) for y in range(5)] for x in range(5)]
+        a[0][0] ^= rc
+    return a
+
+
+def keccak256(msg):
+    padded = bytearray(msg) + b"\x01"
+    while len(padded) % RATE:
+        padded += b"\x00"
+    padded[-1] |= 0x80
+
+    a = [[0] * 5 for _ in range(5)]
+    for offset in range(0, len(padded), RATE):
+        for i in range(RATE // 8):
+            a[i % 5][i // 5] ^= int.from_bytes(padded[offset + 8 * i : offset + 8 * i + 8], "little")
+        a = keccak_f(a)
+
+    return b"".join(a[i % 5][i // 5].to_bytes(8, "little") for i in range(4))
+
+
+def expand_message_xmd(msg, dst, length):
+    ell = -(-length // 32)
+    dst_prime = dst + bytes([len(dst)])
+    b0 = keccak256(bytes(RATE) + msg + length.to_bytes(2, "big") + b"\x00" + dst_prime)
+    b = [keccak256(b0 + b"\x01" + dst_prime)]
+    for i in range(2, ell + 1):
+        b.append(keccak256(bytes(x ^ y for x, y in zip(b0, b[-1])) + bytes([i]) + dst_prime))
+    return b"".join(b)[:length]
+
+
+def hash_to_field(msg, dst, count, p):
+    uniform = expand_message_xmd(msg, dst, count * L)
+    return [int.from_bytes(uniform[L * i : L * (i + 1)], "big") % p for i in range(count)]
+
+
+def is_square(x, p):
+    return x == 0 or pow(x, (p - 1) // 2, p) == 1
+
+
+def sqrt(x, p):
+    # Both primes are 3 mod 4.
+    return pow(x, (p + 1) // 4, p)
+
+
+class SvdW:
+    """The Shallue-van de Woestijne map of RFC 9380 section 6.6.1 to y^2 = x^3 + a * x + b over GF(p)."""
+
+    def __init__(self, p, a, b):
+        self.p, self.a, self.b = p, a, b
+        self.z = self.find_z()
+        g_z, t = self.g(self.z), (3 * self.z * self.z + 4 * a) % p
+        self.c1 = g_z
+        self.c2 = -self.z * pow(2, -1, p) % p
+        self.c3 = sqrt(-g_z * t % p, p)
+        if self.c3 & 1:
+            self.c3 = p - self.c3
+        self.c4 = -4 * g_z * pow(t, -1, p) % p
+
+    def g(self, x):
+        return (x * x * x + self.a * x + self.b) % self.p
+
+    def find_z(self):
+        p = self.p
+        ctr = 1
+        while True:
+            for z in (ctr % p, -ctr % p):
+                g_z, t = self.g(z), (3 * z * z + 4 * self.a) % p
+                if g_z == 0 or t == 0:
+                    continue
+                h = -t * pow(4 * g_z, -1, p) % p
+                if is_square(h, p) and (is_square(g_z, p) or is_square(self.g(-z * pow(2, -1, p) % p), p)):
+                    return z
+            ctr += 1
+
+    def map(self, u):
+        p = self.p
+        tv1 = u * u * self.c1 % p
+        tv2 = (1 + tv1) % p
+        tv1 = (1 - tv1) % p
+        tv3 = pow(tv1 * tv2 % p, p - 2, p)
+        tv4 = u * tv1 * tv3 * self.c3 % p
+        x1 = (self.c2 - tv4) % p
+        x2 = (self.c2 + tv4) % p
+        x3 = (pow(tv2 * tv2 * tv3 % p, 2, p) * self.c4 + self.z) % p
+        if is_square(self.g(x1), p):
+            x = x1
+        elif is_square(self.g(x2), p):
+            x = x2
+        else:
+            x = x3
+        y = sqrt(self.g(x), p)
+        if (u & 1) != (y & 1):
+            y = p - y
+        return x, y
+
+    def add(self, pt, q):
+        p = self.p
+        if pt is None:
+            return q
+        if q is None:
+            return pt
+        (x1, y1), (x2, y2) = pt, q
+        if x1 == x2:
+            if (y1 + y2) % p == 0:
+                return None
+            slope = (3 * x1 * x1 + self.a) * pow(2 * y1, -1, p) % p
+        else:
+            slope = (y2 - y1) * pow(x2 - x1, -1, p) % p
+        x3 = (slope * slope - x1 - x2) % p
+        return x3, (slope * (x1 - x3) - y1) % p
+
+
+def main():
+    assert keccak256(b"").hex() == "c5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470"
+
+    dst = b"QUUX-V01-CS02-with-expander-KECCAK256"
+    for length in (32, 200):
+        print(f"expand_message_xmd(abc, {length}): {expand_message_xmd(b'abc', dst, length).hex()}")
+
+    for suite, count in (("secp256k1_XMD:KECCAK-256_SSWU_RO_", 2), ("secp256k1_XMD:KECCAK-256_SSWU_NU_", 1)):
+        u = hash_to_field(b"abc", b"QUUX-V01-CS02-with-" + suite.encode(), count, SECP256K1_P)
+        print(f"{suite} u(abc): {[f'{e:064x}' for e in u]}")
+
+    scalar = hash_to_field(b"abc", b"QUUX-V01-CS02-with-secp256k1_XMD:KECCAK-256_SSWU_RO_", 1, SECP256K1_N)[0]
+    print(f"secp256k1_XMD:KECCAK-256_SSWU_RO_ scalar(abc): {scalar:064x}")
+
+    svdw = SvdW(BN254_P, 0, 3)
+    assert svdw.z == 1
+    for suite, count in (("BN254G1_XMD:KECCAK-256_SVDW_RO_", 2), ("BN254G1_XMD:KECCAK-256_SVDW_NU_", 1)):
+        for msg in (b"", b"abc", b"abcdef0123456789"):
+            point = None
+            for u in hash_to_field(msg, b"QUUX-V01-CS02-with-" + suite.encode(), count, BN254_P):
+                point = svdw.add(point, svdw.map(u))
+            print(f"{suite} {msg.decode()!r}: x = {point[0]:064x}, y = {point[1]:064x}")
+
+
+if __name__ == "__main__":
+    main()
@@ -0,0 +1,191 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+package vectorgen
+
+import (
+	"crypto"
+	"math/big"
+
+	ed "filippo.io/edwards25519"
+
+	"github.com/bytemare/hash2curve"
+	"github.com/bytemare/hash2curve/edwards25519"
+	"github.com/bytemare/hash2curve/nist"
+	"github.com/bytemare/hash2curve/secp256k1"
+)
+
+func stringToInt(s string) *big.Int {
+	i, _ := new(big.Int).SetString(s, 0)
+	return i
+}
+
+var (
+	primeP256      = stringToInt("0xffffffff00000001000000000000000000000000ffffffffffffffffffffffff")
+	primeP384      = stringToInt("0xfffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffeffffffff0000000000000000ffffffff")
+	primeP521      = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 521), big.NewInt(1))
+	prime25519     = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 255), big.NewInt(19))
+	primeSecp256k1 = stringToInt("0xfffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f")
+)
+
+// uncompressedToAffine splits an uncompressed SEC1 encoding into its affine coordinates. The identity returns (0, 0).
+func uncompressedToAffine(b []byte) (x, y *big.Int) {
+	if len(b) == 1 {
+		return new(big.Int), new(big.Int)
+	}
+
+	l := (len(b) - 1) / 2
+
+	return new(big.Int).SetBytes(b[1 : 1+l]), new(big.Int).SetBytes(b[1+l:])
+}
+
+func edwardsToAffine(p *ed.Point) (x, y *big.Int) {
+	X, Y, Z, _ := p.ExtendedCoordinates()
+	Z.Invert(Z)
+
+	return littleEndianToInt(X.Multiply(X, Z).Bytes()), littleEndianToInt(Y.Multiply(Y, Z).Bytes())
+}
+
+func littleEndianToInt(b []byte) *big.Int {
+	l := len(b) - 1
+	for i := range len(b) / 2 {
+		b[i], b[l-i] = b[l-i], b[i]
+	}
+
+	return new(big.Int).SetBytes(b)
+}
+
+func xmdHashToField(id crypto.Hash, l uint, p *big.Int) func(input, dst []byte, count uint) []*big.Int {
+	return func(input, dst []byte, count uint) []*big.Int {
+		return hash2curve.HashToFieldXMD(id, input, dst, count, 1, l, p)
+	}
+}
+
+func nistSuites(
+	id, e2c, curve, hash string,
+	h crypto.Hash,
+	k, l uint,
+	z int64,
+	p *big.Int,
+	mapToCurve func(u *big.Int) []byte,
+	hashToCurve, encodeToCurve func(input, dst []byte) []byte,
+) []*Suite {
+	ro := &Suite{
+		HashToField: xmdHashToField(h, l, p),
+		MapToCurve: func(u *big.Int) (x, y *big.Int) {
+			return uncompressedToAffine(mapToCurve(u))
+		},
+		Encode: func(input, dst []byte) (x, y *big.Int) {
+			return uncompressedToAffine(hashToCurve(input, dst))
+		},
+		Field:        p,
+		Z:            big.NewInt(z),
+		ID:           id,
+		Curve:        curve,
+		Expand:       "XMD",
+		Hash:         hash,
+		Map:          "SSWU",
+		K:            k,
+		L:            l,
+		RandomOracle: true,
+	}
+
+	nu := *ro
+	nu.ID = e2c
+	nu.RandomOracle = false
+	nu.Encode = func(input, dst []byte) (x, y *big.Int) {
+		return uncompressedToAffine(encodeToCurve(input, dst))
+	}
+
+	return []*Suite{ro, &nu}
+}
+
+// Suites returns the descriptors of all the supported hash-to-curve suites.
+func Suites() []*Suite {
+	suites := make([]*Suite, 0, 10)
+
+	suites = append(suites, nistSuites(nist.H2CP256, nist.E2CP256, "NIST P-256", "sha256",
+		crypto.SHA256, 128, 48, -10, primeP256,
+		func(u *big.Int) []byte { return nist.MapToCurveP256(u).Bytes() },
+		func(input, dst []byte) []byte { return nist.HashToP256(input, dst).Bytes() },
+		func(input, dst []byte) []byte { return nist.EncodeToP256(input, dst).Bytes() },
+	)...)
+
+	suites = append(suites, nistSuites(nist.H2CP384, nist.E2CP384, "NIST P-384", "sha384",
+		crypto.SHA384, 192, 72, -12, primeP384,
+		func(u *big.Int) []byte { return nist.MapToCurveP384(u).Bytes() },
+		func(input, dst []byte) []byte { return nist.HashToP384(input, dst).Bytes() },
+		func(input, dst []byte) []byte { return nist.EncodeToP384(input, dst).Bytes() },
+	)...)
+
+	suites = append(suites, nistSuites(nist.H2CP521, nist.E2CP521, "NIST P-521", "sha512",
+		crypto.SHA512, 256, 98, -4, primeP521,
+		func(u *big.Int) []byte { return nist.MapToCurveP521(u).Bytes() },
+		func(input, dst []byte) []byte { return nist.HashToP521(input, dst).Bytes() },
+		func(input, dst []byte) []byte { return nist.EncodeToP521(input, dst).Bytes() },
+	)...)
+
+	edRO := &Suite{
+		HashToField: xmdHashToField(crypto.SHA512, 48, prime25519),
+		MapToCurve: func(u *big.Int) (x, y *big.Int) {
+			return edwardsToAffine(edwards25519.MapToCurve(u))
+		},
+		Encode: func(input, dst []byte) (x, y *big.Int) {
+			return edwardsToAffine(edwards25519.HashToCurve(input, dst))
+		},
+		Field:        prime25519,
+		Z:            big.NewInt(2),
+		ID:           edwards25519.H2C,
+		Curve:        "edwards25519",
+		Expand:       "XMD",
+		Hash:         "sha512",
+		Map:          "ELL2",
+		K:            128,
+		L:            48,
+		RandomOracle: true,
+	}
+	edNU := *edRO
+	edNU.ID = edwards25519.E2C
+	edNU.RandomOracle = false
+	edNU.Encode = func(input, dst []byte) (x, y *big.Int) {
+		return edwardsToAffine(edwards25519.EncodeToCurve(input, dst))
+	}
+
+	secpRO := &Suite{
+		HashToField: xmdHashToField(crypto.SHA256, 48, primeSecp256k1),
+		MapToCurve: func(u *big.Int) (x, y *big.Int) {
+			p := secp256k1.MapToCurve(u)
+			return &p.X, &p.Y
+		},
+		Encode: func(input, dst []byte) (x, y *big.Int) {
+			p := secp256k1.HashToCurve(input, dst)
+			return &p.X, &p.Y
+		},
+		Field:        primeSecp256k1,
+		Z:            big.NewInt(-11),
+		ID:           secp256k1.H2C,
+		Curve:        "secp256k1",
+		Expand:       "XMD",
+		Hash:         "sha256",
+		Map:          "SSWU",
+		K:            128,
+		L:            48,
+		RandomOracle: true,
+	}
+	secpNU := *secpRO
+	secpNU.ID = secp256k1.E2C
+	secpNU.RandomOracle = false
+	secpNU.Encode = func(input, dst []byte) (x, y *big.Int) {
+		p := secp256k1.EncodeToCurve(input, dst)
+		return &p.X, &p.Y
+	}
+
+	return append(suites, edRO, &edNU, secpRO, &secpNU)
+}
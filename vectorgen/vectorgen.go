@@ -0,0 +1,215 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+// Package vectorgen generates hash-to-curve test vectors in the JSON format of RFC 9380 (msg, u, Q0, Q1, P), for the
+// supported suites or any custom suite.
+package vectorgen
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/bytemare/hash2curve"
+)
+
+var errNoSuite = hash2curve.ErrInvalidSuite
+
+// Suite describes a hash-to-curve suite and the functions needed to produce its vectors. Points are given in affine
+// coordinates.
+type Suite struct {
+	// HashToField returns count field elements hashed from input and dst.
+	HashToField func(input, dst []byte, count uint) []*big.Int
+
+	// MapToCurve maps a field element to a point of the curve, before any cofactor clearing.
+	MapToCurve func(u *big.Int) (x, y *big.Int)
+
+	// Encode returns the final point, i.e. the output of hash_to_curve or encode_to_curve.
+	Encode func(input, dst []byte) (x, y *big.Int)
+
+	// Field is the prime p of the base field.
+	Field *big.Int
+
+	// Z is the non-square Z constant of the mapping.
+	Z *big.Int
+
+	// ID is the suite identifier, e.g. P256_XMD:SHA-256_SSWU_RO_.
+	ID string
+
+	// Curve is the curve name, as used in the vector files, e.g. NIST P-256.
+	Curve string
+
+	// Expand is the expander type, either XMD or XOF.
+	Expand string
+
+	// Hash is the hash function name, as used in the vector files, e.g. sha256.
+	Hash string
+
+	// Map is the mapping name, e.g. SSWU.
+	Map string
+
+	// K is the target security level in bits.
+	K uint
+
+	// L is the security length used in hash_to_field.
+	L uint
+
+	// RandomOracle indicates whether the suite is hash_to_curve (true) or encode_to_curve (false).
+	RandomOracle bool
+}
+
+// Point holds the hex encoded affine coordinates of a point.
+type Point struct {
+	X string `json:"x"`
+	Y string `json:"y"`
+}
+
+// Vector is a single test vector.
+type Vector struct {
+	P   Point    `json:"P"`
+	Q0  *Point   `json:"Q0,omitempty"`
+	Q1  *Point   `json:"Q1,omitempty"`
+	Q   *Point   `json:"Q,omitempty"`
+	Msg string   `json:"msg"`
+	U   []string `json:"u"`
+}
+
+// Field describes the base field of the curve.
+type Field struct {
+	M string `json:"m"`
+	P string `json:"p"`
+}
+
+// Map describes the mapping.
+type Map struct {
+	Name string `json:"name"`
+}
+
+// Vectors is a set of test vectors for a suite, as encoded in the RFC 9380 vector files.
+type Vectors struct {
+	Field        Field    `json:"field"`
+	Map          Map      `json:"map"`
+	L            string   `json:"L"`
+	Z            string   `json:"Z"`
+	Ciphersuite  string   `json:"ciphersuite"`
+	Curve        string   `json:"curve"`
+	Dst          string   `json:"dst"`
+	Expand       string   `json:"expand"`
+	Hash         string   `json:"hash"`
+	K            string   `json:"k"`
+	Vectors      []Vector `json:"vectors"`
+	RandomOracle bool     `json:"randomOracle"`
+}
+
+// DefaultMessages are the messages used in the RFC 9380 test vectors.
+var DefaultMessages = []string{
+	"",
+	"abc",
+	"abcdef0123456789",
+	"q128_" + repeat("q", 128),
+	"a512_" + repeat("a", 512),
+}
+
+// DefaultDST returns the domain separation tag used in the RFC 9380 test vectors for the suite identifier.
+func DefaultDST(id string) string {
+	return "QUUX-V01-CS02-with-" + id
+}
+
+func repeat(s string, n int) string {
+	out := make([]byte, 0, len(s)*n)
+	for range n {
+		out = append(out, s...)
+	}
+
+	return string(out)
+}
+
+func hexInt(i *big.Int) string {
+	return fmt.Sprintf("0x%x", i)
+}
+
+func (s *Suite) hexElement(e *big.Int) string {
+	return fmt.Sprintf("0x%0*x", 2*((s.Field.BitLen()+7)/8), e)
+}
+
+func (s *Suite) point(x, y *big.Int) Point {
+	return Point{X: s.hexElement(x), Y: s.hexElement(y)}
+}
+
+// Generate produces the test vectors for the suite and the given messages, using dst as domain separation tag.
+func (s *Suite) Generate(dst string, messages ...string) *Vectors {
+	v := &Vectors{
+		Field:        Field{M: "0x1", P: hexInt(s.Field)},
+		Map:          Map{Name: s.Map},
+		L:            hexInt(new(big.Int).SetUint64(uint64(s.L))),
+		Z:            hexInt(new(big.Int).Mod(s.Z, s.Field)),
+		Ciphersuite:  s.ID,
+		Curve:        s.Curve,
+		Dst:          dst,
+		Expand:       s.Expand,
+		Hash:         s.Hash,
+		K:            hexInt(new(big.Int).SetUint64(uint64(s.K))),
+		Vectors:      make([]Vector, len(messages)),
+		RandomOracle: s.RandomOracle,
+	}
+
+	count := uint(1)
+	if s.RandomOracle {
+		count = 2
+	}
+
+	for i, msg := range messages {
+		u := s.HashToField([]byte(msg), []byte(dst), count)
+		vector := Vector{
+			Msg: msg,
+			U:   make([]string, len(u)),
+		}
+
+		for j, e := range u {
+			vector.U[j] = s.hexElement(e)
+		}
+
+		q := make([]Point, len(u))
+		for j, e := range u {
+			q[j] = s.point(s.MapToCurve(e))
+		}
+
+		if s.RandomOracle {
+			vector.Q0, vector.Q1 = &q[0], &q[1]
+		} else {
+			vector.Q = &q[0]
+		}
+
+		vector.P = s.point(s.Encode([]byte(msg), []byte(dst)))
+		v.Vectors[i] = vector
+	}
+
+	return v
+}
+
+// Write encodes the vectors as indented JSON to w.
+func (v *Vectors) Write(w io.Writer) error {
+	e := json.NewEncoder(w)
+	e.SetIndent("", "  ")
+
+	return e.Encode(v)
+}
+
+// Lookup returns the descriptor of the supported suite identified by id.
+func Lookup(id string) (*Suite, error) {
+	for _, s := range Suites() {
+		if s.ID == id {
+			return s, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %q", errNoSuite, id)
+}
@@ -0,0 +1,21 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package hash2curve
+
+import "github.com/bytemare/hash2curve/internal"
+
+// Wipe overwrites the buffer with zeros, for callers hashing secret inputs, e.g. passwords in PAKEs, to clear the
+// outputs of ExpandXMD, ExpandXOF, and HashToField*Bytes once they are consumed.
+//
+// The intermediate buffers of the expansion and of hash_to_field, i.e. b_0, the b_i, and the uniform bytes, are
+// wiped after use by this module. This is best-effort: the Go runtime may move or copy memory, and the hash states
+// and math/big temporaries are out of its reach.
+func Wipe(b []byte) {
+	internal.Wipe(b)
+}
@@ -0,0 +1,25 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !nomathbig
+
+package hash2curve
+
+import (
+	"math/big"
+
+	"github.com/bytemare/hash2curve/internal"
+)
+
+// WipeInts overwrites the memory of the integers with zeros, and sets them to 0, e.g. to clear the outputs of
+// HashToFieldXMD and HashToFieldXOF once they are consumed. It is best-effort, as Wipe.
+func WipeInts(x ...*big.Int) {
+	for _, i := range x {
+		internal.WipeInt(i)
+	}
+}